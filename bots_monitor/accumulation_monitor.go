@@ -0,0 +1,199 @@
+package bots_monitor
+
+// Whale accumulation monitor: wallets of tracked holders tickers that net-bought more than % of supply
+// or BTC over last 7 days (see holders.FindAccumulations), alert shows day by day timeline of the wallet
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/infra/events"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// RunAccumulationMonitor looks for accumulating wallets every interval and alerts about them
+// bot - Telegram for alerts
+// chatID - ID for alerts
+// thresholds - % of supply and BTC of net buying, both 0 - monitor disabled
+func RunAccumulationMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, thresholds holders.AccumulationThresholds, interval time.Duration) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, accumulation monitor not started")
+		return
+	}
+	if !thresholds.Enabled() {
+		log.LogInfo("Accumulation supply percent and min BTC are 0, accumulation monitor disabled")
+		return
+	}
+
+	log.LogInfo("Starting Accumulation Monitor...",
+		zap.String("chatID", chatID),
+		zap.Float64("supplyPercent", thresholds.SupplyPercent),
+		zap.Float64("minBTC", thresholds.MinBTC),
+		zap.Duration("interval", interval))
+
+	scheduler.Default.Run(ctx, scheduler.Job{
+		Name:       "accumulation",
+		Schedule:   scheduler.Interval(interval),
+		RunOnStart: true,
+		Run: func(ctx context.Context, now time.Time) {
+			checkAccumulations(ctx, bot, chatID, thresholds, now)
+		},
+	})
+	log.LogInfo("Accumulation Monitor stopped")
+}
+
+// checkAccumulations finds accumulating wallets of tracked tickers and sends alert for each
+func checkAccumulations(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, thresholds holders.AccumulationThresholds, now time.Time) {
+	var lastErr error
+	checked := 0
+	for _, ticker := range holders.GetAllowedTickers() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Without supply only BTC criterion applies
+		poolLpPublicKey, _ := storage.FindPoolLpPublicKeyByTicker(ticker)
+		supply := 0.0
+		if poolLpPublicKey != "" {
+			var err error
+			if supply, err = holders.GetTokenSupply(poolLpPublicKey); err != nil {
+				log.LogDebug("Failed to get token supply for accumulation", zap.String("ticker", ticker), zap.Error(err))
+			}
+		}
+
+		found, err := holders.FindAccumulations(ticker, supply, thresholds, now)
+		if err != nil {
+			log.LogError("Failed to find accumulations", zap.String("ticker", ticker), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		checked++
+
+		for _, accumulation := range found {
+			sendAccumulationAlert(ctx, bot, chatID, accumulation, poolLpPublicKey, now)
+		}
+	}
+
+	// Run fails only if no ticker was checked
+	if checked == 0 && lastErr != nil {
+		ReportMonitorError(ctx, lastErr)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+}
+
+func sendAccumulationAlert(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, accumulation holders.Accumulation, poolLpPublicKey string, now time.Time) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	message := FormatAccumulationMessage(accumulation)
+	if holdAlert(bot, parseChatIDBig(chatID), "accumulation", poolLpPublicKey, accumulation.Ticker, message) {
+		return
+	}
+
+	msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	tradeLink := ""
+	if poolLpPublicKey != "" {
+		tradeLink = fmt.Sprintf("https://luminex.io/spark/trade/%s", poolLpPublicKey)
+		msg.ReplyMarkup = tradeKeyboard(tradeLink, i18n.English)
+	}
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send accumulation alert",
+			zap.String("ticker", accumulation.Ticker),
+			zap.String("wallet", accumulation.Wallet),
+			zap.Error(err))
+		return
+	}
+
+	if err := holders.MarkAccumulationAlerted(accumulation.Ticker, accumulation.Wallet, now); err != nil {
+		log.LogWarn("Failed to save accumulation alert", zap.Error(err))
+	}
+	recordDashboardAlert("accumulation", message, tradeLink)
+	recordAlertEvent(events.Alert{
+		Kind:            "accumulation",
+		ChatID:          parseChatIDBig(chatID),
+		PoolLpPublicKey: poolLpPublicKey,
+		Ticker:          accumulation.Ticker,
+	}, message)
+
+	log.LogInfo("Accumulation alert sent",
+		zap.String("ticker", accumulation.Ticker),
+		zap.String("wallet", accumulation.Wallet),
+		zap.Float64("netTokens", accumulation.NetTokens),
+		zap.Float64("netBTC", accumulation.NetBTC))
+}
+
+// FormatAccumulationMessage formats accumulation alert with buy timeline of wallet (Telegram HTML)
+func FormatAccumulationMessage(accumulation holders.Accumulation) string {
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("🐳 <b>accumulation</b>: {%s}\n", strings.ToUpper(html.EscapeString(accumulation.Ticker))))
+	message.WriteString("<blockquote>")
+	message.WriteString(fmt.Sprintf("Wallet: %s\n", suspiciousWalletLink(accumulation.Wallet)))
+
+	netLine := fmt.Sprintf("Net %dd: +%s tokens", holders.AccumulationWindowDays, formatTokenAmountLocal(accumulation.NetTokens))
+	if accumulation.SupplyPercent > 0 {
+		netLine += fmt.Sprintf(" (%.2f%% of supply)", accumulation.SupplyPercent)
+	}
+	message.WriteString(netLine + "\n")
+
+	btcLine := fmt.Sprintf("Net BTC: %s btc", amount.FormatSignedBTC(accumulation.NetBTC))
+	if usd := formatBTCInUSD(accumulation.NetBTC, true); usd != "" {
+		btcLine += fmt.Sprintf(" (%s)", usd)
+	}
+	message.WriteString(btcLine + "\n")
+	message.WriteString(fmt.Sprintf("Balance: %s tokens", formatTokenAmountLocal(accumulation.Balance)))
+	message.WriteString("</blockquote>")
+
+	if len(accumulation.Timeline) > 0 {
+		message.WriteString("\nBuy timeline:\n")
+	}
+	for _, day := range accumulation.Timeline {
+		date := day.Date
+		if parsed, err := time.Parse("2006-01-02", day.Date); err == nil {
+			date = parsed.Format("02.01")
+		}
+		sign := "+"
+		delta := day.Delta
+		if delta < 0 {
+			sign, delta = "-", -delta
+		}
+		line := fmt.Sprintf("%s: %s%s", date, sign, formatTokenAmountLocal(delta))
+		if day.NetBTC != 0 {
+			line += fmt.Sprintf(", %s btc", amount.FormatSignedBTC(day.NetBTC))
+		}
+		message.WriteString(fmt.Sprintf("%s (%s)\n", line, formatAccumulationActions(day)))
+	}
+	return strings.TrimSuffix(message.String(), "\n")
+}
+
+func formatAccumulationActions(day holders.AccumulationDay) string {
+	var parts []string
+	if day.Buys > 0 {
+		parts = append(parts, pluralize(day.Buys, "buy", "buys"))
+	}
+	if day.Sells > 0 {
+		parts = append(parts, pluralize(day.Sells, "sell", "sells"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func pluralize(count int, one string, many string) string {
+	if count == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", count, many)
+}
@@ -0,0 +1,399 @@
+package bots_monitor
+
+// HTTP admin API for runtime configuration, protected by bearer token (app.admin_api_token)
+// GET    /api/health                   - monitors state (failures, restarts, paused, stalled)
+// GET    /api/tokens                   - filtered tokens
+// POST   /api/tokens                   - add filtered token {"ticker": "..."} or {"pool_lp_public_key": "..."}
+// DELETE /api/tokens/{token}           - remove filtered token (ticker or poolLpPublicKey)
+// GET    /api/thresholds               - min BTC thresholds (effective, configured, overrides)
+// PUT    /api/thresholds               - set overrides {"big_sales_min_btc": 0.005, "filtered_min_btc": 0}, 0 - config value
+// POST   /api/monitors/{name}/pause    - stop monitor until resume (not kept across restarts)
+// POST   /api/monitors/{name}/resume   - start paused monitor
+// GET    /api/reach                    - alert reach of tokens (chats, members, alerts, price alert subscribers)
+// GET    /dashboard                    - web dashboard (dashboard.go), signed in via /dashboard/login
+// Filtered tokens are picked up by Big Sales Monitor within 30 seconds, thresholds from the next batch
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/price_alerts"
+	"spark-wallet/internal/features/reach"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// adminAPIMaxBodySize - max request body of admin API
+const adminAPIMaxBodySize = 64 * 1024
+
+type adminAPI struct {
+	token          string
+	registry       *MonitorRegistry
+	bigSalesMinBTC float64 // configured thresholds
+	filteredMinBTC float64
+}
+
+type adminMonitorStatus struct {
+	Name                string `json:"name"`
+	Paused              bool   `json:"paused"`
+	Stalled             bool   `json:"stalled"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	TotalFailures       int    `json:"total_failures"`
+	Restarts            int    `json:"restarts"`
+	LastSuccess         string `json:"last_success,omitempty"` // RFC3339
+	LastError           string `json:"last_error,omitempty"`
+}
+
+type adminToken struct {
+	PoolLpPublicKey string `json:"pool_lp_public_key"`
+	Ticker          string `json:"ticker,omitempty"`
+	AssetAddress    string `json:"asset_address,omitempty"` // token asset, matched in any pool of token
+}
+
+type adminThresholds struct {
+	BigSalesMinBTC float64 `json:"big_sales_min_btc"`
+	FilteredMinBTC float64 `json:"filtered_min_btc"`
+}
+
+// RunAdminAPI serves admin API on addr until ctx is cancelled
+// token - bearer token required in Authorization header
+// registry - monitors for health and pause/resume
+// bigSalesMinBTC, filteredMinBTC - configured thresholds (used when override is not set)
+func RunAdminAPI(ctx context.Context, addr string, token string, registry *MonitorRegistry, bigSalesMinBTC float64, filteredMinBTC float64) {
+	if addr == "" {
+		log.LogInfo("Admin API address is empty, admin API disabled")
+		return
+	}
+	if token == "" {
+		log.LogWarn("Admin API token is empty, admin API not started")
+		return
+	}
+
+	api := &adminAPI{
+		token:          token,
+		registry:       registry,
+		bigSalesMinBTC: bigSalesMinBTC,
+		filteredMinBTC: filteredMinBTC,
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           api.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.LogWarn("Failed to shut down admin API", zap.Error(err))
+		}
+	}()
+
+	log.LogInfo("Starting Admin API...", zap.String("addr", addr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.LogError("Admin API stopped", zap.Error(err))
+		return
+	}
+	log.LogInfo("Admin API stopped")
+}
+
+func (a *adminAPI) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/health", a.handleHealth)
+	mux.HandleFunc("GET /api/tokens", a.handleListTokens)
+	mux.HandleFunc("POST /api/tokens", a.handleAddToken)
+	mux.HandleFunc("DELETE /api/tokens/{token}", a.handleRemoveToken)
+	mux.HandleFunc("GET /api/thresholds", a.handleGetThresholds)
+	mux.HandleFunc("PUT /api/thresholds", a.handleSetThresholds)
+	mux.HandleFunc("POST /api/monitors/{name}/pause", a.handlePauseMonitor)
+	mux.HandleFunc("POST /api/monitors/{name}/resume", a.handleResumeMonitor)
+	mux.HandleFunc("GET /api/reach", a.handleReach)
+	mux.HandleFunc("GET /dashboard", a.handleDashboard)
+	mux.HandleFunc("GET /dashboard/charts/{name}", a.handleDashboardChart)
+	mux.HandleFunc("POST /dashboard/logout", a.handleDashboardLogout)
+
+	// Sign-in page is the only route without token
+	root := http.NewServeMux()
+	root.HandleFunc("GET /dashboard/login", a.handleDashboardLoginPage)
+	root.HandleFunc("POST /dashboard/login", a.handleDashboardLogin)
+	root.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/dashboard", http.StatusFound)
+	})
+	root.Handle("/", a.authorize(mux))
+	return root
+}
+
+// authorize rejects requests without valid bearer token (dashboard - without token cookie)
+func (a *adminAPI) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/dashboard") {
+			if !a.authorizedDashboard(r) {
+				http.Redirect(w, r, "/dashboard/login", http.StatusSeeOther)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(provided)), []byte(a.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeAdminError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *adminAPI) handleHealth(w http.ResponseWriter, r *http.Request) {
+	statuses := a.registry.Statuses()
+	monitors := make([]adminMonitorStatus, 0, len(statuses))
+	for _, status := range statuses {
+		monitor := adminMonitorStatus{
+			Name:                status.Name,
+			Paused:              status.Paused,
+			Stalled:             status.Stalled,
+			ConsecutiveFailures: status.ConsecutiveFailures,
+			TotalFailures:       status.TotalFailures,
+			Restarts:            status.Restarts,
+			LastError:           status.LastError,
+		}
+		if !status.LastSuccess.IsZero() {
+			monitor.LastSuccess = status.LastSuccess.UTC().Format(time.RFC3339)
+		}
+		monitors = append(monitors, monitor)
+	}
+	writeAdminJSON(w, http.StatusOK, map[string]any{"monitors": monitors})
+}
+
+func (a *adminAPI) handleListTokens(w http.ResponseWriter, r *http.Request) {
+	pools, err := storage.LoadFilteredTokens()
+	if err != nil {
+		log.LogError("Failed to load filtered tokens for admin API", zap.Error(err))
+		writeAdminError(w, http.StatusInternalServerError, "failed to load filtered tokens")
+		return
+	}
+
+	poolAssets := make(map[string]string)
+	if assets, err := storage.LoadFilteredAssets(); err == nil {
+		for asset, pool := range assets {
+			poolAssets[pool] = asset
+		}
+	}
+
+	tokens := make([]adminToken, 0, len(pools))
+	for _, pool := range pools {
+		token := adminToken{PoolLpPublicKey: pool, AssetAddress: poolAssets[pool]}
+		if ticker, err := holders.GetTickerFromPoolLpPublicKey(pool); err == nil {
+			token.Ticker = ticker
+		}
+		tokens = append(tokens, token)
+	}
+	writeAdminJSON(w, http.StatusOK, map[string]any{"tokens": tokens})
+}
+
+func (a *adminAPI) handleAddToken(w http.ResponseWriter, r *http.Request) {
+	var request adminToken
+	if !decodeAdminRequest(w, r, &request) {
+		return
+	}
+
+	pool := strings.TrimSpace(request.PoolLpPublicKey)
+	if pool == "" {
+		if strings.TrimSpace(request.Ticker) == "" {
+			writeAdminError(w, http.StatusBadRequest, "ticker or pool_lp_public_key is required")
+			return
+		}
+		var err error
+		pool, err = storage.FindPoolLpPublicKeyByTicker(request.Ticker)
+		if err != nil {
+			writeAdminError(w, http.StatusNotFound, fmt.Sprintf("ticker %s not found", request.Ticker))
+			return
+		}
+	}
+
+	if err := storage.AddFilteredToken(pool); err != nil {
+		log.LogError("Failed to add filtered token via admin API", zap.String("poolLpPublicKey", pool), zap.Error(err))
+		writeAdminError(w, http.StatusInternalServerError, "failed to add filtered token")
+		return
+	}
+	saveFilteredTokenAsset(pool)
+	log.LogInfo("Filtered token added via admin API", zap.String("poolLpPublicKey", pool))
+	writeAdminJSON(w, http.StatusOK, adminToken{PoolLpPublicKey: pool, Ticker: strings.ToUpper(strings.TrimSpace(request.Ticker))})
+}
+
+func (a *adminAPI) handleRemoveToken(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSpace(r.PathValue("token"))
+
+	pools, err := storage.LoadFilteredTokens()
+	if err != nil {
+		log.LogError("Failed to load filtered tokens for admin API", zap.Error(err))
+		writeAdminError(w, http.StatusInternalServerError, "failed to load filtered tokens")
+		return
+	}
+
+	// Token is poolLpPublicKey from the list or ticker
+	pool := ""
+	for _, existing := range pools {
+		if strings.TrimSpace(existing) == token {
+			pool = token
+			break
+		}
+	}
+	if pool == "" {
+		pool, err = storage.FindPoolLpPublicKeyByTicker(token)
+		if err != nil {
+			writeAdminError(w, http.StatusNotFound, fmt.Sprintf("token %s not found", token))
+			return
+		}
+	}
+
+	if err := storage.RemoveFilteredToken(pool); err != nil {
+		if err.Error() == "token not found in list" {
+			writeAdminError(w, http.StatusNotFound, fmt.Sprintf("token %s is not in the list", token))
+			return
+		}
+		log.LogError("Failed to remove filtered token via admin API", zap.String("poolLpPublicKey", pool), zap.Error(err))
+		writeAdminError(w, http.StatusInternalServerError, "failed to remove filtered token")
+		return
+	}
+	log.LogInfo("Filtered token removed via admin API", zap.String("poolLpPublicKey", pool))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *adminAPI) handleGetThresholds(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, a.thresholdsResponse())
+}
+
+func (a *adminAPI) handleSetThresholds(w http.ResponseWriter, r *http.Request) {
+	// Omitted fields keep current override
+	var request struct {
+		BigSalesMinBTC *float64 `json:"big_sales_min_btc"`
+		FilteredMinBTC *float64 `json:"filtered_min_btc"`
+	}
+	if !decodeAdminRequest(w, r, &request) {
+		return
+	}
+
+	thresholds := GetRuntimeThresholds()
+	if request.BigSalesMinBTC != nil {
+		thresholds.BigSalesMinBTC = *request.BigSalesMinBTC
+	}
+	if request.FilteredMinBTC != nil {
+		thresholds.FilteredMinBTC = *request.FilteredMinBTC
+	}
+
+	if thresholds.BigSalesMinBTC < 0 || thresholds.FilteredMinBTC < 0 {
+		writeAdminError(w, http.StatusBadRequest, "thresholds cannot be negative")
+		return
+	}
+	if err := SetRuntimeThresholds(thresholds); err != nil {
+		log.LogError("Failed to save runtime thresholds", zap.Error(err))
+		writeAdminError(w, http.StatusInternalServerError, "failed to save thresholds")
+		return
+	}
+	log.LogInfo("Thresholds changed via admin API",
+		zap.Float64("bigSalesMinBTC", thresholds.BigSalesMinBTC),
+		zap.Float64("filteredMinBTC", thresholds.FilteredMinBTC))
+	writeAdminJSON(w, http.StatusOK, a.thresholdsResponse())
+}
+
+func (a *adminAPI) thresholdsResponse() map[string]adminThresholds {
+	overrides := GetRuntimeThresholds()
+	return map[string]adminThresholds{
+		"effective": {
+			BigSalesMinBTC: effectiveBigSalesMinBTC(a.bigSalesMinBTC),
+			FilteredMinBTC: effectiveFilteredMinBTC(a.filteredMinBTC),
+		},
+		"configured": {
+			BigSalesMinBTC: a.bigSalesMinBTC,
+			FilteredMinBTC: a.filteredMinBTC,
+		},
+		"overrides": {
+			BigSalesMinBTC: overrides.BigSalesMinBTC,
+			FilteredMinBTC: overrides.FilteredMinBTC,
+		},
+	}
+}
+
+func (a *adminAPI) handlePauseMonitor(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if !a.registry.IsRegistered(name) {
+		writeAdminError(w, http.StatusNotFound, fmt.Sprintf("monitor %s not found", name))
+		return
+	}
+	if !a.registry.Pause(name) {
+		writeAdminError(w, http.StatusConflict, fmt.Sprintf("monitor %s is already paused", name))
+		return
+	}
+	log.LogInfo("Monitor paused via admin API", zap.String("monitor", name))
+	writeAdminJSON(w, http.StatusOK, map[string]any{"name": name, "paused": true})
+}
+
+func (a *adminAPI) handleResumeMonitor(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if !a.registry.IsRegistered(name) {
+		writeAdminError(w, http.StatusNotFound, fmt.Sprintf("monitor %s not found", name))
+		return
+	}
+	if !a.registry.Resume(name) {
+		writeAdminError(w, http.StatusConflict, fmt.Sprintf("monitor %s is not paused", name))
+		return
+	}
+	log.LogInfo("Monitor resumed via admin API", zap.String("monitor", name))
+	writeAdminJSON(w, http.StatusOK, map[string]any{"name": name, "paused": false})
+}
+
+func (a *adminAPI) handleReach(w http.ResponseWriter, r *http.Request) {
+	summaries, err := reach.AllSummaries(time.Now())
+	if err != nil {
+		log.LogError("Admin API failed to load reach", zap.Error(err))
+		writeAdminError(w, http.StatusInternalServerError, "failed to load reach")
+		return
+	}
+	for i := range summaries {
+		subscribers, err := price_alerts.CountSubscribers(summaries[i].PoolLpPublicKey)
+		if err != nil {
+			log.LogWarn("Admin API failed to count price alert subscribers", zap.Error(err))
+			continue
+		}
+		summaries[i].Subscribers = subscribers
+	}
+	writeAdminJSON(w, http.StatusOK, map[string]any{"tokens": summaries})
+}
+
+// decodeAdminRequest parses JSON body, writes 400 and returns false on error
+func decodeAdminRequest(w http.ResponseWriter, r *http.Request, target any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, adminAPIMaxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeAdminJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.LogWarn("Failed to write admin API response", zap.Error(err))
+	}
+}
+
+func writeAdminError(w http.ResponseWriter, status int, message string) {
+	writeAdminJSON(w, status, map[string]string{"error": message})
+}
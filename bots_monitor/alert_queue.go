@@ -0,0 +1,404 @@
+package bots_monitor
+
+// Rate-limit-aware delivery of swap alerts
+// Alerts of one poll are queued per chat and sent after all swaps are routed:
+// - every chat of a bot has token bucket (Telegram allows about 20 messages per minute in a group), sending waits for a token
+// - when chat has more queued alerts than free tokens, consecutive small swaps are combined into one message
+// - tokens with big sales (fast path swaps) go first, alerts of one token keep swap order
+// Chats are sent in parallel, callbacks of sent alerts run afterwards in queue order
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// chatBucketSize - messages chat can receive at once
+	chatBucketSize = 3
+	// chatRefillInterval - one message token is added to chat bucket per interval (20 per minute)
+	chatRefillInterval = 3 * time.Second
+	// maxCombinedAlerts - small swaps in one combined message
+	maxCombinedAlerts = 10
+	// maxMessageLength - Telegram limit of message text
+	maxMessageLength = 4096
+	// maxRetryAfter - longer retry_after of Telegram is not waited, message fails
+	maxRetryAfter = time.Minute
+)
+
+// chatBucketKey - rate limits are per bot and chat
+type chatBucketKey struct {
+	bot  *tgbotapi.BotAPI
+	chat int64
+}
+
+type chatBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+var (
+	chatBuckets      = make(map[chatBucketKey]*chatBucket)
+	chatBucketsMutex sync.Mutex
+)
+
+// refillChatBucketUnlocked returns bucket of chat with tokens added since last use
+func refillChatBucketUnlocked(key chatBucketKey, now time.Time) *chatBucket {
+	bucket, exists := chatBuckets[key]
+	if !exists {
+		bucket = &chatBucket{tokens: chatBucketSize, updatedAt: now}
+		chatBuckets[key] = bucket
+		return bucket
+	}
+	bucket.tokens += float64(now.Sub(bucket.updatedAt)) / float64(chatRefillInterval)
+	if bucket.tokens > chatBucketSize {
+		bucket.tokens = chatBucketSize
+	}
+	bucket.updatedAt = now
+	return bucket
+}
+
+// reserveChatSlot takes message token of chat, returns wait before message can be sent
+func reserveChatSlot(bot *tgbotapi.BotAPI, chat int64, now time.Time) time.Duration {
+	chatBucketsMutex.Lock()
+	defer chatBucketsMutex.Unlock()
+
+	bucket := refillChatBucketUnlocked(chatBucketKey{bot: bot, chat: chat}, now)
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens * float64(chatRefillInterval))
+}
+
+// availableChatSlots returns count of messages chat can receive without waiting
+func availableChatSlots(bot *tgbotapi.BotAPI, chat int64, now time.Time) int {
+	chatBucketsMutex.Lock()
+	defer chatBucketsMutex.Unlock()
+
+	bucket := refillChatBucketUnlocked(chatBucketKey{bot: bot, chat: chat}, now)
+	if bucket.tokens < 1 {
+		return 0
+	}
+	return int(bucket.tokens)
+}
+
+// sleepContext waits for duration, returns false if ctx is done first
+func sleepContext(ctx context.Context, wait time.Duration) bool {
+	if wait <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// sendToChat sends message to chat within its rate limit
+// Throttled message (429) is sent again once after retry_after
+func sendToChat(ctx context.Context, bot *tgbotapi.BotAPI, chat int64, message tgbotapi.Chattable) (tgbotapi.Message, error) {
+	for attempt := 0; ; attempt++ {
+		if !sleepContext(ctx, reserveChatSlot(bot, chat, time.Now())) {
+			return tgbotapi.Message{}, ctx.Err()
+		}
+
+		sent, err := bot.Send(message)
+		var apiErr *tgbotapi.Error
+		if err == nil || attempt > 0 || !errors.As(err, &apiErr) || apiErr.Code != 429 || apiErr.RetryAfter <= 0 {
+			return sent, err
+		}
+
+		retryAfter := time.Duration(apiErr.RetryAfter) * time.Second
+		if retryAfter > maxRetryAfter {
+			return sent, err
+		}
+		log.LogWarn("Telegram rate limit hit, retrying message",
+			zap.Int64("chatID", chat),
+			zap.Duration("retryAfter", retryAfter))
+		if !sleepContext(ctx, retryAfter) {
+			return tgbotapi.Message{}, ctx.Err()
+		}
+	}
+}
+
+// alertPriority - order of tokens in chat queue
+type alertPriority int
+
+const (
+	alertPriorityNormal alertPriority = iota
+	alertPriorityBigSale
+)
+
+// alertStatus - result of queued alert
+type alertStatus int
+
+const (
+	alertUnsent alertStatus = iota // not attempted (shutdown)
+	alertSent
+	alertFailed
+	alertHeld // muted token or quiet hours of chat (see holdAlert)
+)
+
+// alertDelivery - message of delivered alert (audit trail, see recordSwapDeliveries)
+type alertDelivery struct {
+	messageID int
+	format    string // events.DeliveryFormat*
+}
+
+// queuedAlert - swap alert waiting in chat queue
+type queuedAlert struct {
+	swap     flashnet.Swap
+	priority alertPriority
+	// text - alert text for combined message in language of chat, nil if alert is always sent alone (fast path, template photo)
+	text func(lang i18n.Lang) string
+	// send - sends alert alone
+	send func(ctx context.Context) (alertDelivery, error)
+	// onSent - called after delivery (reach, dashboard, holders)
+	onSent func()
+	// label - route name for logs
+	label string
+}
+
+// chatAlertQueue - queued alerts of one chat
+type chatAlertQueue struct {
+	bot        *tgbotapi.BotAPI
+	chatID     string
+	alerts     []queuedAlert
+	statuses   []alertStatus
+	deliveries []alertDelivery
+	errors     []error // send errors of failed alerts
+}
+
+// alertQueue - alerts of one poll grouped by chat
+type alertQueue struct {
+	chats map[chatBucketKey]*chatAlertQueue
+	order []chatBucketKey
+}
+
+func newAlertQueue() *alertQueue {
+	return &alertQueue{chats: make(map[chatBucketKey]*chatAlertQueue)}
+}
+
+// add queues alert for chat
+func (q *alertQueue) add(bot *tgbotapi.BotAPI, chatID string, alert queuedAlert) {
+	key := chatBucketKey{bot: bot, chat: parseChatIDBig(chatID)}
+	chat, exists := q.chats[key]
+	if !exists {
+		chat = &chatAlertQueue{bot: bot, chatID: chatID}
+		q.chats[key] = chat
+		q.order = append(q.order, key)
+	}
+	chat.alerts = append(chat.alerts, alert)
+}
+
+// flush sends queued alerts of all chats and runs callbacks of sent alerts
+// Returns IDs of swaps with alerts left unsent because of shutdown
+func (q *alertQueue) flush(ctx context.Context) map[string]bool {
+	var wg sync.WaitGroup
+	for _, key := range q.order {
+		chat := q.chats[key]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chat.send(ctx)
+		}()
+	}
+	wg.Wait()
+	q.recordDeliveries()
+
+	unsent := make(map[string]bool)
+	for _, key := range q.order {
+		chat := q.chats[key]
+		for i, alert := range chat.alerts {
+			switch chat.statuses[i] {
+			case alertSent:
+				if alert.onSent != nil {
+					alert.onSent()
+				}
+			case alertUnsent:
+				unsent[alert.swap.ID] = true
+			}
+		}
+	}
+	return unsent
+}
+
+// deliveryOrder returns indexes of alerts in send order:
+// tokens with big sale first, alerts of one token in queue (swap) order
+func (c *chatAlertQueue) deliveryOrder() []int {
+	tokenPriority := make(map[string]alertPriority)
+	for _, alert := range c.alerts {
+		if alert.priority > tokenPriority[alert.swap.PoolLpPublicKey] {
+			tokenPriority[alert.swap.PoolLpPublicKey] = alert.priority
+		}
+	}
+
+	order := make([]int, len(c.alerts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return tokenPriority[c.alerts[order[i]].swap.PoolLpPublicKey] > tokenPriority[c.alerts[order[j]].swap.PoolLpPublicKey]
+	})
+	return order
+}
+
+// send delivers alerts of chat, statuses are set per alert
+func (c *chatAlertQueue) send(ctx context.Context) {
+	c.statuses = make([]alertStatus, len(c.alerts))
+	c.deliveries = make([]alertDelivery, len(c.alerts))
+	c.errors = make([]error, len(c.alerts))
+	chat := parseChatIDBig(c.chatID)
+	lang := i18n.ChatLang(chat)
+
+	// Muted tokens and quiet hours: held alerts go to summary, callbacks don't run
+	var order []int
+	for _, index := range c.deliveryOrder() {
+		alert := c.alerts[index]
+		text, _ := formatSwapMessageMinimal(alert.swap, lang)
+		if holdAlert(c.bot, chat, alert.label, alert.swap.PoolLpPublicKey, "", text) {
+			c.statuses[index] = alertHeld
+			continue
+		}
+		order = append(order, index)
+	}
+
+	for pos := 0; pos < len(order); {
+		if ctx.Err() != nil {
+			return
+		}
+
+		group := []int{order[pos]}
+		// Burst: more alerts than chat can take now, small swaps are combined
+		if c.alerts[order[pos]].text != nil && len(order)-pos > availableChatSlots(c.bot, chat, time.Now()) {
+			for next := pos + 1; next < len(order) && len(group) < maxCombinedAlerts; next++ {
+				if c.alerts[order[next]].text == nil {
+					break
+				}
+				group = append(group, order[next])
+			}
+		}
+
+		if len(group) > 1 {
+			group = c.sendCombined(ctx, chat, lang, group)
+		} else {
+			c.sendAlone(ctx, group[0])
+		}
+		pos += len(group)
+	}
+}
+
+// sendAlone sends one alert with its own send function
+func (c *chatAlertQueue) sendAlone(ctx context.Context, index int) {
+	alert := c.alerts[index]
+	delivery, err := alert.send(ctx)
+	c.deliveries[index] = delivery
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		c.statuses[index] = alertFailed
+		c.errors[index] = err
+		log.LogError("Failed to send swap alert",
+			zap.String("route", alert.label),
+			zap.String("chatID", c.chatID),
+			zap.String("swapID", alert.swap.ID),
+			zap.Error(err))
+		return
+	}
+	c.statuses[index] = alertSent
+}
+
+// sendCombined sends alerts of group as one message
+// Group is cut to fit message length limit, returns alerts that were handled
+func (c *chatAlertQueue) sendCombined(ctx context.Context, chat int64, lang i18n.Lang, group []int) []int {
+	texts := make([]string, 0, len(group))
+	length := 0
+	for i, index := range group {
+		text := c.alerts[index].text(lang)
+		if i > 0 && length+len(text)+100 > maxMessageLength {
+			group = group[:i]
+			break
+		}
+		texts = append(texts, text)
+		length += len(text) + 2
+	}
+	if len(group) == 1 {
+		c.sendAlone(ctx, group[0])
+		return group
+	}
+
+	message := tgbotapi.NewMessage(chat, i18n.T(lang, "swap.combined", len(texts))+"\n\n"+strings.Join(texts, "\n\n"))
+	message.ParseMode = tgbotapi.ModeHTML
+	message.DisableWebPagePreview = true
+	sent, err := sendToChat(ctx, c.bot, chat, message)
+	if err != nil && ctx.Err() != nil {
+		return group
+	}
+
+	status := alertSent
+	if err != nil {
+		status = alertFailed
+		log.LogError("Failed to send combined swap alert",
+			zap.String("chatID", c.chatID),
+			zap.Int("swaps", len(group)),
+			zap.Error(err))
+	} else {
+		log.LogInfo("Sent combined swap alert",
+			zap.String("chatID", c.chatID),
+			zap.Int("swaps", len(group)))
+	}
+	for _, index := range group {
+		c.statuses[index] = status
+		c.deliveries[index] = alertDelivery{messageID: sent.MessageID, format: events.DeliveryFormatCombined}
+		c.errors[index] = err
+	}
+	return group
+}
+
+// swapAlertPriority returns priority of swap in chat with minBTCAmount threshold
+func swapAlertPriority(swap flashnet.Swap, minBTCAmount float64) alertPriority {
+	if isFastPathSwap(swap, minBTCAmount) {
+		return alertPriorityBigSale
+	}
+	return alertPriorityNormal
+}
+
+// memoizeSwapAlert returns format function that gathers swap alert data once for all chats
+// and renders it in language of each chat
+func memoizeSwapAlert(client *flashnet.Client, swap flashnet.Swap) func(lang i18n.Lang) swapAlert {
+	var once sync.Once
+	var sc SwapContext
+	return func(lang i18n.Lang) swapAlert {
+		once.Do(func() {
+			sc = GatherSwapContext(client, swap)
+		})
+		return renderSwapAlert(sc, lang)
+	}
+}
+
+// combinableSwapText returns text of swap for combined message
+// Nil for fast path swaps: they are sent alone as minimal alert and edited
+func combinableSwapText(swap flashnet.Swap, minBTCAmount float64, format func(lang i18n.Lang) swapAlert) func(lang i18n.Lang) string {
+	if isFastPathSwap(swap, minBTCAmount) {
+		return nil
+	}
+	return func(lang i18n.Lang) string {
+		return format(lang).Text
+	}
+}
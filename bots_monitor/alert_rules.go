@@ -0,0 +1,119 @@
+package bots_monitor
+
+// Alerts from rules engine (app.alert_rules_file), evaluated for each new swap
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/alerts"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/reach"
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// buildSwapFacts prepares swap values for rules engine
+func buildSwapFacts(swap flashnet.Swap) *alerts.SwapFacts {
+	ticker, _ := holders.GetTickerFromPoolLpPublicKey(swap.PoolLpPublicKey)
+
+	return &alerts.SwapFacts{
+		Swap:      swap,
+		Ticker:    ticker,
+		BTCAmount: getBTCAmountFromSwap(swap),
+		LoadMarketCapUSD: func() float64 {
+			return luminex.GetPoolMarketCap(swap.PoolLpPublicKey, swap)
+		},
+		LoadSellPctOfHoldings: func() float64 {
+			pct, err := getSellPctOfHoldings(swap)
+			if err != nil {
+				log.LogDebug("Failed to get sold share of holdings for alert rules",
+					zap.String("swapID", swap.ID),
+					zap.Error(err))
+			}
+			return pct
+		},
+	}
+}
+
+// getSellPctOfHoldings returns percent of wallet holdings sold in swap
+// Balance is taken after swap: holdings before sell = balance + sold amount
+func getSellPctOfHoldings(swap flashnet.Swap) (float64, error) {
+	if !swap.IsSell() {
+		return 0, nil
+	}
+
+	soldAmount, err := amount.ParseFloat(swap.AmountIn)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse sold amount: %w", err)
+	}
+	if soldAmount <= 0 {
+		return 0, nil
+	}
+
+	balanceResp, err := luminex.GetWalletTokensBalance(swap.SwapperPublicKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get wallet tokens balance: %w", err)
+	}
+
+	// Raw balance and raw swap amount are in same units (no decimals)
+	var remaining float64
+	for _, token := range balanceResp.Tokens {
+		if token.TokenAddress == swap.AssetInAddress {
+			remaining, err = amount.ParseFloat(token.Balance)
+			if err != nil {
+				return 0, fmt.Errorf("failed to parse wallet token balance: %w", err)
+			}
+			break
+		}
+	}
+
+	return soldAmount / (soldAmount + remaining) * 100, nil
+}
+
+// queueRuleAlerts evaluates rules for swap and queues alert to chats of matched rules
+// format - formats swap message in language of chat (data is gathered once for all chats), onSent - called for every delivered alert
+func queueRuleAlerts(queue *alertQueue, bot *tgbotapi.BotAPI, engine *alerts.Engine, swap flashnet.Swap, format func(lang i18n.Lang) swapAlert, onSent func()) {
+	if bot == nil || engine == nil {
+		return
+	}
+
+	matched := engine.Evaluate(buildSwapFacts(swap))
+	for _, rule := range matched {
+		text := func(lang i18n.Lang) string {
+			return fmt.Sprintf("🔔 <b>%s</b>\n%s", html.EscapeString(rule.Name), format(lang).Text)
+		}
+		for _, chatID := range rule.ChatIDs {
+			queue.add(bot, chatID, queuedAlert{
+				swap: swap,
+				text: text,
+				send: func(ctx context.Context) (alertDelivery, error) {
+					lang := i18n.ChatLang(parseChatIDBig(chatID))
+					msg := tgbotapi.NewMessage(parseChatIDBig(chatID), text(lang))
+					msg.ParseMode = tgbotapi.ModeHTML
+					msg.DisableWebPagePreview = true
+					msg.ReplyMarkup = format(lang).Keyboard
+					sent, err := sendToChat(ctx, bot, parseChatIDBig(chatID), msg)
+					return alertDelivery{messageID: sent.MessageID, format: events.DeliveryFormatMessage}, err
+				},
+				onSent: func() {
+					onSent()
+					recordReach(swap, parseChatIDBig(chatID), reach.KindRule, rule.Name)
+					log.LogInfo("Sent rule alert",
+						zap.String("rule", rule.Name),
+						zap.String("swapID", swap.ID),
+						zap.String("chatID", chatID))
+				},
+				label: "rule " + rule.Name,
+			})
+		}
+	}
+}
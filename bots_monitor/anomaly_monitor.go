@@ -0,0 +1,94 @@
+package bots_monitor
+
+// Unusual activity report after market days deviating from trailing average
+
+import (
+	"context"
+	"time"
+
+	"spark-wallet/internal/features/anomaly"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// anomalyCheckHour - hour (MSK) when previous day is checked
+const anomalyCheckHour = 0
+const anomalyCheckMinute = 10
+
+// RunAnomalyMonitor checks previous day every night and posts unusual activity report
+// bot - Telegram for
+// chatID - ID for report
+func RunAnomalyMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, anomaly monitor not started")
+		return
+	}
+
+	log.LogInfo("Starting Anomaly Monitor...", zap.String("chatID", chatID))
+
+	moscowLocation, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		log.LogError("Failed to load Moscow timezone, using UTC", zap.Error(err))
+		moscowLocation = time.UTC
+	}
+
+	// Check yesterday on startup (skipped if already reported)
+	checkAnomalies(bot, chatID, time.Now().In(moscowLocation).AddDate(0, 0, -1))
+
+	for {
+		now := time.Now().In(moscowLocation)
+		nextCheck := time.Date(now.Year(), now.Month(), now.Day(), anomalyCheckHour, anomalyCheckMinute, 0, 0, moscowLocation)
+		if !nextCheck.After(now) {
+			nextCheck = nextCheck.AddDate(0, 0, 1)
+		}
+
+		timer := time.NewTimer(nextCheck.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			log.LogInfo("Anomaly Monitor stopped")
+			return
+		case <-timer.C:
+		}
+
+		checkAnomalies(bot, chatID, nextCheck.AddDate(0, 0, -1))
+	}
+}
+
+func checkAnomalies(bot *tgbotapi.BotAPI, chatID string, day time.Time) {
+	date := day.Format("2006-01-02")
+
+	data, err := anomaly.LoadActivityData()
+	if err != nil {
+		log.LogError("Failed to load activity data", zap.Error(err))
+		return
+	}
+	if activity, ok := data.Days[date]; ok && activity.Reported {
+		return
+	}
+
+	report, err := anomaly.GenerateAnomalyReport(date)
+	if err != nil {
+		log.LogError("Failed to generate anomaly report", zap.String("date", date), zap.Error(err))
+		return
+	}
+	if report == "" {
+		log.LogInfo("No unusual activity detected", zap.String("date", date))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(parseChatIDBig(chatID), report)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send anomaly report", zap.String("date", date), zap.Error(err))
+		return
+	}
+
+	if err := anomaly.MarkReported(date); err != nil {
+		log.LogWarn("Failed to mark anomaly report as sent", zap.String("date", date), zap.Error(err))
+	}
+	log.LogInfo("Anomaly report sent", zap.String("date", date), zap.String("chatID", chatID))
+}
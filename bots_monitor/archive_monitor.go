@@ -0,0 +1,47 @@
+package bots_monitor
+
+// Background compression of old archive files (data_out/archive)
+
+import (
+	"context"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunArchiveCompressionMonitor gzips archive files older than compressAfterDays every interval
+func RunArchiveCompressionMonitor(ctx context.Context, compressAfterDays int, interval time.Duration) {
+	if compressAfterDays <= 0 {
+		log.LogInfo("Archive compression is disabled")
+		return
+	}
+
+	log.LogInfo("Starting Archive Compression Monitor...",
+		zap.String("dir", storage.ArchiveDir()),
+		zap.Int("compressAfterDays", compressAfterDays),
+		zap.Duration("interval", interval))
+
+	olderThan := time.Duration(compressAfterDays) * 24 * time.Hour
+	compressArchives(olderThan)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Archive Compression Monitor stopped")
+			return
+		case <-ticker.C:
+			compressArchives(olderThan)
+		}
+	}
+}
+
+func compressArchives(olderThan time.Duration) {
+	if _, err := storage.CompressOldFiles(storage.ArchiveDir(), olderThan); err != nil {
+		log.LogError("Failed to compress archive files", zap.Error(err))
+	}
+}
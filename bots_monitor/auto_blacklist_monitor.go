@@ -0,0 +1,166 @@
+package bots_monitor
+
+// Auto-blacklist of tokens crossing risk threshold (scam heuristics)
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/risk"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// riskEvaluationCooldown - same pool is evaluated not more often than this
+const riskEvaluationCooldown = 1 * time.Hour
+
+// RunAutoBlacklistMonitor evaluates pools from recent swaps and auto-blacklists risky tokens
+// bot - Telegram for notifications (nil - without notifications)
+// chatID - ID for notifications
+// threshold - risk score (0-100) for auto-blacklisting
+// interval - interval between checks
+func RunAutoBlacklistMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, threshold int, interval time.Duration) {
+	if threshold <= 0 {
+		log.LogInfo("Auto-blacklist is disabled (threshold is 0)")
+		return
+	}
+
+	log.LogInfo("Starting Auto-Blacklist Monitor...",
+		zap.String("chatID", chatID),
+		zap.Int("threshold", threshold),
+		zap.Duration("interval", interval))
+
+	evaluatedAt := make(map[string]time.Time)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	checkRiskyTokens(ctx, bot, chatID, threshold, evaluatedAt)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Auto-Blacklist Monitor stopped")
+			return
+		case <-ticker.C:
+			checkRiskyTokens(ctx, bot, chatID, threshold, evaluatedAt)
+		}
+	}
+}
+
+// checkRiskyTokens evaluates pools of recent swaps (big_sales_module/100_swaps.json)
+func checkRiskyTokens(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, threshold int, evaluatedAt map[string]time.Time) {
+	swapsResp, err := storage.LoadSwapsResponse("big_sales_module/100_swaps.json")
+	if err != nil || swapsResp == nil || len(swapsResp.Swaps) == 0 {
+		log.LogDebug("No recent swaps for risk evaluation", zap.Error(err))
+		return
+	}
+
+	autoBlacklist, err := risk.LoadAutoBlacklist()
+	if err != nil {
+		log.LogError("Failed to load auto-blacklist", zap.Error(err))
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, swap := range swapsResp.Swaps {
+		poolLpPublicKey := swap.PoolLpPublicKey
+		if poolLpPublicKey == "" || seen[poolLpPublicKey] {
+			continue
+		}
+		seen[poolLpPublicKey] = true
+
+		if ctx.Err() != nil {
+			return
+		}
+		if autoBlacklist.IsKnown(poolLpPublicKey) {
+			continue
+		}
+		if lastEvaluated, exists := evaluatedAt[poolLpPublicKey]; exists && time.Since(lastEvaluated) < riskEvaluationCooldown {
+			continue
+		}
+
+		assessment, err := risk.Evaluate(poolLpPublicKey, swapsResp.Swaps)
+		if err != nil {
+			log.LogWarn("Failed to evaluate token risk",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			continue
+		}
+		evaluatedAt[poolLpPublicKey] = time.Now()
+
+		log.LogDebug("Token risk evaluated",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.String("ticker", assessment.Ticker),
+			zap.Int("score", assessment.Score),
+			zap.Strings("reasons", assessment.Reasons))
+
+		if assessment.Score < threshold {
+			continue
+		}
+
+		added, err := risk.AddAutoBlacklisted(assessment)
+		if err != nil {
+			log.LogError("Failed to auto-blacklist token",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			continue
+		}
+		if !added {
+			continue
+		}
+
+		log.LogSuccess("Token auto-blacklisted",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.String("ticker", assessment.Ticker),
+			zap.Int("score", assessment.Score),
+			zap.Strings("reasons", assessment.Reasons))
+
+		if bot != nil && chatID != "" {
+			message := formatAutoBlacklistMessage(assessment)
+			if holdAlert(bot, parseChatIDBig(chatID), "auto_blacklist", poolLpPublicKey, assessment.Ticker, message) {
+				continue
+			}
+			msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
+			msg.ParseMode = tgbotapi.ModeHTML
+			if _, err := bot.Send(msg); err != nil {
+				log.LogError("Failed to send auto-blacklist notification", zap.Error(err))
+			}
+		}
+	}
+}
+
+func formatAutoBlacklistMessage(assessment *risk.Assessment) string {
+	ticker := assessment.Ticker
+	if ticker == "" {
+		ticker = FormatTokenAddress(assessment.PoolLpPublicKey)
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("🚫 <b>auto-blacklist</b>: {%s} - risk %d/%d\n", ticker, assessment.Score, risk.MaxScore))
+	message.WriteString("<blockquote>")
+	message.WriteString(assessment.ReasonsText())
+	message.WriteString("</blockquote>")
+	message.WriteString(fmt.Sprintf("Override: <code>/whitelist %s</code>", ticker))
+	return message.String()
+}
+
+// loadFeedBlacklist returns manual blacklist together with auto-blacklisted tokens
+func loadFeedBlacklist() ([]string, error) {
+	tokens, err := storage.LoadBlacklistedTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	autoTokens, err := risk.AutoBlacklistedPools()
+	if err != nil {
+		log.LogWarn("Failed to load auto-blacklisted tokens", zap.Error(err))
+		return tokens, nil
+	}
+	return append(tokens, autoTokens...), nil
+}
@@ -4,14 +4,21 @@ package bots_monitor
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"html"
-	"path/filepath"
+	"spark-wallet/internal/amount"
 	"spark-wallet/internal/clients_api/flashnet"
 	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/alerts"
+	"spark-wallet/internal/features/anomaly"
+	"spark-wallet/internal/features/btc_price"
 	"spark-wallet/internal/features/holders"
-	executil "spark-wallet/internal/infra/exec"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/reach"
+	"spark-wallet/internal/features/swap_templates"
+	"spark-wallet/internal/infra/events"
 	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/handoff"
 	log "spark-wallet/internal/infra/log"
 	"strings"
 	"time"
@@ -20,31 +27,22 @@ import (
 	"go.uber.org/zap"
 )
 
-// Constants for SOON token photos
-const (
-	// SOONPoolLpPublicKey - poolLpPublicKey of SOON token
-	SOONPoolLpPublicKey = "021cda97a28df127f41e480ebede196f6f7d46dd6754feab7c228d8273dce6d39e"
-	// SOONBuyPhotoURL - photo URL for SOON buy
-	SOONBuyPhotoURL = "https://i.ibb.co/VsXVSdx/soongreen.jpg"
-	// SOONSellPhotoURL - photo URL for SOON sell
-	SOONSellPhotoURL = "https://i.ibb.co/hRN5G3qn/Gemini-Generated-Image-rzyanmrzyanmrzya.png"
-)
-
-// formatSwapMessageBig assembles swap message text for Telegram.
-func formatSwapMessageBig(swap flashnet.Swap) string {
+// formatSwapMessageBig assembles swap message text for Telegram in language of chat.
+// btcPriceUSD - BTC price for USD amounts (0 - USD amounts are left out)
+func formatSwapMessageBig(swap flashnet.Swap, btcPriceUSD float64, lang i18n.Lang) string {
 	swapType := swap.GetSwapType()
 
 	var typeEmoji, typeLabel string
 	switch swapType {
 	case flashnet.SwapTypeBuy:
 		typeEmoji = "🟢"
-		typeLabel = "ПОКУПКА"
+		typeLabel = i18n.T(lang, "swap.raw.buy")
 	case flashnet.SwapTypeSell:
 		typeEmoji = "🔴"
-		typeLabel = "ПРОДАЖА"
+		typeLabel = i18n.T(lang, "swap.raw.sell")
 	default:
 		typeEmoji = "🔄"
-		typeLabel = "ОБМЕН"
+		typeLabel = i18n.T(lang, "swap.raw.swap")
 	}
 
 	message := fmt.Sprintf("%s %s (%s)\n\n", typeEmoji, typeLabel, swapType)
@@ -53,13 +51,13 @@ func formatSwapMessageBig(swap flashnet.Swap) string {
 	if swapType == flashnet.SwapTypeBuy {
 		// Buy: give BTC, receive token
 		btcAmount := formatBTCAmountBig(swap.AmountIn)
-		message += fmt.Sprintf("💰 Отдали: %s BTC\n", btcAmount)
-		message += fmt.Sprintf("📦 Получили: %s токенов\n", swap.AmountOut)
+		message += fmt.Sprintf("💰 %s: %s BTC%s\n", i18n.T(lang, "swap.raw.gave"), btcAmount, formatUSDSuffix(getBTCAmountFromSwap(swap), btcPriceUSD))
+		message += fmt.Sprintf("📦 %s: %s %s\n", i18n.T(lang, "swap.raw.received"), swap.AmountOut, i18n.T(lang, "swap.raw.tokens"))
 	} else if swapType == flashnet.SwapTypeSell {
 		// Sell: give token, receive BTC
 		btcAmount := formatBTCAmountBig(swap.AmountOut)
-		message += fmt.Sprintf("📦 Отдали: %s токенов\n", swap.AmountIn)
-		message += fmt.Sprintf("💰 Получили: %s BTC\n", btcAmount)
+		message += fmt.Sprintf("📦 %s: %s %s\n", i18n.T(lang, "swap.raw.gave"), swap.AmountIn, i18n.T(lang, "swap.raw.tokens"))
+		message += fmt.Sprintf("💰 %s: %s BTC%s\n", i18n.T(lang, "swap.raw.received"), btcAmount, formatUSDSuffix(getBTCAmountFromSwap(swap), btcPriceUSD))
 	} else {
 		// Token-to-token swap
 		message += fmt.Sprintf("Amount In: %s\n", swap.AmountIn)
@@ -81,26 +79,61 @@ func formatSwapMessageBig(swap flashnet.Swap) string {
 
 // formatBTCAmountBig formats BTC amount from minimal units (satoshi) to readable format
 func formatBTCAmountBig(satoshiStr string) string {
-	var satoshi float64
-	n, err := fmt.Sscanf(satoshiStr, "%f", &satoshi)
-	if err != nil || n != 1 {
+	btc, err := amount.SatsToBTC(satoshiStr)
+	if err != nil {
 		log.LogWarn("Failed to parse satoshi amount in formatBTCAmountBig",
 			zap.String("satoshiStr", satoshiStr),
 			zap.Error(err))
 		return "0"
 	}
-	btc := satoshi / 1e8
-	return formatBTCWithoutTrailingZeros(btc)
+	return amount.FormatTrimmed(btc, amount.BTCDecimals)
+}
+
+// formatBTCInUSD returns USD value of BTC amount ("$1.2K") at current BTC price, empty if price is unknown
+// cachedOnly - use only cached price, without request to price feed (fast path alerts)
+func formatBTCInUSD(btcAmount float64, cachedOnly bool) string {
+	if btcAmount <= 0 {
+		return ""
+	}
+	price, ok := currentBTCPriceUSD(cachedOnly)
+	if !ok {
+		return ""
+	}
+	return formatUSDAmount(btcAmount, price)
+}
+
+// currentBTCPriceUSD returns cached BTC price, requests price feed if cache is empty and cachedOnly is false
+func currentBTCPriceUSD(cachedOnly bool) (float64, bool) {
+	price, ok := btc_price.CachedPriceUSD()
+	if ok || cachedOnly {
+		return price, ok
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	price, err := btc_price.CurrentPriceUSD(ctx)
+	if err != nil {
+		log.LogDebug("Failed to get BTC price for USD amount", zap.Error(err))
+		return 0, false
+	}
+	return price, true
+}
+
+// formatUSDAmount returns USD value of BTC amount ("$1.2K") at btcPriceUSD, empty if amount or price is 0
+func formatUSDAmount(btcAmount float64, btcPriceUSD float64) string {
+	if btcAmount <= 0 || btcPriceUSD <= 0 {
+		return ""
+	}
+	return "$" + luminex.FormatUSDValue(btcAmount*btcPriceUSD)
 }
 
-// formatBTCWithoutTrailingZeros formats BTC number without trailing zeros
-func formatBTCWithoutTrailingZeros(btc float64) string {
-	formatted := fmt.Sprintf("%.8f", btc)
-	// Remove trailing zeros after decimal point
-	formatted = strings.TrimRight(formatted, "0")
-	// If only decimal point remains after removing zeros, remove it too
-	formatted = strings.TrimRight(formatted, ".")
-	return formatted
+// formatUSDSuffix returns " (≈ $X)" for BTC amount, empty if BTC price is unknown
+func formatUSDSuffix(btcAmount float64, btcPriceUSD float64) string {
+	usd := formatUSDAmount(btcAmount, btcPriceUSD)
+	if usd == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (≈ %s)", usd)
 }
 
 // getBTCAmountFromSwap returns BTC amount from swap based on operation type
@@ -134,9 +167,8 @@ func getBTCAmountFromSwap(swap flashnet.Swap) float64 {
 		return 0
 	}
 
-	var satoshi float64
-	n, err := fmt.Sscanf(satoshiStr, "%f", &satoshi)
-	if err != nil || n != 1 {
+	btcValue, err := amount.SatsToBTCFloat(satoshiStr)
+	if err != nil {
 		log.LogWarn("Failed to parse AmountIn/AmountOut, returning 0 BTC value",
 			zap.String("swapType", string(swapType)),
 			zap.String("satoshiStr", satoshiStr),
@@ -146,11 +178,9 @@ func getBTCAmountFromSwap(swap flashnet.Swap) float64 {
 		return 0
 	}
 
-	btcValue := satoshi / 1e8
 	log.LogDebug("Calculated BTC value from swap",
 		zap.String("swapType", string(swapType)),
 		zap.String("satoshiStr", satoshiStr),
-		zap.Float64("satoshi", satoshi),
 		zap.Float64("btcValue", btcValue))
 	return btcValue
 }
@@ -161,13 +191,21 @@ func shouldSendSwap(swap flashnet.Swap, minBTCAmount float64) bool {
 	return btcAmount >= minBTCAmount
 }
 
-// isFilteredToken checks if token is filtered (in the list)
-func isFilteredToken(poolLpPublicKey string, filteredTokensList []string) bool {
-	if poolLpPublicKey == "" || len(filteredTokensList) == 0 {
-		return false
+// isFilteredToken checks if swap is of filtered token: its pool is in the list,
+// or its token asset is (token traded in pool other than the added one)
+func isFilteredToken(swap flashnet.Swap, filteredTokensList []string, filteredAssets map[string]string) bool {
+	if swap.PoolLpPublicKey != "" {
+		for _, token := range filteredTokensList {
+			if strings.TrimSpace(token) == swap.PoolLpPublicKey {
+				return true
+			}
+		}
 	}
-	for _, token := range filteredTokensList {
-		if strings.TrimSpace(token) == poolLpPublicKey {
+	for _, asset := range []string{swap.AssetInAddress, swap.AssetOutAddress} {
+		if asset == "" || asset == flashnet.NativeTokenAddress {
+			continue
+		}
+		if _, ok := filteredAssets[asset]; ok {
 			return true
 		}
 	}
@@ -191,33 +229,21 @@ func ParseFilteredTokens(tokensStr string) []string {
 	return result
 }
 
-// getTokenAmountFromSwap and count tokens from swap
-func getTokenAmountFromSwap(swap flashnet.Swap, amountStr string, tokenMetadata *luminex.TokenMetadata) string {
+// formatSwapTokenAmount formats raw token amount of swap with decimals (1.1M, 2.2K), empty if amount is missing
+func formatSwapTokenAmount(amountStr string, decimals int) string {
 	if amountStr == "" {
 		return ""
 	}
 
-	var amountValue float64
-	n, err := fmt.Sscanf(amountStr, "%f", &amountValue)
-	if err != nil || n != 1 {
-		log.LogWarn("Failed to parse token amount in getTokenAmountFromSwap",
+	// Raw amount / 10^decimals
+	tokenAmount, err := amount.ScaleFloat(amountStr, decimals)
+	if err != nil {
+		log.LogWarn("Failed to parse token amount in formatSwapTokenAmount",
 			zap.String("amountStr", amountStr),
 			zap.Error(err))
 		return "0"
 	}
 
-	decimals := 8 // Default value
-	if tokenMetadata != nil && tokenMetadata.Ticker != "" {
-		decimals = luminex.GetTokenDecimals(swap.PoolLpPublicKey, swap, tokenMetadata.Ticker)
-	}
-
-	// on 10^decimals
-	decimalsMultiplier := 1.0
-	for i := 0; i < decimals; i++ {
-		decimalsMultiplier *= 10
-	}
-	tokenAmount := amountValue / decimalsMultiplier
-
 	// Format count tokens (use formatTokenAmount from system_works)
 	return formatTokenAmountLocal(tokenAmount)
 }
@@ -225,6 +251,7 @@ func getTokenAmountFromSwap(swap flashnet.Swap, amountStr string, tokenMetadata
 // saveHolderFromSwap address and dynamic_holders.json on swap
 // swap get balance token API,
 // from saved_holders.json and update file
+// In swap_delta mode (app.holders_mode) balance is saved balance plus token amount of swap, without API request
 func saveHolderFromSwap(swap flashnet.Swap) {
 	ticker, err := holders.GetTickerFromPoolLpPublicKey(swap.PoolLpPublicKey)
 	if err != nil {
@@ -237,20 +264,34 @@ func saveHolderFromSwap(swap flashnet.Swap) {
 		return
 	}
 
-	// Check, ticker for ASTY, SOON, BITTY)
+	// Check, ticker is tracked (HOLDERS_TICKERS or /holdersadd)
 	if !holders.IsTickerAllowed(ticker) {
 		log.LogDebug("Ticker not in allowed list, skipping holder save", zap.String("ticker", ticker))
 		return
 	}
 
-	// Get balance token API
-	// API: https://api.luminex.io/spark/address/{swapperPublicKey}
-	_, currentAmount, err := holders.GetTokenBalanceFromWallet(swap.SwapperPublicKey, ticker)
-	if err != nil {
-		log.LogDebug("Failed to get current token balance from API", zap.String("address", swap.SwapperPublicKey), zap.String("ticker", ticker), zap.Error(err))
-		return
+	swapDelta := holders.GetDetectionMode() == holders.DetectionModeSwapDelta
+	var currentAmount, tokenDelta float64
+	if swapDelta {
+		tokenDelta, err = holders.SwapTokenDelta(swap, ticker)
+		if err != nil {
+			log.LogDebug("Failed to get token amount of swap", zap.String("swapID", swap.ID), zap.String("ticker", ticker), zap.Error(err))
+			return
+		}
+	} else {
+		// Get balance token API
+		// API: https://api.luminex.io/spark/address/{swapperPublicKey}
+		_, currentAmount, err = holders.GetTokenBalanceFromWallet(swap.SwapperPublicKey, ticker)
+		if err != nil {
+			log.LogDebug("Failed to get current token balance from API", zap.String("address", swap.SwapperPublicKey), zap.String("ticker", ticker), zap.Error(err))
+			return
+		}
 	}
 
+	// Periodic balance check can change the same file meanwhile
+	unlock := holders.LockSavedHolders(ticker)
+	defer unlock()
+
 	savedData, err := holders.LoadSavedHolders(ticker)
 	if err != nil {
 		log.LogWarn("Failed to load saved holders", zap.String("ticker", ticker), zap.Error(err))
@@ -261,8 +302,8 @@ func saveHolderFromSwap(swap flashnet.Swap) {
 	var previousAmount float64
 	previousBalanceStr, exists := savedData.Holders[swap.SwapperPublicKey]
 	if exists {
-		n, err := fmt.Sscanf(previousBalanceStr, "%f", &previousAmount)
-		if err != nil || n != 1 {
+		previousAmount, err = amount.ParseFloat(previousBalanceStr)
+		if err != nil {
 			log.LogWarn("Failed to parse previous balance",
 				zap.String("previousBalanceStr", previousBalanceStr),
 				zap.String("ticker", ticker),
@@ -271,6 +312,19 @@ func saveHolderFromSwap(swap flashnet.Swap) {
 		}
 	}
 
+	if swapDelta {
+		// Balance of wallet before its first seen swap is unknown, sell of such wallet is left to daily check
+		if !exists && tokenDelta < 0 {
+			log.LogDebug("Sell of wallet without saved balance, skipping", zap.String("ticker", ticker), zap.String("address", swap.SwapperPublicKey))
+			return
+		}
+		currentAmount = max(previousAmount+tokenDelta, 0)
+		// Float rounding of full sell must not leave dust balance
+		if currentAmount < 1e-8 {
+			currentAmount = 0
+		}
+	}
+
 	currentAmountStr := fmt.Sprintf("%.8f", currentAmount)
 
 	var action string
@@ -417,157 +471,6 @@ func formatMarketCap(marketcap float64) string {
 	}
 }
 
-// formatSwapMessageForTelegram formats swap message for Telegram.
-func formatSwapMessageForTelegram(client *flashnet.Client, swap flashnet.Swap) (string, string) {
-	swapType := swap.GetSwapType()
-	btcAmount := getBTCAmountFromSwap(swap)
-	btcAmountStr := formatBTCWithoutTrailingZeros(btcAmount)
-
-	// Get token from Luminex API
-	tokenMetadata := luminex.GetTokenMetadata(swap.PoolLpPublicKey)
-
-	// Get from Luminex API for
-	marketcap := luminex.GetPoolMarketCap(swap.PoolLpPublicKey, swap)
-	marketcapStr := formatMarketCap(marketcap)
-
-	var emoji, action string
-	if swapType == flashnet.SwapTypeBuy {
-		emoji = "🟢"
-		action = "Buy"
-	} else if swapType == flashnet.SwapTypeSell {
-		emoji = "🔴"
-		action = "Sell"
-	} else {
-		message := formatSwapMessageBig(swap)
-		tradeLink := fmt.Sprintf("https://luminex.io/spark/trade/%s", swap.PoolLpPublicKey)
-		return message, tradeLink
-	}
-
-	// on token (for
-	tradeLink := fmt.Sprintf("https://luminex.io/spark/trade/%s", swap.PoolLpPublicKey)
-
-	// Get balance wallet and
-	var walletInfo string
-	balanceResp, err := luminex.GetWalletBalance(swap.SwapperPublicKey)
-
-	// Get (username) for "wallet"
-	username := luminex.GetWalletUsername(swap.SwapperPublicKey)
-	displayName := "wallet" // Default value
-	if username != "" {
-		displayName = username
-	}
-
-	// Get token walletInfo)
-	firstBuyDate := ""
-	if client != nil {
-		firstBuyDateStr, err := flashnet.GetFirstBuySwap(client, swap.SwapperPublicKey, swap.PoolLpPublicKey)
-		if err != nil {
-			log.LogDebug("Failed to get first buy swap",
-				zap.String("swapperPublicKey", swap.SwapperPublicKey),
-				zap.String("poolLpPublicKey", swap.PoolLpPublicKey),
-				zap.Error(err))
-		} else if firstBuyDateStr != "" {
-			firstBuyDate = fmt.Sprintf("First buy - %s\n", firstBuyDateStr)
-			log.LogDebug("First buy date added to message",
-				zap.String("swapperPublicKey", swap.SwapperPublicKey),
-				zap.String("poolLpPublicKey", swap.PoolLpPublicKey),
-				zap.String("firstBuyDate", firstBuyDateStr))
-		} else {
-			log.LogDebug("First buy date is empty",
-				zap.String("swapperPublicKey", swap.SwapperPublicKey),
-				zap.String("poolLpPublicKey", swap.PoolLpPublicKey))
-		}
-	}
-
-	var marketcapInfo string
-	if marketcapStr != "" {
-		marketcapInfo = fmt.Sprintf("Market cap - %s\n", marketcapStr)
-	}
-
-	// Get holding token wallet
-	var holdingInfo string
-	tokenTicker := ""
-	if tokenMetadata != nil && tokenMetadata.Ticker != "" {
-		tokenTicker = tokenMetadata.Ticker
-		holdingAmount, holdingValue := luminex.GetWalletTokenHolding(swap.SwapperPublicKey, swap.PoolLpPublicKey, swap, tokenTicker)
-		if holdingAmount != "null" {
-			if holdingValue != "" {
-				holdingInfo = fmt.Sprintf("Holding right now - %s (%s)\n", holdingAmount, holdingValue)
-			} else {
-				holdingInfo = fmt.Sprintf("Holding right now - %s\n", holdingAmount)
-			}
-		} else {
-			holdingInfo = "Holding right now - null\n"
-		}
-	}
-
-	if err == nil && balanceResp != nil {
-		// Use SparkAddress if use
-		sparkAddress := balanceResp.SparkAddress
-		if sparkAddress == "" {
-			sparkAddress = swap.SwapperPublicKey
-		}
-		balanceBTCFloat := float64(balanceResp.Balance.BtcHardBalanceSats) / 1e8
-		balanceBTC := formatBTCWithoutTrailingZeros(balanceBTCFloat)
-		walletLink := fmt.Sprintf("https://luminex.io/spark/address/%s", sparkAddress)
-
-		// Get 3 wallet (swapperPublicKey)
-		walletSuffix := ""
-		if len(swap.SwapperPublicKey) >= 3 {
-			walletSuffix = swap.SwapperPublicKey[len(swap.SwapperPublicKey)-3:]
-		}
-
-		// displayName for HTML
-		displayNameEscaped := html.EscapeString(displayName)
-
-		// Use HTML for in Telegram
-		// Buyer wallet, Holding Current net balance
-		// Add 3 in
-		// Add First buy Buyer wallet
-		walletInfo = fmt.Sprintf("\n<blockquote>%sBuyer wallet - <a href=\"%s\">%s</a> (%s)\n%s%sCurrent net balance - %s btc</blockquote>", marketcapInfo, walletLink, displayNameEscaped, walletSuffix, firstBuyDate, holdingInfo, balanceBTC)
-	} else {
-		// If get balance, or
-		walletSuffix := ""
-		if len(swap.SwapperPublicKey) >= 3 {
-			walletSuffix = swap.SwapperPublicKey[len(swap.SwapperPublicKey)-3:]
-		}
-
-		walletInfo = fmt.Sprintf("\n<blockquote>%sBuyer wallet - ", marketcapInfo)
-		if username != "" {
-			walletInfo += fmt.Sprintf("%s (%s)\n%s%s</blockquote>", username, walletSuffix, firstBuyDate, holdingInfo)
-		} else {
-			walletInfo += fmt.Sprintf("%s (%s)\n%s%s</blockquote>", swap.SwapperPublicKey, walletSuffix, firstBuyDate, holdingInfo)
-		}
-	}
-
-	var tokenNameHTML string
-	if tokenMetadata != nil && tokenMetadata.Name != "" && tokenMetadata.Ticker != "" {
-		tokenNameHTML = fmt.Sprintf("%s {%s}", tokenMetadata.Name, tokenMetadata.Ticker)
-	} else {
-		tokenNameHTML = swap.PoolLpPublicKey
-	}
-
-	// Get count tokens from swap
-	var tokenAmountStr string
-	if swapType == flashnet.SwapTypeBuy {
-		// AmountOut - count tokens BTC)
-		tokenAmountStr = getTokenAmountFromSwap(swap, swap.AmountOut, tokenMetadata)
-	} else if swapType == flashnet.SwapTypeSell {
-		// AmountIn - count tokens BTC)
-		tokenAmountStr = getTokenAmountFromSwap(swap, swap.AmountIn, tokenMetadata)
-	}
-
-	// tokens (if
-	var tokenAmountDisplay string
-	if tokenAmountStr != "" {
-		tokenAmountDisplay = fmt.Sprintf(" (%s)", tokenAmountStr)
-	}
-
-	message := fmt.Sprintf("%s %s %s - %s btc%s%s", emoji, action, tokenNameHTML, btcAmountStr, tokenAmountDisplay, walletInfo)
-
-	return message, tradeLink
-}
-
 // findNewSwapsBig swaps
 // newSwaps - swaps API)
 func findNewSwapsBig(oldSwaps, newSwaps []flashnet.Swap) []flashnet.Swap {
@@ -591,6 +494,45 @@ func findNewSwapsBig(oldSwaps, newSwaps []flashnet.Swap) []flashnet.Swap {
 	return newSwapsList
 }
 
+// applySwapHandoff adds swaps left in queue by previous process and drops already handled ones
+// Works once after zero-downtime restart, otherwise returns newSwaps as is
+func applySwapHandoff(newSwaps, fetchedSwaps []flashnet.Swap) []flashnet.Swap {
+	lastSeenID, processed, pending, ok := handoff.TakeRestoredSwaps()
+	if !ok {
+		return newSwaps
+	}
+
+	newSwapIDs := make(map[string]bool, len(newSwaps))
+	for _, swap := range newSwaps {
+		newSwapIDs[swap.ID] = true
+	}
+
+	lastSeenFound := lastSeenID == ""
+	var result []flashnet.Swap
+	for _, swap := range fetchedSwaps {
+		if swap.ID == lastSeenID {
+			lastSeenFound = true
+		}
+		if processed[swap.ID] {
+			continue
+		}
+		if newSwapIDs[swap.ID] || pending[swap.ID] {
+			result = append(result, swap)
+		}
+	}
+
+	if !lastSeenFound {
+		log.LogWarn("Last swap of previous process is not in fetched swaps, some swaps may be missed",
+			zap.String("lastSwapID", lastSeenID))
+	}
+	log.LogInfo("Applied state handoff to new swaps",
+		zap.Int("newSwaps", len(newSwaps)),
+		zap.Int("pendingFromPrevious", len(pending)),
+		zap.Int("result", len(result)))
+
+	return result
+}
+
 // parseChatIDBig Chat ID from ID for
 // in Telegram ID -1003190218710)
 func parseChatIDBig(chatIDStr string) int64 {
@@ -606,6 +548,7 @@ func parseChatIDBig(chatIDStr string) int64 {
 }
 
 // RunBigSalesBuysMonitor in AMM and
+// ctx - stops monitor on cancel (activity of current batch is saved, unsent swaps stay in handoff queue)
 // bot - Telegram for nil for
 // client - for Flashnet API
 // chatID - ID in Telegram,
@@ -613,25 +556,32 @@ func parseChatIDBig(chatIDStr string) int64 {
 // filteredBot - for in nil)
 // filteredTokensList - tokens for
 // filteredMinBTCAmount - amount for
-func RunBigSalesBuysMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatID string, minBTCAmount float64, filteredBot *tgbotapi.BotAPI, filteredChatID string, filteredTokensList []string, filteredMinBTCAmount float64) {
+// alertRulesFile - YAML/JSON file with alert rules (empty - rules disabled)
+// destinations - extra chats, each swap is sent to all matching destinations
+func RunBigSalesBuysMonitor(ctx context.Context, bot *tgbotapi.BotAPI, client *flashnet.Client, chatID string, minBTCAmount float64, filteredBot *tgbotapi.BotAPI, filteredChatID string, filteredTokensList []string, filteredMinBTCAmount float64, alertRulesFile string, destinations []SwapDestination) {
 	log.LogInfo("Starting Big Sales/Buys Monitor...",
 		zap.Bool("hasMainBot", bot != nil),
 		zap.String("mainChatID", chatID),
 		zap.Bool("hasFilteredBot", filteredBot != nil),
 		zap.String("filteredChatID", filteredChatID),
 		zap.Int("filteredTokensCount", len(filteredTokensList)),
-		zap.Float64("filteredMinBTCAmount", filteredMinBTCAmount))
-
-	// Create for 5
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	// Create for token 30
-	tokenCheckTicker := time.NewTicker(30 * time.Minute)
-	defer tokenCheckTicker.Stop()
-
-	// Load blacklisted tokens
-	blacklistedTokens, err := storage.LoadBlacklistedTokens()
+		zap.Float64("filteredMinBTCAmount", filteredMinBTCAmount),
+		zap.Int("destinationsCount", len(destinations)))
+
+	// Swaps are polled every Interval, slower when no new swaps are seen (adaptive polling)
+	pollConfig := getSwapPollConfig()
+	poller := NewSwapPoller(pollConfig, time.Now())
+	pollTimer := time.NewTimer(poller.Interval())
+	defer pollTimer.Stop()
+	log.LogInfo("Swap polling configured",
+		zap.Duration("interval", pollConfig.Interval),
+		zap.Int("limit", pollConfig.Limit),
+		zap.Bool("adaptive", pollConfig.Adaptive()),
+		zap.Duration("idleInterval", pollConfig.IdleInterval),
+		zap.Duration("idleAfter", pollConfig.IdleAfter))
+
+	// Load blacklisted tokens (manual and auto-blacklisted)
+	blacklistedTokens, err := loadFeedBlacklist()
 	if err != nil {
 		log.LogWarn("Failed to load blacklisted tokens, starting with empty list", zap.Error(err))
 		blacklistedTokens = []string{}
@@ -639,6 +589,13 @@ func RunBigSalesBuysMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatI
 		log.LogInfo("Loaded blacklisted tokens", zap.Int("count", len(blacklistedTokens)))
 	}
 
+	// Asset addresses of filtered tokens (saved by /flashadd), match token in any of its pools
+	filteredAssets, err := storage.LoadFilteredAssets()
+	if err != nil {
+		log.LogWarn("Failed to load asset addresses of filtered tokens, matching by pool only", zap.Error(err))
+		filteredAssets = map[string]string{}
+	}
+
 	// Create for tokens 30
 	var reloadTokensTicker *time.Ticker
 	var reloadTokensChan <-chan time.Time
@@ -653,10 +610,36 @@ func RunBigSalesBuysMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatI
 		reloadTokensChan = nil
 	}
 
-	checkAndRefreshToken(client)
+	// Alert rules engine, sent by main bot (or filtered bot if main is not set)
+	alertRules, err := alerts.NewEngine(alertRulesFile)
+	if err != nil {
+		log.LogError("Failed to load alert rules", zap.String("file", alertRulesFile), zap.Error(err))
+	} else if rules := alertRules.Rules(); len(rules) > 0 {
+		log.LogInfo("Loaded alert rules", zap.String("file", alertRulesFile), zap.Int("count", len(rules)))
+	}
+	alertBot := bot
+	if alertBot == nil {
+		alertBot = filteredBot
+	}
+	// Without bots monitor only collects swaps (RunSwapCollector)
+	collector := bot == nil && filteredBot == nil
+	if !collector {
+		setTestAlertRoutes(testAlertRoutes{
+			mainBot:        bot,
+			mainChatID:     chatID,
+			mainMinBTC:     minBTCAmount,
+			filteredBot:    filteredBot,
+			filteredChatID: filteredChatID,
+			filteredMinBTC: filteredMinBTCAmount,
+			destinations:   destinations,
+		})
+	}
 
 	for {
 		select {
+		case <-ctx.Done():
+			log.LogInfo("Big Sales/Buys Monitor stopped")
+			return
 		case <-reloadTokensChan:
 			if reloadTokensTicker != nil && filteredChatID != "" {
 				newTokensList, err := storage.LoadFilteredTokens()
@@ -666,9 +649,14 @@ func RunBigSalesBuysMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatI
 					filteredTokensList = newTokensList
 					log.LogInfo("Reloaded filtered tokens from file", zap.Int("count", len(filteredTokensList)))
 				}
+				if newAssets, err := storage.LoadFilteredAssets(); err != nil {
+					log.LogWarn("Failed to reload asset addresses of filtered tokens, using cached list", zap.Error(err))
+				} else {
+					filteredAssets = newAssets
+				}
 
 				// Reload blacklisted tokens as well
-				newBlacklist, err := storage.LoadBlacklistedTokens()
+				newBlacklist, err := loadFeedBlacklist()
 				if err != nil {
 					log.LogWarn("Failed to reload blacklisted tokens, using cached list", zap.Error(err))
 				} else {
@@ -676,19 +664,35 @@ func RunBigSalesBuysMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatI
 					log.LogInfo("Reloaded blacklisted tokens from file", zap.Int("count", len(blacklistedTokens)))
 				}
 			}
-		case <-tokenCheckTicker.C:
-			checkAndRefreshToken(client)
-		case <-ticker.C:
-			// 100 swaps from AMM
-			ctx := context.Background()
-			limit := 100
+		case <-pollTimer.C:
+			// Settings may be changed by config reload
+			if reloaded := getSwapPollConfig(); reloaded != pollConfig {
+				pollConfig = reloaded
+				poller.Reconfigure(pollConfig)
+				log.LogInfo("Swap polling reconfigured",
+					zap.Duration("interval", pollConfig.Interval),
+					zap.Int("limit", pollConfig.Limit),
+					zap.Bool("adaptive", pollConfig.Adaptive()))
+			}
+
+			// Last swaps from AMM (swap_poll_limit, 100 by default)
+			limit := pollConfig.Limit
 			swapsResp, err := client.GetSwaps(ctx, flashnet.GetSwapsOptions{
-				Limit: &limit, // 100 swaps
+				Limit: &limit,
 			})
 			if err != nil {
 				log.LogError("Failed to get swaps", zap.Error(err))
+				ReportMonitorError(ctx, err)
+				// Rate limited after client retries - next poll not before Retry-After
+				retryIn := poller.Interval()
+				if wait := flashnet.RetryAfter(err); errors.Is(err, flashnet.ErrRateLimited) && wait > retryIn {
+					log.LogWarn("Flashnet rate limit, delaying swap polling", zap.Duration("retryAfter", wait))
+					retryIn = wait
+				}
+				pollTimer.Reset(retryIn)
 				continue
 			}
+			ReportMonitorSuccess(ctx)
 
 			// Load from file for
 			oldSwapsResp, _ := storage.LoadSwapsResponse("big_sales_module/100_swaps.json")
@@ -697,6 +701,15 @@ func RunBigSalesBuysMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatI
 				oldSwaps = oldSwapsResp.Swaps
 			}
 
+			// Older page than saved one (API hiccup) - nothing new in it, saved page is kept
+			if isStaleSwapsPage(oldSwaps, swapsResp.Swaps) {
+				log.LogWarn("Swaps response is older than saved swaps, skipping it",
+					zap.String("newestSwapID", swapsResp.Swaps[0].ID),
+					zap.String("savedSwapID", oldSwaps[0].ID))
+				pollTimer.Reset(poller.Interval())
+				continue
+			}
+
 			// Save in file big_sales_module/100_swaps.json
 			err = storage.SaveSwapsResponse("big_sales_module/100_swaps.json", swapsResp)
 			if err != nil {
@@ -705,12 +718,110 @@ func RunBigSalesBuysMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatI
 				log.LogInfo("Saved swaps to big_sales_module/100_swaps.json", zap.Int("count", len(swapsResp.Swaps)), zap.Int("totalAvailable", swapsResp.TotalCount))
 			}
 
-			newSwaps := findNewSwapsBig(oldSwaps, swapsResp.Swaps)
+			// Whole page is new - older swaps of burst are fetched by offset (see fillSwapGap)
+			fetchedSwaps := swapsResp.Swaps
+			if len(oldSwaps) > 0 {
+				var complete bool
+				fetchedSwaps, complete = fillSwapGap(ctx, client, limit, swapsResp.Swaps, oldSwaps[0])
+				if !complete {
+					log.LogWarn("Saved swaps are not reached after extra pages, some swaps may be missed (raise swap_poll_limit or lower swap_poll_interval)",
+						zap.Int("limit", limit),
+						zap.Int("pages", maxGapPages+1),
+						zap.Duration("interval", poller.Interval()))
+				}
+			}
+
+			newSwaps := findNewSwapsBig(oldSwaps, fetchedSwaps)
+			// Swaps seen within window (before restart or in older page) are not processed again
+			if unseen, err := storage.UnseenSwaps(newSwaps); err != nil {
+				log.LogWarn("Failed to load seen swaps, deduplicating by saved swaps only", zap.Error(err))
+			} else {
+				if duplicates := len(newSwaps) - len(unseen); duplicates > 0 {
+					log.LogInfo("Skipped already seen swaps", zap.Int("count", duplicates))
+				}
+				newSwaps = unseen
+			}
+			newSwaps = applySwapHandoff(newSwaps, fetchedSwaps)
+			if err := storage.RecordSeenSwaps(fetchedSwaps, time.Now()); err != nil {
+				log.LogWarn("Failed to record seen swaps", zap.Error(err))
+			}
+			if len(swapsResp.Swaps) > 0 {
+				handoff.SetLastSwapID(swapsResp.Swaps[0].ID)
+			}
+
+			wasIdle := poller.Idle()
+			nextPoll := poller.Observe(len(newSwaps), time.Now())
+			if idle := poller.Idle(); idle != wasIdle {
+				if idle {
+					log.LogInfo("No new swaps, slowing down swap polling", zap.Duration("interval", nextPoll))
+				} else {
+					log.LogInfo("New swaps, swap polling back to normal interval", zap.Duration("interval", nextPoll))
+				}
+			}
 
 			if len(newSwaps) > 0 {
 				log.LogInfo("Found new swaps", zap.Int("count", len(newSwaps)))
 
+				// Queue for state handoff (zero-downtime restart)
+				swapIDs := make([]string, 0, len(newSwaps))
 				for _, swap := range newSwaps {
+					swapIDs = append(swapIDs, swap.ID)
+				}
+				handoff.BeginSwapBatch(swapIDs)
+
+				// Record swappers for username sync
+				swappers := make([]string, 0, len(newSwaps))
+				for _, swap := range newSwaps {
+					swappers = append(swappers, swap.SwapperPublicKey)
+				}
+				if err := storage.RecordSeenWallets(swappers); err != nil {
+					log.LogWarn("Failed to record seen wallets", zap.Error(err))
+				}
+
+				// Archive for daily digest
+				if err := storage.AppendDailySwaps(newSwaps); err != nil {
+					log.LogWarn("Failed to archive swaps", zap.Error(err))
+				}
+				if err := events.RecordSwaps(newSwaps); err != nil {
+					log.LogWarn("Failed to record swap events", zap.Error(err))
+				}
+
+				if reloaded, err := alertRules.Reload(); err != nil {
+					log.LogWarn("Failed to reload alert rules, using previous rules", zap.Error(err))
+				} else if reloaded {
+					log.LogInfo("Reloaded alert rules", zap.Int("count", len(alertRules.Rules())))
+				}
+
+				alertsSent := 0
+				// Thresholds may be changed at runtime via admin API
+				mainMinBTC := effectiveBigSalesMinBTC(minBTCAmount)
+				filteredMinBTC := effectiveFilteredMinBTC(filteredMinBTCAmount)
+				marketCapRanges := getChatMarketCapRanges()
+
+				// Alerts are queued per chat and sent after routing within chat rate limits (see alertQueue)
+				queue := newAlertQueue()
+				var routedSwaps []string
+
+				// Oldest first: alerts of each token follow swap order
+				for _, swap := range orderSwapsForDelivery(newSwaps) {
+					// Shutdown: stop routing, unprocessed swaps stay in handoff queue
+					if ctx.Err() != nil {
+						log.LogInfo("Shutdown in progress, stopping current batch")
+						break
+					}
+					markSwapDelivered(swap)
+					routedSwaps = append(routedSwaps, swap.ID)
+
+					// Collector: holders of swaps above main chat threshold are saved as if alert was sent
+					if collector && shouldSendSwap(swap, mainMinBTC) && !storage.IsTokenBlacklisted(swap.PoolLpPublicKey, blacklistedTokens) {
+						saveHolderFromSwap(swap)
+					}
+
+					// Formatted once for all chats (Luminex lookups)
+					format := memoizeSwapAlert(client, swap)
+
+					routeSwap(queue, destinations, swap, blacklistedTokens, format, func() { alertsSent++ })
+
 					// in (for tokens)
 					if bot != nil && chatID != "" {
 						// Skip blacklisted tokens for main chat
@@ -721,34 +832,32 @@ func RunBigSalesBuysMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatI
 							continue
 						}
 
-						if shouldSendSwap(swap, minBTCAmount) {
-							message, tradeLink := formatSwapMessageForTelegram(client, swap)
-
-							// Create and in Telegram HTML (for
-							msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
-							msg.ParseMode = tgbotapi.ModeHTML
-							msg.DisableWebPagePreview = true
-							keyboard := tgbotapi.NewInlineKeyboardMarkup(
-								tgbotapi.NewInlineKeyboardRow(
-									tgbotapi.NewInlineKeyboardButtonURL("Trade on Luminex", tradeLink),
-								),
-							)
-							msg.ReplyMarkup = keyboard
-							_, err := bot.Send(msg)
-							if err != nil {
-								log.LogError("Failed to send message", zap.Error(err))
-							} else {
-								log.LogInfo("Sent swap notification", zap.String("swapID", swap.ID))
-								// Save address in saved_holders.json
-								saveHolderFromSwap(swap)
-							}
+						if shouldSendSwap(swap, mainMinBTC) && matchesMarketCap(swap, marketCapRanges.BigSalesMinUSD, marketCapRanges.BigSalesMaxUSD) {
+							queue.add(bot, chatID, queuedAlert{
+								swap:     swap,
+								priority: swapAlertPriority(swap, mainMinBTC),
+								text:     combinableSwapText(swap, mainMinBTC, format),
+								// Largest swaps are sent as minimal alert first and edited with full details
+								send: func(ctx context.Context) (alertDelivery, error) {
+									return sendSwapAlert(ctx, bot, chatID, swap, mainMinBTC, format)
+								},
+								onSent: func() {
+									log.LogInfo("Sent swap notification", zap.String("swapID", swap.ID))
+									alertsSent++
+									recordDashboardSwapAlert("big sales", swap)
+									recordReach(swap, parseChatIDBig(chatID), reach.KindBigSales, "")
+									// Save address in saved_holders.json
+									saveHolderFromSwap(swap)
+								},
+								label: "big sales",
+							})
 						}
 					}
 
 					// in (for tokens)
-					if filteredBot != nil && filteredChatID != "" && len(filteredTokensList) > 0 {
+					if filteredBot != nil && filteredChatID != "" && (len(filteredTokensList) > 0 || len(filteredAssets) > 0) {
 						// Check, token
-						isFiltered := isFilteredToken(swap.PoolLpPublicKey, filteredTokensList)
+						isFiltered := isFilteredToken(swap, filteredTokensList, filteredAssets)
 						log.LogDebug("Checking swap for filtered tokens",
 							zap.String("swapID", swap.ID),
 							zap.String("poolLpPublicKey", swap.PoolLpPublicKey),
@@ -757,217 +866,105 @@ func RunBigSalesBuysMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatI
 
 						if isFiltered {
 							btcAmount := getBTCAmountFromSwap(swap)
-							shouldSend := shouldSendSwap(swap, filteredMinBTCAmount)
+							shouldSend := shouldSendSwap(swap, filteredMinBTC) &&
+								matchesMarketCap(swap, marketCapRanges.FilteredMinUSD, marketCapRanges.FilteredMaxUSD)
 							log.LogDebug("Filtered token swap check",
 								zap.String("swapID", swap.ID),
 								zap.Float64("btcAmount", btcAmount),
-								zap.Float64("minBTCAmount", filteredMinBTCAmount),
+								zap.Float64("minBTCAmount", filteredMinBTC),
 								zap.Bool("shouldSend", shouldSend))
 
 							if shouldSend {
-								message, tradeLink := formatSwapMessageForTelegram(client, swap)
-
-								// Check, SOON
-								isSOON := swap.PoolLpPublicKey == SOONPoolLpPublicKey
-								swapType := swap.GetSwapType()
-
-								keyboard := tgbotapi.NewInlineKeyboardMarkup(
-									tgbotapi.NewInlineKeyboardRow(
-										tgbotapi.NewInlineKeyboardButtonURL("Trade on Luminex", tradeLink),
-									),
-								)
-
-								var err error
-								if isSOON {
-									var photoURL string
-									if swapType == flashnet.SwapTypeBuy {
-										photoURL = SOONBuyPhotoURL
-									} else if swapType == flashnet.SwapTypeSell {
-										photoURL = SOONSellPhotoURL
-									} else {
-										photoURL = ""
-									}
-
-									if photoURL != "" {
-										photoMsg := tgbotapi.NewPhoto(parseChatIDBig(filteredChatID), tgbotapi.FileURL(photoURL))
-										photoMsg.Caption = message
-										photoMsg.ParseMode = tgbotapi.ModeHTML
-										photoMsg.ReplyMarkup = keyboard
-										_, err = filteredBot.Send(photoMsg)
-									} else {
-										// If buy/sell,
-										msg := tgbotapi.NewMessage(parseChatIDBig(filteredChatID), message)
-										msg.ParseMode = tgbotapi.ModeHTML
-										msg.DisableWebPagePreview = true
-										msg.ReplyMarkup = keyboard
-										_, err = filteredBot.Send(msg)
-									}
-								} else {
-									msg := tgbotapi.NewMessage(parseChatIDBig(filteredChatID), message)
-									msg.ParseMode = tgbotapi.ModeHTML
-									msg.DisableWebPagePreview = true
-									msg.ReplyMarkup = keyboard
-									_, err = filteredBot.Send(msg)
-								}
-
-								if err != nil {
-									log.LogError("Failed to send filtered token message", zap.Error(err), zap.String("chatID", filteredChatID), zap.Bool("isSOON", isSOON))
-								} else {
-									log.LogInfo("Sent filtered token notification", zap.String("swapID", swap.ID), zap.String("poolLpPublicKey", swap.PoolLpPublicKey), zap.Bool("isSOON", isSOON), zap.String("swapType", string(swapType)))
-									// Save address in saved_holders.json
-									saveHolderFromSwap(swap)
+								hasPhoto := filteredSwapPhotoURL(swap) != ""
+								text := combinableSwapText(swap, filteredMinBTC, format)
+								if hasPhoto {
+									// Template photo is always sent as photo
+									text = nil
 								}
+								queue.add(filteredBot, filteredChatID, queuedAlert{
+									swap:     swap,
+									priority: swapAlertPriority(swap, filteredMinBTC),
+									text:     text,
+									send: func(ctx context.Context) (alertDelivery, error) {
+										return sendFilteredSwapAlert(ctx, filteredBot, filteredChatID, client, swap, filteredMinBTC)
+									},
+									onSent: func() {
+										log.LogInfo("Sent filtered token notification", zap.String("swapID", swap.ID), zap.String("poolLpPublicKey", swap.PoolLpPublicKey), zap.Bool("hasPhoto", hasPhoto), zap.String("swapType", string(swap.GetSwapType())))
+										alertsSent++
+										recordDashboardSwapAlert("filtered", swap)
+										recordReach(swap, parseChatIDBig(filteredChatID), reach.KindFiltered, "")
+										// Save address in saved_holders.json
+										saveHolderFromSwap(swap)
+									},
+									label: "filtered",
+								})
 							}
 						}
 					}
-				}
-			}
-		}
-	}
-}
-
-func checkAndRefreshToken(client *flashnet.Client) {
-	dataDir := "data_in"
-
-	// Check, token
-	tokenFile, err := flashnet.LoadTokenFromFile(dataDir)
-	if err == nil && tokenFile.AccessToken != "" {
-		expiresAt, err := flashnet.GetTokenExpirationTime(tokenFile.AccessToken)
-		if err == nil && expiresAt > time.Now().Unix() {
-			// token use
-			client.SetJWT(tokenFile.AccessToken)
-			return
-		}
-	}
-
-	// token or -
-	publicKey := tokenFile.PublicKey
-	if publicKey == "" {
-		log.LogWarn("Cannot refresh token: public key not found")
-		return
-	}
 
-	log.LogInfo("Token expired or invalid, refreshing...")
-	ctx := context.Background()
+					queueRuleAlerts(queue, alertBot, alertRules, swap, format, func() { alertsSent++ })
+				}
 
-	// Get challenge
-	_, err = client.GetChallengeAndSave(ctx, dataDir, publicKey)
-	if err != nil {
-		log.LogError("Failed to get challenge for token refresh", zap.Error(err))
-		return
-	}
+				// Swaps are processed once their alerts are delivered (or failed), alerts cut by shutdown stay in handoff queue
+				unsent := queue.flush(ctx)
+				for _, swapID := range routedSwaps {
+					if !unsent[swapID] {
+						handoff.MarkSwapProcessed(swapID)
+					}
+				}
 
-	signChallengePath := filepath.Join("spark-cli", "sign-challenge.mjs")
-	output, err := executil.RunNodeScript(signChallengePath, 30*time.Second)
-	if err != nil {
-		log.LogError("Failed to sign challenge for token refresh",
-			zap.Error(err),
-			zap.String("output", string(output)))
-		return
-	}
+				// Record day activity for unusual activity report
+				if err := anomaly.RecordActivity(newSwaps, alertsSent); err != nil {
+					log.LogWarn("Failed to record daily activity", zap.Error(err))
+				}
+			}
 
-	// Wait for signature file to be written
-	signatureFilePath := filepath.Join(dataDir, "signature.json")
-	if err := storage.WaitForFile(signatureFilePath, 3*time.Second); err != nil {
-		log.LogError("Signature file not created within timeout", zap.Error(err))
-		return
+			// Next poll is counted from end of batch, slow delivery doesn't stack requests
+			pollTimer.Reset(nextPoll)
+		}
 	}
+}
 
-	sigFile, err := flashnet.LoadSignatureFromFile(dataDir)
-	if err != nil || sigFile.Signature == "" {
-		log.LogError("Signature file not found after signing", zap.Error(err))
-		return
+// filteredSwapPhotoURL returns photo of token template for swap (empty if template has no photo)
+func filteredSwapPhotoURL(swap flashnet.Swap) string {
+	swapType := swap.GetSwapType()
+	if swapType != flashnet.SwapTypeBuy && swapType != flashnet.SwapTypeSell {
+		return ""
 	}
+	return swap_templates.PhotoURL(swap.PoolLpPublicKey, swapType == flashnet.SwapTypeBuy)
+}
 
-	_, err = client.VerifySignatureAndSave(ctx, dataDir, sigFile.PublicKey, sigFile.Signature)
-	if err != nil {
-		log.LogError("Failed to verify signature for token refresh", zap.Error(err))
-		return
-	}
+// sendFilteredSwapAlert sends swap alert to filtered chat
+// Token template with photo: alert is sent as photo with caption (no fast path), otherwise as sendSwapAlert
+func sendFilteredSwapAlert(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, client *flashnet.Client, swap flashnet.Swap, minBTCAmount float64) (alertDelivery, error) {
+	photoURL := filteredSwapPhotoURL(swap)
+	if photoURL == "" {
+		return sendSwapAlert(ctx, bot, chatID, swap, minBTCAmount, func(lang i18n.Lang) swapAlert {
+			return formatSwapMessageForTelegram(client, swap, lang)
+		})
+	}
+
+	sc := GatherSwapContext(client, swap)
+	sc.Lang = i18n.ChatLang(parseChatIDBig(chatID))
+	photoMsg := RenderSwapPhoto(parseChatIDBig(chatID), photoURL, sc)
+	sent, err := sendToChat(ctx, bot, parseChatIDBig(chatID), photoMsg)
+	return alertDelivery{messageID: sent.MessageID, format: events.DeliveryFormatPhoto}, err
+}
 
-	log.LogSuccess("Token refreshed successfully")
+// RunSwapCollector polls swaps without Telegram (app.mode: collector)
+// Swaps are archived, swappers recorded for username sync and holders saved for swaps above minBTCAmount
+func RunSwapCollector(ctx context.Context, client *flashnet.Client, minBTCAmount float64) {
+	RunBigSalesBuysMonitor(ctx, nil, client, "", minBTCAmount, nil, "", nil, 0, "", nil)
 }
 
 // RunFilteredTokensMonitor for tokens and in
+// ctx - stops monitor on cancel
 // bot - Telegram for nil for
 // client - for Flashnet API
 // chatID - ID in Telegram for tokens
 // filteredTokensList - poolLpPublicKey tokens for
 // minBTCAmount - amount in BTC for
-func RunFilteredTokensMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, chatID string, filteredTokensList []string, minBTCAmount float64) {
-	log.LogInfo("Starting Filtered Tokens Monitor...", zap.Int("filteredTokensCount", len(filteredTokensList)))
-
-	// Create for 5
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	// Create for token 30
-	tokenCheckTicker := time.NewTicker(30 * time.Minute)
-	defer tokenCheckTicker.Stop()
-
-	checkAndRefreshToken(client)
-
-	for {
-		select {
-		case <-tokenCheckTicker.C:
-			checkAndRefreshToken(client)
-		case <-ticker.C:
-			// 100 swaps from AMM
-			ctx := context.Background()
-			limit := 100
-			swapsResp, err := client.GetSwaps(ctx, flashnet.GetSwapsOptions{
-				Limit: &limit, // 100 swaps
-			})
-			if err != nil {
-				log.LogError("Failed to get swaps", zap.Error(err))
-				continue
-			}
-
-			// Load from file for
-			oldSwapsResp, _ := storage.LoadSwapsResponse("big_sales_module/100_swaps.json")
-			var oldSwaps []flashnet.Swap
-			if oldSwapsResp != nil {
-				oldSwaps = oldSwapsResp.Swaps
-			}
-
-			newSwaps := findNewSwapsBig(oldSwaps, swapsResp.Swaps)
-
-			if len(newSwaps) > 0 {
-				log.LogInfo("Found new swaps for filtered monitor", zap.Int("count", len(newSwaps)))
-
-				if bot != nil && chatID != "" && len(filteredTokensList) > 0 {
-					for _, swap := range newSwaps {
-						// Check, token
-						if !isFilteredToken(swap.PoolLpPublicKey, filteredTokensList) {
-							continue
-						}
-
-						if !shouldSendSwap(swap, minBTCAmount) {
-							continue
-						}
-
-						message, tradeLink := formatSwapMessageForTelegram(client, swap)
-
-						msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
-						msg.ParseMode = tgbotapi.ModeHTML
-						msg.DisableWebPagePreview = true
-						keyboard := tgbotapi.NewInlineKeyboardMarkup(
-							tgbotapi.NewInlineKeyboardRow(
-								tgbotapi.NewInlineKeyboardButtonURL("Trade on Luminex", tradeLink),
-							),
-						)
-						msg.ReplyMarkup = keyboard
-						_, err := bot.Send(msg)
-						if err != nil {
-							log.LogError("Failed to send filtered token message", zap.Error(err), zap.String("chatID", chatID))
-						} else {
-							log.LogInfo("Sent filtered token notification", zap.String("swapID", swap.ID), zap.String("poolLpPublicKey", swap.PoolLpPublicKey))
-							// Save address in saved_holders.json
-							saveHolderFromSwap(swap)
-						}
-					}
-				}
-			}
-		}
-	}
+func RunFilteredTokensMonitor(ctx context.Context, bot *tgbotapi.BotAPI, client *flashnet.Client, chatID string, filteredTokensList []string, minBTCAmount float64) {
+	// Filtered chat is served by the same code path as big sales monitor (without main chat)
+	RunBigSalesBuysMonitor(ctx, nil, client, "", 0, bot, chatID, filteredTokensList, minBTCAmount, "", nil)
 }
@@ -0,0 +1,266 @@
+package bots_monitor
+
+// Telegram command autocomplete (setMyCommands)
+// Commands are registered per chat (BotCommandScopeChat) by each command handler:
+// filtered chat gets user commands, admin chat (API_BOT_CHAT_ID) gets admin commands as well
+// Commands of disabled features are not registered; toggling feature re-registers changed lists
+// Descriptions are in language of chat (i18n keys cmd.{name}), /lang re-registers list of chat
+// Lists are compared with Telegram every commandsSyncInterval and registered again if they differ
+// (failed registration, list changed in BotFather or by another deployment of the bot)
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/features/i18n"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// FeatureAutoBlacklist - auto-blacklist of risky tokens (/whitelist)
+	FeatureAutoBlacklist = "auto_blacklist"
+
+	// commandsSyncInterval - interval of comparing registered command lists with Telegram
+	commandsSyncInterval = time.Hour
+)
+
+// botCommand - command shown in Telegram autocomplete, description is catalog key cmd.{name}
+type botCommand struct {
+	name      string
+	adminOnly bool   // admin chat only (API_BOT_CHAT_ID, or filtered chat if not set)
+	feature   string // feature toggle, empty - always available
+}
+
+// botCommands - commands handled by RunCommandHandler (same order as in /helps, commands with help.{name} key are listed there)
+var botCommands = []botCommand{
+	{name: "flashadd"},
+	{name: "flashdel"},
+	{name: "flash"},
+	{name: "flow"},
+	{name: "cohort"},
+	{name: "distribution"},
+	{name: "export"},
+	{name: "holdersadd"},
+	{name: "top"},
+	{name: "holders"},
+	{name: "pnl"},
+	{name: "leaderboard"},
+	{name: "apr"},
+	{name: "token"},
+	{name: "price"},
+	{name: "chart"},
+	{name: "community"},
+	{name: "reach"},
+	{name: "alert"},
+	{name: "watch"},
+	{name: "unwatch"},
+	{name: "quiet"},
+	{name: "mute"},
+	{name: "unmute"},
+	{name: "lang"},
+	{name: "admins"},
+	{name: "blacklist"},
+	{name: "whitelist", adminOnly: true, feature: FeatureAutoBlacklist},
+	{name: "exclude", adminOnly: true},
+	{name: "include", adminOnly: true},
+	{name: "flashrefresh", adminOnly: true},
+	{name: "flagwallet", adminOnly: true},
+	{name: "unflagwallet", adminOnly: true},
+	{name: "label", adminOnly: true},
+	{name: "unlabel", adminOnly: true},
+	{name: "testalert", adminOnly: true},
+	{name: "audit", adminOnly: true},
+	{name: "portfolio", adminOnly: true},
+	{name: "stats"},
+	{name: "spark"},
+	{name: "whatsnew"},
+	{name: "botstats"},
+	{name: "helps"},
+}
+
+// commandAliases - other names of commands, handled as command they point to and not shown in autocomplete
+var commandAliases = map[string]string{
+	"charts": "stats",
+}
+
+// BotCommandNames returns names of all commands shown in autocomplete (user and admin), in /helps order
+func BotCommandNames() []string {
+	names := make([]string, 0, len(botCommands))
+	for _, command := range botCommands {
+		names = append(names, command.name)
+	}
+	return names
+}
+
+// CommandAliases returns other names of commands (alias -> command)
+func CommandAliases() map[string]string {
+	aliases := make(map[string]string, len(commandAliases))
+	for alias, command := range commandAliases {
+		aliases[alias] = command
+	}
+	return aliases
+}
+
+// resolveCommandAlias returns command of alias, other names unchanged
+func resolveCommandAlias(command string) string {
+	if target, exists := commandAliases[command]; exists {
+		return target
+	}
+	return command
+}
+
+// commandScope - chat whose command list is registered by bot
+type commandScope struct {
+	bot        *tgbotapi.BotAPI
+	chatID     int64
+	admin      bool
+	registered string // last registered language and command names (to skip unchanged lists)
+}
+
+var (
+	commandScopes   = make(map[string]*commandScope) // botID:chatID -> scope
+	disabledFeature = make(map[string]bool)
+	commandsMutex   sync.Mutex
+)
+
+// SetCommandFeature enables or disables commands of feature
+// Already registered chats are updated if their command list changes
+func SetCommandFeature(feature string, enabled bool) {
+	commandsMutex.Lock()
+	defer commandsMutex.Unlock()
+
+	if disabledFeature[feature] == !enabled {
+		return
+	}
+	disabledFeature[feature] = !enabled
+
+	for _, scope := range commandScopes {
+		registerScopeCommandsUnlocked(scope)
+	}
+}
+
+// registerBotCommands registers autocomplete of chats served by command handler
+// filteredChatID - user chat, apiChatID - admin chat (may be empty: filtered chat is admin chat then)
+func registerBotCommands(bot *tgbotapi.BotAPI, filteredChatID string, apiChatID string) {
+	commandsMutex.Lock()
+	defer commandsMutex.Unlock()
+
+	addScope := func(chatID string, admin bool) {
+		if chatID == "" {
+			return
+		}
+		key := fmt.Sprintf("%d:%s", bot.Self.ID, chatID)
+		scope, exists := commandScopes[key]
+		if !exists {
+			scope = &commandScope{bot: bot, chatID: parseChatIDBig(chatID)}
+			commandScopes[key] = scope
+		}
+		// Chat may be admin chat of another handler of the same bot
+		scope.admin = scope.admin || admin
+		registerScopeCommandsUnlocked(scope)
+	}
+
+	addScope(filteredChatID, apiChatID == "")
+	addScope(apiChatID, true)
+}
+
+// refreshChatCommands registers command lists of chat again (language of chat changed)
+func refreshChatCommands(chatID int64) {
+	commandsMutex.Lock()
+	defer commandsMutex.Unlock()
+
+	for _, scope := range commandScopes {
+		if scope.chatID == chatID {
+			registerScopeCommandsUnlocked(scope)
+		}
+	}
+}
+
+// syncBotCommands registers again command lists of bot that differ from lists in Telegram
+func syncBotCommands(bot *tgbotapi.BotAPI) {
+	commandsMutex.Lock()
+	defer commandsMutex.Unlock()
+
+	for _, scope := range commandScopes {
+		if scope.bot != bot {
+			continue
+		}
+		commands, _ := scopeCommandsUnlocked(scope)
+		current, err := bot.GetMyCommandsWithConfig(tgbotapi.NewGetMyCommandsWithScope(tgbotapi.NewBotCommandScopeChat(scope.chatID)))
+		if err != nil {
+			log.LogDebug("Failed to get registered bot commands",
+				zap.String("bot", bot.Self.UserName),
+				zap.Int64("chatID", scope.chatID),
+				zap.Error(err))
+			continue
+		}
+		if sameBotCommands(current, commands) {
+			continue
+		}
+
+		log.LogInfo("Bot commands differ from Telegram, registering again",
+			zap.String("bot", bot.Self.UserName),
+			zap.Int64("chatID", scope.chatID),
+			zap.Int("registered", len(current)),
+			zap.Int("expected", len(commands)))
+		scope.registered = ""
+		registerScopeCommandsUnlocked(scope)
+	}
+}
+
+// scopeCommandsUnlocked returns command list of chat and its key (language and command names)
+func scopeCommandsUnlocked(scope *commandScope) ([]tgbotapi.BotCommand, string) {
+	lang := i18n.ChatLang(scope.chatID)
+	var commands []tgbotapi.BotCommand
+	var names []string
+	for _, command := range botCommands {
+		if command.adminOnly && !scope.admin {
+			continue
+		}
+		if command.feature != "" && disabledFeature[command.feature] {
+			continue
+		}
+		commands = append(commands, tgbotapi.BotCommand{Command: command.name, Description: i18n.T(lang, "cmd."+command.name)})
+		names = append(names, command.name)
+	}
+	return commands, string(lang) + ":" + strings.Join(names, ",")
+}
+
+func sameBotCommands(a, b []tgbotapi.BotCommand) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func registerScopeCommandsUnlocked(scope *commandScope) {
+	commands, registered := scopeCommandsUnlocked(scope)
+	if registered == scope.registered {
+		return
+	}
+
+	config := tgbotapi.NewSetMyCommandsWithScope(tgbotapi.NewBotCommandScopeChat(scope.chatID), commands...)
+	if _, err := scope.bot.Request(config); err != nil {
+		log.LogWarn("Failed to register bot commands",
+			zap.String("bot", scope.bot.Self.UserName),
+			zap.Int64("chatID", scope.chatID),
+			zap.Error(err))
+		return
+	}
+	scope.registered = registered
+
+	log.LogInfo("Registered bot commands",
+		zap.String("bot", scope.bot.Self.UserName),
+		zap.Int64("chatID", scope.chatID),
+		zap.Int("count", len(commands)))
+}
@@ -0,0 +1,83 @@
+package bots_monitor
+
+// Daily BTC price history sampling (used for USD equivalents in flow and holders reports)
+// Sampling also keeps cached current price warm for USD amounts in swap alerts
+
+import (
+	"context"
+	"time"
+
+	"spark-wallet/internal/features/btc_price"
+	"spark-wallet/internal/features/holders"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// ConfigureBTCPriceFeed sets source of current BTC price (coingecko or luminex, the other one is fallback)
+// Luminex source reads price from filtered tokens and holders tickers pools
+func ConfigureBTCPriceFeed(source string) error {
+	feed, err := btc_price.NewFeed(source, btcPricePools)
+	if err != nil {
+		return err
+	}
+	btc_price.SetFeed(feed)
+	log.LogInfo("BTC price feed configured", zap.String("feed", feed.Name()))
+	return nil
+}
+
+// RunBTCPriceMonitor samples BTC price every interval and saves it as price of current day
+func RunBTCPriceMonitor(ctx context.Context, interval time.Duration) {
+	log.LogInfo("Starting BTC Price Monitor...",
+		zap.String("file", btc_price.PriceHistoryFile()),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial sample
+	sampleBTCPrice(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("BTC Price Monitor stopped")
+			return
+		case <-ticker.C:
+			sampleBTCPrice(ctx)
+		}
+	}
+}
+
+func sampleBTCPrice(ctx context.Context) {
+	price, err := btc_price.CurrentPriceUSD(ctx)
+	if err != nil {
+		log.LogWarn("Failed to sample BTC price", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	if err := btc_price.RecordPrice(time.Now(), price); err != nil {
+		log.LogError("Failed to save BTC price", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	ReportMonitorSuccess(ctx)
+	log.LogDebug("BTC price sampled", zap.Float64("priceUSD", price))
+}
+
+// btcPricePools returns pools to read BTC price from (filtered tokens, then holders tickers)
+func btcPricePools() []string {
+	var pools []string
+	if filtered, err := storage.LoadFilteredTokens(); err == nil {
+		pools = append(pools, filtered...)
+	}
+	for _, ticker := range holders.GetAllowedTickers() {
+		if poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker); err == nil {
+			pools = append(pools, poolLpPublicKey)
+		}
+	}
+	return pools
+}
@@ -3,6 +3,7 @@ package bots_monitor
 // bot — Telegram- and
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -18,7 +19,7 @@ import (
 )
 
 // RunBTCSparkMonitor by BTC Spark in time (MSK).
-func RunBTCSparkMonitor(bot *tgbotapi.BotAPI, filteredChatID string, sendTime string) {
+func RunBTCSparkMonitor(ctx context.Context, bot *tgbotapi.BotAPI, filteredChatID string, sendTime string) {
 	if bot == nil {
 		log.LogWarn("Bot is nil, BTC spark monitor not started")
 		return
@@ -61,7 +62,7 @@ func RunBTCSparkMonitor(bot *tgbotapi.BotAPI, filteredChatID string, sendTime st
 			),
 		)
 
-		chartPath, err := tg_charts.GenerateBTCSparkChart()
+		chartPath, err := tg_charts.GenerateBTCSparkChart(parseChatIDBig(filteredChatID))
 		if err != nil {
 			log.LogWarn("Failed to generate BTC spark chart", zap.Error(err))
 			msg := tgbotapi.NewMessage(parseChatIDBig(filteredChatID), sparkMessage)
@@ -137,23 +138,33 @@ func RunBTCSparkMonitor(bot *tgbotapi.BotAPI, filteredChatID string, sendTime st
 		zap.Time("nextSend", nextSend),
 		zap.Duration("delay", delay))
 
+	log.LogInfo("BTC spark monitor started successfully",
+		zap.String("sendTime", sendTime),
+		zap.Time("nextSend", nextSend),
+		zap.Duration("delay", delay))
+
 	firstTimer := time.NewTimer(delay)
-	go func() {
-		<-firstTimer.C
-		sendBTCReserve(true) // check = true for
+	select {
+	case <-ctx.Done():
+		firstTimer.Stop()
+		log.LogInfo("BTC spark monitor stopped")
+		return
+	case <-firstTimer.C:
+	}
+	sendBTCReserve(true) // check = true for
 
-		ticker := time.NewTicker(1 * time.Hour)
-		defer ticker.Stop()
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
 
-		for range ticker.C {
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("BTC spark monitor stopped")
+			return
+		case <-ticker.C:
 			sendBTCReserve(true) // check = true for
 		}
-	}()
-
-	log.LogInfo("BTC spark monitor started successfully",
-		zap.String("sendTime", sendTime),
-		zap.Time("nextSend", nextSend),
-		zap.Duration("delay", delay))
+	}
 }
 
 // CheckAndSendBTCSparkOnStartup BTC and if -
@@ -205,7 +216,7 @@ func CheckAndSendBTCSparkOnStartup(bot *tgbotapi.BotAPI, filteredChatID string)
 		),
 	)
 
-	chartPath, err := tg_charts.GenerateBTCSparkChart()
+	chartPath, err := tg_charts.GenerateBTCSparkChart(parseChatIDBig(filteredChatID))
 	if err != nil {
 		log.LogWarn("Failed to generate BTC spark chart on startup", zap.Error(err))
 		msg := tgbotapi.NewMessage(parseChatIDBig(filteredChatID), sparkMessage)
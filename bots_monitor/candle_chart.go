@@ -0,0 +1,84 @@
+package bots_monitor
+
+// /chart {ticker} {timeframe} - candlestick chart of token price from archived swaps (1m, 5m or 1h candles)
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/candles"
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/features/tg_charts"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// handleChartCommand /chart {ticker} {timeframe}
+func handleChartCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string, timeframe candles.Timeframe) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	ticker = strings.ToUpper(ticker)
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker for chart",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply(fmt.Sprintf("Ticker {%s} not found. Make sure the token has been traded before.", ticker))
+		return
+	}
+
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		log.LogError("Failed to get pool data for chart",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	tokenMeta, _, _ := poolData.TokenSide()
+
+	decimals := tokenMeta.Decimals
+	if decimals == 0 {
+		decimals = 8 // Default value
+	}
+
+	points, err := candles.LoadCandles(poolLpPublicKey, decimals, timeframe, time.Now())
+	if err != nil {
+		log.LogError("Failed to build candles", zap.String("ticker", ticker), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	chartPath, err := tg_charts.GenerateCandleChart(ticker, timeframe, points)
+	if err != nil {
+		log.LogWarn("Failed to generate candle chart",
+			zap.String("ticker", ticker),
+			zap.String("timeframe", timeframe.Name),
+			zap.Error(err))
+		reply(fmt.Sprintf("Not enough swaps of {%s} for %s chart yet.", ticker, timeframe.Name))
+		return
+	}
+
+	photo := tgbotapi.NewPhoto(message.Chat.ID, tgbotapi.FilePath(chartPath))
+	photo.Caption = fmt.Sprintf("{%s} %s candles, price in sats per token", ticker, timeframe.Name)
+	photo.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(photo); err != nil {
+		log.LogError("Failed to send candle chart", zap.String("ticker", ticker), zap.Error(err))
+		return
+	}
+
+	log.LogInfo("Candle chart sent",
+		zap.String("ticker", ticker),
+		zap.String("timeframe", timeframe.Name),
+		zap.Int("candles", len(points)),
+		zap.String("chatID", formatChatID(message.Chat.ID)))
+}
@@ -0,0 +1,66 @@
+package bots_monitor
+
+// Language of bot messages in chat (see internal/features/i18n)
+// /lang - current language, /lang {en|ru} - set language of chat, /lang reset - language from config
+// Swap alerts, /flow, /cohort, /distribution, /leaderboard, /helps and command autocomplete follow language of chat
+
+import (
+	"strings"
+
+	"spark-wallet/internal/features/i18n"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// handleLangCommand /lang {en|ru|reset}, /lang - current language of chat
+func handleLangCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args string) {
+	chat := message.Chat.ID
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(chat, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	current := i18n.ChatLang(chat)
+	codes := make([]string, 0, len(i18n.Languages))
+	for _, lang := range i18n.Languages {
+		codes = append(codes, string(lang))
+	}
+	usage := i18n.T(current, "lang.usage", strings.Join(codes, "|"))
+
+	if args == "" {
+		reply(i18n.T(current, "lang.current", current.Name()) + "\n" + usage)
+		return
+	}
+
+	var lang i18n.Lang
+	if !strings.EqualFold(args, "reset") {
+		var err error
+		lang, err = i18n.ParseLang(args)
+		if err != nil {
+			reply(usage)
+			return
+		}
+	}
+
+	if err := i18n.SetChatLang(chat, lang); err != nil {
+		log.LogError("Failed to set chat language",
+			zap.String("chatID", formatChatID(chat)),
+			zap.String("args", args),
+			zap.Error(err))
+		reply(i18n.T(current, "lang.error"))
+		return
+	}
+
+	// Command descriptions of autocomplete follow language of chat
+	refreshChatCommands(chat)
+
+	updated := i18n.ChatLang(chat)
+	log.LogInfo("Chat language set",
+		zap.String("chatID", formatChatID(chat)),
+		zap.String("lang", string(updated)))
+	reply(i18n.T(updated, "lang.set", updated.Name()))
+}
@@ -3,27 +3,52 @@ package bots_monitor
 // Package bot contains Telegram
 
 import (
+	"context"
 	"fmt"
+	"html"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"spark-wallet/internal/clients_api/flashnet"
 	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/candles"
+	"spark-wallet/internal/features/changelog"
 	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/pnl"
+	"spark-wallet/internal/features/pool_fees"
+	"spark-wallet/internal/features/price_alerts"
+	"spark-wallet/internal/features/risk"
 	"spark-wallet/internal/features/tg_charts"
+	"spark-wallet/internal/features/trade_sizes"
+	"spark-wallet/internal/infra/buildinfo"
 	storage "spark-wallet/internal/infra/fs"
 	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/infra/scheduler"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
 )
 
+// stopUpdatesOnce - several handlers may share one bot, updates receiver is stopped once per bot
+var stopUpdatesOnce sync.Map // *tgbotapi.BotAPI -> *sync.Once
+
+func stopReceivingUpdates(bot *tgbotapi.BotAPI) {
+	once, _ := stopUpdatesOnce.LoadOrStore(bot, &sync.Once{})
+	once.(*sync.Once).Do(bot.StopReceivingUpdates)
+}
+
 // RunCommandHandler for Telegram
 // filteredChatID - ID (filtered_chat_id)
 // client - Flashnet API for first buy
 // apiBotChatID - optional second chat ID to listen to (for /exclude and /include commands)
-func RunCommandHandler(bot *tgbotapi.BotAPI, filteredChatID string, client *flashnet.Client, apiBotChatID ...string) {
+func RunCommandHandler(ctx context.Context, bot *tgbotapi.BotAPI, filteredChatID string, client *flashnet.Client, apiBotChatID ...string) {
 	if bot == nil {
 		log.LogWarn("Bot is nil, command handler not started")
 		return
@@ -47,12 +72,43 @@ func RunCommandHandler(bot *tgbotapi.BotAPI, filteredChatID string, client *flas
 		log.LogInfo("Starting command handler", zap.String("filteredChatID", filteredChatID))
 	}
 
+	// Autocomplete of served chats matches commands handled below
+	registerBotCommands(bot, filteredChatID, apiChatID)
+
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
 	updates := bot.GetUpdatesChan(u)
 
-	for update := range updates {
+	syncTicker := time.NewTicker(commandsSyncInterval)
+	defer syncTicker.Stop()
+
+	for {
+		var update tgbotapi.Update
+		var ok bool
+		select {
+		case <-ctx.Done():
+			stopReceivingUpdates(bot)
+			log.LogInfo("Command handler stopped", zap.String("filteredChatID", filteredChatID))
+			return
+		case <-syncTicker.C:
+			syncBotCommands(bot)
+			continue
+		case update, ok = <-updates:
+			if !ok {
+				return
+			}
+		}
+
+		// Inline queries (@bot SOON) come from any chat, chat filter does not apply
+		if update.InlineQuery != nil {
+			go func() {
+				defer recoverHandlerPanic("inline_query")
+				handleInlineQuery(bot, update.InlineQuery)
+			}()
+			continue
+		}
+
 		if update.Message == nil {
 			continue
 		}
@@ -74,9 +130,15 @@ func RunCommandHandler(bot *tgbotapi.BotAPI, filteredChatID string, client *flas
 			continue
 		}
 
-		if update.Message.IsCommand() {
-			command := update.Message.Command()
+		if !update.Message.IsCommand() {
+			continue
+		}
+
+		// Panic of one command is recovered here, handler keeps polling updates
+		func() {
+			command := resolveCommandAlias(update.Message.Command())
 			args := update.Message.CommandArguments()
+			defer recoverHandlerPanic("/" + command)
 
 			log.LogDebug("Received command",
 				zap.String("command", command),
@@ -84,16 +146,18 @@ func RunCommandHandler(bot *tgbotapi.BotAPI, filteredChatID string, client *flas
 				zap.String("chatID", chatIDStr),
 				zap.String("username", update.Message.From.UserName))
 
+			// Commands above member level need role of sender, separate admin chat is trusted (see roles.go)
+			if !authorizeCommand(bot, update.Message, command, isFromApiChat && !isFromFilteredChat) {
+				return
+			}
+
 			// /flashadd {token}
 			// /flashadd SOON or /flashadd@botname SOON
 			if command == "flashadd" {
 				// Parse "SOON" -> ticker
 				ticker := strings.TrimSpace(args)
 				if ticker == "" {
-					msg := tgbotapi.NewMessage(update.Message.Chat.ID,
-						"Usage: /flashadd {ticker}\n\nExample: /flashadd SOON")
-					msg.ReplyToMessageID = update.Message.MessageID
-					bot.Send(msg)
+					replyCommandText(bot, update.Message, "usage.ticker", "flashadd")
 				} else {
 					handleAddTokenCommand(bot, update.Message, ticker)
 				}
@@ -105,25 +169,33 @@ func RunCommandHandler(bot *tgbotapi.BotAPI, filteredChatID string, client *flas
 				// Parse "SOON" -> ticker
 				ticker := strings.TrimSpace(args)
 				if ticker == "" {
-					msg := tgbotapi.NewMessage(update.Message.Chat.ID,
-						"Usage: /flashdel {ticker}\n\nExample: /flashdel SOON")
-					msg.ReplyToMessageID = update.Message.MessageID
-					bot.Send(msg)
+					replyCommandText(bot, update.Message, "usage.ticker", "flashdel")
 				} else {
 					handleDeleteTokenCommand(bot, update.Message, ticker)
 				}
 			}
 
+			// /flashrefresh {ticker or pool} (admin chat)
+			// /flashrefresh SOON or /flashrefresh@botname SOON
+			if command == "flashrefresh" {
+				isAdminChat := isFromApiChat || (apiChatID == "" && isFromFilteredChat)
+				token := strings.TrimSpace(args)
+				if !isAdminChat {
+					replyCommandText(bot, update.Message, "command.admin_only")
+				} else if token == "" {
+					replyCommandText(bot, update.Message, "usage.flashrefresh")
+				} else {
+					handleRefreshTokenCommand(bot, update.Message, token)
+				}
+			}
+
 			// /flash {ticker} {date}
 			// /flash SOON 0812 or /flash@botname SOON 0812
 			if command == "flash" {
 				// Parse "SOON 0812" -> ticker and date
 				parts := strings.Fields(args)
 				if len(parts) < 2 {
-					msg := tgbotapi.NewMessage(update.Message.Chat.ID,
-						"Usage: /flash {ticker} {date}\n\nExample: /flash SOON 0812\n\nDate format: DDMM (e.g., 0812 for December 8)")
-					msg.ReplyToMessageID = update.Message.MessageID
-					bot.Send(msg)
+					replyCommandText(bot, update.Message, "usage.flash", holders.ReportDateFormats)
 				} else {
 					ticker := strings.TrimSpace(parts[0])
 					dateStr := strings.TrimSpace(parts[1])
@@ -131,16 +203,13 @@ func RunCommandHandler(bot *tgbotapi.BotAPI, filteredChatID string, client *flas
 				}
 			}
 
-			// /flow {ticker} {date}
-			// /flow SOON 0912 or /flow@botname SOON 0912
+			// /flow {ticker} {date|range}
+			// /flow SOON 0912, /flow SOON 0112-0712 or /flow@botname SOON month
 			if command == "flow" {
 				// Parse "SOON 0912" -> ticker and date
 				parts := strings.Fields(args)
 				if len(parts) < 2 {
-					msg := tgbotapi.NewMessage(update.Message.Chat.ID,
-						"Usage: /flow {ticker} {date}\n\nExample: /flow SOON 0912\n\nDate format: DDMM (e.g., 0912 for December 9)")
-					msg.ReplyToMessageID = update.Message.MessageID
-					bot.Send(msg)
+					replyCommandText(bot, update.Message, "usage.flow", holders.ReportDateFormats, holders.ReportRangeFormats)
 				} else {
 					ticker := strings.TrimSpace(parts[0])
 					dateStr := strings.TrimSpace(parts[1])
@@ -148,14 +217,219 @@ func RunCommandHandler(bot *tgbotapi.BotAPI, filteredChatID string, client *flas
 				}
 			}
 
+			// /cohort {ticker} {week} - first-time and returning buyers of week with chart
+			// /cohort SOON, /cohort SOON 2025-W49 or /cohort SOON last
+			if command == "cohort" {
+				parts := strings.Fields(args)
+				if len(parts) < 1 || len(parts) > 2 {
+					replyCommandText(bot, update.Message, "usage.cohort", holders.CohortWeekFormats)
+				} else {
+					weekStr := ""
+					if len(parts) == 2 {
+						weekStr = parts[1]
+					}
+					handleCohortReportCommand(bot, update.Message, strings.TrimSpace(parts[0]), weekStr)
+				}
+			}
+
+			// /distribution {ticker} {24h|7d} - swaps of token by BTC size with histogram
+			if command == "distribution" {
+				fields := strings.Fields(args)
+				period := trade_sizes.Period24h
+				var err error
+				if len(fields) == 2 {
+					period, err = trade_sizes.ParsePeriod(fields[1])
+				}
+				if len(fields) == 0 || len(fields) > 2 || err != nil {
+					replyCommandText(bot, update.Message, "usage.distribution")
+				} else {
+					handleDistributionCommand(bot, update.Message, fields[0], period)
+				}
+			}
+
+			// /export {ticker} {from} {to} - holders changes as CSV
+			// /export SOON 0112 0712 or /export SOON week
+			if command == "export" {
+				parts := strings.Fields(args)
+				if len(parts) < 2 || len(parts) > 3 {
+					replyCommandText(bot, update.Message, "usage.export", holders.ExportRangeFormats, holders.ReportDateFormats)
+				} else {
+					handleExportCommand(bot, update.Message, strings.TrimSpace(parts[0]), parts[1:])
+				}
+			}
+
+			// /holdersadd {ticker} - start holders tracking for token
+			if command == "holdersadd" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.ticker", "holdersadd")
+				} else {
+					handleHoldersAddCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /top {ticker} - top holders of tracked token
+			if command == "top" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.ticker", "top")
+				} else {
+					handleTopHoldersCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /holders {ticker} - live state of holders module of tracked token
+			if command == "holders" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.ticker", "holders")
+				} else {
+					handleHoldersSummaryCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /pnl {ticker} {wallet-suffix} - wallet PnL in token
+			if command == "pnl" {
+				parts := strings.Fields(args)
+				if len(parts) < 2 {
+					replyCommandText(bot, update.Message, "usage.pnl")
+				} else {
+					ticker := strings.TrimSpace(parts[0])
+					walletSuffix := strings.TrimSpace(parts[1])
+					handlePnLCommand(bot, update.Message, ticker, walletSuffix, client)
+				}
+			}
+
+			// /leaderboard {ticker} - wallets with most realized PnL in token over last 7 days
+			if command == "leaderboard" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" || len(strings.Fields(ticker)) > 1 {
+					replyCommandText(bot, update.Message, "usage.ticker", "leaderboard")
+				} else {
+					handleLeaderboardCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /apr {ticker} - LP APR estimate for token pool
+			if command == "apr" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.ticker", "apr")
+				} else {
+					handleAPRCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /token {ticker} - token card with price risk figures
+			if command == "token" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.ticker", "token")
+				} else {
+					handleTokenCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /price {ticker} - price, 24h change and marketcap with 7-day sparkline
+			if command == "price" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.ticker", "price")
+				} else {
+					handlePriceCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /chart {ticker} {timeframe} - candlestick chart of token price
+			if command == "chart" {
+				fields := strings.Fields(args)
+				timeframe := candles.Timeframe5m
+				var err error
+				if len(fields) == 2 {
+					timeframe, err = candles.ParseTimeframe(fields[1])
+				}
+				if len(fields) == 0 || len(fields) > 2 || err != nil {
+					replyCommandText(bot, update.Message, "usage.chart")
+				} else {
+					handleChartCommand(bot, update.Message, fields[0], timeframe)
+				}
+			}
+
+			// /community {ticker} - community chat member count over price and volume
+			if command == "community" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.ticker", "community")
+				} else {
+					handleCommunityCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /reach {ticker} - chats and audience reached by token alerts
+			if command == "reach" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.ticker", "reach")
+				} else {
+					handleReachCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /alert {ticker} {above|below} {price_usd} [repeat], /alert list, /alert del {id}
+			if command == "alert" {
+				handleAlertCommand(bot, update.Message, args)
+			}
+
+			// /watch {wallet} - notify about every swap of wallet, /watch - watched wallets
+			if command == "watch" {
+				handleWatchCommand(bot, update.Message, strings.TrimSpace(args))
+			}
+
+			// /unwatch {wallet}
+			if command == "unwatch" {
+				wallet := strings.TrimSpace(args)
+				if wallet == "" {
+					replyCommandText(bot, update.Message, "usage.unwatch")
+				} else {
+					handleUnwatchCommand(bot, update.Message, wallet)
+				}
+			}
+
+			// /quiet - quiet hours of chat, /quiet {HH:MM-HH:MM} or /quiet off
+			if command == "quiet" {
+				handleQuietCommand(bot, update.Message, strings.TrimSpace(args))
+			}
+
+			// /mute {ticker} {duration} - drop alerts of token in chat, /mute - muted tokens
+			if command == "mute" {
+				handleMuteCommand(bot, update.Message, args)
+			}
+
+			// /unmute {ticker}
+			if command == "unmute" {
+				ticker := strings.TrimSpace(args)
+				if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.unmute")
+				} else {
+					handleUnmuteCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /lang {en|ru} - language of bot messages in chat, /lang - current language
+			if command == "lang" {
+				handleLangCommand(bot, update.Message, strings.TrimSpace(args))
+			}
+
+			// /admins - owners and admins, /admins add {userID} or reply, /admins del {userID}
+			if command == "admins" {
+				handleAdminsCommand(bot, update.Message, args, isFromApiChat && !isFromFilteredChat)
+			}
+
 			// /exclude {ticker} - add token to blacklist (API_BOT_CHAT_ID only)
 			if command == "exclude" {
 				ticker := strings.TrimSpace(args)
 				if ticker == "" {
-					msg := tgbotapi.NewMessage(update.Message.Chat.ID,
-						"Usage: /exclude {ticker}\n\nExample: /exclude SOON")
-					msg.ReplyToMessageID = update.Message.MessageID
-					bot.Send(msg)
+					replyCommandText(bot, update.Message, "usage.ticker", "exclude")
 				} else {
 					handleExcludeTokenCommand(bot, update.Message, ticker)
 				}
@@ -165,18 +439,91 @@ func RunCommandHandler(bot *tgbotapi.BotAPI, filteredChatID string, client *flas
 			if command == "include" {
 				ticker := strings.TrimSpace(args)
 				if ticker == "" {
-					msg := tgbotapi.NewMessage(update.Message.Chat.ID,
-						"Usage: /include {ticker}\n\nExample: /include SOON")
-					msg.ReplyToMessageID = update.Message.MessageID
-					bot.Send(msg)
+					replyCommandText(bot, update.Message, "usage.ticker", "include")
 				} else {
 					handleIncludeTokenCommand(bot, update.Message, ticker)
 				}
 			}
 
-			// /stats or /charts
+			// /blacklist - manual and auto-blacklisted tokens
+			if command == "blacklist" {
+				handleBlacklistCommand(bot, update.Message)
+			}
+
+			// /whitelist {ticker} - admin override of auto-blacklist (API_BOT_CHAT_ID, or filtered chat if not set)
+			if command == "whitelist" {
+				isAdminChat := isFromApiChat || (apiChatID == "" && isFromFilteredChat)
+				ticker := strings.TrimSpace(args)
+				if !isAdminChat {
+					replyCommandText(bot, update.Message, "command.admin_only")
+				} else if ticker == "" {
+					replyCommandText(bot, update.Message, "usage.ticker", "whitelist")
+				} else {
+					handleWhitelistCommand(bot, update.Message, ticker)
+				}
+			}
+
+			// /flagwallet {wallet} {team|rug|other} [note] - flag funding source wallet (admin chat), /flagwallet - flagged wallets
+			// /unflagwallet {wallet}
+			if command == "flagwallet" || command == "unflagwallet" {
+				isAdminChat := isFromApiChat || (apiChatID == "" && isFromFilteredChat)
+				if !isAdminChat {
+					replyCommandText(bot, update.Message, "command.admin_only")
+				} else if command == "flagwallet" {
+					handleFlagWalletCommand(bot, update.Message, strings.TrimSpace(args))
+				} else {
+					handleUnflagWalletCommand(bot, update.Message, strings.TrimSpace(args))
+				}
+			}
+
+			// /label {wallet} {name} - label wallet in alerts and holders reports (admin chat), /label - labeled wallets
+			// /unlabel {wallet}
+			if command == "label" || command == "unlabel" {
+				isAdminChat := isFromApiChat || (apiChatID == "" && isFromFilteredChat)
+				if !isAdminChat {
+					replyCommandText(bot, update.Message, "command.admin_only")
+				} else if command == "label" {
+					handleLabelCommand(bot, update.Message, strings.TrimSpace(args))
+				} else {
+					handleUnlabelCommand(bot, update.Message, strings.TrimSpace(args))
+				}
+			}
+
+			// /testalert {route} [ticker] - test buy and sell alerts through route (admin chat)
+			if command == "testalert" {
+				isAdminChat := isFromApiChat || (apiChatID == "" && isFromFilteredChat)
+				if !isAdminChat {
+					replyCommandText(bot, update.Message, "command.admin_only")
+				} else {
+					handleTestAlertCommand(ctx, bot, update.Message, client, args)
+				}
+			}
+
+			// /audit {swapID} - whether swap was seen and where its alerts went (admin chat)
+			if command == "audit" {
+				isAdminChat := isFromApiChat || (apiChatID == "" && isFromFilteredChat)
+				if !isAdminChat {
+					replyCommandText(bot, update.Message, "command.admin_only")
+				} else if swapID := strings.TrimSpace(args); swapID == "" || strings.ContainsAny(swapID, " \n") {
+					replyCommandText(bot, update.Message, "usage.audit")
+				} else {
+					handleAuditCommand(bot, update.Message, swapID)
+				}
+			}
+
+			// /portfolio - balances of own wallet (PUBLIC_KEY) with 24h/7d change and chart (admin chat)
+			if command == "portfolio" {
+				isAdminChat := isFromApiChat || (apiChatID == "" && isFromFilteredChat)
+				if !isAdminChat {
+					replyCommandText(bot, update.Message, "command.admin_only")
+				} else {
+					handlePortfolioCommand(bot, update.Message)
+				}
+			}
+
+			// /stats or /charts (alias)
 			// /stats, /charts or /stats@botname, /charts@botname
-			if command == "stats" || command == "charts" {
+			if command == "stats" {
 				handleStatsCommand(bot, update.Message)
 			}
 
@@ -191,26 +538,27 @@ func RunCommandHandler(bot *tgbotapi.BotAPI, filteredChatID string, client *flas
 			if command == "spark" {
 				handleSparkCommand(bot, update.Message)
 			}
-		}
+
+			// /whatsnew [version] - changes of latest (or given) release
+			if command == "whatsnew" {
+				handleWhatsNewCommand(bot, update.Message, strings.TrimSpace(args))
+			}
+
+			// /botstats - version, build info and monitors state
+			if command == "botstats" {
+				handleBotStatsCommand(bot, update.Message)
+			}
+		}()
 	}
 }
 
 // handleHelpCommand /helps
 func handleHelpCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
-	helpText := "" +
-		"Commands:\n" +
-		"• <code>/flashadd {ticker}</code> - добавляет токен в big sales\n" +
-		"• <code>/flashdel {ticker}</code> - удаляет токен из big sales\n" +
-		"• <code>/flash {ticker} {date}</code> - движение холдеров в токене\n" +
-		"• <code>/flow {ticker} {date}</code> - отчет о коэффициенте покупок/продаж\n" +
-		"• <code>/stats</code> - общая статистика по рынку spark\n" +
-		"• <code>/spark</code> - график резервов btc в spark\n" +
-		"\n" +
-		"<a href=\"https:// t.me/+5jHhbz8ZlDIyNWZi\">Big sales</a> / flashnet"
+	helpText := formatHelpText(i18n.ChatLang(message.Chat.ID))
 
 	// Try multiple paths for asty1.jpeg (same as spark.png in stats_chart.go)
 	photoPaths := []string{
-		"etc/telegram/asty1.jpeg",
+		paths.Asset("telegram", "asty1.jpeg"),
 		"./etc/telegram/asty1.jpeg",
 		"../etc/telegram/asty1.jpeg",
 		"../../etc/telegram/asty1.jpeg",
@@ -264,178 +612,884 @@ func handleHelpCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
 		zap.String("username", message.From.UserName))
 }
 
-// handleAddTokenCommand /flashadd {token}
-func handleAddTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
-
-	// poolLpPublicKey by ticker in saved_ticket.json
-	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+// commandText returns catalog text in language of chat of command message
+func commandText(message *tgbotapi.Message, key string, args ...interface{}) string {
+	return i18n.T(i18n.ChatLang(message.Chat.ID), key, args...)
+}
+
+// replyCommandText replies to command message with catalog text in language of chat
+func replyCommandText(bot *tgbotapi.BotAPI, message *tgbotapi.Message, key string, args ...interface{}) {
+	msg := tgbotapi.NewMessage(message.Chat.ID, commandText(message, key, args...))
+	msg.ReplyToMessageID = message.MessageID
+	bot.Send(msg)
+}
+
+// formatHelpText lists user commands of botCommands in language of chat (lines are catalog keys help.{name})
+func formatHelpText(lang i18n.Lang) string {
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "help.title") + "\n")
+	for _, command := range botCommands {
+		if command.adminOnly || !i18n.Has("help."+command.name) {
+			continue
+		}
+		text.WriteString("• " + i18n.T(lang, "help."+command.name) + "\n")
+	}
+	text.WriteString("\n<a href=\"https:// t.me/+5jHhbz8ZlDIyNWZi\">Big sales</a> / flashnet")
+	return text.String()
+}
+
+// handleAddTokenCommand /flashadd {token}
+func handleAddTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+
+	// poolLpPublicKey by ticker in saved_ticket.json
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+
+		// - ticker
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "flashadd.unavailable", ticker))
+		msg.ReplyToMessageID = message.MessageID
+		_, err := bot.Send(msg)
+		if err != nil {
+			log.LogError("Failed to send error message", zap.Error(err))
+		}
+		return
+	}
+
+	// Check, token in
+	existingTokens, err := storage.LoadFilteredTokens()
+	if err == nil {
+		for _, existingToken := range existingTokens {
+			if strings.TrimSpace(existingToken) == poolLpPublicKey {
+				msg := tgbotapi.NewMessage(message.Chat.ID,
+					commandText(message, "flashadd.exists", ticker))
+				msg.ReplyToMessageID = message.MessageID
+				_, err := bot.Send(msg)
+				if err != nil {
+					log.LogError("Failed to send message", zap.Error(err))
+				}
+				log.LogDebug("Token already in filtered list",
+					zap.String("ticker", ticker),
+					zap.String("poolLpPublicKey", poolLpPublicKey))
+				// Tokens added before asset matching get their asset address now
+				saveFilteredTokenAsset(poolLpPublicKey)
+				return
+			}
+		}
+	}
+
+	err = storage.AddFilteredToken(poolLpPublicKey)
+	if err != nil {
+		log.LogError("Failed to add filtered token",
+			zap.String("ticker", ticker),
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.Error(err))
+
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "command.error"))
+		msg.ReplyToMessageID = message.MessageID
+		_, err := bot.Send(msg)
+		if err != nil {
+			log.LogError("Failed to send error message", zap.Error(err))
+		}
+		return
+	}
+
+	// Check, token
+	verifyTokens, err := storage.LoadFilteredTokens()
+	if err == nil {
+		found := false
+		for _, token := range verifyTokens {
+			if strings.TrimSpace(token) == poolLpPublicKey {
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.LogError("Token was not saved to file after AddFilteredToken",
+				zap.String("ticker", ticker),
+				zap.String("poolLpPublicKey", poolLpPublicKey))
+			msg := tgbotapi.NewMessage(message.Chat.ID,
+				commandText(message, "command.error"))
+			msg.ReplyToMessageID = message.MessageID
+			bot.Send(msg)
+			return
+		}
+	}
+
+	saveFilteredTokenAsset(poolLpPublicKey)
+
+	msg := tgbotapi.NewMessage(message.Chat.ID,
+		commandText(message, "flashadd.added", ticker))
+	msg.ReplyToMessageID = message.MessageID
+	_, err = bot.Send(msg)
+	if err != nil {
+		log.LogError("Failed to send success message", zap.Error(err))
+	}
+
+	log.LogInfo("Token added to filtered list via command",
+		zap.String("ticker", ticker),
+		zap.String("poolLpPublicKey", poolLpPublicKey),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// saveFilteredTokenAsset saves token asset address of filtered pool, so swaps of token in its other pools are filtered too
+// Best-effort: without asset address token is still matched by pool
+func saveFilteredTokenAsset(poolLpPublicKey string) {
+	pool, err := luminex.DefaultClient().GetPool(context.Background(), poolLpPublicKey)
+	if err != nil {
+		log.LogWarn("Failed to resolve token asset of filtered pool, matching by pool only",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.Error(err))
+		return
+	}
+	assetAddress := pool.TokenSideAddress()
+	if assetAddress == "" || assetAddress == flashnet.NativeTokenAddress {
+		return
+	}
+	if err := storage.SetFilteredTokenAsset(poolLpPublicKey, assetAddress); err != nil {
+		log.LogError("Failed to save token asset of filtered pool",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.String("assetAddress", assetAddress),
+			zap.Error(err))
+	}
+}
+
+// handleDeleteTokenCommand /flashdel {token}
+func handleDeleteTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	// poolLpPublicKey by ticker in saved_ticket.json
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+
+		// - ticker
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "flashdel.unavailable", ticker))
+		msg.ReplyToMessageID = message.MessageID
+		_, err := bot.Send(msg)
+		if err != nil {
+			log.LogError("Failed to send error message", zap.Error(err))
+		}
+		return
+	}
+
+	err = storage.RemoveFilteredToken(poolLpPublicKey)
+	if err != nil {
+		if err.Error() == "token not found in list" {
+			msg := tgbotapi.NewMessage(message.Chat.ID,
+				commandText(message, "flashdel.missing", ticker))
+			msg.ReplyToMessageID = message.MessageID
+			_, err := bot.Send(msg)
+			if err != nil {
+				log.LogError("Failed to send message", zap.Error(err))
+			}
+			log.LogDebug("Token not found in filtered list",
+				zap.String("ticker", ticker),
+				zap.String("poolLpPublicKey", poolLpPublicKey))
+		} else {
+			log.LogError("Failed to remove filtered token",
+				zap.String("ticker", ticker),
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+
+			msg := tgbotapi.NewMessage(message.Chat.ID,
+				commandText(message, "command.error"))
+			msg.ReplyToMessageID = message.MessageID
+			_, err := bot.Send(msg)
+			if err != nil {
+				log.LogError("Failed to send error message", zap.Error(err))
+			}
+		}
+		return
+	}
+
+	msg := tgbotapi.NewMessage(message.Chat.ID,
+		commandText(message, "flashdel.removed", ticker))
+	msg.ReplyToMessageID = message.MessageID
+	_, err = bot.Send(msg)
+	if err != nil {
+		log.LogError("Failed to send success message", zap.Error(err))
+	}
+
+	log.LogInfo("Token removed from filtered list via command",
+		zap.String("ticker", ticker),
+		zap.String("poolLpPublicKey", poolLpPublicKey),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// handleRefreshTokenCommand /flashrefresh {ticker or pool} - requests token metadata (ticker, name, decimals) from Luminex again
+func handleRefreshTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, token string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ReplyToMessageID = message.MessageID
+		if _, err := bot.Send(msg); err != nil {
+			log.LogError("Failed to send message", zap.Error(err))
+		}
+	}
+
+	// Pool address is accepted for renamed tickers (new ticker is not cached yet)
+	poolLpPublicKey := token
+	if !isHexPublicKey(token) {
+		var err error
+		poolLpPublicKey, err = storage.FindPoolLpPublicKeyByTicker(token)
+		if err != nil {
+			log.LogWarn("Failed to find token by ticker", zap.String("ticker", token), zap.Error(err))
+			reply(commandText(message, "flashrefresh.not_found", token))
+			return
+		}
+	}
+
+	previous, metadata, err := luminex.RefreshTokenMetadata(poolLpPublicKey)
+	if err != nil {
+		log.LogWarn("Failed to refresh token metadata",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.Error(err))
+		reply(commandText(message, "flashrefresh.unavailable"))
+		return
+	}
+
+	decimals := commandText(message, "flashrefresh.decimals_unknown")
+	if metadata.Decimals > 0 {
+		decimals = fmt.Sprintf("%d", metadata.Decimals)
+	}
+	text := commandText(message, "flashrefresh.done", metadata.Ticker, metadata.Name, decimals)
+	if previous != nil && (previous.Ticker != metadata.Ticker || previous.Name != metadata.Name) {
+		text += commandText(message, "flashrefresh.was", previous.Ticker, previous.Name)
+	}
+	reply(text)
+
+	log.LogInfo("Token metadata refreshed via command",
+		zap.String("poolLpPublicKey", poolLpPublicKey),
+		zap.String("ticker", metadata.Ticker),
+		zap.Int("decimals", metadata.Decimals),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// handleHoldersAddCommand /holdersadd {ticker}
+func handleHoldersAddCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	// Check, token is known (saved_ticket.json)
+	if _, err := storage.FindPoolLpPublicKeyByTicker(ticker); err != nil {
+		log.LogWarn("Failed to find token by ticker for holders tracking",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "command.ticker_not_found", ticker))
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	added, err := holders.AddTrackedTicker(ticker)
+	if err != nil {
+		log.LogError("Failed to add tracked ticker",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "command.error"))
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	text := commandText(message, "holdersadd.added", strings.ToUpper(ticker))
+	if !added {
+		text = commandText(message, "holdersadd.exists", strings.ToUpper(ticker))
+	}
+	msg := tgbotapi.NewMessage(message.Chat.ID, text)
+	msg.ReplyToMessageID = message.MessageID
+	bot.Send(msg)
+
+	log.LogInfo("Holders tracking ticker added via command",
+		zap.String("ticker", ticker),
+		zap.Bool("added", added),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// handlePnLCommand /pnl {ticker} {wallet-suffix}
+func handlePnLCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string, walletSuffix string, client *flashnet.Client) {
+	report, err := pnl.GeneratePnLReport(client, ticker, walletSuffix)
+	if err != nil {
+		log.LogError("Failed to generate PnL report",
+			zap.String("ticker", ticker),
+			zap.String("walletSuffix", walletSuffix),
+			zap.Error(err))
+
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "command.pnl_failed", err.Error()))
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, report)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send PnL report", zap.Error(err))
+		return
+	}
+
+	log.LogInfo("PnL report sent via command",
+		zap.String("ticker", ticker),
+		zap.String("walletSuffix", walletSuffix),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// handleAPRCommand /apr {ticker}
+func handleAPRCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker for APR",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "command.ticker_not_found", ticker))
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	// Live pool details, not saved: samples are taken hourly by fees monitor only
+	estimate, err := pool_fees.EstimateCurrentAPR(poolLpPublicKey)
+	if err != nil {
+		log.LogError("Failed to estimate APR",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "command.error"))
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("<b>LP APR</b>: {%s}\n", strings.ToUpper(ticker)))
+	text.WriteString("<blockquote>")
+	text.WriteString(fmt.Sprintf("Fees: LP %d bps / host %d bps\n", estimate.LpFeeBps, estimate.HostFeeBps))
+	text.WriteString(fmt.Sprintf("TVL: %s\n", luminex.FormatUSDValue(estimate.TvlUsd)))
+	text.WriteString(fmt.Sprintf("Volume 24h: %s\n", luminex.FormatUSDValue(estimate.Volume24hUsd)))
+	text.WriteString(fmt.Sprintf("LP fees 24h: %s\n", luminex.FormatUSDValue(estimate.FeeRevenue24hUsd)))
+	text.WriteString(fmt.Sprintf("APR (24h): %.2f%%\n", estimate.CurrentAPR))
+	if estimate.SamplesCount > 0 {
+		text.WriteString(fmt.Sprintf("APR (avg %.1fd, %d samples): %.2f%%", estimate.WindowDays, estimate.SamplesCount, estimate.AvgAPR))
+	} else {
+		text.WriteString("APR (avg): no hourly samples yet")
+	}
+	text.WriteString("</blockquote>")
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, text.String())
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send APR message", zap.Error(err))
+		return
+	}
+
+	log.LogInfo("APR estimate sent via command",
+		zap.String("ticker", ticker),
+		zap.Float64("apr", estimate.CurrentAPR),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// handleAlertCommand /alert - price alert subscriptions of user
+func handleAlertCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	if message.From == nil {
+		return
+	}
+	userID := message.From.ID
+	parts := strings.Fields(args)
+
+	switch {
+	case len(parts) == 1 && strings.EqualFold(parts[0], "list"):
+		subs, err := price_alerts.ListSubscriptions(userID)
+		if err != nil {
+			log.LogError("Failed to list price alerts", zap.Error(err))
+			reply(commandText(message, "command.error"))
+			return
+		}
+		if len(subs) == 0 {
+			reply(commandText(message, "alert.none"))
+			return
+		}
+		var text strings.Builder
+		text.WriteString("<b>" + commandText(message, "alert.title") + "</b>\n<blockquote>")
+		for _, sub := range subs {
+			mode := "once"
+			if sub.Recurring {
+				mode = "repeat"
+			}
+			text.WriteString(fmt.Sprintf("#%d {%s} %s $%s (%s)\n", sub.ID, html.EscapeString(sub.Ticker), sub.Condition, formatAlertPrice(sub.PriceUSD), mode))
+		}
+		text.WriteString("</blockquote>")
+		reply(text.String())
+		return
+
+	case len(parts) == 2 && strings.EqualFold(parts[0], "del"):
+		id, err := strconv.Atoi(strings.TrimPrefix(parts[1], "#"))
+		if err != nil {
+			reply(commandText(message, "usage.alert"))
+			return
+		}
+		removed, err := price_alerts.RemoveSubscription(userID, id)
+		if err != nil {
+			log.LogError("Failed to remove price alert", zap.Int("alertID", id), zap.Error(err))
+			reply(commandText(message, "command.error"))
+			return
+		}
+		if !removed {
+			reply(commandText(message, "alert.not_found", id))
+			return
+		}
+		reply(commandText(message, "alert.removed", id))
+		return
+
+	case len(parts) == 3 || len(parts) == 4:
+		// parsed below
+
+	default:
+		reply(commandText(message, "usage.alert"))
+		return
+	}
+
+	ticker := strings.ToUpper(strings.TrimSpace(parts[0]))
+	condition, err := price_alerts.ParseCondition(parts[1])
+	if err != nil {
+		reply(commandText(message, "usage.alert"))
+		return
+	}
+	priceUSD, err := strconv.ParseFloat(strings.TrimPrefix(parts[2], "$"), 64)
+	if err != nil || priceUSD <= 0 {
+		reply(commandText(message, "alert.invalid_price"))
+		return
+	}
+	recurring := false
+	if len(parts) == 4 {
+		if !strings.EqualFold(parts[3], "repeat") {
+			reply(commandText(message, "usage.alert"))
+			return
+		}
+		recurring = true
+	}
+
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker for price alert",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply(commandText(message, "command.ticker_not_found", html.EscapeString(ticker)))
+		return
+	}
+
+	sub, err := price_alerts.AddSubscription(price_alerts.Subscription{
+		BotID:           bot.Self.ID,
+		ChatID:          message.Chat.ID,
+		UserID:          userID,
+		Username:        message.From.UserName,
+		Ticker:          ticker,
+		PoolLpPublicKey: poolLpPublicKey,
+		Condition:       condition,
+		PriceUSD:        priceUSD,
+		Recurring:       recurring,
+	})
+	if err != nil {
+		log.LogWarn("Failed to add price alert", zap.String("ticker", ticker), zap.Error(err))
+		reply(commandText(message, "alert.add_failed", html.EscapeString(err.Error())))
+		return
+	}
+
+	text := commandText(message, "alert.created", sub.ID, html.EscapeString(ticker), condition, formatAlertPrice(priceUSD))
+	if currentPrice := luminex.GetPoolTokenPrice(poolLpPublicKey, flashnet.Swap{}, ticker); currentPrice > 0 {
+		text += "\n" + commandText(message, "alert.price_now", formatAlertPrice(currentPrice))
+	}
+	if recurring {
+		text += "\n" + commandText(message, "alert.mode_repeat")
+	} else {
+		text += "\n" + commandText(message, "alert.mode_once")
+	}
+	reply(text)
+
+	log.LogInfo("Price alert added via command",
+		zap.Int("alertID", sub.ID),
+		zap.String("ticker", ticker),
+		zap.String("condition", condition),
+		zap.Float64("priceUSD", priceUSD),
+		zap.Bool("recurring", recurring),
+		zap.String("username", message.From.UserName))
+}
+
+// handleWatchCommand /watch {wallet} - add wallet to watchlist of chat, /watch - list watched wallets
+func handleWatchCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, wallet string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	if wallet == "" {
+		watchlist, err := storage.LoadWatchlist()
+		if err != nil {
+			log.LogError("Failed to load watchlist", zap.Error(err))
+			reply(commandText(message, "command.error"))
+			return
+		}
+		var text strings.Builder
+		for _, entry := range watchlist.Wallets {
+			if entry.ChatID != message.Chat.ID {
+				continue
+			}
+			address := entry.SparkAddress
+			if address == "" {
+				address = entry.PublicKey
+			}
+			text.WriteString(fmt.Sprintf("<code>%s</code>\n", html.EscapeString(address)))
+		}
+		if text.Len() == 0 {
+			reply(commandText(message, "usage.watch"))
+			return
+		}
+		reply("<b>" + commandText(message, "watch.title") + "</b>\n<blockquote>" + text.String() + "</blockquote>")
+		return
+	}
+
+	// Luminex resolves both public key and spark address
+	balanceResp, err := luminex.GetWalletTokensBalance(wallet)
+	if err != nil || balanceResp.PublicKey == "" {
+		log.LogWarn("Failed to resolve wallet for watchlist",
+			zap.String("wallet", wallet),
+			zap.Error(err))
+		reply(commandText(message, "command.wallet_not_found"))
+		return
+	}
+
+	added, err := storage.AddWatchedWallet(storage.WatchedWallet{
+		PublicKey:    balanceResp.PublicKey,
+		SparkAddress: balanceResp.SparkAddress,
+		BotID:        bot.Self.ID,
+		ChatID:       message.Chat.ID,
+		AddedBy:      message.From.UserName,
+	})
+	if err != nil {
+		log.LogError("Failed to add wallet to watchlist", zap.String("wallet", wallet), zap.Error(err))
+		reply(commandText(message, "command.error"))
+		return
+	}
+
+	address := balanceResp.SparkAddress
+	if address == "" {
+		address = balanceResp.PublicKey
+	}
+	if !added {
+		reply(commandText(message, "watch.exists", html.EscapeString(formatWatchedAddress(address))))
+		return
+	}
+	reply(commandText(message, "watch.added", html.EscapeString(formatWatchedAddress(address))))
+
+	log.LogInfo("Wallet added to watchlist via command",
+		zap.String("publicKey", balanceResp.PublicKey),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// handleUnwatchCommand /unwatch {wallet} - remove wallet from watchlist of chat
+func handleUnwatchCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, wallet string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	removed, err := storage.RemoveWatchedWallet(message.Chat.ID, wallet)
+	if err != nil {
+		log.LogError("Failed to remove wallet from watchlist", zap.String("wallet", wallet), zap.Error(err))
+		reply(commandText(message, "command.error"))
+		return
+	}
+	if !removed {
+		reply(commandText(message, "watch.missing"))
+		return
+	}
+	reply(commandText(message, "watch.removed", formatWatchedAddress(wallet)))
+
+	log.LogInfo("Wallet removed from watchlist via command",
+		zap.String("wallet", wallet),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// formatWatchedAddress shortens wallet address: sp1qxyz...abcd
+func formatWatchedAddress(address string) string {
+	if len(address) <= 16 {
+		return address
+	}
+	return address[:10] + "..." + address[len(address)-6:]
+}
+
+// handleFlagWalletCommand /flagwallet {wallet} {team|rug|other} [note] - flag wallet as risky funding source
+// Without arguments lists flagged wallets
+func handleFlagWalletCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+	usage := commandText(message, "usage.flagwallet")
+
+	parts := strings.Fields(args)
+	if len(parts) == 0 {
+		data, err := risk.LoadWalletFlags()
+		if err != nil {
+			log.LogError("Failed to load wallet flags", zap.Error(err))
+			reply(commandText(message, "command.error"))
+			return
+		}
+		if len(data.Flagged) == 0 {
+			reply(html.EscapeString(usage) + "\n\n" + commandText(message, "flagwallet.none"))
+			return
+		}
+		flagged := make([]*risk.FlaggedWallet, 0, len(data.Flagged))
+		for _, flag := range data.Flagged {
+			flagged = append(flagged, flag)
+		}
+		sort.Slice(flagged, func(i, j int) bool { return flagged[i].AddedAt < flagged[j].AddedAt })
+
+		var text strings.Builder
+		for _, flag := range flagged {
+			text.WriteString(fmt.Sprintf("<code>%s</code> - %s", html.EscapeString(flag.PublicKey), flag.Reason))
+			if flag.Note != "" {
+				text.WriteString(" (" + html.EscapeString(flag.Note) + ")")
+			}
+			text.WriteString("\n")
+		}
+		reply("<b>" + commandText(message, "flagwallet.title") + "</b>\n<blockquote>" + text.String() + "</blockquote>")
+		return
+	}
+
+	if len(parts) < 2 {
+		reply(html.EscapeString(usage))
+		return
+	}
+	reason, ok := risk.ValidFlagReason(parts[1])
+	if !ok {
+		reply(html.EscapeString(usage))
+		return
+	}
+
+	// Luminex resolves both public key and spark address
+	balanceResp, err := luminex.GetWalletTokensBalance(parts[0])
+	if err != nil || balanceResp.PublicKey == "" {
+		log.LogWarn("Failed to resolve wallet for flagging",
+			zap.String("wallet", parts[0]),
+			zap.Error(err))
+		reply(commandText(message, "command.wallet_not_found"))
+		return
+	}
+
+	added, err := risk.FlagWallet(risk.FlaggedWallet{
+		PublicKey: balanceResp.PublicKey,
+		Reason:    reason,
+		Note:      strings.Join(parts[2:], " "),
+		AddedBy:   message.From.UserName,
+	})
+	if err != nil {
+		log.LogError("Failed to flag wallet", zap.String("wallet", parts[0]), zap.Error(err))
+		reply(commandText(message, "command.error"))
+		return
+	}
+
+	address := formatWatchedAddress(balanceResp.PublicKey)
+	if added {
+		reply(commandText(message, "flagwallet.added", html.EscapeString(address), reason))
+	} else {
+		reply(commandText(message, "flagwallet.updated", html.EscapeString(address), reason))
+	}
+
+	log.LogInfo("Wallet flagged via command",
+		zap.String("publicKey", balanceResp.PublicKey),
+		zap.String("reason", reason),
+		zap.String("username", message.From.UserName))
+}
+
+// handleUnflagWalletCommand /unflagwallet {wallet} - remove flag of wallet
+func handleUnflagWalletCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, wallet string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	if wallet == "" {
+		reply(commandText(message, "usage.unflagwallet"))
+		return
+	}
+
+	publicKey := wallet
+	if balanceResp, err := luminex.GetWalletTokensBalance(wallet); err == nil && balanceResp.PublicKey != "" {
+		publicKey = balanceResp.PublicKey
+	}
+
+	removed, err := risk.UnflagWallet(publicKey)
+	if err != nil {
+		log.LogError("Failed to unflag wallet", zap.String("wallet", wallet), zap.Error(err))
+		reply(commandText(message, "command.error"))
+		return
+	}
+	if !removed {
+		reply(commandText(message, "flagwallet.missing", formatWatchedAddress(publicKey)))
+		return
+	}
+	reply(commandText(message, "flagwallet.removed", formatWatchedAddress(publicKey)))
+
+	log.LogInfo("Wallet unflagged via command",
+		zap.String("publicKey", publicKey),
+		zap.String("username", message.From.UserName))
+}
+
+// handleFlashReportCommand /flash {ticker} {date}
+func handleFlashReportCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string, dateStr string, client *flashnet.Client) {
+	// Generate
+	report, err := holders.GenerateHoldersReport(ticker, dateStr, client, i18n.ChatLang(message.Chat.ID))
 	if err != nil {
-		log.LogWarn("Failed to find token by ticker",
+		log.LogError("Failed to generate holders report",
 			zap.String("ticker", ticker),
+			zap.String("dateStr", dateStr),
 			zap.Error(err))
 
-		// - ticker
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("Ticker {%s} cannot be added at this time", ticker))
+			commandText(message, "command.report_failed", err.Error()))
 		msg.ReplyToMessageID = message.MessageID
-		_, err := bot.Send(msg)
-		if err != nil {
-			log.LogError("Failed to send error message", zap.Error(err))
-		}
+		bot.Send(msg)
 		return
 	}
 
-	// Check, token in
-	existingTokens, err := storage.LoadFilteredTokens()
-	if err == nil {
-		for _, existingToken := range existingTokens {
-			if strings.TrimSpace(existingToken) == poolLpPublicKey {
-				msg := tgbotapi.NewMessage(message.Chat.ID,
-					fmt.Sprintf("Ticker {%s} already exists in the list", ticker))
-				msg.ReplyToMessageID = message.MessageID
-				_, err := bot.Send(msg)
-				if err != nil {
-					log.LogError("Failed to send message", zap.Error(err))
-				}
-				log.LogDebug("Token already in filtered list",
-					zap.String("ticker", ticker),
-					zap.String("poolLpPublicKey", poolLpPublicKey))
-				return
-			}
-		}
+	msg := tgbotapi.NewMessage(message.Chat.ID, report)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	msg.ReplyToMessageID = message.MessageID
+	_, err = bot.Send(msg)
+	if err != nil {
+		log.LogError("Failed to send report message", zap.Error(err))
 	}
 
-	err = storage.AddFilteredToken(poolLpPublicKey)
+	log.LogInfo("Holders report generated and sent",
+		zap.String("ticker", ticker),
+		zap.String("dateStr", dateStr),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// handleFlowReportCommand /flow {ticker} {date|range}
+func handleFlowReportCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string, dateStr string) {
+	// Generate
+	report, err := holders.GenerateFlowReport(ticker, dateStr, i18n.ChatLang(message.Chat.ID))
 	if err != nil {
-		log.LogError("Failed to add filtered token",
+		log.LogError("Failed to generate flow report",
 			zap.String("ticker", ticker),
-			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.String("dateStr", dateStr),
 			zap.Error(err))
 
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"An error occurred, please try again later")
+			commandText(message, "command.flow_failed", err.Error()))
 		msg.ReplyToMessageID = message.MessageID
-		_, err := bot.Send(msg)
-		if err != nil {
-			log.LogError("Failed to send error message", zap.Error(err))
-		}
+		bot.Send(msg)
 		return
 	}
 
-	// Check, token
-	verifyTokens, err := storage.LoadFilteredTokens()
-	if err == nil {
-		found := false
-		for _, token := range verifyTokens {
-			if strings.TrimSpace(token) == poolLpPublicKey {
-				found = true
-				break
-			}
-		}
-		if !found {
-			log.LogError("Token was not saved to file after AddFilteredToken",
-				zap.String("ticker", ticker),
-				zap.String("poolLpPublicKey", poolLpPublicKey))
-			msg := tgbotapi.NewMessage(message.Chat.ID,
-				"An error occurred, please try again later")
-			msg.ReplyToMessageID = message.MessageID
-			bot.Send(msg)
-			return
-		}
-	}
-
-	msg := tgbotapi.NewMessage(message.Chat.ID,
-		fmt.Sprintf("Ticker {%s} successfully added to the list", ticker))
+	msg := tgbotapi.NewMessage(message.Chat.ID, report)
+	msg.ParseMode = tgbotapi.ModeHTML
 	msg.ReplyToMessageID = message.MessageID
 	_, err = bot.Send(msg)
 	if err != nil {
-		log.LogError("Failed to send success message", zap.Error(err))
+		log.LogError("Failed to send flow report", zap.Error(err))
+		return
 	}
 
-	log.LogInfo("Token added to filtered list via command",
+	log.LogInfo("Flow report sent via command",
 		zap.String("ticker", ticker),
-		zap.String("poolLpPublicKey", poolLpPublicKey),
+		zap.String("dateStr", dateStr),
 		zap.String("chatID", formatChatID(message.Chat.ID)),
 		zap.String("username", message.From.UserName))
 }
 
-// handleDeleteTokenCommand /flashdel {token}
-func handleDeleteTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
-	// poolLpPublicKey by ticker in saved_ticket.json
-	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+// handleCohortReportCommand /cohort {ticker} {week}
+func handleCohortReportCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string, weekStr string) {
+	report, err := holders.GenerateCohortReport(ticker, weekStr)
 	if err != nil {
-		log.LogWarn("Failed to find token by ticker",
+		log.LogError("Failed to generate cohort report",
 			zap.String("ticker", ticker),
+			zap.String("week", weekStr),
 			zap.Error(err))
 
-		// - ticker
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("Ticker {%s} cannot be removed at this time", ticker))
+			commandText(message, "command.cohort_failed", err.Error()))
 		msg.ReplyToMessageID = message.MessageID
-		_, err := bot.Send(msg)
-		if err != nil {
-			log.LogError("Failed to send error message", zap.Error(err))
-		}
+		bot.Send(msg)
 		return
 	}
 
-	err = storage.RemoveFilteredToken(poolLpPublicKey)
+	caption := holders.FormatCohortReport(report, i18n.ChatLang(message.Chat.ID))
+	chartPath, err := tg_charts.GenerateCohortChart(report)
 	if err != nil {
-		if err.Error() == "token not found in list" {
-			msg := tgbotapi.NewMessage(message.Chat.ID,
-				fmt.Sprintf("Ticker {%s} is not in the list", ticker))
-			msg.ReplyToMessageID = message.MessageID
-			_, err := bot.Send(msg)
-			if err != nil {
-				log.LogError("Failed to send message", zap.Error(err))
-			}
-			log.LogDebug("Token not found in filtered list",
-				zap.String("ticker", ticker),
-				zap.String("poolLpPublicKey", poolLpPublicKey))
-		} else {
-			log.LogError("Failed to remove filtered token",
-				zap.String("ticker", ticker),
-				zap.String("poolLpPublicKey", poolLpPublicKey),
-				zap.Error(err))
-
-			msg := tgbotapi.NewMessage(message.Chat.ID,
-				"An error occurred, please try again later")
-			msg.ReplyToMessageID = message.MessageID
-			_, err := bot.Send(msg)
-			if err != nil {
-				log.LogError("Failed to send error message", zap.Error(err))
-			}
-		}
+		// No buyers in archive: report without chart
+		log.LogDebug("Cohort chart not generated", zap.Error(err))
+		msg := tgbotapi.NewMessage(message.Chat.ID, caption)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
 		return
 	}
 
-	msg := tgbotapi.NewMessage(message.Chat.ID,
-		fmt.Sprintf("Ticker {%s} successfully removed from the list", ticker))
-	msg.ReplyToMessageID = message.MessageID
-	_, err = bot.Send(msg)
-	if err != nil {
-		log.LogError("Failed to send success message", zap.Error(err))
+	photo := tgbotapi.NewPhoto(message.Chat.ID, tgbotapi.FilePath(chartPath))
+	photo.Caption = caption
+	photo.ParseMode = tgbotapi.ModeHTML
+	photo.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(photo); err != nil {
+		log.LogError("Failed to send cohort report", zap.Error(err))
+		return
 	}
 
-	log.LogInfo("Token removed from filtered list via command",
+	log.LogInfo("Cohort report sent via command",
 		zap.String("ticker", ticker),
-		zap.String("poolLpPublicKey", poolLpPublicKey),
+		zap.String("week", weekStr),
 		zap.String("chatID", formatChatID(message.Chat.ID)),
 		zap.String("username", message.From.UserName))
 }
 
-// handleFlashReportCommand /flash {ticker} {date}
-func handleFlashReportCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string, dateStr string, client *flashnet.Client) {
-	// Generate
-	report, err := holders.GenerateHoldersReport(ticker, dateStr, client)
+// handleTopHoldersCommand /top {ticker}
+func handleTopHoldersCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	report, err := holders.GenerateTopHoldersReport(ticker)
 	if err != nil {
-		log.LogError("Failed to generate holders report",
+		log.LogError("Failed to generate top holders report",
 			zap.String("ticker", ticker),
-			zap.String("dateStr", dateStr),
 			zap.Error(err))
 
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("Failed to generate report: %s", err.Error()))
+			commandText(message, "command.top_failed", err.Error()))
 		msg.ReplyToMessageID = message.MessageID
 		bot.Send(msg)
 		return
@@ -445,30 +1499,27 @@ func handleFlashReportCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, t
 	msg.ParseMode = tgbotapi.ModeHTML
 	msg.DisableWebPagePreview = true
 	msg.ReplyToMessageID = message.MessageID
-	_, err = bot.Send(msg)
-	if err != nil {
-		log.LogError("Failed to send report message", zap.Error(err))
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send top holders report", zap.Error(err))
+		return
 	}
 
-	log.LogInfo("Holders report generated and sent",
+	log.LogInfo("Top holders report sent via command",
 		zap.String("ticker", ticker),
-		zap.String("dateStr", dateStr),
 		zap.String("chatID", formatChatID(message.Chat.ID)),
 		zap.String("username", message.From.UserName))
 }
 
-// handleFlowReportCommand /flow {ticker} {date}
-func handleFlowReportCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string, dateStr string) {
-	// Generate
-	report, err := holders.GenerateFlowReport(ticker, dateStr)
+// handleHoldersSummaryCommand /holders {ticker}
+func handleHoldersSummaryCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	report, err := holders.GenerateHoldersSummaryReport(ticker)
 	if err != nil {
-		log.LogError("Failed to generate flow report",
+		log.LogError("Failed to generate holders summary",
 			zap.String("ticker", ticker),
-			zap.String("dateStr", dateStr),
 			zap.Error(err))
 
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("Failed to generate flow report: %s", err.Error()))
+			commandText(message, "command.holders_failed", err.Error()))
 		msg.ReplyToMessageID = message.MessageID
 		bot.Send(msg)
 		return
@@ -476,16 +1527,15 @@ func handleFlowReportCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ti
 
 	msg := tgbotapi.NewMessage(message.Chat.ID, report)
 	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
 	msg.ReplyToMessageID = message.MessageID
-	_, err = bot.Send(msg)
-	if err != nil {
-		log.LogError("Failed to send flow report", zap.Error(err))
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send holders summary", zap.Error(err))
 		return
 	}
 
-	log.LogInfo("Flow report sent via command",
+	log.LogInfo("Holders summary sent via command",
 		zap.String("ticker", ticker),
-		zap.String("dateStr", dateStr),
 		zap.String("chatID", formatChatID(message.Chat.ID)),
 		zap.String("username", message.From.UserName))
 }
@@ -499,14 +1549,15 @@ func handleStatsCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
 			zap.Error(err))
 
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("Failed to get stats: %s", err.Error()))
+			commandText(message, "command.stats_failed", err.Error()))
 		bot.Send(msg)
 		return
 	}
 
 	// Check, (check = true)
 	// If - save check = true, check = false
-	checked, err := luminex.IsStatsCheckedToday()
+	today := scheduler.Date(time.Now(), statsLocation)
+	checked, err := luminex.IsStatsChecked(today)
 	if err != nil {
 		log.LogWarn("Failed to check if stats were checked today", zap.Error(err))
 		// in save check = false
@@ -518,7 +1569,7 @@ func handleStatsCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
 	checkFlag := !checked
 
 	// Save data in stats.json
-	if err := luminex.SaveStatsData(stats, checkFlag); err != nil {
+	if err := luminex.SaveStatsData(stats, checkFlag, today); err != nil {
 		log.LogWarn("Failed to save stats data", zap.Error(err))
 	}
 
@@ -530,7 +1581,7 @@ func handleStatsCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
 		return // if error
 	}
 
-	chartPath, err := tg_charts.GenerateVolumeChart()
+	chartPath, err := tg_charts.GenerateVolumeChart(message.Chat.ID)
 	if err != nil {
 		log.LogWarn("Failed to generate volume chart", zap.Error(err))
 		keyboard := tgbotapi.NewInlineKeyboardMarkup(
@@ -593,6 +1644,105 @@ func handleStatsCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
 		zap.String("username", message.From.UserName))
 }
 
+// handleWhatsNewCommand /whatsnew [version] - user-facing changelog
+func handleWhatsNewCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, version string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	if version == "" {
+		version = buildinfo.Version
+	}
+	release, err := changelog.Find(version)
+	if err != nil {
+		log.LogError("Failed to load changelog", zap.Error(err))
+		reply(commandText(message, "command.error"))
+		return
+	}
+	if release == nil {
+		releases, _ := changelog.Releases()
+		versions := make([]string, 0, len(releases))
+		for _, r := range releases {
+			versions = append(versions, r.Version)
+		}
+		reply(commandText(message, "whatsnew.not_found", html.EscapeString(version), strings.Join(versions, ", ")))
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🆕 <b>What's new in %s</b>", release.Version))
+	if release.Date != "" {
+		text.WriteString(fmt.Sprintf(" (%s)", release.Date))
+	}
+	text.WriteString("\n<blockquote>")
+	for _, change := range release.Changes {
+		text.WriteString("• " + change + "\n")
+	}
+	text.WriteString("</blockquote>")
+	if release.Version != buildinfo.Version {
+		text.WriteString(fmt.Sprintf("\nRunning version: %s", buildinfo.Version))
+	}
+	reply(text.String())
+}
+
+// botStatsRegistry - registry of running monitors shown in /botstats
+var botStatsRegistry atomic.Pointer[MonitorRegistry]
+
+// SetBotStatsRegistry sets monitor registry shown in /botstats
+func SetBotStatsRegistry(registry *MonitorRegistry) {
+	botStatsRegistry.Store(registry)
+}
+
+// handleBotStatsCommand /botstats - version, build info, uptime and monitors state
+func handleBotStatsCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
+	info := buildinfo.Get()
+
+	var text strings.Builder
+	text.WriteString("<b>Bot stats</b>\n<blockquote>")
+	text.WriteString(fmt.Sprintf("Version: %s\n", html.EscapeString(info.Version)))
+	commit := info.Commit
+	if commit == "" {
+		commit = "unknown"
+	} else if info.Modified {
+		commit += " (modified)"
+	}
+	text.WriteString(fmt.Sprintf("Commit: <code>%s</code>\n", html.EscapeString(commit)))
+	if info.BuildTime != "" {
+		text.WriteString(fmt.Sprintf("Built: %s\n", html.EscapeString(info.BuildTime)))
+	}
+	text.WriteString(fmt.Sprintf("Go: %s\n", info.GoVersion))
+	text.WriteString(fmt.Sprintf("Uptime: %s", buildinfo.Uptime().Truncate(time.Second)))
+	text.WriteString("</blockquote>")
+
+	if registry := botStatsRegistry.Load(); registry != nil {
+		statuses := registry.Statuses()
+		failing := 0
+		restarts := 0
+		for _, status := range statuses {
+			if status.ConsecutiveFailures > 0 {
+				failing++
+			}
+			restarts += status.Restarts
+		}
+		text.WriteString(fmt.Sprintf("\nMonitors: %d, failing: %d, restarts: %d", len(statuses), failing, restarts))
+		for _, status := range statuses {
+			if status.Paused {
+				text.WriteString(fmt.Sprintf("\n⏸ %s - paused", html.EscapeString(status.Name)))
+			} else if status.ConsecutiveFailures > 0 {
+				text.WriteString(fmt.Sprintf("\n⚠️ %s - %d failures in a row", html.EscapeString(status.Name), status.ConsecutiveFailures))
+			}
+		}
+	}
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, text.String())
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyToMessageID = message.MessageID
+	bot.Send(msg)
+}
+
 // handleSparkCommand /spark
 func handleSparkCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
 	// Get BTC from API
@@ -602,7 +1752,7 @@ func handleSparkCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
 			zap.Error(err))
 
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("Failed to get BTC reserve: %s", err.Error()))
+			commandText(message, "command.spark_failed", err.Error()))
 		bot.Send(msg)
 		return
 	}
@@ -629,7 +1779,7 @@ func handleSparkCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
 	sparkMessage := formatSparkMessage(btcReserve)
 
 	// Generate
-	chartPath, err := tg_charts.GenerateBTCSparkChart()
+	chartPath, err := tg_charts.GenerateBTCSparkChart(message.Chat.ID)
 	if err != nil {
 		log.LogWarn("Failed to generate BTC spark chart", zap.Error(err))
 		keyboard := tgbotapi.NewInlineKeyboardMarkup(
@@ -867,7 +2017,7 @@ func handleExcludeTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 			zap.Error(err))
 
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Ticker {%s} not found. Make sure the token has been traded before.", ticker))
+			"❌ "+commandText(message, "command.ticker_not_found", ticker))
 		msg.ReplyToMessageID = message.MessageID
 		bot.Send(msg)
 		return
@@ -879,7 +2029,7 @@ func handleExcludeTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 		for _, existingToken := range existingTokens {
 			if strings.TrimSpace(existingToken) == poolLpPublicKey {
 				msg := tgbotapi.NewMessage(message.Chat.ID,
-					fmt.Sprintf("⚠️ Ticker {%s} is already excluded from notifications", ticker))
+					"⚠️ "+commandText(message, "exclude.exists", ticker))
 				msg.ReplyToMessageID = message.MessageID
 				bot.Send(msg)
 				log.LogDebug("Token already in blacklist",
@@ -899,7 +2049,7 @@ func handleExcludeTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 			zap.Error(err))
 
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ An error occurred, please try again later")
+			"❌ "+commandText(message, "command.error"))
 		msg.ReplyToMessageID = message.MessageID
 		bot.Send(msg)
 		return
@@ -907,7 +2057,7 @@ func handleExcludeTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 
 	// Success message
 	msg := tgbotapi.NewMessage(message.Chat.ID,
-		fmt.Sprintf("Ticker {%s} excluded from notifications", ticker))
+		commandText(message, "exclude.done", ticker))
 	msg.ReplyToMessageID = message.MessageID
 	bot.Send(msg)
 
@@ -927,7 +2077,7 @@ func handleIncludeTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 			zap.Error(err))
 
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Ticker {%s} not found", ticker))
+			"❌ "+commandText(message, "command.ticker_unknown", ticker))
 		msg.ReplyToMessageID = message.MessageID
 		bot.Send(msg)
 		return
@@ -942,7 +2092,7 @@ func handleIncludeTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 			zap.Error(err))
 
 		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Token not found in exclusion list or error occurred")
+			"❌ "+commandText(message, "include.failed"))
 		msg.ReplyToMessageID = message.MessageID
 		bot.Send(msg)
 		return
@@ -950,7 +2100,7 @@ func handleIncludeTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 
 	// Success message
 	msg := tgbotapi.NewMessage(message.Chat.ID,
-		fmt.Sprintf("Ticker {%s} included back in notifications", ticker))
+		commandText(message, "include.done", ticker))
 	msg.ReplyToMessageID = message.MessageID
 	bot.Send(msg)
 
@@ -959,3 +2109,114 @@ func handleIncludeTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 		zap.String("poolLpPublicKey", poolLpPublicKey),
 		zap.String("chatID", formatChatID(message.Chat.ID)))
 }
+
+// handleBlacklistCommand /blacklist - manual and auto-blacklisted tokens
+func handleBlacklistCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
+	manualTokens, err := storage.LoadBlacklistedTokens()
+	if err != nil {
+		log.LogWarn("Failed to load blacklisted tokens", zap.Error(err))
+	}
+	autoBlacklist, err := risk.LoadAutoBlacklist()
+	if err != nil {
+		log.LogError("Failed to load auto-blacklist", zap.Error(err))
+
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "command.error"))
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString("<b>Blacklist</b>\n")
+
+	text.WriteString(fmt.Sprintf("\nManual (%d):\n", len(manualTokens)))
+	for _, poolLpPublicKey := range manualTokens {
+		text.WriteString(fmt.Sprintf("• %s\n", formatBlacklistTicker(poolLpPublicKey, "")))
+	}
+
+	entries := make([]*risk.AutoBlacklistEntry, 0, len(autoBlacklist.Entries))
+	for _, entry := range autoBlacklist.Entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].AddedAt > entries[j].AddedAt
+	})
+
+	text.WriteString(fmt.Sprintf("\nAuto (%d):\n", len(entries)))
+	for _, entry := range entries {
+		text.WriteString(fmt.Sprintf("• %s - risk %d: %s\n",
+			formatBlacklistTicker(entry.PoolLpPublicKey, entry.Ticker), entry.Score, strings.Join(entry.Reasons, ", ")))
+	}
+
+	if len(autoBlacklist.Whitelist) > 0 {
+		text.WriteString(fmt.Sprintf("\nWhitelisted (%d):\n", len(autoBlacklist.Whitelist)))
+		for _, poolLpPublicKey := range autoBlacklist.Whitelist {
+			text.WriteString(fmt.Sprintf("• %s\n", formatBlacklistTicker(poolLpPublicKey, "")))
+		}
+	}
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, text.String())
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send blacklist", zap.Error(err))
+	}
+}
+
+// handleWhitelistCommand /whitelist {ticker} - remove token from auto-blacklist and keep it out of it
+func handleWhitelistCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker for whitelist",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "command.ticker_not_found", ticker))
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	added, err := risk.WhitelistToken(poolLpPublicKey)
+	if err != nil {
+		log.LogError("Failed to whitelist token",
+			zap.String("ticker", ticker),
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.Error(err))
+
+		msg := tgbotapi.NewMessage(message.Chat.ID,
+			commandText(message, "command.error"))
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	text := commandText(message, "whitelist.added", strings.ToUpper(ticker))
+	if !added {
+		text = commandText(message, "whitelist.exists", strings.ToUpper(ticker))
+	}
+	msg := tgbotapi.NewMessage(message.Chat.ID, text)
+	msg.ReplyToMessageID = message.MessageID
+	bot.Send(msg)
+
+	log.LogSuccess("Token whitelisted",
+		zap.String("ticker", ticker),
+		zap.String("poolLpPublicKey", poolLpPublicKey),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// formatBlacklistTicker - ticker from saved_ticket.json or short pool address
+func formatBlacklistTicker(poolLpPublicKey string, ticker string) string {
+	if ticker == "" {
+		if savedTicker, err := holders.GetTickerFromPoolLpPublicKey(poolLpPublicKey); err == nil {
+			ticker = savedTicker
+		}
+	}
+	if ticker == "" {
+		return FormatTokenAddress(poolLpPublicKey)
+	}
+	return "{" + strings.ToUpper(ticker) + "}"
+}
@@ -0,0 +1,199 @@
+package bots_monitor
+
+// Community monitor: samples member count of token community chats (telegram.community_chats)
+// /community {ticker} charts member count over token price and volume
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/community"
+	"spark-wallet/internal/features/tg_charts"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// communityChartDays - days shown on /community chart
+const communityChartDays = 30
+
+// RunCommunityMonitor samples member count of community chats
+// bot - Telegram bot that is member of community chats
+// chats - ticker -> chat ID or @username
+// interval - interval between samples (one sample per day is kept, the latest)
+func RunCommunityMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chats map[string]string, interval time.Duration) {
+	if bot == nil || len(chats) == 0 {
+		log.LogInfo("No community chats configured, community monitor not started")
+		return
+	}
+
+	log.LogInfo("Starting Community Monitor...",
+		zap.Int("chats", len(chats)),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial sample
+	sampleCommunityChats(ctx, bot, chats)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Community Monitor stopped")
+			return
+		case <-ticker.C:
+			sampleCommunityChats(ctx, bot, chats)
+		}
+	}
+}
+
+// sampleCommunityChats saves member count of each community chat
+func sampleCommunityChats(ctx context.Context, bot *tgbotapi.BotAPI, chats map[string]string) {
+	var lastErr error
+	sampled := 0
+	for ticker, chat := range chats {
+		if ctx.Err() != nil {
+			return
+		}
+
+		members, err := bot.GetChatMembersCount(tgbotapi.ChatMemberCountConfig{ChatConfig: communityChatConfig(chat)})
+		if err != nil {
+			log.LogWarn("Failed to get community chat member count",
+				zap.String("ticker", ticker),
+				zap.String("chat", chat),
+				zap.Error(err))
+			lastErr = err
+			continue
+		}
+
+		if err := community.RecordMemberCount(ticker, members, time.Now()); err != nil {
+			log.LogError("Failed to save community member count",
+				zap.String("ticker", ticker),
+				zap.Error(err))
+			lastErr = err
+			continue
+		}
+		sampled++
+
+		log.LogDebug("Community member count sampled",
+			zap.String("ticker", ticker),
+			zap.Int("members", members))
+	}
+
+	// Run fails only if no chat was sampled
+	if sampled == 0 && lastErr != nil {
+		ReportMonitorError(ctx, lastErr)
+	} else {
+		ReportMonitorSuccess(ctx)
+	}
+}
+
+// communityChatConfig - chat by numeric ID or @username
+func communityChatConfig(chat string) tgbotapi.ChatConfig {
+	chat = strings.TrimSpace(chat)
+	if chatID, err := strconv.ParseInt(chat, 10, 64); err == nil {
+		return tgbotapi.ChatConfig{ChatID: chatID}
+	}
+	if !strings.HasPrefix(chat, "@") {
+		chat = "@" + chat
+	}
+	return tgbotapi.ChatConfig{SuperGroupUsername: chat}
+}
+
+// handleCommunityCommand /community {ticker}
+func handleCommunityCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	members, err := community.LoadMemberHistory(ticker)
+	if err != nil {
+		log.LogError("Failed to load community history", zap.String("ticker", ticker), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+	if len(members) == 0 {
+		reply(fmt.Sprintf("Community of {%s} is not tracked. Add it to telegram.community_chats in config.yaml.", strings.ToUpper(ticker)))
+		return
+	}
+
+	// Price and volume overlay needs pool, member count alone is still shown without it
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find pool for community chart", zap.String("ticker", ticker), zap.Error(err))
+		poolLpPublicKey = ""
+	}
+
+	points, err := community.BuildPoints(ticker, poolLpPublicKey, communityChartDays, time.Now().UTC())
+	if err != nil {
+		log.LogError("Failed to build community chart data", zap.String("ticker", ticker), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	chartPath, err := tg_charts.GenerateCommunityChart(ticker, points)
+	if err != nil {
+		log.LogWarn("Failed to generate community chart", zap.String("ticker", ticker), zap.Error(err))
+		reply(fmt.Sprintf("Not enough data for {%s} yet: member count is sampled daily.", strings.ToUpper(ticker)))
+		return
+	}
+	photo := tgbotapi.NewPhoto(message.Chat.ID, tgbotapi.FilePath(chartPath))
+	photo.Caption = formatCommunityCaption(ticker, points)
+	photo.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(photo); err != nil {
+		log.LogError("Failed to send community chart", zap.String("ticker", ticker), zap.Error(err))
+		return
+	}
+
+	log.LogInfo("Community chart sent",
+		zap.String("ticker", ticker),
+		zap.String("chatID", formatChatID(message.Chat.ID)))
+}
+
+// formatCommunityCaption - member, price and volume change over chart period
+func formatCommunityCaption(ticker string, points []community.Point) string {
+	var caption strings.Builder
+	caption.WriteString(fmt.Sprintf("{%s} community, %d days\n", strings.ToUpper(ticker), len(points)))
+
+	change := func(from float64, to float64) string {
+		if from <= 0 || to <= 0 {
+			return "n/a"
+		}
+		return fmt.Sprintf("%+.1f%%", (to-from)/from*100)
+	}
+
+	var membersFrom, membersTo, priceFrom, priceTo, volumeFrom, volumeTo float64
+	for _, point := range points {
+		if point.Members > 0 {
+			if membersFrom == 0 {
+				membersFrom = float64(point.Members)
+			}
+			membersTo = float64(point.Members)
+		}
+		if point.PriceUsd > 0 {
+			if priceFrom == 0 {
+				priceFrom = point.PriceUsd
+			}
+			priceTo = point.PriceUsd
+		}
+		if point.Volume24hUsd > 0 {
+			if volumeFrom == 0 {
+				volumeFrom = point.Volume24hUsd
+			}
+			volumeTo = point.Volume24hUsd
+		}
+	}
+
+	caption.WriteString(fmt.Sprintf("Members: %.0f → %.0f (%s)\n", membersFrom, membersTo, change(membersFrom, membersTo)))
+	caption.WriteString(fmt.Sprintf("Price: %s\n", change(priceFrom, priceTo)))
+	caption.WriteString(fmt.Sprintf("Volume 24h: %s", change(volumeFrom, volumeTo)))
+	return caption.String()
+}
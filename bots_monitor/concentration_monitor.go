@@ -0,0 +1,179 @@
+package bots_monitor
+
+// Sell pressure monitor: concentration of daily holders snapshots of tracked tickers (top-10 share, Gini),
+// warning when it rises sharply or a top-5 holder sells (see holders.CheckConcentration)
+// Snapshots are saved after the daily balance check, the monitor picks up a new one within interval
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/infra/events"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// RunConcentrationMonitor checks latest holders snapshots every interval and warns about sell pressure
+// bot - Telegram for alerts
+// chatID - ID for alerts
+// thresholds - top-10 share rise, Gini rise and part sold by top-5 holder, all 0 - monitor disabled
+func RunConcentrationMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, thresholds holders.ConcentrationThresholds, interval time.Duration) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, concentration monitor not started")
+		return
+	}
+	if !thresholds.Enabled() {
+		log.LogInfo("Concentration thresholds are 0, concentration monitor disabled")
+		return
+	}
+
+	log.LogInfo("Starting Concentration Monitor...",
+		zap.String("chatID", chatID),
+		zap.Float64("topShareRise", thresholds.TopShareRise),
+		zap.Float64("giniRise", thresholds.GiniRise),
+		zap.Float64("topSellerPercent", thresholds.TopSellerPercent),
+		zap.Duration("interval", interval))
+
+	scheduler.Default.Run(ctx, scheduler.Job{
+		Name:       "concentration",
+		Schedule:   scheduler.Interval(interval),
+		RunOnStart: true,
+		Run: func(ctx context.Context, now time.Time) {
+			checkConcentration(ctx, bot, chatID, thresholds)
+		},
+	})
+	log.LogInfo("Concentration Monitor stopped")
+}
+
+// checkConcentration records metrics of latest snapshots and sends one warning per ticker and snapshot
+func checkConcentration(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, thresholds holders.ConcentrationThresholds) {
+	var lastErr error
+	checked := 0
+	for _, ticker := range holders.GetAllowedTickers() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		risk, err := holders.CheckConcentration(ticker, thresholds)
+		if err != nil {
+			log.LogError("Failed to check holders concentration", zap.String("ticker", ticker), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		checked++
+		if risk == nil || risk.Alerted || !risk.Alerting() {
+			continue
+		}
+		sendConcentrationAlert(ctx, bot, chatID, risk)
+	}
+
+	// Run fails only if no ticker was checked
+	if checked == 0 && lastErr != nil {
+		ReportMonitorError(ctx, lastErr)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+}
+
+func sendConcentrationAlert(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, risk *holders.ConcentrationRisk) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	poolLpPublicKey, _ := storage.FindPoolLpPublicKeyByTicker(risk.Ticker)
+	message := FormatConcentrationMessage(risk)
+	if holdAlert(bot, parseChatIDBig(chatID), "concentration", poolLpPublicKey, risk.Ticker, message) {
+		return
+	}
+
+	msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	tradeLink := ""
+	if poolLpPublicKey != "" {
+		tradeLink = fmt.Sprintf("https://luminex.io/spark/trade/%s", poolLpPublicKey)
+		msg.ReplyMarkup = tradeKeyboard(tradeLink, i18n.English)
+	}
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send concentration alert", zap.String("ticker", risk.Ticker), zap.Error(err))
+		return
+	}
+
+	if err := holders.MarkConcentrationAlerted(risk.Ticker, risk.Current.Date); err != nil {
+		log.LogWarn("Failed to save concentration alert", zap.Error(err))
+	}
+	recordDashboardAlert("concentration", message, tradeLink)
+	recordAlertEvent(events.Alert{
+		Kind:            "concentration",
+		ChatID:          parseChatIDBig(chatID),
+		PoolLpPublicKey: poolLpPublicKey,
+		Ticker:          risk.Ticker,
+	}, message)
+
+	log.LogInfo("Concentration alert sent",
+		zap.String("ticker", risk.Ticker),
+		zap.String("date", risk.Current.Date),
+		zap.Float64("topShare", risk.Current.TopShare),
+		zap.Float64("gini", risk.Current.Gini),
+		zap.Int("sellers", len(risk.Sellers)))
+}
+
+// FormatConcentrationMessage formats sell pressure warning with concentration change and selling top holders (Telegram HTML)
+func FormatConcentrationMessage(risk *holders.ConcentrationRisk) string {
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("📉 <b>sell pressure</b>: {%s}\n", strings.ToUpper(html.EscapeString(risk.Ticker))))
+
+	current := risk.Current
+	message.WriteString("<blockquote>")
+	if previous := risk.Previous; previous != nil {
+		message.WriteString(fmt.Sprintf("Top %d share: %.2f%% → %.2f%% (%+.2f pp)%s\n",
+			holders.ConcentrationTopHolders, previous.TopShare, current.TopShare, current.TopShare-previous.TopShare, concentrationMark(risk.TopShareRise)))
+		message.WriteString(fmt.Sprintf("Gini: %.3f → %.3f (%+.3f)%s\n",
+			previous.Gini, current.Gini, current.Gini-previous.Gini, concentrationMark(risk.GiniRise)))
+		message.WriteString(fmt.Sprintf("Holders: %d → %d", previous.Holders, current.Holders))
+	} else {
+		message.WriteString(fmt.Sprintf("Top %d share: %.2f%%\nGini: %.3f\nHolders: %d",
+			holders.ConcentrationTopHolders, current.TopShare, current.Gini, current.Holders))
+	}
+	message.WriteString("</blockquote>")
+
+	if len(risk.Sellers) > 0 {
+		message.WriteString(fmt.Sprintf("\nTop %d holders selling:\n", holders.ConcentrationTopSellers))
+	}
+	for _, seller := range risk.Sellers {
+		line := fmt.Sprintf("#%d %s: -%.0f%% (%s → %s)", seller.Rank, suspiciousWalletLink(seller.Address),
+			seller.SoldPercent, formatTokenAmountLocal(seller.Previous), formatTokenAmountLocal(seller.Current))
+		if risk.Supply > 0 {
+			line += fmt.Sprintf(", %.2f%% of supply", (seller.Previous-seller.Current)/risk.Supply*100)
+		}
+		message.WriteString(line + "\n")
+	}
+
+	if risk.Previous != nil {
+		message.WriteString(fmt.Sprintf("\n<i>Snapshot %s against %s</i>", formatConcentrationDate(current.Date), formatConcentrationDate(risk.Previous.Date)))
+	}
+	return strings.TrimSuffix(message.String(), "\n")
+}
+
+func concentrationMark(triggered bool) string {
+	if triggered {
+		return " ⚠️"
+	}
+	return ""
+}
+
+func formatConcentrationDate(date string) string {
+	if parsed, err := time.Parse("2006-01-02", date); err == nil {
+		return parsed.Format("02.01")
+	}
+	return date
+}
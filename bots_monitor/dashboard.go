@@ -0,0 +1,200 @@
+package bots_monitor
+
+// Web dashboard served by admin API: monitors state, recent alerts, hot tokens and generated charts
+// Templates are embedded (dashboard_templates), page refreshes itself every 30 seconds
+// Browser signs in with admin API token once, token is kept in HttpOnly cookie
+
+import (
+	"crypto/subtle"
+	"embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/infra/buildinfo"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// dashboardCookie - cookie with admin API token
+	dashboardCookie = "admin_api_token"
+)
+
+//go:embed dashboard_templates/*.html
+var dashboardTemplateFiles embed.FS
+
+var dashboardTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"formatTime": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format("2006-01-02 15:04:05")
+	},
+	"formatAgo": func(t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return time.Since(t).Truncate(time.Second).String() + " ago"
+	},
+	"formatUSD":    luminex.FormatUSDValue,
+	"formatBTC":    amount.FormatBTC,
+	"shortAddress": FormatTokenAddress,
+}).ParseFS(dashboardTemplateFiles, "dashboard_templates/*.html"))
+
+type dashboardChart struct {
+	Name      string
+	UpdatedAt time.Time
+}
+
+type dashboardPage struct {
+	GeneratedAt    time.Time
+	Version        string
+	Uptime         time.Duration
+	Monitors       []MonitorStatus
+	FailingCount   int
+	PausedCount    int
+	Thresholds     adminThresholds
+	FilteredTokens int
+	Alerts         []DashboardAlert
+	HotTokens      []DashboardHotToken
+	Charts         []dashboardChart
+}
+
+// authorizedDashboard checks admin API token in cookie
+func (a *adminAPI) authorizedDashboard(r *http.Request) bool {
+	cookie, err := r.Cookie(dashboardCookie)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(a.token)) == 1
+}
+
+func (a *adminAPI) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	statuses := a.registry.Statuses()
+	page := dashboardPage{
+		GeneratedAt: time.Now(),
+		Version:     buildinfo.Get().Version,
+		Uptime:      buildinfo.Uptime().Truncate(time.Second),
+		Monitors:    statuses,
+		Thresholds: adminThresholds{
+			BigSalesMinBTC: effectiveBigSalesMinBTC(a.bigSalesMinBTC),
+			FilteredMinBTC: effectiveFilteredMinBTC(a.filteredMinBTC),
+		},
+		Alerts:    RecentDashboardAlerts(),
+		HotTokens: RecentDashboardHotTokens(),
+		Charts:    listDashboardCharts(),
+	}
+	for _, status := range statuses {
+		if status.Paused {
+			page.PausedCount++
+		} else if status.ConsecutiveFailures > 0 || status.Stalled {
+			page.FailingCount++
+		}
+	}
+	if tokens, err := storage.LoadFilteredTokens(); err == nil {
+		page.FilteredTokens = len(tokens)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := dashboardTemplates.ExecuteTemplate(w, "dashboard.html", page); err != nil {
+		log.LogError("Failed to render dashboard", zap.Error(err))
+	}
+}
+
+func (a *adminAPI) handleDashboardChart(w http.ResponseWriter, r *http.Request) {
+	// Only PNG files directly in charts directory
+	name := r.PathValue("name")
+	if name != filepath.Base(name) || !strings.HasSuffix(name, ".png") {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeFile(w, r, filepath.Join(paths.Charts(), name))
+}
+
+func (a *adminAPI) handleDashboardLoginPage(w http.ResponseWriter, r *http.Request) {
+	a.renderDashboardLogin(w, http.StatusOK, "")
+}
+
+func (a *adminAPI) handleDashboardLogin(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, adminAPIMaxBodySize)
+	token := strings.TrimSpace(r.PostFormValue("token"))
+	if subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+		log.LogWarn("Dashboard sign-in with invalid token", zap.String("remoteAddr", r.RemoteAddr))
+		a.renderDashboardLogin(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     dashboardCookie,
+		Value:    token,
+		Path:     "/dashboard",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   int((30 * 24 * time.Hour).Seconds()),
+	})
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+func (a *adminAPI) handleDashboardLogout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     dashboardCookie,
+		Value:    "",
+		Path:     "/dashboard",
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+	http.Redirect(w, r, "/dashboard/login", http.StatusSeeOther)
+}
+
+func (a *adminAPI) renderDashboardLogin(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := dashboardTemplates.ExecuteTemplate(w, "login.html", message); err != nil {
+		log.LogError("Failed to render dashboard login", zap.Error(err))
+	}
+}
+
+// listDashboardCharts returns generated charts (newest first)
+func listDashboardCharts() []dashboardChart {
+	entries, err := os.ReadDir(paths.Charts())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.LogWarn("Failed to list charts for dashboard", zap.Error(err))
+		}
+		return nil
+	}
+
+	var charts []dashboardChart
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".png") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		charts = append(charts, dashboardChart{Name: entry.Name(), UpdatedAt: info.ModTime()})
+	}
+	sort.Slice(charts, func(i, j int) bool {
+		return charts[i].UpdatedAt.After(charts[j].UpdatedAt)
+	})
+	return charts
+}
+
+// URL - chart image path with modification time against browser cache
+func (c dashboardChart) URL() string {
+	return fmt.Sprintf("/dashboard/charts/%s?v=%d", c.Name, c.UpdatedAt.Unix())
+}
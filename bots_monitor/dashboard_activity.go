@@ -0,0 +1,108 @@
+package bots_monitor
+
+// Recent alerts and hot tokens for web dashboard (in memory, lost on restart)
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/i18n"
+)
+
+const (
+	// dashboardMaxAlerts - recent alerts kept for dashboard
+	dashboardMaxAlerts = 50
+	// dashboardMaxHotTokens - recent hot tokens kept for dashboard
+	dashboardMaxHotTokens = 20
+)
+
+// DashboardAlert - alert sent to Telegram
+type DashboardAlert struct {
+	Time time.Time
+	Kind string // big sales, filtered, hot token, liquidity, listing, suspicious
+	Text string // plain text (HTML tags removed)
+	Link string // trade page, may be empty
+}
+
+// DashboardHotToken - token reported by hot token monitor
+type DashboardHotToken struct {
+	Time            time.Time
+	PoolLpPublicKey string
+	Ticker          string
+	Name            string
+	UniqueAddresses int
+	Score           float64 // hot token score (0-100)
+	MarketcapUsd    float64
+}
+
+var (
+	dashboardAlerts    []DashboardAlert
+	dashboardHotTokens []DashboardHotToken
+	dashboardMutex     sync.RWMutex
+
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+)
+
+// recordDashboardAlert saves sent alert (Telegram HTML message)
+func recordDashboardAlert(kind string, message string, link string) {
+	alert := DashboardAlert{
+		Time: time.Now(),
+		Kind: kind,
+		Text: strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(message, ""))),
+		Link: link,
+	}
+
+	dashboardMutex.Lock()
+	defer dashboardMutex.Unlock()
+	dashboardAlerts = append(dashboardAlerts, alert)
+	if len(dashboardAlerts) > dashboardMaxAlerts {
+		dashboardAlerts = dashboardAlerts[len(dashboardAlerts)-dashboardMaxAlerts:]
+	}
+}
+
+// recordDashboardSwapAlert saves swap alert as one line (action, token, BTC amount)
+func recordDashboardSwapAlert(kind string, swap flashnet.Swap) {
+	message, link := formatSwapMessageMinimal(swap, i18n.English)
+	headline, _, _ := strings.Cut(message, "\n")
+	recordDashboardAlert(kind, headline, link)
+}
+
+// recordDashboardHotToken saves hot token
+func recordDashboardHotToken(hotToken DashboardHotToken) {
+	hotToken.Time = time.Now()
+
+	dashboardMutex.Lock()
+	defer dashboardMutex.Unlock()
+	dashboardHotTokens = append(dashboardHotTokens, hotToken)
+	if len(dashboardHotTokens) > dashboardMaxHotTokens {
+		dashboardHotTokens = dashboardHotTokens[len(dashboardHotTokens)-dashboardMaxHotTokens:]
+	}
+}
+
+// RecentDashboardAlerts returns recent alerts (newest first)
+func RecentDashboardAlerts() []DashboardAlert {
+	dashboardMutex.RLock()
+	defer dashboardMutex.RUnlock()
+
+	alerts := make([]DashboardAlert, 0, len(dashboardAlerts))
+	for i := len(dashboardAlerts) - 1; i >= 0; i-- {
+		alerts = append(alerts, dashboardAlerts[i])
+	}
+	return alerts
+}
+
+// RecentDashboardHotTokens returns recent hot tokens (newest first)
+func RecentDashboardHotTokens() []DashboardHotToken {
+	dashboardMutex.RLock()
+	defer dashboardMutex.RUnlock()
+
+	hotTokens := make([]DashboardHotToken, 0, len(dashboardHotTokens))
+	for i := len(dashboardHotTokens) - 1; i >= 0; i-- {
+		hotTokens = append(hotTokens, dashboardHotTokens[i])
+	}
+	return hotTokens
+}
@@ -0,0 +1,88 @@
+package bots_monitor
+
+// Delivery audit of swap alerts: outcome of every queued alert (sent, failed, held, unsent) is written
+// to event log with chat, message ID, layout and format
+// /audit {swapID} (admin chat) shows whether swap was seen and where its alerts went
+
+import (
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/delivery_audit"
+	"spark-wallet/internal/features/swap_templates"
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+var deliveryStatuses = map[alertStatus]string{
+	alertUnsent: events.DeliveryUnsent,
+	alertSent:   events.DeliverySent,
+	alertFailed: events.DeliveryFailed,
+	alertHeld:   events.DeliveryHeld,
+}
+
+// recordDeliveries writes outcome of every alert of flushed queue to event log
+func (q *alertQueue) recordDeliveries() {
+	var deliveries []events.Delivery
+	for _, key := range q.order {
+		chat := q.chats[key]
+		for i, alert := range chat.alerts {
+			delivery := events.Delivery{
+				SwapID:          alert.swap.ID,
+				PoolLpPublicKey: alert.swap.PoolLpPublicKey,
+				ChatID:          key.chat,
+				Route:           alert.label,
+				Status:          deliveryStatuses[chat.statuses[i]],
+				Layout:          swap_templates.Layout(alert.swap.PoolLpPublicKey),
+			}
+			if i < len(chat.deliveries) {
+				delivery.MessageID = chat.deliveries[i].messageID
+				delivery.Format = chat.deliveries[i].format
+			}
+			if i < len(chat.errors) && chat.errors[i] != nil {
+				delivery.Error = chat.errors[i].Error()
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	recordSwapDeliveries(deliveries)
+}
+
+// recordSwapDeliveries writes deliveries of swap alerts to event log
+func recordSwapDeliveries(deliveries []events.Delivery) {
+	if err := events.RecordDeliveries(deliveries); err != nil {
+		log.LogWarn("Failed to record alert deliveries",
+			zap.Int("count", len(deliveries)),
+			zap.Error(err))
+	}
+}
+
+// handleAuditCommand /audit {swapID}
+func handleAuditCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, swapID string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	swapID = strings.TrimSpace(swapID)
+	trail, err := delivery_audit.Lookup(swapID, delivery_audit.LookbackDays, time.Now())
+	if err != nil {
+		log.LogError("Failed to look up swap deliveries", zap.String("swapID", swapID), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	reply(delivery_audit.Format(trail, delivery_audit.LookbackDays))
+
+	log.LogInfo("Swap audit sent",
+		zap.String("swapID", swapID),
+		zap.Bool("seen", trail.Swap != nil),
+		zap.Int("deliveries", len(trail.Deliveries)),
+		zap.String("chatID", formatChatID(message.Chat.ID)))
+}
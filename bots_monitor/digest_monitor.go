@@ -0,0 +1,140 @@
+package bots_monitor
+
+// Daily digest of previous day's swaps per token (from swap archive)
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/digest"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// digestTopTokens - tokens listed in digest (by volume)
+const digestTopTokens = 10
+
+// RunDailyDigestMonitor posts digest of previous UTC day every day at sendTime (MSK)
+// bot - Telegram for
+// chatID - ID for digest
+// sendTime - time in "HH:MM" (by default "09:00")
+func RunDailyDigestMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, sendTime string) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, daily digest monitor not started")
+		return
+	}
+
+	schedule, err := scheduler.ParseDaily(sendTime, statsLocation)
+	if err != nil {
+		log.LogWarn("Invalid digest send time format, using default 09:00", zap.String("sendTime", sendTime), zap.Error(err))
+		schedule = scheduler.Daily{Hour: 9, Minute: 0, Location: statsLocation}
+	}
+
+	log.LogInfo("Starting Daily Digest Monitor...",
+		zap.String("chatID", chatID),
+		zap.String("sendTime", fmt.Sprintf("%02d:%02d", schedule.Hour, schedule.Minute)))
+
+	scheduler.Default.Run(ctx, scheduler.Job{
+		Name:     "daily_digest",
+		Schedule: schedule,
+		Run: func(ctx context.Context, now time.Time) {
+			// Last complete UTC day (swap archive is split by UTC date)
+			sendDailyDigest(ctx, bot, chatID, now.UTC().AddDate(0, 0, -1).Format("2006-01-02"))
+		},
+	})
+	log.LogInfo("Daily Digest Monitor stopped")
+}
+
+func sendDailyDigest(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, date string) {
+	dayDigest, err := digest.BuildDailyDigest(date)
+	if err != nil {
+		log.LogError("Failed to build daily digest", zap.String("date", date), zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+
+	if dayDigest.Swaps == 0 {
+		log.LogInfo("No archived swaps for daily digest", zap.String("date", date))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(parseChatIDBig(chatID), formatDailyDigestMessage(dayDigest))
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send daily digest", zap.String("date", date), zap.Error(err))
+		return
+	}
+
+	log.LogInfo("Daily digest sent",
+		zap.String("date", date),
+		zap.String("chatID", chatID),
+		zap.Int("swaps", dayDigest.Swaps),
+		zap.Int("tokens", len(dayDigest.Tokens)))
+}
+
+func formatDailyDigestMessage(dayDigest *digest.Digest) string {
+	day, err := time.Parse("2006-01-02", dayDigest.Date)
+	dateStr := dayDigest.Date
+	if err == nil {
+		dateStr = day.Format("02 Jan")
+	}
+
+	var buyBTC, sellBTC float64
+	for _, token := range dayDigest.Tokens {
+		buyBTC += token.BuyBTC
+		sellBTC += token.SellBTC
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("📊 <b>Daily digest</b> on %s (UTC)\n", dateStr))
+	message.WriteString(fmt.Sprintf("Swaps: <code>%d</code>, wallets: <code>%d</code>\n", dayDigest.Swaps, dayDigest.Wallets))
+	message.WriteString(fmt.Sprintf("Buy: <code>%s</code> btc / Sell: <code>%s</code> btc / Net: <code>%s</code> btc\n",
+		amount.FormatBTC(buyBTC), amount.FormatBTC(sellBTC), amount.FormatSignedBTC(buyBTC-sellBTC)))
+
+	tokens := dayDigest.Tokens
+	if len(tokens) > digestTopTokens {
+		tokens = tokens[:digestTopTokens]
+	}
+
+	message.WriteString(fmt.Sprintf("\nTop %d tokens by volume:\n", len(tokens)))
+	for i, token := range tokens {
+		message.WriteString(fmt.Sprintf("%d. <a href=\"https://luminex.io/spark/trade/%s\"><b>%s</b></a> - <code>%s</code> btc\n",
+			i+1, token.PoolLpPublicKey, html.EscapeString(digestTokenName(token.PoolLpPublicKey)), amount.FormatBTC(token.VolumeBTC())))
+		message.WriteString("<blockquote>")
+		message.WriteString(fmt.Sprintf("Buy: %s btc (%d buyers)\n", amount.FormatBTC(token.BuyBTC), token.Buyers))
+		message.WriteString(fmt.Sprintf("Sell: %s btc (%d sellers)\n", amount.FormatBTC(token.SellBTC), token.Sellers))
+		message.WriteString(fmt.Sprintf("Net flow: %s btc\n", amount.FormatSignedBTC(token.NetFlowBTC())))
+
+		action := "buy"
+		if token.BiggestTrade.Type == flashnet.SwapTypeSell {
+			action = "sell"
+		}
+		message.WriteString(fmt.Sprintf("Biggest trade: %s btc %s", amount.FormatBTC(token.BiggestTrade.AmountBTC), action))
+		message.WriteString("</blockquote>")
+	}
+
+	if rest := len(dayDigest.Tokens) - len(tokens); rest > 0 {
+		message.WriteString(fmt.Sprintf("\n<i>+%d more tokens</i>", rest))
+	}
+
+	return message.String()
+}
+
+// digestTokenName returns ticker of pool token (shortened pool key if metadata is unavailable)
+func digestTokenName(poolLpPublicKey string) string {
+	if metadata := luminex.GetTokenMetadata(poolLpPublicKey); metadata != nil && metadata.Ticker != "" {
+		return "{" + metadata.Ticker + "}"
+	}
+	return FormatTokenAddress(poolLpPublicKey)
+}
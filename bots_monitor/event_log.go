@@ -0,0 +1,26 @@
+package bots_monitor
+
+// Alerts sent by monitors are written to event log (data_out/events), see internal/infra/events
+
+import (
+	"html"
+	"strings"
+
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// recordAlertEvent writes sent alert to event log, message is saved as plain text
+func recordAlertEvent(alert events.Alert, message string) {
+	if message != "" {
+		alert.Text = strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(message, "")))
+	}
+	if err := events.RecordAlert(alert); err != nil {
+		log.LogWarn("Failed to record alert event",
+			zap.String("kind", alert.Kind),
+			zap.Int64("chatID", alert.ChatID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,58 @@
+package bots_monitor
+
+// Rotation of event log (data_out/events): old days are gzipped and removed after retention
+
+import (
+	"context"
+	"time"
+
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// RunEventsRotationMonitor gzips event files older than compressAfterDays (0 - not compressed)
+// and removes event files older than retentionDays (0 - kept forever) every interval
+func RunEventsRotationMonitor(ctx context.Context, compressAfterDays int, retentionDays int, interval time.Duration) {
+	if compressAfterDays <= 0 && retentionDays <= 0 {
+		log.LogInfo("Events rotation is disabled")
+		return
+	}
+
+	log.LogInfo("Starting Events Rotation Monitor...",
+		zap.String("dir", events.Dir()),
+		zap.Int("compressAfterDays", compressAfterDays),
+		zap.Int("retentionDays", retentionDays),
+		zap.Duration("interval", interval))
+
+	rotateEvents(compressAfterDays, retentionDays)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Events Rotation Monitor stopped")
+			return
+		case <-ticker.C:
+			rotateEvents(compressAfterDays, retentionDays)
+		}
+	}
+}
+
+func rotateEvents(compressAfterDays int, retentionDays int) {
+	removed, err := events.Prune(retentionDays, time.Now())
+	if err != nil {
+		log.LogError("Failed to remove old event files", zap.Error(err))
+	} else if removed > 0 {
+		log.LogInfo("Removed old event files", zap.Int("count", removed), zap.Int("retentionDays", retentionDays))
+	}
+
+	if compressAfterDays > 0 {
+		if _, err := events.Compress(time.Duration(compressAfterDays) * 24 * time.Hour); err != nil {
+			log.LogError("Failed to compress event files", zap.Error(err))
+		}
+	}
+}
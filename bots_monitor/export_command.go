@@ -0,0 +1,75 @@
+package bots_monitor
+
+// /export {ticker} {from} {to} - balance changes of holders as CSV document for Excel
+
+import (
+	"fmt"
+	"html"
+	"time"
+
+	"spark-wallet/internal/features/holders"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// handleExportCommand /export {ticker} {from} {to}
+func handleExportCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string, rangeArgs []string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	if !holders.IsTickerAllowed(ticker) {
+		reply(fmt.Sprintf("Holders of {%s} are not tracked. Start tracking with /holdersadd %s",
+			html.EscapeString(ticker), html.EscapeString(ticker)))
+		return
+	}
+
+	from, to, err := holders.ParseExportRange(rangeArgs, time.Now())
+	if err != nil {
+		reply(html.EscapeString(fmt.Sprintf("Failed to parse dates: %s", err.Error())))
+		return
+	}
+
+	csvData, rows, err := holders.ExportHolderChangesCSV(ticker, from, to)
+	if err != nil {
+		log.LogError("Failed to export holders changes",
+			zap.String("ticker", ticker),
+			zap.Time("from", from),
+			zap.Time("to", to),
+			zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+	if rows == 0 {
+		reply(fmt.Sprintf("No holders changes of {%s} from %s to %s",
+			html.EscapeString(ticker), from.Format("02.01.2006"), to.Format("02.01.2006")))
+		return
+	}
+
+	doc := tgbotapi.NewDocument(message.Chat.ID, tgbotapi.FileBytes{
+		Name:  holders.ExportFileName(ticker, from, to),
+		Bytes: csvData,
+	})
+	doc.Caption = fmt.Sprintf("{%s} holders changes from %s to %s: %d rows",
+		html.EscapeString(ticker), from.Format("02.01.2006"), to.Format("02.01.2006"), rows)
+	doc.ParseMode = tgbotapi.ModeHTML
+	doc.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(doc); err != nil {
+		log.LogError("Failed to send holders export", zap.String("ticker", ticker), zap.Error(err))
+		reply("Failed to send CSV file, please try again later")
+		return
+	}
+
+	log.LogInfo("Holders export sent via command",
+		zap.String("ticker", ticker),
+		zap.String("from", from.Format("2006-01-02")),
+		zap.String("to", to.Format("2006-01-02")),
+		zap.Int("rows", rows),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
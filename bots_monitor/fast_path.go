@@ -0,0 +1,139 @@
+package bots_monitor
+
+// Fast path for the largest swaps
+// Swap above FastPathMultiplier x chat threshold is sent immediately as minimal alert (no Luminex lookups),
+// then the same message is edited with full enrichment (wallet, holding, marketcap, first buy)
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sync"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/swap_templates"
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// DefaultFastPathMultiplier - swap above threshold x multiplier goes through fast path
+const DefaultFastPathMultiplier = 10.0
+
+var (
+	fastPathMultiplier      = DefaultFastPathMultiplier
+	fastPathMultiplierMutex sync.RWMutex
+)
+
+// SetFastPathMultiplier sets fast path multiplier from config (0 - fast path disabled)
+func SetFastPathMultiplier(multiplier float64) {
+	fastPathMultiplierMutex.Lock()
+	fastPathMultiplier = multiplier
+	fastPathMultiplierMutex.Unlock()
+}
+
+func getFastPathMultiplier() float64 {
+	fastPathMultiplierMutex.RLock()
+	defer fastPathMultiplierMutex.RUnlock()
+	return fastPathMultiplier
+}
+
+// isFastPathSwap checks if swap is large enough for fast path of chat with minBTCAmount threshold
+func isFastPathSwap(swap flashnet.Swap, minBTCAmount float64) bool {
+	multiplier := getFastPathMultiplier()
+	if multiplier <= 0 || minBTCAmount <= 0 {
+		return false
+	}
+	return getBTCAmountFromSwap(swap) >= minBTCAmount*multiplier
+}
+
+// formatSwapMessageMinimal formats alert in language of chat without network lookups
+// Token name is taken from local token cache only (poolLpPublicKey if not cached)
+func formatSwapMessageMinimal(swap flashnet.Swap, lang i18n.Lang) (string, string) {
+	tradeLink := fmt.Sprintf("https://luminex.io/spark/trade/%s", swap.PoolLpPublicKey)
+
+	emoji, action := "🔄", i18n.T(lang, "swap.swap")
+	buyEmoji, sellEmoji := swap_templates.Emojis(swap.PoolLpPublicKey)
+	switch swap.GetSwapType() {
+	case flashnet.SwapTypeBuy:
+		emoji, action = buyEmoji, i18n.T(lang, "swap.buy")
+	case flashnet.SwapTypeSell:
+		emoji, action = sellEmoji, i18n.T(lang, "swap.sell")
+	}
+
+	tokenName := swap.PoolLpPublicKey
+	if metadata := luminex.GetCachedTokenMetadata(swap.PoolLpPublicKey); metadata != nil && metadata.Ticker != "" {
+		tokenName = metadata.Ticker
+		if metadata.Name != "" {
+			tokenName = fmt.Sprintf("%s {%s}", metadata.Name, metadata.Ticker)
+		}
+	}
+
+	btcAmount := amount.FormatBTC(getBTCAmountFromSwap(swap))
+	usdAmount := ""
+	if usd := formatBTCInUSD(getBTCAmountFromSwap(swap), true); usd != "" {
+		usdAmount = " ≈ " + usd
+	}
+	message := fmt.Sprintf("%s %s %s - %s btc%s\n<i>%s</i>", emoji, action, html.EscapeString(tokenName), btcAmount, usdAmount, i18n.T(lang, "swap.loading"))
+	return message, tradeLink
+}
+
+// tradeKeyboard - keyboard with link to token trade page, button in language of chat
+func tradeKeyboard(tradeLink string, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "swap.trade_button"), tradeLink),
+		),
+	)
+}
+
+// sendSwapAlert sends swap alert to chat within its rate limit (see sendToChat)
+// Fast path swaps are sent as minimal alert first and edited once format returns full message
+// format - returns full message with keyboard in language of chat
+func sendSwapAlert(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, swap flashnet.Swap, minBTCAmount float64, format func(lang i18n.Lang) swapAlert) (alertDelivery, error) {
+	chat := parseChatIDBig(chatID)
+	lang := i18n.ChatLang(chat)
+
+	if !isFastPathSwap(swap, minBTCAmount) {
+		alert := format(lang)
+		msg := tgbotapi.NewMessage(chat, alert.Text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyMarkup = alert.Keyboard
+		sent, err := sendToChat(ctx, bot, chat, msg)
+		return alertDelivery{messageID: sent.MessageID, format: events.DeliveryFormatMessage}, err
+	}
+
+	minimal, tradeLink := formatSwapMessageMinimal(swap, lang)
+	msg := tgbotapi.NewMessage(chat, minimal)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	msg.ReplyMarkup = tradeKeyboard(tradeLink, lang)
+	sent, err := sendToChat(ctx, bot, chat, msg)
+	if err != nil {
+		return alertDelivery{format: events.DeliveryFormatFastPath}, err
+	}
+	log.LogInfo("Sent fast path swap alert",
+		zap.String("swapID", swap.ID),
+		zap.String("chatID", chatID),
+		zap.Int("messageID", sent.MessageID))
+
+	alert := format(lang)
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chat, sent.MessageID, alert.Text, alert.Keyboard)
+	edit.ParseMode = tgbotapi.ModeHTML
+	edit.DisableWebPagePreview = true
+	if _, err := sendToChat(ctx, bot, chat, edit); err != nil {
+		// Minimal alert is already delivered - enrichment failure is not a send failure
+		log.LogWarn("Failed to edit fast path swap alert",
+			zap.String("swapID", swap.ID),
+			zap.String("chatID", chatID),
+			zap.Int("messageID", sent.MessageID),
+			zap.Error(err))
+	}
+	return alertDelivery{messageID: sent.MessageID, format: events.DeliveryFormatFastPath}, nil
+}
@@ -0,0 +1,73 @@
+package bots_monitor
+
+// Funding warning for buy alerts ("⚠️ Funded by flagged wallet (rug)")
+// Warning is computed once per swap, funding source of wallet is cached by risk package
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/risk"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxFundingWarningCache - swaps kept in warning cache (cache is reset when exceeded)
+	maxFundingWarningCache = 1000
+	// fundingCheckTimeout - time limit of funding lookup, alert is sent without warning on timeout
+	fundingCheckTimeout = 10 * time.Second
+)
+
+var (
+	fundingWarningCache      = make(map[string]string) // swapID -> warning ("" - no warning)
+	fundingWarningCacheMutex sync.Mutex
+)
+
+// fundingWarningForSwap returns warning line for buy of wallet funded by flagged wallet
+func fundingWarningForSwap(swap flashnet.Swap) string {
+	if swap.GetSwapType() != flashnet.SwapTypeBuy {
+		return ""
+	}
+
+	fundingWarningCacheMutex.Lock()
+	defer fundingWarningCacheMutex.Unlock()
+
+	if warning, exists := fundingWarningCache[swap.ID]; exists {
+		return warning
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fundingCheckTimeout)
+	defer cancel()
+
+	warning := ""
+	flag, err := risk.CheckFunding(ctx, swap.SwapperPublicKey)
+	if err != nil {
+		log.LogDebug("Failed to check wallet funding source",
+			zap.String("swapperPublicKey", swap.SwapperPublicKey),
+			zap.Error(err))
+	} else if flag != nil {
+		funderSuffix := flag.Funder.PublicKey
+		if len(funderSuffix) > 6 {
+			funderSuffix = funderSuffix[len(funderSuffix)-6:]
+		}
+		warning = fmt.Sprintf("⚠️ Funded by flagged wallet (%s, ...%s) - %s btc\n",
+			flag.Funder.Reason, funderSuffix, amount.FormatBTC(float64(flag.AmountSats)/1e8))
+		log.LogInfo("Buyer wallet funded by flagged wallet",
+			zap.String("swapID", swap.ID),
+			zap.String("wallet", swap.SwapperPublicKey),
+			zap.String("funder", flag.Funder.PublicKey),
+			zap.String("reason", flag.Funder.Reason))
+	}
+
+	if len(fundingWarningCache) >= maxFundingWarningCache {
+		fundingWarningCache = make(map[string]string)
+	}
+	fundingWarningCache[swap.ID] = warning
+	return warning
+}
@@ -0,0 +1,170 @@
+package bots_monitor
+
+// Liveness endpoint for Docker/Kubernetes healthchecks (app.health_addr), no auth, read only
+// GET /healthz - last swaps request, last Telegram message, JWT validity and heartbeat of monitors
+// Returns 503 when a monitor with heartbeat is stalled, swaps were not fetched for stallAfter or JWT has expired,
+// so orchestrator restarts the bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/infra/buildinfo"
+	"spark-wallet/internal/infra/health"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// swapFeedMonitors - monitors that request swaps, swaps freshness is checked only when one of them runs
+var swapFeedMonitors = []string{"big_sales", "swap_collector"}
+
+type healthServer struct {
+	registry    *MonitorRegistry
+	client      *flashnet.Client
+	jwtRequired bool
+	stallAfter  time.Duration
+	startedAt   time.Time
+}
+
+type healthMonitor struct {
+	Name        string `json:"name"`
+	LastSuccess string `json:"last_success,omitempty"` // RFC3339
+	Heartbeat   int64  `json:"heartbeat_seconds,omitempty"`
+	Paused      bool   `json:"paused,omitempty"`
+	Stalled     bool   `json:"stalled"`
+}
+
+type healthJWT struct {
+	Required  bool   `json:"required"`
+	Valid     bool   `json:"valid"`
+	ExpiresAt string `json:"expires_at,omitempty"` // RFC3339
+}
+
+type healthResponse struct {
+	Status           string          `json:"status"` // ok or unhealthy
+	Problems         []string        `json:"problems,omitempty"`
+	Version          string          `json:"version"`
+	UptimeSeconds    int64           `json:"uptime_seconds"`
+	LastSwapsFetched string          `json:"last_swaps_fetched,omitempty"` // RFC3339
+	LastTelegramSent string          `json:"last_telegram_sent,omitempty"` // RFC3339
+	JWT              healthJWT       `json:"jwt"`
+	Monitors         []healthMonitor `json:"monitors"`
+}
+
+// RunHealthServer serves /healthz on addr until ctx is cancelled
+// client - default Flashnet client (JWT validity), jwtRequired - bot runs with authentication
+// stallAfter - max time without successful swaps request
+func RunHealthServer(ctx context.Context, addr string, registry *MonitorRegistry, client *flashnet.Client, jwtRequired bool, stallAfter time.Duration) {
+	if addr == "" {
+		log.LogInfo("Health address is empty, health server disabled")
+		return
+	}
+
+	server := &healthServer{
+		registry:    registry,
+		client:      client,
+		jwtRequired: jwtRequired,
+		stallAfter:  stallAfter,
+		startedAt:   time.Now(),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", server.handleHealthz)
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.LogWarn("Failed to shut down health server", zap.Error(err))
+		}
+	}()
+
+	log.LogInfo("Starting health server...", zap.String("addr", addr), zap.Duration("stallAfter", stallAfter))
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.LogError("Health server stopped", zap.Error(err))
+		return
+	}
+	log.LogInfo("Health server stopped")
+}
+
+func (s *healthServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	response := healthResponse{
+		Status:        "ok",
+		Version:       buildinfo.Get().Version,
+		UptimeSeconds: int64(buildinfo.Uptime().Seconds()),
+		JWT:           healthJWT{Required: s.jwtRequired},
+		Monitors:      []healthMonitor{},
+	}
+
+	swapFeedRunning := false
+	for _, status := range s.registry.Statuses() {
+		monitor := healthMonitor{
+			Name:      status.Name,
+			Heartbeat: int64(status.Heartbeat.Seconds()),
+			Paused:    status.Paused,
+			Stalled:   status.Stalled,
+		}
+		if !status.LastSuccess.IsZero() {
+			monitor.LastSuccess = status.LastSuccess.UTC().Format(time.RFC3339)
+		}
+		if status.Stalled {
+			response.Problems = append(response.Problems, fmt.Sprintf("monitor %s: no success for more than %s", status.Name, status.Heartbeat))
+		}
+		for _, name := range swapFeedMonitors {
+			if status.Name == name && !status.Paused {
+				swapFeedRunning = true
+			}
+		}
+		response.Monitors = append(response.Monitors, monitor)
+	}
+
+	lastSwaps := health.LastSwapsFetched()
+	if !lastSwaps.IsZero() {
+		response.LastSwapsFetched = lastSwaps.UTC().Format(time.RFC3339)
+	}
+	if swapFeedRunning && s.stallAfter > 0 {
+		alive := s.startedAt
+		if lastSwaps.After(alive) {
+			alive = lastSwaps
+		}
+		if now.Sub(alive) > s.stallAfter {
+			response.Problems = append(response.Problems, fmt.Sprintf("swaps: not fetched for more than %s", s.stallAfter))
+		}
+	}
+	if lastSent := health.LastTelegramSent(); !lastSent.IsZero() {
+		response.LastTelegramSent = lastSent.UTC().Format(time.RFC3339)
+	}
+
+	if s.client != nil {
+		if token := s.client.GetJWT(); token != "" {
+			if expiresAt, err := flashnet.GetTokenExpirationTime(token); err == nil {
+				response.JWT.ExpiresAt = time.Unix(expiresAt, 0).UTC().Format(time.RFC3339)
+				response.JWT.Valid = now.Before(time.Unix(expiresAt, 0))
+			}
+		}
+	}
+	if s.jwtRequired && !response.JWT.Valid {
+		response.Problems = append(response.Problems, "jwt: missing or expired")
+	}
+
+	status := http.StatusOK
+	if len(response.Problems) > 0 {
+		response.Status = "unhealthy"
+		status = http.StatusServiceUnavailable
+	}
+	writeAdminJSON(w, status, response)
+}
@@ -4,83 +4,122 @@ package bots_monitor
 // on swap'
 
 import (
+	"context"
 	"spark-wallet/internal/features/holders"
 	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 )
 
+const (
+	// holdersCheckInterval - interval between balance checks of one ticker
+	holdersCheckInterval = 24 * time.Hour
+	// holdersScheduleTick - how often due tickers are looked up
+	holdersScheduleTick = time.Hour
+)
+
 // RunHoldersDynamicMonitor
 // on swap' (saveHolderFromSwap)
-func RunHoldersDynamicMonitor() {
+// Tracked tickers come from config (HOLDERS_TICKERS) and /holdersadd
+// Every ticker is checked once per holdersCheckInterval after its last successful check (holders_checks.json),
+// failed checks are retried every hour and checks missed while process was down are run on startup as catch-up
+func RunHoldersDynamicMonitor(ctx context.Context) {
 	log.LogInfo("Starting Holders Dynamic Monitor...")
 
-	// Load tokens
-	tokenIDsFile := "data_out/holders_module/id_tokens.json"
-	tokenIDs, err := holders.LoadTokenIdentifiers(tokenIDsFile)
-	if err != nil {
-		log.LogError("Failed to load token identifiers", zap.Error(err))
+	log.LogSuccess("Holders dynamic monitor is running",
+		zap.String("status", "active"),
+		zap.Duration("checkInterval", holdersCheckInterval),
+		zap.Duration("scheduleTick", holdersScheduleTick),
+		zap.String("note", "Works parallel with swap-based tracking"))
+
+	// Initial run checks due holders right away (catch-up of missed checks on startup)
+	scheduler.Default.Run(ctx, scheduler.Job{
+		Name:       "holders_dynamic",
+		Schedule:   scheduler.Interval(holdersScheduleTick),
+		RunOnStart: true,
+		Run:        checkTrackedHolders,
+	})
+	log.LogInfo("Holders dynamic monitor stopped")
+}
+
+// checkTrackedHolders checks balance of every tracked ticker that is due
+// Tickers list is re-read on every run, so /holdersadd works without restart
+func checkTrackedHolders(ctx context.Context, now time.Time) {
+	tickers := holders.GetAllowedTickers()
+	if len(tickers) == 0 {
+		log.LogWarn("No tracked tickers - holders dynamic check skipped",
+			zap.String("note", "Set HOLDERS_TICKERS or use /holdersadd to enable holders monitoring"))
 		return
 	}
 
-	if len(tokenIDs) == 0 {
-		log.LogWarn("No token identifiers found - holders dynamic monitor will not run",
-			zap.String("file", tokenIDsFile),
-			zap.String("note", "Create this file with token identifiers to enable holders monitoring"))
-		return
+	// tokenIdentifier by ticker from id_tokens.json (optional)
+	tokenIDs, err := holders.LoadTokenIdentifiers(holders.TokenIDsFile())
+	if err != nil {
+		log.LogWarn("Failed to load token identifiers", zap.Error(err))
+		tokenIDs = map[string]string{}
+	}
+	identifierByTicker := make(map[string]string, len(tokenIDs))
+	for tokenIdentifier, ticker := range tokenIDs {
+		identifierByTicker[strings.ToUpper(ticker)] = tokenIdentifier
 	}
 
-	log.LogInfo("Loaded token identifiers", zap.Int("count", len(tokenIDs)))
+	var lastErr error
+	checked := 0
+	for _, ticker := range tickers {
+		if ctx.Err() != nil {
+			return
+		}
 
-	// Check (ASTY, SOON, BITTY)
-	// forceCheck = true, if
-	log.LogInfo("Performing initial check of all holders (force check on startup)...")
-	for tokenIdentifier, ticker := range tokenIDs {
-		// Check, ticker for
-		if !holders.IsTickerAllowed(ticker) {
-			log.LogDebug("Ticker not in allowed list, skipping", zap.String("ticker", ticker))
+		lastCheck, err := holders.GetLastSuccessfulCheck(ticker)
+		if err != nil {
+			log.LogWarn("Failed to load last holders check", zap.String("ticker", ticker), zap.Error(err))
+		}
+		if !lastCheck.IsZero() && now.Sub(lastCheck) < holdersCheckInterval {
 			continue
 		}
 
-		// Check balance forceCheck = true
-		// tokenIdentifier in CheckHoldersBalance, for
-		if err := holders.CheckHoldersBalanceWithForce(ticker, tokenIdentifier, true); err != nil {
+		// Scheduled check runs within one tick after interval, later than that some check was missed
+		catchUp := !lastCheck.IsZero() && now.Sub(lastCheck) >= holdersCheckInterval+holdersScheduleTick
+
+		tokenIdentifier := identifierByTicker[ticker]
+		log.LogDebug("Checking holders balance for token",
+			zap.String("ticker", ticker),
+			zap.String("tokenIdentifier", tokenIdentifier),
+			zap.Time("lastCheck", lastCheck),
+			zap.Bool("catchUp", catchUp))
+
+		if catchUp {
+			log.LogInfo("Catching up missed holders check",
+				zap.String("ticker", ticker),
+				zap.Time("lastCheck", lastCheck),
+				zap.Duration("missedFor", now.Sub(lastCheck).Truncate(time.Minute)))
+			err = holders.CheckHoldersBalanceCatchUp(ticker, tokenIdentifier, lastCheck)
+		} else {
+			err = holders.CheckHoldersBalanceWithForce(ticker, tokenIdentifier, true)
+		}
+		if err != nil {
 			log.LogError("Failed to check holders balance", zap.String("ticker", ticker), zap.Error(err))
+			lastErr = err
 			continue
 		}
-	}
-
-	ticker := time.NewTicker(24 * time.Hour)
-	defer ticker.Stop()
+		checked++
 
-	log.LogSuccess("Holders dynamic monitor is running",
-		zap.String("status", "active"),
-		zap.String("checkInterval", "24h"),
-		zap.String("note", "Works parallel with swap-based tracking"))
-
-	for {
-		select {
-		case <-ticker.C:
-			// Check (ASTY, SOON, BITTY)
-			log.LogInfo("Running daily holders balance check...")
-			for tokenIdentifier, ticker := range tokenIDs {
-				// Check, ticker for
-				if !holders.IsTickerAllowed(ticker) {
-					log.LogDebug("Ticker not in allowed list, skipping", zap.String("ticker", ticker))
-					continue
-				}
-
-				log.LogDebug("Checking holders balance for token", zap.String("ticker", ticker), zap.String("tokenIdentifier", tokenIdentifier))
-
-				// Check balance
-				// tokenIdentifier in CheckHoldersBalance, for
-				if err := holders.CheckHoldersBalance(ticker, tokenIdentifier); err != nil {
-					log.LogError("Failed to check holders balance", zap.String("ticker", ticker), zap.Error(err))
-					continue
-				}
-			}
-			log.LogInfo("Daily holders balance check completed")
+		if err := holders.RecordSuccessfulCheck(ticker, now); err != nil {
+			log.LogWarn("Failed to save last holders check", zap.String("ticker", ticker), zap.Error(err))
 		}
+		// Balances are fresh after check, distribution of the day is kept for /top
+		if err := holders.SaveHoldersSnapshot(ticker, now); err != nil {
+			log.LogWarn("Failed to save holders snapshot", zap.String("ticker", ticker), zap.Error(err))
+		}
+	}
+
+	// Run fails only if no due ticker was checked
+	if checked == 0 && lastErr != nil {
+		ReportMonitorError(ctx, lastErr)
+	} else {
+		ReportMonitorSuccess(ctx)
 	}
 }
@@ -0,0 +1,191 @@
+package bots_monitor
+
+// Scheduled holders reports: /flash report of configured tickers is posted to filtered chat every day
+// Without send time the report of check day is posted right after daily balance check of ticker,
+// with send time (MSK) the report of previous day is posted at that time
+// Posted dates are kept in holders_reports.json, so a day is reported once across restarts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/i18n"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// holdersReportTick - how often finished balance checks are looked up when report follows the check
+const holdersReportTick = 5 * time.Minute
+
+// HoldersReportConfig - tickers and schedule of holders reports (telegram.holders_report_*)
+type HoldersReportConfig struct {
+	Tickers   []string
+	SendTime  string // "HH:MM" (MSK), empty - right after daily balance check
+	SkipEmpty bool   // report of day without holder changes is not posted
+}
+
+// RunHoldersReportMonitor posts holders reports of configured tickers to chat
+func RunHoldersReportMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, client *flashnet.Client, cfg HoldersReportConfig) {
+	if bot == nil || chatID == "" || len(cfg.Tickers) == 0 {
+		log.LogWarn("Bot, chat ID or tickers are empty, holders report monitor not started")
+		return
+	}
+
+	for _, ticker := range cfg.Tickers {
+		if !holders.IsTickerAllowed(ticker) {
+			log.LogWarn("Holders report ticker is not tracked, enable it with /holdersadd or app.holders_tickers",
+				zap.String("ticker", ticker))
+		}
+	}
+
+	job := scheduler.Job{
+		Name:       "holders_report",
+		Schedule:   scheduler.Interval(holdersReportTick),
+		RunOnStart: true,
+		Run: func(ctx context.Context, now time.Time) {
+			postHoldersReportsAfterCheck(ctx, bot, chatID, client, cfg, now)
+		},
+	}
+	sendTime := "after balance check"
+	if cfg.SendTime != "" {
+		schedule, err := scheduler.ParseDaily(cfg.SendTime, statsLocation)
+		if err != nil {
+			log.LogWarn("Invalid holders report send time, posting after balance check", zap.String("sendTime", cfg.SendTime), zap.Error(err))
+		} else {
+			sendTime = fmt.Sprintf("%02d:%02d", schedule.Hour, schedule.Minute)
+			job = scheduler.Job{
+				Name:     "holders_report",
+				Schedule: schedule,
+				Run: func(ctx context.Context, now time.Time) {
+					// Last complete day (holder changes are dated in local time)
+					postHoldersReports(ctx, bot, chatID, client, cfg, now.In(time.Local).AddDate(0, 0, -1).Format("2006-01-02"))
+				},
+			}
+		}
+	}
+
+	log.LogInfo("Starting Holders Report Monitor...",
+		zap.String("chatID", chatID),
+		zap.Strings("tickers", cfg.Tickers),
+		zap.String("sendTime", sendTime),
+		zap.Bool("skipEmpty", cfg.SkipEmpty))
+
+	scheduler.Default.Run(ctx, job)
+	log.LogInfo("Holders Report Monitor stopped")
+}
+
+// postHoldersReportsAfterCheck posts report of check day of every ticker whose balance check is done and not reported yet
+func postHoldersReportsAfterCheck(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, client *flashnet.Client, cfg HoldersReportConfig, now time.Time) {
+	var lastErr error
+	for _, ticker := range cfg.Tickers {
+		if ctx.Err() != nil {
+			return
+		}
+		if !holders.IsTickerAllowed(ticker) {
+			continue
+		}
+
+		lastCheck, err := holders.GetLastSuccessfulCheck(ticker)
+		if err != nil {
+			log.LogWarn("Failed to load last holders check for report", zap.String("ticker", ticker), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		lastPosted, err := holders.GetLastPostedReport(ticker)
+		if err != nil {
+			log.LogWarn("Failed to load last posted holders report", zap.String("ticker", ticker), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		date, due := holders.ReportDateAfterCheck(lastCheck, lastPosted, now)
+		if !due {
+			continue
+		}
+		if err := postHoldersReport(bot, chatID, client, cfg, ticker, date); err != nil {
+			lastErr = err
+		}
+	}
+
+	if lastErr != nil {
+		ReportMonitorError(ctx, lastErr)
+	} else {
+		ReportMonitorSuccess(ctx)
+	}
+}
+
+// postHoldersReports posts report of date of every tracked ticker not reported yet
+func postHoldersReports(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, client *flashnet.Client, cfg HoldersReportConfig, date string) {
+	var lastErr error
+	for _, ticker := range cfg.Tickers {
+		if ctx.Err() != nil {
+			return
+		}
+		if !holders.IsTickerAllowed(ticker) {
+			log.LogWarn("Holders report skipped, ticker is not tracked", zap.String("ticker", ticker))
+			continue
+		}
+
+		if lastPosted, err := holders.GetLastPostedReport(ticker); err == nil && lastPosted >= date {
+			continue
+		}
+		if err := postHoldersReport(bot, chatID, client, cfg, ticker, date); err != nil {
+			lastErr = err
+		}
+	}
+
+	if lastErr != nil {
+		ReportMonitorError(ctx, lastErr)
+	} else {
+		ReportMonitorSuccess(ctx)
+	}
+}
+
+// postHoldersReport sends holders report of ticker for date and records it as posted
+func postHoldersReport(bot *tgbotapi.BotAPI, chatID string, client *flashnet.Client, cfg HoldersReportConfig, ticker string, date string) error {
+	ticker = strings.ToUpper(ticker)
+
+	if cfg.SkipEmpty {
+		changes, err := holders.CountHoldersChanges(ticker, date)
+		if err != nil {
+			log.LogError("Failed to count holder changes for report", zap.String("ticker", ticker), zap.String("date", date), zap.Error(err))
+			return err
+		}
+		if changes == 0 {
+			log.LogInfo("No holder changes, holders report skipped", zap.String("ticker", ticker), zap.String("date", date))
+			if err := holders.RecordPostedReport(ticker, date); err != nil {
+				log.LogWarn("Failed to save posted holders report", zap.String("ticker", ticker), zap.Error(err))
+			}
+			return nil
+		}
+	}
+
+	report, err := holders.GenerateHoldersReport(ticker, date, client, i18n.ChatLang(parseChatIDBig(chatID)))
+	if err != nil {
+		log.LogError("Failed to generate scheduled holders report", zap.String("ticker", ticker), zap.String("date", date), zap.Error(err))
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(parseChatIDBig(chatID), report)
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send scheduled holders report", zap.String("ticker", ticker), zap.String("date", date), zap.Error(err))
+		return err
+	}
+
+	if err := holders.RecordPostedReport(ticker, date); err != nil {
+		log.LogWarn("Failed to save posted holders report", zap.String("ticker", ticker), zap.Error(err))
+	}
+	log.LogInfo("Scheduled holders report sent",
+		zap.String("ticker", ticker),
+		zap.String("date", date),
+		zap.String("chatID", chatID))
+	return nil
+}
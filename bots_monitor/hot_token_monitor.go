@@ -1,10 +1,14 @@
 package bots_monitor
 
 import (
+	"context"
 	"fmt"
+	"spark-wallet/internal/amount"
 	"spark-wallet/internal/clients_api/flashnet"
 	"spark-wallet/internal/clients_api/luminex"
 	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/infra/events"
+	"spark-wallet/internal/infra/handoff"
 	"spark-wallet/internal/infra/log"
 	"strings"
 	"time"
@@ -22,9 +26,9 @@ func FormatTokenAddress(address string) string {
 }
 
 // FormatHotTokenMessage
-func FormatHotTokenMessage(poolData *hot_token.LuminexFullPoolResponse) string {
+func FormatHotTokenMessage(poolData *luminex.LuminexPoolResponse) string {
 	// token BTC)
-	var tokenMeta *hot_token.LuminexFullTokenMetadata
+	var tokenMeta *luminex.LuminexTokenMetadata
 	var marketcap float64
 
 	if poolData.AssetBAddress == flashnet.NativeTokenAddress {
@@ -84,71 +88,86 @@ func FormatHotTokenMessage(poolData *hot_token.LuminexFullPoolResponse) string {
 // bot - Telegram bot for sending notifications
 // client - Flashnet API client
 // filteredChatID - Chat ID for sending notifications (FILTERED_CHAT_ID)
-// swapsCount - Minimum number of swaps required for hot token
-// minAddresses - Minimum number of unique addresses required
+// config - minimum swaps/addresses to score token, score to alert, cooldown and score increase to alert again
 // checkInterval - Interval between checks in seconds
-func RunHotTokenMonitor(bot *tgbotapi.BotAPI, client *flashnet.Client, filteredChatID string, swapsCount int, minAddresses int, checkInterval int) {
+func RunHotTokenMonitor(ctx context.Context, bot *tgbotapi.BotAPI, client *flashnet.Client, filteredChatID string, config hot_token.ScoreConfig, checkInterval int) {
 	log.LogInfo("Starting Hot Token Monitor...",
 		zap.String("filteredChatID", filteredChatID),
-		zap.Int("swapsCount", swapsCount),
-		zap.Int("minAddresses", minAddresses),
+		zap.Int("swapsCount", config.MinSwaps),
+		zap.Int("minAddresses", config.MinAddresses),
+		zap.Float64("minScore", config.MinScore),
+		zap.Duration("cooldown", config.Cooldown),
+		zap.Float64("scoreIncrease", config.ScoreIncrease),
 		zap.Int("checkInterval", checkInterval),
 		zap.String("note", "Checking ALL tokens from recent swaps"))
 
-	sentNotifications := make(map[string]time.Time)
-	notificationCooldown := 1 * time.Hour // Cooldown between notifications for the same token
+	// Alerts of previous process (state handoff) newer than saved state
+	if err := hot_token.RestoreAlerts(handoff.RestoredHotTokenNotifications()); err != nil {
+		log.LogWarn("Failed to restore hot token alerts", zap.Error(err))
+	}
 
 	ticker := time.NewTicker(time.Duration(checkInterval) * time.Second)
 	defer ticker.Stop()
 
 	// Initial check
-	checkHotTokens(bot, client, filteredChatID, swapsCount, minAddresses, sentNotifications, notificationCooldown)
+	checkHotTokens(ctx, bot, client, filteredChatID, config)
 
 	// Periodic checks
-	for range ticker.C {
-		checkHotTokens(bot, client, filteredChatID, swapsCount, minAddresses, sentNotifications, notificationCooldown)
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Hot Token Monitor stopped")
+			return
+		case <-ticker.C:
+			checkHotTokens(ctx, bot, client, filteredChatID, config)
+		}
 	}
 }
 
-// checkHotTokens checks ALL tokens from recent swaps and sends notifications for hot tokens
-func checkHotTokens(bot *tgbotapi.BotAPI, client *flashnet.Client, filteredChatID string,
-	swapsCount int, minAddresses int,
-	sentNotifications map[string]time.Time, cooldown time.Duration) {
-
+// checkHotTokens scores ALL tokens from recent swaps and sends notifications for hot tokens
+func checkHotTokens(ctx context.Context, bot *tgbotapi.BotAPI, client *flashnet.Client, filteredChatID string, config hot_token.ScoreConfig) {
 	// Get all unique pools from recent swaps AND the swaps themselves
 	// This way we only make ONE API request instead of one per pool
-	uniquePools, swaps, err := hot_token.GetAllUniquePoolsFromSwaps(client, swapsCount)
+	uniquePools, swaps, err := hot_token.GetAllUniquePoolsFromSwaps(client, config.MinSwaps)
 	if err != nil {
 		log.LogWarn("Failed to get unique pools from swaps", zap.Error(err))
+		ReportMonitorError(ctx, err)
 		return
 	}
+	ReportMonitorSuccess(ctx)
 
 	if len(uniquePools) == 0 {
 		log.LogDebug("No pools found in recent swaps")
 		return
 	}
 
+	now := time.Now()
+	activities := hot_token.CollectActivity(swaps, now)
+
 	log.LogDebug("Checking hot token conditions",
 		zap.Int("totalPools", len(uniquePools)),
+		zap.Int("activePools", len(activities)),
 		zap.Int("totalSwaps", len(swaps)),
-		zap.Int("swapsCount", swapsCount),
-		zap.Int("minAddresses", minAddresses))
+		zap.Int("swapsCount", config.MinSwaps),
+		zap.Int("minAddresses", config.MinAddresses))
 
-	// Check each pool for hot token conditions using already fetched swaps
-	// This avoids making additional API requests for each pool
-	for _, poolLpPublicKey := range uniquePools {
-		isHot, uniqueCount := hot_token.CheckHotTokenConditionsFromSwaps(swaps, poolLpPublicKey, swapsCount, minAddresses)
-
-		if !isHot {
+	// Score pools with enough activity using already fetched swaps
+	for poolLpPublicKey, activity := range activities {
+		if !activity.Eligible(config) {
 			continue
 		}
 
-		// Check cooldown for this token
-		lastSent, exists := sentNotifications[poolLpPublicKey]
-		if exists && time.Since(lastSent) < cooldown {
-			log.LogDebug("Hot token notification skipped (cooldown)",
+		// Pool data is not requested for tokens in cooldown
+		inCooldown, err := hot_token.InCooldown(poolLpPublicKey, config.Cooldown, now)
+		if err != nil {
+			log.LogWarn("Failed to check hot token cooldown",
 				zap.String("poolLpPublicKey", poolLpPublicKey),
-				zap.Time("lastSent", lastSent))
+				zap.Error(err))
+			continue
+		}
+		if inCooldown {
+			log.LogDebug("Hot token notification skipped (cooldown)",
+				zap.String("poolLpPublicKey", poolLpPublicKey))
 			continue
 		}
 
@@ -159,6 +178,26 @@ func checkHotTokens(bot *tgbotapi.BotAPI, client *flashnet.Client, filteredChatI
 				zap.Error(err))
 			continue
 		}
+		tokenMeta, _, _ := poolData.TokenSide()
+
+		score, alert, err := hot_token.Evaluate(activity, poolData.Extra.MarketCapUsd, tokenMeta.AggPriceChange24h, config, now)
+		if err != nil {
+			log.LogWarn("Failed to evaluate hot token score",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			continue
+		}
+		log.LogDebug("Hot token score",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.String("ticker", tokenMeta.Ticker),
+			zap.Float64("score", score.Total),
+			zap.Int("uniqueBuyers", activity.UniqueBuyers),
+			zap.Float64("buyVolumeBTC", activity.BuyVolumeBTC),
+			zap.Float64("marketcapVelocity", score.MarketcapVelocity),
+			zap.Bool("alert", alert))
+		if !alert {
+			continue
+		}
 
 		// Format message
 		message := FormatHotTokenMessage(poolData)
@@ -167,8 +206,12 @@ func checkHotTokens(bot *tgbotapi.BotAPI, client *flashnet.Client, filteredChatI
 				zap.String("poolLpPublicKey", poolLpPublicKey))
 			continue
 		}
+		message += "\n" + formatHotTokenScore(activity, score)
 
 		chatID := parseChatIDBig(filteredChatID)
+		if holdAlert(bot, chatID, "hot_token", poolLpPublicKey, tokenMeta.Ticker, message) {
+			continue
+		}
 
 		msg := tgbotapi.NewMessage(chatID, message)
 		msg.ParseMode = tgbotapi.ModeHTML
@@ -181,15 +224,47 @@ func checkHotTokens(bot *tgbotapi.BotAPI, client *flashnet.Client, filteredChatI
 			continue
 		}
 
-		sentNotifications[poolLpPublicKey] = time.Now()
+		sentAt := time.Now()
+		if err := hot_token.MarkAlerted(poolLpPublicKey, score.Total, sentAt); err != nil {
+			log.LogWarn("Failed to save hot token alert",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+		}
+		handoff.RecordHotTokenNotification(poolLpPublicKey, sentAt)
+		recordDashboardAlert("hot token", message, fmt.Sprintf("https://luminex.io/spark/trade/%s", poolLpPublicKey))
+		recordAlertEvent(events.Alert{
+			Kind:            "hot_token",
+			ChatID:          chatID,
+			PoolLpPublicKey: poolLpPublicKey,
+			Ticker:          tokenMeta.Ticker,
+		}, message)
+		recordDashboardHotToken(DashboardHotToken{
+			PoolLpPublicKey: poolLpPublicKey,
+			Ticker:          tokenMeta.Ticker,
+			Name:            tokenMeta.Name,
+			UniqueAddresses: activity.UniqueAddresses,
+			Score:           score.Total,
+			MarketcapUsd:    poolData.Extra.MarketCapUsd,
+		})
 
 		log.LogInfo("Hot token notification sent",
 			zap.String("poolLpPublicKey", poolLpPublicKey),
-			zap.Int("uniqueAddresses", uniqueCount),
+			zap.Float64("score", score.Total),
+			zap.Int("uniqueAddresses", activity.UniqueAddresses),
 			zap.String("chatID", filteredChatID))
 	}
 }
 
+// formatHotTokenScore - score line of hot token message
+// Score: 72 · 8 buyers · 0.034 btc · mcap +12%/h
+func formatHotTokenScore(activity *hot_token.Activity, score hot_token.Score) string {
+	line := fmt.Sprintf("Score: %.0f · %d buyers · %s btc", score.Total, activity.UniqueBuyers, amount.FormatBTC(activity.BuyVolumeBTC))
+	if score.MarketcapVelocity >= 1 {
+		line += fmt.Sprintf(" · mcap +%.0f%%/h", score.MarketcapVelocity)
+	}
+	return line
+}
+
 // SendTestHotTokenNotification
 func SendTestHotTokenNotification(bot *tgbotapi.BotAPI, filteredChatID string, poolLpPublicKey string) error {
 	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
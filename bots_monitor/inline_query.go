@@ -0,0 +1,109 @@
+package bots_monitor
+
+// Inline mode: typing "@bot SOON" in any chat returns token summary (marketcap, 24h volume, price change, Trade link)
+// Inline mode has to be enabled for the bot in BotFather (/setinline), otherwise Telegram does not send inline queries
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"spark-wallet/internal/features/hot_token"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// inlineQueryCacheTime - seconds Telegram caches inline result of the same query
+const inlineQueryCacheTime = 60
+
+// handleInlineQuery answers inline query with token summary of ticker
+// Unknown tickers and empty queries are answered with no results
+func handleInlineQuery(bot *tgbotapi.BotAPI, query *tgbotapi.InlineQuery) {
+	ticker := strings.TrimPrefix(strings.TrimSpace(query.Query), "$")
+
+	results := []interface{}{}
+	if ticker != "" && !strings.ContainsAny(ticker, " \t\n") {
+		if result, ok := buildInlineTokenResult(query.ID, ticker); ok {
+			results = append(results, result)
+		}
+	}
+
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		Results:       results,
+		CacheTime:     inlineQueryCacheTime,
+	}
+	if _, err := bot.Request(answer); err != nil {
+		log.LogWarn("Failed to answer inline query",
+			zap.String("query", query.Query),
+			zap.Error(err))
+		return
+	}
+
+	username := ""
+	if query.From != nil {
+		username = query.From.UserName
+	}
+	log.LogDebug("Inline query answered",
+		zap.String("query", query.Query),
+		zap.Int("results", len(results)),
+		zap.String("username", username))
+}
+
+// buildInlineTokenResult creates article with token summary (false if ticker is unknown or pool data is unavailable)
+func buildInlineTokenResult(queryID string, ticker string) (tgbotapi.InlineQueryResultArticle, bool) {
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		return tgbotapi.InlineQueryResultArticle{}, false
+	}
+
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		log.LogWarn("Failed to get pool data for inline query",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		return tgbotapi.InlineQueryResultArticle{}, false
+	}
+
+	tokenMeta, _, _ := poolData.TokenSide()
+
+	ticker = strings.ToUpper(ticker)
+	marketcap := formatMarketCap(tokenMeta.AggMarketcapUsd)
+	if marketcap == "" {
+		marketcap = "n/a"
+	}
+	volume := formatMarketCap(poolData.Extra.Volume24hUsd)
+	if volume == "" {
+		volume = "n/a"
+	}
+	tradeURL := fmt.Sprintf("https://luminex.io/spark/trade/%s", poolLpPublicKey)
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("<b>%s</b> {%s}\n", html.EscapeString(tokenMeta.Name), ticker))
+	text.WriteString("<blockquote>")
+	text.WriteString(fmt.Sprintf("Market cap: %s\n", marketcap))
+	text.WriteString(fmt.Sprintf("Volume 24h: %s\n", volume))
+	text.WriteString(fmt.Sprintf("Price: $%s (%+.1f%% 24h)", formatAlertPrice(tokenMeta.AggPriceUsd), tokenMeta.AggPriceChange24h))
+	text.WriteString("</blockquote>")
+
+	// ID of result must be unique within answer, ticker is enough for one result
+	result := tgbotapi.NewInlineQueryResultArticleHTML(queryID+"-"+ticker, fmt.Sprintf("%s {%s}", tokenMeta.Name, ticker), text.String())
+	result.Description = fmt.Sprintf("MC %s · Vol 24h %s · %+.1f%% 24h", marketcap, volume, tokenMeta.AggPriceChange24h)
+	result.URL = tradeURL
+	result.HideURL = true
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL("Trade", tradeURL),
+		),
+	)
+	result.ReplyMarkup = &keyboard
+	if inputContent, ok := result.InputMessageContent.(tgbotapi.InputTextMessageContent); ok {
+		inputContent.DisableWebPagePreview = true
+		result.InputMessageContent = inputContent
+	}
+
+	return result, true
+}
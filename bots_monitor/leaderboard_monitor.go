@@ -0,0 +1,150 @@
+package bots_monitor
+
+// Leaderboard of most profitable wallets per token: weekly post of filtered tokens and /leaderboard {ticker}
+// Wallets are ranked by realized PnL of last 7 days from archived swaps (see internal/features/leaderboard)
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/leaderboard"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// leaderboardWeekday - day of weekly leaderboard (MSK)
+const leaderboardWeekday = time.Monday
+
+// RunLeaderboardMonitor posts leaderboard of every filtered token for last 7 UTC days every Monday at sendTime (MSK)
+// bot - Telegram for
+// chatID - ID for leaderboards
+// sendTime - time in "HH:MM"
+func RunLeaderboardMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, sendTime string) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, leaderboard monitor not started")
+		return
+	}
+
+	schedule, err := scheduler.ParseDaily(sendTime, statsLocation, leaderboardWeekday)
+	if err != nil {
+		log.LogWarn("Invalid leaderboard send time format, using default 10:00", zap.String("sendTime", sendTime), zap.Error(err))
+		schedule = scheduler.Daily{Hour: 10, Minute: 0, Location: statsLocation, Weekdays: []time.Weekday{leaderboardWeekday}}
+	}
+
+	log.LogInfo("Starting Leaderboard Monitor...",
+		zap.String("chatID", chatID),
+		zap.String("weekday", leaderboardWeekday.String()),
+		zap.String("sendTime", fmt.Sprintf("%02d:%02d", schedule.Hour, schedule.Minute)))
+
+	scheduler.Default.Run(ctx, scheduler.Job{
+		Name:     "leaderboard",
+		Schedule: schedule,
+		Run: func(ctx context.Context, now time.Time) {
+			sendWeeklyLeaderboards(ctx, bot, chatID, now.UTC())
+		},
+	})
+	log.LogInfo("Leaderboard Monitor stopped")
+}
+
+func sendWeeklyLeaderboards(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, now time.Time) {
+	pools, err := storage.LoadFilteredTokens()
+	if err != nil {
+		log.LogError("Failed to load filtered tokens for leaderboard", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	// Last complete UTC days
+	to := now.Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -leaderboard.PeriodDays)
+	btcPriceUSD, _ := currentBTCPriceUSD(false)
+	chat := parseChatIDBig(chatID)
+
+	sent := 0
+	for _, poolLpPublicKey := range pools {
+		board, err := leaderboard.LoadBoard(poolLpPublicKey, from, to)
+		if err != nil {
+			log.LogError("Failed to build leaderboard", zap.String("poolLpPublicKey", poolLpPublicKey), zap.Error(err))
+			ReportMonitorError(ctx, err)
+			return
+		}
+		if len(board.Wallets) == 0 {
+			continue
+		}
+
+		board.Ticker = FormatTokenAddress(poolLpPublicKey)
+		if metadata := luminex.GetTokenMetadata(poolLpPublicKey); metadata != nil && metadata.Ticker != "" {
+			board.Ticker = strings.ToUpper(metadata.Ticker)
+		}
+
+		msg := tgbotapi.NewMessage(chat, leaderboard.Format(board, btcPriceUSD, i18n.ChatLang(chat)))
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		if _, err := bot.Send(msg); err != nil {
+			log.LogError("Failed to send leaderboard", zap.String("ticker", board.Ticker), zap.Error(err))
+			continue
+		}
+		sent++
+	}
+	ReportMonitorSuccess(ctx)
+
+	log.LogInfo("Weekly leaderboards sent",
+		zap.String("chatID", chatID),
+		zap.Int("tokens", len(pools)),
+		zap.Int("sent", sent))
+}
+
+// handleLeaderboardCommand /leaderboard {ticker}
+func handleLeaderboardCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	ticker = strings.ToUpper(ticker)
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker for leaderboard",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply(fmt.Sprintf("Ticker {%s} not found. Make sure the token has been traded before.", ticker))
+		return
+	}
+
+	now := time.Now().UTC()
+	board, err := leaderboard.LoadBoard(poolLpPublicKey, now.AddDate(0, 0, -leaderboard.PeriodDays), now)
+	if err != nil {
+		log.LogError("Failed to build leaderboard", zap.String("ticker", ticker), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+	board.Ticker = ticker
+	if len(board.Wallets) == 0 {
+		reply(fmt.Sprintf("No wallet of {%s} took profit in the last %d days.", ticker, leaderboard.PeriodDays))
+		return
+	}
+
+	btcPriceUSD, _ := currentBTCPriceUSD(false)
+	msg := tgbotapi.NewMessage(message.Chat.ID, leaderboard.Format(board, btcPriceUSD, i18n.ChatLang(message.Chat.ID)))
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	msg.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send leaderboard", zap.String("ticker", ticker), zap.Error(err))
+		return
+	}
+
+	log.LogInfo("Leaderboard sent via command",
+		zap.String("ticker", ticker),
+		zap.Int("wallets", len(board.Wallets)),
+		zap.String("chatID", formatChatID(message.Chat.ID)))
+}
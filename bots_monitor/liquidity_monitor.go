@@ -0,0 +1,161 @@
+package bots_monitor
+
+// Pool liquidity monitor: alerts when TVL of tracked pool changes by more than threshold within window
+// Large removal of liquidity (rug pull) is otherwise invisible, it produces no swaps
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/liquidity"
+	"spark-wallet/internal/infra/events"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// RunLiquidityMonitor samples reserves of tracked pools (filtered_tokens.json)
+// bot - Telegram for liquidity alerts
+// chatID - ID for alerts
+// changePercent - TVL change (percent, both directions) that triggers alert
+// window - period TVL change is measured over
+// interval - interval between samples
+func RunLiquidityMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, changePercent float64, window time.Duration, interval time.Duration) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, liquidity monitor not started")
+		return
+	}
+	if changePercent <= 0 {
+		log.LogInfo("Liquidity change threshold is 0, liquidity monitor disabled")
+		return
+	}
+
+	log.LogInfo("Starting Liquidity Monitor...",
+		zap.String("chatID", chatID),
+		zap.Float64("changePercent", changePercent),
+		zap.Duration("window", window),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial sample
+	sampleLiquidity(ctx, bot, chatID, changePercent, window)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Liquidity Monitor stopped")
+			return
+		case <-ticker.C:
+			sampleLiquidity(ctx, bot, chatID, changePercent, window)
+		}
+	}
+}
+
+// sampleLiquidity samples all tracked pools and sends alerts for TVL changes
+func sampleLiquidity(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, changePercent float64, window time.Duration) {
+	pools, err := storage.LoadFilteredTokens()
+	if err != nil {
+		log.LogError("Failed to load filtered tokens for liquidity monitor", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	var lastErr error
+	sampled := 0
+	defer func() {
+		// Run fails only if no pool was sampled
+		if sampled == 0 && lastErr != nil {
+			ReportMonitorError(ctx, lastErr)
+		} else {
+			ReportMonitorSuccess(ctx)
+		}
+	}()
+
+	for _, poolLpPublicKey := range pools {
+		if ctx.Err() != nil {
+			return
+		}
+
+		change, err := liquidity.SamplePool(poolLpPublicKey, window, changePercent)
+		if err != nil {
+			log.LogWarn("Failed to sample pool liquidity",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			lastErr = err
+			continue
+		}
+		sampled++
+		if change == nil {
+			continue
+		}
+
+		message := FormatLiquidityChangeMessage(change)
+		if holdAlert(bot, parseChatIDBig(chatID), "liquidity", poolLpPublicKey, change.Ticker, message) {
+			continue
+		}
+		tradeLink := fmt.Sprintf("https://luminex.io/spark/trade/%s", poolLpPublicKey)
+		msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyMarkup = tradeKeyboard(tradeLink, i18n.English)
+		if _, err := bot.Send(msg); err != nil {
+			log.LogError("Failed to send liquidity alert",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			continue
+		}
+		recordDashboardAlert("liquidity", message, tradeLink)
+		recordAlertEvent(events.Alert{
+			Kind:            "liquidity",
+			ChatID:          parseChatIDBig(chatID),
+			PoolLpPublicKey: poolLpPublicKey,
+			Ticker:          change.Ticker,
+		}, message)
+
+		log.LogInfo("Liquidity alert sent",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.Float64("changePercent", change.ChangePercent),
+			zap.Duration("period", change.Period))
+	}
+}
+
+// FormatLiquidityChangeMessage
+func FormatLiquidityChangeMessage(change *liquidity.Change) string {
+	ticker := change.Ticker
+	if ticker == "" {
+		ticker = FormatTokenAddress(change.PoolLpPublicKey)
+	}
+
+	title := "💧 <b>liquidity added</b>"
+	if change.Removed() {
+		title = "🚨 <b>liquidity removed</b>"
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("%s: {%s}\n", title, strings.ToUpper(ticker)))
+	message.WriteString("<blockquote>")
+	if change.From.TvlBTC > 0 && change.To.TvlBTC > 0 {
+		message.WriteString(fmt.Sprintf("TVL: %s → %s btc (%+.1f%%)\n",
+			amount.FormatBTC(change.From.TvlBTC), amount.FormatBTC(change.To.TvlBTC), change.ChangePercent))
+		if change.To.TvlUsd > 0 {
+			message.WriteString(fmt.Sprintf("TVL USD: %s\n", formatMarketCap(change.To.TvlUsd)))
+		}
+	} else {
+		message.WriteString(fmt.Sprintf("TVL: %s → %s (%+.1f%%)\n",
+			formatMarketCap(change.From.TvlUsd), formatMarketCap(change.To.TvlUsd), change.ChangePercent))
+	}
+	message.WriteString(fmt.Sprintf("BTC reserve: %s → %s btc\n",
+		amount.FormatBTC(change.From.BtcReserve), amount.FormatBTC(change.To.BtcReserve)))
+	message.WriteString(fmt.Sprintf("Period: %d min", int(math.Round(change.Period.Minutes()))))
+	message.WriteString("</blockquote>")
+	return message.String()
+}
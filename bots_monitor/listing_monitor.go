@@ -0,0 +1,145 @@
+package bots_monitor
+
+// New listing monitor: alerts when new pool appears in Luminex tokens-with-pools
+// Separate from hot token monitor (swaps based), new pool is reported before it has any trades
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/listings"
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// listingFetchLimit - newest tokens fetched per check
+const listingFetchLimit = 50
+
+// RunListingMonitor periodically lists newest pools and alerts about new ones
+// bot - Telegram for listing alerts
+// chatID - ID for alerts
+// interval - interval between checks
+func RunListingMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, interval time.Duration) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, listing monitor not started")
+		return
+	}
+
+	log.LogInfo("Starting Listing Monitor...",
+		zap.String("chatID", chatID),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial check
+	checkNewListings(ctx, bot, chatID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Listing Monitor stopped")
+			return
+		case <-ticker.C:
+			checkNewListings(ctx, bot, chatID)
+		}
+	}
+}
+
+// checkNewListings fetches newest tokens and sends alert for each new pool
+func checkNewListings(ctx context.Context, bot *tgbotapi.BotAPI, chatID string) {
+	tokens, err := luminex.GetNewestTokens(ctx, listingFetchLimit)
+	if err != nil {
+		log.LogError("Failed to fetch newest tokens", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	newListings, err := listings.DetectNewPools(tokens)
+	if err != nil {
+		log.LogError("Failed to detect new pools", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+
+	for _, listing := range newListings {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Initial liquidity from pool details (not part of listing)
+		poolData, err := hot_token.GetFullPoolData(listing.Pool.LpPublicKey)
+		if err != nil {
+			log.LogWarn("Failed to get pool details for new listing",
+				zap.String("poolLpPublicKey", listing.Pool.LpPublicKey),
+				zap.Error(err))
+		}
+
+		message := FormatNewListingMessage(listing, poolData)
+		if holdAlert(bot, parseChatIDBig(chatID), "listing", listing.Pool.LpPublicKey, listing.Token.Ticker, message) {
+			continue
+		}
+		tradeLink := fmt.Sprintf("https://luminex.io/spark/trade/%s", listing.Pool.LpPublicKey)
+		msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyMarkup = tradeKeyboard(tradeLink, i18n.English)
+		if _, err := bot.Send(msg); err != nil {
+			log.LogError("Failed to send new listing alert",
+				zap.String("poolLpPublicKey", listing.Pool.LpPublicKey),
+				zap.Error(err))
+			continue
+		}
+		recordDashboardAlert("listing", message, tradeLink)
+		recordAlertEvent(events.Alert{
+			Kind:            "listing",
+			ChatID:          parseChatIDBig(chatID),
+			PoolLpPublicKey: listing.Pool.LpPublicKey,
+			Ticker:          listing.Token.Ticker,
+		}, message)
+
+		log.LogInfo("New listing alert sent",
+			zap.String("poolLpPublicKey", listing.Pool.LpPublicKey),
+			zap.String("ticker", listing.Token.Ticker))
+	}
+}
+
+// FormatNewListingMessage
+// poolData - pool details for initial liquidity (nil if not available)
+func FormatNewListingMessage(listing listings.Listing, poolData *luminex.LuminexPoolResponse) string {
+	ticker := listing.Token.Ticker
+	if ticker == "" {
+		ticker = FormatTokenAddress(listing.Pool.LpPublicKey)
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("🆕 <b>New token listed</b>: {%s}\n", html.EscapeString(strings.ToUpper(ticker))))
+	message.WriteString("<blockquote>")
+	if listing.Token.Name != "" {
+		message.WriteString(fmt.Sprintf("Name: %s\n", html.EscapeString(listing.Token.Name)))
+	}
+	if poolData != nil {
+		_, btcReserve, _ := poolData.TokenSide()
+		if sats, err := strconv.ParseFloat(btcReserve, 64); err == nil && sats > 0 {
+			message.WriteString(fmt.Sprintf("Initial liquidity: %s btc\n", amount.FormatBTC(sats/1e8)))
+		}
+		if poolData.Extra.PoolTvlUsd > 0 {
+			message.WriteString(fmt.Sprintf("TVL: %s\n", formatMarketCap(poolData.Extra.PoolTvlUsd)))
+		}
+	}
+	message.WriteString(fmt.Sprintf("Pool: <code>%s</code>", listing.Pool.LpPublicKey))
+	message.WriteString("</blockquote>")
+	return message.String()
+}
@@ -0,0 +1,68 @@
+package bots_monitor
+
+// Time-series export: stats, token flows and holders are pushed to InfluxDB or Prometheus remote-write
+// every interval (app.metrics_*), dashboards keep history beyond weekly charts of the bot
+
+import (
+	"context"
+	"time"
+
+	"spark-wallet/internal/features/metrics_export"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
+
+	"go.uber.org/zap"
+)
+
+// MetricsExportConfig - sink and interval of time-series export (app.metrics_*)
+type MetricsExportConfig struct {
+	Sink     string // influx or prometheus
+	URL      string // write endpoint
+	Token    string
+	Interval time.Duration
+}
+
+// RunMetricsExportMonitor pushes collected samples to sink every interval
+func RunMetricsExportMonitor(ctx context.Context, cfg MetricsExportConfig) {
+	sink, err := metrics_export.NewSink(cfg.Sink, cfg.URL, cfg.Token)
+	if err != nil {
+		log.LogWarn("Metrics sink is not configured, metrics export not started", zap.Error(err))
+		return
+	}
+
+	log.LogInfo("Starting Metrics Export Monitor...",
+		zap.String("sink", cfg.Sink),
+		zap.Duration("interval", cfg.Interval))
+
+	scheduler.Default.Run(ctx, scheduler.Job{
+		Name:       "metrics_export",
+		Schedule:   scheduler.Interval(cfg.Interval),
+		RunOnStart: true,
+		Run: func(ctx context.Context, now time.Time) {
+			exportMetrics(ctx, sink, now)
+		},
+	})
+	log.LogInfo("Metrics Export Monitor stopped")
+}
+
+func exportMetrics(ctx context.Context, sink metrics_export.Sink, now time.Time) {
+	samples, err := metrics_export.Collect(now)
+	if err != nil {
+		log.LogWarn("Failed to collect some metrics", zap.Error(err))
+	}
+	// Run fails only if nothing was collected
+	if len(samples) == 0 {
+		if err != nil {
+			ReportMonitorError(ctx, err)
+		}
+		return
+	}
+
+	if err := sink.Push(ctx, samples); err != nil {
+		log.LogError("Failed to push metrics", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+	log.LogDebug("Metrics pushed", zap.Int("samples", len(samples)))
+}
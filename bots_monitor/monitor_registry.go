@@ -0,0 +1,458 @@
+package bots_monitor
+
+// Monitor registry with per-monitor error budgets
+// Monitors report failures via ReportMonitorError(ctx, err), successes via ReportMonitorSuccess(ctx)
+// When consecutive failures exceed budget (or monitor panics), monitor is stopped, operator is alerted
+// with recent errors and monitor is restarted with backoff
+// Paused monitor (admin API) is stopped until resumed, pause is not counted as failure
+// Monitor with expected heartbeat (ExpectHeartbeat) is stalled when it reports no success for longer than heartbeat (/healthz)
+// Panic is logged with stack, goroutines that are not restarted (command updates) recover with recoverHandlerPanic
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultMonitorErrorBudget - consecutive failures before monitor restart
+	DefaultMonitorErrorBudget = 10
+	// monitorRecentErrors - errors kept per monitor for alert summary
+	monitorRecentErrors = 5
+	// monitorRestartBackoff - first restart delay, doubled on each restart without success
+	monitorRestartBackoff = 5 * time.Second
+	// monitorMaxRestartBackoff - upper bound of restart delay
+	monitorMaxRestartBackoff = 5 * time.Minute
+	// handlerPanicAlertInterval - operator is alerted about panics of same handler at most this often
+	handlerPanicAlertInterval = 10 * time.Minute
+)
+
+// MonitorStatus - error budget state of monitor
+type MonitorStatus struct {
+	Name                string
+	ConsecutiveFailures int
+	TotalFailures       int
+	Restarts            int
+	LastSuccess         time.Time
+	LastError           string
+	Paused              bool
+	Heartbeat           time.Duration // max time without success, 0 - not checked
+	Stalled             bool          // no success for longer than Heartbeat
+}
+
+type monitorError struct {
+	at      time.Time
+	message string
+}
+
+type monitorState struct {
+	name                string
+	consecutiveFailures int
+	totalFailures       int
+	restarts            int
+	lastSuccess         time.Time
+	runningSince        time.Time // first start or resume, not reset by restarts
+	recentErrors        []monitorError
+	budgetExceeded      bool
+	cancelRun           context.CancelFunc
+	paused              bool
+	resumed             chan struct{} // closed on resume
+}
+
+// MonitorRegistry - registered monitors and their error budgets
+type MonitorRegistry struct {
+	mu          sync.Mutex
+	monitors    map[string]*monitorState
+	errorBudget int
+	alert       func(text string)
+	heartbeats  map[string]time.Duration // monitor -> max time without success
+	panicAlerts map[string]time.Time     // handler -> last operator alert of its panic
+}
+
+type monitorContextKey struct{}
+
+type monitorHandle struct {
+	registry *MonitorRegistry
+	state    *monitorState
+}
+
+// NewMonitorRegistry creates registry
+// errorBudget - max consecutive failures of monitor (DefaultMonitorErrorBudget if <= 0)
+// alert - sends operator alert (HTML), may be nil
+func NewMonitorRegistry(errorBudget int, alert func(text string)) *MonitorRegistry {
+	if errorBudget <= 0 {
+		errorBudget = DefaultMonitorErrorBudget
+	}
+	return &MonitorRegistry{
+		monitors:    make(map[string]*monitorState),
+		errorBudget: errorBudget,
+		alert:       alert,
+		heartbeats:  make(map[string]time.Duration),
+		panicAlerts: make(map[string]time.Time),
+	}
+}
+
+// ExpectHeartbeat marks monitor stalled when it reports no success for longer than maxSilence
+// Time is counted from last success, or from start (resume) if monitor hasn't succeeded since
+func (r *MonitorRegistry) ExpectHeartbeat(name string, maxSilence time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.heartbeats[name] = maxSilence
+}
+
+// Run runs monitor under registry until ctx is cancelled
+// Monitor is restarted with backoff if it exceeds error budget or panics
+// Normal return of monitor (e.g. monitor is disabled) is not restarted
+// Paused monitor is started again on resume
+func (r *MonitorRegistry) Run(ctx context.Context, name string, run func(ctx context.Context)) {
+	state := r.register(name)
+	backoff := monitorRestartBackoff
+
+	for {
+		if !r.waitResumed(ctx, state) {
+			return
+		}
+
+		runCtx, cancel := context.WithCancel(context.WithValue(ctx, monitorContextKey{}, &monitorHandle{registry: r, state: state}))
+		runStartedAt := time.Now()
+		r.mu.Lock()
+		state.cancelRun = cancel
+		state.budgetExceeded = false
+		state.consecutiveFailures = 0
+		if state.runningSince.IsZero() {
+			state.runningSince = runStartedAt
+		}
+		if state.paused {
+			// Paused between wait and start
+			cancel()
+		}
+		r.mu.Unlock()
+
+		panicValue, stack := runRecovered(runCtx, run)
+		cancel()
+		if panicValue != nil {
+			logPanic(name, panicValue, stack)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		r.mu.Lock()
+		if state.paused && panicValue == nil {
+			r.mu.Unlock()
+			log.LogInfo("Monitor paused", zap.String("monitor", name))
+			continue
+		}
+		exceeded := state.budgetExceeded
+		if panicValue != nil {
+			state.totalFailures++
+			state.recentErrors = appendMonitorError(state.recentErrors, formatPanic(panicValue, stack))
+		}
+		if !exceeded && panicValue == nil {
+			r.mu.Unlock()
+			return
+		}
+		// Monitor worked for a while before failing - start backoff over
+		if state.lastSuccess.After(runStartedAt) {
+			backoff = monitorRestartBackoff
+		}
+		state.restarts++
+		restarts := state.restarts
+		summary := formatMonitorErrors(state.recentErrors)
+		r.mu.Unlock()
+
+		reason := fmt.Sprintf("%d consecutive errors", r.errorBudget)
+		if panicValue != nil {
+			reason = "panic"
+		}
+		log.LogError("Monitor failed, restarting",
+			zap.String("monitor", name),
+			zap.String("reason", reason),
+			zap.Int("restart", restarts),
+			zap.Duration("backoff", backoff))
+
+		if r.alert != nil {
+			r.alert(fmt.Sprintf("⚠️ <b>monitor restart</b>: %s\n<blockquote>Reason: %s\nRestart: #%d in %s\n%s</blockquote>",
+				html.EscapeString(name), html.EscapeString(reason), restarts, backoff, html.EscapeString(summary)))
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > monitorMaxRestartBackoff {
+			backoff = monitorMaxRestartBackoff
+		}
+	}
+}
+
+// Statuses returns error budget state of all monitors (sorted by name)
+func (r *MonitorRegistry) Statuses() []MonitorStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]MonitorStatus, 0, len(r.monitors))
+	for _, state := range r.monitors {
+		status := MonitorStatus{
+			Name:                state.name,
+			ConsecutiveFailures: state.consecutiveFailures,
+			TotalFailures:       state.totalFailures,
+			Restarts:            state.restarts,
+			LastSuccess:         state.lastSuccess,
+			Paused:              state.paused,
+			Heartbeat:           r.heartbeats[state.name],
+		}
+		if status.Heartbeat > 0 && !state.paused && !state.runningSince.IsZero() {
+			alive := state.runningSince
+			if state.lastSuccess.After(alive) {
+				alive = state.lastSuccess
+			}
+			status.Stalled = now.Sub(alive) > status.Heartbeat
+		}
+		if len(state.recentErrors) > 0 {
+			status.LastError = state.recentErrors[len(state.recentErrors)-1].message
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// Pause stops monitor until Resume, returns false if monitor is already paused
+// Monitor that is not running yet is registered paused and starts on Resume
+func (r *MonitorRegistry) Pause(name string) bool {
+	state := r.register(name)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if state.paused {
+		return false
+	}
+	state.paused = true
+	state.runningSince = time.Time{}
+	state.resumed = make(chan struct{})
+	if state.cancelRun != nil {
+		state.cancelRun()
+	}
+	return true
+}
+
+// Resume starts paused monitor again, returns false if monitor is not paused
+func (r *MonitorRegistry) Resume(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, exists := r.monitors[name]
+	if !exists || !state.paused {
+		return false
+	}
+	state.paused = false
+	close(state.resumed)
+	return true
+}
+
+// IsRegistered checks if monitor with name runs (or ran) under registry
+func (r *MonitorRegistry) IsRegistered(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, exists := r.monitors[name]
+	return exists
+}
+
+// ReportMonitorError counts failure of monitor running under registry (no-op otherwise)
+func ReportMonitorError(ctx context.Context, err error) {
+	handle, ok := ctx.Value(monitorContextKey{}).(*monitorHandle)
+	if !ok || err == nil {
+		return
+	}
+	handle.registry.reportError(handle.state, err)
+}
+
+// ReportMonitorSuccess resets consecutive failures of monitor running under registry (no-op otherwise)
+func ReportMonitorSuccess(ctx context.Context) {
+	handle, ok := ctx.Value(monitorContextKey{}).(*monitorHandle)
+	if !ok {
+		return
+	}
+
+	handle.registry.mu.Lock()
+	defer handle.registry.mu.Unlock()
+	handle.state.consecutiveFailures = 0
+	handle.state.lastSuccess = time.Now()
+}
+
+func (r *MonitorRegistry) register(name string) *monitorState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, exists := r.monitors[name]
+	if !exists {
+		state = &monitorState{name: name}
+		r.monitors[name] = state
+	}
+	return state
+}
+
+// waitResumed blocks while monitor is paused, returns false if ctx is cancelled
+func (r *MonitorRegistry) waitResumed(ctx context.Context, state *monitorState) bool {
+	r.mu.Lock()
+	if !state.paused {
+		r.mu.Unlock()
+		return true
+	}
+	resumed := state.resumed
+	r.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-resumed:
+		log.LogInfo("Monitor resumed", zap.String("monitor", state.name))
+		return true
+	}
+}
+
+func (r *MonitorRegistry) reportError(state *monitorState, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state.consecutiveFailures++
+	state.totalFailures++
+	state.recentErrors = appendMonitorError(state.recentErrors, err.Error())
+
+	if state.consecutiveFailures >= r.errorBudget && !state.budgetExceeded {
+		state.budgetExceeded = true
+		log.LogWarn("Monitor error budget exceeded",
+			zap.String("monitor", state.name),
+			zap.Int("consecutiveFailures", state.consecutiveFailures))
+		if state.cancelRun != nil {
+			state.cancelRun()
+		}
+	}
+}
+
+// runRecovered runs monitor and returns panic value with stack (nil if monitor returned normally)
+func runRecovered(ctx context.Context, run func(ctx context.Context)) (panicValue any, stack []byte) {
+	defer func() {
+		if panicValue = recover(); panicValue != nil {
+			stack = debug.Stack()
+		}
+	}()
+	run(ctx)
+	return nil, nil
+}
+
+// recoverHandlerPanic recovers panic of goroutine that is not restarted by registry (command update, inline query)
+// Must be deferred directly. Panic is logged with stack and operator is alerted (registry of SetBotStatsRegistry)
+func recoverHandlerPanic(name string) {
+	panicValue := recover()
+	if panicValue == nil {
+		return
+	}
+	stack := debug.Stack()
+	logPanic(name, panicValue, stack)
+	if registry := botStatsRegistry.Load(); registry != nil {
+		registry.alertHandlerPanic(name, formatPanic(panicValue, stack))
+	}
+}
+
+// alertHandlerPanic alerts operator about recovered panic of handler, repeated panics are throttled
+func (r *MonitorRegistry) alertHandlerPanic(name string, message string) {
+	if r.alert == nil {
+		return
+	}
+	r.mu.Lock()
+	if last, ok := r.panicAlerts[name]; ok && time.Since(last) < handlerPanicAlertInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.panicAlerts[name] = time.Now()
+	r.mu.Unlock()
+
+	r.alert(fmt.Sprintf("⚠️ <b>panic recovered</b>: %s\n<blockquote>%s</blockquote>",
+		html.EscapeString(name), html.EscapeString(message)))
+}
+
+func logPanic(name string, panicValue any, stack []byte) {
+	log.LogError("Monitor panicked",
+		zap.String("monitor", name),
+		zap.String("panic", fmt.Sprint(panicValue)),
+		zap.String("stack", string(stack)))
+}
+
+// formatPanic formats panic value with location for operator alert
+func formatPanic(panicValue any, stack []byte) string {
+	if location := panicLocation(stack); location != "" {
+		return fmt.Sprintf("panic: %v at %s", panicValue, location)
+	}
+	return fmt.Sprintf("panic: %v", panicValue)
+}
+
+// panicLocation returns function and file:line that panicked, runtime frames (nil dereference, index) are skipped
+// Stack is debug.Stack() of deferred recover: function line, then tab and file:line of each frame
+func panicLocation(stack []byte) string {
+	lines := strings.Split(string(stack), "\n")
+	for i := 0; i < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "panic(") {
+			continue
+		}
+		for j := i + 2; j+1 < len(lines); j += 2 {
+			function := lines[j]
+			if strings.HasPrefix(function, "runtime.") {
+				continue
+			}
+			if idx := strings.LastIndex(function, "("); idx > 0 {
+				function = function[:idx]
+			}
+			file := strings.TrimSpace(lines[j+1])
+			if idx := strings.LastIndex(file, " +0x"); idx >= 0 {
+				file = file[:idx]
+			}
+			return fmt.Sprintf("%s (%s)", function, filepath.Base(file))
+		}
+		return ""
+	}
+	return ""
+}
+
+func appendMonitorError(errors []monitorError, message string) []monitorError {
+	errors = append(errors, monitorError{at: time.Now(), message: message})
+	if len(errors) > monitorRecentErrors {
+		errors = errors[len(errors)-monitorRecentErrors:]
+	}
+	return errors
+}
+
+func formatMonitorErrors(errors []monitorError) string {
+	if len(errors) == 0 {
+		return "no errors recorded"
+	}
+	lines := make([]string, 0, len(errors))
+	for _, e := range errors {
+		message := e.message
+		if len(message) > 200 {
+			message = message[:200] + "..."
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", e.at.Format("15:04:05"), message))
+	}
+	return strings.Join(lines, "\n")
+}
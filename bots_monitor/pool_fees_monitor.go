@@ -0,0 +1,123 @@
+package bots_monitor
+
+// Pool fee sampling for tracked pools and alerts on fee parameters change
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/pool_fees"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// RunPoolFeesMonitor samples fee rates and 24h fee revenue of tracked pools
+// bot - Telegram for fee change alerts
+// chatID - ID for alerts
+// interval - interval between samples
+func RunPoolFeesMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, interval time.Duration) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, pool fees monitor not started")
+		return
+	}
+
+	log.LogInfo("Starting Pool Fees Monitor...",
+		zap.String("chatID", chatID),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial sample
+	samplePoolFees(ctx, bot, chatID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Pool Fees Monitor stopped")
+			return
+		case <-ticker.C:
+			samplePoolFees(ctx, bot, chatID)
+		}
+	}
+}
+
+// samplePoolFees samples all tracked pools (filtered_tokens.json)
+func samplePoolFees(ctx context.Context, bot *tgbotapi.BotAPI, chatID string) {
+	pools, err := storage.LoadFilteredTokens()
+	if err != nil {
+		log.LogError("Failed to load filtered tokens for pool fees", zap.Error(err))
+		return
+	}
+
+	var lastErr error
+	sampled := 0
+	defer func() {
+		// Run fails only if no pool was sampled
+		if sampled == 0 && lastErr != nil {
+			ReportMonitorError(ctx, lastErr)
+		} else {
+			ReportMonitorSuccess(ctx)
+		}
+	}()
+
+	for _, poolLpPublicKey := range pools {
+		if ctx.Err() != nil {
+			return
+		}
+
+		change, err := pool_fees.SamplePool(poolLpPublicKey)
+		if err != nil {
+			log.LogWarn("Failed to sample pool fees",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			lastErr = err
+			continue
+		}
+		sampled++
+		if change == nil {
+			continue
+		}
+
+		message := FormatFeeChangeMessage(change)
+		if holdAlert(bot, parseChatIDBig(chatID), "pool_fee", poolLpPublicKey, change.Ticker, message) {
+			continue
+		}
+		msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
+		msg.ParseMode = tgbotapi.ModeHTML
+		if _, err := bot.Send(msg); err != nil {
+			log.LogError("Failed to send fee change alert",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			continue
+		}
+
+		log.LogInfo("Fee change alert sent",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.Int("oldLpFeeBps", change.OldLpFeeBps),
+			zap.Int("newLpFeeBps", change.NewLpFeeBps),
+			zap.Int("oldHostFeeBps", change.OldHostFeeBps),
+			zap.Int("newHostFeeBps", change.NewHostFeeBps))
+	}
+}
+
+// FormatFeeChangeMessage
+func FormatFeeChangeMessage(change *pool_fees.FeeChange) string {
+	ticker := change.Ticker
+	if ticker == "" {
+		ticker = FormatTokenAddress(change.PoolLpPublicKey)
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("⚙️ <b>fee change</b>: {%s}\n", strings.ToUpper(ticker)))
+	message.WriteString("<blockquote>")
+	message.WriteString(fmt.Sprintf("LP fee: %d → %d bps\n", change.OldLpFeeBps, change.NewLpFeeBps))
+	message.WriteString(fmt.Sprintf("Host fee: %d → %d bps", change.OldHostFeeBps, change.NewHostFeeBps))
+	message.WriteString("</blockquote>")
+	return message.String()
+}
@@ -0,0 +1,186 @@
+package bots_monitor
+
+// Portfolio monitor: samples balances of own wallet (PUBLIC_KEY) via Luminex address API
+// /portfolio (admin chat) shows allocations, 24h and 7d change and value chart
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/btc_price"
+	"spark-wallet/internal/features/portfolio"
+	"spark-wallet/internal/features/tg_charts"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// portfolioChartDays - days shown on /portfolio chart
+	portfolioChartDays = 30
+	// portfolioMaxHoldings - holdings listed in /portfolio, the rest is summed as "other"
+	portfolioMaxHoldings = 10
+)
+
+// RunPortfolioMonitor samples balances of wallet every interval
+// publicKey - own wallet (flashnet.public_key or public key of signer)
+func RunPortfolioMonitor(ctx context.Context, publicKey string, interval time.Duration) {
+	if publicKey == "" {
+		log.LogInfo("PUBLIC_KEY is not set, portfolio monitor not started")
+		return
+	}
+
+	log.LogInfo("Starting Portfolio Monitor...",
+		zap.String("publicKey", publicKey),
+		zap.String("file", portfolio.PortfolioFile()),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial sample
+	samplePortfolio(ctx, publicKey)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Portfolio Monitor stopped")
+			return
+		case <-ticker.C:
+			samplePortfolio(ctx, publicKey)
+		}
+	}
+}
+
+func samplePortfolio(ctx context.Context, publicKey string) {
+	balance, err := luminex.GetWalletTokensBalance(publicKey)
+	if err != nil {
+		log.LogWarn("Failed to get wallet balance for portfolio", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	// Without price feed BTC price implied by Luminex balance value is used
+	btcPriceUsd, err := btc_price.CurrentPriceUSD(ctx)
+	if err != nil {
+		log.LogDebug("Failed to get BTC price for portfolio", zap.Error(err))
+		btcPriceUsd = 0
+	}
+
+	snapshot, err := portfolio.BuildSnapshot(balance, btcPriceUsd, time.Now())
+	if err != nil {
+		log.LogWarn("Failed to build portfolio snapshot", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+	if err := portfolio.RecordSnapshot(publicKey, snapshot); err != nil {
+		log.LogError("Failed to save portfolio snapshot", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	ReportMonitorSuccess(ctx)
+	log.LogDebug("Portfolio sampled",
+		zap.Float64("valueBTC", snapshot.ValueBTC),
+		zap.Int("holdings", len(snapshot.Holdings)))
+}
+
+// handlePortfolioCommand /portfolio
+func handlePortfolioCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	data, err := portfolio.Load()
+	if err != nil {
+		log.LogError("Failed to load portfolio", zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+	if data.Latest == nil {
+		reply("Portfolio is not tracked yet. Set PUBLIC_KEY and wait for the first sample (hourly).")
+		return
+	}
+
+	caption := formatPortfolioCaption(data)
+	points := data.DailySince(time.Now().UTC().AddDate(0, 0, -(portfolioChartDays - 1)))
+	chartPath, err := tg_charts.GeneratePortfolioChart(points)
+	if err != nil {
+		// One day of history: allocations without chart
+		log.LogDebug("Portfolio chart not generated", zap.Error(err))
+		reply(caption)
+		return
+	}
+
+	photo := tgbotapi.NewPhoto(message.Chat.ID, tgbotapi.FilePath(chartPath))
+	photo.Caption = caption
+	photo.ParseMode = tgbotapi.ModeHTML
+	photo.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(photo); err != nil {
+		log.LogError("Failed to send portfolio chart", zap.Error(err))
+		return
+	}
+
+	log.LogInfo("Portfolio sent via command",
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// formatPortfolioCaption - total value, 24h and 7d change and allocations of latest snapshot
+func formatPortfolioCaption(data *portfolio.PortfolioData) string {
+	latest := data.Latest
+	change := func(c portfolio.Change) string {
+		if !c.Known {
+			return "n/a"
+		}
+		return fmt.Sprintf("%+.1f%%", c.Percent)
+	}
+
+	var caption strings.Builder
+	wallet := data.PublicKey
+	if len(wallet) > 3 {
+		wallet = wallet[len(wallet)-3:]
+	}
+	caption.WriteString(fmt.Sprintf("💼 Portfolio (%s)\n", html.EscapeString(wallet)))
+	caption.WriteString(fmt.Sprintf("Value: <b>%s btc</b> ≈ $%s\n",
+		amount.FormatBTC(latest.ValueBTC), luminex.FormatUSDValue(latest.ValueUsd)))
+	caption.WriteString(fmt.Sprintf("24h: %s · 7d: %s\n", change(data.ChangeOver(24*time.Hour)), change(data.ChangeOver(7*24*time.Hour))))
+
+	if len(latest.Holdings) > 0 {
+		caption.WriteString("\n")
+	}
+	var otherBTC float64
+	for i, holding := range latest.Holdings {
+		if i >= portfolioMaxHoldings {
+			otherBTC += holding.ValueBTC
+			continue
+		}
+		share := 0.0
+		if latest.ValueBTC > 0 {
+			share = holding.ValueBTC / latest.ValueBTC * 100
+		}
+		ticker := holding.Ticker
+		if ticker == "" {
+			ticker = "?"
+		}
+		if holding.Ticker == portfolio.BTCTicker {
+			caption.WriteString(fmt.Sprintf("• BTC - %s btc (%.1f%%)\n", amount.FormatBTC(holding.ValueBTC), share))
+			continue
+		}
+		caption.WriteString(fmt.Sprintf("• {%s} %s - %s btc (%.1f%%)\n",
+			html.EscapeString(ticker), formatTokenAmountLocal(holding.Amount), amount.FormatBTC(holding.ValueBTC), share))
+	}
+	if otherBTC > 0 {
+		caption.WriteString(fmt.Sprintf("• other - %s btc\n", amount.FormatBTC(otherBTC)))
+	}
+	return strings.TrimRight(caption.String(), "\n")
+}
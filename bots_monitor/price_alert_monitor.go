@@ -0,0 +1,143 @@
+package bots_monitor
+
+// Price alert subscriptions monitor (/alert command)
+// Polls token prices of subscribed pools and notifies subscribers when condition triggers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/price_alerts"
+	"spark-wallet/internal/features/reach"
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// RunPriceAlertMonitor checks price alert subscriptions every interval
+// bots - bots running command handlers, notification is sent by the bot that received /alert
+func RunPriceAlertMonitor(ctx context.Context, bots []*tgbotapi.BotAPI, interval time.Duration) {
+	botsByID := make(map[int64]*tgbotapi.BotAPI)
+	var defaultBot *tgbotapi.BotAPI
+	for _, bot := range bots {
+		if bot == nil {
+			continue
+		}
+		if defaultBot == nil {
+			defaultBot = bot
+		}
+		botsByID[bot.Self.ID] = bot
+	}
+	if defaultBot == nil {
+		log.LogWarn("No bot for price alerts, monitor not started")
+		return
+	}
+
+	log.LogInfo("Starting Price Alert Monitor...",
+		zap.String("file", price_alerts.PriceAlertsFile()),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Price Alert Monitor stopped")
+			return
+		case <-ticker.C:
+			checkPriceAlerts(ctx, botsByID, defaultBot)
+		}
+	}
+}
+
+func checkPriceAlerts(ctx context.Context, botsByID map[int64]*tgbotapi.BotAPI, defaultBot *tgbotapi.BotAPI) {
+	triggers, err := price_alerts.CheckSubscriptions(func(sub price_alerts.Subscription) float64 {
+		return luminex.GetPoolTokenPrice(sub.PoolLpPublicKey, flashnet.Swap{}, sub.Ticker)
+	})
+	if err != nil {
+		log.LogError("Failed to check price alerts", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+
+	for _, trigger := range triggers {
+		sub := trigger.Subscription
+		bot, exists := botsByID[sub.BotID]
+		if !exists {
+			bot = defaultBot
+		}
+
+		message := formatPriceAlertMessage(trigger)
+		if holdAlert(bot, sub.ChatID, reach.KindPriceAlert, sub.PoolLpPublicKey, sub.Ticker, message) {
+			continue
+		}
+		msg := tgbotapi.NewMessage(sub.ChatID, message)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		if _, err := bot.Send(msg); err != nil {
+			log.LogError("Failed to send price alert",
+				zap.Int("alertID", sub.ID),
+				zap.Int64("chatID", sub.ChatID),
+				zap.Error(err))
+			continue
+		}
+		if err := reach.RecordDelivery(sub.PoolLpPublicKey, sub.Ticker, sub.ChatID, reach.KindPriceAlert, "", time.Now()); err != nil {
+			log.LogWarn("Failed to record alert reach", zap.Int("alertID", sub.ID), zap.Error(err))
+		}
+		recordAlertEvent(events.Alert{
+			Kind:            reach.KindPriceAlert,
+			ChatID:          sub.ChatID,
+			PoolLpPublicKey: sub.PoolLpPublicKey,
+			Ticker:          sub.Ticker,
+		}, msg.Text)
+
+		log.LogInfo("Sent price alert",
+			zap.Int("alertID", sub.ID),
+			zap.String("ticker", sub.Ticker),
+			zap.Float64("priceUSD", trigger.PriceUSD),
+			zap.Bool("recurring", sub.Recurring))
+	}
+}
+
+func formatPriceAlertMessage(trigger price_alerts.Trigger) string {
+	sub := trigger.Subscription
+
+	mention := ""
+	if sub.Username != "" {
+		mention = "@" + html.EscapeString(sub.Username) + " "
+	}
+
+	direction := "above"
+	if sub.Condition == price_alerts.ConditionBelow {
+		direction = "below"
+	}
+
+	text := fmt.Sprintf("🔔 %s<b>{%s}</b> %s $%s\n<blockquote>Price now: $%s\n", mention, html.EscapeString(sub.Ticker), direction, formatAlertPrice(sub.PriceUSD), formatAlertPrice(trigger.PriceUSD))
+	if sub.Recurring {
+		text += fmt.Sprintf("Recurring alert #%d, triggers again after price crosses back</blockquote>", sub.ID)
+	} else {
+		text += fmt.Sprintf("Alert #%d removed</blockquote>", sub.ID)
+	}
+	text += fmt.Sprintf("\n<a href=\"https://luminex.io/spark/trade/%s\">Trade on Luminex</a>", sub.PoolLpPublicKey)
+	return text
+}
+
+// formatAlertPrice formats token price in USD (small prices keep significant digits)
+func formatAlertPrice(price float64) string {
+	switch {
+	case price >= 1:
+		return fmt.Sprintf("%.2f", price)
+	case price >= 0.01:
+		return fmt.Sprintf("%.4f", price)
+	default:
+		return fmt.Sprintf("%.8f", price)
+	}
+}
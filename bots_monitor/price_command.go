@@ -0,0 +1,114 @@
+package bots_monitor
+
+// /price {ticker} - quick quote: price, 24h change and marketcap with 7-day sparkline
+// Sparkline is drawn from hourly price samples (token_prices.json), tokens without samples get text quote only
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/features/price_history"
+	"spark-wallet/internal/features/tg_charts"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// priceSparklineWindow - price history shown in /price sparkline
+const priceSparklineWindow = 7 * 24 * time.Hour
+
+// handlePriceCommand /price {ticker}
+func handlePriceCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker for price",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply(fmt.Sprintf("Ticker {%s} not found. Make sure the token has been traded before.", html.EscapeString(ticker)))
+		return
+	}
+
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		log.LogError("Failed to get pool data for price",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	tokenMeta, _, _ := poolData.TokenSide()
+
+	samples, err := price_history.SamplesSince(poolLpPublicKey, time.Now().Add(-priceSparklineWindow))
+	if err != nil {
+		log.LogWarn("Failed to load price history for sparkline",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("<b>{%s}</b> $%s (%+.1f%% 24h)", strings.ToUpper(html.EscapeString(ticker)), formatAlertPrice(tokenMeta.AggPriceUsd), tokenMeta.AggPriceChange24h))
+	if marketcap := formatMarketCap(tokenMeta.AggMarketcapUsd); marketcap != "" {
+		text.WriteString(fmt.Sprintf("\nMarket cap: %s", marketcap))
+	}
+	if change := sparklineChange(samples); change != "" {
+		text.WriteString(fmt.Sprintf("\n7d: %s", change))
+	}
+	text.WriteString(fmt.Sprintf("\n<a href=\"https://luminex.io/spark/trade/%s\">Trade on Luminex</a>", poolLpPublicKey))
+
+	chartPath, err := tg_charts.GeneratePriceSparkline(ticker, samples)
+	if err != nil {
+		// Not a tracked pool or history just started - quote without chart
+		log.LogDebug("Price sparkline skipped", zap.String("ticker", ticker), zap.Error(err))
+		reply(text.String())
+		return
+	}
+
+	photo := tgbotapi.NewPhoto(message.Chat.ID, tgbotapi.FilePath(chartPath))
+	photo.Caption = text.String()
+	photo.ParseMode = tgbotapi.ModeHTML
+	photo.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(photo); err != nil {
+		log.LogError("Failed to send price sparkline", zap.String("ticker", ticker), zap.Error(err))
+		reply(text.String())
+		return
+	}
+
+	log.LogInfo("Price sent",
+		zap.String("ticker", ticker),
+		zap.Int("samples", len(samples)),
+		zap.String("chatID", formatChatID(message.Chat.ID)))
+}
+
+// sparklineChange - USD price change between first and last sample, empty if unknown
+func sparklineChange(samples []price_history.PriceSample) string {
+	var first, last float64
+	valid := 0
+	for _, sample := range samples {
+		if sample.PriceUsd <= 0 {
+			continue
+		}
+		if valid == 0 {
+			first = sample.PriceUsd
+		}
+		last = sample.PriceUsd
+		valid++
+	}
+	if valid < 2 {
+		return ""
+	}
+	return fmt.Sprintf("%+.1f%%", (last-first)/first*100)
+}
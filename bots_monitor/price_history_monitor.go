@@ -0,0 +1,70 @@
+package bots_monitor
+
+// Hourly token price sampling for tracked pools (volatility and drawdown in /token and weekly recap)
+
+import (
+	"context"
+	"time"
+
+	"spark-wallet/internal/features/price_history"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// RunPriceHistoryMonitor samples prices of tracked pools (filtered_tokens.json) every interval
+func RunPriceHistoryMonitor(ctx context.Context, interval time.Duration) {
+	log.LogInfo("Starting Price History Monitor...",
+		zap.String("file", price_history.PriceHistoryFile()),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial sample
+	samplePriceHistory(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Price History Monitor stopped")
+			return
+		case <-ticker.C:
+			samplePriceHistory(ctx)
+		}
+	}
+}
+
+func samplePriceHistory(ctx context.Context) {
+	pools, err := storage.LoadFilteredTokens()
+	if err != nil {
+		log.LogError("Failed to load filtered tokens for price history", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	var lastErr error
+	sampled := 0
+	for _, poolLpPublicKey := range pools {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := price_history.SamplePool(poolLpPublicKey); err != nil {
+			log.LogWarn("Failed to sample token price",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			lastErr = err
+			continue
+		}
+		sampled++
+	}
+
+	// Run fails only if no pool was sampled
+	if sampled == 0 && lastErr != nil {
+		ReportMonitorError(ctx, lastErr)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+	log.LogDebug("Token prices sampled", zap.Int("pools", sampled))
+}
@@ -0,0 +1,317 @@
+package bots_monitor
+
+// Quiet hours and token mutes of chats (see internal/features/quiet_hours)
+// Monitors call holdAlert before sending alert: alerts of muted token are dropped,
+// alerts in quiet hours are held and RunQuietHoursMonitor sends them as one summary after window ends
+// /quiet {HH:MM-HH:MM|off} - quiet hours of chat (MSK), /mute {ticker} {duration}, /unmute {ticker}
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/quiet_hours"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// maxSummaryLines - held alerts listed in quiet hours summary, others are counted
+const maxSummaryLines = 40
+
+// holdAlert checks mutes and quiet hours of chat before alert is sent
+// Returns true if alert must not be sent now (muted token or quiet hours)
+// message - alert text (HTML), its first line is kept for summary
+func holdAlert(bot *tgbotapi.BotAPI, chat int64, kind string, poolLpPublicKey string, ticker string, message string) bool {
+	text := strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(message, "")))
+	if line, _, found := strings.Cut(text, "\n"); found {
+		text = strings.TrimSpace(line)
+	}
+
+	alert := quiet_hours.HeldAlert{
+		Kind:            kind,
+		PoolLpPublicKey: poolLpPublicKey,
+		Ticker:          ticker,
+		Text:            text,
+	}
+	if bot != nil {
+		alert.BotID = bot.Self.ID
+	}
+
+	decision, err := quiet_hours.Check(chat, alert, time.Now())
+	if err != nil {
+		// Alert is not lost because of broken quiet hours file
+		log.LogWarn("Failed to check quiet hours, sending alert",
+			zap.Int64("chatID", chat),
+			zap.String("kind", kind),
+			zap.Error(err))
+		return false
+	}
+
+	switch decision {
+	case quiet_hours.Muted:
+		log.LogDebug("Alert of muted token dropped",
+			zap.Int64("chatID", chat),
+			zap.String("kind", kind),
+			zap.String("poolLpPublicKey", poolLpPublicKey))
+		return true
+	case quiet_hours.Held:
+		log.LogDebug("Alert held for quiet hours summary",
+			zap.Int64("chatID", chat),
+			zap.String("kind", kind),
+			zap.String("poolLpPublicKey", poolLpPublicKey))
+		return true
+	}
+	return false
+}
+
+// RunQuietHoursMonitor sends summary of held alerts to chats whose quiet hours are over
+// bots - bots sending alerts, summary is sent by the bot of last held alert
+func RunQuietHoursMonitor(ctx context.Context, bots []*tgbotapi.BotAPI, interval time.Duration) {
+	botsByID := make(map[int64]*tgbotapi.BotAPI)
+	var defaultBot *tgbotapi.BotAPI
+	for _, bot := range bots {
+		if bot == nil {
+			continue
+		}
+		if defaultBot == nil {
+			defaultBot = bot
+		}
+		botsByID[bot.Self.ID] = bot
+	}
+	if defaultBot == nil {
+		log.LogWarn("No bot for quiet hours summaries, monitor not started")
+		return
+	}
+
+	log.LogInfo("Starting Quiet Hours Monitor...",
+		zap.String("file", quiet_hours.QuietHoursFile()),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial check: window may have ended while bot was stopped
+	sendQuietHoursSummaries(ctx, botsByID, defaultBot)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Quiet Hours Monitor stopped")
+			return
+		case <-ticker.C:
+			sendQuietHoursSummaries(ctx, botsByID, defaultBot)
+		}
+	}
+}
+
+func sendQuietHoursSummaries(ctx context.Context, botsByID map[int64]*tgbotapi.BotAPI, defaultBot *tgbotapi.BotAPI) {
+	summaries, err := quiet_hours.TakeSummaries(time.Now())
+	if err != nil {
+		log.LogError("Failed to take quiet hours summaries", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+
+	for _, summary := range summaries {
+		bot, exists := botsByID[summary.BotID]
+		if !exists {
+			bot = defaultBot
+		}
+
+		msg := tgbotapi.NewMessage(summary.ChatID, FormatQuietHoursSummary(summary))
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		if _, err := sendToChat(ctx, bot, summary.ChatID, msg); err != nil {
+			log.LogError("Failed to send quiet hours summary",
+				zap.Int64("chatID", summary.ChatID),
+				zap.Int("alerts", len(summary.Alerts)),
+				zap.Error(err))
+			continue
+		}
+
+		log.LogInfo("Quiet hours summary sent",
+			zap.Int64("chatID", summary.ChatID),
+			zap.Int("alerts", len(summary.Alerts)+summary.Dropped))
+	}
+}
+
+// FormatQuietHoursSummary formats alerts held during quiet hours (HTML)
+func FormatQuietHoursSummary(summary quiet_hours.Summary) string {
+	total := len(summary.Alerts) + summary.Dropped
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("🌙 <b>Quiet hours summary</b>: %d alerts\n", total))
+	message.WriteString("<blockquote>")
+	for i, alert := range summary.Alerts {
+		if i == maxSummaryLines {
+			break
+		}
+		at := alert.Time
+		if t, err := time.Parse(time.RFC3339, alert.Time); err == nil {
+			at = t.In(quiet_hours.DefaultLocation).Format("15:04")
+		}
+		message.WriteString(fmt.Sprintf("%s %s\n", at, html.EscapeString(alert.Text)))
+	}
+	if more := total - min(len(summary.Alerts), maxSummaryLines); more > 0 {
+		message.WriteString(fmt.Sprintf("…and %d more\n", more))
+	}
+	message.WriteString("</blockquote>")
+	return message.String()
+}
+
+// handleQuietCommand /quiet - quiet hours and mutes of chat, /quiet {HH:MM-HH:MM} or /quiet off
+func handleQuietCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	chat := message.Chat.ID
+	if args != "" {
+		if err := quiet_hours.SetWindow(chat, args); err != nil {
+			log.LogWarn("Failed to set quiet hours",
+				zap.String("chatID", formatChatID(chat)),
+				zap.String("args", args),
+				zap.Error(err))
+			reply("Usage: /quiet {HH:MM-HH:MM} (MSK) or /quiet off")
+			return
+		}
+		log.LogInfo("Quiet hours set",
+			zap.String("chatID", formatChatID(chat)),
+			zap.String("quietHours", args))
+	}
+
+	window, enabled, err := quiet_hours.WindowOf(chat)
+	if err != nil {
+		log.LogError("Failed to load quiet hours", zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+	mutes, err := quiet_hours.ActiveMutes(chat, time.Now())
+	if err != nil {
+		log.LogError("Failed to load muted tokens", zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	var text strings.Builder
+	if enabled {
+		text.WriteString(fmt.Sprintf("🌙 Quiet hours: <b>%s</b> (%s)\nAlerts are held and sent as one summary after quiet hours", window, window.Location))
+	} else {
+		text.WriteString("🌙 Quiet hours are off\nUsage: /quiet {HH:MM-HH:MM} (MSK) or /quiet off")
+	}
+	text.WriteString("\n\n")
+	text.WriteString(formatMutes(mutes))
+	reply(text.String())
+}
+
+// handleMuteCommand /mute {ticker} {duration}, /mute - muted tokens of chat
+func handleMuteCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	chat := message.Chat.ID
+	parts := strings.Fields(args)
+	if len(parts) == 0 {
+		mutes, err := quiet_hours.ActiveMutes(chat, time.Now())
+		if err != nil {
+			log.LogError("Failed to load muted tokens", zap.Error(err))
+			reply("An error occurred, please try again later")
+			return
+		}
+		reply("Usage: /mute {ticker} {duration}, e.g. /mute SOON 2h (30m, 2h, 1d)\n\n" + formatMutes(mutes))
+		return
+	}
+	if len(parts) != 2 {
+		reply("Usage: /mute {ticker} {duration}, e.g. /mute SOON 2h (30m, 2h, 1d)")
+		return
+	}
+
+	ticker := parts[0]
+	duration, err := quiet_hours.ParseMuteDuration(parts[1])
+	if err != nil {
+		reply(fmt.Sprintf("Invalid duration: %s\nUsage: /mute {ticker} {duration}, e.g. /mute SOON 2h (30m, 2h, 1d)", html.EscapeString(parts[1])))
+		return
+	}
+
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker for mute",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply(fmt.Sprintf("Ticker {%s} not found. Make sure the token has been traded before.", html.EscapeString(ticker)))
+		return
+	}
+
+	until := time.Now().Add(duration)
+	if err := quiet_hours.MuteToken(chat, poolLpPublicKey, strings.ToUpper(ticker), until); err != nil {
+		log.LogError("Failed to mute token", zap.String("ticker", ticker), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	log.LogInfo("Token muted",
+		zap.String("ticker", ticker),
+		zap.String("chatID", formatChatID(chat)),
+		zap.Duration("duration", duration))
+	reply(fmt.Sprintf("🔕 Alerts of {%s} are muted in this chat until %s MSK",
+		strings.ToUpper(html.EscapeString(ticker)), until.In(quiet_hours.DefaultLocation).Format("02.01 15:04")))
+}
+
+// handleUnmuteCommand /unmute {ticker}
+func handleUnmuteCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		reply(fmt.Sprintf("Ticker {%s} not found. Make sure the token has been traded before.", html.EscapeString(ticker)))
+		return
+	}
+
+	removed, err := quiet_hours.UnmuteToken(message.Chat.ID, poolLpPublicKey)
+	if err != nil {
+		log.LogError("Failed to unmute token", zap.String("ticker", ticker), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+	if !removed {
+		reply(fmt.Sprintf("{%s} is not muted in this chat", strings.ToUpper(html.EscapeString(ticker))))
+		return
+	}
+	reply(fmt.Sprintf("🔔 Alerts of {%s} are back on", strings.ToUpper(html.EscapeString(ticker))))
+}
+
+// formatMutes formats muted tokens of chat (HTML)
+func formatMutes(mutes []quiet_hours.Mute) string {
+	if len(mutes) == 0 {
+		return "No muted tokens"
+	}
+	var text strings.Builder
+	text.WriteString("<b>Muted tokens</b>\n<blockquote>")
+	for _, mute := range mutes {
+		until := mute.Until
+		if t, err := time.Parse(time.RFC3339, mute.Until); err == nil {
+			until = t.In(quiet_hours.DefaultLocation).Format("02.01 15:04")
+		}
+		text.WriteString(fmt.Sprintf("{%s} until %s\n", html.EscapeString(mute.Ticker), until))
+	}
+	text.WriteString("</blockquote>")
+	return text.String()
+}
@@ -0,0 +1,237 @@
+package bots_monitor
+
+// Reach of token alerts: deliveries are counted per token and chat, member count of chats is sampled
+// /reach {ticker} shows chats the token alerts go to, their audience and price alert subscribers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/price_alerts"
+	"spark-wallet/internal/features/reach"
+	"spark-wallet/internal/infra/events"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// reachMaxChatsShown - chats listed in /reach reply
+const reachMaxChatsShown = 15
+
+// recordReach counts swap alert delivered to chat
+// label - destination or rule name (empty for other kinds)
+func recordReach(swap flashnet.Swap, chatID int64, kind string, label string) {
+	ticker := ""
+	if metadata := luminex.GetCachedTokenMetadata(swap.PoolLpPublicKey); metadata != nil {
+		ticker = metadata.Ticker
+	}
+	if err := reach.RecordDelivery(swap.PoolLpPublicKey, ticker, chatID, kind, label, time.Now()); err != nil {
+		log.LogWarn("Failed to record alert reach",
+			zap.String("poolLpPublicKey", swap.PoolLpPublicKey),
+			zap.Int64("chatID", chatID),
+			zap.Error(err))
+	}
+	recordAlertEvent(events.Alert{
+		Kind:            kind,
+		ChatID:          chatID,
+		Label:           label,
+		PoolLpPublicKey: swap.PoolLpPublicKey,
+		Ticker:          ticker,
+		SwapID:          swap.ID,
+	}, "")
+}
+
+// RunReachMonitor samples member count of chats that received token alerts
+// bots - bots sending alerts, chat is sampled by the first bot that can see it
+// interval - interval between samples
+func RunReachMonitor(ctx context.Context, bots []*tgbotapi.BotAPI, interval time.Duration) {
+	var available []*tgbotapi.BotAPI
+	for _, bot := range bots {
+		if bot != nil {
+			available = append(available, bot)
+		}
+	}
+	if len(available) == 0 {
+		log.LogWarn("No bot for reach sampling, monitor not started")
+		return
+	}
+
+	log.LogInfo("Starting Reach Monitor...",
+		zap.String("file", reach.ReachFile()),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial sample
+	sampleReachChats(ctx, available)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Reach Monitor stopped")
+			return
+		case <-ticker.C:
+			sampleReachChats(ctx, available)
+		}
+	}
+}
+
+// sampleReachChats saves title and member count of each chat that received alerts
+// Private chat (positive ID) is one user and is not requested
+func sampleReachChats(ctx context.Context, bots []*tgbotapi.BotAPI) {
+	chatIDs, err := reach.ChatIDs()
+	if err != nil {
+		log.LogError("Failed to load reach chats", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	var lastErr error
+	sampled := 0
+	for _, chatID := range chatIDs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		title, members := "", 1
+		if chatID < 0 {
+			title, members, err = getChatAudience(bots, chatID)
+			if err != nil {
+				log.LogWarn("Failed to get chat member count for reach",
+					zap.Int64("chatID", chatID),
+					zap.Error(err))
+				lastErr = err
+				continue
+			}
+		}
+
+		if err := reach.SetChatInfo(chatID, title, members, time.Now()); err != nil {
+			log.LogError("Failed to save chat reach", zap.Int64("chatID", chatID), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		sampled++
+	}
+
+	log.LogDebug("Reach chats sampled", zap.Int("chats", len(chatIDs)), zap.Int("sampled", sampled))
+
+	// Run fails only if no chat was sampled
+	if sampled == 0 && lastErr != nil {
+		ReportMonitorError(ctx, lastErr)
+	} else {
+		ReportMonitorSuccess(ctx)
+	}
+}
+
+// getChatAudience returns title and member count of group or channel, tried with each bot
+func getChatAudience(bots []*tgbotapi.BotAPI, chatID int64) (string, int, error) {
+	var lastErr error
+	for _, bot := range bots {
+		config := tgbotapi.ChatConfig{ChatID: chatID}
+		members, err := bot.GetChatMembersCount(tgbotapi.ChatMemberCountConfig{ChatConfig: config})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		title := ""
+		if chat, err := bot.GetChat(tgbotapi.ChatInfoConfig{ChatConfig: config}); err == nil {
+			title = chat.Title
+		}
+		return title, members, nil
+	}
+	return "", 0, lastErr
+}
+
+// handleReachCommand /reach {ticker}
+func handleReachCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil || poolLpPublicKey == "" {
+		// Token may be missing in holders storage but already have delivered alerts
+		poolLpPublicKey, err = reach.FindPoolByTicker(ticker)
+		if err != nil {
+			log.LogError("Failed to find pool for reach", zap.String("ticker", ticker), zap.Error(err))
+			reply("An error occurred, please try again later")
+			return
+		}
+	}
+	if poolLpPublicKey == "" {
+		reply(fmt.Sprintf("Token {%s} not found", html.EscapeString(ticker)))
+		return
+	}
+
+	summary, err := reach.GetSummary(poolLpPublicKey, time.Now())
+	if err != nil {
+		log.LogError("Failed to load reach", zap.String("ticker", ticker), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	subscribers, err := price_alerts.CountSubscribers(poolLpPublicKey)
+	if err != nil {
+		log.LogWarn("Failed to count price alert subscribers", zap.String("ticker", ticker), zap.Error(err))
+	}
+
+	reply(formatReachMessage(ticker, summary, subscribers))
+
+	log.LogInfo("Reach sent",
+		zap.String("ticker", ticker),
+		zap.String("chatID", formatChatID(message.Chat.ID)))
+}
+
+// formatReachMessage - reach of token (summary is nil if alerts were never delivered)
+func formatReachMessage(ticker string, summary *reach.Summary, subscribers int) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📣 <b>{%s}</b> alert reach\n", html.EscapeString(ticker)))
+
+	if summary == nil || len(summary.Chats) == 0 {
+		text.WriteString("<blockquote>No alerts delivered yet\n")
+		text.WriteString(fmt.Sprintf("Price alert subscribers - %d</blockquote>", subscribers))
+		return text.String()
+	}
+
+	text.WriteString("<blockquote>")
+	text.WriteString(fmt.Sprintf("Chats - %d\n", len(summary.Chats)))
+	text.WriteString(fmt.Sprintf("Audience - %d members\n", summary.Members))
+	text.WriteString(fmt.Sprintf("Alerts - %d (7d: %d)\n", summary.Alerts, summary.AlertsWeek))
+	text.WriteString(fmt.Sprintf("Price alert subscribers - %d</blockquote>\n", subscribers))
+
+	for i, chat := range summary.Chats {
+		if i == reachMaxChatsShown {
+			text.WriteString(fmt.Sprintf("\n... and %d more", len(summary.Chats)-reachMaxChatsShown))
+			break
+		}
+
+		name := chat.Title
+		if name == "" {
+			name = chat.ChatID
+		}
+		kind := chat.Kind
+		if chat.Label != "" {
+			kind += ": " + chat.Label
+		}
+		members := "?"
+		if chat.Members > 0 {
+			members = fmt.Sprintf("%d", chat.Members)
+		}
+		text.WriteString(fmt.Sprintf("\n• %s (%s) - %s members, %d alerts (7d: %d)",
+			html.EscapeString(name), html.EscapeString(kind), members, chat.Alerts, chat.AlertsWeek))
+	}
+	return text.String()
+}
@@ -0,0 +1,144 @@
+package bots_monitor
+
+// Pool reserve monitor: snapshots both reserves of tracked pools every interval (pool_reserves.json)
+// and alerts when BTC reserve of pool drains by threshold or more within an hour
+// Liquidity Monitor compares TVL, which moves with price too; drain alert follows BTC that can actually leave the pool
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/reserves"
+	"spark-wallet/internal/infra/events"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// RunReserveMonitor snapshots reserves of tracked pools (filtered_tokens.json)
+// bot, chatID - drain alerts, nil bot - snapshots only (collector mode)
+// drainPercent - BTC reserve drop (percent) within reserves.DrainWindow that triggers alert, 0 - snapshots only
+// interval - interval between snapshots
+func RunReserveMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, drainPercent float64, interval time.Duration) {
+	if bot == nil || chatID == "" {
+		drainPercent = 0
+	}
+
+	log.LogInfo("Starting Reserve Monitor...",
+		zap.String("file", reserves.ReservesFile()),
+		zap.String("chatID", chatID),
+		zap.Float64("drainPercent", drainPercent),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Initial snapshot
+	sampleReserves(ctx, bot, chatID, drainPercent)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Reserve Monitor stopped")
+			return
+		case <-ticker.C:
+			sampleReserves(ctx, bot, chatID, drainPercent)
+		}
+	}
+}
+
+// sampleReserves snapshots all tracked pools and sends alerts for drained pools
+func sampleReserves(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, drainPercent float64) {
+	pools, err := storage.LoadFilteredTokens()
+	if err != nil {
+		log.LogError("Failed to load filtered tokens for reserve monitor", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	var lastErr error
+	sampled := 0
+	defer func() {
+		// Run fails only if no pool was sampled
+		if sampled == 0 && lastErr != nil {
+			ReportMonitorError(ctx, lastErr)
+		} else {
+			ReportMonitorSuccess(ctx)
+		}
+	}()
+
+	for _, poolLpPublicKey := range pools {
+		if ctx.Err() != nil {
+			return
+		}
+
+		drain, err := reserves.SamplePool(poolLpPublicKey, drainPercent)
+		if err != nil {
+			log.LogWarn("Failed to sample pool reserves",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			lastErr = err
+			continue
+		}
+		sampled++
+		if drain == nil {
+			continue
+		}
+
+		message := FormatReserveDrainMessage(drain)
+		if holdAlert(bot, parseChatIDBig(chatID), "reserve_drain", poolLpPublicKey, drain.Ticker, message) {
+			continue
+		}
+		tradeLink := fmt.Sprintf("https://luminex.io/spark/trade/%s", poolLpPublicKey)
+		msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyMarkup = tradeKeyboard(tradeLink, i18n.English)
+		if _, err := bot.Send(msg); err != nil {
+			log.LogError("Failed to send reserve drain alert",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			continue
+		}
+		recordDashboardAlert("reserve_drain", message, tradeLink)
+		recordAlertEvent(events.Alert{
+			Kind:            "reserve_drain",
+			ChatID:          parseChatIDBig(chatID),
+			PoolLpPublicKey: poolLpPublicKey,
+			Ticker:          drain.Ticker,
+		}, message)
+
+		log.LogInfo("Reserve drain alert sent",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.Float64("dropPercent", drain.DropPercent),
+			zap.Duration("period", drain.Period))
+	}
+}
+
+// FormatReserveDrainMessage formats BTC reserve drain alert (HTML)
+func FormatReserveDrainMessage(drain *reserves.Drain) string {
+	ticker := drain.Ticker
+	if ticker == "" {
+		ticker = FormatTokenAddress(drain.PoolLpPublicKey)
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("🩸 <b>BTC reserve drained</b>: {%s}\n", strings.ToUpper(ticker)))
+	message.WriteString("<blockquote>")
+	message.WriteString(fmt.Sprintf("BTC reserve: %s → %s btc (-%.1f%%)\n",
+		amount.FormatBTC(drain.From.BtcReserve), amount.FormatBTC(drain.To.BtcReserve), drain.DropPercent))
+	if drain.From.TokenReserve > 0 || drain.To.TokenReserve > 0 {
+		message.WriteString(fmt.Sprintf("Token reserve: %s → %s\n",
+			formatTokenAmountLocal(drain.From.TokenReserve), formatTokenAmountLocal(drain.To.TokenReserve)))
+	}
+	message.WriteString(fmt.Sprintf("Period: %d min", int(math.Round(drain.Period.Minutes()))))
+	message.WriteString("</blockquote>")
+	return message.String()
+}
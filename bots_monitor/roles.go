@@ -0,0 +1,277 @@
+package bots_monitor
+
+// Command authorization by roles of users (see internal/features/roles)
+// Commands above member level are run only by owners, admins and, with telegram.chat_admins,
+// administrators of chat (Telegram check is cached for chatAdminCacheTTL)
+// Separate admin chat (API_BOT_CHAT_ID) is trusted: its members have admin role
+// /admins - list of owners and admins, /admins add {userID} (or reply to user), /admins del {userID}
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/features/roles"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/ttlcache"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// chatAdminCacheTTL - lifetime of Telegram chat administrator check
+	chatAdminCacheTTL = 5 * time.Minute
+)
+
+// chatAdminCaches - chat administrator checks per bot, key "chatID:userID"
+var (
+	chatAdminCachesMutex sync.Mutex
+	chatAdminCaches      = make(map[*tgbotapi.BotAPI]*ttlcache.Cache[bool])
+)
+
+// authorizeCommand reports whether sender of message may run command, replies with denial if not
+// isTrustedChat - message is from separate admin chat
+func authorizeCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, command string, isTrustedChat bool) bool {
+	required := roles.CommandLevel(command)
+	if required == roles.LevelMember || !roles.Enabled() {
+		return true
+	}
+
+	role := userRole(bot, message, isTrustedChat)
+	if role >= required {
+		return true
+	}
+
+	log.LogWarn("Command denied",
+		zap.String("command", command),
+		zap.String("required", required.String()),
+		zap.String("role", role.String()),
+		zap.Int64("userID", senderID(message)),
+		zap.String("chatID", formatChatID(message.Chat.ID)))
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, denialText("/"+command, required))
+	msg.ReplyToMessageID = message.MessageID
+	bot.Send(msg)
+	return false
+}
+
+// userRole returns role of sender: owner or added admin, admin in trusted chat or as chat administrator
+func userRole(bot *tgbotapi.BotAPI, message *tgbotapi.Message, isTrustedChat bool) roles.Level {
+	if message.From == nil {
+		return roles.LevelMember
+	}
+
+	role, err := roles.RoleOf(message.From.ID)
+	if err != nil {
+		log.LogWarn("Failed to load admins", zap.Error(err))
+	}
+	if role >= roles.LevelAdmin {
+		return role
+	}
+	if isTrustedChat || (roles.ChatAdmins() && isChatAdmin(bot, message.Chat.ID, message.From.ID)) {
+		return roles.LevelAdmin
+	}
+	return role
+}
+
+// isChatAdmin checks whether user is creator or administrator of chat (cached)
+func isChatAdmin(bot *tgbotapi.BotAPI, chatID int64, userID int64) bool {
+	chatAdminCachesMutex.Lock()
+	cache, exists := chatAdminCaches[bot]
+	if !exists {
+		cache = ttlcache.New(ttlcache.Options{TTL: chatAdminCacheTTL, NegativeTTL: chatAdminCacheTTL, ErrorTTL: time.Minute},
+			func(ctx context.Context, key string) (bool, bool, error) {
+				chat, user, _ := strings.Cut(key, ":")
+				chatID, _ := strconv.ParseInt(chat, 10, 64)
+				userID, _ := strconv.ParseInt(user, 10, 64)
+				member, err := bot.GetChatMember(tgbotapi.GetChatMemberConfig{
+					ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: userID},
+				})
+				if err != nil {
+					return false, false, err
+				}
+				isAdmin := member.IsCreator() || member.IsAdministrator()
+				return isAdmin, isAdmin, nil
+			})
+		chatAdminCaches[bot] = cache
+	}
+	chatAdminCachesMutex.Unlock()
+
+	isAdmin, err := cache.Get(context.Background(), fmt.Sprintf("%d:%d", chatID, userID))
+	if err != nil {
+		log.LogWarn("Failed to check chat administrator",
+			zap.Int64("userID", userID),
+			zap.String("chatID", formatChatID(chatID)),
+			zap.Error(err))
+		return false
+	}
+	return isAdmin
+}
+
+func denialText(action string, required roles.Level) string {
+	if required == roles.LevelOwner {
+		return fmt.Sprintf("⛔ %s is available only to bot owners", action)
+	}
+	return fmt.Sprintf("⛔ %s is available only to bot admins. Ask an admin to run it or to add you with /admins add", action)
+}
+
+func senderID(message *tgbotapi.Message) int64 {
+	if message.From == nil {
+		return 0
+	}
+	return message.From.ID
+}
+
+// handleAdminsCommand /admins, /admins add {userID}, /admins del {userID}
+func handleAdminsCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args string, isTrustedChat bool) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+	usage := "Usage: /admins, /admins add {userID} or /admins del {userID}\n\nReply to a user's message with /admins add to add them"
+
+	fields := strings.Fields(args)
+	if len(fields) == 0 || strings.EqualFold(fields[0], "list") {
+		reply(formatAdmins())
+		return
+	}
+
+	action := strings.ToLower(fields[0])
+	if action != "add" && action != "del" {
+		reply(html.EscapeString(usage))
+		return
+	}
+
+	// Adding and removing admins needs owner level (admin without owners in config),
+	// while roles are off only admin chat adds the first admin
+	required := roles.CommandLevel(roles.ManageAdminsCommand)
+	if !roles.CanManageAdmins(userRole(bot, message, isTrustedChat), isTrustedChat) {
+		log.LogWarn("Command denied",
+			zap.String("command", "admins "+action),
+			zap.String("required", required.String()),
+			zap.Bool("rolesEnabled", roles.Enabled()),
+			zap.Int64("userID", senderID(message)),
+			zap.String("chatID", formatChatID(message.Chat.ID)))
+		if !roles.Enabled() {
+			reply(html.EscapeString(fmt.Sprintf("⛔ Roles are off. The first admin is added with /admins %s from the admin chat, or set owners in telegram.admin_user_ids", action)))
+			return
+		}
+		reply(html.EscapeString(denialText("/admins "+action, required)))
+		return
+	}
+
+	var userID int64
+	var username string
+	switch {
+	case len(fields) == 2:
+		id, err := strconv.ParseInt(strings.TrimSpace(fields[1]), 10, 64)
+		if err != nil || id <= 0 {
+			reply("User ID must be a number, e.g. <code>/admins " + action + " 123456789</code>. Or reply to the user's message with <code>/admins " + action + "</code>")
+			return
+		}
+		userID = id
+	case len(fields) == 1 && message.ReplyToMessage != nil && message.ReplyToMessage.From != nil && !message.ReplyToMessage.From.IsBot:
+		userID = message.ReplyToMessage.From.ID
+		username = message.ReplyToMessage.From.UserName
+	default:
+		reply(html.EscapeString(usage))
+		return
+	}
+
+	if action == "add" {
+		added, err := roles.AddAdmin(roles.Admin{UserID: userID, Username: username, AddedBy: senderID(message), AddedAt: time.Now().UTC()})
+		if err != nil {
+			log.LogError("Failed to add admin", zap.Int64("userID", userID), zap.Error(err))
+			reply("An error occurred, please try again later")
+			return
+		}
+		if !added {
+			reply(fmt.Sprintf("%s is already an admin", html.EscapeString(adminName(userID, username))))
+			return
+		}
+		reply(fmt.Sprintf("✅ %s is now an admin", html.EscapeString(adminName(userID, username))))
+		log.LogInfo("Admin added",
+			zap.Int64("userID", userID),
+			zap.Int64("addedBy", senderID(message)))
+		return
+	}
+
+	removed, err := roles.RemoveAdmin(userID)
+	if err != nil {
+		log.LogError("Failed to remove admin", zap.Int64("userID", userID), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+	if !removed {
+		reply(fmt.Sprintf("%s is not an admin (owners are set in config)", html.EscapeString(adminName(userID, username))))
+		return
+	}
+	reply(fmt.Sprintf("🗑 %s is no longer an admin", html.EscapeString(adminName(userID, username))))
+	log.LogInfo("Admin removed",
+		zap.Int64("userID", userID),
+		zap.Int64("removedBy", senderID(message)))
+}
+
+// formatAdmins returns owners, admins and commands above member level (HTML)
+func formatAdmins() string {
+	var text strings.Builder
+	text.WriteString("👮 <b>Bot roles</b>\n")
+	if !roles.Enabled() {
+		text.WriteString("Roles are off: every member runs every command. Set <code>telegram.admin_user_ids</code> or add an admin with <code>/admins add</code> from the admin chat.\n")
+	}
+
+	owners := roles.Owners()
+	text.WriteString(fmt.Sprintf("\nOwners (config): %d\n", len(owners)))
+	if len(owners) > 0 {
+		var ids []string
+		for _, owner := range owners {
+			ids = append(ids, fmt.Sprintf("<code>%d</code>", owner))
+		}
+		text.WriteString("<blockquote>" + strings.Join(ids, ", ") + "</blockquote>\n")
+	}
+
+	admins, err := roles.Admins()
+	if err != nil {
+		log.LogWarn("Failed to load admins", zap.Error(err))
+	}
+	text.WriteString(fmt.Sprintf("Admins: %d\n", len(admins)))
+	if len(admins) > 0 {
+		text.WriteString("<blockquote>")
+		for i, admin := range admins {
+			if i > 0 {
+				text.WriteString("\n")
+			}
+			text.WriteString(fmt.Sprintf("%s, added %s", html.EscapeString(adminName(admin.UserID, admin.Username)), admin.AddedAt.Format("02.01.2006")))
+		}
+		text.WriteString("</blockquote>\n")
+	}
+	if roles.ChatAdmins() {
+		text.WriteString("Chat administrators are admins\n")
+	}
+
+	var levels []string
+	for _, command := range botCommands {
+		if level := roles.CommandLevel(command.name); level > roles.LevelMember {
+			levels = append(levels, fmt.Sprintf("/%s - %s", command.name, level))
+		}
+	}
+	levels = append(levels, fmt.Sprintf("/admins add, del - %s", roles.CommandLevel(roles.ManageAdminsCommand)))
+	sort.Strings(levels)
+	text.WriteString("\nCommands above member level:\n<blockquote>" + strings.Join(levels, "\n") + "</blockquote>")
+	return text.String()
+}
+
+func adminName(userID int64, username string) string {
+	if username != "" {
+		return fmt.Sprintf("@%s (%d)", username, userID)
+	}
+	return strconv.FormatInt(userID, 10)
+}
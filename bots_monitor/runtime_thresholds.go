@@ -0,0 +1,129 @@
+package bots_monitor
+
+// Minimum BTC thresholds changed at runtime (admin API)
+// Override of each chat replaces config value until reset to 0, overrides are kept in
+// data_out/telegram_out/runtime_thresholds.json and applied on start
+// Config values of reloaded config.yaml replace values monitors were started with (SetConfigThresholds)
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"spark-wallet/internal/infra/paths"
+)
+
+// RuntimeThresholdsFile - thresholds set via admin API
+func RuntimeThresholdsFile() string {
+	return paths.Output("telegram_out", "runtime_thresholds.json")
+}
+
+// RuntimeThresholds - overrides of min BTC thresholds, 0 - config value
+type RuntimeThresholds struct {
+	BigSalesMinBTC float64 `json:"big_sales_min_btc"`
+	FilteredMinBTC float64 `json:"filtered_min_btc"`
+}
+
+var (
+	runtimeThresholds      RuntimeThresholds
+	runtimeThresholdsMutex sync.RWMutex
+
+	// configThresholds - thresholds of reloaded config, 0 - value monitor was started with
+	configThresholds RuntimeThresholds
+)
+
+// LoadRuntimeThresholds loads saved overrides (no overrides if file doesn't exist)
+func LoadRuntimeThresholds() (RuntimeThresholds, error) {
+	runtimeThresholdsMutex.Lock()
+	defer runtimeThresholdsMutex.Unlock()
+
+	raw, err := os.ReadFile(RuntimeThresholdsFile())
+	if os.IsNotExist(err) || (err == nil && len(raw) == 0) {
+		runtimeThresholds = RuntimeThresholds{}
+		return runtimeThresholds, nil
+	}
+	if err != nil {
+		return RuntimeThresholds{}, fmt.Errorf("failed to read runtime thresholds file: %w", err)
+	}
+
+	var thresholds RuntimeThresholds
+	if err := json.Unmarshal(raw, &thresholds); err != nil {
+		return RuntimeThresholds{}, fmt.Errorf("failed to parse runtime thresholds JSON: %w", err)
+	}
+	runtimeThresholds = thresholds
+	return thresholds, nil
+}
+
+// GetRuntimeThresholds returns current overrides
+func GetRuntimeThresholds() RuntimeThresholds {
+	runtimeThresholdsMutex.RLock()
+	defer runtimeThresholdsMutex.RUnlock()
+	return runtimeThresholds
+}
+
+// SetRuntimeThresholds saves overrides and applies them from the next monitor batch
+func SetRuntimeThresholds(thresholds RuntimeThresholds) error {
+	if thresholds.BigSalesMinBTC < 0 || thresholds.FilteredMinBTC < 0 {
+		return fmt.Errorf("thresholds cannot be negative")
+	}
+
+	runtimeThresholdsMutex.Lock()
+	defer runtimeThresholdsMutex.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(RuntimeThresholdsFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(thresholds, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal runtime thresholds JSON: %w", err)
+	}
+
+	tempFilePath := RuntimeThresholdsFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary runtime thresholds file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, RuntimeThresholdsFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to runtime thresholds file: %w", err)
+	}
+
+	runtimeThresholds = thresholds
+	return nil
+}
+
+// SetConfigThresholds sets thresholds of reloaded config, applied from the next monitor batch
+func SetConfigThresholds(thresholds RuntimeThresholds) {
+	runtimeThresholdsMutex.Lock()
+	defer runtimeThresholdsMutex.Unlock()
+	configThresholds = thresholds
+}
+
+// effectiveBigSalesMinBTC returns big sales chat threshold (override, reloaded config or configured)
+func effectiveBigSalesMinBTC(configured float64) float64 {
+	runtimeThresholdsMutex.RLock()
+	defer runtimeThresholdsMutex.RUnlock()
+	if runtimeThresholds.BigSalesMinBTC > 0 {
+		return runtimeThresholds.BigSalesMinBTC
+	}
+	if configThresholds.BigSalesMinBTC > 0 {
+		return configThresholds.BigSalesMinBTC
+	}
+	return configured
+}
+
+// effectiveFilteredMinBTC returns filtered chat threshold (override, reloaded config or configured)
+func effectiveFilteredMinBTC(configured float64) float64 {
+	runtimeThresholdsMutex.RLock()
+	defer runtimeThresholdsMutex.RUnlock()
+	if runtimeThresholds.FilteredMinBTC > 0 {
+		return runtimeThresholds.FilteredMinBTC
+	}
+	if configThresholds.FilteredMinBTC > 0 {
+		return configThresholds.FilteredMinBTC
+	}
+	return configured
+}
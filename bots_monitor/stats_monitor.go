@@ -3,24 +3,28 @@ package bots_monitor
 // Package bot contains and in Telegram
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	"spark-wallet/internal/clients_api/luminex"
 	"spark-wallet/internal/features/tg_charts"
 	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
 )
 
+// statsLocation - timezone of stats send time and of stats.json dates
+var statsLocation = scheduler.LoadLocation("Europe/Moscow")
+
 // RunStatsMonitor in time by
 // bot - Telegram for
 // filteredChatID - ID for
 // sendTime - time in "HH:MM" "10:00")
-func RunStatsMonitor(bot *tgbotapi.BotAPI, filteredChatID string, sendTime string) {
+func RunStatsMonitor(ctx context.Context, bot *tgbotapi.BotAPI, filteredChatID string, sendTime string) {
 	if bot == nil {
 		log.LogWarn("Bot is nil, stats monitor not started")
 		return
@@ -33,14 +37,7 @@ func RunStatsMonitor(bot *tgbotapi.BotAPI, filteredChatID string, sendTime strin
 
 	log.LogInfo("Starting Stats Monitor...", zap.String("filteredChatID", filteredChatID))
 
-	// Load
-	moscowLocation, err := time.LoadLocation("Europe/Moscow")
-	if err != nil {
-		log.LogError("Failed to load Moscow timezone, using UTC", zap.Error(err))
-		moscowLocation = time.UTC
-	}
-
-	sendStats := func(check bool) {
+	sendStats := func(check bool, date string) {
 		// Get from API
 		stats, err := luminex.GetStats()
 		if err != nil {
@@ -49,7 +46,7 @@ func RunStatsMonitor(bot *tgbotapi.BotAPI, filteredChatID string, sendTime strin
 		}
 
 		// Save data in stats.json check
-		if err := luminex.SaveStatsData(stats, check); err != nil {
+		if err := luminex.SaveStatsData(stats, check, date); err != nil {
 			log.LogError("Failed to save stats data", zap.Error(err))
 		}
 
@@ -66,7 +63,7 @@ func RunStatsMonitor(bot *tgbotapi.BotAPI, filteredChatID string, sendTime strin
 			),
 		)
 
-		chartPath, err := tg_charts.GenerateVolumeChart()
+		chartPath, err := tg_charts.GenerateVolumeChart(parseChatIDBig(filteredChatID))
 		if err != nil {
 			log.LogWarn("Failed to generate volume chart", zap.Error(err))
 			msg := tgbotapi.NewMessage(parseChatIDBig(filteredChatID), statsMessage)
@@ -111,57 +108,25 @@ func RunStatsMonitor(bot *tgbotapi.BotAPI, filteredChatID string, sendTime strin
 			zap.Float64("volume24h", stats.TotalVolume24HUSD))
 	}
 
-	// Parse time "HH:MM")
-	timeParts := strings.Split(sendTime, ":")
-	if len(timeParts) != 2 {
-		log.LogWarn("Invalid send time format, using default 10:00", zap.String("sendTime", sendTime))
-		sendTime = "10:00"
-		timeParts = []string{"10", "00"}
-	}
-
-	var hour, minute int
-	n1, err1 := fmt.Sscanf(timeParts[0], "%d", &hour)
-	n2, err2 := fmt.Sscanf(timeParts[1], "%d", &minute)
-	if err1 != nil || n1 != 1 || err2 != nil || n2 != 1 {
-		log.LogWarn("Failed to parse time components, using default 10:00",
-			zap.String("hourStr", timeParts[0]),
-			zap.String("minuteStr", timeParts[1]))
-		hour = 10
-		minute = 0
-	}
-
-	now := time.Now().In(moscowLocation)
-	nextSend := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, moscowLocation)
-
-	// If 10:00 on
-	if now.After(nextSend) || now.Equal(nextSend) {
-		nextSend = nextSend.Add(24 * time.Hour)
+	schedule, err := scheduler.ParseDaily(sendTime, statsLocation)
+	if err != nil {
+		log.LogWarn("Invalid send time format, using default 10:00", zap.String("sendTime", sendTime), zap.Error(err))
+		schedule = scheduler.Daily{Hour: 10, Minute: 0, Location: statsLocation}
 	}
 
-	// Calculate
-	delay := nextSend.Sub(now)
-	log.LogInfo("Stats monitor scheduled",
-		zap.Time("nextSend", nextSend),
-		zap.Duration("delay", delay))
-
-	firstTimer := time.NewTimer(delay)
-	go func() {
-		<-firstTimer.C
-		sendStats(true) // check = true for
-
-		// create ticker on 24
-		ticker := time.NewTicker(24 * time.Hour)
-		defer ticker.Stop()
-
-		for range ticker.C {
-			sendStats(true) // check = true for
-		}
-	}()
-
 	log.LogInfo("Stats monitor started successfully",
-		zap.String("sendTime", sendTime),
-		zap.Time("nextSend", nextSend),
-		zap.Duration("delay", delay))
+		zap.String("sendTime", fmt.Sprintf("%02d:%02d", schedule.Hour, schedule.Minute)),
+		zap.String("timezone", statsLocation.String()),
+		zap.Time("nextSend", schedule.Next(scheduler.Default.Now())))
+
+	scheduler.Default.Run(ctx, scheduler.Job{
+		Name:     "stats",
+		Schedule: schedule,
+		Run: func(ctx context.Context, now time.Time) {
+			sendStats(true, scheduler.Date(now, statsLocation)) // check = true for
+		},
+	})
+	log.LogInfo("Stats monitor stopped")
 }
 
 // CheckAndSendStatsOnStartup and if -
@@ -179,7 +144,8 @@ func CheckAndSendStatsOnStartup(bot *tgbotapi.BotAPI, filteredChatID string) {
 	}
 
 	// Check,
-	checked, err := luminex.IsStatsCheckedToday()
+	today := scheduler.Date(time.Now(), statsLocation)
+	checked, err := luminex.IsStatsChecked(today)
 	if err != nil {
 		log.LogWarn("Failed to check if stats were checked today", zap.Error(err))
 	}
@@ -199,7 +165,7 @@ func CheckAndSendStatsOnStartup(bot *tgbotapi.BotAPI, filteredChatID string) {
 	}
 
 	// Save data in stats.json check = true
-	if err := luminex.SaveStatsData(stats, true); err != nil {
+	if err := luminex.SaveStatsData(stats, true, today); err != nil {
 		log.LogError("Failed to save stats data on startup", zap.Error(err))
 	}
 
@@ -216,7 +182,7 @@ func CheckAndSendStatsOnStartup(bot *tgbotapi.BotAPI, filteredChatID string) {
 		),
 	)
 
-	chartPath, err := tg_charts.GenerateVolumeChart()
+	chartPath, err := tg_charts.GenerateVolumeChart(parseChatIDBig(filteredChatID))
 	if err != nil {
 		log.LogWarn("Failed to generate volume chart on startup", zap.Error(err))
 		msg := tgbotapi.NewMessage(parseChatIDBig(filteredChatID), statsMessage)
@@ -0,0 +1,174 @@
+package bots_monitor
+
+// Suspicious activity monitor: wash trading and self-swaps in new swaps (see risk.FindSuspiciousActivity)
+// Alert lists wallets of the pattern with links, so chat can check them before trading
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/risk"
+	"spark-wallet/internal/features/wallet_labels"
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// suspiciousSwapsPeriod - archived swaps checked on every run (volume spike needs trailing day)
+const suspiciousSwapsPeriod = 24 * time.Hour
+
+// RunSuspiciousActivityMonitor checks archived swaps every interval and alerts about suspicious patterns
+// bot - Telegram for alerts
+// chatID - ID for alerts
+// minBTC - volume of pattern (BTC) for alert, 0 - monitor disabled
+func RunSuspiciousActivityMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, minBTC float64, interval time.Duration) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, suspicious activity monitor not started")
+		return
+	}
+	if minBTC <= 0 {
+		log.LogInfo("Suspicious activity min BTC is 0, suspicious activity monitor disabled")
+		return
+	}
+
+	log.LogInfo("Starting Suspicious Activity Monitor...",
+		zap.String("chatID", chatID),
+		zap.Float64("minBTC", minBTC),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Suspicious Activity Monitor stopped")
+			return
+		case <-ticker.C:
+			checkSuspiciousActivity(ctx, bot, chatID, minBTC)
+		}
+	}
+}
+
+// checkSuspiciousActivity finds suspicious patterns in recent swaps and sends alerts
+func checkSuspiciousActivity(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, minBTC float64) {
+	now := time.Now()
+	swaps, err := risk.LoadRecentSwaps(suspiciousSwapsPeriod, now)
+	if err != nil {
+		log.LogError("Failed to load recent swaps for suspicious activity", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	found, err := risk.FindSuspiciousActivity(ctx, swaps, minBTC, now)
+	if err != nil {
+		log.LogError("Failed to check suspicious activity", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+
+	for _, activity := range found {
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := ""
+		if metadata := luminex.GetTokenMetadata(activity.PoolLpPublicKey); metadata != nil {
+			ticker = metadata.Ticker
+		}
+		message := FormatSuspiciousActivityMessage(activity, ticker)
+		if holdAlert(bot, parseChatIDBig(chatID), "suspicious", activity.PoolLpPublicKey, ticker, message) {
+			continue
+		}
+		tradeLink := fmt.Sprintf("https://luminex.io/spark/trade/%s", activity.PoolLpPublicKey)
+
+		msg := tgbotapi.NewMessage(parseChatIDBig(chatID), message)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyMarkup = tradeKeyboard(tradeLink, i18n.English)
+		if _, err := bot.Send(msg); err != nil {
+			log.LogError("Failed to send suspicious activity alert",
+				zap.String("poolLpPublicKey", activity.PoolLpPublicKey),
+				zap.String("pattern", activity.Pattern),
+				zap.Error(err))
+			continue
+		}
+
+		if err := risk.MarkSuspiciousAlerted(activity.PoolLpPublicKey, activity.Pattern, now); err != nil {
+			log.LogWarn("Failed to save suspicious activity alert", zap.Error(err))
+		}
+		recordDashboardAlert("suspicious", message, tradeLink)
+		recordAlertEvent(events.Alert{
+			Kind:            "suspicious",
+			ChatID:          parseChatIDBig(chatID),
+			PoolLpPublicKey: activity.PoolLpPublicKey,
+			Ticker:          ticker,
+		}, message)
+
+		log.LogInfo("Suspicious activity alert sent",
+			zap.String("poolLpPublicKey", activity.PoolLpPublicKey),
+			zap.String("pattern", activity.Pattern),
+			zap.Float64("volumeBTC", activity.VolumeBTC),
+			zap.Int("wallets", len(activity.Evidence)))
+	}
+}
+
+// FormatSuspiciousActivityMessage formats suspicious activity alert (Telegram HTML)
+func FormatSuspiciousActivityMessage(activity risk.SuspiciousActivity, ticker string) string {
+	if ticker == "" {
+		ticker = FormatTokenAddress(activity.PoolLpPublicKey)
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("⚠️ <b>suspicious activity</b>: {%s}\n", strings.ToUpper(html.EscapeString(ticker))))
+	message.WriteString("<blockquote>")
+	message.WriteString(fmt.Sprintf("Pattern: %s\n", suspiciousPatternName(activity.Pattern)))
+	message.WriteString(html.EscapeString(activity.Summary) + "\n")
+	message.WriteString(fmt.Sprintf("Volume: %s btc", amount.FormatBTC(activity.VolumeBTC)))
+	message.WriteString("</blockquote>")
+
+	if len(activity.Evidence) > 0 {
+		message.WriteString("\nEvidence:\n")
+	}
+	for i, evidence := range activity.Evidence {
+		line := suspiciousWalletLink(evidence.Wallet)
+		if evidence.Counterparty != "" {
+			line += " → " + suspiciousWalletLink(evidence.Counterparty)
+		}
+		message.WriteString(fmt.Sprintf("%d. %s: %s\n", i+1, line, html.EscapeString(evidence.Detail)))
+	}
+	return strings.TrimSuffix(message.String(), "\n")
+}
+
+// suspiciousPatternName returns readable name of pattern
+func suspiciousPatternName(pattern string) string {
+	switch pattern {
+	case risk.PatternFlipping:
+		return "wash trading (buy/sell flipping)"
+	case risk.PatternCircular:
+		return "circular transfers between traders"
+	case risk.PatternVolumeSpike:
+		return "volume spike without holder growth"
+	}
+	return pattern
+}
+
+// suspiciousWalletLink returns Luminex link of wallet (username or short address)
+func suspiciousWalletLink(wallet string) string {
+	name := FormatTokenAddress(wallet)
+	if label := wallet_labels.Of(wallet); label != "" {
+		name = label
+	} else if username := luminex.GetWalletUsername(wallet); username != "" {
+		name = username
+	}
+	return fmt.Sprintf("<a href=\"https://luminex.io/spark/address/%s\">%s</a>", wallet, html.EscapeString(name))
+}
@@ -0,0 +1,88 @@
+package bots_monitor
+
+// Gaps and duplicates of swap feed
+// Swap IDs seen within storage.SeenSwapsWindow are kept in data_out/big_sales_module/seen_swaps.json,
+// so swaps are alerted once after restart or when API returns an older page
+// When newest saved swap is not in fetched page (burst above swap_poll_limit or missed polls),
+// older pages are fetched by offset until it is found, up to maxGapPages pages
+
+import (
+	"context"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// maxGapPages - extra pages fetched to close gap of one poll
+const maxGapPages = 5
+
+// fillSwapGap adds older pages to fetched page until newestSaved swap is reached
+// Returns swaps (newest first) and false if gap could not be closed (some swaps may be missed)
+func fillSwapGap(ctx context.Context, client *flashnet.Client, limit int, fetched []flashnet.Swap, newestSaved flashnet.Swap) ([]flashnet.Swap, bool) {
+	if newestSaved.ID == "" || len(fetched) < limit || gapClosed(fetched, newestSaved) {
+		return fetched, true
+	}
+
+	swaps := append([]flashnet.Swap(nil), fetched...)
+	fetchedIDs := make(map[string]bool, len(fetched))
+	for _, swap := range fetched {
+		fetchedIDs[swap.ID] = true
+	}
+
+	for page := 1; page <= maxGapPages; page++ {
+		offset := page * limit
+		resp, err := client.GetSwaps(ctx, flashnet.GetSwapsOptions{Limit: &limit, Offset: &offset})
+		if err != nil {
+			log.LogWarn("Failed to fetch missed swaps", zap.Int("offset", offset), zap.Error(err))
+			return swaps, false
+		}
+		// Offsets shift when new swaps arrive between requests, repeated swaps are skipped
+		for _, swap := range resp.Swaps {
+			if !fetchedIDs[swap.ID] {
+				fetchedIDs[swap.ID] = true
+				swaps = append(swaps, swap)
+			}
+		}
+		if len(resp.Swaps) < limit || gapClosed(resp.Swaps, newestSaved) {
+			log.LogInfo("Fetched missed swaps", zap.Int("pages", page), zap.Int("swaps", len(swaps)-len(fetched)))
+			return swaps, true
+		}
+	}
+	return swaps, false
+}
+
+// gapClosed reports whether page reaches newestSaved swap (contains it or older swaps)
+// Page is not treated as older when time of its last swap or of newestSaved is unknown, paging goes on
+func gapClosed(page []flashnet.Swap, newestSaved flashnet.Swap) bool {
+	for _, swap := range page {
+		if swap.ID == newestSaved.ID {
+			return true
+		}
+	}
+	if len(page) == 0 {
+		return false
+	}
+	oldest, ok := storage.SwapTime(page[len(page)-1])
+	if !ok {
+		return false
+	}
+	saved, ok := storage.SwapTime(newestSaved)
+	return ok && oldest.Before(saved)
+}
+
+// isStaleSwapsPage reports whether API returned page older than saved one (newest swap is before newest saved swap)
+// Page with unknown swap time is not stale, it is processed and gap is filled as usual
+func isStaleSwapsPage(saved, fetched []flashnet.Swap) bool {
+	if len(saved) == 0 || len(fetched) == 0 || fetched[0].ID == saved[0].ID {
+		return false
+	}
+	newest, ok := storage.SwapTime(fetched[0])
+	if !ok {
+		return false
+	}
+	newestSaved, ok := storage.SwapTime(saved[0])
+	return ok && newest.Before(newestSaved)
+}
@@ -0,0 +1,238 @@
+package bots_monitor
+
+// Swap alert message: data gathering and rendering are separate steps
+// GatherSwapContext collects everything alert shows (Luminex pool and wallet, first buy, holders, BTC price)
+// RenderSwapMessage / RenderSwapPhoto build Telegram message from SwapContext without network requests,
+// so layouts are covered by golden-file tests (internal/tests/testdata/swap_messages)
+// Context is gathered once per swap and rendered in language of each chat (i18n)
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/swap_templates"
+	"spark-wallet/internal/features/wallet_labels"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// defaultTokenDecimals - decimals of token amount when token metadata is unknown
+const defaultTokenDecimals = 8
+
+// swapAlert - rendered swap notification
+type swapAlert struct {
+	Text     string
+	Keyboard tgbotapi.InlineKeyboardMarkup
+}
+
+// SwapContext - data shown in swap alert, empty field is left out of message
+type SwapContext struct {
+	Swap           flashnet.Swap
+	Name           string  // token name
+	Ticker         string  // token ticker
+	Decimals       int     // token decimals
+	MarketCapUSD   float64 // 0 - unknown
+	BTCPriceUSD    float64 // 0 - USD amounts are left out
+	FirstBuy       string  // date of first buy of wallet in token
+	Holding        string  // token holding of wallet ("null" if not found)
+	HoldingValue   string
+	Username       string // Luminex username of wallet
+	Label          string // wallet label (/label), shown instead of username
+	BalanceKnown   bool   // wallet balance was fetched (wallet link and balance are shown)
+	SparkAddress   string
+	BalanceSats    int64
+	WhaleBadge     string    // "🐋 ..." line
+	FundingWarning string    // "⚠️ ..." line
+	Lang           i18n.Lang // language of chat, empty - English
+}
+
+// formatSwapMessageForTelegram formats swap message for Telegram with template of token (swap_templates).
+func formatSwapMessageForTelegram(client *flashnet.Client, swap flashnet.Swap, lang i18n.Lang) swapAlert {
+	return renderSwapAlert(GatherSwapContext(client, swap), lang)
+}
+
+// renderSwapAlert renders gathered swap alert in language of chat
+func renderSwapAlert(sc SwapContext, lang i18n.Lang) swapAlert {
+	sc.Lang = lang
+	text, keyboard := RenderSwapMessage(sc)
+	return swapAlert{Text: text, Keyboard: keyboard}
+}
+
+// GatherSwapContext requests data of swap alert from Luminex, Flashnet (first buy, client may be nil) and local storage
+func GatherSwapContext(client *flashnet.Client, swap flashnet.Swap) SwapContext {
+	sc := SwapContext{Swap: swap, Decimals: defaultTokenDecimals}
+
+	swapType := swap.GetSwapType()
+	if swapType != flashnet.SwapTypeBuy && swapType != flashnet.SwapTypeSell {
+		// Token-to-token swap is shown as raw swap data
+		return sc
+	}
+
+	if price, ok := currentBTCPriceUSD(false); ok {
+		sc.BTCPriceUSD = price
+	}
+
+	tokenMetadata := luminex.GetTokenMetadata(swap.PoolLpPublicKey)
+	if tokenMetadata != nil {
+		sc.Name = tokenMetadata.Name
+		sc.Ticker = tokenMetadata.Ticker
+	}
+
+	// Pool is cached by client and reused for decimals and price
+	if pool, err := luminex.DefaultClient().GetPool(context.Background(), swap.PoolLpPublicKey); err == nil {
+		sc.MarketCapUSD = pool.TokenMetadataForSwap(swap, "").AggMarketcapUsd
+	} else {
+		log.LogDebug("Failed to get pool for marketcap",
+			zap.String("poolLpPublicKey", swap.PoolLpPublicKey),
+			zap.Error(err))
+	}
+
+	if client != nil {
+		firstBuyDate, err := storage.GetFirstBuyDate(client, swap.SwapperPublicKey, swap.PoolLpPublicKey)
+		if err != nil {
+			log.LogDebug("Failed to get first buy swap",
+				zap.String("swapperPublicKey", swap.SwapperPublicKey),
+				zap.String("poolLpPublicKey", swap.PoolLpPublicKey),
+				zap.Error(err))
+		}
+		sc.FirstBuy = firstBuyDate
+	}
+
+	if sc.Ticker != "" {
+		sc.Decimals = luminex.GetTokenDecimals(swap.PoolLpPublicKey, swap, sc.Ticker)
+		sc.Holding, sc.HoldingValue = luminex.GetWalletTokenHolding(swap.SwapperPublicKey, swap.PoolLpPublicKey, swap, sc.Ticker)
+	}
+
+	sc.Username = luminex.GetWalletUsername(swap.SwapperPublicKey)
+	sc.Label = wallet_labels.Of(swap.SwapperPublicKey)
+	if balanceResp, err := luminex.GetWalletBalance(swap.SwapperPublicKey); err == nil && balanceResp != nil {
+		sc.BalanceKnown = true
+		sc.SparkAddress = balanceResp.SparkAddress
+		sc.BalanceSats = balanceResp.Balance.BtcHardBalanceSats
+	}
+
+	// Badge for whale wallets (holding above configured % of token supply)
+	sc.WhaleBadge = whaleBadgeForSwap(swap, sc.Ticker)
+	// Warning for new buyer wallets funded by flagged wallet (team, previous rug)
+	sc.FundingWarning = fundingWarningForSwap(swap)
+
+	return sc
+}
+
+// RenderSwapMessage renders swap alert with template of token (swap_templates)
+// Token-to-token swap and broken template get raw swap layout with trade button
+func RenderSwapMessage(sc SwapContext) (string, tgbotapi.InlineKeyboardMarkup) {
+	swap := sc.Swap
+	tradeLink := fmt.Sprintf("https://luminex.io/spark/trade/%s", swap.PoolLpPublicKey)
+
+	swapType := swap.GetSwapType()
+	if swapType != flashnet.SwapTypeBuy && swapType != flashnet.SwapTypeSell {
+		return formatSwapMessageBig(swap, sc.BTCPriceUSD, sc.Lang), tradeKeyboard(tradeLink, sc.Lang)
+	}
+
+	rendered, err := swap_templates.Render(swapTemplateData(sc, tradeLink))
+	if err != nil {
+		log.LogWarn("Failed to render swap template, using default layout",
+			zap.String("poolLpPublicKey", swap.PoolLpPublicKey),
+			zap.Error(err))
+	}
+	if rendered == nil {
+		return formatSwapMessageBig(swap, sc.BTCPriceUSD, sc.Lang), tradeKeyboard(tradeLink, sc.Lang)
+	}
+
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(rendered.Buttons))
+	for _, button := range rendered.Buttons {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonURL(button.Text, button.URL)))
+	}
+	return rendered.Text, tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// RenderSwapPhoto renders swap alert as photo with message as caption (token template with photo)
+func RenderSwapPhoto(chatID int64, photoURL string, sc SwapContext) tgbotapi.PhotoConfig {
+	text, keyboard := RenderSwapMessage(sc)
+	photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileURL(photoURL))
+	photo.Caption = text
+	photo.ParseMode = tgbotapi.ModeHTML
+	photo.ReplyMarkup = keyboard
+	return photo
+}
+
+// swapTemplateData converts context of buy/sell swap to template values (HTML-escaped)
+func swapTemplateData(sc SwapContext, tradeLink string) swap_templates.SwapData {
+	swap := sc.Swap
+	btcAmount := getBTCAmountFromSwap(swap)
+
+	data := swap_templates.SwapData{
+		IsBuy:            swap.GetSwapType() == flashnet.SwapTypeBuy,
+		IsSell:           swap.GetSwapType() == flashnet.SwapTypeSell,
+		Emoji:            swap_templates.DefaultBuyEmoji,
+		Action:           i18n.T(sc.Lang, "swap.buy"),
+		BTCAmount:        amount.FormatBTC(btcAmount),
+		USDAmount:        formatUSDAmount(btcAmount, sc.BTCPriceUSD),
+		MarketCap:        formatMarketCap(sc.MarketCapUSD),
+		FirstBuy:         html.EscapeString(sc.FirstBuy),
+		WhaleBadge:       sc.WhaleBadge,
+		FundingWarning:   sc.FundingWarning,
+		TradeLink:        tradeLink,
+		PoolLpPublicKey:  swap.PoolLpPublicKey,
+		SwapperPublicKey: swap.SwapperPublicKey,
+		Lang:             sc.Lang,
+	}
+	if data.IsSell {
+		data.Emoji = swap_templates.DefaultSellEmoji
+		data.Action = i18n.T(sc.Lang, "swap.sell")
+	}
+
+	if sc.Holding != "" {
+		data.Holding = html.EscapeString(sc.Holding)
+		if sc.Holding != "null" {
+			data.HoldingValue = html.EscapeString(sc.HoldingValue)
+		}
+	}
+
+	if len(swap.SwapperPublicKey) >= 3 {
+		data.WalletSuffix = swap.SwapperPublicKey[len(swap.SwapperPublicKey)-3:]
+	}
+
+	// Wallet with known balance is linked, otherwise its public key is shown
+	data.WalletName = html.EscapeString(swap.SwapperPublicKey)
+	if sc.BalanceKnown {
+		sparkAddress := sc.SparkAddress
+		if sparkAddress == "" {
+			sparkAddress = swap.SwapperPublicKey
+		}
+		data.WalletLink = html.EscapeString(fmt.Sprintf("https://luminex.io/spark/address/%s", sparkAddress))
+		data.Balance = amount.FormatBTC(float64(sc.BalanceSats) / 1e8)
+		data.WalletName = "wallet"
+	}
+	if sc.Username != "" {
+		data.WalletName = html.EscapeString(sc.Username)
+	}
+	if sc.Label != "" {
+		data.WalletName = html.EscapeString(sc.Label)
+	}
+
+	data.Ticker = html.EscapeString(sc.Ticker)
+	data.TokenName = swap.PoolLpPublicKey
+	if sc.Name != "" && sc.Ticker != "" {
+		data.Name = html.EscapeString(sc.Name)
+		data.TokenName = fmt.Sprintf("%s {%s}", data.Name, data.Ticker)
+	}
+
+	// Buy: amountOut is token, sell: amountIn is token
+	tokenAmount := swap.AmountOut
+	if data.IsSell {
+		tokenAmount = swap.AmountIn
+	}
+	data.TokenAmount = html.EscapeString(formatSwapTokenAmount(tokenAmount, sc.Decimals))
+
+	return data
+}
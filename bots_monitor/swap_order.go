@@ -0,0 +1,73 @@
+package bots_monitor
+
+// Swap delivery order
+// API returns swaps newest first; alerts are delivered oldest first (by swap timestamp),
+// so within a token a sell never appears before the buy that preceded it
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// maxDeliveredPools - pools whose last delivered swap time is remembered
+const maxDeliveredPools = 5000
+
+var (
+	lastDeliveredSwap      = make(map[string]time.Time) // poolLpPublicKey -> time of last delivered swap
+	lastDeliveredSwapMutex sync.Mutex
+)
+
+// orderSwapsForDelivery returns swaps sorted by swap timestamp, oldest first
+// Swaps with equal timestamps keep reversed API order (API lists newest first)
+func orderSwapsForDelivery(swaps []flashnet.Swap) []flashnet.Swap {
+	ordered := make([]flashnet.Swap, len(swaps))
+	for i, swap := range swaps {
+		ordered[len(swaps)-1-i] = swap
+	}
+	// Swaps of unknown time are delivered last
+	now := time.Now()
+	deliveryTime := func(swap flashnet.Swap) time.Time {
+		if at, ok := storage.SwapTime(swap); ok {
+			return at
+		}
+		return now
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return deliveryTime(ordered[i]).Before(deliveryTime(ordered[j]))
+	})
+	return ordered
+}
+
+// markSwapDelivered records delivery of swap in its token sequence
+// Swap older than already delivered swap of the same token (late in API) is logged, it cannot be reordered anymore
+func markSwapDelivered(swap flashnet.Swap) {
+	swapTime, ok := storage.SwapTime(swap)
+	if !ok {
+		return
+	}
+
+	lastDeliveredSwapMutex.Lock()
+	defer lastDeliveredSwapMutex.Unlock()
+
+	last, exists := lastDeliveredSwap[swap.PoolLpPublicKey]
+	if exists && swapTime.Before(last) {
+		log.LogWarn("Swap delivered out of order (arrived after newer swap of the same token)",
+			zap.String("swapID", swap.ID),
+			zap.String("poolLpPublicKey", swap.PoolLpPublicKey),
+			zap.Time("swapTime", swapTime),
+			zap.Time("lastDelivered", last))
+		return
+	}
+
+	if !exists && len(lastDeliveredSwap) >= maxDeliveredPools {
+		lastDeliveredSwap = make(map[string]time.Time)
+	}
+	lastDeliveredSwap[swap.PoolLpPublicKey] = swapTime
+}
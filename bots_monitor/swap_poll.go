@@ -0,0 +1,123 @@
+package bots_monitor
+
+// Swap polling schedule of big sales monitor
+// Swaps are requested every Interval. With adaptive polling the interval doubles after IdleAfter without new swaps
+// (up to IdleInterval) and drops back to Interval as soon as new swaps are seen
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSwapPollInterval - time between swaps requests
+	DefaultSwapPollInterval = 5 * time.Second
+	// DefaultSwapPollLimit - swaps per request
+	DefaultSwapPollLimit = 100
+	// DefaultSwapPollIdleInterval - longest time between requests when no new swaps are seen
+	DefaultSwapPollIdleInterval = 30 * time.Second
+	// DefaultSwapPollIdleAfter - time without new swaps before polling slows down
+	DefaultSwapPollIdleAfter = 2 * time.Minute
+)
+
+// SwapPollConfig - swap polling settings
+type SwapPollConfig struct {
+	Interval     time.Duration // time between requests while swaps are coming
+	Limit        int           // swaps per request
+	IdleInterval time.Duration // longest time between requests when idle, not above Interval - adaptive polling disabled
+	IdleAfter    time.Duration // time without new swaps before polling slows down
+}
+
+var (
+	swapPollConfig      = DefaultSwapPollConfig()
+	swapPollConfigMutex sync.RWMutex
+)
+
+// DefaultSwapPollConfig returns default swap polling settings
+func DefaultSwapPollConfig() SwapPollConfig {
+	return SwapPollConfig{
+		Interval:     DefaultSwapPollInterval,
+		Limit:        DefaultSwapPollLimit,
+		IdleInterval: DefaultSwapPollIdleInterval,
+		IdleAfter:    DefaultSwapPollIdleAfter,
+	}
+}
+
+// SetSwapPollConfig sets swap polling settings from config (zero values - defaults)
+func SetSwapPollConfig(cfg SwapPollConfig) {
+	swapPollConfigMutex.Lock()
+	swapPollConfig = cfg.withDefaults()
+	swapPollConfigMutex.Unlock()
+}
+
+func getSwapPollConfig() SwapPollConfig {
+	swapPollConfigMutex.RLock()
+	defer swapPollConfigMutex.RUnlock()
+	return swapPollConfig
+}
+
+func (c SwapPollConfig) withDefaults() SwapPollConfig {
+	if c.Interval <= 0 {
+		c.Interval = DefaultSwapPollInterval
+	}
+	if c.Limit <= 0 {
+		c.Limit = DefaultSwapPollLimit
+	}
+	if c.IdleAfter <= 0 {
+		c.IdleAfter = DefaultSwapPollIdleAfter
+	}
+	return c
+}
+
+// Adaptive returns true if polling slows down when no new swaps are seen
+func (c SwapPollConfig) Adaptive() bool {
+	return c.IdleInterval > c.Interval
+}
+
+// SwapPoller - interval of next swaps request
+type SwapPoller struct {
+	cfg          SwapPollConfig
+	interval     time.Duration
+	lastActivity time.Time // last poll with new swaps (or start)
+}
+
+// NewSwapPoller creates poller starting at cfg.Interval
+func NewSwapPoller(cfg SwapPollConfig, now time.Time) *SwapPoller {
+	cfg = cfg.withDefaults()
+	return &SwapPoller{cfg: cfg, interval: cfg.Interval, lastActivity: now}
+}
+
+// Reconfigure applies reloaded settings, polling restarts at cfg.Interval
+func (p *SwapPoller) Reconfigure(cfg SwapPollConfig) {
+	p.cfg = cfg.withDefaults()
+	p.interval = p.cfg.Interval
+}
+
+// Interval returns current time between requests
+func (p *SwapPoller) Interval() time.Duration {
+	return p.interval
+}
+
+// Idle returns true if polling is slowed down
+func (p *SwapPoller) Idle() bool {
+	return p.interval > p.cfg.Interval
+}
+
+// Observe records result of poll and returns time until next one
+// newSwaps - count of new swaps in response
+func (p *SwapPoller) Observe(newSwaps int, now time.Time) time.Duration {
+	if newSwaps > 0 || !p.cfg.Adaptive() {
+		p.lastActivity = now
+		p.interval = p.cfg.Interval
+		return p.interval
+	}
+	if now.Sub(p.lastActivity) < p.cfg.IdleAfter {
+		return p.interval
+	}
+	// Slowing down step by step, so burst right after quiet period is still caught early
+	p.interval *= 2
+	if p.interval > p.cfg.IdleInterval {
+		p.interval = p.cfg.IdleInterval
+	}
+	return p.interval
+}
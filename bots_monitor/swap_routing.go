@@ -0,0 +1,168 @@
+package bots_monitor
+
+// Swap notification routing to extra chats (telegram.destinations)
+// Each destination has its own bot, min BTC amount, token list, buy/sell and marketcap filters
+// Every new swap is sent to all matching destinations
+// Big sales and filtered chats have marketcap filter of their own (SetChatMarketCapRanges)
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/reach"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// SwapDestination - chat receiving swap notifications
+type SwapDestination struct {
+	Name   string
+	Bot    *tgbotapi.BotAPI
+	ChatID string
+	MinBTC float64
+	Tokens []string // tickers or poolLpPublicKey, empty - all tokens (blacklist applies)
+	Side   string   // buy, sell or empty for both
+
+	// Marketcap range of token in USD, 0 - no bound (swaps of tokens with unknown marketcap are skipped when set)
+	MinMarketCapUSD float64
+	MaxMarketCapUSD float64
+}
+
+// Matches checks destination filters against swap
+func (d *SwapDestination) Matches(swap flashnet.Swap) bool {
+	switch d.Side {
+	case "buy":
+		if !swap.IsBuy() {
+			return false
+		}
+	case "sell":
+		if !swap.IsSell() {
+			return false
+		}
+	default:
+		if !swap.IsBuy() && !swap.IsSell() {
+			return false
+		}
+	}
+
+	if !shouldSendSwap(swap, d.MinBTC) {
+		return false
+	}
+
+	if len(d.Tokens) > 0 && !d.matchesToken(swap) {
+		return false
+	}
+	return matchesMarketCap(swap, d.MinMarketCapUSD, d.MaxMarketCapUSD)
+}
+
+// ChatMarketCapRanges - marketcap range of token in USD for big sales and filtered chats, 0 - no bound
+type ChatMarketCapRanges struct {
+	BigSalesMinUSD float64
+	BigSalesMaxUSD float64
+	FilteredMinUSD float64
+	FilteredMaxUSD float64
+}
+
+var (
+	chatMarketCapRanges      ChatMarketCapRanges
+	chatMarketCapRangesMutex sync.RWMutex
+)
+
+// SetChatMarketCapRanges sets marketcap ranges of big sales and filtered chats (config, applied from the next monitor batch)
+func SetChatMarketCapRanges(ranges ChatMarketCapRanges) {
+	chatMarketCapRangesMutex.Lock()
+	chatMarketCapRanges = ranges
+	chatMarketCapRangesMutex.Unlock()
+}
+
+func getChatMarketCapRanges() ChatMarketCapRanges {
+	chatMarketCapRangesMutex.RLock()
+	defer chatMarketCapRangesMutex.RUnlock()
+	return chatMarketCapRanges
+}
+
+// matchesMarketCap checks marketcap of swap token against range (0 - no bound)
+// Pool response is cached by Luminex client, so marketcap is the value alert is rendered with
+// Tokens with unknown marketcap don't match when a bound is set
+func matchesMarketCap(swap flashnet.Swap, minUSD, maxUSD float64) bool {
+	if minUSD <= 0 && maxUSD <= 0 {
+		return true
+	}
+
+	marketCap := luminex.GetPoolMarketCap(swap.PoolLpPublicKey, swap)
+	if marketCap <= 0 {
+		return false
+	}
+	if minUSD > 0 && marketCap < minUSD {
+		return false
+	}
+	if maxUSD > 0 && marketCap > maxUSD {
+		return false
+	}
+	return true
+}
+
+func (d *SwapDestination) matchesToken(swap flashnet.Swap) bool {
+	var ticker string
+	tickerLoaded := false
+	for _, token := range d.Tokens {
+		token = strings.TrimSpace(token)
+		if token == swap.PoolLpPublicKey {
+			return true
+		}
+		// Ticker is needed only if token is not poolLpPublicKey
+		if !tickerLoaded {
+			if metadata := luminex.GetTokenMetadata(swap.PoolLpPublicKey); metadata != nil {
+				ticker = metadata.Ticker
+			}
+			tickerLoaded = true
+		}
+		if ticker != "" && strings.EqualFold(token, ticker) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeSwap queues swap for all matching destinations
+// format - formats message in language of chat, data is gathered once for all chats (largest swaps go through fast path)
+// onSent - called for every delivered notification
+func routeSwap(queue *alertQueue, destinations []SwapDestination, swap flashnet.Swap, blacklistedTokens []string, format func(lang i18n.Lang) swapAlert, onSent func()) {
+	for i := range destinations {
+		destination := &destinations[i]
+		if destination.Bot == nil || destination.ChatID == "" {
+			continue
+		}
+		// Destinations with explicit token list receive blacklisted tokens too
+		if len(destination.Tokens) == 0 && storage.IsTokenBlacklisted(swap.PoolLpPublicKey, blacklistedTokens) {
+			continue
+		}
+		if !destination.Matches(swap) {
+			continue
+		}
+
+		queue.add(destination.Bot, destination.ChatID, queuedAlert{
+			swap:     swap,
+			priority: swapAlertPriority(swap, destination.MinBTC),
+			text:     combinableSwapText(swap, destination.MinBTC, format),
+			send: func(ctx context.Context) (alertDelivery, error) {
+				return sendSwapAlert(ctx, destination.Bot, destination.ChatID, swap, destination.MinBTC, format)
+			},
+			onSent: func() {
+				onSent()
+				recordReach(swap, parseChatIDBig(destination.ChatID), reach.KindDestination, destination.Name)
+				log.LogInfo("Sent swap to destination",
+					zap.String("destination", destination.Name),
+					zap.String("swapID", swap.ID))
+			},
+			label: "destination " + destination.Name,
+		})
+	}
+}
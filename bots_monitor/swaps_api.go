@@ -0,0 +1,186 @@
+package bots_monitor
+
+// Read-only HTTP API of enriched swaps for external dashboards (app.swaps_api_addr), bearer token app.swaps_api_token
+// GET /api/swaps - swaps of archive, newest first (see internal/features/swap_feed)
+//   from, to  - RFC3339, YYYY-MM-DD or unix seconds/milliseconds (Grafana ${__from}), by default last 24h
+//   pool, ticker, wallet, side (buy, sell, swap), min_btc - filters
+//   offset, limit - page (by default 0 and 100, limit up to 500)
+// Token is separate from admin API token, so dashboards get no write access
+// CORS is open: the token is sent in header, browsers of custom frontends can call the API directly
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/swap_feed"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+type swapsAPI struct {
+	token string
+}
+
+// RunSwapsAPI serves swaps API on addr until ctx is cancelled
+func RunSwapsAPI(ctx context.Context, addr string, token string) {
+	if addr == "" {
+		log.LogInfo("Swaps API address is empty, swaps API disabled")
+		return
+	}
+	if token == "" {
+		log.LogWarn("Swaps API token is empty, swaps API not started")
+		return
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           NewSwapsAPIHandler(token),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second, // a month of archive is read by one request
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.LogWarn("Failed to shut down swaps API", zap.Error(err))
+		}
+	}()
+
+	log.LogInfo("Starting Swaps API...", zap.String("addr", addr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.LogError("Swaps API stopped", zap.Error(err))
+		return
+	}
+	log.LogInfo("Swaps API stopped")
+}
+
+// NewSwapsAPIHandler returns routes of swaps API protected by bearer token
+func NewSwapsAPIHandler(token string) http.Handler {
+	a := &swapsAPI{token: token}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/swaps", a.authorize(a.handleSwaps))
+	mux.HandleFunc("OPTIONS /api/swaps", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization")
+		w.Header().Set("Access-Control-Max-Age", "86400")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authorize rejects requests without valid bearer token
+func (a *swapsAPI) authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(provided)), []byte(a.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeAdminError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (a *swapsAPI) handleSwaps(w http.ResponseWriter, r *http.Request) {
+	filter, offset, limit, err := parseSwapsQuery(r, time.Now())
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := swap_feed.Load(filter, offset, limit)
+	if err != nil {
+		log.LogError("Swaps API failed to load swaps", zap.Error(err))
+		writeAdminError(w, http.StatusInternalServerError, "failed to load swaps")
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, page)
+}
+
+// parseSwapsQuery reads filter and page of GET /api/swaps
+func parseSwapsQuery(r *http.Request, now time.Time) (swap_feed.Filter, int, int, error) {
+	query := r.URL.Query()
+	filter := swap_feed.Filter{
+		To:     now,
+		Pool:   strings.TrimSpace(query.Get("pool")),
+		Ticker: strings.TrimSpace(query.Get("ticker")),
+		Wallet: strings.TrimSpace(query.Get("wallet")),
+		Side:   strings.ToLower(strings.TrimSpace(query.Get("side"))),
+	}
+
+	if value := query.Get("to"); value != "" {
+		to, err := parseSwapsTime(value)
+		if err != nil {
+			return filter, 0, 0, fmt.Errorf("invalid to: %w", err)
+		}
+		filter.To = to
+	}
+	filter.From = filter.To.Add(-swap_feed.DefaultPeriod)
+	if value := query.Get("from"); value != "" {
+		from, err := parseSwapsTime(value)
+		if err != nil {
+			return filter, 0, 0, fmt.Errorf("invalid from: %w", err)
+		}
+		filter.From = from
+	}
+	if value := query.Get("min_btc"); value != "" {
+		minBTC, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return filter, 0, 0, fmt.Errorf("invalid min_btc: %q", value)
+		}
+		filter.MinBTC = minBTC
+	}
+	if err := filter.Validate(); err != nil {
+		return filter, 0, 0, err
+	}
+
+	offset, limit := 0, swap_feed.DefaultLimit
+	if value := query.Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return filter, 0, 0, fmt.Errorf("offset must be a non-negative number")
+		}
+		offset = parsed
+	}
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > swap_feed.MaxLimit {
+			return filter, 0, 0, fmt.Errorf("limit must be from 1 to %d", swap_feed.MaxLimit)
+		}
+		limit = parsed
+	}
+	return filter, offset, limit, nil
+}
+
+// parseSwapsTime parses RFC3339, YYYY-MM-DD (UTC) or unix time in seconds or milliseconds
+func parseSwapsTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		// 13+ digits - milliseconds (Grafana), otherwise seconds
+		if unix >= 1e12 {
+			return time.UnixMilli(unix), nil
+		}
+		return time.Unix(unix, 0), nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.Parse("2006-01-02", value); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, fmt.Errorf("expected RFC3339, YYYY-MM-DD or unix time, got %q", value)
+}
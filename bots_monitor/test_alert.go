@@ -0,0 +1,292 @@
+package bots_monitor
+
+// Test alerts (/testalert): synthetic buy and sell swaps of a real pool sent to one route
+// Swaps go through the same formatting, templates and sending as real alerts (fast path, template photos, buttons),
+// so admins can check a route after configuration changes without waiting for a big swap
+// Test alerts are not counted in reach and dashboard, and their wallet is not saved as holder
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/i18n"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// testAlertSwapperPublicKey - wallet of test swaps (not a real wallet)
+	testAlertSwapperPublicKey = "020000000000000000000000000000000000000000000000000000000000000001"
+	// testAlertDefaultBTC - BTC amount of test swaps for routes without threshold
+	testAlertDefaultBTC = 0.01
+
+	testAlertRouteMain     = "main"
+	testAlertRouteFiltered = "filtered"
+)
+
+// testAlertRoutes - chats of big sales monitor, set when monitor starts
+type testAlertRoutes struct {
+	mainBot        *tgbotapi.BotAPI
+	mainChatID     string
+	mainMinBTC     float64
+	filteredBot    *tgbotapi.BotAPI
+	filteredChatID string
+	filteredMinBTC float64
+	destinations   []SwapDestination
+}
+
+var (
+	testAlertRoutesMutex sync.RWMutex
+	testAlertRoutesValue *testAlertRoutes // nil - big sales monitor is not running
+)
+
+// setTestAlertRoutes sets routes available for /testalert
+func setTestAlertRoutes(routes testAlertRoutes) {
+	testAlertRoutesMutex.Lock()
+	defer testAlertRoutesMutex.Unlock()
+	testAlertRoutesValue = &routes
+}
+
+func getTestAlertRoutes() *testAlertRoutes {
+	testAlertRoutesMutex.RLock()
+	defer testAlertRoutesMutex.RUnlock()
+	return testAlertRoutesValue
+}
+
+// names returns names of configured routes
+func (r *testAlertRoutes) names() []string {
+	var names []string
+	if r.mainBot != nil && r.mainChatID != "" {
+		names = append(names, testAlertRouteMain)
+	}
+	if r.filteredBot != nil && r.filteredChatID != "" {
+		names = append(names, testAlertRouteFiltered)
+	}
+	for _, destination := range r.destinations {
+		if destination.Bot != nil && destination.ChatID != "" {
+			names = append(names, destination.Name)
+		}
+	}
+	return names
+}
+
+// testAlertRoute - route chosen for test alert
+type testAlertRoute struct {
+	name        string
+	bot         *tgbotapi.BotAPI
+	chatID      string
+	minBTC      float64
+	filtered    bool             // filtered chat: template photos
+	destination *SwapDestination // nil for main and filtered chats
+}
+
+// route finds route by name (main, filtered or destination name)
+func (r *testAlertRoutes) route(name string) (testAlertRoute, bool) {
+	switch strings.ToLower(name) {
+	case testAlertRouteMain:
+		if r.mainBot == nil || r.mainChatID == "" {
+			return testAlertRoute{}, false
+		}
+		return testAlertRoute{name: testAlertRouteMain, bot: r.mainBot, chatID: r.mainChatID,
+			minBTC: effectiveBigSalesMinBTC(r.mainMinBTC)}, true
+	case testAlertRouteFiltered:
+		if r.filteredBot == nil || r.filteredChatID == "" {
+			return testAlertRoute{}, false
+		}
+		return testAlertRoute{name: testAlertRouteFiltered, bot: r.filteredBot, chatID: r.filteredChatID,
+			minBTC: effectiveFilteredMinBTC(r.filteredMinBTC), filtered: true}, true
+	}
+
+	for i := range r.destinations {
+		destination := &r.destinations[i]
+		if destination.Bot == nil || destination.ChatID == "" || !strings.EqualFold(destination.Name, name) {
+			continue
+		}
+		return testAlertRoute{name: destination.Name, bot: destination.Bot, chatID: destination.ChatID,
+			minBTC: destination.MinBTC, destination: destination}, true
+	}
+	return testAlertRoute{}, false
+}
+
+// defaultPool returns pool of test swaps when ticker is not given:
+// first token of destination list, otherwise first filtered token
+func (route testAlertRoute) defaultPool() string {
+	if route.destination != nil {
+		for _, token := range route.destination.Tokens {
+			token = strings.TrimSpace(token)
+			if token == "" {
+				continue
+			}
+			if poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(token); err == nil {
+				return poolLpPublicKey
+			}
+			return token
+		}
+	}
+
+	filteredTokens, err := storage.LoadFilteredTokens()
+	if err != nil {
+		log.LogWarn("Failed to load filtered tokens for test alert", zap.Error(err))
+		return ""
+	}
+	if len(filteredTokens) > 0 {
+		return filteredTokens[0]
+	}
+	return ""
+}
+
+// buildTestSwaps creates buy and sell swaps of pool for btcAmount
+// Token amount is calculated from current pool reserves
+func buildTestSwaps(ctx context.Context, client *flashnet.Client, poolLpPublicKey string, btcAmount float64) ([]flashnet.Swap, error) {
+	pool, err := client.GetPool(ctx, poolLpPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	reserves := pool.Reserves()
+	if !reserves.IsBTCPool {
+		return nil, fmt.Errorf("pool %s is not a BTC pool", poolLpPublicKey)
+	}
+
+	btcReserve, ok := new(big.Int).SetString(reserves.BTCReserve, 10)
+	if !ok || btcReserve.Sign() <= 0 {
+		return nil, fmt.Errorf("pool %s has no BTC reserve", poolLpPublicKey)
+	}
+	tokenReserve, ok := new(big.Int).SetString(reserves.TokenReserve, 10)
+	if !ok || tokenReserve.Sign() <= 0 {
+		return nil, fmt.Errorf("pool %s has no token reserve", poolLpPublicKey)
+	}
+
+	sats := big.NewInt(int64(btcAmount * 1e8))
+	tokens := new(big.Int).Div(new(big.Int).Mul(sats, tokenReserve), btcReserve)
+
+	now := time.Now().UTC()
+	base := flashnet.Swap{
+		CreatedAt:         now.Format(time.RFC3339),
+		Timestamp:         now.Format(time.RFC3339),
+		FeePaid:           "0",
+		PoolAssetAAddress: pool.AssetAAddress,
+		PoolAssetBAddress: pool.AssetBAddress,
+		PoolLpPublicKey:   poolLpPublicKey,
+		PoolType:          pool.CurveType,
+		Price:             pool.CurrentPriceAInB,
+		SwapperPublicKey:  testAlertSwapperPublicKey,
+	}
+
+	buy := base
+	buy.ID = fmt.Sprintf("testalert-buy-%d", now.UnixNano())
+	buy.AssetInAddress = flashnet.NativeTokenAddress
+	buy.AssetOutAddress = reserves.TokenAddress
+	buy.AmountIn = sats.String()
+	buy.AmountOut = tokens.String()
+
+	sell := base
+	sell.ID = fmt.Sprintf("testalert-sell-%d", now.UnixNano())
+	sell.AssetInAddress = reserves.TokenAddress
+	sell.AssetOutAddress = flashnet.NativeTokenAddress
+	sell.AmountIn = tokens.String()
+	sell.AmountOut = sats.String()
+
+	return []flashnet.Swap{buy, sell}, nil
+}
+
+// send sends swap to route the same way as real alert
+func (route testAlertRoute) send(ctx context.Context, client *flashnet.Client, swap flashnet.Swap) error {
+	if route.destination != nil && !route.destination.Matches(swap) {
+		return fmt.Errorf("skipped, swap does not match route filters")
+	}
+	if route.filtered {
+		_, err := sendFilteredSwapAlert(ctx, route.bot, route.chatID, client, swap, route.minBTC)
+		return err
+	}
+	_, err := sendSwapAlert(ctx, route.bot, route.chatID, swap, route.minBTC, func(lang i18n.Lang) swapAlert {
+		return formatSwapMessageForTelegram(client, swap, lang)
+	})
+	return err
+}
+
+// handleTestAlertCommand /testalert {route} [ticker] - sends test buy and sell alerts to route
+func handleTestAlertCommand(ctx context.Context, bot *tgbotapi.BotAPI, message *tgbotapi.Message, client *flashnet.Client, args string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	routes := getTestAlertRoutes()
+	if routes == nil || client == nil {
+		reply("Big sales monitor is not running, no routes to test")
+		return
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		reply(fmt.Sprintf("Usage: /testalert {route} [ticker]\n\nRoutes: %s\n\nExample: /testalert filtered SOON",
+			strings.Join(routes.names(), ", ")))
+		return
+	}
+
+	route, ok := routes.route(fields[0])
+	if !ok {
+		reply(fmt.Sprintf("Route {%s} not found. Routes: %s", fields[0], strings.Join(routes.names(), ", ")))
+		return
+	}
+
+	poolLpPublicKey := ""
+	if len(fields) > 1 {
+		var err error
+		poolLpPublicKey, err = storage.FindPoolLpPublicKeyByTicker(fields[1])
+		if err != nil {
+			reply(fmt.Sprintf("Ticker {%s} not found. Make sure the token has been traded before.", fields[1]))
+			return
+		}
+	} else {
+		poolLpPublicKey = route.defaultPool()
+		if poolLpPublicKey == "" {
+			reply("No default token for this route, specify ticker: /testalert {route} {ticker}")
+			return
+		}
+	}
+
+	btcAmount := route.minBTC * 2
+	if btcAmount <= 0 {
+		btcAmount = testAlertDefaultBTC
+	}
+
+	swaps, err := buildTestSwaps(ctx, client, poolLpPublicKey, btcAmount)
+	if err != nil {
+		log.LogError("Failed to build test swaps",
+			zap.String("route", route.name),
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.Error(err))
+		reply("Failed to get pool of the token, please try again later")
+		return
+	}
+
+	lines := []string{fmt.Sprintf("Test alerts for route {%s}:", route.name)}
+	for _, swap := range swaps {
+		result := "sent"
+		if err := route.send(ctx, client, swap); err != nil {
+			log.LogWarn("Failed to send test alert",
+				zap.String("route", route.name),
+				zap.String("swapID", swap.ID),
+				zap.Error(err))
+			result = err.Error()
+		}
+		lines = append(lines, fmt.Sprintf("• %s: %s", swap.GetSwapType(), result))
+	}
+	reply(strings.Join(lines, "\n"))
+
+	log.LogInfo("Test alerts sent",
+		zap.String("route", route.name),
+		zap.String("poolLpPublicKey", poolLpPublicKey),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
@@ -0,0 +1,166 @@
+package bots_monitor
+
+// /token {ticker} - token card: price, marketcap, volume, TVL, holders, pool age, our 24h swap activity,
+// price risk (volatility, max drawdown) and links to Luminex and explorer
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/features/price_history"
+	"spark-wallet/internal/features/token_info"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// tokenRiskWindow - price history window of risk figures in token card
+const tokenRiskWindow = 7 * 24 * time.Hour
+
+// handleTokenCommand /token {ticker}
+func handleTokenCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker for token card",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply(fmt.Sprintf("Ticker {%s} not found. Make sure the token has been traded before.", ticker))
+		return
+	}
+
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		log.LogError("Failed to get pool data for token card",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	tokenMeta, _, _ := poolData.TokenSide()
+
+	stats, err := price_history.Stats(poolLpPublicKey, tokenRiskWindow)
+	if err != nil {
+		log.LogWarn("Failed to calculate price risk for token card",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+	}
+
+	now := time.Now()
+	activity, err := token_info.LoadActivity(poolLpPublicKey, now)
+	if err != nil {
+		log.LogWarn("Failed to load swap activity for token card",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+	}
+
+	var text strings.Builder
+	title := fmt.Sprintf("<b>%s</b> {%s}", html.EscapeString(tokenMeta.Name), strings.ToUpper(ticker))
+	if tokenMeta.Verified {
+		title += " ✅"
+	}
+	text.WriteString(title + "\n")
+	text.WriteString("<blockquote>")
+	text.WriteString(fmt.Sprintf("Price: $%s (%+.1f%% 24h)\n", formatAlertPrice(tokenMeta.AggPriceUsd), tokenMeta.AggPriceChange24h))
+	if marketcap := formatMarketCap(tokenMeta.AggMarketcapUsd); marketcap != "" {
+		text.WriteString(fmt.Sprintf("Market cap: %s", marketcap))
+		if ath := formatMarketCap(tokenMeta.AthMarketcapUsd); ath != "" {
+			text.WriteString(fmt.Sprintf(" (ATH %s)", ath))
+		}
+		text.WriteString("\n")
+	}
+	text.WriteString(fmt.Sprintf("Volume 24h: %s\n", formatMarketCap(poolData.Extra.Volume24hUsd)))
+	// Pool TVL, aggregated TVL of token if pool has none yet
+	tvl := poolData.Extra.PoolTvlUsd
+	if tvl == 0 {
+		tvl = tokenMeta.AggTvlUsd
+	}
+	if tvl := formatMarketCap(tvl); tvl != "" {
+		text.WriteString(fmt.Sprintf("Pool TVL: %s\n", tvl))
+	}
+	text.WriteString(formatTokenHolders(ticker, tokenMeta))
+	if age, ok := token_info.PoolAge(poolData.CreatedAt, now); ok {
+		text.WriteString(fmt.Sprintf("Pool age: %s\n", token_info.FormatAge(age)))
+	}
+	text.WriteString("</blockquote>")
+
+	if activity != nil {
+		text.WriteString("<blockquote>")
+		text.WriteString(formatTokenActivity(activity))
+		text.WriteString("</blockquote>")
+	}
+	text.WriteString("<blockquote>")
+	text.WriteString(formatRiskStats(stats))
+	text.WriteString("</blockquote>")
+
+	text.WriteString(fmt.Sprintf("\n<a href=\"https://luminex.io/spark/trade/%s\">Trade on Luminex</a>", poolLpPublicKey))
+	if explorerURL := token_info.ExplorerURL(tokenMeta.TokenAddress); explorerURL != "" {
+		text.WriteString(fmt.Sprintf(" | <a href=\"%s\">Explorer</a>", explorerURL))
+	}
+
+	reply(text.String())
+
+	log.LogInfo("Token card sent",
+		zap.String("ticker", ticker),
+		zap.String("chatID", formatChatID(message.Chat.ID)),
+		zap.String("username", message.From.UserName))
+}
+
+// formatTokenHolders formats holders line: Luminex holder count, top 10 share and holders tracked by bot
+func formatTokenHolders(ticker string, tokenMeta luminex.LuminexTokenMetadata) string {
+	if tokenMeta.HolderCount == 0 {
+		return ""
+	}
+
+	line := fmt.Sprintf("Holders: %d", tokenMeta.HolderCount)
+	if tokenMeta.Top10HoldersPct > 0 {
+		line += fmt.Sprintf(" (top 10 hold %.1f%%)", tokenMeta.Top10HoldersPct)
+	}
+	if holders.IsTickerAllowed(ticker) {
+		if saved, err := holders.LoadSavedHolders(ticker); err == nil && len(saved.Holders) > 0 {
+			line += fmt.Sprintf(", %d tracked", len(saved.Holders))
+		}
+	}
+	return line + "\n"
+}
+
+// formatTokenActivity formats swaps of token seen by bot in last 24h
+func formatTokenActivity(activity *token_info.Activity) string {
+	if activity.Swaps() == 0 {
+		return "Our swaps 24h: none"
+	}
+	return fmt.Sprintf("Our swaps 24h: %d (%d buys, %d sells), %d wallets\nSwap volume 24h: %s btc (buys %s, sells %s)",
+		activity.Swaps(), activity.Buys, activity.Sells, activity.Wallets,
+		amount.FormatBTC(activity.VolumeBTC()), amount.FormatBTC(activity.BuyBTC), amount.FormatBTC(activity.SellBTC))
+}
+
+// formatRiskStats formats volatility and max drawdown lines (placeholder if price history is too short)
+func formatRiskStats(stats *price_history.RiskStats) string {
+	if stats == nil {
+		return "Volatility / drawdown: not enough price history yet"
+	}
+
+	days := stats.Period.Hours() / 24
+	currency := "usd"
+	if stats.InBTC {
+		currency = "btc"
+	}
+	return fmt.Sprintf("Volatility: %.1f%% per day\nMax drawdown: -%.1f%%\n<i>%.1fd of %s price, %d samples</i>",
+		stats.VolatilityPct, stats.MaxDrawdownPct, days, currency, stats.Samples)
+}
@@ -0,0 +1,47 @@
+package bots_monitor
+
+// Token identifier discovery: id_tokens.json of holders module is refreshed from Luminex tokens-with-pools,
+// manual entries of the file are kept (see holders.DiscoverTokenIdentifiers)
+
+import (
+	"context"
+	"time"
+
+	"spark-wallet/internal/features/holders"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
+
+	"go.uber.org/zap"
+)
+
+// RunTokenDiscoveryMonitor refreshes token identifiers on start and every interval
+func RunTokenDiscoveryMonitor(ctx context.Context, interval time.Duration) {
+	log.LogInfo("Starting Token Discovery Monitor...",
+		zap.String("file", holders.TokenIDsFile()),
+		zap.Duration("interval", interval))
+
+	scheduler.Default.Run(ctx, scheduler.Job{
+		Name:       "token_discovery",
+		Schedule:   scheduler.Interval(interval),
+		RunOnStart: true,
+		Run: func(ctx context.Context, now time.Time) {
+			discoverTokenIdentifiers(ctx, now)
+		},
+	})
+	log.LogInfo("Token Discovery Monitor stopped")
+}
+
+func discoverTokenIdentifiers(ctx context.Context, now time.Time) {
+	result, err := holders.DiscoverTokenIdentifiers(ctx, now)
+	if err != nil {
+		log.LogError("Failed to discover token identifiers", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+	log.LogInfo("Token identifiers refreshed",
+		zap.Int("discovered", result.Discovered),
+		zap.Int("manual", result.Manual),
+		zap.Int("added", result.Added),
+		zap.Int("removed", result.Removed))
+}
@@ -0,0 +1,75 @@
+package bots_monitor
+
+// /distribution {ticker} {24h|7d} - swaps of token by BTC size (whales vs retail) from archived swaps
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/tg_charts"
+	"spark-wallet/internal/features/trade_sizes"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// handleDistributionCommand /distribution {ticker} {period}
+func handleDistributionCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ticker string, period trade_sizes.Period) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	ticker = strings.ToUpper(ticker)
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		log.LogWarn("Failed to find token by ticker for distribution",
+			zap.String("ticker", ticker),
+			zap.Error(err))
+		reply(fmt.Sprintf("Ticker {%s} not found. Make sure the token has been traded before.", ticker))
+		return
+	}
+
+	report, err := trade_sizes.LoadReport(poolLpPublicKey, period, time.Now())
+	if err != nil {
+		log.LogError("Failed to build trade sizes distribution", zap.String("ticker", ticker), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+	report.Ticker = ticker
+	if report.Swaps() == 0 {
+		reply(fmt.Sprintf("No swaps of {%s} in the last %s.", ticker, period.Name))
+		return
+	}
+
+	caption := trade_sizes.Format(report, i18n.ChatLang(message.Chat.ID))
+	chartPath, err := tg_charts.GenerateTradeSizesChart(report)
+	if err != nil {
+		log.LogWarn("Failed to generate trade sizes chart", zap.String("ticker", ticker), zap.Error(err))
+		msg := tgbotapi.NewMessage(message.Chat.ID, caption)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	photo := tgbotapi.NewPhoto(message.Chat.ID, tgbotapi.FilePath(chartPath))
+	photo.Caption = caption
+	photo.ParseMode = tgbotapi.ModeHTML
+	photo.ReplyToMessageID = message.MessageID
+	if _, err := bot.Send(photo); err != nil {
+		log.LogError("Failed to send trade sizes distribution", zap.String("ticker", ticker), zap.Error(err))
+		return
+	}
+
+	log.LogInfo("Trade sizes distribution sent",
+		zap.String("ticker", ticker),
+		zap.String("period", period.Name),
+		zap.Int("swaps", report.Swaps()),
+		zap.String("chatID", formatChatID(message.Chat.ID)))
+}
@@ -0,0 +1,64 @@
+package bots_monitor
+
+// Background sync of wallet usernames (Luminex profiles) into local table
+// Wallets are recorded by Big Sales monitor on every new swap, wallets not seen for
+// storage.UsernamesRetention are pruned after sync
+
+import (
+	"context"
+	"spark-wallet/internal/clients_api/luminex"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunUsernameSyncMonitor loads local username table and syncs it every interval
+func RunUsernameSyncMonitor(ctx context.Context, interval time.Duration) {
+	log.LogInfo("Starting Username Sync Monitor...", zap.Duration("interval", interval))
+
+	loaded, err := luminex.LoadUsernameTable()
+	if err != nil {
+		log.LogWarn("Failed to load local username table", zap.Error(err))
+	} else {
+		log.LogInfo("Loaded local username table", zap.Int("count", loaded))
+	}
+
+	syncUsernames(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Username Sync Monitor stopped")
+			return
+		case <-ticker.C:
+			syncUsernames(ctx)
+		}
+	}
+}
+
+func syncUsernames(ctx context.Context) {
+	synced, err := luminex.SyncUsernames(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		log.LogError("Failed to sync wallet usernames", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+	ReportMonitorSuccess(ctx)
+	if synced > 0 {
+		log.LogInfo("Synced wallet usernames", zap.Int("count", synced))
+	}
+
+	if pruned, err := storage.PruneUsernames(time.Now(), storage.UsernamesRetention); err != nil {
+		log.LogWarn("Failed to prune wallet usernames", zap.Error(err))
+	} else if pruned > 0 {
+		log.LogInfo("Pruned wallet usernames not seen recently", zap.Int("count", pruned))
+	}
+}
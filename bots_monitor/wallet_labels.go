@@ -0,0 +1,200 @@
+package bots_monitor
+
+// /label and /unlabel: human labels of wallets shown in swap alerts and holders reports
+// Wallet is given by public key, spark address or suffix of wallet seen in swaps ("e0f" from alert)
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/wallet_labels"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// fullWalletMinLength - shorter wallet argument is treated as suffix
+	fullWalletMinLength = 40
+	// maxSuffixMatchesShown - wallets listed when suffix is ambiguous
+	maxSuffixMatchesShown = 5
+)
+
+// handleLabelCommand /label {suffix|pubkey} {name} - label wallet, /label - labeled wallets
+func handleLabelCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+	usage := fmt.Sprintf("Usage: /label {wallet suffix, pubkey or spark address} {name}\n\n"+
+		"Example: /label e0f team wallet\n"+
+		"Label (up to %d characters) is shown in alerts and holders reports instead of \"wallet\"", wallet_labels.MaxNameLength)
+
+	wallet, name, _ := strings.Cut(strings.TrimSpace(args), " ")
+	if wallet == "" {
+		labels, err := wallet_labels.All()
+		if err != nil {
+			log.LogError("Failed to load wallet labels", zap.Error(err))
+			reply("An error occurred, please try again later")
+			return
+		}
+		if len(labels) == 0 {
+			reply(html.EscapeString(usage) + "\n\nNo wallets are labeled")
+			return
+		}
+		var text strings.Builder
+		for _, label := range labels {
+			text.WriteString(fmt.Sprintf("<code>%s</code> - %s\n",
+				html.EscapeString(formatWatchedAddress(label.PublicKey)), html.EscapeString(label.Name)))
+		}
+		reply("<b>Wallet labels</b>\n<blockquote>" + text.String() + "</blockquote>")
+		return
+	}
+
+	name, err := wallet_labels.ValidName(name)
+	if err != nil {
+		reply(html.EscapeString(usage))
+		return
+	}
+
+	publicKey, problem := resolveLabelWallet(wallet)
+	if problem != "" {
+		reply(problem)
+		return
+	}
+
+	added, err := wallet_labels.Set(wallet_labels.Label{
+		PublicKey: publicKey,
+		Name:      name,
+		AddedBy:   message.From.UserName,
+	})
+	if err != nil {
+		log.LogError("Failed to label wallet", zap.String("wallet", wallet), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+
+	address := html.EscapeString(formatWatchedAddress(publicKey))
+	if added {
+		reply(fmt.Sprintf("Wallet <code>%s</code> labeled: %s", address, html.EscapeString(name)))
+	} else {
+		reply(fmt.Sprintf("Label of wallet <code>%s</code> updated: %s", address, html.EscapeString(name)))
+	}
+
+	log.LogInfo("Wallet labeled via command",
+		zap.String("publicKey", publicKey),
+		zap.String("label", name),
+		zap.String("username", message.From.UserName))
+}
+
+// handleUnlabelCommand /unlabel {suffix|pubkey} - remove label of wallet
+func handleUnlabelCommand(bot *tgbotapi.BotAPI, message *tgbotapi.Message, wallet string) {
+	reply := func(text string) {
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyToMessageID = message.MessageID
+		bot.Send(msg)
+	}
+
+	if wallet == "" {
+		reply("Usage: /unlabel {wallet suffix, pubkey or spark address}")
+		return
+	}
+
+	publicKey, problem := resolveLabelWallet(wallet)
+	if problem != "" {
+		reply(problem)
+		return
+	}
+
+	removed, err := wallet_labels.Remove(publicKey)
+	if err != nil {
+		log.LogError("Failed to remove wallet label", zap.String("wallet", wallet), zap.Error(err))
+		reply("An error occurred, please try again later")
+		return
+	}
+	address := html.EscapeString(formatWatchedAddress(publicKey))
+	if !removed {
+		reply(fmt.Sprintf("Wallet <code>%s</code> has no label", address))
+		return
+	}
+	reply(fmt.Sprintf("Label of wallet <code>%s</code> removed", address))
+
+	log.LogInfo("Wallet label removed via command",
+		zap.String("publicKey", publicKey),
+		zap.String("username", message.From.UserName))
+}
+
+// resolveLabelWallet returns public key of wallet argument, or reply text (HTML) if it can't be resolved
+// Full public key or spark address is resolved by Luminex, suffix is matched against labeled wallets
+// and wallets seen in swaps and must match exactly one of them
+func resolveLabelWallet(wallet string) (string, string) {
+	wallet = strings.TrimSpace(wallet)
+
+	if len(wallet) >= fullWalletMinLength {
+		if balanceResp, err := luminex.GetWalletTokensBalance(wallet); err == nil && balanceResp.PublicKey != "" {
+			return strings.ToLower(balanceResp.PublicKey), ""
+		} else if err != nil {
+			log.LogWarn("Failed to resolve wallet for label",
+				zap.String("wallet", wallet),
+				zap.Error(err))
+		}
+		if isHexPublicKey(wallet) {
+			return strings.ToLower(wallet), ""
+		}
+		return "", "Wallet not found. Use a public key, spark address or wallet suffix."
+	}
+
+	var candidates []string
+	if table, err := storage.LoadUsernames(); err != nil {
+		log.LogWarn("Failed to load seen wallets for label", zap.Error(err))
+	} else {
+		candidates = make([]string, 0, len(table.Wallets))
+		for publicKey := range table.Wallets {
+			candidates = append(candidates, publicKey)
+		}
+	}
+
+	matches, err := wallet_labels.MatchSuffix(wallet, candidates)
+	if err != nil {
+		return "", html.EscapeString(err.Error())
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Sprintf("No known wallet ends with <code>%s</code>. Use a public key or spark address.", html.EscapeString(wallet))
+	case 1:
+		return matches[0], ""
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("%d wallets end with <code>%s</code>, use a longer suffix or public key:\n",
+		len(matches), html.EscapeString(wallet)))
+	for i, match := range matches {
+		if i == maxSuffixMatchesShown {
+			text.WriteString(fmt.Sprintf("…and %d more\n", len(matches)-maxSuffixMatchesShown))
+			break
+		}
+		text.WriteString(fmt.Sprintf("<code>%s</code>\n", html.EscapeString(match)))
+	}
+	return "", text.String()
+}
+
+// isHexPublicKey checks if value is compressed public key in hex (66 characters)
+func isHexPublicKey(value string) bool {
+	if len(value) != 66 {
+		return false
+	}
+	for _, c := range strings.ToLower(value) {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package bots_monitor
+
+// Wallet watchlist monitor (/watch command)
+// Notifies chat about every new swap of watched wallets regardless of BTC amount
+
+import (
+	"context"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/reach"
+	"spark-wallet/internal/features/swap_templates"
+	"spark-wallet/internal/infra/events"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// RunWatchlistMonitor polls latest swaps every interval and notifies about swaps of watched wallets
+// bots - bots running command handlers, notification is sent by the bot that received /watch
+func RunWatchlistMonitor(ctx context.Context, bots []*tgbotapi.BotAPI, client *flashnet.Client, interval time.Duration) {
+	botsByID := make(map[int64]*tgbotapi.BotAPI)
+	var defaultBot *tgbotapi.BotAPI
+	for _, bot := range bots {
+		if bot == nil {
+			continue
+		}
+		if defaultBot == nil {
+			defaultBot = bot
+		}
+		botsByID[bot.Self.ID] = bot
+	}
+	if defaultBot == nil {
+		log.LogWarn("No bot for watchlist notifications, monitor not started")
+		return
+	}
+
+	log.LogInfo("Starting Watchlist Monitor...",
+		zap.String("file", storage.WatchlistFile()),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// First poll is baseline - swaps made before start are not reported
+	var lastSwaps []flashnet.Swap
+	baseline := true
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.LogInfo("Watchlist Monitor stopped")
+			return
+		case <-ticker.C:
+			watchlist, err := storage.LoadWatchlist()
+			if err != nil {
+				log.LogError("Failed to load watchlist", zap.Error(err))
+				ReportMonitorError(ctx, err)
+				continue
+			}
+			if len(watchlist.Wallets) == 0 {
+				// Nothing to watch - next poll after /watch starts from new baseline
+				lastSwaps = nil
+				baseline = true
+				ReportMonitorSuccess(ctx)
+				continue
+			}
+
+			limit := 100
+			swapsResp, err := client.GetSwaps(ctx, flashnet.GetSwapsOptions{
+				Limit: &limit,
+			})
+			if err != nil {
+				log.LogError("Failed to get swaps for watchlist", zap.Error(err))
+				ReportMonitorError(ctx, err)
+				continue
+			}
+			ReportMonitorSuccess(ctx)
+
+			newSwaps := findNewSwapsBig(lastSwaps, swapsResp.Swaps)
+			lastSwaps = swapsResp.Swaps
+			if baseline {
+				baseline = false
+				continue
+			}
+
+			notifyWatchedSwaps(client, watchlist.Wallets, newSwaps, botsByID, defaultBot)
+		}
+	}
+}
+
+func notifyWatchedSwaps(client *flashnet.Client, wallets []storage.WatchedWallet, swaps []flashnet.Swap, botsByID map[int64]*tgbotapi.BotAPI, defaultBot *tgbotapi.BotAPI) {
+	watchers := make(map[string][]storage.WatchedWallet)
+	for _, wallet := range wallets {
+		watchers[wallet.PublicKey] = append(watchers[wallet.PublicKey], wallet)
+	}
+
+	for _, swap := range orderSwapsForDelivery(swaps) {
+		entries, watched := watchers[swap.SwapperPublicKey]
+		if !watched {
+			continue
+		}
+
+		format := memoizeSwapAlert(client, swap)
+		for _, entry := range entries {
+			bot, exists := botsByID[entry.BotID]
+			if !exists {
+				bot = defaultBot
+			}
+			lang := i18n.ChatLang(entry.ChatID)
+			alert := format(lang)
+			message := i18n.T(lang, "swap.watched") + "\n" + alert.Text
+			delivery := events.Delivery{
+				SwapID:          swap.ID,
+				PoolLpPublicKey: swap.PoolLpPublicKey,
+				ChatID:          entry.ChatID,
+				Route:           reach.KindWatchlist,
+				Layout:          swap_templates.Layout(swap.PoolLpPublicKey),
+				Format:          events.DeliveryFormatMessage,
+			}
+			if holdAlert(bot, entry.ChatID, reach.KindWatchlist, swap.PoolLpPublicKey, "", message) {
+				delivery.Status = events.DeliveryHeld
+				recordSwapDeliveries([]events.Delivery{delivery})
+				continue
+			}
+
+			msg := tgbotapi.NewMessage(entry.ChatID, message)
+			msg.ParseMode = tgbotapi.ModeHTML
+			msg.DisableWebPagePreview = true
+			msg.ReplyMarkup = alert.Keyboard
+			sent, err := bot.Send(msg)
+			if err != nil {
+				log.LogError("Failed to send watched wallet swap",
+					zap.String("swapID", swap.ID),
+					zap.Int64("chatID", entry.ChatID),
+					zap.Error(err))
+				delivery.Status, delivery.Error = events.DeliveryFailed, err.Error()
+				recordSwapDeliveries([]events.Delivery{delivery})
+				continue
+			}
+			delivery.Status, delivery.MessageID = events.DeliverySent, sent.MessageID
+			recordSwapDeliveries([]events.Delivery{delivery})
+			recordReach(swap, entry.ChatID, reach.KindWatchlist, "")
+
+			log.LogInfo("Sent watched wallet swap",
+				zap.String("swapID", swap.ID),
+				zap.String("wallet", swap.SwapperPublicKey),
+				zap.Int64("chatID", entry.ChatID))
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package bots_monitor
+
+// Inbound webhook server for external trading signals (telegram.webhooks, app.webhook_addr)
+// POST /webhook/{source} - signal of source, signed with its secret (see internal/features/signals)
+// Signal is sent to chats of source, so bot works as alert hub for TradingView alerts and custom scripts
+// Accepted signatures are remembered while their timestamp is valid, the same request is not sent twice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"spark-wallet/internal/features/signals"
+	"spark-wallet/internal/infra/events"
+	log "spark-wallet/internal/infra/log"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// webhookMaxBodySize - max body of signal request
+const webhookMaxBodySize = 16 * 1024
+
+// WebhookSource - sender of signals with its secret and chats
+type WebhookSource struct {
+	Name    string
+	Secret  string
+	Bot     *tgbotapi.BotAPI
+	ChatIDs []string
+}
+
+type webhookServer struct {
+	sources map[string]WebhookSource
+	replay  *signals.ReplayGuard
+}
+
+// RunWebhookServer serves signal webhooks on addr until ctx is cancelled
+func RunWebhookServer(ctx context.Context, addr string, sources []WebhookSource) {
+	if addr == "" {
+		log.LogInfo("Webhook address is empty, webhook server disabled")
+		return
+	}
+	if len(sources) == 0 {
+		log.LogWarn("No webhook sources configured, webhook server not started")
+		return
+	}
+
+	server := &webhookServer{
+		sources: make(map[string]WebhookSource, len(sources)),
+		replay:  signals.NewReplayGuard(),
+	}
+	for _, source := range sources {
+		server.sources[source.Name] = source
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webhook/{source}", server.handleSignal)
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.LogWarn("Failed to shut down webhook server", zap.Error(err))
+		}
+	}()
+
+	log.LogInfo("Starting webhook server...", zap.String("addr", addr), zap.Int("sources", len(sources)))
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.LogError("Webhook server stopped", zap.Error(err))
+		return
+	}
+	log.LogInfo("Webhook server stopped")
+}
+
+func (s *webhookServer) handleSignal(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("source")
+	source, exists := s.sources[name]
+	if !exists {
+		writeAdminError(w, http.StatusNotFound, "unknown source")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, webhookMaxBodySize))
+	if err != nil {
+		writeAdminError(w, http.StatusRequestEntityTooLarge, "body too large")
+		return
+	}
+
+	now := time.Now()
+	signature := r.Header.Get(signals.SignatureHeader)
+	if err := signals.Verify(source.Secret, r.Header.Get(signals.TimestampHeader), signature, body, now); err != nil {
+		log.LogWarn("Rejected webhook signal",
+			zap.String("source", name),
+			zap.String("remote", r.RemoteAddr),
+			zap.Error(err))
+		writeAdminError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+	if err := s.replay.Accept(signature, now); err != nil {
+		writeAdminError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	signal, err := signals.Parse(body)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	message := signals.FormatMessage(source.Name, signal)
+	sent := 0
+	for _, chatID := range source.ChatIDs {
+		chat := parseChatIDBig(chatID)
+		// Signal held for quiet hours summary counts as delivered
+		if holdAlert(source.Bot, chat, "signal", "", signal.Ticker, message) {
+			sent++
+			continue
+		}
+		msg := tgbotapi.NewMessage(chat, message)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		if _, err := sendToChat(r.Context(), source.Bot, chat, msg); err != nil {
+			log.LogError("Failed to send webhook signal",
+				zap.String("source", name),
+				zap.String("chatID", chatID),
+				zap.Error(err))
+			continue
+		}
+		sent++
+		recordAlertEvent(events.Alert{
+			Kind:   "signal",
+			ChatID: chat,
+			Label:  source.Name,
+			Ticker: signal.Ticker,
+		}, message)
+	}
+	if sent > 0 {
+		recordDashboardAlert("signal", message, signal.URL)
+	}
+
+	log.LogInfo("Webhook signal received",
+		zap.String("source", name),
+		zap.String("ticker", signal.Ticker),
+		zap.String("side", signal.Side),
+		zap.Int("sent", sent),
+		zap.Int("chats", len(source.ChatIDs)))
+
+	if sent == 0 {
+		writeAdminError(w, http.StatusBadGateway, fmt.Sprintf("signal not sent to any of %d chats", len(source.ChatIDs)))
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, map[string]any{"sent": sent, "chats": len(source.ChatIDs)})
+}
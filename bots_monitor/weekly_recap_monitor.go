@@ -0,0 +1,172 @@
+package bots_monitor
+
+// Weekly recap of tracked tokens: price change, marketcap, 7d volume, volatility and max drawdown
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/features/digest"
+	"spark-wallet/internal/features/price_history"
+	storage "spark-wallet/internal/infra/fs"
+	log "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/scheduler"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// weeklyRecapWeekday - day of weekly recap (MSK)
+	weeklyRecapWeekday = time.Monday
+	// weeklyRecapDays - days covered by recap
+	weeklyRecapDays = 7
+)
+
+// weeklyRecapToken - recap line of one token
+type weeklyRecapToken struct {
+	PoolLpPublicKey string
+	Ticker          string
+	Last            *price_history.PriceSample
+	Risk            *price_history.RiskStats
+	VolumeBTC       float64 // buys and sells archived for last 7 days
+}
+
+// RunWeeklyRecapMonitor posts recap of last 7 UTC days every Monday at sendTime (MSK)
+// bot - Telegram for
+// chatID - ID for recap
+// sendTime - time in "HH:MM"
+func RunWeeklyRecapMonitor(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, sendTime string) {
+	if bot == nil || chatID == "" {
+		log.LogWarn("Bot or chat ID is empty, weekly recap monitor not started")
+		return
+	}
+
+	schedule, err := scheduler.ParseDaily(sendTime, statsLocation, weeklyRecapWeekday)
+	if err != nil {
+		log.LogWarn("Invalid weekly recap send time format, using default 10:00", zap.String("sendTime", sendTime), zap.Error(err))
+		schedule = scheduler.Daily{Hour: 10, Minute: 0, Location: statsLocation, Weekdays: []time.Weekday{weeklyRecapWeekday}}
+	}
+
+	log.LogInfo("Starting Weekly Recap Monitor...",
+		zap.String("chatID", chatID),
+		zap.String("weekday", weeklyRecapWeekday.String()),
+		zap.String("sendTime", fmt.Sprintf("%02d:%02d", schedule.Hour, schedule.Minute)))
+
+	scheduler.Default.Run(ctx, scheduler.Job{
+		Name:     "weekly_recap",
+		Schedule: schedule,
+		Run: func(ctx context.Context, now time.Time) {
+			sendWeeklyRecap(ctx, bot, chatID, now.UTC())
+		},
+	})
+	log.LogInfo("Weekly Recap Monitor stopped")
+}
+
+func sendWeeklyRecap(ctx context.Context, bot *tgbotapi.BotAPI, chatID string, now time.Time) {
+	pools, err := storage.LoadFilteredTokens()
+	if err != nil {
+		log.LogError("Failed to load filtered tokens for weekly recap", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	history, err := price_history.LoadPriceHistory()
+	if err != nil {
+		log.LogError("Failed to load price history for weekly recap", zap.Error(err))
+		ReportMonitorError(ctx, err)
+		return
+	}
+
+	// 7d volume from swap archive (last complete UTC days)
+	volumes := make(map[string]float64)
+	for i := 1; i <= weeklyRecapDays; i++ {
+		date := now.AddDate(0, 0, -i).Format("2006-01-02")
+		dayDigest, err := digest.BuildDailyDigest(date)
+		if err != nil {
+			log.LogWarn("Failed to load archived swaps for weekly recap", zap.String("date", date), zap.Error(err))
+			continue
+		}
+		for _, token := range dayDigest.Tokens {
+			volumes[token.PoolLpPublicKey] += token.VolumeBTC()
+		}
+	}
+	ReportMonitorSuccess(ctx)
+
+	var tokens []weeklyRecapToken
+	for _, poolLpPublicKey := range pools {
+		token := weeklyRecapToken{
+			PoolLpPublicKey: poolLpPublicKey,
+			VolumeBTC:       volumes[poolLpPublicKey],
+		}
+		if prices, exists := history.Pools[poolLpPublicKey]; exists {
+			token.Ticker = prices.Ticker
+			samples, err := price_history.SamplesSince(poolLpPublicKey, now.AddDate(0, 0, -weeklyRecapDays))
+			if err == nil && len(samples) > 0 {
+				token.Last = &samples[len(samples)-1]
+				token.Risk = price_history.ComputeRiskStats(samples)
+			}
+		}
+		if token.Last == nil && token.VolumeBTC == 0 {
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+
+	if len(tokens) == 0 {
+		log.LogInfo("No data for weekly recap")
+		return
+	}
+
+	sort.SliceStable(tokens, func(i, j int) bool { return tokens[i].VolumeBTC > tokens[j].VolumeBTC })
+
+	msg := tgbotapi.NewMessage(parseChatIDBig(chatID), formatWeeklyRecapMessage(tokens, now))
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.DisableWebPagePreview = true
+	if _, err := bot.Send(msg); err != nil {
+		log.LogError("Failed to send weekly recap", zap.Error(err))
+		return
+	}
+
+	log.LogInfo("Weekly recap sent",
+		zap.String("chatID", chatID),
+		zap.Int("tokens", len(tokens)))
+}
+
+func formatWeeklyRecapMessage(tokens []weeklyRecapToken, now time.Time) string {
+	from := now.AddDate(0, 0, -weeklyRecapDays)
+	to := now.AddDate(0, 0, -1)
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("🗓 <b>Weekly recap</b> %s - %s (UTC)\n", from.Format("02 Jan"), to.Format("02 Jan")))
+
+	for _, token := range tokens {
+		name := FormatTokenAddress(token.PoolLpPublicKey)
+		if token.Ticker != "" {
+			name = "{" + strings.ToUpper(token.Ticker) + "}"
+		}
+		message.WriteString(fmt.Sprintf("\n<a href=\"https://luminex.io/spark/trade/%s\"><b>%s</b></a>\n",
+			token.PoolLpPublicKey, html.EscapeString(name)))
+		message.WriteString("<blockquote>")
+		if token.Last != nil {
+			message.WriteString(fmt.Sprintf("Price: $%s", formatAlertPrice(token.Last.PriceUsd)))
+			if token.Risk != nil {
+				message.WriteString(fmt.Sprintf(" (%+.1f%% 7d)", token.Risk.ChangePercent))
+			}
+			message.WriteString("\n")
+			if token.Last.MarketcapUsd > 0 {
+				message.WriteString(fmt.Sprintf("Market cap: %s\n", formatMarketCap(token.Last.MarketcapUsd)))
+			}
+		}
+		message.WriteString(fmt.Sprintf("Volume 7d: %s btc\n", amount.FormatBTC(token.VolumeBTC)))
+		message.WriteString(formatRiskStats(token.Risk))
+		message.WriteString("</blockquote>")
+	}
+
+	return message.String()
+}
@@ -0,0 +1,58 @@
+package bots_monitor
+
+// Whale badge for swap alerts ("🐋 Top-15 holder sold")
+// Badge is computed once per swap: saved_holders.json is updated after first alert is sent,
+// so other chats of the same swap would see holding after the swap
+
+import (
+	"fmt"
+	"sync"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/holders"
+	log "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// maxWhaleBadgeCache - swaps kept in badge cache (cache is reset when exceeded)
+const maxWhaleBadgeCache = 1000
+
+var (
+	whaleBadgeCache      = make(map[string]string) // swapID -> badge ("" - not whale)
+	whaleBadgeCacheMutex sync.Mutex
+)
+
+// whaleBadgeForSwap returns badge line for swap of whale wallet (empty if wallet is not whale)
+func whaleBadgeForSwap(swap flashnet.Swap, ticker string) string {
+	whaleBadgeCacheMutex.Lock()
+	defer whaleBadgeCacheMutex.Unlock()
+
+	if badge, exists := whaleBadgeCache[swap.ID]; exists {
+		return badge
+	}
+
+	badge := ""
+	whale, err := holders.FindWhale(ticker, swap.PoolLpPublicKey, swap.SwapperPublicKey)
+	if err != nil {
+		log.LogDebug("Failed to check whale holder",
+			zap.String("ticker", ticker),
+			zap.String("swapperPublicKey", swap.SwapperPublicKey),
+			zap.Error(err))
+	} else if whale != nil {
+		action := "traded"
+		switch swap.GetSwapType() {
+		case flashnet.SwapTypeBuy:
+			action = "bought"
+		case flashnet.SwapTypeSell:
+			action = "sold"
+		}
+		badge = fmt.Sprintf("🐋 Top-%d holder %s (%.2f%% of supply)\n", whale.Rank, action, whale.SupplyPercent)
+	}
+
+	if len(whaleBadgeCache) >= maxWhaleBadgeCache {
+		whaleBadgeCache = make(map[string]string)
+	}
+	whaleBadgeCache[swap.ID] = badge
+	return badge
+}
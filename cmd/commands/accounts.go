@@ -0,0 +1,97 @@
+package commands
+
+// Flashnet identities (flashnet.accounts)
+// Each extra account has its own client, signer and token files in data_in/{publicKey}/
+// Monitors listed in flashnet.monitor_accounts use client of that account, others use default identity
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/infra/config"
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// flashnetAccounts - clients of configured identities
+type flashnetAccounts struct {
+	defaultClient *flashnet.Client
+	clients       map[string]*flashnet.Client // account name -> client
+	monitors      map[string]string           // monitor name -> account name
+}
+
+// clientFor returns client of identity assigned to monitor (default client if not assigned)
+func (a *flashnetAccounts) clientFor(monitor string) *flashnet.Client {
+	if account, exists := a.monitors[monitor]; exists {
+		if client, exists := a.clients[account]; exists {
+			return client
+		}
+	}
+	return a.defaultClient
+}
+
+// setupAccounts authenticates extra accounts and starts renewal of their tokens
+// Account that fails initial authentication keeps retrying in background (its monitors run unauthenticated meanwhile)
+func setupAccounts(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config, dataDir string, defaultClient *flashnet.Client) (*flashnetAccounts, error) {
+	accounts := &flashnetAccounts{
+		defaultClient: defaultClient,
+		clients:       make(map[string]*flashnet.Client),
+		monitors:      cfg.Flashnet.MonitorAccounts,
+	}
+
+	renewMargin := time.Duration(cfg.Flashnet.TokenRenewMargin) * time.Minute
+	for _, account := range cfg.Flashnet.Accounts {
+		client, err := newAMMClient(cfg.Flashnet.Network, cfg.Flashnet.APIURL)
+		if err != nil {
+			return nil, err
+		}
+		configureRetry(client, cfg)
+		configureSigner(client, account.PrivateKey, account.KeystorePath, account.PublicKey)
+
+		publicKey := account.PublicKey
+		if publicKey == "" && client.GetSigner() != nil {
+			publicKey = client.GetSigner().PublicKey()
+		}
+		if publicKey == "" {
+			return nil, fmt.Errorf("flashnet account %q: public key not found", account.Name)
+		}
+
+		accountDir := flashnet.AccountDataDir(dataDir, publicKey)
+		if err := os.MkdirAll(accountDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create data dir of account %q: %w", account.Name, err)
+		}
+
+		authManager := flashnet.NewAuthManager(client, accountDir, publicKey, renewMargin)
+		if err := authManager.EnsureValid(ctx); err != nil {
+			logging.LogError("Failed to authenticate Flashnet account",
+				zap.String("account", account.Name),
+				zap.Error(err))
+		} else {
+			logging.LogSuccess("Flashnet account authenticated",
+				zap.String("account", account.Name),
+				zap.String("publicKey", publicKey),
+				zap.String("dataDir", accountDir))
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			authManager.Run(ctx)
+		}()
+
+		accounts.clients[account.Name] = client
+	}
+
+	for monitor, account := range accounts.monitors {
+		logging.LogInfo("Monitor uses Flashnet account",
+			zap.String("monitor", monitor),
+			zap.String("account", account))
+	}
+
+	return accounts, nil
+}
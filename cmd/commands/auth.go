@@ -9,11 +9,10 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"spark-wallet/internal/clients_api/flashnet"
-	executil "spark-wallet/internal/infra/exec"
-	storage "spark-wallet/internal/infra/fs"
 	"spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -30,10 +29,17 @@ var authCmd = &cobra.Command{
 var authChallengeCmd = &cobra.Command{
 	Use:   "challenge",
 	Short: "Get challenge from Flashnet API",
-	Long:  `Request a challenge from Flashnet API and save it to data_in/challenge.json`,
+	Long:  `Request a challenge from Flashnet API and save it to challenge.json in data directory (data_in by default)`,
 	RunE:  runAuthChallenge,
 }
 
+var authSignCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Sign challenge with wallet private key",
+	Long:  `Sign data_in/challenge.json with the private key from PRIVATE_KEY or FLASHNET_KEYSTORE and save data_in/signature.json`,
+	RunE:  runAuthSign,
+}
+
 var authVerifyCmd = &cobra.Command{
 	Use:   "verify",
 	Short: "Verify signature and get JWT token",
@@ -50,6 +56,7 @@ var authFullCmd = &cobra.Command{
 
 func init() {
 	authCmd.AddCommand(authChallengeCmd)
+	authCmd.AddCommand(authSignCmd)
 	authCmd.AddCommand(authVerifyCmd)
 	authCmd.AddCommand(authFullCmd)
 }
@@ -72,21 +79,80 @@ func runAuthChallenge(cmd *cobra.Command, args []string) error {
 	log.LogInfo("Public Key", zap.String("publicKey", publicKey))
 	log.LogInfo("Network", zap.String("network", network))
 
-	client := flashnet.NewAMMClient(network)
-	dataDir := "data_in"
+	client, err := newAMMClient(network, os.Getenv("FLASHNET_API_URL"))
+	if err != nil {
+		return err
+	}
+	dataDir := paths.DataDir()
 	ctx := context.Background()
 
-	_, err := client.GetChallengeAndSave(ctx, dataDir, publicKey)
+	_, err = client.GetChallengeAndSave(ctx, dataDir, publicKey)
 	if err != nil {
 		log.LogError("Failed to get challenge", zap.Error(err))
 		return fmt.Errorf("failed to get challenge: %w", err)
 	}
 
-	log.LogInfo("Challenge saved to data_in/challenge.json")
-	log.LogInfo("Next step: sign the challenge using 'make sign' or 'flashnet-api auth verify'")
+	log.LogInfo("Challenge saved", zap.String("dir", dataDir))
+	log.LogInfo("Next step: sign the challenge using 'make sign' or 'flashnet-api auth sign'")
+	return nil
+}
+
+func runAuthSign(cmd *cobra.Command, args []string) error {
+	godotenv.Load(".env")
+
+	signer, err := flashnet.LoadSigner(os.Getenv("PRIVATE_KEY"), os.Getenv("FLASHNET_KEYSTORE"))
+	if err != nil {
+		log.LogError("Failed to load signing key", zap.Error(err))
+		return fmt.Errorf("failed to load signing key: %w", err)
+	}
+
+	client, err := newAMMClient(os.Getenv("NETWORK"), os.Getenv("FLASHNET_API_URL"))
+	if err != nil {
+		return err
+	}
+	client.SetSigner(signer)
+
+	if _, err := client.SignChallengeAndSave(paths.DataDir()); err != nil {
+		log.LogError("Failed to sign challenge", zap.Error(err))
+		return fmt.Errorf("failed to sign challenge: %w", err)
+	}
+
+	log.LogSuccess("Challenge signed successfully")
 	return nil
 }
 
+// newAMMClient creates Flashnet client of network, apiURL (FLASHNET_API_URL) overrides its default endpoint
+// Networks without public API (regtest, dev) require apiURL
+func newAMMClient(network string, apiURL string) (*flashnet.Client, error) {
+	client := flashnet.NewAMMClient(network)
+	if apiURL != "" {
+		client.SetBaseURL(apiURL)
+		return client, nil
+	}
+	if _, ok := flashnet.NetworkAPI(network); !ok {
+		return nil, fmt.Errorf("network %s has no default API URL, set FLASHNET_API_URL", network)
+	}
+	return client, nil
+}
+
+// configureSigner sets challenge signer on client if private key is configured
+// Without signer the token can't be refreshed automatically
+func configureSigner(client *flashnet.Client, privateKey string, keystorePath string, publicKey string) {
+	signer, err := flashnet.LoadSigner(privateKey, keystorePath)
+	if err != nil {
+		log.LogWarn("Challenge signer not configured, automatic signing disabled", zap.Error(err))
+		return
+	}
+
+	if publicKey != "" && !strings.EqualFold(publicKey, signer.PublicKey()) {
+		log.LogWarn("PUBLIC_KEY does not match public key derived from private key",
+			zap.String("provided", publicKey),
+			zap.String("derived", signer.PublicKey()))
+	}
+
+	client.SetSigner(signer)
+}
+
 func runAuthVerify(cmd *cobra.Command, args []string) error {
 	godotenv.Load(".env")
 
@@ -95,7 +161,7 @@ func runAuthVerify(cmd *cobra.Command, args []string) error {
 		network = "mainnet"
 	}
 
-	dataDir := "data_in"
+	dataDir := paths.DataDir()
 
 	tokenFile, err := flashnet.LoadTokenFromFile(dataDir)
 	if err == nil && tokenFile.AccessToken != "" {
@@ -133,7 +199,10 @@ func runAuthVerify(cmd *cobra.Command, args []string) error {
 	log.LogInfo("Signature loaded from file", zap.String("signature", sigFile.Signature[:20]))
 	log.LogInfo("Using public key", zap.String("publicKey", sigFile.PublicKey[:20]))
 
-	client := flashnet.NewAMMClient(network)
+	client, err := newAMMClient(network, os.Getenv("FLASHNET_API_URL"))
+	if err != nil {
+		return err
+	}
 
 	log.LogInfo("Verifying signature with API...")
 	ctx := context.Background()
@@ -176,23 +245,10 @@ func runAuthFull(cmd *cobra.Command, args []string) error {
 	}
 
 	log.LogInfo("Signing challenge...")
-	signChallengePath := filepath.Join("spark-cli", "sign-challenge.mjs")
-	output, err := executil.RunNodeScript(signChallengePath, 30*time.Second)
-	if err != nil {
-		log.LogError("Failed to sign challenge", zap.Error(err), zap.String("output", string(output)))
+	if err := runAuthSign(cmd, args); err != nil {
 		return fmt.Errorf("failed to sign challenge: %w", err)
 	}
 
-	log.LogSuccess("Challenge signed successfully")
-
-	// Wait for signature file to be written
-	dataDir := "data_in"
-	signatureFilePath := filepath.Join(dataDir, "signature.json")
-	if err := storage.WaitForFile(signatureFilePath, 3*time.Second); err != nil {
-		log.LogError("Signature file not created within timeout", zap.Error(err))
-		return fmt.Errorf("signature file not created: %w", err)
-	}
-
 	// Step 3: Verify signature
 	if err := runAuthVerify(cmd, args); err != nil {
 		return fmt.Errorf("failed to verify signature: %w", err)
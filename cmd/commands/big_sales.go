@@ -7,15 +7,16 @@ package commands
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"spark-wallet/bots_monitor"
 	"spark-wallet/internal/clients_api/flashnet"
-	executil "spark-wallet/internal/infra/exec"
-	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/infra/config"
+	"spark-wallet/internal/infra/dryrun"
 	"spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -42,15 +43,33 @@ func runBigSales(cmd *cobra.Command, args []string) error {
 	}
 
 	publicKey := os.Getenv("PUBLIC_KEY")
-	dataDir := "data_in"
+	dataDir := paths.DataDir()
+
+	configureHoldersTickers()
+
+	if err := runStorageMigrations(); err != nil {
+		log.LogError("Failed to migrate storage", zap.Error(err))
+		return err
+	}
 
 	log.LogInfo("Starting Big Sales Monitor...")
 	log.LogInfo("Network", zap.String("network", network))
 
-	client := flashnet.NewAMMClient(network)
+	client, err := newAMMClient(network, os.Getenv("FLASHNET_API_URL"))
+	if err != nil {
+		return err
+	}
+	configureSigner(client, os.Getenv("PRIVATE_KEY"), os.Getenv("FLASHNET_KEYSTORE"), publicKey)
+
+	renewMargin := flashnet.DefaultTokenRenewMargin
+	if minutes, err := strconv.Atoi(os.Getenv("FLASHNET_TOKEN_RENEW_MARGIN")); err == nil && minutes > 0 {
+		renewMargin = time.Duration(minutes) * time.Minute
+	}
+	authManager := flashnet.NewAuthManager(client, dataDir, publicKey, renewMargin)
 
 	if publicKey != "" {
-		if err := ensureValidToken(client, publicKey, dataDir); err != nil {
+		if err := authManager.EnsureValid(context.Background()); err != nil {
+			log.LogError("Failed to get valid token", zap.Error(err))
 			return err
 		}
 	} else {
@@ -63,7 +82,7 @@ func runBigSales(cmd *cobra.Command, args []string) error {
 
 	if apiBotToken != "" {
 		var err error
-		apiBot, err = tgbotapi.NewBotAPI(apiBotToken)
+		apiBot, err = dryrun.NewBotAPI(apiBotToken)
 		if err != nil {
 			log.LogWarn("Failed to initialize API bot (continuing without it)", zap.Error(err))
 		} else {
@@ -75,12 +94,72 @@ func runBigSales(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	var wg sync.WaitGroup
+	// Monitor restarts (panic) are reported to API chat
+	var operatorAlert func(text string)
+	if chatID, err := strconv.ParseInt(apiBotChatID, 10, 64); err == nil && apiBot != nil {
+		operatorAlert = func(text string) {
+			msg := tgbotapi.NewMessage(chatID, text)
+			msg.ParseMode = tgbotapi.ModeHTML
+			if _, err := apiBot.Send(msg); err != nil {
+				log.LogError("Failed to send operator alert", zap.Error(err))
+			}
+		}
+	}
+	registry := bots_monitor.NewMonitorRegistry(0, operatorAlert)
 	minBTCAmount := 0.0025
+	alertRulesFile := os.Getenv("ALERT_RULES_FILE")
+	if alertRulesFile == "" {
+		alertRulesFile = config.DefaultAlertRulesFile
+	}
+	if whalePercent, err := strconv.ParseFloat(os.Getenv("WHALE_SUPPLY_PERCENT"), 64); err == nil {
+		holders.SetWhaleSupplyPercent(whalePercent)
+	}
+	holdersMode, err := holders.ParseDetectionMode(os.Getenv("HOLDERS_MODE"))
+	if err != nil {
+		return err
+	}
+	holders.SetDetectionMode(holdersMode)
+	if multiplier, err := strconv.ParseFloat(os.Getenv("FAST_PATH_MULTIPLIER"), 64); err == nil {
+		bots_monitor.SetFastPathMultiplier(multiplier)
+	}
+	marketCapRanges := bots_monitor.ChatMarketCapRanges{}
+	if minUSD, err := strconv.ParseFloat(os.Getenv("BIG_SALES_MIN_MARKETCAP_USD"), 64); err == nil && minUSD > 0 {
+		marketCapRanges.BigSalesMinUSD = minUSD
+	}
+	if maxUSD, err := strconv.ParseFloat(os.Getenv("BIG_SALES_MAX_MARKETCAP_USD"), 64); err == nil && maxUSD > 0 {
+		marketCapRanges.BigSalesMaxUSD = maxUSD
+	}
+	bots_monitor.SetChatMarketCapRanges(marketCapRanges)
+	pollConfig := bots_monitor.DefaultSwapPollConfig()
+	if seconds, err := strconv.Atoi(os.Getenv("SWAP_POLL_INTERVAL")); err == nil && seconds > 0 {
+		pollConfig.Interval = time.Duration(seconds) * time.Second
+	}
+	if limit, err := strconv.Atoi(os.Getenv("SWAP_POLL_LIMIT")); err == nil && limit > 0 {
+		pollConfig.Limit = limit
+	}
+	if seconds, err := strconv.Atoi(os.Getenv("SWAP_POLL_IDLE_INTERVAL")); err == nil && seconds >= 0 {
+		pollConfig.IdleInterval = time.Duration(seconds) * time.Second
+	}
+	if seconds, err := strconv.Atoi(os.Getenv("SWAP_POLL_IDLE_AFTER")); err == nil && seconds > 0 {
+		pollConfig.IdleAfter = time.Duration(seconds) * time.Second
+	}
+	bots_monitor.SetSwapPollConfig(pollConfig)
+
+	if publicKey != "" || client.GetSigner() != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			authManager.Run(ctx)
+		}()
+	}
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		bots_monitor.RunBigSalesBuysMonitor(apiBot, client, apiBotChatID, minBTCAmount, nil, "", nil, 0)
+		// Restarted with backoff on panic, stack is logged
+		registry.Run(ctx, "big_sales", func(ctx context.Context) {
+			bots_monitor.RunBigSalesBuysMonitor(ctx, apiBot, client, apiBotChatID, minBTCAmount, nil, "", nil, 0, alertRulesFile, nil)
+		})
 	}()
 
 	log.LogSuccess("Big Sales monitor is running", zap.String("status", "active"))
@@ -104,55 +183,3 @@ func runBigSales(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
-
-func ensureValidToken(client *flashnet.Client, publicKey string, dataDir string) error {
-	tokenFile, err := flashnet.LoadTokenFromFile(dataDir)
-	if err == nil && tokenFile.AccessToken != "" {
-		expiresAt, err := flashnet.GetTokenExpirationTime(tokenFile.AccessToken)
-		if err == nil && expiresAt > time.Now().Unix() {
-			client.SetJWT(tokenFile.AccessToken)
-			log.LogInfo("Using saved JWT token", zap.String("expiresAt", time.Unix(expiresAt, 0).Format(time.RFC3339)))
-			return nil
-		} else {
-			log.LogWarn("Saved token is expired or invalid, refreshing...")
-		}
-	}
-
-	log.LogInfo("Getting new challenge...")
-	ctx := context.Background()
-	_, err = client.GetChallengeAndSave(ctx, dataDir, publicKey)
-	if err != nil {
-		log.LogError("Failed to get challenge", zap.Error(err))
-		return fmt.Errorf("failed to get challenge: %w", err)
-	}
-
-	log.LogInfo("Signing challenge automatically...")
-	signChallengePath := filepath.Join("spark-cli", "sign-challenge.mjs")
-	output, err := executil.RunNodeScript(signChallengePath, 30*time.Second)
-	if err != nil {
-		log.LogError("Failed to sign challenge", zap.Error(err), zap.String("output", string(output)))
-		return fmt.Errorf("failed to sign challenge: %w", err)
-	}
-
-	// Wait for signature file to be written
-	signatureFilePath := filepath.Join(dataDir, "signature.json")
-	if err := storage.WaitForFile(signatureFilePath, 3*time.Second); err != nil {
-		log.LogError("Signature file not created within timeout", zap.Error(err))
-		return fmt.Errorf("signature file not created: %w", err)
-	}
-
-	sigFile, err := flashnet.LoadSignatureFromFile(dataDir)
-	if err != nil || sigFile.Signature == "" {
-		return fmt.Errorf("signature file not found after signing")
-	}
-
-	log.LogInfo("Verifying signature...")
-	_, err = client.VerifySignatureAndSave(ctx, dataDir, sigFile.PublicKey, sigFile.Signature)
-	if err != nil {
-		log.LogError("Failed to verify signature", zap.Error(err))
-		return fmt.Errorf("failed to verify signature: %w", err)
-	}
-
-	log.LogSuccess("Token refreshed successfully")
-	return nil
-}
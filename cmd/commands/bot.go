@@ -7,16 +7,30 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"spark-wallet/bots_monitor"
 	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/quiet_hours"
+	"spark-wallet/internal/features/roles"
+	"spark-wallet/internal/features/tg_charts"
+	"spark-wallet/internal/features/trading"
+	"spark-wallet/internal/infra/cloudflare"
 	"spark-wallet/internal/infra/config"
-	executil "spark-wallet/internal/infra/exec"
+	"spark-wallet/internal/infra/dryrun"
 	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/handoff"
 	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/network"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/infra/transfer"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -33,32 +47,102 @@ var botCmd = &cobra.Command{
 	RunE:  runBot,
 }
 
+// handoffTimeout - how long new process waits for checkpoint of old one
+const handoffTimeout = 30 * time.Second
+
+func init() {
+	botCmd.Flags().Bool("handoff", false, "Take over from running bot: send SIGUSR2 and wait for its checkpoint before starting monitors")
+}
+
 func runBot(cmd *cobra.Command, args []string) error {
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		logging.LogError("Failed to load config", zap.Error(err))
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	network.Set(cfg.Flashnet.Network)
+	paths.Configure(cfg.App.DataDir, cfg.App.OutputDir)
+	logging.LogInfo("Data directories configured",
+		zap.String("network", network.Name()),
+		zap.String("dataDir", paths.DataDir()),
+		zap.String("outputDir", paths.OutputDir()))
+
+	if err := runStorageMigrations(); err != nil {
+		logging.LogError("Failed to migrate storage", zap.Error(err))
+		return err
+	}
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	var wg sync.WaitGroup
 
-	const expectedPublicKey = "038ad2deab88fa2f278ad895f61254a804370d987db61301a7d6872df4231b6597"
-	if cfg.Flashnet.PublicKey != "" && cfg.Flashnet.PublicKey != expectedPublicKey {
-		logging.LogWarn("Public key does not match expected Spark SDK public key",
-			zap.String("provided", cfg.Flashnet.PublicKey),
-			zap.String("expected", expectedPublicKey))
-		logging.LogInfo("Make sure PUBLIC_KEY matches the key from spark-cli (Spark SDK)")
-	}
+	dataDir := paths.DataDir()
 
-	dataDir := cfg.App.DataDir
-	if dataDir == "" {
-		dataDir = "data_in"
+	holders.SetConfiguredTickers(cfg.App.HoldersTickers)
+	logging.LogInfo("Holders tracking configured", zap.Strings("tickers", cfg.App.HoldersTickers))
+	holders.SetWhaleSupplyPercent(cfg.App.WhaleSupplyPercent)
+	holders.SetBalanceFetchConfig(holders.BalanceFetchConfig{
+		Workers: cfg.App.HoldersBalanceWorkers,
+		Rate:    cfg.App.HoldersBalanceRate,
+		Retries: cfg.App.HoldersBalanceRetries,
+	})
+	holdersMode, err := holders.ParseDetectionMode(cfg.App.HoldersMode)
+	if err != nil {
+		logging.LogError("Invalid holders mode", zap.Error(err))
+		return err
+	}
+	holders.SetDetectionMode(holdersMode)
+	logging.LogInfo("Holders detection mode configured", zap.String("mode", string(holdersMode)))
+	if chartTheme, err := tg_charts.LoadThemeFile(cfg.App.ChartThemeFile); err == nil {
+		tg_charts.SetThemeFile(*chartTheme)
+		logging.LogInfo("Chart theme loaded",
+			zap.String("file", cfg.App.ChartThemeFile),
+			zap.String("theme", tg_charts.ThemeFor(0).Name),
+			zap.Int("chats", len(chartTheme.Chats)))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		logging.LogError("Invalid chart theme file", zap.String("file", cfg.App.ChartThemeFile), zap.Error(err))
+		return err
+	}
+	bots_monitor.SetFastPathMultiplier(cfg.Telegram.FastPathMultiplier)
+	bots_monitor.SetChatMarketCapRanges(chatMarketCapRanges(cfg))
+	trading.SetLimits(trading.Limits{
+		Enabled:               cfg.Trading.Enabled,
+		MaxSlippageBps:        cfg.Trading.MaxSlippageBps,
+		MaxAmountSats:         cfg.Trading.MaxAmountSats,
+		MaxPriceImpactPercent: cfg.Trading.MaxPriceImpactPercent,
+	})
+	if cfg.Trading.Enabled {
+		logging.LogWarn("Trading is enabled, swaps can be executed with wallet identity key",
+			zap.Int("maxSlippageBps", cfg.Trading.MaxSlippageBps),
+			zap.Int64("maxAmountSats", cfg.Trading.MaxAmountSats),
+			zap.Float64("maxPriceImpactPercent", cfg.Trading.MaxPriceImpactPercent))
+	}
+	bots_monitor.SetSwapPollConfig(bots_monitor.SwapPollConfig{
+		Interval:     time.Duration(cfg.Telegram.SwapPollInterval) * time.Second,
+		Limit:        cfg.Telegram.SwapPollLimit,
+		IdleInterval: time.Duration(cfg.Telegram.SwapPollIdleInterval) * time.Second,
+		IdleAfter:    time.Duration(cfg.Telegram.SwapPollIdleAfter) * time.Second,
+	})
+	if err := bots_monitor.ConfigureBTCPriceFeed(cfg.App.BTCPriceSource); err != nil {
+		logging.LogError("Invalid BTC price source", zap.Error(err))
+		return err
+	}
+	if thresholds, err := bots_monitor.LoadRuntimeThresholds(); err != nil {
+		logging.LogWarn("Failed to load runtime thresholds, using config", zap.Error(err))
+	} else if thresholds.BigSalesMinBTC > 0 || thresholds.FilteredMinBTC > 0 {
+		logging.LogInfo("Runtime thresholds override config",
+			zap.Float64("bigSalesMinBTC", thresholds.BigSalesMinBTC),
+			zap.Float64("filteredMinBTC", thresholds.FilteredMinBTC))
 	}
 
-	client := flashnet.NewAMMClient(cfg.Flashnet.Network)
+	client, err := newAMMClient(cfg.Flashnet.Network, cfg.Flashnet.APIURL)
+	if err != nil {
+		logging.LogError("Failed to create Flashnet client", zap.Error(err))
+		return err
+	}
+	configureRetry(client, cfg)
+	configureSigner(client, cfg.Flashnet.PrivateKey, cfg.Flashnet.KeystorePath, cfg.Flashnet.PublicKey)
 
 	if cfg.Flashnet.PublicKey != "" {
 		if err := handleAuthentication(ctx, client, cfg, dataDir); err != nil {
@@ -68,19 +152,64 @@ func runBot(cmd *cobra.Command, args []string) error {
 		logging.LogWarn("PUBLIC_KEY not provided, running without authentication")
 	}
 
-	apiBot, bot1, bot2, err := initializeBots(cfg)
+	if cfg.Flashnet.PublicKey != "" || client.GetSigner() != nil {
+		renewMargin := time.Duration(cfg.Flashnet.TokenRenewMargin) * time.Minute
+		authManager := flashnet.NewAuthManager(client, dataDir, cfg.Flashnet.PublicKey, renewMargin)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			authManager.Run(ctx)
+		}()
+	}
+
+	accounts, err := setupAccounts(ctx, &wg, cfg, dataDir, client)
 	if err != nil {
 		return err
 	}
 
-	if err := startMonitors(ctx, &wg, cfg, client, apiBot, bot1, bot2); err != nil {
+	var apiBot, bot1, bot2 *tgbotapi.BotAPI
+	if cfg.App.IsCollector() {
+		logging.LogInfo("Collector mode: running without Telegram (swap archive, holders, sampling, admin API)")
+	} else {
+		apiBot, bot1, bot2, err = initializeBots(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	takeOver, _ := cmd.Flags().GetBool("handoff")
+	restoreHandoffState(takeOver)
+	handoffRequested := handoff.Notify()
+
+	if err := startMonitors(ctx, &wg, cfg, accounts, apiBot, bot1, bot2); err != nil {
 		return err
 	}
 
+	// config.yaml is reloaded on change and on SIGHUP, safe changes are applied to running monitors
+	config.SetCurrent(cfg)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := config.Watch(ctx, config.File, onConfigReload); err != nil {
+			logging.LogWarn("Config watcher stopped, config changes need restart", zap.Error(err))
+		}
+	}()
+
+	if err := handoff.WritePIDFile(); err != nil {
+		logging.LogWarn("Failed to write pid file, state handoff to next process disabled", zap.Error(err))
+	}
+	defer handoff.RemovePIDFile()
+
 	logging.LogSuccess("Bots are running", zap.String("status", "active"))
 
-	<-ctx.Done()
-	logging.LogInfo("Shutdown signal received, gracefully stopping all monitors...")
+	isHandoff := false
+	select {
+	case <-ctx.Done():
+		logging.LogInfo("Shutdown signal received, gracefully stopping all monitors...")
+	case <-handoffRequested:
+		isHandoff = true
+		logging.LogInfo("Handoff requested (SIGUSR2), stopping monitors and writing checkpoint...")
+	}
 
 	cancel()
 
@@ -97,9 +226,316 @@ func runBot(cmd *cobra.Command, args []string) error {
 		logging.LogWarn("Timeout waiting for monitors to stop, forcing shutdown")
 	}
 
+	logTransferStats()
+
+	if isHandoff {
+		checkpoint, err := handoff.WriteCheckpoint()
+		if err != nil {
+			logging.LogError("Failed to write handoff checkpoint", zap.Error(err))
+			return fmt.Errorf("failed to write handoff checkpoint: %w", err)
+		}
+		logging.LogSuccess("Handoff checkpoint written",
+			zap.String("file", handoff.CheckpointFile()),
+			zap.Int("processedSwaps", len(checkpoint.ProcessedSwapIDs)),
+			zap.Int("pendingSwaps", len(checkpoint.PendingSwapIDs)))
+	}
+
 	return nil
 }
 
+// logTransferStats logs response sizes of API clients (wire - gzipped, body - decompressed)
+func logTransferStats() {
+	for _, stats := range transfer.AllStats() {
+		logging.LogInfo("API transfer stats",
+			zap.String("client", stats.Client),
+			zap.Int64("responses", stats.Responses),
+			zap.Int64("compressed", stats.Compressed),
+			zap.Int64("wireBytes", stats.WireBytes),
+			zap.Int64("bodyBytes", stats.BodyBytes),
+			zap.Float64("savings", stats.Savings()))
+	}
+}
+
+// newOperatorAlert returns sender of operator alerts (API bot chat, or filtered chat if API chat is not set)
+func newOperatorAlert(cfg *config.Config, apiBot, bot1 *tgbotapi.BotAPI) func(text string) {
+	alertBot := apiBot
+	alertChatID := cfg.Telegram.ApiBotChatID
+	if alertBot == nil || alertChatID == "" {
+		alertBot = bot1
+		alertChatID = cfg.Telegram.FilteredChatID
+	}
+	if alertBot == nil || alertChatID == "" {
+		logging.LogWarn("No chat for operator alerts, monitor restarts and Cloudflare blocks are only logged")
+		return nil
+	}
+
+	return func(text string) {
+		var chatID int64
+		if _, err := fmt.Sscanf(alertChatID, "%d", &chatID); err != nil {
+			logging.LogWarn("Failed to parse operator alert chat ID", zap.String("chatID", alertChatID), zap.Error(err))
+			return
+		}
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		if _, err := alertBot.Send(msg); err != nil {
+			logging.LogError("Failed to send operator alert", zap.Error(err))
+		}
+	}
+}
+
+// buildSwapDestinations creates destinations from telegram.destinations
+// Destination without bot_token uses defaultBot, without min_btc - defaultMinBTC
+// Bots are shared between destinations with the same token
+func buildSwapDestinations(cfg *config.Config, defaultBot *tgbotapi.BotAPI, defaultMinBTC float64) []bots_monitor.SwapDestination {
+	bots := make(map[string]*tgbotapi.BotAPI)
+	if defaultBot != nil {
+		bots[defaultBot.Token] = defaultBot
+	}
+
+	destinations := make([]bots_monitor.SwapDestination, 0, len(cfg.Telegram.Destinations))
+	for _, destinationCfg := range cfg.Telegram.Destinations {
+		bot := defaultBot
+		if destinationCfg.BotToken != "" {
+			var exists bool
+			bot, exists = bots[destinationCfg.BotToken]
+			if !exists {
+				var err error
+				bot, err = dryrun.NewBotAPI(destinationCfg.BotToken)
+				if err != nil {
+					logging.LogError("Failed to create destination bot, destination skipped",
+						zap.String("destination", destinationCfg.Name),
+						zap.Error(err))
+					continue
+				}
+				bots[destinationCfg.BotToken] = bot
+			}
+		}
+		if bot == nil {
+			logging.LogWarn("No bot for destination, destination skipped", zap.String("destination", destinationCfg.Name))
+			continue
+		}
+
+		minBTC := destinationCfg.MinBTC
+		if minBTC == 0 {
+			minBTC = defaultMinBTC
+		}
+
+		destinations = append(destinations, bots_monitor.SwapDestination{
+			Name:   destinationCfg.Name,
+			Bot:    bot,
+			ChatID: destinationCfg.ChatID,
+			MinBTC: minBTC,
+			Tokens: destinationCfg.Tokens,
+			Side:   destinationCfg.Side,
+
+			MinMarketCapUSD: destinationCfg.MinMarketCapUSD,
+			MaxMarketCapUSD: destinationCfg.MaxMarketCapUSD,
+		})
+		logging.LogInfo("Swap destination configured",
+			zap.String("destination", destinationCfg.Name),
+			zap.String("chatID", destinationCfg.ChatID),
+			zap.Float64("minBTC", minBTC),
+			zap.Int("tokensCount", len(destinationCfg.Tokens)),
+			zap.String("side", destinationCfg.Side),
+			zap.Float64("minMarketCapUSD", destinationCfg.MinMarketCapUSD),
+			zap.Float64("maxMarketCapUSD", destinationCfg.MaxMarketCapUSD))
+	}
+	return destinations
+}
+
+// configureRetry sets retry of Flashnet requests from config
+func configureRetry(client *flashnet.Client, cfg *config.Config) {
+	client.SetRetry(cfg.Flashnet.MaxRetries,
+		time.Duration(cfg.Flashnet.RetryDelayMs)*time.Millisecond,
+		time.Duration(cfg.Flashnet.RetryMaxDelaySec)*time.Second,
+		cfg.Flashnet.RetryBackoff)
+}
+
+// configureQuietHours sets quiet hours of chats from telegram.quiet_hours (/quiet in chat overrides them)
+func configureQuietHours(cfg *config.Config) error {
+	windows := make(map[int64]quiet_hours.Window)
+	for _, quietCfg := range cfg.Telegram.QuietHours {
+		location := quiet_hours.DefaultLocation
+		if quietCfg.Timezone != "" {
+			var err error
+			location, err = time.LoadLocation(quietCfg.Timezone)
+			if err != nil {
+				return fmt.Errorf("invalid telegram.quiet_hours %q timezone: %w", quietCfg.ChatID, err)
+			}
+		}
+		window, err := quiet_hours.ParseWindow(quietCfg.Window, location)
+		if err != nil {
+			return fmt.Errorf("invalid telegram.quiet_hours %q: %w", quietCfg.ChatID, err)
+		}
+		chatID, err := strconv.ParseInt(quietCfg.ChatID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid telegram.quiet_hours chat_id %q: %w", quietCfg.ChatID, err)
+		}
+		windows[chatID] = window
+		logging.LogInfo("Quiet hours configured",
+			zap.String("chatID", quietCfg.ChatID),
+			zap.String("window", window.String()),
+			zap.String("timezone", location.String()))
+	}
+	quiet_hours.Configure(windows)
+	return nil
+}
+
+// configureLanguages sets default language (telegram.language) and languages of chats (telegram.chat_languages)
+// /lang in chat overrides them
+func configureLanguages(cfg *config.Config) error {
+	defaultLang := i18n.English
+	if cfg.Telegram.Language != "" {
+		var err error
+		defaultLang, err = i18n.ParseLang(cfg.Telegram.Language)
+		if err != nil {
+			return fmt.Errorf("invalid telegram.language: %w", err)
+		}
+	}
+	i18n.SetDefault(defaultLang)
+
+	languages := make(map[int64]i18n.Lang)
+	for chatIDStr, langStr := range cfg.Telegram.ChatLanguages {
+		chatID, err := strconv.ParseInt(chatIDStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid telegram.chat_languages chat_id %q: %w", chatIDStr, err)
+		}
+		lang, err := i18n.ParseLang(langStr)
+		if err != nil {
+			return fmt.Errorf("invalid telegram.chat_languages %q: %w", chatIDStr, err)
+		}
+		languages[chatID] = lang
+	}
+	i18n.Configure(languages)
+	logging.LogInfo("Languages configured",
+		zap.String("default", string(defaultLang)),
+		zap.Int("chats", len(languages)))
+	return nil
+}
+
+// configureRoles sets bot owners (telegram.admin_user_ids), chat admin check (telegram.chat_admins)
+// and levels of commands (telegram.command_levels), admins added with /admins come on top
+func configureRoles(cfg *config.Config) error {
+	var owners []int64
+	for _, idStr := range cfg.Telegram.AdminUserIDs {
+		if idStr == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid telegram.admin_user_ids %q: %w", idStr, err)
+		}
+		owners = append(owners, id)
+	}
+
+	levels := make(map[string]roles.Level)
+	for command, levelStr := range cfg.Telegram.CommandLevels {
+		level, err := roles.ParseLevel(levelStr)
+		if err != nil {
+			return fmt.Errorf("invalid telegram.command_levels %q: %w", command, err)
+		}
+		levels[strings.ToLower(strings.TrimPrefix(command, "/"))] = level
+	}
+
+	roles.Configure(roles.Config{Owners: owners, ChatAdmins: cfg.Telegram.ChatAdmins, Levels: levels})
+	if !roles.Enabled() {
+		logging.LogWarn("No bot owners or admins: every chat member can run every command (set telegram.admin_user_ids)")
+		return nil
+	}
+	logging.LogInfo("Command roles configured",
+		zap.Int("owners", len(owners)),
+		zap.Bool("chatAdmins", cfg.Telegram.ChatAdmins),
+		zap.Int("commandLevels", len(levels)))
+	return nil
+}
+
+// buildWebhookSources creates signal sources from telegram.webhooks
+// Source without bot_token uses defaultBot, bots are shared between sources with the same token
+func buildWebhookSources(cfg *config.Config, defaultBot *tgbotapi.BotAPI) []bots_monitor.WebhookSource {
+	bots := make(map[string]*tgbotapi.BotAPI)
+	if defaultBot != nil {
+		bots[defaultBot.Token] = defaultBot
+	}
+
+	sources := make([]bots_monitor.WebhookSource, 0, len(cfg.Telegram.Webhooks))
+	for _, webhookCfg := range cfg.Telegram.Webhooks {
+		bot := defaultBot
+		if webhookCfg.BotToken != "" {
+			var exists bool
+			bot, exists = bots[webhookCfg.BotToken]
+			if !exists {
+				var err error
+				bot, err = dryrun.NewBotAPI(webhookCfg.BotToken)
+				if err != nil {
+					logging.LogError("Failed to create webhook bot, source skipped",
+						zap.String("source", webhookCfg.Name),
+						zap.Error(err))
+					continue
+				}
+				bots[webhookCfg.BotToken] = bot
+			}
+		}
+		if bot == nil {
+			logging.LogWarn("No bot for webhook source, source skipped", zap.String("source", webhookCfg.Name))
+			continue
+		}
+
+		sources = append(sources, bots_monitor.WebhookSource{
+			Name:    webhookCfg.Name,
+			Secret:  webhookCfg.Secret,
+			Bot:     bot,
+			ChatIDs: webhookCfg.ChatIDs,
+		})
+		logging.LogInfo("Webhook source configured",
+			zap.String("source", webhookCfg.Name),
+			zap.Strings("chatIDs", webhookCfg.ChatIDs))
+	}
+	return sources
+}
+
+// restoreHandoffState restores state of previous process before monitors start
+// takeOver - signal running process (pid file) and wait for its checkpoint
+// Without takeOver a fresh checkpoint (written by SIGUSR2 from deploy script) is still used
+func restoreHandoffState(takeOver bool) {
+	var checkpoint *handoff.Checkpoint
+	var err error
+
+	if takeOver {
+		logging.LogInfo("Requesting state handoff from running bot...")
+		checkpoint, err = handoff.RequestHandoff(handoffTimeout)
+		if err != nil {
+			logging.LogWarn("State handoff failed, starting with clean state", zap.Error(err))
+			return
+		}
+		if checkpoint == nil {
+			logging.LogInfo("No running bot found for handoff")
+		}
+	}
+
+	if checkpoint == nil {
+		checkpoint, err = handoff.LoadCheckpoint()
+		if err != nil {
+			logging.LogWarn("Failed to load handoff checkpoint", zap.Error(err))
+			return
+		}
+		if checkpoint == nil {
+			return
+		}
+		if time.Since(checkpoint.CreatedAt) > handoff.CheckpointMaxAge {
+			logging.LogInfo("Handoff checkpoint is too old, ignoring", zap.Time("createdAt", checkpoint.CreatedAt))
+			return
+		}
+	}
+
+	handoff.Restore(checkpoint)
+	logging.LogSuccess("State restored from previous process",
+		zap.Int("pid", checkpoint.PID),
+		zap.String("lastSwapID", checkpoint.LastSwapID),
+		zap.Int("processedSwaps", len(checkpoint.ProcessedSwapIDs)),
+		zap.Int("pendingSwaps", len(checkpoint.PendingSwapIDs)),
+		zap.Int("hotTokenCooldowns", len(checkpoint.HotTokenSent)))
+}
+
 func handleAuthentication(ctx context.Context, client *flashnet.Client, cfg *config.Config, dataDir string) error {
 	tokenFile, err := flashnet.LoadTokenFromFile(dataDir)
 	if err == nil && tokenFile.AccessToken != "" {
@@ -165,34 +601,20 @@ func handleAuthentication(ctx context.Context, client *flashnet.Client, cfg *con
 
 		if sigFile == nil || sigFile.Signature == "" {
 			logging.LogInfo("Signature not found, signing challenge automatically...")
-			signChallengePath := filepath.Join("spark-cli", "sign-challenge.mjs")
-			output, err := executil.RunNodeScript(signChallengePath, 30*time.Second)
+			sigFile, err := client.SignChallengeAndSave(dataDir)
 			if err != nil {
-				logging.LogError("Failed to sign challenge", zap.Error(err), zap.String("output", string(output)))
-				logging.LogWarn("Bot will run without authentication. Please sign manually:")
-				logging.LogInfo("1. Run: make sign")
+				logging.LogError("Failed to sign challenge", zap.Error(err))
+				logging.LogWarn("Bot will run without authentication. Please configure signing key:")
+				logging.LogInfo("1. Set PRIVATE_KEY or FLASHNET_KEYSTORE in .env")
 				logging.LogInfo("2. Restart the bot")
 				return nil
 			}
 
-			logging.LogSuccess("Challenge signed successfully")
-
-			// Wait for signature file to be written
-			signatureFilePath := filepath.Join(dataDir, "signature.json")
-			if err := storage.WaitForFile(signatureFilePath, 3*time.Second); err != nil {
-				logging.LogWarn("Signature file not created within timeout, bot will run without authentication", zap.Error(err))
-			}
-
-			sigFile, err := flashnet.LoadSignatureFromFile(dataDir)
-			if err == nil && sigFile.Signature != "" {
-				logging.LogInfo("Verifying signature...")
-				_, err := client.VerifySignatureAndSave(ctx, dataDir, sigFile.PublicKey, sigFile.Signature)
-				if err != nil {
-					logging.LogError("Failed to verify signature", zap.Error(err))
-					return fmt.Errorf("failed to verify signature: %w", err)
-				}
-			} else {
-				logging.LogWarn("Signature file not found after signing. Bot will run without authentication.")
+			logging.LogInfo("Verifying signature...")
+			_, err = client.VerifySignatureAndSave(ctx, dataDir, sigFile.PublicKey, sigFile.Signature)
+			if err != nil {
+				logging.LogError("Failed to verify signature", zap.Error(err))
+				return fmt.Errorf("failed to verify signature: %w", err)
 			}
 		}
 	}
@@ -203,7 +625,7 @@ func initializeBots(cfg *config.Config) (*tgbotapi.BotAPI, *tgbotapi.BotAPI, *tg
 	var apiBot *tgbotapi.BotAPI
 	if cfg.Telegram.ApiBotToken != "" {
 		var err error
-		apiBot, err = tgbotapi.NewBotAPI(cfg.Telegram.ApiBotToken)
+		apiBot, err = dryrun.NewBotAPI(cfg.Telegram.ApiBotToken)
 		if err != nil {
 			logging.LogWarn("Failed to initialize API bot (continuing without it)", zap.Error(err))
 		} else {
@@ -214,7 +636,7 @@ func initializeBots(cfg *config.Config) (*tgbotapi.BotAPI, *tgbotapi.BotAPI, *tg
 	var bot1 *tgbotapi.BotAPI
 	if cfg.Telegram.Bot1Token != "" {
 		var err error
-		bot1, err = tgbotapi.NewBotAPI(cfg.Telegram.Bot1Token)
+		bot1, err = dryrun.NewBotAPI(cfg.Telegram.Bot1Token)
 		if err != nil {
 			logging.LogError("Failed to initialize bot 1", zap.Error(err))
 			return nil, nil, nil, fmt.Errorf("failed to initialize bot 1: %w", err)
@@ -227,7 +649,7 @@ func initializeBots(cfg *config.Config) (*tgbotapi.BotAPI, *tgbotapi.BotAPI, *tg
 	var bot2 *tgbotapi.BotAPI
 	if cfg.Telegram.Bot2Token != "" {
 		var err error
-		bot2, err = tgbotapi.NewBotAPI(cfg.Telegram.Bot2Token)
+		bot2, err = dryrun.NewBotAPI(cfg.Telegram.Bot2Token)
 		if err != nil {
 			logging.LogWarn("Failed to initialize bot 2 (continuing without it)", zap.Error(err))
 		} else {
@@ -238,17 +660,32 @@ func initializeBots(cfg *config.Config) (*tgbotapi.BotAPI, *tgbotapi.BotAPI, *tg
 	return apiBot, bot1, bot2, nil
 }
 
-func startMonitors(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config, client *flashnet.Client, apiBot, bot1, bot2 *tgbotapi.BotAPI) error {
+func startMonitors(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config, accounts *flashnetAccounts, apiBot, bot1, bot2 *tgbotapi.BotAPI) error {
+	// Monitors run under registry: restart with backoff when error budget is exceeded or on panic
+	operatorAlert := newOperatorAlert(cfg, apiBot, bot1)
+	registry := bots_monitor.NewMonitorRegistry(cfg.App.MonitorErrorBudget, operatorAlert)
+	bots_monitor.SetBotStatsRegistry(registry)
+	// Command autocomplete hides commands of disabled features
+	bots_monitor.SetCommandFeature(bots_monitor.FeatureAutoBlacklist, cfg.Telegram.AutoBlacklistThreshold > 0)
+	// Operator is also alerted when Cloudflare block rate of API clients spikes
+	cloudflare.SetAlertFunc(operatorAlert)
+	if err := configureQuietHours(cfg); err != nil {
+		return err
+	}
+	if err := configureLanguages(cfg); err != nil {
+		return err
+	}
+	if err := configureRoles(cfg); err != nil {
+		return err
+	}
+
 	bigSalesBot := apiBot
 	bigSalesChatID := cfg.Telegram.ApiBotChatID
 	if bigSalesBot == nil || bigSalesChatID == "" {
 		bigSalesBot = bot1
 		bigSalesChatID = cfg.Telegram.BigSalesChatID
 	}
-	bigSalesMinBTCAmount := cfg.Telegram.BigSalesMinBTCAmount
-	if bigSalesMinBTCAmount == 0 {
-		bigSalesMinBTCAmount = 0.0025
-	}
+	bigSalesMinBTCAmount := configuredBigSalesMinBTC(cfg)
 
 	var filteredBot *tgbotapi.BotAPI
 	var filteredChatID string
@@ -281,10 +718,7 @@ func startMonitors(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config,
 			}
 		}
 
-		filteredMinBTCAmount = cfg.Telegram.FilteredMinBTCAmount
-		if filteredMinBTCAmount == 0 {
-			filteredMinBTCAmount = 0.01
-		}
+		filteredMinBTCAmount = configuredFilteredMinBTC(cfg)
 		logging.LogInfo("Filtered tokens monitor configured",
 			zap.String("chatID", filteredChatID),
 			zap.Int("tokensCount", len(filteredTokensList)),
@@ -309,7 +743,7 @@ func startMonitors(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config,
 				wg.Add(1)
 				go func() {
 					defer wg.Done()
-					bots_monitor.RunCommandHandler(filteredBot, filteredChatID, client)
+					bots_monitor.RunCommandHandler(ctx, filteredBot, filteredChatID, accounts.clientFor("commands"))
 				}()
 			}
 
@@ -320,7 +754,135 @@ func startMonitors(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config,
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				bots_monitor.RunStatsMonitor(filteredBot, filteredChatID, statsSendTime)
+				registry.Run(ctx, "stats", func(ctx context.Context) {
+					bots_monitor.RunStatsMonitor(ctx, filteredBot, filteredChatID, statsSendTime)
+				})
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "weekly_recap", func(ctx context.Context) {
+					bots_monitor.RunWeeklyRecapMonitor(ctx, filteredBot, filteredChatID, statsSendTime)
+				})
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "leaderboard", func(ctx context.Context) {
+					bots_monitor.RunLeaderboardMonitor(ctx, filteredBot, filteredChatID, statsSendTime)
+				})
+			}()
+
+			digestSendTime := cfg.Telegram.DigestSendTime
+			if digestSendTime == "" {
+				digestSendTime = "09:00"
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "daily_digest", func(ctx context.Context) {
+					bots_monitor.RunDailyDigestMonitor(ctx, filteredBot, filteredChatID, digestSendTime)
+				})
+			}()
+
+			if len(cfg.Telegram.HoldersReportTickers) > 0 {
+				holdersReportConfig := bots_monitor.HoldersReportConfig{
+					Tickers:   cfg.Telegram.HoldersReportTickers,
+					SendTime:  cfg.Telegram.HoldersReportTime,
+					SkipEmpty: cfg.Telegram.HoldersReportSkipEmpty,
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					registry.Run(ctx, "holders_report", func(ctx context.Context) {
+						bots_monitor.RunHoldersReportMonitor(ctx, filteredBot, filteredChatID, accounts.clientFor("holders_report"), holdersReportConfig)
+					})
+				}()
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "anomaly", func(ctx context.Context) {
+					bots_monitor.RunAnomalyMonitor(ctx, filteredBot, filteredChatID)
+				})
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "pool_fees", func(ctx context.Context) {
+					bots_monitor.RunPoolFeesMonitor(ctx, filteredBot, filteredChatID, time.Hour)
+				})
+			}()
+
+			liquidityWindow := time.Duration(cfg.Telegram.LiquidityWindow) * time.Minute
+			if liquidityWindow <= 0 {
+				liquidityWindow = time.Hour
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "liquidity", func(ctx context.Context) {
+					bots_monitor.RunLiquidityMonitor(ctx, filteredBot, filteredChatID, cfg.Telegram.LiquidityChangePercent, liquidityWindow, 5*time.Minute)
+				})
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "suspicious_activity", func(ctx context.Context) {
+					bots_monitor.RunSuspiciousActivityMonitor(ctx, filteredBot, filteredChatID, cfg.Telegram.SuspiciousMinBTC, 5*time.Minute)
+				})
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "accumulation", func(ctx context.Context) {
+					thresholds := holders.AccumulationThresholds{SupplyPercent: cfg.Telegram.AccumulationPercent, MinBTC: cfg.Telegram.AccumulationMinBTC}
+					bots_monitor.RunAccumulationMonitor(ctx, filteredBot, filteredChatID, thresholds, time.Hour)
+				})
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "concentration", func(ctx context.Context) {
+					thresholds := holders.ConcentrationThresholds{
+						TopShareRise:     cfg.Telegram.ConcentrationTopRise,
+						GiniRise:         cfg.Telegram.ConcentrationGiniRise,
+						TopSellerPercent: cfg.Telegram.ConcentrationTopSell,
+					}
+					bots_monitor.RunConcentrationMonitor(ctx, filteredBot, filteredChatID, thresholds, time.Hour)
+				})
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "listings", func(ctx context.Context) {
+					bots_monitor.RunListingMonitor(ctx, filteredBot, filteredChatID, 2*time.Minute)
+				})
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "community", func(ctx context.Context) {
+					bots_monitor.RunCommunityMonitor(ctx, filteredBot, cfg.Telegram.CommunityChats, 6*time.Hour)
+				})
+			}()
+
+			autoBlacklistThreshold := cfg.Telegram.AutoBlacklistThreshold
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Run(ctx, "auto_blacklist", func(ctx context.Context) {
+					bots_monitor.RunAutoBlacklistMonitor(ctx, filteredBot, filteredChatID, autoBlacklistThreshold, 5*time.Minute)
+				})
 			}()
 		}
 	}
@@ -346,26 +908,50 @@ func startMonitors(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config,
 		if checkInterval == 0 {
 			checkInterval = 30
 		}
+		hotTokenConfig := hot_token.ScoreConfig{
+			MinSwaps:      hotTokenSwapsCount,
+			MinAddresses:  hotTokenMinAddresses,
+			MinScore:      cfg.Telegram.HotTokenMinScore,
+			Cooldown:      time.Duration(cfg.Telegram.HotTokenCooldown) * time.Minute,
+			ScoreIncrease: cfg.Telegram.HotTokenScoreIncrease,
+		}
 
 		if hotTokenBot != nil {
 			logging.LogInfo("Hot token monitor configured",
 				zap.Int("swapsCount", hotTokenSwapsCount),
 				zap.Int("minAddresses", hotTokenMinAddresses),
+				zap.Float64("minScore", hotTokenConfig.MinScore),
+				zap.Duration("cooldown", hotTokenConfig.Cooldown),
 				zap.Int("checkInterval", checkInterval),
 				zap.String("chatID", cfg.Telegram.FilteredChatID))
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				bots_monitor.RunHotTokenMonitor(hotTokenBot, client, cfg.Telegram.FilteredChatID, hotTokenSwapsCount, hotTokenMinAddresses, checkInterval)
+				registry.Run(ctx, "hot_token", func(ctx context.Context) {
+					bots_monitor.RunHotTokenMonitor(ctx, hotTokenBot, accounts.clientFor("hot_token"), cfg.Telegram.FilteredChatID, hotTokenConfig, checkInterval)
+				})
 			}()
 		}
 	}
 
-	if bigSalesBot != nil && bigSalesChatID != "" {
+	destinations := buildSwapDestinations(cfg, bigSalesBot, bigSalesMinBTCAmount)
+
+	if cfg.App.IsCollector() {
+		// Same swap feed as big sales monitor, without alerts
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			bots_monitor.RunBigSalesBuysMonitor(bigSalesBot, client, bigSalesChatID, bigSalesMinBTCAmount, filteredBot, filteredChatID, filteredTokensList, filteredMinBTCAmount)
+			registry.Run(ctx, "swap_collector", func(ctx context.Context) {
+				bots_monitor.RunSwapCollector(ctx, accounts.clientFor("big_sales"), bigSalesMinBTCAmount)
+			})
+		}()
+	} else if bigSalesBot != nil && bigSalesChatID != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			registry.Run(ctx, "big_sales", func(ctx context.Context) {
+				bots_monitor.RunBigSalesBuysMonitor(ctx, bigSalesBot, accounts.clientFor("big_sales"), bigSalesChatID, bigSalesMinBTCAmount, filteredBot, filteredChatID, filteredTokensList, filteredMinBTCAmount, cfg.App.AlertRulesFile, destinations)
+			})
 		}()
 
 		// Start command handler for main chat (big sales chat)
@@ -390,9 +976,9 @@ func startMonitors(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config,
 					zap.String("handlerFilteredChatID", handlerFilteredChatID),
 					zap.String("apiChatID", apiChatID))
 				if apiChatID != "" {
-					bots_monitor.RunCommandHandler(bigSalesBot, handlerFilteredChatID, client, apiChatID)
+					bots_monitor.RunCommandHandler(ctx, bigSalesBot, handlerFilteredChatID, accounts.clientFor("commands"), apiChatID)
 				} else {
-					bots_monitor.RunCommandHandler(bigSalesBot, handlerFilteredChatID, client)
+					bots_monitor.RunCommandHandler(ctx, bigSalesBot, handlerFilteredChatID, accounts.clientFor("commands"))
 				}
 			}()
 		} else if bigSalesChatID == cfg.Telegram.ApiBotChatID && cfg.Telegram.ApiBotChatID != "" {
@@ -400,7 +986,7 @@ func startMonitors(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config,
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				bots_monitor.RunCommandHandler(bigSalesBot, bigSalesChatID, client)
+				bots_monitor.RunCommandHandler(ctx, bigSalesBot, bigSalesChatID, accounts.clientFor("commands"))
 			}()
 		}
 	}
@@ -408,8 +994,189 @@ func startMonitors(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config,
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		bots_monitor.RunHoldersDynamicMonitor()
+		registry.Run(ctx, "holders_dynamic", func(ctx context.Context) {
+			bots_monitor.RunHoldersDynamicMonitor(ctx)
+		})
+	}()
+
+	// tokenIdentifier -> ticker map of holders module from Luminex tokens
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "token_discovery", func(ctx context.Context) {
+			bots_monitor.RunTokenDiscoveryMonitor(ctx, 6*time.Hour)
+		})
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "username_sync", func(ctx context.Context) {
+			bots_monitor.RunUsernameSyncMonitor(ctx, time.Hour)
+		})
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "archive_compression", func(ctx context.Context) {
+			bots_monitor.RunArchiveCompressionMonitor(ctx, cfg.App.ArchiveCompressDays, 24*time.Hour)
+		})
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "events_rotation", func(ctx context.Context) {
+			bots_monitor.RunEventsRotationMonitor(ctx, cfg.App.ArchiveCompressDays, cfg.App.EventsRetentionDays, 24*time.Hour)
+		})
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "btc_price", func(ctx context.Context) {
+			bots_monitor.RunBTCPriceMonitor(ctx, time.Hour)
+		})
+	}()
+
+	// Balances of own wallet for /portfolio
+	portfolioPublicKey := cfg.Flashnet.PublicKey
+	if signer := accounts.defaultClient.GetSigner(); portfolioPublicKey == "" && signer != nil {
+		portfolioPublicKey = signer.PublicKey()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "portfolio", func(ctx context.Context) {
+			bots_monitor.RunPortfolioMonitor(ctx, portfolioPublicKey, time.Hour)
+		})
+	}()
+
+	// Price history for /token card and weekly recap (volatility, max drawdown)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "price_history", func(ctx context.Context) {
+			bots_monitor.RunPriceHistoryMonitor(ctx, time.Hour)
+		})
+	}()
+
+	// Time-series export of stats, flows and holders for dashboards
+	if cfg.App.MetricsSink != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			registry.Run(ctx, "metrics_export", func(ctx context.Context) {
+				bots_monitor.RunMetricsExportMonitor(ctx, bots_monitor.MetricsExportConfig{
+					Sink:     cfg.App.MetricsSink,
+					URL:      cfg.App.MetricsURL,
+					Token:    cfg.App.MetricsToken,
+					Interval: time.Duration(cfg.App.MetricsIntervalMinutes) * time.Minute,
+				})
+			})
+		}()
+	}
+
+	// Pool reserve snapshots, drain alerts go to filtered chat (collector keeps snapshots only)
+	reserveAlertBot := filteredBot
+	if cfg.App.IsCollector() {
+		reserveAlertBot = nil
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "pool_reserves", func(ctx context.Context) {
+			bots_monitor.RunReserveMonitor(ctx, reserveAlertBot, filteredChatID, cfg.Telegram.ReserveDrainPercent, time.Duration(cfg.Telegram.ReserveInterval)*time.Minute)
+		})
+	}()
+
+	// Monitors polling swaps must succeed at least every health_stall_minutes, /healthz returns 503 otherwise
+	healthStallAfter := time.Duration(cfg.App.HealthStallMinutes) * time.Minute
+	if healthStallAfter > 0 {
+		for _, name := range []string{"big_sales", "swap_collector", "hot_token", "watchlist", "price_alerts"} {
+			registry.ExpectHeartbeat(name, healthStallAfter)
+		}
+	}
+
+	if cfg.App.HealthAddr != "" {
+		swapsClient := accounts.clientFor("big_sales")
+		jwtRequired := cfg.Flashnet.PublicKey != "" || swapsClient.GetSigner() != nil
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bots_monitor.RunHealthServer(ctx, cfg.App.HealthAddr, registry, swapsClient, jwtRequired, healthStallAfter)
+		}()
+	}
+
+	// Admin API is not a monitor: it is not restarted and cannot pause itself
+	if cfg.App.AdminAPIAddr != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bots_monitor.RunAdminAPI(ctx, cfg.App.AdminAPIAddr, cfg.App.AdminAPIToken, registry, bigSalesMinBTCAmount, filteredMinBTCAmount)
+		}()
+	}
+
+	// Swaps API only reads swap archive, it serves collector too
+	if cfg.App.SwapsAPIAddr != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bots_monitor.RunSwapsAPI(ctx, cfg.App.SwapsAPIAddr, cfg.App.SwapsAPIToken)
+		}()
+	}
+
+	// Monitors below only notify chats
+	if cfg.App.IsCollector() {
+		return nil
+	}
+
+	// Price alerts (/alert) are sent by the bot that received the command
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "price_alerts", func(ctx context.Context) {
+			bots_monitor.RunPriceAlertMonitor(ctx, []*tgbotapi.BotAPI{bigSalesBot, filteredBot}, time.Minute)
+		})
+	}()
+
+	// Watched wallets (/watch) are notified by the bot that received the command
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "watchlist", func(ctx context.Context) {
+			bots_monitor.RunWatchlistMonitor(ctx, []*tgbotapi.BotAPI{bigSalesBot, filteredBot}, accounts.clientFor("watchlist"), 10*time.Second)
+		})
+	}()
+
+	// Alerts held during quiet hours are sent as one summary after window ends
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "quiet_hours", func(ctx context.Context) {
+			bots_monitor.RunQuietHoursMonitor(ctx, []*tgbotapi.BotAPI{bigSalesBot, filteredBot}, time.Minute)
+		})
+	}()
+
+	// Member count of chats that received alerts (/reach)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		registry.Run(ctx, "reach", func(ctx context.Context) {
+			bots_monitor.RunReachMonitor(ctx, []*tgbotapi.BotAPI{bigSalesBot, filteredBot}, 6*time.Hour)
+		})
 	}()
 
+	// Webhook server is not a monitor, like admin API
+	if cfg.App.WebhookAddr != "" {
+		sources := buildWebhookSources(cfg, bigSalesBot)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bots_monitor.RunWebhookServer(ctx, cfg.App.WebhookAddr, sources)
+		}()
+	}
+
 	return nil
 }
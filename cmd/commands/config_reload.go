@@ -0,0 +1,174 @@
+package commands
+
+// Config reload without restart (config.yaml change or SIGHUP, see config.Watch)
+// Thresholds, swap polling, fast path, languages, quiet hours, roles, holders balance requests,
+// whale share, chart theme and trading limits are applied to running monitors
+// Trading limits are only tightened by reload, trading.enabled and raised limits need restart
+// Other keys (bot tokens, main chats, schedules, addresses) are logged as needing restart
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/tg_charts"
+	"spark-wallet/internal/features/trading"
+	"spark-wallet/internal/infra/config"
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// Default thresholds of chats when config value is 0
+const (
+	defaultBigSalesMinBTC = 0.0025
+	defaultFilteredMinBTC = 0.01
+)
+
+// liveConfigKeys - keys (or key prefixes) applied by config reload without restart
+var liveConfigKeys = []string{
+	"telegram.big_sales_min_btc_amount",
+	"telegram.filtered_min_btc_amount",
+	"telegram.big_sales_min_marketcap_usd",
+	"telegram.big_sales_max_marketcap_usd",
+	"telegram.filtered_min_marketcap_usd",
+	"telegram.filtered_max_marketcap_usd",
+	"telegram.fast_path_multiplier",
+	"telegram.swap_poll_",
+	"telegram.language",
+	"telegram.chat_languages",
+	"telegram.quiet_hours",
+	"telegram.admin_user_ids",
+	"telegram.chat_admins",
+	"telegram.command_levels",
+	"app.holders_balance_",
+	"app.whale_supply_percent",
+	"app.chart_theme_file",
+	"trading.max_",
+}
+
+// isLiveConfigKey reports whether changed key is applied without restart
+func isLiveConfigKey(key string) bool {
+	for _, live := range liveConfigKeys {
+		if strings.HasPrefix(key, live) {
+			return true
+		}
+	}
+	return false
+}
+
+func configuredBigSalesMinBTC(cfg *config.Config) float64 {
+	if cfg.Telegram.BigSalesMinBTCAmount == 0 {
+		return defaultBigSalesMinBTC
+	}
+	return cfg.Telegram.BigSalesMinBTCAmount
+}
+
+func configuredFilteredMinBTC(cfg *config.Config) float64 {
+	if cfg.Telegram.FilteredMinBTCAmount == 0 {
+		return defaultFilteredMinBTC
+	}
+	return cfg.Telegram.FilteredMinBTCAmount
+}
+
+func chatMarketCapRanges(cfg *config.Config) bots_monitor.ChatMarketCapRanges {
+	return bots_monitor.ChatMarketCapRanges{
+		BigSalesMinUSD: cfg.Telegram.BigSalesMinMarketCapUSD,
+		BigSalesMaxUSD: cfg.Telegram.BigSalesMaxMarketCapUSD,
+		FilteredMinUSD: cfg.Telegram.FilteredMinMarketCapUSD,
+		FilteredMaxUSD: cfg.Telegram.FilteredMaxMarketCapUSD,
+	}
+}
+
+// onConfigReload applies reloaded config and logs what changed
+func onConfigReload(changes []config.Change, err error) {
+	if err != nil {
+		logging.LogError("Config reload failed, keeping current config", zap.Error(err))
+		return
+	}
+	if len(changes) == 0 {
+		logging.LogInfo("Config reloaded, no changes")
+		return
+	}
+
+	var restartKeys []string
+	for _, change := range changes {
+		live := isLiveConfigKey(change.Key)
+		if !live {
+			restartKeys = append(restartKeys, change.Key)
+		}
+		logging.LogInfo("Config changed",
+			zap.String("key", change.Key),
+			zap.String("old", change.Old),
+			zap.String("new", change.New),
+			zap.Bool("applied", live))
+	}
+
+	applyReloadedConfig(config.Current())
+
+	if len(restartKeys) > 0 {
+		logging.LogWarn("Config changes need restart to take effect", zap.Strings("keys", restartKeys))
+	}
+	logging.LogSuccess("Config reloaded", zap.Int("changes", len(changes)), zap.Int("needRestart", len(restartKeys)))
+}
+
+// applyReloadedConfig applies live keys of config, invalid parts are logged and previous values stay
+func applyReloadedConfig(cfg *config.Config) {
+	bots_monitor.SetConfigThresholds(bots_monitor.RuntimeThresholds{
+		BigSalesMinBTC: configuredBigSalesMinBTC(cfg),
+		FilteredMinBTC: configuredFilteredMinBTC(cfg),
+	})
+	bots_monitor.SetChatMarketCapRanges(chatMarketCapRanges(cfg))
+	bots_monitor.SetFastPathMultiplier(cfg.Telegram.FastPathMultiplier)
+	bots_monitor.SetSwapPollConfig(bots_monitor.SwapPollConfig{
+		Interval:     time.Duration(cfg.Telegram.SwapPollInterval) * time.Second,
+		Limit:        cfg.Telegram.SwapPollLimit,
+		IdleInterval: time.Duration(cfg.Telegram.SwapPollIdleInterval) * time.Second,
+		IdleAfter:    time.Duration(cfg.Telegram.SwapPollIdleAfter) * time.Second,
+	})
+
+	if err := configureLanguages(cfg); err != nil {
+		logging.LogError("Failed to apply reloaded languages", zap.Error(err))
+	}
+	if err := configureQuietHours(cfg); err != nil {
+		logging.LogError("Failed to apply reloaded quiet hours", zap.Error(err))
+	}
+	if err := configureRoles(cfg); err != nil {
+		logging.LogError("Failed to apply reloaded roles", zap.Error(err))
+	}
+
+	holders.SetWhaleSupplyPercent(cfg.App.WhaleSupplyPercent)
+	holders.SetBalanceFetchConfig(holders.BalanceFetchConfig{
+		Workers: cfg.App.HoldersBalanceWorkers,
+		Rate:    cfg.App.HoldersBalanceRate,
+		Retries: cfg.App.HoldersBalanceRetries,
+	})
+	if chartTheme, err := tg_charts.LoadThemeFile(cfg.App.ChartThemeFile); err == nil {
+		tg_charts.SetThemeFile(*chartTheme)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		logging.LogWarn("Chart theme not reloaded, using previous theme", zap.String("file", cfg.App.ChartThemeFile), zap.Error(err))
+	}
+
+	requested := trading.Limits{
+		MaxSlippageBps:        cfg.Trading.MaxSlippageBps,
+		MaxAmountSats:         cfg.Trading.MaxAmountSats,
+		MaxPriceImpactPercent: cfg.Trading.MaxPriceImpactPercent,
+	}
+	applied := trading.TightenLimits(requested)
+	if cfg.Trading.Enabled != applied.Enabled {
+		logging.LogWarn("trading.enabled is not changed by config reload, restart to apply it",
+			zap.Bool("configured", cfg.Trading.Enabled),
+			zap.Bool("current", applied.Enabled))
+	}
+	if (requested.MaxSlippageBps > 0 && requested.MaxSlippageBps != applied.MaxSlippageBps) ||
+		(requested.MaxAmountSats > 0 && requested.MaxAmountSats != applied.MaxAmountSats) ||
+		requested.MaxPriceImpactPercent != applied.MaxPriceImpactPercent {
+		logging.LogWarn("Trading limits are only tightened by config reload, raised limits need restart",
+			zap.Int("maxSlippageBps", applied.MaxSlippageBps),
+			zap.Int64("maxAmountSats", applied.MaxAmountSats),
+			zap.Float64("maxPriceImpactPercent", applied.MaxPriceImpactPercent))
+	}
+}
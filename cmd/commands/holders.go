@@ -10,11 +10,14 @@ import (
 	"os"
 	"os/signal"
 	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/infra/config"
 	"spark-wallet/internal/infra/log"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
@@ -30,12 +33,24 @@ func runHolders(cmd *cobra.Command, args []string) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
+	configureHoldersTickers()
+
+	if err := runStorageMigrations(); err != nil {
+		log.LogError("Failed to migrate storage", zap.Error(err))
+		return err
+	}
+
 	var wg sync.WaitGroup
+	// Standalone monitor has no operator chat, failures are only logged
+	registry := bots_monitor.NewMonitorRegistry(0, nil)
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		bots_monitor.RunHoldersDynamicMonitor()
+		// Restarted with backoff on panic, stack is logged
+		registry.Run(ctx, "holders_dynamic", func(ctx context.Context) {
+			bots_monitor.RunHoldersDynamicMonitor(ctx)
+		})
 	}()
 
 	log.LogSuccess("Holders monitor is running", zap.String("status", "active"))
@@ -59,3 +74,15 @@ func runHolders(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// configureHoldersTickers sets tracked tickers from HOLDERS_TICKERS (standalone mode, without full config)
+func configureHoldersTickers() {
+	godotenv.Load(".env")
+
+	tickers := bots_monitor.ParseFilteredTokens(os.Getenv("HOLDERS_TICKERS"))
+	if len(tickers) == 0 {
+		tickers = config.DefaultHoldersTickers
+	}
+	holders.SetConfiguredTickers(tickers)
+	log.LogInfo("Holders tracking configured", zap.Strings("tickers", tickers))
+}
@@ -0,0 +1,34 @@
+package commands
+
+// Storage migrations run by every command before monitors start
+// New format changes are added here with the next version number, never renumbered
+
+import (
+	"fmt"
+
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/swap_templates"
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/migrations"
+
+	"go.uber.org/zap"
+)
+
+func storageMigrations() []migrations.Migration {
+	return []migrations.Migration{
+		{Version: 1, Name: "saved_holders: holder objects to address -> amount", Up: holders.MigrateSavedHoldersFormat},
+		{Version: 2, Name: "dynamic_holders: untyped changes to balance changes", Up: holders.MigrateDynamicHoldersFormat},
+		{Version: 3, Name: "templates: SOON buy/sell photos from code to template file", Up: swap_templates.MigrateSOONPhotos},
+	}
+}
+
+func runStorageMigrations() error {
+	if err := migrations.Run(storageMigrations()); err != nil {
+		return fmt.Errorf("failed to migrate storage: %w", err)
+	}
+	// Tracked tickers are kept in memory, migrated storage is read again
+	if err := holders.ReloadTrackedTickers(); err != nil {
+		logging.LogWarn("Failed to load tracked tickers", zap.Error(err))
+	}
+	return nil
+}
@@ -3,9 +3,25 @@ package commands
 // Root command for Cobra CLI
 // Defines the main command structure of the application
 // Registers all subcommands (bot, big-sales, holders, auth)
+// Global --dry-run flag (or DRY_RUN env) writes Telegram messages to file instead of sending them
+// FLASHNET_AUTH_KEY (or FLASHNET_AUTH_KEY_FILE) enables encryption of auth files in data_in for every command
+// SPARK_APP_DATA_DIR and SPARK_APP_OUTPUT_DIR move data_in and data_out for every command (bot also reads them from config)
+// NETWORK other than mainnet moves them to {network} subfolders and labels logs and Telegram messages
 
 import (
+	"fmt"
+	"os"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/infra/buildinfo"
+	"spark-wallet/internal/infra/dryrun"
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/network"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 )
 
 var rootCmd = &cobra.Command{
@@ -13,7 +29,28 @@ var rootCmd = &cobra.Command{
 	Short: "Flashnet Market Monitor - Telegram bot for monitoring Flashnet/Spark AMM activity",
 	Long: `Flashnet Market Monitor is a Go-based Telegram bot for monitoring Flashnet/Spark AMM activity 
 with real-time notifications, chart generation, and comprehensive market analytics.`,
-	Version: "1.0.0",
+	Version: buildinfo.Version,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		godotenv.Load(".env")
+		network.Set(os.Getenv("NETWORK"))
+		paths.Configure(os.Getenv("SPARK_APP_DATA_DIR"), os.Getenv("SPARK_APP_OUTPUT_DIR"))
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun || dryrun.EnabledFromEnv() {
+			dryrun.Enable("")
+			logging.LogWarn("Dry-run mode: Telegram messages are written to file instead of being sent",
+				zap.String("file", dryrun.MessagesFile()))
+		}
+
+		encrypted, err := flashnet.ConfigureAuthEncryptionFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to configure auth files encryption: %w", err)
+		}
+		if encrypted {
+			logging.LogInfo("Auth files (challenge, signature, token) are encrypted at rest")
+		}
+		return nil
+	},
 }
 
 func Execute() error {
@@ -21,6 +58,7 @@ func Execute() error {
 }
 
 func init() {
+	rootCmd.PersistentFlags().Bool("dry-run", false, "Run all monitors but write Telegram messages to "+dryrun.DefaultMessagesFile+" instead of sending them (env: DRY_RUN)")
 	rootCmd.AddCommand(botCmd)
 	rootCmd.AddCommand(bigSalesCmd)
 	rootCmd.AddCommand(holdersCmd)
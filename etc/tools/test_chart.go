@@ -7,11 +7,11 @@ import (
 )
 
 // go run etc/tools/test_chart.go
-// in etc/charts/volume_chart.png
+// in data_out/charts/volume_chart.png
 func main() {
 	fmt.Println("Generating test chart...")
 
-	chartPath, err := tg_charts.GenerateVolumeChart()
+	chartPath, err := tg_charts.GenerateVolumeChart(0)
 	if err != nil {
 		fmt.Printf("Error generating chart: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,110 @@
+package amount
+
+// Decimal amounts from API strings (satoshi, raw token units, balances)
+// Parsed with math/big so large raw amounts (tokens with big supply and 8+ decimals) are scaled without float rounding
+// Conversion to float64 happens once, after scaling, only where value is used for display or comparison
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// BTCDecimals - satoshi per BTC as power of 10
+const BTCDecimals = 8
+
+// Parse parses integer or decimal string ("12345", "0.00012", "1e-3") exactly
+func Parse(value string) (*big.Rat, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("amount is empty")
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	return r, nil
+}
+
+// Scale parses raw amount and divides it by 10^decimals (raw token units -> tokens)
+func Scale(raw string, decimals int) (*big.Rat, error) {
+	r, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if decimals <= 0 {
+		return r, nil
+	}
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return r.Quo(r, new(big.Rat).SetInt(divisor)), nil
+}
+
+// SatsToBTC converts satoshi string to BTC
+func SatsToBTC(sats string) (*big.Rat, error) {
+	return Scale(sats, BTCDecimals)
+}
+
+// ParseFloat parses amount as float64
+func ParseFloat(value string) (float64, error) {
+	r, err := Parse(value)
+	if err != nil {
+		return 0, err
+	}
+	return Float64(r), nil
+}
+
+// ScaleFloat scales raw amount by 10^decimals and returns float64
+func ScaleFloat(raw string, decimals int) (float64, error) {
+	r, err := Scale(raw, decimals)
+	if err != nil {
+		return 0, err
+	}
+	return Float64(r), nil
+}
+
+// SatsToBTCFloat converts satoshi string to BTC as float64
+func SatsToBTCFloat(sats string) (float64, error) {
+	return ScaleFloat(sats, BTCDecimals)
+}
+
+// Float64 returns nearest float64 of amount
+func Float64(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
+
+// FormatFixed formats amount with exactly precision digits after decimal point ("1.50000000")
+func FormatFixed(r *big.Rat, precision int) string {
+	return r.FloatString(precision)
+}
+
+// FormatTrimmed formats amount with up to precision digits after decimal point, without trailing zeros ("1.5")
+func FormatTrimmed(r *big.Rat, precision int) string {
+	formatted := r.FloatString(precision)
+	if strings.Contains(formatted, ".") {
+		formatted = strings.TrimRight(formatted, "0")
+		formatted = strings.TrimRight(formatted, ".")
+	}
+	if formatted == "-0" {
+		formatted = "0"
+	}
+	return formatted
+}
+
+// FormatBTC formats BTC amount with up to 8 decimals without trailing zeros ("0.001")
+func FormatBTC(btc float64) string {
+	r := new(big.Rat)
+	if r.SetFloat64(btc) == nil {
+		return strconv.FormatFloat(btc, 'f', -1, 64)
+	}
+	return FormatTrimmed(r, BTCDecimals)
+}
+
+// FormatSignedBTC formats BTC amount with sign ("+0.01", "-0.5", "0")
+func FormatSignedBTC(btc float64) string {
+	if btc > 0 {
+		return "+" + FormatBTC(btc)
+	}
+	return FormatBTC(btc)
+}
@@ -0,0 +1,251 @@
+package flashnet
+
+// AuthManager - JWT lifecycle of Client as background service
+// Token is renewed (challenge -> native signature -> verify) a margin before expiry,
+// with random jitter so several processes sharing one key do not renew at the same moment
+// Requests keep using the current token while renewal runs (Client.SetJWT/GetJWT are thread-safe)
+// Token rejected by API (ErrUnauthorized) is renewed right away, rate limited renewal waits for Retry-After
+// Rejections of already replaced token (in-flight requests) are ignored, and rejected token is not
+// renewed again within tokenRenewMinInterval of successful renewal (API keeps returning 401)
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultTokenRenewMargin - token is renewed this long before expiry
+	DefaultTokenRenewMargin = 10 * time.Minute
+	// tokenRenewRetryMin - first retry delay after failed renewal, doubled up to tokenRenewRetryMax
+	tokenRenewRetryMin = 30 * time.Second
+	tokenRenewRetryMax = 10 * time.Minute
+	// tokenRenewMinInterval - rejected token is not renewed sooner than this after successful renewal
+	tokenRenewMinInterval = 5 * time.Minute
+	// tokenDefaultLifetime - assumed lifetime if token has no expiration time
+	tokenDefaultLifetime = 24 * time.Hour
+)
+
+// AuthManager keeps JWT of Client valid
+type AuthManager struct {
+	client    *Client
+	dataDir   string
+	publicKey string // used if token file and signer have no public key
+	margin    time.Duration
+
+	mu        sync.Mutex // serializes renewals
+	expiresAt time.Time
+	renewNow  chan struct{} // renewal requested before schedule (token rejected)
+}
+
+// NewAuthManager creates token manager of client
+// dataDir - directory with token.json, challenge.json and signature.json
+// publicKey - identity public key (may be empty: taken from token file or signer)
+// margin - renew token this long before expiry (DefaultTokenRenewMargin if <= 0)
+func NewAuthManager(client *Client, dataDir string, publicKey string, margin time.Duration) *AuthManager {
+	if margin <= 0 {
+		margin = DefaultTokenRenewMargin
+	}
+	return &AuthManager{
+		client:    client,
+		dataDir:   dataDir,
+		publicKey: publicKey,
+		margin:    margin,
+		renewNow:  make(chan struct{}, 1),
+	}
+}
+
+// Token returns current JWT (empty if not authorized)
+func (m *AuthManager) Token() string {
+	return m.client.GetJWT()
+}
+
+// ExpiresAt returns expiry time of current token (zero if unknown)
+func (m *AuthManager) ExpiresAt() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.expiresAt
+}
+
+// EnsureValid uses saved token if it is valid for longer than margin, renews it otherwise
+func (m *AuthManager) EnsureValid(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.loadSavedTokenUnlocked() {
+		return nil
+	}
+	return m.renewUnlocked(ctx)
+}
+
+// Renew gets new token (challenge, signature, verify) and applies it to client
+func (m *AuthManager) Renew(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.renewUnlocked(ctx)
+}
+
+// RequestRenewal asks Run to renew token now (non-blocking, repeated requests are merged)
+func (m *AuthManager) RequestRenewal() {
+	select {
+	case m.renewNow <- struct{}{}:
+	default:
+	}
+}
+
+// tokenRejected requests renewal if rejected token is still current one
+func (m *AuthManager) tokenRejected(token string) {
+	if token != m.client.GetJWT() {
+		// Request was sent before renewal, its token is already replaced
+		return
+	}
+	m.RequestRenewal()
+}
+
+// Run renews token before expiry until ctx is cancelled
+// Failed renewal is retried with backoff (at least Retry-After of rate limited response)
+// While running, 401 responses of client requests trigger renewal
+func (m *AuthManager) Run(ctx context.Context) {
+	LogInfo("Starting Auth Manager...", zap.Duration("renewMargin", m.margin))
+	m.client.SetUnauthorizedHandler(m.tokenRejected)
+	defer m.client.SetUnauthorizedHandler(nil)
+
+	retry := tokenRenewRetryMin
+	failed := false
+	var renewedAt time.Time
+	if err := m.EnsureValid(ctx); err != nil {
+		LogError("Failed to get valid token", zap.Error(err), zap.Duration("retryIn", retry))
+		failed = true
+	}
+
+	for {
+		delay := m.nextRenewDelay()
+		if failed || m.ExpiresAt().IsZero() {
+			// Last renewal failed - retry with backoff (current token is used meanwhile)
+			delay = retry
+		}
+
+		timer := time.NewTimer(delay)
+		for waiting := true; waiting; {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				LogInfo("Auth Manager stopped")
+				return
+			case <-m.renewNow:
+				if failed {
+					// Renewal is already failing, keep backoff instead of hammering auth endpoints
+					continue
+				}
+				if since := time.Since(renewedAt); since < tokenRenewMinInterval {
+					// Fresh token is rejected too - renewing again won't help, wait for schedule
+					LogDebug("Token rejected right after renewal, not renewing again", zap.Duration("sinceRenewal", since))
+					continue
+				}
+				timer.Stop()
+				LogWarn("Token rejected by API, renewing now")
+				waiting = false
+			case <-timer.C:
+				waiting = false
+			}
+		}
+
+		if err := m.Renew(ctx); err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			if failed {
+				if retry *= 2; retry > tokenRenewRetryMax {
+					retry = tokenRenewRetryMax
+				}
+			}
+			failed = true
+			if wait := RetryAfter(err); errors.Is(err, ErrRateLimited) && wait > retry {
+				retry = wait
+			}
+			LogError("Failed to renew token", zap.Error(err), zap.Duration("retryIn", retry))
+			continue
+		}
+		failed = false
+		retry = tokenRenewRetryMin
+		renewedAt = time.Now()
+	}
+}
+
+// nextRenewDelay - time until renewal: expiry - margin - random jitter (up to margin/2)
+func (m *AuthManager) nextRenewDelay() time.Duration {
+	expiresAt := m.ExpiresAt()
+	if expiresAt.IsZero() {
+		return 0
+	}
+	jitter := time.Duration(rand.Int63n(int64(m.margin/2) + 1))
+	delay := time.Until(expiresAt) - m.margin - jitter
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// loadSavedTokenUnlocked applies saved token.json if it is valid for longer than margin
+func (m *AuthManager) loadSavedTokenUnlocked() bool {
+	tokenFile, err := LoadTokenFromFile(m.dataDir)
+	if err != nil || tokenFile.AccessToken == "" {
+		return false
+	}
+	if tokenFile.PublicKey != "" && m.publicKey == "" {
+		m.publicKey = tokenFile.PublicKey
+	}
+
+	expiresAt, err := GetTokenExpirationTime(tokenFile.AccessToken)
+	if err != nil || time.Until(time.Unix(expiresAt, 0)) <= m.margin {
+		return false
+	}
+
+	m.client.SetJWT(tokenFile.AccessToken)
+	m.expiresAt = time.Unix(expiresAt, 0)
+	LogInfo("Using saved JWT token", zap.String("expiresAt", m.expiresAt.Format(time.RFC3339)))
+	return true
+}
+
+func (m *AuthManager) renewUnlocked(ctx context.Context) error {
+	publicKey := m.publicKey
+	if publicKey == "" && m.client.GetSigner() != nil {
+		publicKey = m.client.GetSigner().PublicKey()
+	}
+	if publicKey == "" {
+		return fmt.Errorf("public key not found")
+	}
+
+	LogInfo("Renewing JWT token...")
+
+	if _, err := m.client.GetChallengeAndSave(ctx, m.dataDir, publicKey); err != nil {
+		return fmt.Errorf("failed to get challenge: %w", err)
+	}
+
+	sigFile, err := m.client.SignChallengeAndSave(m.dataDir)
+	if err != nil {
+		return fmt.Errorf("failed to sign challenge: %w", err)
+	}
+
+	if _, err := m.client.VerifySignatureAndSave(ctx, m.dataDir, sigFile.PublicKey, sigFile.Signature); err != nil {
+		if IsAlreadySignedInError(err) && m.loadSavedTokenUnlocked() {
+			return nil
+		}
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+
+	expiresAt, err := GetTokenExpirationTime(m.client.GetJWT())
+	if err != nil {
+		m.expiresAt = time.Now().Add(tokenDefaultLifetime)
+	} else {
+		m.expiresAt = time.Unix(expiresAt, 0)
+	}
+
+	LogSuccess("Token refreshed successfully", zap.String("expiresAt", m.expiresAt.Format(time.RFC3339)))
+	return nil
+}
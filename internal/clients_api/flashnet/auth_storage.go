@@ -0,0 +1,187 @@
+package flashnet
+
+// Optional encryption at rest of auth files (challenge.json, signature.json, token.json)
+// With key configured files are written as AES-256-GCM envelope, file name is authenticated data,
+// so one file can't be substituted with another. Load functions read encrypted and plain files,
+// plain files are encrypted on next save
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"spark-wallet/internal/infra/atomicfile"
+)
+
+const (
+	// AuthKeyEnv - encryption key of auth files (32 bytes, hex or base64)
+	AuthKeyEnv = "FLASHNET_AUTH_KEY"
+	// AuthKeyFileEnv - file with encryption key (Docker/Kubernetes secret), used if AuthKeyEnv is not set
+	AuthKeyFileEnv = "FLASHNET_AUTH_KEY_FILE"
+
+	authEncryptionAlgorithm = "aes-256-gcm"
+)
+
+// encryptedAuthFile - file structure of encrypted auth file
+type encryptedAuthFile struct {
+	Encryption string `json:"encryption"` // aes-256-gcm
+	Nonce      string `json:"nonce"`      // base64
+	Ciphertext string `json:"ciphertext"` // base64, JSON of auth file + GCM tag
+}
+
+var (
+	authCipherMutex sync.RWMutex
+	authCipher      cipher.AEAD // nil - auth files are stored in plain JSON
+)
+
+// SetAuthEncryptionKey enables encryption of auth files with key (32 bytes, hex or base64)
+// Empty key disables encryption
+func SetAuthEncryptionKey(key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		authCipherMutex.Lock()
+		authCipher = nil
+		authCipherMutex.Unlock()
+		return nil
+	}
+
+	keyBytes, err := decodeAuthKey(key)
+	if err != nil {
+		return err
+	}
+
+	block, err := aes.NewCipher(keyBytes)
+	if err != nil {
+		return fmt.Errorf("failed to create auth cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("failed to create auth cipher: %w", err)
+	}
+
+	authCipherMutex.Lock()
+	authCipher = aead
+	authCipherMutex.Unlock()
+	return nil
+}
+
+// ConfigureAuthEncryptionFromEnv sets encryption key from FLASHNET_AUTH_KEY or file in FLASHNET_AUTH_KEY_FILE
+// Returns true if encryption is enabled
+func ConfigureAuthEncryptionFromEnv() (bool, error) {
+	key := os.Getenv(AuthKeyEnv)
+	if key == "" {
+		if keyFile := os.Getenv(AuthKeyFileEnv); keyFile != "" {
+			data, err := os.ReadFile(keyFile)
+			if err != nil {
+				return false, fmt.Errorf("failed to read auth key file: %w", err)
+			}
+			key = string(data)
+		}
+	}
+
+	if err := SetAuthEncryptionKey(key); err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(key) != "", nil
+}
+
+// decodeAuthKey decodes 32-byte key from hex or base64
+func decodeAuthKey(key string) ([]byte, error) {
+	if keyBytes, err := hex.DecodeString(strings.TrimPrefix(key, "0x")); err == nil && len(keyBytes) == 32 {
+		return keyBytes, nil
+	}
+	if keyBytes, err := base64.StdEncoding.DecodeString(key); err == nil && len(keyBytes) == 32 {
+		return keyBytes, nil
+	}
+	return nil, fmt.Errorf("invalid auth encryption key: expected 32 bytes in hex (64 characters) or base64")
+}
+
+func getAuthCipher() cipher.AEAD {
+	authCipherMutex.RLock()
+	defer authCipherMutex.RUnlock()
+	return authCipher
+}
+
+// writeAuthFile writes auth file as JSON, encrypted if key is configured
+func writeAuthFile(filename string, value any) error {
+	jsonData, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(filename), err)
+	}
+
+	if aead := getAuthCipher(); aead != nil {
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		ciphertext := aead.Seal(nil, nonce, jsonData, []byte(filepath.Base(filename)))
+
+		jsonData, err = json.MarshalIndent(encryptedAuthFile{
+			Encryption: authEncryptionAlgorithm,
+			Nonce:      base64.StdEncoding.EncodeToString(nonce),
+			Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal encrypted %s: %w", filepath.Base(filename), err)
+		}
+	}
+
+	// Atomic write: token is read by other processes while it is renewed, accounts may write the same file at once
+	// Owner-only mode: file holds JWT or signature in plain text when no key is set
+	if err := atomicfile.Write(filename, jsonData, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(filename), err)
+	}
+	return nil
+}
+
+// readAuthFile reads auth file into value, decrypts it if it is encrypted
+func readAuthFile(filename string, value any) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	var envelope encryptedAuthFile
+	if err := json.Unmarshal(data, &envelope); err == nil && envelope.Encryption != "" {
+		data, err = decryptAuthFile(filename, &envelope)
+		if err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+func decryptAuthFile(filename string, envelope *encryptedAuthFile) ([]byte, error) {
+	if envelope.Encryption != authEncryptionAlgorithm {
+		return nil, fmt.Errorf("unsupported encryption of %s: %s", filepath.Base(filename), envelope.Encryption)
+	}
+
+	aead := getAuthCipher()
+	if aead == nil {
+		return nil, fmt.Errorf("%s is encrypted: set %s or %s", filepath.Base(filename), AuthKeyEnv, AuthKeyFileEnv)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(envelope.Nonce)
+	if err != nil || len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce in %s", filepath.Base(filename))
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext in %s: %w", filepath.Base(filename), err)
+	}
+
+	data, err := aead.Open(nil, nonce, ciphertext, []byte(filepath.Base(filename)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s (wrong key?): %w", filepath.Base(filename), err)
+	}
+	return data, nil
+}
@@ -0,0 +1,93 @@
+package flashnet
+
+// Typed errors of Flashnet API, so callers branch with errors.Is/errors.As instead of matching error text
+// APIError is returned for every non-2xx response (MakeRequest and auth methods wrap it with %w)
+// Sentinels: ErrUnauthorized (401), ErrRateLimited (429), ErrCloudflareBlocked, ErrAlreadySignedIn (FSAG-4102)
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/infra/cloudflare"
+	"spark-wallet/internal/infra/retry"
+)
+
+// CodeAlreadySignedIn - error code of verify when session of public key is active
+const CodeAlreadySignedIn = "FSAG-4102"
+
+var (
+	// ErrUnauthorized - JWT is missing, expired or rejected (401)
+	ErrUnauthorized = errors.New("flashnet: unauthorized")
+	// ErrRateLimited - too many requests (429), RetryAfter returns pause requested by API
+	ErrRateLimited = errors.New("flashnet: rate limited")
+	// ErrAlreadySignedIn - verify rejected because user already signed in (FSAG-4102)
+	ErrAlreadySignedIn = errors.New("flashnet: already signed in")
+	// ErrCloudflareBlocked - response is Cloudflare challenge page (same as cloudflare.ErrBlocked)
+	ErrCloudflareBlocked = cloudflare.ErrBlocked
+)
+
+// APIError - non-2xx response of Flashnet API
+// Unwraps to retry.HTTPError, so retry.Do can classify it
+type APIError struct {
+	StatusCode int
+	Code       string // Flashnet error code ("FSAG-4102"), empty if body has none
+	Message    string // response body or short description of non-JSON response
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	return &retry.HTTPError{StatusCode: e.StatusCode, Body: []byte(e.Message), RetryAfter: e.RetryAfter}
+}
+
+// Is matches sentinel errors by status and error code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrAlreadySignedIn:
+		return e.Code == CodeAlreadySignedIn || strings.Contains(strings.ToLower(e.Message), "already signed in")
+	}
+	return false
+}
+
+// apiErrorBody - error fields of JSON response (errorCode or code)
+type apiErrorBody struct {
+	ErrorCode string `json:"errorCode"`
+	Code      string `json:"code"`
+}
+
+// parseErrorCode returns Flashnet error code of JSON body (empty if none)
+func parseErrorCode(body []byte) string {
+	var parsed apiErrorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return ""
+	}
+	if parsed.ErrorCode != "" {
+		return parsed.ErrorCode
+	}
+	return parsed.Code
+}
+
+// RetryAfter returns pause requested by API (Retry-After of 429/503), 0 if err has none
+func RetryAfter(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// IsAlreadySignedInError error "already signed in" (FSAG-4102)
+func IsAlreadySignedInError(err error) bool {
+	return errors.Is(err, ErrAlreadySignedIn)
+}
@@ -13,14 +13,17 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"spark-wallet/internal/infra/cloudflare"
+	"spark-wallet/internal/infra/health"
 	"spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/retry"
+	"spark-wallet/internal/infra/transfer"
 
 	"github.com/sony/gobreaker"
 	"go.uber.org/zap"
@@ -34,6 +37,21 @@ const (
 	AMMTestnetAPI = "https://api.makebitcoingreatagain.dev/v1"
 )
 
+// DefaultRetry - retries of failed requests: 429 and gateway errors (502, 503) are retried with
+// exponential backoff and full jitter, Retry-After of response is honored up to MaxDelay
+var DefaultRetry = retry.Options{
+	MaxRetries:        3,
+	BaseDelay:         500 * time.Millisecond,
+	MaxDelay:          30 * time.Second,
+	Backoff:           2.0,
+	RetryableStatuses: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable},
+}
+
+// RequestOptions - per-request settings of MakeRequestWithOptions
+type RequestOptions struct {
+	MaxRetries *int // retry budget of request (nil - client setting, 0 - no retries)
+}
+
 func GenerateRequestID() string { return log.GenerateRequestID() }
 
 func LogRequest(requestID, method, endpoint string, fields ...zap.Field) {
@@ -53,23 +71,39 @@ func LogSuccess(message string, fields ...zap.Field) { log.LogSuccess(message, f
 // Client is a struct containing API client data
 // Stores everything needed for API work: base URL, HTTP client and token
 type Client struct {
-	baseURL         string                    // Base API URL (mainnet or testnet)
+	baseURL         string                    // Base API URL (mainnet, testnet or FLASHNET_API_URL)
 	httpClient      *http.Client              // HTTP client for requests
 	jwtToken        string                    // JWT token for authorized requests (can be empty if not authorized)
+	jwtMutex        sync.RWMutex              // guards jwtToken (renewed by AuthManager while requests run)
 	rateLimiter     *rate.Limiter             // Rate limiter for request frequency limiting
 	circuitBreaker  *gobreaker.CircuitBreaker // Circuit breaker for error avalanche protection
 	maxResponseSize int64                     // Maximum response size in bytes
+	signer          *Signer                   // Challenge signer (nil if private key not configured)
+	cloudflare      *cloudflare.Guard         // Cloudflare block detection, cool-down and header rotation
+	retry           retry.Options             // Retries of 429/502/503 responses (DefaultRetry, flashnet.max_retries)
+	onUnauthorized  func(token string)        // called on 401 of authorized request (AuthManager renews token), guarded by jwtMutex
+}
+
+// NetworkAPI returns default API URL of network (false for networks without public API, e.g. regtest or dev)
+// Empty network is mainnet
+func NetworkAPI(network string) (string, bool) {
+	switch network {
+	case "", "mainnet":
+		return AMMMainnetAPI, true
+	case "testnet":
+		return AMMTestnetAPI, true
+	}
+	return "", false
 }
 
 // NewAMMClient is a constructor function
 // Creates and returns new Client object ready to use
-// network - network name string ("mainnet" or "testnet")
+// network - network name string ("mainnet" or "testnet"), other networks need SetBaseURL
 func NewAMMClient(network string) *Client {
 	// Default to mainnet (main network)
-	baseURL := AMMMainnetAPI
-	// If testnet specified, use test URL
-	if network == "testnet" {
-		baseURL = AMMTestnetAPI
+	baseURL, ok := NetworkAPI(network)
+	if !ok {
+		baseURL = AMMMainnetAPI
 	}
 
 	// Create rate limiter: 10 requests per second, burst up to 20
@@ -91,6 +125,8 @@ func NewAMMClient(network string) *Client {
 		rateLimiter:     rateLimiter,
 		circuitBreaker:  circuitBreaker,
 		maxResponseSize: 10 * 1024 * 1024, // 10MB default
+		cloudflare:      cloudflare.NewGuard("FlashnetAPI"),
+		retry:           DefaultRetry,
 		httpClient: &http.Client{
 			// Timeout - maximum wait time for server response
 			// 30 * time.Second means 30 seconds
@@ -106,6 +142,34 @@ func NewAMMClient(network string) *Client {
 	}
 }
 
+// SetBaseURL overrides API URL of network (FLASHNET_API_URL: regtest, dev or self-hosted endpoints)
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SetRetry sets retry of requests (flashnet.max_retries and retry_* settings), 0 maxRetries disables retries
+// Zero baseDelay, maxDelay or backoff keep DefaultRetry values
+func (c *Client) SetRetry(maxRetries int, baseDelay time.Duration, maxDelay time.Duration, backoff float64) {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	c.retry.MaxRetries = maxRetries
+	if baseDelay > 0 {
+		c.retry.BaseDelay = baseDelay
+	}
+	if maxDelay > 0 {
+		c.retry.MaxDelay = maxDelay
+	}
+	if backoff > 0 {
+		c.retry.Backoff = backoff
+	}
+}
+
+// BaseURL returns API URL requests are sent to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
 // SetJWT is a method of Client struct
 // Methods in Go are declared as functions with receiver
 // (c *Client) Client
@@ -114,14 +178,39 @@ func NewAMMClient(network string) *Client {
 func (c *Client) SetJWT(token string) {
 	// Save JWT token in
 	// token in Authorization
+	c.jwtMutex.Lock()
+	defer c.jwtMutex.Unlock()
 	c.jwtToken = token
 }
 
 // GetJWT JWT token
 func (c *Client) GetJWT() string {
+	c.jwtMutex.RLock()
+	defer c.jwtMutex.RUnlock()
 	return c.jwtToken
 }
 
+// SetUnauthorizedHandler sets function called when API rejects JWT of request (401), nil removes it
+// Handler gets JWT the rejected request was sent with, runs in request goroutine and must not block
+func (c *Client) SetUnauthorizedHandler(handler func(token string)) {
+	c.jwtMutex.Lock()
+	defer c.jwtMutex.Unlock()
+	c.onUnauthorized = handler
+}
+
+// notifyUnauthorized calls unauthorized handler if request was sent with JWT (auth endpoints excluded)
+func (c *Client) notifyUnauthorized(endpoint string, token string) {
+	if token == "" || strings.HasPrefix(endpoint, "/auth/") {
+		return
+	}
+	c.jwtMutex.RLock()
+	handler := c.onUnauthorized
+	c.jwtMutex.RUnlock()
+	if handler != nil {
+		handler(token)
+	}
+}
+
 // MakeRequest HTTP API rate limiting and circuit breaker
 // ctx - for and
 // method - HTTP (GET, POST, PUT, DELETE and ..)
@@ -129,6 +218,13 @@ func (c *Client) GetJWT() string {
 // body - nil for GET
 // []byte (data and error (error, if
 func (c *Client) MakeRequest(ctx context.Context, method, endpoint string, body interface{}) ([]byte, error) {
+	return c.MakeRequestWithOptions(ctx, method, endpoint, body, RequestOptions{})
+}
+
+// MakeRequestWithOptions is MakeRequest with per-request options (retry budget)
+// 429, 502 and 503 responses are retried inside circuit breaker, so one request with retries
+// counts as one failure and short API hiccups don't trip the breaker
+func (c *Client) MakeRequestWithOptions(ctx context.Context, method, endpoint string, body interface{}, options RequestOptions) ([]byte, error) {
 	// Generate request ID for
 	requestID := GenerateRequestID()
 	startTime := time.Now()
@@ -138,11 +234,42 @@ func (c *Client) MakeRequest(ctx context.Context, method, endpoint string, body
 		return nil, fmt.Errorf("context cancelled: %w", ctx.Err())
 	}
 
-	// rate limiter 429
-	if c.rateLimiter != nil {
-		if err := c.rateLimiter.Wait(ctx); err != nil {
-			return nil, fmt.Errorf("rate limiter wait failed: %w", err)
-		}
+	// Wait for cool-down after Cloudflare block
+	if err := c.cloudflare.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("cloudflare cooldown wait failed: %w", err)
+	}
+
+	retryOptions := c.retry
+	if options.MaxRetries != nil {
+		retryOptions.MaxRetries = *options.MaxRetries
+	}
+	retryOptions.OnRetry = func(attempt int, err error, sleep time.Duration) {
+		LogWarn("Retrying Flashnet request",
+			zap.String("request_id", requestID),
+			zap.String("endpoint", endpoint),
+			zap.Int("attempt", attempt+1),
+			zap.Int("maxRetries", retryOptions.MaxRetries),
+			zap.Duration("sleep", sleep),
+			zap.Error(err))
+	}
+
+	requestWithRetry := func() ([]byte, error) {
+		var respBody []byte
+		err := retry.Do(ctx, retryOptions, func() error {
+			// rate limiter 429, each attempt takes a token
+			if c.rateLimiter != nil {
+				if err := c.rateLimiter.Wait(ctx); err != nil {
+					return fmt.Errorf("rate limiter wait failed: %w", err)
+				}
+			}
+			body, err := c.makeRequestWithContext(ctx, requestID, method, endpoint, body, startTime)
+			if err != nil {
+				return err
+			}
+			respBody = body
+			return nil
+		})
+		return respBody, err
 	}
 
 	// circuit breaker
@@ -151,7 +278,7 @@ func (c *Client) MakeRequest(ctx context.Context, method, endpoint string, body
 
 	if c.circuitBreaker != nil {
 		_, err = c.circuitBreaker.Execute(func() (interface{}, error) {
-			body, err := c.makeRequestWithContext(ctx, requestID, method, endpoint, body, startTime)
+			body, err := requestWithRetry()
 			if err != nil {
 				return nil, err
 			}
@@ -163,7 +290,7 @@ func (c *Client) MakeRequest(ctx context.Context, method, endpoint string, body
 			return nil, err
 		}
 	} else {
-		respBody, err = c.makeRequestWithContext(ctx, requestID, method, endpoint, body, startTime)
+		respBody, err = requestWithRetry()
 		if err != nil {
 			return nil, err
 		}
@@ -197,7 +324,10 @@ func (c *Client) makeRequestWithContext(ctx context.Context, requestID, method,
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	setNormalizedHeaders(req, c.jwtToken)
+	token := c.GetJWT()
+	setNormalizedHeaders(req, token)
+	c.cloudflare.SetHeaders(req)
+	transfer.AcceptGzip(req)
 
 	LogRequest(requestID, method, endpoint, zap.String("url", req.URL.String()))
 
@@ -210,29 +340,45 @@ func (c *Client) makeRequestWithContext(ctx context.Context, requestID, method,
 	}
 	defer resp.Body.Close()
 
-	limitedReader := io.LimitReader(resp.Body, c.maxResponseSize)
-
-	respBody, err := io.ReadAll(limitedReader)
+	// Gzip response is decompressed, size limit applies to decompressed body
+	respBody, wireBytes, err := transfer.ReadBody("FlashnetAPI", resp, c.maxResponseSize)
 	if err != nil {
 		duration := time.Since(startTime).Milliseconds()
 		LogResponse(requestID, resp.StatusCode, duration, zap.String("endpoint", endpoint), zap.Error(err))
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
 	duration := time.Since(startTime).Milliseconds()
 
+	// Cloudflare challenge may come with any status (including 200)
+	if err := c.cloudflare.Observe(resp.StatusCode, resp.Header, respBody); err != nil {
+		LogResponse(requestID, resp.StatusCode, duration, zap.String("endpoint", endpoint), zap.String("error", "blocked by Cloudflare"))
+		return nil, fmt.Errorf("API error (%d): %w", resp.StatusCode, err)
+	}
+
 	// Check
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       parseErrorCode(respBody),
+			Message:    string(respBody),
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			c.notifyUnauthorized(endpoint, token)
+		}
 		contentType := resp.Header.Get("Content-Type")
 		if contentType != "" && !strings.Contains(contentType, "application/json") {
-			LogResponse(requestID, resp.StatusCode, duration, zap.String("endpoint", endpoint), zap.String("error", "blocked by Cloudflare or invalid response"))
-			return nil, fmt.Errorf("API error (%d): blocked by Cloudflare or invalid response", resp.StatusCode)
+			LogResponse(requestID, resp.StatusCode, duration, zap.String("endpoint", endpoint), zap.String("error", "invalid response"))
+			apiErr.Message = fmt.Sprintf("invalid response (%s)", contentType)
+			return nil, apiErr
 		}
 		LogResponse(requestID, resp.StatusCode, duration, zap.String("endpoint", endpoint), zap.String("error", "API error response received"))
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		return nil, apiErr
 	}
 
-	LogResponse(requestID, resp.StatusCode, duration, zap.String("endpoint", endpoint), zap.String("status", "success"))
+	LogResponse(requestID, resp.StatusCode, duration, zap.String("endpoint", endpoint), zap.String("status", "success"),
+		zap.Int64("wireBytes", wireBytes), zap.Int("bodyBytes", len(respBody)))
 
 	return respBody, nil
 }
@@ -291,6 +437,7 @@ func (c *Client) GetSwaps(ctx context.Context, options GetSwapsOptions) (*SwapsR
 	if err := json.Unmarshal(respBody, &swapsResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal swaps response: %w", err)
 	}
+	health.MarkSwapsFetched()
 
 	return &swapsResp, nil
 }
@@ -338,7 +485,7 @@ func (c *Client) GetUserSwaps(ctx context.Context, userPublicKey string, options
 		endpoint += "?" + params.Encode()
 	}
 
-	respBody, err := c.MakeRequest(ctx, "GET", endpoint, nil)
+	respBody, err := c.MakeRequestWithOptions(ctx, "GET", endpoint, nil, RequestOptions{MaxRetries: options.MaxRetries})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user swaps: %w", err)
 	}
@@ -428,91 +575,21 @@ func SaveChallengeToFile(dataDir string, challengeResp *ChallengeResponse, publi
 		RequestID:       challengeResp.RequestID,
 	}
 
-	jsonData, err := json.MarshalIndent(challengeFile, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal challenge file: %w", err)
-	}
-
 	filename := filepath.Join(dataDir, "challenge.json")
-	err = os.WriteFile(filename, jsonData, 0644)
-	if err != nil {
+	if err := writeAuthFile(filename, challengeFile); err != nil {
 		return "", fmt.Errorf("failed to save challenge file: %w", err)
 	}
 
 	return filename, nil
 }
 
-// updateSignChallengeFile challengeString in sign-challenge.mjs
-func updateSignChallengeFile(dataDir string, challengeString string) {
-	// sign-challenge.mjs
-	// dataDir "data_in" or "cmd/bot/data", use
-	signChallengePaths := []string{
-		filepath.Join("spark-cli", "sign-challenge.mjs"), // If start from
-		filepath.Join(".", "spark-cli", "sign-challenge.mjs"),
-		filepath.Join(dataDir, "..", "..", "spark-cli", "sign-challenge.mjs"), // If dataDir = cmd/bot/data
-		filepath.Join(dataDir, "..", "spark-cli", "sign-challenge.mjs"),       // If dataDir = data_in
-	}
-
-	var signChallengePath string
-	for _, path := range signChallengePaths {
-		if _, err := os.Stat(path); err == nil {
-			signChallengePath = path
-			break
-		}
-	}
-
-	if signChallengePath == "" {
-		LogDebug("Could not find sign-challenge.mjs", zap.Strings("tried_paths", signChallengePaths))
-		return
-	}
-
-	data, err := os.ReadFile(signChallengePath)
-	if err != nil {
-		LogDebug("Could not read sign-challenge.mjs", zap.String("path", signChallengePath), zap.Error(err))
-		return
-	}
-
-	fileContent := string(data)
-
-	// const challengeString = process.argv[2] || "FLASHNET_AUTH_CHALLENGE_V1:...";
-	pattern1 := regexp.MustCompile(`(const challengeString = process\.argv\[2\] \|\| )"[^"]*"`)
-	if pattern1.MatchString(fileContent) {
-		newContent := pattern1.ReplaceAllString(fileContent, fmt.Sprintf(`$1"%s"`, challengeString))
-		if err := os.WriteFile(signChallengePath, []byte(newContent), 0644); err != nil {
-			LogWarn("Failed to update sign-challenge.mjs", zap.Error(err))
-			return
-		}
-		LogInfo("Updated challengeString in sign-challenge.mjs", zap.String("file", signChallengePath))
-		return
-	}
-
-	// If process.argv[2])
-	pattern2 := regexp.MustCompile(`(const challengeString = )"[^"]*"`)
-	if pattern2.MatchString(fileContent) {
-		newContent := pattern2.ReplaceAllString(fileContent, fmt.Sprintf(`$1"%s"`, challengeString))
-		if err := os.WriteFile(signChallengePath, []byte(newContent), 0644); err != nil {
-			LogWarn("Failed to update sign-challenge.mjs", zap.Error(err))
-			return
-		}
-		LogInfo("Updated challengeString in sign-challenge.mjs", zap.String("file", signChallengePath))
-		return
-	}
-
-	LogWarn("Could not find challengeString pattern in sign-challenge.mjs")
-}
-
 // publicKey and requestId from challenge.json if
 func LoadSignatureFromFile(dataDir string) (*SignatureFile, error) {
 	filename := filepath.Join(dataDir, "signature.json")
 
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read signature file: %w", err)
-	}
-
 	var sigFile SignatureFile
-	if err := json.Unmarshal(data, &sigFile); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal signature file: %w", err)
+	if err := readAuthFile(filename, &sigFile); err != nil {
+		return nil, fmt.Errorf("failed to read signature file: %w", err)
 	}
 
 	// publicKey and requestId from challenge.json if
@@ -521,8 +598,7 @@ func LoadSignatureFromFile(dataDir string) (*SignatureFile, error) {
 		// update publicKey and requestId from challenge.json
 		sigFile.PublicKey = challengeFile.PublicKey
 		sigFile.RequestID = challengeFile.RequestID
-		jsonData, _ := json.MarshalIndent(sigFile, "", "  ")
-		os.WriteFile(filename, jsonData, 0644)
+		writeAuthFile(filename, sigFile)
 	}
 
 	return &sigFile, nil
@@ -532,14 +608,9 @@ func LoadSignatureFromFile(dataDir string) (*SignatureFile, error) {
 func LoadChallengeFromFile(dataDir string) (*ChallengeFile, error) {
 	filename := filepath.Join(dataDir, "challenge.json")
 
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read challenge file: %w", err)
-	}
-
 	var challengeFile ChallengeFile
-	if err := json.Unmarshal(data, &challengeFile); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal challenge file: %w", err)
+	if err := readAuthFile(filename, &challengeFile); err != nil {
+		return nil, fmt.Errorf("failed to read challenge file: %w", err)
 	}
 
 	return &challengeFile, nil
@@ -553,32 +624,27 @@ func SaveTokenToFile(dataDir string, accessToken string, publicKey string, expir
 		PublicKey:   publicKey,
 	}
 
-	jsonData, err := json.MarshalIndent(tokenFile, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal token file: %w", err)
-	}
-
 	filename := filepath.Join(dataDir, "token.json")
-	err = os.WriteFile(filename, jsonData, 0644)
-	if err != nil {
+	if err := writeAuthFile(filename, tokenFile); err != nil {
 		return "", fmt.Errorf("failed to save token file: %w", err)
 	}
 
 	return filename, nil
 }
 
+// AccountDataDir - directory with challenge, signature and token files of identity (dataDir/{publicKey})
+// Used by extra accounts, default identity keeps its files in dataDir itself
+func AccountDataDir(dataDir string, publicKey string) string {
+	return filepath.Join(dataDir, strings.ToLower(publicKey))
+}
+
 // LoadTokenFromFile token from file
 func LoadTokenFromFile(dataDir string) (*TokenFile, error) {
 	filename := filepath.Join(dataDir, "token.json")
 
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read token file: %w", err)
-	}
-
 	var tokenFile TokenFile
-	if err := json.Unmarshal(data, &tokenFile); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal token file: %w", err)
+	if err := readAuthFile(filename, &tokenFile); err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
 	}
 
 	return &tokenFile, nil
@@ -653,22 +719,14 @@ func (c *Client) GetChallengeAndSave(ctx context.Context, dataDir string, public
 	}
 
 	signatureFilename := filepath.Join(dataDir, "signature.json")
-	if data, err := os.ReadFile(signatureFilename); err == nil {
-		var existingSigFile SignatureFile
-		if json.Unmarshal(data, &existingSigFile) == nil && existingSigFile.Signature != "" {
-			// Save if
-			sigFile.Signature = existingSigFile.Signature
-		}
+	var existingSigFile SignatureFile
+	if readAuthFile(signatureFilename, &existingSigFile) == nil && existingSigFile.Signature != "" {
+		// Save if
+		sigFile.Signature = existingSigFile.Signature
 	}
 
 	// Save signature.json
-	jsonData, err := json.MarshalIndent(sigFile, "", "  ")
-	if err == nil {
-		os.WriteFile(signatureFilename, jsonData, 0644)
-	}
-
-	// update challengeString in sign-challenge.mjs
-	updateSignChallengeFile(dataDir, challengeResp.ChallengeString)
+	writeAuthFile(signatureFilename, sigFile)
 
 	LogSuccess("Challenge received and saved", zap.String("file", filename), zap.Int64("duration_ms", duration))
 
@@ -759,14 +817,3 @@ func (c *Client) VerifySignature(ctx context.Context, publicKey, signature strin
 
 	return &verifyResp, nil
 }
-
-// IsAlreadySignedInError error "already signed in"
-func IsAlreadySignedInError(err error) bool {
-	if err == nil {
-		return false
-	}
-	errStr := err.Error()
-	return strings.Contains(errStr, "already signed in") ||
-		strings.Contains(errStr, "FSAG-4102") ||
-		strings.Contains(errStr, "User already signed in")
-}
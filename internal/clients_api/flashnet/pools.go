@@ -0,0 +1,99 @@
+package flashnet
+
+// Pool endpoints of Flashnet AMM API: list of pools, pool details and reserves
+// Source of pool composition (assets, reserves, curve) without Luminex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GetPools returns pools matching options (GET /pools)
+func (c *Client) GetPools(ctx context.Context, options GetPoolsOptions) (*PoolsResponse, error) {
+	params := url.Values{}
+
+	if options.AssetAAddress != "" {
+		params.Set("assetAAddress", options.AssetAAddress)
+	}
+	if options.AssetBAddress != "" {
+		params.Set("assetBAddress", options.AssetBAddress)
+	}
+	if len(options.HostNames) > 0 {
+		params.Set("hostNames", strings.Join(options.HostNames, ","))
+	}
+	if len(options.CurveTypes) > 0 {
+		params.Set("curveTypes", strings.Join(options.CurveTypes, ","))
+	}
+	if options.MinVolume24h != "" {
+		params.Set("minVolume24h", options.MinVolume24h)
+	}
+	if options.MinTvl != "" {
+		params.Set("minTvl", options.MinTvl)
+	}
+	if options.AfterUpdatedAt != "" {
+		params.Set("afterUpdatedAt", options.AfterUpdatedAt)
+	}
+	if options.Sort != "" {
+		params.Set("sort", options.Sort)
+	}
+	if options.Limit > 0 {
+		params.Set("limit", strconv.Itoa(options.Limit))
+	}
+	if options.Offset > 0 {
+		params.Set("offset", strconv.Itoa(options.Offset))
+	}
+
+	endpoint := "/pools"
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	respBody, err := c.MakeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pools: %w", err)
+	}
+
+	var poolsResp PoolsResponse
+	if err := json.Unmarshal(respBody, &poolsResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pools response: %w", err)
+	}
+
+	return &poolsResp, nil
+}
+
+// GetPool returns pool by LP public key (GET /pools/{lpPublicKey})
+func (c *Client) GetPool(ctx context.Context, lpPublicKey string) (*Pool, error) {
+	if lpPublicKey == "" {
+		return nil, fmt.Errorf("lpPublicKey is required")
+	}
+
+	respBody, err := c.MakeRequest(ctx, "GET", "/pools/"+url.PathEscape(lpPublicKey), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool: %w", err)
+	}
+
+	var pool Pool
+	if err := json.Unmarshal(respBody, &pool); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pool response: %w", err)
+	}
+	if pool.LpPublicKey == "" {
+		pool.LpPublicKey = lpPublicKey
+	}
+
+	return &pool, nil
+}
+
+// GetPoolReserves returns current reserves of pool with token and BTC sides resolved
+func (c *Client) GetPoolReserves(ctx context.Context, lpPublicKey string) (*PoolReserves, error) {
+	pool, err := c.GetPool(ctx, lpPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	reserves := pool.Reserves()
+	return &reserves, nil
+}
@@ -0,0 +1,101 @@
+package flashnet
+
+// Pool data of Flashnet AMM API (GET /pools, GET /pools/{lpPublicKey})
+// Amounts, prices and reserves are strings, as in API (raw token units, BTC side in sats)
+
+// Pool curve types
+const (
+	CurveTypeConstantProduct = "CONSTANT_PRODUCT"
+	CurveTypeSingleSided     = "SINGLE_SIDED"
+)
+
+// Pool sort orders for GetPoolsOptions.Sort
+const (
+	PoolSortCreatedAtDesc   = "CREATED_AT_DESC"
+	PoolSortCreatedAtAsc    = "CREATED_AT_ASC"
+	PoolSortTVLDesc         = "TVL_DESC"
+	PoolSortTVLAsc          = "TVL_ASC"
+	PoolSortVolume24hDesc   = "VOLUME24H_DESC"
+	PoolSortVolume24hAsc    = "VOLUME24H_ASC"
+	PoolSortPriceChangeDesc = "PRICE_CHANGE_24H_DESC"
+)
+
+// Pool - AMM pool
+type Pool struct {
+	LpPublicKey               string `json:"lpPublicKey"` // pool identifier (poolLpPublicKey of swaps)
+	CurveType                 string `json:"curveType"`   // CONSTANT_PRODUCT or SINGLE_SIDED
+	HostName                  string `json:"hostName"`
+	LpFeeBps                  int    `json:"lpFeeBps"`
+	HostFeeBps                int    `json:"hostFeeBps"`
+	AssetAAddress             string `json:"assetAAddress"`
+	AssetBAddress             string `json:"assetBAddress"`
+	AssetAReserve             string `json:"assetAReserve"`
+	AssetBReserve             string `json:"assetBReserve"`
+	VirtualReserveA           string `json:"virtualReserveA,omitempty"` // single-sided pools
+	VirtualReserveB           string `json:"virtualReserveB,omitempty"` // single-sided pools
+	InitialReserveA           string `json:"initialReserveA,omitempty"` // single-sided pools
+	GraduationThresholdAmount string `json:"graduationThresholdAmount,omitempty"`
+	BondingProgressPercent    string `json:"bondingProgressPercent,omitempty"`
+	CurrentPriceAInB          string `json:"currentPriceAInB"`
+	TvlAssetB                 string `json:"tvlAssetB"`
+	Volume24hAssetB           string `json:"volume24hAssetB"`
+	PriceChangePercent24h     string `json:"priceChangePercent24h"`
+	CreatedAt                 string `json:"createdAt"`
+	UpdatedAt                 string `json:"updatedAt"`
+}
+
+// PoolsResponse - response of GET /pools
+type PoolsResponse struct {
+	Pools      []Pool `json:"pools"`
+	TotalCount int    `json:"totalCount"`
+}
+
+// GetPoolsOptions - filters and paging of GetPools, empty values are not sent (API defaults)
+type GetPoolsOptions struct {
+	AssetAAddress  string   // pools with token as asset A
+	AssetBAddress  string   // pools with asset B (NativeTokenAddress for BTC pools)
+	HostNames      []string // pools created by hosts
+	CurveTypes     []string // CurveTypeConstantProduct, CurveTypeSingleSided
+	MinVolume24h   string   // minimum 24h volume in asset B
+	MinTvl         string   // minimum TVL in asset B
+	AfterUpdatedAt string   // pools updated after time in RFC3339
+	Sort           string   // PoolSort* constant
+	Limit          int      // count pools (by default: API default)
+	Offset         int      // count pools to skip
+}
+
+// PoolReserves - reserves of pool with token and BTC sides resolved
+type PoolReserves struct {
+	LpPublicKey     string
+	TokenAddress    string // non-BTC asset (asset A for token-to-token pools)
+	TokenReserve    string // raw units of token
+	BTCReserve      string // sats, empty for token-to-token pools
+	IsBTCPool       bool   // one of assets is BTC
+	VirtualReserveA string // single-sided pools, empty otherwise
+	VirtualReserveB string
+	UpdatedAt       string
+}
+
+// Reserves resolves token and BTC sides of pool
+func (p *Pool) Reserves() PoolReserves {
+	reserves := PoolReserves{
+		LpPublicKey:     p.LpPublicKey,
+		TokenAddress:    p.AssetAAddress,
+		TokenReserve:    p.AssetAReserve,
+		VirtualReserveA: p.VirtualReserveA,
+		VirtualReserveB: p.VirtualReserveB,
+		UpdatedAt:       p.UpdatedAt,
+	}
+
+	switch NativeTokenAddress {
+	case p.AssetBAddress:
+		reserves.BTCReserve = p.AssetBReserve
+		reserves.IsBTCPool = true
+	case p.AssetAAddress:
+		reserves.TokenAddress = p.AssetBAddress
+		reserves.TokenReserve = p.AssetBReserve
+		reserves.BTCReserve = p.AssetAReserve
+		reserves.IsBTCPool = true
+	}
+	return reserves
+}
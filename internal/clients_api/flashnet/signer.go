@@ -0,0 +1,135 @@
+package flashnet
+
+// Native signing of Flashnet auth challenge (secp256k1 ECDSA)
+// sha256(challengeString) is signed with wallet identity key, signature is DER hex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"go.uber.org/zap"
+)
+
+// Signer - signs challenges with wallet identity private key
+type Signer struct {
+	privateKey *secp256k1.PrivateKey
+}
+
+// keystoreFile - keystore file structure ({"privateKey": "hex"})
+type keystoreFile struct {
+	PrivateKey string `json:"privateKey"`
+}
+
+// NewSigner creates signer from hex-encoded 32-byte private key
+func NewSigner(privateKeyHex string) (*Signer, error) {
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private key: %w", err)
+	}
+	if len(keyBytes) != 32 {
+		return nil, fmt.Errorf("invalid private key length: expected 32 bytes, got %d", len(keyBytes))
+	}
+
+	return &Signer{privateKey: secp256k1.PrivKeyFromBytes(keyBytes)}, nil
+}
+
+// LoadSigner creates signer from private key or keystore file
+// privateKeyHex has priority, keystorePath contains either raw hex or {"privateKey": "hex"}
+func LoadSigner(privateKeyHex string, keystorePath string) (*Signer, error) {
+	if strings.TrimSpace(privateKeyHex) != "" {
+		return NewSigner(privateKeyHex)
+	}
+
+	if keystorePath == "" {
+		return nil, fmt.Errorf("private key is not configured: set PRIVATE_KEY or FLASHNET_KEYSTORE")
+	}
+
+	data, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	content := strings.TrimSpace(string(data))
+	if strings.HasPrefix(content, "{") {
+		var keystore keystoreFile
+		if err := json.Unmarshal([]byte(content), &keystore); err != nil {
+			return nil, fmt.Errorf("failed to parse keystore JSON: %w", err)
+		}
+		content = keystore.PrivateKey
+	}
+
+	return NewSigner(content)
+}
+
+// PublicKey returns compressed public key in hex (33 bytes)
+func (s *Signer) PublicKey() string {
+	return hex.EncodeToString(s.privateKey.PubKey().SerializeCompressed())
+}
+
+// SignChallenge signs sha256(challengeString) and returns DER signature in hex
+// Signature is deterministic (RFC6979) with low S
+func (s *Signer) SignChallenge(challengeString string) string {
+	return s.SignMessage([]byte(challengeString))
+}
+
+// SignMessage signs sha256(message) and returns DER signature in hex (swap intents)
+func (s *Signer) SignMessage(message []byte) string {
+	hash := sha256.Sum256(message)
+	signature := ecdsa.Sign(s.privateKey, hash[:])
+	return hex.EncodeToString(signature.Serialize())
+}
+
+// SetSigner sets signer used for automatic token refresh
+func (c *Client) SetSigner(signer *Signer) {
+	c.signer = signer
+}
+
+// GetSigner returns signer (nil if private key not configured)
+func (c *Client) GetSigner() *Signer {
+	return c.signer
+}
+
+// SignChallengeAndSave signs challenge from challenge.json and saves signature.json
+// signature.json and error
+func (c *Client) SignChallengeAndSave(dataDir string) (*SignatureFile, error) {
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is not configured: set PRIVATE_KEY or FLASHNET_KEYSTORE")
+	}
+
+	challengeFile, err := LoadChallengeFromFile(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if challengeFile.ChallengeString == "" {
+		return nil, fmt.Errorf("challenge string is empty in challenge.json")
+	}
+
+	publicKey := c.signer.PublicKey()
+	if challengeFile.PublicKey != "" && !strings.EqualFold(challengeFile.PublicKey, publicKey) {
+		return nil, fmt.Errorf("public key mismatch: expected %s, got %s", challengeFile.PublicKey, publicKey)
+	}
+
+	sigFile := SignatureFile{
+		PublicKey: challengeFile.PublicKey,
+		Signature: c.signer.SignChallenge(challengeFile.ChallengeString),
+		RequestID: challengeFile.RequestID,
+	}
+	if sigFile.PublicKey == "" {
+		sigFile.PublicKey = publicKey
+	}
+
+	filename := filepath.Join(dataDir, "signature.json")
+	if err := writeAuthFile(filename, sigFile); err != nil {
+		return nil, fmt.Errorf("failed to save signature file: %w", err)
+	}
+
+	LogSuccess("Challenge signed and saved", zap.String("file", filename))
+	return &sigFile, nil
+}
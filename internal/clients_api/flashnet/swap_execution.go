@@ -0,0 +1,176 @@
+package flashnet
+
+// Swap placement endpoints of Flashnet AMM API (authenticated, JWT required)
+// POST /swap/simulate - quote of swap without execution
+// POST /swap - execution of swap, asset in must be transferred to pool (Spark transfer) before the call
+// Execution is signed with wallet identity key: sha256 of swap intent JSON, DER signature in hex
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// SimulateSwapRequest - body of POST /swap/simulate
+type SimulateSwapRequest struct {
+	PoolID          string `json:"poolId"` // pool LP public key
+	AssetInAddress  string `json:"assetInAddress"`
+	AssetOutAddress string `json:"assetOutAddress"`
+	AmountIn        string `json:"amountIn"` // raw units of asset in (sats for BTC)
+}
+
+// SimulateSwapResponse - quote of swap
+type SimulateSwapResponse struct {
+	AmountOut      string `json:"amountOut"` // raw units of asset out (sats for BTC)
+	ExecutionPrice string `json:"executionPrice"`
+	FeePaidAssetIn string `json:"feePaidAssetIn"`
+	PriceImpactPct string `json:"priceImpactPct"`
+	WarningMessage string `json:"warningMessage,omitempty"`
+}
+
+// ExecuteSwapRequest - body of POST /swap
+// Nonce and Signature are filled by ExecuteSwap if empty
+type ExecuteSwapRequest struct {
+	UserPublicKey             string `json:"userPublicKey"`
+	PoolID                    string `json:"poolId"`
+	AssetInAddress            string `json:"assetInAddress"`
+	AssetOutAddress           string `json:"assetOutAddress"`
+	AmountIn                  string `json:"amountIn"`
+	MaxSlippageBps            string `json:"maxSlippageBps"`
+	MinAmountOut              string `json:"minAmountOut"`
+	AssetInSparkTransferID    string `json:"assetInSparkTransferId"` // transfer of amountIn to pool
+	TotalIntegratorFeeRateBps string `json:"totalIntegratorFeeRateBps"`
+	IntegratorPublicKey       string `json:"integratorPublicKey,omitempty"`
+	Nonce                     string `json:"nonce"`
+	Signature                 string `json:"signature"`
+}
+
+// ExecuteSwapResponse - result of POST /swap
+type ExecuteSwapResponse struct {
+	RequestID          string `json:"requestId"`
+	Accepted           bool   `json:"accepted"`
+	AmountOut          string `json:"amountOut,omitempty"`
+	FeeCharged         string `json:"feeCharged,omitempty"`
+	ExecutionPrice     string `json:"executionPrice,omitempty"`
+	AssetInAddress     string `json:"assetInAddress,omitempty"`
+	AssetOutAddress    string `json:"assetOutAddress,omitempty"`
+	OutboundTransferID string `json:"outboundTransferId,omitempty"`
+	Error              string `json:"error,omitempty"`
+}
+
+// swapIntent - signed message of swap execution (field order is part of message)
+type swapIntent struct {
+	UserPublicKey             string `json:"userPublicKey"`
+	LpIdentityPublicKey       string `json:"lpIdentityPublicKey"`
+	AssetInSparkTransferID    string `json:"assetInSparkTransferId"`
+	AssetInTokenPublicKey     string `json:"assetInTokenPublicKey"`
+	AssetOutTokenPublicKey    string `json:"assetOutTokenPublicKey"`
+	AmountIn                  string `json:"amountIn"`
+	MaxSlippageBps            string `json:"maxSlippageBps"`
+	MinAmountOut              string `json:"minAmountOut"`
+	TotalIntegratorFeeRateBps string `json:"totalIntegratorFeeRateBps"`
+	Nonce                     string `json:"nonce"`
+}
+
+// SimulateSwap returns quote of swap (POST /swap/simulate)
+func (c *Client) SimulateSwap(ctx context.Context, request SimulateSwapRequest) (*SimulateSwapResponse, error) {
+	if request.PoolID == "" || request.AssetInAddress == "" || request.AssetOutAddress == "" || request.AmountIn == "" {
+		return nil, fmt.Errorf("poolId, assetInAddress, assetOutAddress and amountIn are required")
+	}
+
+	respBody, err := c.MakeRequest(ctx, "POST", "/swap/simulate", request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate swap: %w", err)
+	}
+
+	var simulation SimulateSwapResponse
+	if err := json.Unmarshal(respBody, &simulation); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal simulate swap response: %w", err)
+	}
+
+	return &simulation, nil
+}
+
+// ExecuteSwap signs and sends swap (POST /swap)
+// Request is sent once: swap is not idempotent, so 429/502/503 are not retried
+// Rejected swap (accepted=false) is returned as error together with response
+func (c *Client) ExecuteSwap(ctx context.Context, request ExecuteSwapRequest) (*ExecuteSwapResponse, error) {
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is not configured: set PRIVATE_KEY or FLASHNET_KEYSTORE")
+	}
+	if request.AssetInSparkTransferID == "" {
+		return nil, fmt.Errorf("assetInSparkTransferId is required")
+	}
+	if request.UserPublicKey == "" {
+		request.UserPublicKey = c.signer.PublicKey()
+	}
+	if request.TotalIntegratorFeeRateBps == "" {
+		request.TotalIntegratorFeeRateBps = "0"
+	}
+	if request.Nonce == "" {
+		nonce := make([]byte, 16)
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, fmt.Errorf("failed to generate swap nonce: %w", err)
+		}
+		request.Nonce = hex.EncodeToString(nonce)
+	}
+	if request.Signature == "" {
+		signature, err := c.signSwapIntent(request)
+		if err != nil {
+			return nil, err
+		}
+		request.Signature = signature
+	}
+
+	noRetries := 0
+	respBody, err := c.MakeRequestWithOptions(ctx, "POST", "/swap", request, RequestOptions{MaxRetries: &noRetries})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute swap: %w", err)
+	}
+
+	var result ExecuteSwapResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal execute swap response: %w", err)
+	}
+	if !result.Accepted {
+		return &result, fmt.Errorf("swap was not accepted: %s", result.Error)
+	}
+
+	LogInfo("Swap executed",
+		zap.String("requestId", result.RequestID),
+		zap.String("poolId", request.PoolID),
+		zap.String("amountIn", request.AmountIn),
+		zap.String("amountOut", result.AmountOut))
+
+	return &result, nil
+}
+
+// signSwapIntent signs intent message of swap with identity key
+func (c *Client) signSwapIntent(request ExecuteSwapRequest) (string, error) {
+	if _, err := strconv.Atoi(request.MaxSlippageBps); err != nil {
+		return "", fmt.Errorf("invalid maxSlippageBps %q", request.MaxSlippageBps)
+	}
+
+	message, err := json.Marshal(swapIntent{
+		UserPublicKey:             request.UserPublicKey,
+		LpIdentityPublicKey:       request.PoolID,
+		AssetInSparkTransferID:    request.AssetInSparkTransferID,
+		AssetInTokenPublicKey:     request.AssetInAddress,
+		AssetOutTokenPublicKey:    request.AssetOutAddress,
+		AmountIn:                  request.AmountIn,
+		MaxSlippageBps:            request.MaxSlippageBps,
+		MinAmountOut:              request.MinAmountOut,
+		TotalIntegratorFeeRateBps: request.TotalIntegratorFeeRateBps,
+		Nonce:                     request.Nonce,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal swap intent: %w", err)
+	}
+
+	return c.signer.SignMessage(message), nil
+}
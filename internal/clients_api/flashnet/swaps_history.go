@@ -23,10 +23,13 @@ func GetFirstBuySwap(client *Client, userPubkey string, poolLpPublicKey string)
 
 	ctx := context.Background()
 
+	// First buy is shown in alerts, one retry keeps alert from waiting on long backoff
+	maxRetries := 1
 	options := GetUserSwapsOptions{
 		PoolLpPubkey: poolLpPublicKey,
 		Limit:        1000,
 		Sort:         "timestampAsc",
+		MaxRetries:   &maxRetries,
 	}
 
 	userSwapsResp, err := client.GetUserSwaps(ctx, userPubkey, options)
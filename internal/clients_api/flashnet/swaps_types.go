@@ -3,6 +3,8 @@ package flashnet
 // Package flashnet contains for Flashnet AMM API
 // data API
 
+import "spark-wallet/internal/amount"
+
 // NativeTokenAddress - address token (BTC) in Flashnet
 // for (/)
 const NativeTokenAddress = "020202020202020202020202020202020202020202020202020202020202020202"
@@ -78,6 +80,7 @@ type GetUserSwapsOptions struct {
 	Sort            string // (timestampDesc, timestampAsc, amountInDesc and ..)
 	Limit           int    // count swaps (1-100, by default: 20)
 	Offset          int    // count swaps for (by default: 0)
+	MaxRetries      *int   // retry budget of request (nil - client setting)
 }
 
 // GetSwapType swap on tokens
@@ -105,3 +108,17 @@ func (s *Swap) IsBuy() bool {
 func (s *Swap) IsSell() bool {
 	return s.GetSwapType() == SwapTypeSell
 }
+
+// BTCAmount returns BTC side of buy or sell in BTC, false for token-to-token swap or broken amount
+func (s *Swap) BTCAmount() (float64, bool) {
+	sats := s.AmountIn
+	switch s.GetSwapType() {
+	case SwapTypeBuy:
+	case SwapTypeSell:
+		sats = s.AmountOut
+	default:
+		return 0, false
+	}
+	btc, err := amount.SatsToBTCFloat(sats)
+	return btc, err == nil
+}
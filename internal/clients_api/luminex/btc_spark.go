@@ -1,11 +1,9 @@
 package luminex
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"time"
 )
 
 const (
@@ -29,33 +27,13 @@ type BTCSparkAddressResponse struct {
 func GetBTCSparkReserve() (float64, error) {
 	url := fmt.Sprintf("%s/%s", LuminexSparkAddressAPIBaseURL, SparkPublicKey)
 
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+	raw, err := DefaultClient().Get(context.Background(), url)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch from Luminex Spark Address API: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("luminex Spark Address API returned status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
 
 	var addressResp BTCSparkAddressResponse
-	if err := json.NewDecoder(resp.Body).Decode(&addressResp); err != nil {
+	if err := json.Unmarshal(raw, &addressResp); err != nil {
 		return 0, fmt.Errorf("failed to decode Luminex Spark Address API response: %w", err)
 	}
 
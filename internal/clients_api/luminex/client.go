@@ -0,0 +1,300 @@
+package luminex
+
+// Shared Luminex client: one HTTP client, rate limiter and circuit breaker for all requests
+// Pool responses (/spark/pool/{lpPublicKey}) are cached in memory for PoolCacheTTL,
+// so token metadata, decimals, price and marketcap of one swap need a single pool fetch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/infra/cloudflare"
+	"spark-wallet/internal/infra/retry"
+	"spark-wallet/internal/infra/transfer"
+
+	"github.com/sony/gobreaker"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// PoolCacheTTL - time pool response is served from memory
+	PoolCacheTTL = 30 * time.Second
+)
+
+// Client - Luminex API client
+type Client struct {
+	httpClient     *http.Client              // Shared HTTP client (keep-alive)
+	rateLimiter    *rate.Limiter             // Rate limiter for request frequency limiting
+	circuitBreaker *gobreaker.CircuitBreaker // Circuit breaker for error avalanche protection
+	poolTTL        time.Duration             // Pool cache TTL
+	cloudflare     *cloudflare.Guard         // Cloudflare block detection, cool-down and header rotation
+
+	poolMutex    sync.Mutex
+	poolCache    map[string]*cachedPool // poolLpPublicKey -> pool response
+	poolInflight map[string]*poolFetch  // poolLpPublicKey -> fetch in progress
+}
+
+type cachedPool struct {
+	raw       []byte
+	pool      *LuminexPoolResponse
+	fetchedAt time.Time
+}
+
+// poolFetch - pool fetch shared by concurrent callers
+type poolFetch struct {
+	done   chan struct{}
+	cached *cachedPool
+	err    error
+}
+
+var (
+	defaultClient     *Client
+	defaultClientOnce sync.Once
+)
+
+// NewClient creates Luminex client
+func NewClient() *Client {
+	// Create rate limiter: 5 requests per second, burst up to 10
+	rateLimiter := rate.NewLimiter(rate.Limit(5), 10)
+
+	// Create circuit breaker for error avalanche protection
+	circuitBreaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        "LuminexAPI",
+		MaxRequests: 3,
+		Interval:    60 * time.Second,
+		Timeout:     30 * time.Second,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures > 5
+		},
+	})
+
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: luminexHTTPTimeout,
+			Transport: &http.Transport{
+				// Enable keep-alive for better Cloudflare compatibility
+				DisableKeepAlives: false,
+				MaxIdleConns:      10,
+				IdleConnTimeout:   90 * time.Second,
+			},
+		},
+		rateLimiter:    rateLimiter,
+		circuitBreaker: circuitBreaker,
+		poolTTL:        PoolCacheTTL,
+		cloudflare:     cloudflare.NewGuard("LuminexAPI"),
+		poolCache:      make(map[string]*cachedPool),
+		poolInflight:   make(map[string]*poolFetch),
+	}
+}
+
+// DefaultClient returns client shared by package functions
+func DefaultClient() *Client {
+	defaultClientOnce.Do(func() {
+		defaultClient = NewClient()
+	})
+	return defaultClient
+}
+
+// Get sends GET request with rate limiting, circuit breaker and retries
+// Returns response body
+func (c *Client) Get(ctx context.Context, url string) ([]byte, error) {
+	if err := c.cloudflare.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("cloudflare cooldown wait failed: %w", err)
+	}
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limiter wait failed: %w", err)
+		}
+	}
+
+	result, err := c.circuitBreaker.Execute(func() (interface{}, error) {
+		return c.getWithRetry(ctx, url)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("luminex GET failed: %w", err)
+	}
+	return result.([]byte), nil
+}
+
+// GetPool returns pool response (from cache if it is younger than TTL)
+func (c *Client) GetPool(ctx context.Context, poolLpPublicKey string) (*LuminexPoolResponse, error) {
+	cached, err := c.getCachedPool(ctx, poolLpPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return cached.pool, nil
+}
+
+// GetPoolJSON returns raw pool response (from cache if it is younger than TTL)
+// Used by callers decoding pool into extended structures
+func (c *Client) GetPoolJSON(ctx context.Context, poolLpPublicKey string) ([]byte, error) {
+	cached, err := c.getCachedPool(ctx, poolLpPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return cached.raw, nil
+}
+
+// InvalidatePool removes pool from cache
+func (c *Client) InvalidatePool(poolLpPublicKey string) {
+	c.poolMutex.Lock()
+	defer c.poolMutex.Unlock()
+	delete(c.poolCache, poolLpPublicKey)
+}
+
+func (c *Client) getCachedPool(ctx context.Context, poolLpPublicKey string) (*cachedPool, error) {
+	if poolLpPublicKey == "" {
+		return nil, fmt.Errorf("poolLpPublicKey is required")
+	}
+
+	c.poolMutex.Lock()
+	if cached, exists := c.poolCache[poolLpPublicKey]; exists && time.Since(cached.fetchedAt) < c.poolTTL {
+		c.poolMutex.Unlock()
+		return cached, nil
+	}
+	// Same pool is already being fetched - wait for it instead of sending second request
+	if fetch, exists := c.poolInflight[poolLpPublicKey]; exists {
+		c.poolMutex.Unlock()
+		select {
+		case <-fetch.done:
+			return fetch.cached, fetch.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	fetch := &poolFetch{done: make(chan struct{})}
+	c.poolInflight[poolLpPublicKey] = fetch
+	c.poolMutex.Unlock()
+
+	fetch.cached, fetch.err = c.fetchPool(ctx, poolLpPublicKey)
+
+	c.poolMutex.Lock()
+	delete(c.poolInflight, poolLpPublicKey)
+	if fetch.err == nil {
+		c.poolCache[poolLpPublicKey] = fetch.cached
+	}
+	c.poolMutex.Unlock()
+	close(fetch.done)
+
+	return fetch.cached, fetch.err
+}
+
+func (c *Client) fetchPool(ctx context.Context, poolLpPublicKey string) (*cachedPool, error) {
+	raw, err := c.Get(ctx, fmt.Sprintf("%s/%s", LuminexPoolAPIBaseURL, poolLpPublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from Luminex Pool API: %w", err)
+	}
+
+	var pool LuminexPoolResponse
+	if err := json.Unmarshal(raw, &pool); err != nil {
+		return nil, fmt.Errorf("failed to decode Luminex Pool API response: %w", err)
+	}
+
+	return &cachedPool{raw: raw, pool: &pool, fetchedAt: time.Now()}, nil
+}
+
+func (c *Client) getWithRetry(ctx context.Context, url string) ([]byte, error) {
+	var respBody []byte
+	err := retry.Do(ctx, luminexRetry, func() error {
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL(url), nil)
+		if err != nil {
+			return err
+		}
+		setCloudflareHeaders(req)
+		c.cloudflare.SetHeaders(req)
+		transfer.AcceptGzip(req)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		body, _, err := transfer.ReadBody("LuminexAPI", resp, 0)
+		if err != nil {
+			return err
+		}
+		respBody = body
+
+		// Challenge is not retried here - guard pauses next requests instead
+		if err := c.cloudflare.Observe(resp.StatusCode, resp.Header, body); err != nil {
+			return err
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return &retry.HTTPError{
+				StatusCode: resp.StatusCode,
+				Body:       body,
+				RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return respBody, nil
+}
+
+// TokenMetadataForSwap returns metadata of non-BTC token of pool
+// swap - swap in pool (token side is resolved by swap asset addresses)
+// ticker - expected ticker, used if asset addresses don't match (may be empty)
+func (p *LuminexPoolResponse) TokenMetadataForSwap(swap flashnet.Swap, ticker string) LuminexTokenMetadata {
+	tokenAddress := swapTokenAddress(swap)
+
+	switch {
+	case tokenAddress != "" && tokenAddress == p.AssetAAddress:
+		if ticker != "" && p.TokenAMetadata.Ticker != ticker && p.TokenBMetadata.Ticker == ticker {
+			return p.TokenBMetadata
+		}
+		return p.TokenAMetadata
+	case tokenAddress != "" && tokenAddress == p.AssetBAddress:
+		if ticker != "" && p.TokenBMetadata.Ticker != ticker && p.TokenAMetadata.Ticker == ticker {
+			return p.TokenAMetadata
+		}
+		return p.TokenBMetadata
+	case ticker != "" && p.TokenAMetadata.Ticker == ticker && p.AssetBAddress == flashnet.NativeTokenAddress:
+		return p.TokenAMetadata
+	case ticker != "" && p.TokenBMetadata.Ticker == ticker && p.AssetAAddress == flashnet.NativeTokenAddress:
+		return p.TokenBMetadata
+	}
+	meta, _, _ := p.TokenSide()
+	return meta
+}
+
+// TokenSide returns metadata and reserves of token side of pool (other side is BTC, by pool asset addresses)
+func (p *LuminexPoolResponse) TokenSide() (meta LuminexTokenMetadata, btcReserve string, tokenReserve string) {
+	if p.AssetBAddress != flashnet.NativeTokenAddress {
+		return p.TokenBMetadata, p.AssetAReserve, p.AssetBReserve
+	}
+	return p.TokenAMetadata, p.AssetBReserve, p.AssetAReserve
+}
+
+// TokenSideAddress returns address of non-BTC asset of pool
+func (p *LuminexPoolResponse) TokenSideAddress() string {
+	if p.AssetBAddress == flashnet.NativeTokenAddress {
+		return p.AssetAAddress
+	}
+	return p.AssetBAddress
+}
+
+// swapTokenAddress returns address of non-BTC asset of swap
+func swapTokenAddress(swap flashnet.Swap) string {
+	if swap.AssetOutAddress == flashnet.NativeTokenAddress {
+		// If get BTC, token (assetInAddress - token)
+		return swap.AssetInAddress
+	}
+	if swap.AssetInAddress == flashnet.NativeTokenAddress {
+		// If BTC, get token (assetOutAddress - token)
+		return swap.AssetOutAddress
+	}
+	if swap.PoolAssetBAddress == flashnet.NativeTokenAddress {
+		return swap.PoolAssetAAddress
+	}
+	return swap.PoolAssetBAddress
+}
@@ -7,9 +7,9 @@ package luminex
 
 import (
 	"context"
-	"fmt"
-	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"spark-wallet/internal/infra/retry"
@@ -23,8 +23,30 @@ var luminexRetry = retry.Options{
 	Backoff:    2.0,
 }
 
-func newHTTPClient() *http.Client {
-	return &http.Client{Timeout: luminexHTTPTimeout}
+// LuminexAPIHost - host of Luminex API URLs (LuminexPoolAPIBaseURL, LuminexStatsAPIBaseURL, ...)
+const LuminexAPIHost = "https://api.luminex.io"
+
+var (
+	apiHost      string // replaces LuminexAPIHost in requests, empty - Luminex API
+	apiHostMutex sync.RWMutex
+)
+
+// SetAPIHost sends requests of Luminex API URLs to another host (mock server in tests), empty - Luminex API
+func SetAPIHost(host string) {
+	apiHostMutex.Lock()
+	apiHost = strings.TrimRight(host, "/")
+	apiHostMutex.Unlock()
+}
+
+// apiURL returns url with LuminexAPIHost replaced by host set with SetAPIHost
+func apiURL(url string) string {
+	apiHostMutex.RLock()
+	host := apiHost
+	apiHostMutex.RUnlock()
+	if host == "" || !strings.HasPrefix(url, LuminexAPIHost) {
+		return url
+	}
+	return host + strings.TrimPrefix(url, LuminexAPIHost)
 }
 
 func setCloudflareHeaders(req *http.Request) {
@@ -37,41 +59,7 @@ func setCloudflareHeaders(req *http.Request) {
 }
 
 func doGET(ctx context.Context, url string) ([]byte, error) {
-	client := newHTTPClient()
-
-	var respBody []byte
-	err := retry.Do(ctx, luminexRetry, func() error {
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return err
-		}
-		setCloudflareHeaders(req)
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-		respBody = body
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return &retry.HTTPError{
-				StatusCode: resp.StatusCode,
-				Body:       body,
-				RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
-			}
-		}
-		return nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("luminex GET failed: %w", err)
-	}
-	return respBody, nil
+	return DefaultClient().Get(ctx, url)
 }
 
 func DoGET(ctx context.Context, url string) ([]byte, error) {
@@ -0,0 +1,85 @@
+package luminex
+
+// Token listings from API Luminex
+// /spark/tokens-with-pools sorted by token creation time lists newest tokens with their pools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"spark-wallet/internal/clients_api/flashnet"
+)
+
+// ListedToken - token from tokens-with-pools
+type ListedToken struct {
+	Pubkey         string       `json:"pubkey"`
+	Name           string       `json:"name"`
+	Ticker         string       `json:"ticker"`
+	TokenCreatedAt string       `json:"token_created_at"`
+	Pools          []ListedPool `json:"pools"`
+}
+
+// ListedPool - pool of listed token
+type ListedPool struct {
+	LpPublicKey   string `json:"lpPublicKey"`
+	AssetAAddress string `json:"assetAAddress"`
+	AssetBAddress string `json:"assetBAddress"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// TokenAddress returns address of token side of pool paired with BTC, false for token-to-token pool
+func (p ListedPool) TokenAddress() (string, bool) {
+	switch {
+	case p.AssetBAddress == flashnet.NativeTokenAddress && p.AssetAAddress != "":
+		return p.AssetAAddress, true
+	case p.AssetAAddress == flashnet.NativeTokenAddress && p.AssetBAddress != "":
+		return p.AssetBAddress, true
+	}
+	return "", false
+}
+
+// GetNewestTokens returns newest tokens with pools (up to limit, newest first)
+func GetNewestTokens(ctx context.Context, limit int) ([]ListedToken, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	url := fmt.Sprintf("%s?offset=0&limit=%d&sort_by=token_created_at&order=desc", LuminexTokensAPIBaseURL, limit)
+	tokens, err := getListedTokens(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch newest tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// GetTokensPage returns page of tokens with pools by 24h volume, most traded first
+func GetTokensPage(ctx context.Context, offset int, limit int) ([]ListedToken, error) {
+	url := fmt.Sprintf("%s?offset=%d&limit=%d&sort_by=agg_volume_24h_usd&order=desc", LuminexTokensAPIBaseURL, offset, limit)
+	tokens, err := getListedTokens(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tokens from offset %d: %w", offset, err)
+	}
+	return tokens, nil
+}
+
+func getListedTokens(ctx context.Context, url string) ([]ListedToken, error) {
+	raw, err := DefaultClient().Get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response is array, or object with array in "data"
+	var tokens []ListedToken
+	if err := json.Unmarshal(raw, &tokens); err != nil {
+		var wrapped struct {
+			Data []ListedToken `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &wrapped); err != nil {
+			return nil, fmt.Errorf("failed to decode tokens: %w", err)
+		}
+		tokens = wrapped.Data
+	}
+
+	return tokens, nil
+}
@@ -3,17 +3,16 @@ package luminex
 // Package system_works contains for from API Luminex
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	storage "spark-wallet/internal/infra/fs"
 	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
 
 	"go.uber.org/zap"
 )
@@ -51,35 +50,13 @@ type TokensResponse []TokenInfo
 func GetStats() (*StatsResponse, error) {
 	url := LuminexStatsAPIBaseURL
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	// create Cloudflare)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+	raw, err := DefaultClient().Get(context.Background(), url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from Luminex Stats API: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("luminex Stats API returned status %d", resp.StatusCode)
-	}
 
 	var statsResp StatsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&statsResp); err != nil {
+	if err := json.Unmarshal(raw, &statsResp); err != nil {
 		return nil, fmt.Errorf("failed to decode Luminex Stats API response: %w", err)
 	}
 
@@ -101,37 +78,10 @@ func GetTopTokens(limit int) ([]TokenInfo, error) {
 	url := fmt.Sprintf("%s?offset=0&limit=%d&sort_by=agg_volume_24h_usd&order=desc",
 		LuminexTokensAPIBaseURL, requestLimit)
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	// create Cloudflare)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+	bodyBytes, err := DefaultClient().Get(context.Background(), url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from Luminex Tokens API: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("luminex Tokens API returned status %d", resp.StatusCode)
-	}
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
 
 	bodyPreview := string(bodyBytes)
 	if len(bodyPreview) > 500 {
@@ -278,35 +228,13 @@ func GetPoolStats(poolLpPublicKey string) (*PoolStatsResponse, error) {
 	// URL
 	url := fmt.Sprintf("%s/%s/stats?timeframe=24h", LuminexPoolStatsAPIBaseURL, poolLpPublicKey)
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	// create Cloudflare)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+	raw, err := DefaultClient().Get(context.Background(), url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from Luminex Pool Stats API: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("luminex Pool Stats API returned status %d", resp.StatusCode)
-	}
 
 	var poolStatsResp PoolStatsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&poolStatsResp); err != nil {
+	if err := json.Unmarshal(raw, &poolStatsResp); err != nil {
 		return nil, fmt.Errorf("failed to decode Luminex Pool Stats API response: %w", err)
 	}
 
@@ -356,8 +284,9 @@ type StatsData struct {
 }
 
 // SaveStatsData data in file stats.json
-func SaveStatsData(stats *StatsResponse, check bool) error {
-	dataOutDir := filepath.Join("data_out", "telegram_out")
+// date - day of entry (YYYY-MM-DD) in timezone of stats schedule
+func SaveStatsData(stats *StatsResponse, check bool, date string) error {
+	dataOutDir := paths.Output("telegram_out")
 	if err := os.MkdirAll(dataOutDir, 0755); err != nil {
 		return fmt.Errorf("failed to create telegram_out directory: %w", err)
 	}
@@ -369,8 +298,7 @@ func SaveStatsData(stats *StatsResponse, check bool) error {
 		existingData = &StatsData{Entries: []StatsDataEntry{}}
 	}
 
-	// Get
-	currentDate := time.Now().Format("2006-01-02")
+	currentDate := date
 
 	// Check,
 	found := false
@@ -425,7 +353,7 @@ func SaveStatsData(stats *StatsResponse, check bool) error {
 
 // LoadStatsData data from file stats.json
 func LoadStatsData() (*StatsData, error) {
-	filename := filepath.Join("data_out", "telegram_out", "stats.json")
+	filename := paths.Output("telegram_out", "stats.json")
 
 	// Check, file
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
@@ -445,17 +373,15 @@ func LoadStatsData() (*StatsData, error) {
 	return &statsData, nil
 }
 
-// IsStatsCheckedToday
-func IsStatsCheckedToday() (bool, error) {
+// IsStatsChecked returns true if scheduled stats were sent on date (YYYY-MM-DD)
+func IsStatsChecked(date string) (bool, error) {
 	statsData, err := LoadStatsData()
 	if err != nil {
 		return false, err
 	}
 
-	currentDate := time.Now().Format("2006-01-02")
-
 	for _, entry := range statsData.Entries {
-		if entry.Date == currentDate {
+		if entry.Date == date {
 			return entry.Check, nil
 		}
 	}
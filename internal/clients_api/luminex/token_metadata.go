@@ -3,17 +3,20 @@ package luminex
 // tokens from Luminex + (in and on
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"spark-wallet/internal/amount"
 	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
 	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
 
 	"go.uber.org/zap"
 )
@@ -21,31 +24,75 @@ import (
 const (
 	// LuminexAPIBaseURL - URL API Luminex
 	LuminexAPIBaseURL = "https://api.luminex.io/spark/pool"
-	TokenCacheFile    = "data_out/saved_ticket.json"
-	// CacheTimeout - time in (5
+	// CacheTimeout - stale token metadata is not requested again for 5 minutes after failed refresh
 	CacheTimeout = 5 * time.Minute
+	// TokenMetadataTTL - token metadata older than TTL is refreshed from Luminex (renamed tickers, decimals)
+	TokenMetadataTTL = 24 * time.Hour
 )
 
+// TokenCacheFile returns path of data_out/saved_ticket.json
+func TokenCacheFile() string {
+	return paths.Output("saved_ticket.json")
+}
+
 // TokenMetadataCache - for tokens
 type TokenMetadataCache struct {
 	mutex     sync.RWMutex
 	cache     map[string]*TokenMetadata // poolLpPublicKey -> TokenMetadata
+	failedAt  map[string]time.Time      // poolLpPublicKey -> last failed refresh of stale metadata
 	cacheFile string
 }
 
 // TokenMetadata - token from API Luminex
 type TokenMetadata struct {
-	Name   string `json:"name"`
-	Ticker string `json:"ticker"`
+	Name         string `json:"name"`
+	Ticker       string `json:"ticker"`
+	Decimals     int    `json:"decimals,omitempty"`      // 0 - unknown (8 is used)
+	TokenAddress string `json:"token_address,omitempty"` // asset address of token in pool
+	FetchedAt    string `json:"fetched_at,omitempty"`    // RFC3339, empty - loaded from ticker:name entry
+}
+
+// Stale returns true if metadata is older than TokenMetadataTTL
+func (m *TokenMetadata) Stale(now time.Time) bool {
+	fetchedAt, err := time.Parse(time.RFC3339, m.FetchedAt)
+	return err != nil || now.Sub(fetchedAt) >= TokenMetadataTTL
 }
 
-// LuminexPoolResponse - API Luminex
+// LuminexPoolResponse - API Luminex response for pool (extended metadata)
 type LuminexPoolResponse struct {
-	LpPublicKey    string               `json:"lpPublicKey"`
-	AssetAAddress  string               `json:"assetAAddress"`
-	AssetBAddress  string               `json:"assetBAddress"`
-	TokenAMetadata LuminexTokenMetadata `json:"tokenAMetadata"`
-	TokenBMetadata LuminexTokenMetadata `json:"tokenBMetadata"`
+	LpPublicKey               string               `json:"lpPublicKey"`
+	CurveType                 string               `json:"curveType"`
+	LpFeeBps                  int                  `json:"lpFeeBps"`
+	HostName                  string               `json:"hostName"`
+	HostFeeBps                int                  `json:"hostFeeBps"`
+	AssetAAddress             string               `json:"assetAAddress"`
+	AssetBAddress             string               `json:"assetBAddress"`
+	AssetAReserve             string               `json:"assetAReserve"`
+	AssetBReserve             string               `json:"assetBReserve"`
+	CurrentPriceAInB          string               `json:"currentPriceAInB"`
+	TvlAssetB                 string               `json:"tvlAssetB"`
+	Volume24hAssetB           string               `json:"volume24hAssetB"`
+	PriceChangePercent24h     string               `json:"priceChangePercent24h"`
+	BondingProgressPercent    string               `json:"bondingProgressPercent"`
+	InitialReserveA           string               `json:"initialReserveA"`
+	VirtualReserveA           string               `json:"virtualReserveA"`
+	VirtualReserveB           string               `json:"virtualReserveB"`
+	GraduationThresholdAmount string               `json:"graduationThresholdAmount"`
+	CreatedAt                 string               `json:"createdAt"`
+	UpdatedAt                 string               `json:"updatedAt"`
+	TokenAMetadata            LuminexTokenMetadata `json:"tokenAMetadata"`
+	TokenBMetadata            LuminexTokenMetadata `json:"tokenBMetadata"`
+	Extra                     LuminexPoolExtra     `json:"extra"`
+}
+
+// LuminexPoolExtra - extra pool data
+type LuminexPoolExtra struct {
+	BondingCurveProgress float64 `json:"bondingCurveProgress"`
+	Category             string  `json:"category"`
+	MarketCapUsd         float64 `json:"marketCapUsd"`
+	PoolTvlUsd           float64 `json:"poolTvlUsd"`
+	Volume24hUsd         float64 `json:"volume24hUsd"`
+	BundledPercentage    float64 `json:"bundledPercentage"`
 }
 
 // LuminexTokenMetadataWithDecimals - token decimals
@@ -56,36 +103,82 @@ type LuminexTokenMetadataWithDecimals struct {
 
 // LuminexTokenMetadata - token from Luminex
 type LuminexTokenMetadata struct {
-	Name            string  `json:"name"`
-	Ticker          string  `json:"ticker"`
-	AggMarketcapUsd float64 `json:"agg_marketcap_usd"`
-	AggPriceUsd     float64 `json:"agg_price_usd"`
-	Decimals        int     `json:"decimals"`
+	ID                 int      `json:"id"`
+	Pubkey             string   `json:"pubkey"`
+	TokenIdentifier    string   `json:"token_identifier"`
+	TokenAddress       string   `json:"token_address"`
+	Name               string   `json:"name"`
+	Ticker             string   `json:"ticker"`
+	Decimals           int      `json:"decimals"`
+	IconURL            string   `json:"icon_url"`
+	HolderCount        int      `json:"holder_count"`
+	TotalSupply        int64    `json:"total_supply"`
+	MaxSupply          int64    `json:"max_supply"`
+	IsFreezable        bool     `json:"is_freezable"`
+	Description        *string  `json:"description"`
+	WebsiteURL         *string  `json:"website_url"`
+	TwitterURL         *string  `json:"twitter_url"`
+	TelegramURL        *string  `json:"telegram_url"`
+	CreatorPubkey      *string  `json:"creator_pubkey"`
+	TokenCreatedAt     string   `json:"token_created_at"`
+	TokenUpdatedAt     string   `json:"token_updated_at"`
+	AggUpdatedAt       string   `json:"agg_updated_at"`
+	Network            string   `json:"network"`
+	AggVolume24hUsd    float64  `json:"agg_volume_24h_usd"`
+	AggPriceChange24h  float64  `json:"agg_price_change_24h"`
+	AggPriceUsd        float64  `json:"agg_price_usd"`
+	AggPriceBtc        float64  `json:"agg_price_btc"`
+	AggVolumeBtc       float64  `json:"agg_volume_btc"`
+	AggMarketcapUsd    float64  `json:"agg_marketcap_usd"`
+	AggTvlUsd          float64  `json:"agg_tvl_usd"`
+	AthPriceUsd        float64  `json:"ath_price_usd"`
+	AthMarketcapUsd    float64  `json:"ath_marketcap_usd"`
+	HoldersUpdatedAt   string   `json:"holders_updated_at"`
+	Top10HoldersPct    float64  `json:"top_10_holders_pct"`
+	DevHoldingPct      *float64 `json:"dev_holding_pct"`
+	AggPriceConfidence float64  `json:"agg_price_confidence"`
+	Verified           bool     `json:"verified"`
+}
+
+// DecimalsOrDefault returns token decimals or 8 if API has no decimals
+func (m LuminexTokenMetadata) DecimalsOrDefault() int {
+	if m.Decimals == 0 {
+		return 8 // Default value
+	}
+	return m.Decimals
 }
 
 // savedTicketsFile - for in file
+// Tickets is kept for FindPoolLpPublicKeyByTicker and older versions, Tokens has full metadata
 type savedTicketsFile struct {
-	Tickets map[string]string `json:"tickets"` // poolLpPublicKey -> "ticker:name"
+	Tickets map[string]string         `json:"tickets"`          // poolLpPublicKey -> "ticker:name"
+	Tokens  map[string]*TokenMetadata `json:"tokens,omitempty"` // poolLpPublicKey -> metadata with decimals, address and fetch time
 }
 
 var (
-	tokenCache *TokenMetadataCache
-	once       sync.Once
+	tokenCache      *TokenMetadataCache
+	tokenCacheMutex sync.Mutex
 )
 
 // getTokenCache tokens
+// Cache is loaded again when data dir changes (paths.Configure)
 func getTokenCache() *TokenMetadataCache {
-	once.Do(func() {
+	tokenCacheMutex.Lock()
+	defer tokenCacheMutex.Unlock()
+
+	if cacheFile := TokenCacheFile(); tokenCache == nil || tokenCache.cacheFile != cacheFile {
 		tokenCache = &TokenMetadataCache{
 			cache:     make(map[string]*TokenMetadata),
-			cacheFile: TokenCacheFile,
+			failedAt:  make(map[string]time.Time),
+			cacheFile: cacheFile,
 		}
 		tokenCache.loadFromFile()
-	})
+	}
 	return tokenCache
 }
 
 // loadFromFile tokens from file
+// Entries of ticker:name only (file of older version) have no fetch time and are refreshed on first use
 func (c *TokenMetadataCache) loadFromFile() {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -113,7 +206,15 @@ func (c *TokenMetadataCache) loadFromFile() {
 		return
 	}
 
+	for poolKey, metadata := range saved.Tokens {
+		if metadata != nil {
+			c.cache[poolKey] = metadata
+		}
+	}
 	for poolKey, tickerName := range saved.Tickets {
+		if _, exists := c.cache[poolKey]; exists {
+			continue
+		}
 		parts := strings.SplitN(tickerName, ":", 2)
 		if len(parts) == 2 {
 			c.cache[poolKey] = &TokenMetadata{
@@ -149,6 +250,7 @@ func (c *TokenMetadataCache) getFromCache(poolLpPublicKey string) (*TokenMetadat
 func (c *TokenMetadataCache) setToCache(poolLpPublicKey string, metadata *TokenMetadata) {
 	c.mutex.Lock()
 	c.cache[poolLpPublicKey] = metadata
+	delete(c.failedAt, poolLpPublicKey)
 	cacheCopy := make(map[string]*TokenMetadata)
 	for k, v := range c.cache {
 		cacheCopy[k] = v
@@ -158,16 +260,32 @@ func (c *TokenMetadataCache) setToCache(poolLpPublicKey string, metadata *TokenM
 	c.saveToFileUnlocked(cacheCopy)
 }
 
+// refreshFailedRecently returns true if refresh of stale metadata failed less than CacheTimeout ago
+func (c *TokenMetadataCache) refreshFailedRecently(poolLpPublicKey string, now time.Time) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	failedAt, exists := c.failedAt[poolLpPublicKey]
+	return exists && now.Sub(failedAt) < CacheTimeout
+}
+
+func (c *TokenMetadataCache) setRefreshFailed(poolLpPublicKey string, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.failedAt[poolLpPublicKey] = now
+}
+
 // saveToFileUnlocked tokens in file
 func (c *TokenMetadataCache) saveToFileUnlocked(cache map[string]*TokenMetadata) {
 	saved := savedTicketsFile{
 		Tickets: make(map[string]string),
+		Tokens:  make(map[string]*TokenMetadata),
 	}
 
 	// Save in "ticker:name"
 	for poolKey, metadata := range cache {
 		if metadata != nil {
 			saved.Tickets[poolKey] = fmt.Sprintf("%s:%s", metadata.Ticker, metadata.Name)
+			saved.Tokens[poolKey] = metadata
 		}
 	}
 
@@ -177,7 +295,7 @@ func (c *TokenMetadataCache) saveToFileUnlocked(cache map[string]*TokenMetadata)
 		return
 	}
 
-	if err := os.WriteFile(c.cacheFile, data, 0644); err != nil {
+	if err := storage.WriteFileAtomic(c.cacheFile, data, 0644); err != nil {
 		logging.LogWarn("Failed to save token cache file", zap.Error(err))
 		return
 	}
@@ -185,58 +303,30 @@ func (c *TokenMetadataCache) saveToFileUnlocked(cache map[string]*TokenMetadata)
 	logging.LogDebug("Saved token cache to file", zap.Int("count", len(saved.Tickets)))
 }
 
-// fetchFromAPI token from API Luminex
+// fetchFromAPI token from API Luminex (pool response is shared via client cache)
 func fetchFromAPI(poolLpPublicKey string) (*TokenMetadata, error) {
-	url := fmt.Sprintf("%s/%s", LuminexAPIBaseURL, poolLpPublicKey)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	// create Cloudflare)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+	poolResp, err := DefaultClient().GetPool(context.Background(), poolLpPublicKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from Luminex API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("luminex API returned status %d", resp.StatusCode)
-	}
-
-	var poolResp LuminexPoolResponse
-	if err := json.NewDecoder(resp.Body).Decode(&poolResp); err != nil {
-		return nil, fmt.Errorf("failed to decode Luminex API response: %w", err)
+		return nil, err
 	}
 
 	// tokenBMetadata BTC (NativeTokenAddress)
 	// tokenAMetadata token BTC)
 	// Check addresses,
 	var tokenMeta LuminexTokenMetadata
+	var tokenAddress string
 
 	if poolResp.AssetBAddress == flashnet.NativeTokenAddress {
 		// assetBAddress BTC, assetAAddress token
-		tokenMeta = poolResp.TokenAMetadata
+		tokenMeta, tokenAddress = poolResp.TokenAMetadata, poolResp.AssetAAddress
 	} else if poolResp.AssetAAddress == flashnet.NativeTokenAddress {
 		// assetAAddress BTC, assetBAddress token
-		tokenMeta = poolResp.TokenBMetadata
+		tokenMeta, tokenAddress = poolResp.TokenBMetadata, poolResp.AssetBAddress
 	} else {
 		// If tokenAMetadata
-		tokenMeta = poolResp.TokenAMetadata
+		tokenMeta, tokenAddress = poolResp.TokenAMetadata, poolResp.AssetAAddress
 		if tokenMeta.Name == "" && tokenMeta.Ticker == "" {
-			tokenMeta = poolResp.TokenBMetadata
+			tokenMeta, tokenAddress = poolResp.TokenBMetadata, poolResp.AssetBAddress
 		}
 	}
 
@@ -245,8 +335,11 @@ func fetchFromAPI(poolLpPublicKey string) (*TokenMetadata, error) {
 	}
 
 	return &TokenMetadata{
-		Name:   tokenMeta.Name,
-		Ticker: tokenMeta.Ticker,
+		Name:         tokenMeta.Name,
+		Ticker:       tokenMeta.Ticker,
+		Decimals:     tokenMeta.Decimals,
+		TokenAddress: tokenAddress,
+		FetchedAt:    time.Now().UTC().Format(time.RFC3339),
 	}, nil
 }
 
@@ -258,108 +351,86 @@ func GetTokenMetadata(poolLpPublicKey string) *TokenMetadata {
 	}
 
 	cache := getTokenCache()
+	now := time.Now()
 
 	// Check
-	if metadata, exists := cache.getFromCache(poolLpPublicKey); exists {
-		return metadata
+	cached, exists := cache.getFromCache(poolLpPublicKey)
+	if exists && (!cached.Stale(now) || cache.refreshFailedRecently(poolLpPublicKey, now)) {
+		return cached
 	}
 
 	metadata, err := fetchFromAPI(poolLpPublicKey)
 	if err != nil {
+		if exists {
+			// Stale metadata is served until Luminex answers again
+			logging.LogDebug("Failed to refresh token metadata, using cached",
+				zap.String("poolLpPublicKey", poolLpPublicKey),
+				zap.Error(err))
+			cache.setRefreshFailed(poolLpPublicKey, now)
+			return cached
+		}
 		// log API Luminex -
 		// Return nil,
 		return nil
 	}
 
+	if exists && cached.Ticker != metadata.Ticker {
+		logging.LogInfo("Token ticker changed",
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.String("oldTicker", cached.Ticker),
+			zap.String("ticker", metadata.Ticker))
+	}
 	cache.setToCache(poolLpPublicKey, metadata)
 
 	return metadata
 }
 
-// GetPoolMarketCap token from Luminex API
-// swap - swap for token (A or B)
-// in USD or 0, if get
-func GetPoolMarketCap(poolLpPublicKey string, swap flashnet.Swap) float64 {
+// RefreshTokenMetadata requests metadata of pool from Luminex bypassing token and pool caches (/flashrefresh)
+// Returns previous metadata (nil if token was not cached) and new one, cache is kept on error
+func RefreshTokenMetadata(poolLpPublicKey string) (*TokenMetadata, *TokenMetadata, error) {
 	if poolLpPublicKey == "" {
-		return 0
+		return nil, nil, fmt.Errorf("poolLpPublicKey is required")
 	}
 
-	url := fmt.Sprintf("%s/%s", LuminexAPIBaseURL, poolLpPublicKey)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
+	cache := getTokenCache()
+	previous, _ := cache.getFromCache(poolLpPublicKey)
 
-	req, err := http.NewRequest("GET", url, nil)
+	DefaultClient().InvalidatePool(poolLpPublicKey)
+	metadata, err := fetchFromAPI(poolLpPublicKey)
 	if err != nil {
-		logging.LogDebug("Failed to create request for pool marketcap", zap.Error(err))
-		return 0
+		return previous, nil, fmt.Errorf("failed to refresh token metadata: %w", err)
 	}
+	cache.setToCache(poolLpPublicKey, metadata)
 
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
+	return previous, metadata, nil
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		logging.LogDebug("Failed to fetch pool marketcap from Luminex API", zap.Error(err))
-		return 0
+// GetCachedTokenMetadata token by poolLpPublicKey from cache only (without API request)
+// nil if token is not cached yet
+func GetCachedTokenMetadata(poolLpPublicKey string) *TokenMetadata {
+	if poolLpPublicKey == "" {
+		return nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		logging.LogDebug("luminex pool API returned non-OK status", zap.Int("status", resp.StatusCode))
-		return 0
-	}
+	metadata, _ := getTokenCache().getFromCache(poolLpPublicKey)
+	return metadata
+}
 
-	var poolResp LuminexPoolResponse
-	if err := json.NewDecoder(resp.Body).Decode(&poolResp); err != nil {
-		logging.LogDebug("Failed to decode Luminex pool API response", zap.Error(err))
+// GetPoolMarketCap token from Luminex API
+// swap - swap for token (A or B)
+// in USD or 0, if get
+func GetPoolMarketCap(poolLpPublicKey string, swap flashnet.Swap) float64 {
+	if poolLpPublicKey == "" {
 		return 0
 	}
 
-	// token (token, BTC)
-	// Use addresses from swap for token BTC
-	var marketcap float64
-
-	// address token BTC) from swap
-	var tokenAddress string
-	if swap.AssetOutAddress == flashnet.NativeTokenAddress {
-		// If get BTC, token (assetInAddress - token)
-		tokenAddress = swap.AssetInAddress
-	} else if swap.AssetInAddress == flashnet.NativeTokenAddress {
-		// If BTC, get token (assetOutAddress - token)
-		tokenAddress = swap.AssetOutAddress
-	} else {
-		// - use
-		// PoolAssetBAddress BTC
-		if swap.PoolAssetBAddress == flashnet.NativeTokenAddress {
-			tokenAddress = swap.PoolAssetAAddress
-		} else {
-			tokenAddress = swap.PoolAssetBAddress
-		}
-	}
-
-	// address token from pool
-	if tokenAddress != "" {
-		if tokenAddress == poolResp.AssetAAddress {
-			marketcap = poolResp.TokenAMetadata.AggMarketcapUsd
-		} else if tokenAddress == poolResp.AssetBAddress {
-			marketcap = poolResp.TokenBMetadata.AggMarketcapUsd
-		} else {
-			// assetBAddress BTC in Luminex
-			if poolResp.AssetBAddress == flashnet.NativeTokenAddress {
-				marketcap = poolResp.TokenAMetadata.AggMarketcapUsd
-			} else {
-				marketcap = poolResp.TokenBMetadata.AggMarketcapUsd
-			}
-		}
+	poolResp, err := DefaultClient().GetPool(context.Background(), poolLpPublicKey)
+	if err != nil {
+		logging.LogDebug("Failed to get pool for marketcap", zap.Error(err))
+		return 0
 	}
 
-	return marketcap
+	return poolResp.TokenMetadataForSwap(swap, "").AggMarketcapUsd
 }
 
 // GetTokenDecimals decimals token from Luminex API
@@ -371,97 +442,19 @@ func GetTokenDecimals(poolLpPublicKey string, swap flashnet.Swap, ticker string)
 		return 8 // Default value
 	}
 
-	url := fmt.Sprintf("%s/%s", LuminexAPIBaseURL, poolLpPublicKey)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		logging.LogDebug("Failed to create request for token decimals", zap.Error(err))
-		return 8
+	// Cached decimals of swap token, pool is requested for other side of token-to-token pool
+	if metadata := GetTokenMetadata(poolLpPublicKey); metadata != nil && metadata.Decimals > 0 &&
+		metadata.TokenAddress != "" && metadata.TokenAddress == swapTokenAddress(swap) {
+		return metadata.Decimals
 	}
 
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+	poolResp, err := DefaultClient().GetPool(context.Background(), poolLpPublicKey)
 	if err != nil {
-		logging.LogDebug("Failed to fetch token decimals from Luminex API", zap.Error(err))
+		logging.LogDebug("Failed to get pool for token decimals", zap.Error(err))
 		return 8
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		logging.LogDebug("Luminex pool API returned non-OK status for token decimals", zap.Int("status", resp.StatusCode))
-		return 8
-	}
-
-	var poolResp LuminexPoolResponse
-	if err := json.NewDecoder(resp.Body).Decode(&poolResp); err != nil {
-		logging.LogDebug("Failed to decode Luminex pool API response for token decimals", zap.Error(err))
-		return 8
-	}
-
-	// address token BTC) from swap GetPoolTokenPrice)
-	var tokenAddress string
-	if swap.AssetOutAddress == flashnet.NativeTokenAddress {
-		tokenAddress = swap.AssetInAddress
-	} else if swap.AssetInAddress == flashnet.NativeTokenAddress {
-		tokenAddress = swap.AssetOutAddress
-	} else {
-		if swap.PoolAssetBAddress == flashnet.NativeTokenAddress {
-			tokenAddress = swap.PoolAssetAAddress
-		} else {
-			tokenAddress = swap.PoolAssetBAddress
-		}
-	}
-
-	// address token from pool
-	var decimals int
-	if tokenAddress != "" {
-		if tokenAddress == poolResp.AssetAAddress {
-			decimals = poolResp.TokenAMetadata.Decimals
-			if ticker != "" && poolResp.TokenAMetadata.Ticker != ticker && poolResp.TokenBMetadata.Ticker == ticker {
-				decimals = poolResp.TokenBMetadata.Decimals
-			}
-		} else if tokenAddress == poolResp.AssetBAddress {
-			decimals = poolResp.TokenBMetadata.Decimals
-			if ticker != "" && poolResp.TokenBMetadata.Ticker != ticker && poolResp.TokenAMetadata.Ticker == ticker {
-				decimals = poolResp.TokenAMetadata.Decimals
-			}
-		} else {
-			if ticker != "" {
-				if poolResp.TokenAMetadata.Ticker == ticker && poolResp.AssetBAddress == flashnet.NativeTokenAddress {
-					decimals = poolResp.TokenAMetadata.Decimals
-				} else if poolResp.TokenBMetadata.Ticker == ticker && poolResp.AssetAAddress == flashnet.NativeTokenAddress {
-					decimals = poolResp.TokenBMetadata.Decimals
-				} else {
-					if poolResp.AssetBAddress == flashnet.NativeTokenAddress {
-						decimals = poolResp.TokenAMetadata.Decimals
-					} else {
-						decimals = poolResp.TokenBMetadata.Decimals
-					}
-				}
-			} else {
-				if poolResp.AssetBAddress == flashnet.NativeTokenAddress {
-					decimals = poolResp.TokenAMetadata.Decimals
-				} else {
-					decimals = poolResp.TokenBMetadata.Decimals
-				}
-			}
-		}
-	}
-
-	if decimals == 0 {
-		return 8 // Default value
-	}
-	return decimals
+	return poolResp.TokenMetadataForSwap(swap, ticker).DecimalsOrDefault()
 }
 
 // GetPoolTokenPrice token (agg_price_usd) from Luminex API
@@ -473,104 +466,13 @@ func GetPoolTokenPrice(poolLpPublicKey string, swap flashnet.Swap, ticker string
 		return 0
 	}
 
-	url := fmt.Sprintf("%s/%s", LuminexAPIBaseURL, poolLpPublicKey)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		logging.LogDebug("Failed to create request for pool token price", zap.Error(err))
-		return 0
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+	poolResp, err := DefaultClient().GetPool(context.Background(), poolLpPublicKey)
 	if err != nil {
-		logging.LogDebug("Failed to fetch pool token price from Luminex API", zap.Error(err))
-		return 0
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		logging.LogDebug("Luminex pool API returned non-OK status for token price", zap.Int("status", resp.StatusCode))
+		logging.LogDebug("Failed to get pool for token price", zap.Error(err))
 		return 0
 	}
 
-	var poolResp LuminexPoolResponse
-	if err := json.NewDecoder(resp.Body).Decode(&poolResp); err != nil {
-		logging.LogDebug("Failed to decode Luminex pool API response for token price", zap.Error(err))
-		return 0
-	}
-
-	// address token BTC) from swap
-	var tokenAddress string
-	if swap.AssetOutAddress == flashnet.NativeTokenAddress {
-		// If get BTC, token (assetInAddress - token)
-		tokenAddress = swap.AssetInAddress
-	} else if swap.AssetInAddress == flashnet.NativeTokenAddress {
-		// If BTC, get token (assetOutAddress - token)
-		tokenAddress = swap.AssetOutAddress
-	} else {
-		// - use
-		if swap.PoolAssetBAddress == flashnet.NativeTokenAddress {
-			tokenAddress = swap.PoolAssetAAddress
-		} else {
-			tokenAddress = swap.PoolAssetBAddress
-		}
-	}
-
-	// address token from pool
-	var price float64
-	if tokenAddress != "" {
-		if tokenAddress == poolResp.AssetAAddress {
-			price = poolResp.TokenAMetadata.AggPriceUsd
-			if ticker != "" && poolResp.TokenAMetadata.Ticker != ticker {
-				// If ticker tokenB
-				if poolResp.TokenBMetadata.Ticker == ticker {
-					price = poolResp.TokenBMetadata.AggPriceUsd
-				}
-			}
-		} else if tokenAddress == poolResp.AssetBAddress {
-			price = poolResp.TokenBMetadata.AggPriceUsd
-			if ticker != "" && poolResp.TokenBMetadata.Ticker != ticker {
-				// If ticker tokenA
-				if poolResp.TokenAMetadata.Ticker == ticker {
-					price = poolResp.TokenAMetadata.AggPriceUsd
-				}
-			}
-		} else {
-			// If addresses use and check ticker
-			if ticker != "" {
-				if poolResp.TokenAMetadata.Ticker == ticker && poolResp.AssetBAddress == flashnet.NativeTokenAddress {
-					price = poolResp.TokenAMetadata.AggPriceUsd
-				} else if poolResp.TokenBMetadata.Ticker == ticker && poolResp.AssetAAddress == flashnet.NativeTokenAddress {
-					price = poolResp.TokenBMetadata.AggPriceUsd
-				} else {
-					if poolResp.AssetBAddress == flashnet.NativeTokenAddress {
-						price = poolResp.TokenAMetadata.AggPriceUsd
-					} else {
-						price = poolResp.TokenBMetadata.AggPriceUsd
-					}
-				}
-			} else {
-				if poolResp.AssetBAddress == flashnet.NativeTokenAddress {
-					price = poolResp.TokenAMetadata.AggPriceUsd
-				} else {
-					price = poolResp.TokenBMetadata.AggPriceUsd
-				}
-			}
-		}
-	}
-
-	return price
+	return poolResp.TokenMetadataForSwap(swap, ticker).AggPriceUsd
 }
 
 // GetWalletTokenHolding holding token wallet
@@ -602,16 +504,11 @@ func GetWalletTokenHolding(publicKey string, poolLpPublicKey string, swap flashn
 		return "null", ""
 	}
 
-	// Parse balance
-	var balanceValue float64
-	fmt.Sscanf(tokenBalance.Balance, "%f", &balanceValue)
-
-	// on 10^decimals
-	decimalsMultiplier := 1.0
-	for i := 0; i < tokenBalance.Decimals; i++ {
-		decimalsMultiplier *= 10
+	tokenAmount, err := amount.ScaleFloat(tokenBalance.Balance, tokenBalance.Decimals)
+	if err != nil {
+		logging.LogDebug("Failed to parse wallet token balance", zap.String("publicKey", publicKey), zap.Error(err))
+		return "null", ""
 	}
-	tokenAmount := balanceValue / decimalsMultiplier
 
 	tokenAmountStr := formatTokenAmount(tokenAmount)
 
@@ -0,0 +1,127 @@
+package luminex
+
+// Background sync of Luminex usernames into local table (data_out/usernames.json)
+// Wallets seen on swaps are recorded by monitors, sync resolves them in batches
+// so alerts and reports can resolve display names without API call
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// UsernameSyncWindow - only wallets seen in this window are synced
+	UsernameSyncWindow = 30 * 24 * time.Hour
+	// UsernameResyncAge - resolved usernames older than this are refreshed
+	UsernameResyncAge = 24 * time.Hour
+	// usernameSyncBatchSize - pubkeys per profiles API request
+	usernameSyncBatchSize = 50
+)
+
+// GetWalletUsernames resolves usernames for several wallets in one request
+// Returns pubkey -> username only for wallets with profile
+func GetWalletUsernames(ctx context.Context, pubkeys []string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(pubkeys) == 0 {
+		return result, nil
+	}
+
+	reqURL := fmt.Sprintf("%s?pubkeys=%s", LuminexProfilesAPIBaseURL, url.QueryEscape(strings.Join(pubkeys, ",")))
+	body, err := doGET(ctx, reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch wallet usernames: %w", err)
+	}
+
+	var profileResp UserProfileResponse
+	if err := json.Unmarshal(body, &profileResp); err != nil {
+		return nil, fmt.Errorf("failed to decode Luminex profiles API response: %w", err)
+	}
+
+	for _, profile := range profileResp.Data {
+		if profile.Pubkey != "" && profile.Username != "" {
+			result[profile.Pubkey] = profile.Username
+		}
+	}
+	return result, nil
+}
+
+// LoadUsernameTable seeds in-memory username cache from local table
+func LoadUsernameTable() (int, error) {
+	table, err := storage.LoadUsernames()
+	if err != nil {
+		return 0, err
+	}
+
+	loaded := 0
+	for pubkey, entry := range table.Wallets {
+		if entry.SyncedAt == "" {
+			continue
+		}
+		usernameCache.Set(pubkey, entry.Username, entry.Username != "")
+		loaded++
+	}
+
+	return loaded, nil
+}
+
+// SyncUsernames resolves usernames for wallets seen in the last UsernameSyncWindow
+// which were never resolved or are older than UsernameResyncAge
+// Returns count of wallets synced
+func SyncUsernames(ctx context.Context) (int, error) {
+	table, err := storage.LoadUsernames()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load usernames table: %w", err)
+	}
+
+	now := time.Now()
+	var pending []string
+	for pubkey, entry := range table.Wallets {
+		lastSeen, err := time.Parse(time.RFC3339, entry.LastSeen)
+		if err != nil || now.Sub(lastSeen) > UsernameSyncWindow {
+			continue
+		}
+		if entry.SyncedAt != "" {
+			syncedAt, err := time.Parse(time.RFC3339, entry.SyncedAt)
+			if err == nil && now.Sub(syncedAt) < UsernameResyncAge {
+				continue
+			}
+		}
+		pending = append(pending, pubkey)
+	}
+
+	synced := 0
+	for start := 0; start < len(pending); start += usernameSyncBatchSize {
+		end := start + usernameSyncBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		batch := pending[start:end]
+
+		resolved, err := GetWalletUsernames(ctx, batch)
+		if err != nil {
+			logging.LogWarn("Failed to sync usernames batch", zap.Int("batchSize", len(batch)), zap.Error(err))
+			continue
+		}
+
+		if err := storage.UpdateUsernames(batch, resolved); err != nil {
+			return synced, fmt.Errorf("failed to save usernames table: %w", err)
+		}
+
+		for _, pubkey := range batch {
+			usernameCache.Set(pubkey, resolved[pubkey], resolved[pubkey] != "")
+		}
+
+		synced += len(batch)
+	}
+
+	return synced, nil
+}
@@ -3,11 +3,12 @@ package luminex
 // Package system_works contains for wallet from API Luminex
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	storage "spark-wallet/internal/infra/fs"
 	logging "spark-wallet/internal/infra/log"
-	"sync"
+	"spark-wallet/internal/infra/ttlcache"
 	"time"
 
 	"go.uber.org/zap"
@@ -22,6 +23,21 @@ const (
 	LuminexPoolAPIBaseURL = "https://api.luminex.io/spark/pool"
 )
 
+// Wallet lookups of swap alerts are cached per public key, so burst of swaps of one wallet sends one request
+// Failed lookups are cached too (shortly), so Luminex outage or Cloudflare block is not hammered by every alert
+const (
+	// UsernameCacheTTL - lifetime of resolved username, refreshed in background after half of it
+	UsernameCacheTTL = 24 * time.Hour
+	// UsernameNegativeCacheTTL - lifetime of "wallet has no profile"
+	UsernameNegativeCacheTTL = 6 * time.Hour
+	// BalanceCacheTTL - lifetime of wallet balance, refreshed in background after half of it
+	BalanceCacheTTL = 2 * time.Minute
+	// walletErrorCacheTTL - lifetime of failed username or balance lookup
+	walletErrorCacheTTL = time.Minute
+	// walletCacheMaxEntries - wallets kept per cache
+	walletCacheMaxEntries = 20000
+)
+
 // WalletBalanceResponse - API Luminex for wallet
 type WalletBalanceResponse struct {
 	SparkAddress     string        `json:"sparkAddress"`
@@ -53,11 +69,24 @@ type WalletBalance struct {
 }
 
 var (
-	balanceCache      = make(map[string]*WalletBalanceResponse)
-	balanceCacheMutex sync.RWMutex
-
-	usernameCache      = make(map[string]string) // pubkey -> username
-	usernameCacheMutex sync.RWMutex
+	balanceCache = ttlcache.New(ttlcache.Options{
+		TTL:          BalanceCacheTTL,
+		NegativeTTL:  BalanceCacheTTL,
+		ErrorTTL:     walletErrorCacheTTL,
+		RefreshAfter: BalanceCacheTTL / 2,
+		MaxEntries:   walletCacheMaxEntries,
+	}, func(ctx context.Context, publicKey string) (*WalletBalanceResponse, bool, error) {
+		balanceResp, err := fetchWalletBalance(ctx, publicKey)
+		return balanceResp, err == nil, err
+	})
+
+	usernameCache = ttlcache.New(ttlcache.Options{
+		TTL:          UsernameCacheTTL,
+		NegativeTTL:  UsernameNegativeCacheTTL,
+		ErrorTTL:     walletErrorCacheTTL,
+		RefreshAfter: UsernameCacheTTL / 2,
+		MaxEntries:   walletCacheMaxEntries,
+	}, fetchWalletUsername) // pubkey -> username
 )
 
 // UserProfileResponse - API Luminex for
@@ -72,59 +101,12 @@ type UserProfile struct {
 	ImageURL string `json:"image_url"`
 }
 
-// GetWalletBalance balance wallet by
+// GetWalletBalance balance wallet by (cached for BalanceCacheTTL)
 func GetWalletBalance(publicKey string) (*WalletBalanceResponse, error) {
 	if publicKey == "" {
 		return nil, fmt.Errorf("public key is empty")
 	}
-
-	// Check
-	balanceCacheMutex.RLock()
-	if cached, exists := balanceCache[publicKey]; exists {
-		balanceCacheMutex.RUnlock()
-		return cached, nil
-	}
-	balanceCacheMutex.RUnlock()
-
-	url := fmt.Sprintf("%s/%s", LuminexAddressAPIBaseURL, publicKey)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	// create Cloudflare)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from Luminex API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("luminex API returned status %d", resp.StatusCode)
-	}
-
-	var balanceResp WalletBalanceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&balanceResp); err != nil {
-		return nil, fmt.Errorf("failed to decode Luminex API response: %w", err)
-	}
-
-	balanceCacheMutex.Lock()
-	balanceCache[publicKey] = &balanceResp
-	balanceCacheMutex.Unlock()
-
-	return &balanceResp, nil
+	return balanceCache.Get(context.Background(), publicKey)
 }
 
 // FormatBTCFromSats in BTC
@@ -135,110 +117,49 @@ func FormatBTCFromSats(sats int64) string {
 
 // GetWalletUsername (username) by
 // username or if or error
+// Local table (usernames.json) is used first, see LoadUsernameTable/SyncUsernames
+// Result (including "no profile" and failure) is cached, see UsernameCacheTTL
 func GetWalletUsername(publicKey string) string {
 	if publicKey == "" {
 		return ""
 	}
+	username, _ := usernameCache.Get(context.Background(), publicKey)
+	return username
+}
 
-	// Check
-	usernameCacheMutex.RLock()
-	if username, exists := usernameCache[publicKey]; exists {
-		usernameCacheMutex.RUnlock()
-		return username
-	}
-	usernameCacheMutex.RUnlock()
-
-	url := fmt.Sprintf("%s?pubkeys=%s", LuminexProfilesAPIBaseURL, publicKey)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	// create Cloudflare)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		logging.LogWarn("Failed to create request for wallet username", zap.String("publicKey", publicKey), zap.Error(err))
-		return ""
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+// fetchWalletUsername requests profile of wallet, found=false if wallet has no username
+func fetchWalletUsername(ctx context.Context, publicKey string) (string, bool, error) {
+	resolved, err := GetWalletUsernames(ctx, []string{publicKey})
 	if err != nil {
 		logging.LogWarn("Failed to fetch wallet username from Luminex API", zap.String("publicKey", publicKey), zap.Error(err))
-		return ""
+		return "", false, err
 	}
-	defer resp.Body.Close()
+	username := resolved[publicKey]
 
-	if resp.StatusCode != http.StatusOK {
-		logging.LogWarn("Luminex profiles API returned non-OK status", zap.String("publicKey", publicKey), zap.Int("status", resp.StatusCode))
-		return ""
+	if err := storage.UpdateUsernames([]string{publicKey}, map[string]string{publicKey: username}); err != nil {
+		logging.LogDebug("Failed to save wallet username to local table", zap.String("publicKey", publicKey), zap.Error(err))
 	}
 
-	var profileResp UserProfileResponse
-	if err := json.NewDecoder(resp.Body).Decode(&profileResp); err != nil {
-		logging.LogWarn("Failed to decode Luminex profiles API response", zap.String("publicKey", publicKey), zap.Error(err))
-		return ""
-	}
-
-	var username string
-	for _, profile := range profileResp.Data {
-		if profile.Pubkey == publicKey && profile.Username != "" {
-			username = profile.Username
-			break
-		}
-	}
-
-	// Save in if username
-	usernameCacheMutex.Lock()
-	usernameCache[publicKey] = username
-	usernameCacheMutex.Unlock()
-
-	return username
+	return username, username != "", nil
 }
 
-// GetWalletTokensBalance balance wallet by
+// GetWalletTokensBalance balance wallet by (without cache)
 func GetWalletTokensBalance(publicKey string) (*WalletBalanceResponse, error) {
 	if publicKey == "" {
 		return nil, fmt.Errorf("public key is empty")
 	}
+	return fetchWalletBalance(context.Background(), publicKey)
+}
 
-	url := fmt.Sprintf("%s/%s", LuminexAddressAPIBaseURL, publicKey)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	// create Cloudflare)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+// fetchWalletBalance requests /spark/address/{publicKey} via shared client
+func fetchWalletBalance(ctx context.Context, publicKey string) (*WalletBalanceResponse, error) {
+	raw, err := DefaultClient().Get(ctx, fmt.Sprintf("%s/%s", LuminexAddressAPIBaseURL, publicKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from Luminex API: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("luminex API returned status %d", resp.StatusCode)
-	}
 
 	var balanceResp WalletBalanceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&balanceResp); err != nil {
+	if err := json.Unmarshal(raw, &balanceResp); err != nil {
 		return nil, fmt.Errorf("failed to decode Luminex API response: %w", err)
 	}
 
@@ -267,37 +188,13 @@ func GetPoolTotalSupply(poolLpPublicKey string) (string, int, error) {
 		return "", 0, fmt.Errorf("poolLpPublicKey is empty")
 	}
 
-	url := fmt.Sprintf("%s/%s", LuminexPoolAPIBaseURL, poolLpPublicKey)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	// create Cloudflare)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
+	raw, err := DefaultClient().GetPoolJSON(context.Background(), poolLpPublicKey)
 	if err != nil {
-		return "", 0, fmt.Errorf("failed to fetch from Luminex Pool API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", 0, fmt.Errorf("luminex Pool API returned status %d", resp.StatusCode)
+		return "", 0, err
 	}
 
 	var poolResp PoolResponse
-	if err := json.NewDecoder(resp.Body).Decode(&poolResp); err != nil {
+	if err := json.Unmarshal(raw, &poolResp); err != nil {
 		return "", 0, fmt.Errorf("failed to decode Luminex Pool API response: %w", err)
 	}
 
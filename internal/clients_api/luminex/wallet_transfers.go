@@ -0,0 +1,83 @@
+package luminex
+
+// Wallet transfers (activity) from API Luminex
+// /spark/address/{publicKey}/transactions lists BTC and token transfers of wallet, newest first
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// TransferDirectionIncoming - transfer received by wallet
+	TransferDirectionIncoming = "incoming"
+	// TransferDirectionOutgoing - transfer sent by wallet
+	TransferDirectionOutgoing = "outgoing"
+)
+
+// WalletTransfersResponse - API Luminex for wallet transactions
+type WalletTransfersResponse struct {
+	Transactions []WalletTransfer `json:"transactions"`
+}
+
+// WalletTransfer - transfer of wallet
+type WalletTransfer struct {
+	ID           string               `json:"id"`
+	Type         string               `json:"type"`      // transfer, lightning, deposit, withdrawal, ...
+	Direction    string               `json:"direction"` // incoming or outgoing
+	AmountSats   int64                `json:"amountSats"`
+	TokenAddress string               `json:"tokenAddress"` // empty for BTC transfers
+	Counterparty TransferCounterparty `json:"counterparty"`
+	CreatedAt    string               `json:"createdAt"` // RFC3339
+}
+
+// TransferCounterparty - other side of transfer
+type TransferCounterparty struct {
+	Type       string `json:"type"`       // spark, lightning, bitcoin
+	Identifier string `json:"identifier"` // public key for spark wallets
+}
+
+// IsBTC returns true for BTC transfer (not token)
+func (t WalletTransfer) IsBTC() bool {
+	return t.TokenAddress == ""
+}
+
+// CounterpartyPublicKey returns public key of counterparty if it is Spark wallet
+func (t WalletTransfer) CounterpartyPublicKey() string {
+	if !strings.EqualFold(t.Counterparty.Type, "spark") {
+		return ""
+	}
+	return t.Counterparty.Identifier
+}
+
+// Time returns transfer time (zero if not parsed)
+func (t WalletTransfer) Time() time.Time {
+	parsed, err := time.Parse(time.RFC3339, t.CreatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// GetWalletTransfers returns latest transfers of wallet (up to limit)
+func GetWalletTransfers(ctx context.Context, publicKey string, limit int) ([]WalletTransfer, error) {
+	if publicKey == "" {
+		return nil, fmt.Errorf("public key is empty")
+	}
+
+	url := fmt.Sprintf("%s/%s/transactions?limit=%d", LuminexAddressAPIBaseURL, publicKey, limit)
+	raw, err := DefaultClient().Get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch wallet transfers: %w", err)
+	}
+
+	var transfersResp WalletTransfersResponse
+	if err := json.Unmarshal(raw, &transfersResp); err != nil {
+		return nil, fmt.Errorf("failed to decode wallet transfers: %w", err)
+	}
+
+	return transfersResp.Transactions, nil
+}
@@ -0,0 +1,256 @@
+package alerts
+
+// Alert rules engine
+// Rules are declared in YAML/JSON file (app.alert_rules_file) and evaluated against each new swap
+// All conditions of rule must match (AND), zero value means condition is not used
+// Example: notify if buy > X BTC AND marketcap < Y, notify if wallet sells > Z% of holdings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	SideBuy  = "buy"
+	SideSell = "sell"
+)
+
+// Rule - one alert rule
+type Rule struct {
+	Name     string   `json:"name" yaml:"name"`
+	Disabled bool     `json:"disabled" yaml:"disabled"`
+	ChatIDs  []string `json:"chat_ids" yaml:"chat_ids"` // target chats of rule
+	Side     string   `json:"side" yaml:"side"`         // buy, sell or empty for both
+	Tokens   []string `json:"tokens" yaml:"tokens"`     // tickers or poolLpPublicKey, empty - all tokens
+
+	MinBTC               float64 `json:"min_btc" yaml:"min_btc"`
+	MaxBTC               float64 `json:"max_btc" yaml:"max_btc"`
+	MinMarketCapUSD      float64 `json:"min_marketcap_usd" yaml:"min_marketcap_usd"`
+	MaxMarketCapUSD      float64 `json:"max_marketcap_usd" yaml:"max_marketcap_usd"`
+	MinSellPctOfHoldings float64 `json:"min_sell_pct_of_holdings" yaml:"min_sell_pct_of_holdings"` // percent of wallet holdings sold in swap
+}
+
+// RulesFile - file structure of alert rules
+type RulesFile struct {
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// SwapFacts - swap values rules are evaluated against
+// Values requiring API requests are loaded lazily (only if some rule needs them) and cached
+type SwapFacts struct {
+	Swap      flashnet.Swap
+	Ticker    string
+	BTCAmount float64
+
+	LoadMarketCapUSD      func() float64
+	LoadSellPctOfHoldings func() float64
+
+	marketCapUSD      *float64
+	sellPctOfHoldings *float64
+}
+
+// MarketCapUSD returns token marketcap (0 if unknown)
+func (f *SwapFacts) MarketCapUSD() float64 {
+	if f.marketCapUSD == nil {
+		value := 0.0
+		if f.LoadMarketCapUSD != nil {
+			value = f.LoadMarketCapUSD()
+		}
+		f.marketCapUSD = &value
+	}
+	return *f.marketCapUSD
+}
+
+// SellPctOfHoldings returns percent of wallet holdings sold in swap (0 if unknown)
+func (f *SwapFacts) SellPctOfHoldings() float64 {
+	if f.sellPctOfHoldings == nil {
+		value := 0.0
+		if f.LoadSellPctOfHoldings != nil {
+			value = f.LoadSellPctOfHoldings()
+		}
+		f.sellPctOfHoldings = &value
+	}
+	return *f.sellPctOfHoldings
+}
+
+// Engine - loaded rules, reloaded when file changes
+type Engine struct {
+	path    string
+	mu      sync.RWMutex
+	rules   []Rule
+	modTime time.Time
+}
+
+// NewEngine creates engine for rules file and loads it
+// Missing file is not an error (engine without rules)
+func NewEngine(path string) (*Engine, error) {
+	engine := &Engine{path: path}
+	if _, err := engine.Reload(); err != nil {
+		return engine, err
+	}
+	return engine, nil
+}
+
+// Reload loads rules file if it was changed since last load
+// Returns true if rules were reloaded
+func (e *Engine) Reload() (bool, error) {
+	if e.path == "" {
+		return false, nil
+	}
+
+	info, err := os.Stat(e.path)
+	if os.IsNotExist(err) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		changed := len(e.rules) > 0
+		e.rules = nil
+		e.modTime = time.Time{}
+		return changed, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat alert rules file: %w", err)
+	}
+
+	e.mu.RLock()
+	unchanged := info.ModTime().Equal(e.modTime)
+	e.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	rules, err := LoadRules(e.path)
+	if err != nil {
+		return false, err
+	}
+
+	e.mu.Lock()
+	e.rules = rules
+	e.modTime = info.ModTime()
+	e.mu.Unlock()
+	return true, nil
+}
+
+// Rules returns loaded rules
+func (e *Engine) Rules() []Rule {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]Rule(nil), e.rules...)
+}
+
+// Evaluate returns rules matching swap
+func (e *Engine) Evaluate(facts *SwapFacts) []Rule {
+	var matched []Rule
+	for _, rule := range e.Rules() {
+		if rule.Matches(facts) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// Matches checks all rule conditions against swap
+// Cheap conditions are checked first, lazy values are loaded only when needed
+func (r *Rule) Matches(facts *SwapFacts) bool {
+	if r.Disabled {
+		return false
+	}
+
+	switch r.Side {
+	case SideBuy:
+		if !facts.Swap.IsBuy() {
+			return false
+		}
+	case SideSell:
+		if !facts.Swap.IsSell() {
+			return false
+		}
+	default:
+		if !facts.Swap.IsBuy() && !facts.Swap.IsSell() {
+			return false
+		}
+	}
+
+	if len(r.Tokens) > 0 && !r.matchesToken(facts) {
+		return false
+	}
+	if r.MinBTC > 0 && facts.BTCAmount < r.MinBTC {
+		return false
+	}
+	if r.MaxBTC > 0 && facts.BTCAmount > r.MaxBTC {
+		return false
+	}
+
+	if r.MinMarketCapUSD > 0 || r.MaxMarketCapUSD > 0 {
+		marketCap := facts.MarketCapUSD()
+		if marketCap <= 0 {
+			return false
+		}
+		if r.MinMarketCapUSD > 0 && marketCap < r.MinMarketCapUSD {
+			return false
+		}
+		if r.MaxMarketCapUSD > 0 && marketCap > r.MaxMarketCapUSD {
+			return false
+		}
+	}
+
+	if r.MinSellPctOfHoldings > 0 {
+		if !facts.Swap.IsSell() || facts.SellPctOfHoldings() < r.MinSellPctOfHoldings {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Rule) matchesToken(facts *SwapFacts) bool {
+	for _, token := range r.Tokens {
+		token = strings.TrimSpace(token)
+		if token == facts.Swap.PoolLpPublicKey || (facts.Ticker != "" && strings.EqualFold(token, facts.Ticker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadRules loads and validates rules file (.yaml, .yml or .json)
+func LoadRules(path string) ([]Rule, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert rules file: %w", err)
+	}
+
+	var file RulesFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(raw, &file)
+	default:
+		err = yaml.Unmarshal(raw, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse alert rules file: %w", err)
+	}
+
+	for i := range file.Rules {
+		rule := &file.Rules[i]
+		rule.Side = strings.ToLower(strings.TrimSpace(rule.Side))
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if rule.Side != "" && rule.Side != SideBuy && rule.Side != SideSell {
+			return nil, fmt.Errorf("alert rule %q: side must be buy, sell or empty", rule.Name)
+		}
+		if len(rule.ChatIDs) == 0 {
+			return nil, fmt.Errorf("alert rule %q: chat_ids is required", rule.Name)
+		}
+	}
+	return file.Rules, nil
+}
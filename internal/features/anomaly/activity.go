@@ -0,0 +1,238 @@
+package anomaly
+
+// Daily market activity archive (data_out/telegram_out/activity.json)
+// Filled by Big Sales monitor from every new swap, used by anomaly report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// activityRetentionDays - days kept in archive
+	activityRetentionDays = 60
+)
+
+// ActivityFile - daily activity archive
+func ActivityFile() string {
+	return paths.Output("telegram_out", "activity.json")
+}
+
+// TokenActivity - per-pool activity for one day
+type TokenActivity struct {
+	Swaps      int     `json:"swaps"`
+	VolumeBTC  float64 `json:"volume_btc"`
+	NetFlowBTC float64 `json:"net_flow_btc"` // buys - sells
+}
+
+// DailyActivity - activity for one day (MSK)
+type DailyActivity struct {
+	Date       string                    `json:"date"` // YYYY-MM-DD
+	SwapCount  int                       `json:"swap_count"`
+	AlertCount int                       `json:"alert_count"`
+	VolumeBTC  float64                   `json:"volume_btc"`
+	Tokens     map[string]*TokenActivity `json:"tokens"`  // poolLpPublicKey -> activity
+	Wallets    map[string]float64        `json:"wallets"` // swapper -> volume BTC
+	Reported   bool                      `json:"reported"`
+}
+
+// ActivityData - file structure for activity.json
+type ActivityData struct {
+	Days map[string]*DailyActivity `json:"days"`
+}
+
+var activityMutex sync.Mutex
+
+// LoadActivityData loads activity archive
+// Returns empty archive if file doesn't exist
+func LoadActivityData() (*ActivityData, error) {
+	activityMutex.Lock()
+	defer activityMutex.Unlock()
+	return loadActivityUnlocked()
+}
+
+// RecordActivity adds swaps and sent alerts count to today's entry
+func RecordActivity(swaps []flashnet.Swap, alertsSent int) error {
+	if len(swaps) == 0 && alertsSent == 0 {
+		return nil
+	}
+
+	activityMutex.Lock()
+	defer activityMutex.Unlock()
+
+	data, err := loadActivityUnlocked()
+	if err != nil {
+		data = &ActivityData{Days: make(map[string]*DailyActivity)}
+	}
+
+	day := getOrCreateDay(data, activityDate(time.Now()))
+	day.AlertCount += alertsSent
+
+	var parseErrs []error
+	for _, swap := range swaps {
+		btcValue, isBuy, ok, err := swapBTCValue(swap)
+		day.SwapCount++
+		if err != nil {
+			parseErrs = append(parseErrs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		day.VolumeBTC += btcValue
+
+		token, exists := day.Tokens[swap.PoolLpPublicKey]
+		if !exists {
+			token = &TokenActivity{}
+			day.Tokens[swap.PoolLpPublicKey] = token
+		}
+		token.Swaps++
+		token.VolumeBTC += btcValue
+		if isBuy {
+			token.NetFlowBTC += btcValue
+		} else {
+			token.NetFlowBTC -= btcValue
+		}
+
+		if swap.SwapperPublicKey != "" {
+			day.Wallets[swap.SwapperPublicKey] += btcValue
+		}
+	}
+
+	pruneActivity(data)
+	if err := saveActivityUnlocked(data); err != nil {
+		return err
+	}
+	// Swaps with invalid amount are counted without volume
+	return errors.Join(parseErrs...)
+}
+
+// MarkReported marks day as already reported
+func MarkReported(date string) error {
+	activityMutex.Lock()
+	defer activityMutex.Unlock()
+
+	data, err := loadActivityUnlocked()
+	if err != nil {
+		return err
+	}
+
+	getOrCreateDay(data, date).Reported = true
+	return saveActivityUnlocked(data)
+}
+
+func getOrCreateDay(data *ActivityData, date string) *DailyActivity {
+	day, exists := data.Days[date]
+	if !exists {
+		day = &DailyActivity{Date: date}
+		data.Days[date] = day
+	}
+	if day.Tokens == nil {
+		day.Tokens = make(map[string]*TokenActivity)
+	}
+	if day.Wallets == nil {
+		day.Wallets = make(map[string]float64)
+	}
+	return day
+}
+
+// swapBTCValue returns BTC side of buy/sell swap, ok=false for token-to-token
+func swapBTCValue(swap flashnet.Swap) (float64, bool, bool, error) {
+	var satoshiStr string
+	isBuy := false
+	switch swap.GetSwapType() {
+	case flashnet.SwapTypeBuy:
+		satoshiStr = swap.AmountIn
+		isBuy = true
+	case flashnet.SwapTypeSell:
+		satoshiStr = swap.AmountOut
+	default:
+		return 0, false, false, nil
+	}
+
+	btc, err := amount.SatsToBTCFloat(satoshiStr)
+	if err != nil {
+		return 0, false, false, fmt.Errorf("failed to parse BTC amount of swap %s: %w", swap.ID, err)
+	}
+	return btc, isBuy, true, nil
+}
+
+func pruneActivity(data *ActivityData) {
+	if len(data.Days) <= activityRetentionDays {
+		return
+	}
+	dates := make([]string, 0, len(data.Days))
+	for date := range data.Days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	for _, date := range dates[:len(dates)-activityRetentionDays] {
+		delete(data.Days, date)
+	}
+}
+
+// activityDate returns YYYY-MM-DD in MSK
+func activityDate(t time.Time) string {
+	moscowLocation, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		moscowLocation = time.UTC
+	}
+	return t.In(moscowLocation).Format("2006-01-02")
+}
+
+func loadActivityUnlocked() (*ActivityData, error) {
+	if _, err := os.Stat(ActivityFile()); os.IsNotExist(err) {
+		return &ActivityData{Days: make(map[string]*DailyActivity)}, nil
+	}
+
+	raw, err := os.ReadFile(ActivityFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read activity file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &ActivityData{Days: make(map[string]*DailyActivity)}, nil
+	}
+
+	var data ActivityData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse activity JSON: %w", err)
+	}
+	if data.Days == nil {
+		data.Days = make(map[string]*DailyActivity)
+	}
+	return &data, nil
+}
+
+func saveActivityUnlocked(data *ActivityData) error {
+	if err := os.MkdirAll(filepath.Dir(ActivityFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity JSON: %w", err)
+	}
+
+	tempFilePath := ActivityFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary activity file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, ActivityFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to activity file: %w", err)
+	}
+	return nil
+}
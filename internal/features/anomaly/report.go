@@ -0,0 +1,240 @@
+package anomaly
+
+// Unusual activity report: day metrics compared with trailing average
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/wallet_labels"
+)
+
+const (
+	// SigmaThreshold - deviation (in stddev) which makes day unusual
+	SigmaThreshold = 3.0
+	// TrailingDays - days used for trailing average
+	TrailingDays = 14
+	// minTrailingDays - minimum history required for detection
+	minTrailingDays = 7
+	// reportTopCount - rows in top tokens/wallets sections
+	reportTopCount = 5
+)
+
+// Metric - one day metric compared with trailing window
+type Metric struct {
+	Name   string
+	Value  float64
+	Mean   float64
+	StdDev float64
+	ZScore float64
+}
+
+// IsUnusual returns true if metric deviates more than SigmaThreshold
+func (m Metric) IsUnusual() bool {
+	return math.Abs(m.ZScore) > SigmaThreshold
+}
+
+// DetectAnomalies compares date (YYYY-MM-DD) with previous TrailingDays
+// Returns only unusual metrics and day activity (nil if no data for day)
+func DetectAnomalies(date string) ([]Metric, *DailyActivity, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid date %s: %w", date, err)
+	}
+
+	data, err := LoadActivityData()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statsVolume := make(map[string]float64)
+	if statsData, err := luminex.LoadStatsData(); err == nil {
+		for _, entry := range statsData.Entries {
+			statsVolume[entry.Date] = entry.TotalVolume24HUSD
+		}
+	}
+
+	series := map[string][]float64{}
+	current := map[string]float64{}
+	has := map[string]bool{}
+
+	for i := 0; i <= TrailingDays; i++ {
+		key := day.AddDate(0, 0, -i).Format("2006-01-02")
+		values := map[string]float64{}
+		if activity, ok := data.Days[key]; ok {
+			values["Swaps"] = float64(activity.SwapCount)
+			values["Alerts"] = float64(activity.AlertCount)
+			values["Volume BTC"] = activity.VolumeBTC
+		}
+		if volume, ok := statsVolume[key]; ok {
+			values["Volume USD (Luminex)"] = volume
+		}
+
+		for name, value := range values {
+			if i == 0 {
+				current[name] = value
+				has[name] = true
+			} else {
+				series[name] = append(series[name], value)
+			}
+		}
+	}
+
+	var unusual []Metric
+	for _, name := range []string{"Volume BTC", "Swaps", "Alerts", "Volume USD (Luminex)"} {
+		if !has[name] || len(series[name]) < minTrailingDays {
+			continue
+		}
+		mean, stddev := meanStdDev(series[name])
+		if stddev == 0 {
+			continue
+		}
+		metric := Metric{
+			Name:   name,
+			Value:  current[name],
+			Mean:   mean,
+			StdDev: stddev,
+			ZScore: (current[name] - mean) / stddev,
+		}
+		if metric.IsUnusual() {
+			unusual = append(unusual, metric)
+		}
+	}
+
+	return unusual, data.Days[date], nil
+}
+
+// GenerateAnomalyReport builds HTML report for date if day is unusual
+// Returns empty string if nothing unusual happened
+func GenerateAnomalyReport(date string) (string, error) {
+	metrics, activity, err := DetectAnomalies(date)
+	if err != nil {
+		return "", err
+	}
+	if len(metrics) == 0 {
+		return "", nil
+	}
+
+	parsedDate, _ := time.Parse("2006-01-02", date)
+
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("⚠️ Unusual activity for %s\n\n", parsedDate.Format("02 Jan")))
+
+	report.WriteString("<blockquote>")
+	for i, metric := range metrics {
+		if i > 0 {
+			report.WriteString("\n")
+		}
+		report.WriteString(fmt.Sprintf("%s: <code>%s</code> (avg %s, %+.1fσ)",
+			metric.Name, formatMetricValue(metric.Value), formatMetricValue(metric.Mean), metric.ZScore))
+	}
+	report.WriteString("</blockquote>")
+
+	if activity == nil {
+		return report.String(), nil
+	}
+
+	if len(activity.Tokens) > 0 {
+		type tokenRow struct {
+			pool     string
+			activity *TokenActivity
+		}
+		rows := make([]tokenRow, 0, len(activity.Tokens))
+		var netFlow float64
+		for pool, tokenActivity := range activity.Tokens {
+			rows = append(rows, tokenRow{pool: pool, activity: tokenActivity})
+			netFlow += tokenActivity.NetFlowBTC
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			return rows[i].activity.VolumeBTC > rows[j].activity.VolumeBTC
+		})
+
+		report.WriteString("\n\nTop tokens:\n")
+		for i, row := range rows {
+			if i >= reportTopCount {
+				break
+			}
+			report.WriteString(fmt.Sprintf("%d. %s – %s BTC (net %s, %d swaps)\n",
+				i+1, html.EscapeString(tokenLabel(row.pool)), formatMetricValue(row.activity.VolumeBTC),
+				amount.FormatSignedBTC(row.activity.NetFlowBTC), row.activity.Swaps))
+		}
+		report.WriteString(fmt.Sprintf("\nNet flow: %s BTC", amount.FormatSignedBTC(netFlow)))
+	}
+
+	if len(activity.Wallets) > 0 {
+		type walletRow struct {
+			wallet string
+			volume float64
+		}
+		rows := make([]walletRow, 0, len(activity.Wallets))
+		for wallet, volume := range activity.Wallets {
+			rows = append(rows, walletRow{wallet: wallet, volume: volume})
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].volume > rows[j].volume })
+
+		report.WriteString("\n\nTop wallets:\n")
+		for i, row := range rows {
+			if i >= reportTopCount {
+				break
+			}
+			report.WriteString(fmt.Sprintf("%d. %s – %s BTC\n",
+				i+1, html.EscapeString(walletLabel(row.wallet)), formatMetricValue(row.volume)))
+		}
+	}
+
+	return strings.TrimRight(report.String(), "\n"), nil
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+	return mean, math.Sqrt(variance)
+}
+
+func tokenLabel(poolLpPublicKey string) string {
+	if metadata := luminex.GetTokenMetadata(poolLpPublicKey); metadata != nil && metadata.Ticker != "" {
+		return metadata.Ticker
+	}
+	return shortKey(poolLpPublicKey)
+}
+
+func walletLabel(publicKey string) string {
+	if label := wallet_labels.Of(publicKey); label != "" {
+		return label
+	}
+	if username := luminex.GetWalletUsername(publicKey); username != "" {
+		return username
+	}
+	return shortKey(publicKey)
+}
+
+func shortKey(key string) string {
+	if len(key) <= 10 {
+		return key
+	}
+	return key[:4] + "..." + key[len(key)-4:]
+}
+
+func formatMetricValue(value float64) string {
+	if value >= 1000 {
+		return luminex.FormatUSDValue(value)
+	}
+	formatted := fmt.Sprintf("%.4f", value)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimRight(formatted, ".")
+}
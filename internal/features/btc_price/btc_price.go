@@ -0,0 +1,189 @@
+package btc_price
+
+// Daily BTC price history (data_out/telegram_out/btc_price_history.json)
+// Used to show USD equivalents of BTC values in reports at the report's date, not at today's price
+// Prices are sampled from current price feed (feed.go)
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// maxPriceGapDays - older price is used if date has no price (e.g. bot was down that day)
+	maxPriceGapDays = 3
+)
+
+// PriceHistoryFile - daily BTC prices
+func PriceHistoryFile() string {
+	return paths.Output("telegram_out", "btc_price_history.json")
+}
+
+// DailyPrice - BTC price for one day (last sample of the day)
+type DailyPrice struct {
+	Date      string  `json:"date"` // YYYY-MM-DD (same local date as flow and holders changes)
+	PriceUSD  float64 `json:"price_usd"`
+	UpdatedAt string  `json:"updated_at"` // RFC3339
+}
+
+// PriceHistory - file structure for btc_price_history.json
+type PriceHistory struct {
+	Prices map[string]DailyPrice `json:"prices"` // date (YYYY-MM-DD) -> price
+}
+
+var priceHistoryMutex sync.Mutex
+
+// LoadPriceHistory loads daily BTC prices
+// Returns empty history if file doesn't exist
+func LoadPriceHistory() (*PriceHistory, error) {
+	priceHistoryMutex.Lock()
+	defer priceHistoryMutex.Unlock()
+	return loadPriceHistoryUnlocked()
+}
+
+// RecordPrice saves BTC price for date (overwrites earlier sample of the same day)
+func RecordPrice(date time.Time, priceUSD float64) error {
+	if priceUSD <= 0 {
+		return fmt.Errorf("invalid BTC price: %f", priceUSD)
+	}
+
+	priceHistoryMutex.Lock()
+	defer priceHistoryMutex.Unlock()
+
+	history, err := loadPriceHistoryUnlocked()
+	if err != nil {
+		return err
+	}
+
+	dateKey := date.Format("2006-01-02")
+	history.Prices[dateKey] = DailyPrice{
+		Date:      dateKey,
+		PriceUSD:  priceUSD,
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	return savePriceHistoryUnlocked(history)
+}
+
+// PriceForDate returns BTC price for date (YYYY-MM-DD)
+// If date has no price, nearest earlier price within maxPriceGapDays is used,
+// today without sample yet uses cached current price
+// Returns false if no price is known
+func PriceForDate(date string) (float64, bool) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 0, false
+	}
+
+	history, err := LoadPriceHistory()
+	if err != nil {
+		return 0, false
+	}
+
+	for gap := 0; gap <= maxPriceGapDays; gap++ {
+		key := day.AddDate(0, 0, -gap).Format("2006-01-02")
+		if price, exists := history.Prices[key]; exists && price.PriceUSD > 0 {
+			return price.PriceUSD, true
+		}
+	}
+	if date == time.Now().Format("2006-01-02") {
+		return CachedPriceUSD()
+	}
+	return 0, false
+}
+
+// ToUSD converts BTC amount to USD at price of date (YYYY-MM-DD)
+// Returns false if no price is known for date
+func ToUSD(btcAmount float64, date string) (float64, bool) {
+	price, ok := PriceForDate(date)
+	if !ok {
+		return 0, false
+	}
+	return btcAmount * price, true
+}
+
+// FetchPriceUSD gets current BTC price from Luminex data of first pool that has it
+// (BTC side price or token price USD / token price BTC)
+func FetchPriceUSD(poolLpPublicKeys []string) (float64, error) {
+	if len(poolLpPublicKeys) == 0 {
+		return 0, fmt.Errorf("no pools to get BTC price from")
+	}
+
+	var lastErr error
+	for _, poolLpPublicKey := range poolLpPublicKeys {
+		poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		// BTC side and token side of pool
+		btcMeta, tokenMeta := poolData.TokenBMetadata, poolData.TokenAMetadata
+		if poolData.AssetBAddress != flashnet.NativeTokenAddress {
+			btcMeta, tokenMeta = poolData.TokenAMetadata, poolData.TokenBMetadata
+		}
+
+		if btcMeta.AggPriceUsd > 0 {
+			return btcMeta.AggPriceUsd, nil
+		}
+		if tokenMeta.AggPriceUsd > 0 && tokenMeta.AggPriceBtc > 0 {
+			return tokenMeta.AggPriceUsd / tokenMeta.AggPriceBtc, nil
+		}
+		lastErr = fmt.Errorf("pool %s has no price data", poolLpPublicKey)
+	}
+
+	return 0, fmt.Errorf("failed to get BTC price: %w", lastErr)
+}
+
+func loadPriceHistoryUnlocked() (*PriceHistory, error) {
+	if _, err := os.Stat(PriceHistoryFile()); os.IsNotExist(err) {
+		return &PriceHistory{Prices: make(map[string]DailyPrice)}, nil
+	}
+
+	raw, err := os.ReadFile(PriceHistoryFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read BTC price history file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &PriceHistory{Prices: make(map[string]DailyPrice)}, nil
+	}
+
+	var history PriceHistory
+	if err := json.Unmarshal(raw, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse BTC price history JSON: %w", err)
+	}
+	if history.Prices == nil {
+		history.Prices = make(map[string]DailyPrice)
+	}
+	return &history, nil
+}
+
+func savePriceHistoryUnlocked(history *PriceHistory) error {
+	if err := os.MkdirAll(filepath.Dir(PriceHistoryFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal BTC price history JSON: %w", err)
+	}
+
+	tempFilePath := PriceHistoryFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary BTC price history file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, PriceHistoryFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to BTC price history file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package btc_price
+
+// Current BTC/USD price for alerts and reports
+// Sources implement Feed (CoinGecko, Luminex pool data), configured source is tried first and the other one is fallback
+// Price is cached for PriceCacheTTL; if refresh fails, last price is used while it is younger than maxStalePrice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// SourceCoinGecko - CoinGecko simple price API
+	SourceCoinGecko = "coingecko"
+	// SourceLuminex - BTC price from Luminex data of tracked pools
+	SourceLuminex = "luminex"
+
+	// PriceCacheTTL - price is refreshed after this time
+	PriceCacheTTL = 5 * time.Minute
+	// maxStalePrice - cached price is still used when refresh fails
+	maxStalePrice = time.Hour
+
+	coinGeckoPriceURL = "https://api.coingecko.com/api/v3/simple/price?ids=bitcoin&vs_currencies=usd"
+)
+
+// Feed - source of current BTC price in USD
+type Feed interface {
+	Name() string
+	PriceUSD(ctx context.Context) (float64, error)
+}
+
+// CoinGeckoFeed - BTC price from CoinGecko
+type CoinGeckoFeed struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewCoinGeckoFeed creates CoinGecko feed with public API URL
+func NewCoinGeckoFeed() *CoinGeckoFeed {
+	return &CoinGeckoFeed{
+		URL:        coinGeckoPriceURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (f *CoinGeckoFeed) Name() string { return SourceCoinGecko }
+
+// PriceUSD requests current BTC price
+func (f *CoinGeckoFeed) PriceUSD(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create CoinGecko request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to request CoinGecko price: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read CoinGecko response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("CoinGecko returned status %d", resp.StatusCode)
+	}
+
+	var prices map[string]map[string]float64 // {"bitcoin": {"usd": 67000}}
+	if err := json.Unmarshal(body, &prices); err != nil {
+		return 0, fmt.Errorf("failed to parse CoinGecko response: %w", err)
+	}
+	price := prices["bitcoin"]["usd"]
+	if price <= 0 {
+		return 0, fmt.Errorf("CoinGecko response has no BTC price")
+	}
+	return price, nil
+}
+
+// LuminexFeed - BTC price from Luminex data of pools (see FetchPriceUSD)
+type LuminexFeed struct {
+	Pools func() []string // pools to read price from
+}
+
+func (f *LuminexFeed) Name() string { return SourceLuminex }
+
+// PriceUSD gets BTC price from first pool that has it
+func (f *LuminexFeed) PriceUSD(ctx context.Context) (float64, error) {
+	if f.Pools == nil {
+		return 0, fmt.Errorf("no pools to get BTC price from")
+	}
+	return FetchPriceUSD(f.Pools())
+}
+
+// FallbackFeed tries feeds in order until one returns price
+type FallbackFeed []Feed
+
+func (f FallbackFeed) Name() string {
+	names := make([]string, 0, len(f))
+	for _, feed := range f {
+		names = append(names, feed.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+// PriceUSD returns price of first feed that succeeds
+func (f FallbackFeed) PriceUSD(ctx context.Context) (float64, error) {
+	var errs []string
+	for _, feed := range f {
+		price, err := feed.PriceUSD(ctx)
+		if err == nil {
+			return price, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", feed.Name(), err))
+	}
+	return 0, fmt.Errorf("all BTC price feeds failed: %s", strings.Join(errs, "; "))
+}
+
+// CachedFeed - feed with cached price
+type CachedFeed struct {
+	feed Feed
+	ttl  time.Duration
+
+	mutex     sync.Mutex
+	price     float64
+	fetchedAt time.Time
+}
+
+// NewCachedFeed caches prices of feed for ttl
+func NewCachedFeed(feed Feed, ttl time.Duration) *CachedFeed {
+	return &CachedFeed{feed: feed, ttl: ttl}
+}
+
+func (f *CachedFeed) Name() string { return f.feed.Name() }
+
+// PriceUSD returns cached price or refreshes it
+// If refresh fails, cached price younger than maxStalePrice is returned
+func (f *CachedFeed) PriceUSD(ctx context.Context) (float64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.price > 0 && time.Since(f.fetchedAt) < f.ttl {
+		return f.price, nil
+	}
+
+	price, err := f.feed.PriceUSD(ctx)
+	if err != nil {
+		if f.price > 0 && time.Since(f.fetchedAt) < maxStalePrice {
+			return f.price, nil
+		}
+		return 0, err
+	}
+
+	f.price = price
+	f.fetchedAt = time.Now()
+	return price, nil
+}
+
+// Cached returns last price without network request (false if none or older than maxStalePrice)
+func (f *CachedFeed) Cached() (float64, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.price <= 0 || time.Since(f.fetchedAt) >= maxStalePrice {
+		return 0, false
+	}
+	return f.price, true
+}
+
+// NewFeed creates feed of source with the other source as fallback
+// pools - pools for Luminex source
+func NewFeed(source string, pools func() []string) (Feed, error) {
+	coinGecko := NewCoinGeckoFeed()
+	luminexFeed := &LuminexFeed{Pools: pools}
+
+	switch strings.ToLower(strings.TrimSpace(source)) {
+	case SourceCoinGecko, "":
+		return FallbackFeed{coinGecko, luminexFeed}, nil
+	case SourceLuminex:
+		return FallbackFeed{luminexFeed, coinGecko}, nil
+	default:
+		return nil, fmt.Errorf("unknown BTC price source %q: must be %s or %s", source, SourceCoinGecko, SourceLuminex)
+	}
+}
+
+// defaultFeed - feed used by CurrentPriceUSD and CachedPriceUSD (CoinGecko until SetFeed)
+var defaultFeed atomic.Pointer[CachedFeed]
+
+func init() {
+	defaultFeed.Store(NewCachedFeed(NewCoinGeckoFeed(), PriceCacheTTL))
+}
+
+// SetFeed sets feed of current BTC price (cached for PriceCacheTTL)
+func SetFeed(feed Feed) {
+	defaultFeed.Store(NewCachedFeed(feed, PriceCacheTTL))
+}
+
+// CurrentPriceUSD returns current BTC price (cached)
+func CurrentPriceUSD(ctx context.Context) (float64, error) {
+	return defaultFeed.Load().PriceUSD(ctx)
+}
+
+// CachedPriceUSD returns last known BTC price without network request
+func CachedPriceUSD() (float64, bool) {
+	return defaultFeed.Load().Cached()
+}
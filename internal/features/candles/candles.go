@@ -0,0 +1,163 @@
+package candles
+
+// OHLCV candles of token price built from swaps archived by big sales monitor (data_out/archive/swaps)
+// Price of swap = BTC amount / token amount (BTC per token), token-to-token swaps are skipped
+// Buckets without swaps get flat candle at previous close, so chart has no holes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
+)
+
+// Timeframe - candle duration
+type Timeframe struct {
+	Name     string // 1m, 5m or 1h
+	Duration time.Duration
+	Count    int // candles shown by default
+}
+
+// Supported timeframes
+var (
+	Timeframe1m = Timeframe{Name: "1m", Duration: time.Minute, Count: 60}
+	Timeframe5m = Timeframe{Name: "5m", Duration: 5 * time.Minute, Count: 72}
+	Timeframe1h = Timeframe{Name: "1h", Duration: time.Hour, Count: 48}
+)
+
+// Timeframes - supported timeframes, shortest first
+var Timeframes = []Timeframe{Timeframe1m, Timeframe5m, Timeframe1h}
+
+// Candle - price of token in one bucket (BTC per token)
+type Candle struct {
+	Time      time.Time // bucket start (UTC)
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	VolumeBTC float64
+	Trades    int // 0 - flat candle of empty bucket
+}
+
+// ParseTimeframe returns timeframe by name (1m, 5m, 1h)
+func ParseTimeframe(name string) (Timeframe, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, timeframe := range Timeframes {
+		if timeframe.Name == name {
+			return timeframe, nil
+		}
+	}
+	return Timeframe{}, fmt.Errorf("timeframe must be 1m, 5m or 1h")
+}
+
+// LoadCandles builds last timeframe.Count candles of pool up to now from swap archive
+// decimals - token decimals (raw token amount / 10^decimals)
+// Returns empty list if pool had no swaps in the period
+func LoadCandles(poolLpPublicKey string, decimals int, timeframe Timeframe, now time.Time) ([]Candle, error) {
+	now = now.UTC()
+	end := now.Truncate(timeframe.Duration).Add(timeframe.Duration)
+	start := end.Add(-time.Duration(timeframe.Count) * timeframe.Duration)
+
+	// Price before period start is needed for flat candles at the beginning, previous day is enough for it
+	var swaps []flashnet.Swap
+	for day := start.AddDate(0, 0, -1).Truncate(24 * time.Hour); !day.After(now); day = day.AddDate(0, 0, 1) {
+		daySwaps, err := storage.LoadDailySwaps(day.Format("2006-01-02"))
+		if err != nil {
+			return nil, err
+		}
+		for _, swap := range daySwaps {
+			if swap.PoolLpPublicKey == poolLpPublicKey {
+				swaps = append(swaps, swap)
+			}
+		}
+	}
+
+	return Build(swaps, decimals, timeframe, start, end), nil
+}
+
+// Build aggregates swaps of one pool into candles of [start, end)
+// Swaps before start only set opening price, leading buckets before first known price are dropped
+func Build(swaps []flashnet.Swap, decimals int, timeframe Timeframe, start time.Time, end time.Time) []Candle {
+	type trade struct {
+		time      time.Time
+		price     float64
+		volumeBTC float64
+	}
+
+	var trades []trade
+	for _, swap := range swaps {
+		price, volumeBTC, ok := swapPrice(swap, decimals)
+		if !ok {
+			continue
+		}
+		at, known := storage.SwapTime(swap)
+		if !known {
+			continue
+		}
+		trades = append(trades, trade{time: at.UTC(), price: price, volumeBTC: volumeBTC})
+	}
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].time.Before(trades[j].time) })
+
+	var candles []Candle
+	lastPrice := 0.0
+	next := 0
+	for bucket := start; bucket.Before(end); bucket = bucket.Add(timeframe.Duration) {
+		// Trades before bucket (only before start on first iteration) move last price
+		for next < len(trades) && trades[next].time.Before(bucket) {
+			lastPrice = trades[next].price
+			next++
+		}
+
+		candle := Candle{Time: bucket, Open: lastPrice, High: lastPrice, Low: lastPrice, Close: lastPrice}
+		bucketEnd := bucket.Add(timeframe.Duration)
+		for next < len(trades) && trades[next].time.Before(bucketEnd) {
+			t := trades[next]
+			if candle.Trades == 0 && lastPrice == 0 {
+				candle.Open, candle.High, candle.Low = t.price, t.price, t.price
+			}
+			candle.High = max(candle.High, t.price)
+			candle.Low = min(candle.Low, t.price)
+			candle.Close = t.price
+			candle.VolumeBTC += t.volumeBTC
+			candle.Trades++
+			lastPrice = t.price
+			next++
+		}
+
+		if candle.Close == 0 {
+			continue
+		}
+		candles = append(candles, candle)
+	}
+	return candles
+}
+
+// swapPrice returns price (BTC per token) and BTC volume of buy or sell swap
+func swapPrice(swap flashnet.Swap, decimals int) (float64, float64, bool) {
+	var satsAmount, tokenAmount string
+	switch swap.GetSwapType() {
+	case flashnet.SwapTypeBuy:
+		satsAmount, tokenAmount = swap.AmountIn, swap.AmountOut
+	case flashnet.SwapTypeSell:
+		satsAmount, tokenAmount = swap.AmountOut, swap.AmountIn
+	default:
+		return 0, 0, false
+	}
+
+	sats, err := strconv.ParseFloat(satsAmount, 64)
+	if err != nil || sats <= 0 {
+		return 0, 0, false
+	}
+	tokens, err := amount.ScaleFloat(tokenAmount, decimals)
+	if err != nil || tokens <= 0 {
+		return 0, 0, false
+	}
+
+	volumeBTC := sats / 1e8
+	return volumeBTC / tokens, volumeBTC, true
+}
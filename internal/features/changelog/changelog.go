@@ -0,0 +1,61 @@
+package changelog
+
+// User-facing changelog (/whatsnew)
+// Releases are declared in changelog.yaml, embedded into binary at build time
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+)
+
+//go:embed changelog.yaml
+var changelogYAML []byte
+
+// Release - changes of one release
+type Release struct {
+	Version string   `yaml:"version"`
+	Date    string   `yaml:"date"` // YYYY-MM-DD, may be empty
+	Changes []string `yaml:"changes"`
+}
+
+type changelogFile struct {
+	Releases []Release `yaml:"releases"`
+}
+
+var (
+	releases     []Release
+	releasesErr  error
+	releasesOnce sync.Once
+)
+
+// Releases returns all releases, newest first
+func Releases() ([]Release, error) {
+	releasesOnce.Do(func() {
+		var file changelogFile
+		if err := yaml.Unmarshal(changelogYAML, &file); err != nil {
+			releasesErr = fmt.Errorf("failed to parse changelog: %w", err)
+			return
+		}
+		releases = file.Releases
+	})
+	return releases, releasesErr
+}
+
+// Find returns release by version ("v1.1.0" and "1.1.0" are the same), nil if not found
+func Find(version string) (*Release, error) {
+	all, err := Releases()
+	if err != nil {
+		return nil, err
+	}
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	for i := range all {
+		if all[i].Version == version {
+			return &all[i], nil
+		}
+	}
+	return nil, nil
+}
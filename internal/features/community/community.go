@@ -0,0 +1,191 @@
+package community
+
+// Community chat member counts (data_out/telegram_out/community.json) and their overlay with token activity
+// Member count is sampled per day, price and volume come from token price history (price_history)
+// Comparing both shows whether community growth (marketing pushes) turns into buys
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/features/price_history"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// maxMemberSamples - days kept per ticker
+	maxMemberSamples = 365
+)
+
+// CommunityFile - daily member counts per ticker
+func CommunityFile() string {
+	return paths.Output("telegram_out", "community.json")
+}
+
+// MemberSample - member count of community chat on date
+type MemberSample struct {
+	Date    string `json:"date"` // YYYY-MM-DD (UTC)
+	Members int    `json:"members"`
+}
+
+// CommunityData - file structure for community.json
+type CommunityData struct {
+	Tickers map[string][]MemberSample `json:"tickers"` // ticker (upper case) -> samples, oldest first
+}
+
+// Point - one day of community chart
+type Point struct {
+	Date         time.Time
+	Members      int     // 0 - no sample on date
+	PriceUsd     float64 // 0 - no price sample on date
+	Volume24hUsd float64
+}
+
+var communityMutex sync.Mutex
+
+// RecordMemberCount saves member count of ticker community for date of now (latest sample of day wins)
+func RecordMemberCount(ticker string, members int, now time.Time) error {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	date := now.UTC().Format("2006-01-02")
+
+	communityMutex.Lock()
+	defer communityMutex.Unlock()
+
+	data, err := loadCommunityUnlocked()
+	if err != nil {
+		return err
+	}
+
+	samples := data.Tickers[ticker]
+	if len(samples) > 0 && samples[len(samples)-1].Date == date {
+		samples[len(samples)-1].Members = members
+	} else {
+		samples = append(samples, MemberSample{Date: date, Members: members})
+	}
+	if len(samples) > maxMemberSamples {
+		samples = samples[len(samples)-maxMemberSamples:]
+	}
+	data.Tickers[ticker] = samples
+
+	return saveCommunityUnlocked(data)
+}
+
+// LoadMemberHistory returns member counts of ticker community (oldest first)
+func LoadMemberHistory(ticker string) ([]MemberSample, error) {
+	communityMutex.Lock()
+	defer communityMutex.Unlock()
+
+	data, err := loadCommunityUnlocked()
+	if err != nil {
+		return nil, err
+	}
+	return data.Tickers[strings.ToUpper(strings.TrimSpace(ticker))], nil
+}
+
+// BuildPoints joins member counts with daily price and volume of pool over last days
+// Price and volume of day are taken from the last price sample of that day
+func BuildPoints(ticker string, poolLpPublicKey string, days int, now time.Time) ([]Point, error) {
+	members, err := LoadMemberHistory(ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	points := make(map[string]*Point)
+	pointFor := func(date time.Time) *Point {
+		key := date.Format("2006-01-02")
+		if point, exists := points[key]; exists {
+			return point
+		}
+		point := &Point{Date: date}
+		points[key] = point
+		return point
+	}
+
+	for _, sample := range members {
+		date, err := time.Parse("2006-01-02", sample.Date)
+		if err != nil || date.Before(since) {
+			continue
+		}
+		pointFor(date).Members = sample.Members
+	}
+
+	if poolLpPublicKey != "" {
+		samples, err := price_history.SamplesSince(poolLpPublicKey, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load price history: %w", err)
+		}
+		for _, sample := range samples {
+			sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+			if err != nil {
+				continue
+			}
+			point := pointFor(time.Date(sampleTime.Year(), sampleTime.Month(), sampleTime.Day(), 0, 0, 0, 0, time.UTC))
+			point.PriceUsd = sample.PriceUsd
+			point.Volume24hUsd = sample.Volume24hUsd
+		}
+	}
+
+	result := make([]Point, 0, len(points))
+	for _, point := range points {
+		result = append(result, *point)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result, nil
+}
+
+func loadCommunityUnlocked() (*CommunityData, error) {
+	if _, err := os.Stat(CommunityFile()); os.IsNotExist(err) {
+		return &CommunityData{Tickers: make(map[string][]MemberSample)}, nil
+	}
+
+	raw, err := os.ReadFile(CommunityFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read community file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &CommunityData{Tickers: make(map[string][]MemberSample)}, nil
+	}
+
+	var data CommunityData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse community JSON: %w", err)
+	}
+	if data.Tickers == nil {
+		data.Tickers = make(map[string][]MemberSample)
+	}
+	return &data, nil
+}
+
+func saveCommunityUnlocked(data *CommunityData) error {
+	if err := os.MkdirAll(filepath.Dir(CommunityFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal community JSON: %w", err)
+	}
+
+	tempFilePath := CommunityFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary community file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, CommunityFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to community file: %w", err)
+	}
+	return nil
+}
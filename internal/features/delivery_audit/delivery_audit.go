@@ -0,0 +1,178 @@
+package delivery_audit
+
+// Audit trail of swap alerts for /audit {swapID}: whether swap was seen and where its alerts went
+// Swaps and delivery outcomes (sent, failed, held, unsent) are read from event log (data_out/events),
+// so trail is kept as long as events (app.events_retention_days)
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/infra/events"
+)
+
+// LookbackDays - days of event log searched for swap
+const LookbackDays = 7
+
+// Entry - delivery of swap alert with time it was recorded
+type Entry struct {
+	Time time.Time
+	events.Delivery
+}
+
+// Trail - swap and outcomes of its alerts
+type Trail struct {
+	SwapID     string
+	Swap       *flashnet.Swap // nil - swap is not in event log
+	SeenAt     time.Time      // time swap was processed by Big Sales monitor
+	Deliveries []Entry        // in record order
+}
+
+// Lookup searches event log of last days (up to now) for swap and its deliveries
+func Lookup(swapID string, days int, now time.Time) (*Trail, error) {
+	trail := &Trail{SwapID: swapID}
+	if swapID == "" {
+		return trail, nil
+	}
+	needle := []byte(strconv.Quote(swapID))
+
+	now = now.UTC()
+	for day := now.AddDate(0, 0, 1-days); !day.After(now); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		err := events.ReadDay(date, func(event events.Event) error {
+			if !bytes.Contains(event.Data, needle) {
+				return nil
+			}
+			switch event.Type {
+			case events.TypeSwap:
+				var swap flashnet.Swap
+				if err := json.Unmarshal(event.Data, &swap); err != nil || swap.ID != swapID || trail.Swap != nil {
+					return nil
+				}
+				trail.Swap = &swap
+				trail.SeenAt = event.Time
+			case events.TypeDelivery:
+				var delivery events.Delivery
+				if err := json.Unmarshal(event.Data, &delivery); err != nil || delivery.SwapID != swapID {
+					return nil
+				}
+				trail.Deliveries = append(trail.Deliveries, Entry{Time: event.Time, Delivery: delivery})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read events of %s: %w", date, err)
+		}
+	}
+	return trail, nil
+}
+
+// Sent returns count of delivered alerts
+func (t *Trail) Sent() int {
+	sent := 0
+	for _, entry := range t.Deliveries {
+		if entry.Status == events.DeliverySent {
+			sent++
+		}
+	}
+	return sent
+}
+
+// Format formats trail for Telegram (HTML)
+func Format(trail *Trail, days int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "🔎 Swap <code>%s</code>\n", html.EscapeString(trail.SwapID))
+
+	if trail.Swap == nil && len(trail.Deliveries) == 0 {
+		fmt.Fprintf(&text, "Not seen by the bot in the last %d days: the swap is older, or the swap ID is wrong.", days)
+		return text.String()
+	}
+
+	if trail.Swap != nil {
+		fmt.Fprintf(&text, "Seen %s UTC: %s, pool <code>%s</code>\n",
+			trail.SeenAt.UTC().Format("2006-01-02 15:04:05"), describeSwap(*trail.Swap), html.EscapeString(trail.Swap.PoolLpPublicKey))
+	}
+	if len(trail.Deliveries) == 0 {
+		text.WriteString("No alerts: below the threshold of every chat, blacklisted or not filtered token, or no matching rule.")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "Alerts: %d sent of %d\n<blockquote>", trail.Sent(), len(trail.Deliveries))
+	for i, entry := range trail.Deliveries {
+		if i > 0 {
+			text.WriteString("\n")
+		}
+		text.WriteString(formatEntry(entry))
+	}
+	text.WriteString("</blockquote>")
+	return text.String()
+}
+
+func formatEntry(entry Entry) string {
+	icon := map[string]string{
+		events.DeliverySent:   "✅",
+		events.DeliveryFailed: "❌",
+		events.DeliveryHeld:   "⏸",
+		events.DeliveryUnsent: "⏹",
+	}[entry.Status]
+
+	line := fmt.Sprintf("%s %s %s, %s → <code>%d</code>", icon, entry.Time.UTC().Format("15:04:05"),
+		entry.Status, html.EscapeString(entry.Route), entry.ChatID)
+	if entry.MessageID != 0 {
+		if link := messageLink(entry.ChatID, entry.MessageID); link != "" {
+			line += fmt.Sprintf(", <a href=\"%s\">message %d</a>", link, entry.MessageID)
+		} else {
+			line += fmt.Sprintf(", message %d", entry.MessageID)
+		}
+	}
+	if entry.Format != "" {
+		line += ", " + entry.Format
+	}
+	if entry.Layout != "" {
+		line += ", " + entry.Layout + " layout"
+	}
+	switch entry.Status {
+	case events.DeliveryFailed:
+		line += ": " + html.EscapeString(entry.Error)
+	case events.DeliveryHeld:
+		line += " (muted token or quiet hours)"
+	case events.DeliveryUnsent:
+		line += " (shutdown, resent after restart)"
+	}
+	return line
+}
+
+// describeSwap returns "buy 0.05 btc" (token-to-token swaps have no BTC amount)
+func describeSwap(swap flashnet.Swap) string {
+	var sats string
+	switch swap.GetSwapType() {
+	case flashnet.SwapTypeBuy:
+		sats = swap.AmountIn
+	case flashnet.SwapTypeSell:
+		sats = swap.AmountOut
+	default:
+		return "token-to-token swap"
+	}
+	btc, err := amount.SatsToBTCFloat(sats)
+	if err != nil {
+		return strings.ToLower(string(swap.GetSwapType()))
+	}
+	return fmt.Sprintf("%s %s btc", strings.ToLower(string(swap.GetSwapType())),
+		strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.8f", btc), "0"), "."))
+}
+
+// messageLink returns link to message of supergroup or channel (-100...), empty for other chats
+func messageLink(chatID int64, messageID int) string {
+	chat := strconv.FormatInt(chatID, 10)
+	if !strings.HasPrefix(chat, "-100") {
+		return ""
+	}
+	return fmt.Sprintf("https://t.me/c/%s/%d", strings.TrimPrefix(chat, "-100"), messageID)
+}
@@ -0,0 +1,128 @@
+package digest
+
+// Daily digest - per token aggregation of one day's swaps from swap archive
+// Source - swaps archived by big sales monitor (data_out/archive/swaps), not Luminex stats
+
+import (
+	"sort"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
+)
+
+// Trade - one buy or sell swap
+type Trade struct {
+	SwapID           string
+	Type             flashnet.SwapType
+	AmountBTC        float64
+	SwapperPublicKey string
+}
+
+// TokenDigest - day summary of one token (pool)
+type TokenDigest struct {
+	PoolLpPublicKey string
+	Swaps           int
+	Buys            int
+	Sells           int
+	BuyBTC          float64
+	SellBTC         float64
+	Buyers          int // unique buyer wallets
+	Sellers         int // unique seller wallets
+	BiggestTrade    Trade
+}
+
+// NetFlowBTC - buy volume minus sell volume
+func (t TokenDigest) NetFlowBTC() float64 {
+	return t.BuyBTC - t.SellBTC
+}
+
+// VolumeBTC - buy and sell volume
+func (t TokenDigest) VolumeBTC() float64 {
+	return t.BuyBTC + t.SellBTC
+}
+
+// Digest - day summary of all tokens
+type Digest struct {
+	Date    string        // YYYY-MM-DD (UTC)
+	Swaps   int           // buy and sell swaps
+	Wallets int           // unique wallets
+	Tokens  []TokenDigest // sorted by volume, descending
+}
+
+// BuildDailyDigest aggregates archived swaps of date (YYYY-MM-DD, UTC)
+func BuildDailyDigest(date string) (*Digest, error) {
+	swaps, err := storage.LoadDailySwaps(date)
+	if err != nil {
+		return nil, err
+	}
+	return Aggregate(date, swaps), nil
+}
+
+// Aggregate builds digest of swaps, token-to-token swaps are skipped
+func Aggregate(date string, swaps []flashnet.Swap) *Digest {
+	digest := &Digest{Date: date}
+
+	tokens := make(map[string]*TokenDigest)
+	buyers := make(map[string]map[string]bool)
+	sellers := make(map[string]map[string]bool)
+	wallets := make(map[string]bool)
+
+	for _, swap := range swaps {
+		swapType := swap.GetSwapType()
+		var amountBTC float64
+		switch swapType {
+		case flashnet.SwapTypeBuy:
+			amountBTC, _ = amount.SatsToBTCFloat(swap.AmountIn)
+		case flashnet.SwapTypeSell:
+			amountBTC, _ = amount.SatsToBTCFloat(swap.AmountOut)
+		default:
+			continue
+		}
+
+		token, exists := tokens[swap.PoolLpPublicKey]
+		if !exists {
+			token = &TokenDigest{PoolLpPublicKey: swap.PoolLpPublicKey}
+			tokens[swap.PoolLpPublicKey] = token
+			buyers[swap.PoolLpPublicKey] = make(map[string]bool)
+			sellers[swap.PoolLpPublicKey] = make(map[string]bool)
+		}
+
+		token.Swaps++
+		if swapType == flashnet.SwapTypeBuy {
+			token.Buys++
+			token.BuyBTC += amountBTC
+			buyers[swap.PoolLpPublicKey][swap.SwapperPublicKey] = true
+		} else {
+			token.Sells++
+			token.SellBTC += amountBTC
+			sellers[swap.PoolLpPublicKey][swap.SwapperPublicKey] = true
+		}
+		if amountBTC > token.BiggestTrade.AmountBTC {
+			token.BiggestTrade = Trade{
+				SwapID:           swap.ID,
+				Type:             swapType,
+				AmountBTC:        amountBTC,
+				SwapperPublicKey: swap.SwapperPublicKey,
+			}
+		}
+
+		digest.Swaps++
+		wallets[swap.SwapperPublicKey] = true
+	}
+
+	for pool, token := range tokens {
+		token.Buyers = len(buyers[pool])
+		token.Sellers = len(sellers[pool])
+		digest.Tokens = append(digest.Tokens, *token)
+	}
+	sort.Slice(digest.Tokens, func(i, j int) bool {
+		if digest.Tokens[i].VolumeBTC() != digest.Tokens[j].VolumeBTC() {
+			return digest.Tokens[i].VolumeBTC() > digest.Tokens[j].VolumeBTC()
+		}
+		return digest.Tokens[i].PoolLpPublicKey < digest.Tokens[j].PoolLpPublicKey
+	})
+	digest.Wallets = len(wallets)
+
+	return digest
+}
@@ -0,0 +1,146 @@
+package digest
+
+// Daily buy/sell volumes per pool for date ranges (/flow SOON 0112-0712)
+// Complete UTC days are aggregated from swap archive once and kept in pool_volumes.json,
+// so long ranges don't re-read archive and volumes survive archive cleanup; current day is always read from archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+// MaxVolumeRangeDays - longest range of GetPoolVolumes
+const MaxVolumeRangeDays = 366
+
+// PoolVolumesFile - aggregated daily volumes of pools
+func PoolVolumesFile() string {
+	return paths.Output("telegram_out", "pool_volumes.json")
+}
+
+// PoolVolume - buy/sell volume of pool on day
+type PoolVolume struct {
+	Buys    int     `json:"buys"`
+	Sells   int     `json:"sells"`
+	BuyBTC  float64 `json:"buyBTC"`
+	SellBTC float64 `json:"sellBTC"`
+}
+
+// Add adds volume of other day
+func (v *PoolVolume) Add(other PoolVolume) {
+	v.Buys += other.Buys
+	v.Sells += other.Sells
+	v.BuyBTC += other.BuyBTC
+	v.SellBTC += other.SellBTC
+}
+
+// NetFlowBTC - buy volume minus sell volume
+func (v PoolVolume) NetFlowBTC() float64 {
+	return v.BuyBTC - v.SellBTC
+}
+
+// DailyPoolVolume - volume of pool on date
+type DailyPoolVolume struct {
+	Date string // YYYY-MM-DD (UTC)
+	PoolVolume
+}
+
+// PoolVolumesData - file structure for pool_volumes.json
+type PoolVolumesData struct {
+	Days map[string]map[string]PoolVolume `json:"days"` // date (YYYY-MM-DD) -> poolLpPublicKey -> volume
+}
+
+var poolVolumesMutex sync.Mutex
+
+// GetPoolVolumes returns volumes of pool for every day from..to (inclusive, UTC dates)
+// Days without swaps have zero volume, days missing in pool_volumes.json are aggregated from archive
+func GetPoolVolumes(poolLpPublicKey string, from time.Time, to time.Time, now time.Time) ([]DailyPoolVolume, error) {
+	from = utcDay(from)
+	to = utcDay(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("range end %s is before start %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > MaxVolumeRangeDays {
+		return nil, fmt.Errorf("range is %d days, max %d", days, MaxVolumeRangeDays)
+	}
+
+	poolVolumesMutex.Lock()
+	defer poolVolumesMutex.Unlock()
+
+	data, err := loadPoolVolumesUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	today := now.UTC().Format("2006-01-02")
+	changed := false
+	var volumes []DailyPoolVolume
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		pools, stored := data.Days[date]
+		if !stored {
+			pools, err = aggregatePoolVolumes(date)
+			if err != nil {
+				return nil, err
+			}
+			// Only complete days are kept, today's swaps are still coming
+			if date < today {
+				data.Days[date] = pools
+				changed = true
+			}
+		}
+		volumes = append(volumes, DailyPoolVolume{Date: date, PoolVolume: pools[poolLpPublicKey]})
+	}
+
+	if changed {
+		if err := storage.WriteJSONAtomic(PoolVolumesFile(), data); err != nil {
+			return nil, fmt.Errorf("failed to save pool volumes: %w", err)
+		}
+	}
+	return volumes, nil
+}
+
+// aggregatePoolVolumes returns volumes of all pools on date from swap archive
+func aggregatePoolVolumes(date string) (map[string]PoolVolume, error) {
+	swaps, err := storage.LoadDailySwaps(date)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make(map[string]PoolVolume)
+	for _, token := range Aggregate(date, swaps).Tokens {
+		pools[token.PoolLpPublicKey] = PoolVolume{
+			Buys:    token.Buys,
+			Sells:   token.Sells,
+			BuyBTC:  token.BuyBTC,
+			SellBTC: token.SellBTC,
+		}
+	}
+	return pools, nil
+}
+
+func loadPoolVolumesUnlocked() (*PoolVolumesData, error) {
+	data := &PoolVolumesData{}
+	raw, err := os.ReadFile(PoolVolumesFile())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read pool volumes file: %w", err)
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, data); err != nil {
+			return nil, fmt.Errorf("failed to parse pool volumes JSON: %w", err)
+		}
+	}
+	if data.Days == nil {
+		data.Days = make(map[string]map[string]PoolVolume)
+	}
+	return data, nil
+}
+
+func utcDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,201 @@
+package holders
+
+// Whale accumulation: wallets whose holder changes (dynamic_holders.json) over last AccumulationWindowDays
+// add up to net buying of more than % of token supply or BTC
+// BTC is counted from swap changes (invested minus sold and liquidated value), changes found by daily
+// balance check (transfers) have no BTC value and count in tokens only
+// A wallet of a token is alerted once per window (accumulation_alerts.json)
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+)
+
+const (
+	// AccumulationWindowDays - days of holder changes summed for accumulation, today included
+	AccumulationWindowDays = 7
+	// AccumulationCooldown - wallet of token is not alerted again within cooldown
+	AccumulationCooldown = AccumulationWindowDays * 24 * time.Hour
+)
+
+// AccumulationThresholds - net buying of window from which wallet is flagged, 0 - criterion disabled
+type AccumulationThresholds struct {
+	SupplyPercent float64 // net tokens, % of total supply
+	MinBTC        float64 // net BTC of swaps
+}
+
+// Enabled reports whether any criterion is set
+func (t AccumulationThresholds) Enabled() bool {
+	return t.SupplyPercent > 0 || t.MinBTC > 0
+}
+
+// AccumulationDay - holder changes of wallet on date
+type AccumulationDay struct {
+	Date   string  // YYYY-MM-DD
+	Buys   int     // invested changes
+	Sells  int     // sold and liquidated changes
+	Delta  float64 // net tokens
+	NetBTC float64 // invested minus sold value
+}
+
+// Accumulation - wallet accumulating token over window
+type Accumulation struct {
+	Ticker        string
+	Wallet        string
+	NetTokens     float64
+	NetBTC        float64
+	SupplyPercent float64 // share of NetTokens in supply, 0 - supply unknown
+	Balance       float64 // tokens after last change
+	Timeline      []AccumulationDay
+}
+
+// AccumulationAlertsData - file structure for accumulation_alerts.json
+type AccumulationAlertsData struct {
+	Alerted map[string]string `json:"alerted"` // TICKER:wallet -> last alert (RFC3339)
+}
+
+var accumulationMutex sync.Mutex
+
+// AccumulationAlertsFile - last accumulation alerts per wallet and ticker
+func AccumulationAlertsFile() string {
+	return filepath.Join(HoldersModuleDir(), "accumulation_alerts.json")
+}
+
+// FindAccumulations returns wallets of ticker above thresholds over window ending at now, largest net BTC first
+// supply - total supply of token in tokens, 0 - supply criterion is skipped
+// Wallets alerted within AccumulationCooldown are skipped
+func FindAccumulations(ticker string, supply float64, thresholds AccumulationThresholds, now time.Time) ([]Accumulation, error) {
+	if !thresholds.Enabled() {
+		return nil, nil
+	}
+
+	dynamicData, err := LoadDynamicHolders(ticker)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := loadAccumulationAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	// Change dates are local dates of check or swap
+	today := now.Format("2006-01-02")
+	from := now.AddDate(0, 0, -(AccumulationWindowDays - 1)).Format("2006-01-02")
+
+	var found []Accumulation
+	for wallet, changes := range dynamicData.Changes {
+		if alertedAt, err := time.Parse(time.RFC3339, alerts.Alerted[accumulationKey(ticker, wallet)]); err == nil && now.Sub(alertedAt) < AccumulationCooldown {
+			continue
+		}
+
+		accumulation := Accumulation{Ticker: ticker, Wallet: wallet}
+		days := make(map[string]*AccumulationDay)
+		for _, change := range changes {
+			if change.Date < from || change.Date > today {
+				continue
+			}
+			day, exists := days[change.Date]
+			if !exists {
+				day = &AccumulationDay{Date: change.Date}
+				days[change.Date] = day
+			}
+
+			value := change.Value
+			if change.Action == "invested" {
+				day.Buys++
+			} else {
+				day.Sells++
+				value = -value
+			}
+			day.Delta += change.Delta
+			day.NetBTC += value
+			accumulation.NetTokens += change.Delta
+			accumulation.NetBTC += value
+			accumulation.Balance = change.Amount
+		}
+		if accumulation.NetTokens <= 0 {
+			continue
+		}
+
+		if supply > 0 {
+			accumulation.SupplyPercent = accumulation.NetTokens / supply * 100
+		}
+		bySupply := thresholds.SupplyPercent > 0 && supply > 0 && accumulation.SupplyPercent >= thresholds.SupplyPercent
+		byBTC := thresholds.MinBTC > 0 && accumulation.NetBTC >= thresholds.MinBTC
+		if !bySupply && !byBTC {
+			continue
+		}
+
+		for _, day := range days {
+			accumulation.Timeline = append(accumulation.Timeline, *day)
+		}
+		sort.Slice(accumulation.Timeline, func(i, j int) bool {
+			return accumulation.Timeline[i].Date < accumulation.Timeline[j].Date
+		})
+		found = append(found, accumulation)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].NetBTC != found[j].NetBTC {
+			return found[i].NetBTC > found[j].NetBTC
+		}
+		return found[i].Wallet < found[j].Wallet
+	})
+	return found, nil
+}
+
+// MarkAccumulationAlerted records alert of wallet for ticker (starts cooldown)
+func MarkAccumulationAlerted(ticker string, wallet string, now time.Time) error {
+	accumulationMutex.Lock()
+	defer accumulationMutex.Unlock()
+
+	data, err := loadAccumulationAlertsUnlocked()
+	if err != nil {
+		return err
+	}
+	for key, alertedAt := range data.Alerted {
+		if parsed, err := time.Parse(time.RFC3339, alertedAt); err != nil || now.Sub(parsed) > AccumulationCooldown {
+			delete(data.Alerted, key)
+		}
+	}
+	data.Alerted[accumulationKey(ticker, wallet)] = now.UTC().Format(time.RFC3339)
+
+	if err := storage.WriteJSONAtomic(AccumulationAlertsFile(), data); err != nil {
+		return fmt.Errorf("failed to save accumulation alerts: %w", err)
+	}
+	return nil
+}
+
+func loadAccumulationAlerts() (*AccumulationAlertsData, error) {
+	accumulationMutex.Lock()
+	defer accumulationMutex.Unlock()
+	return loadAccumulationAlertsUnlocked()
+}
+
+func loadAccumulationAlertsUnlocked() (*AccumulationAlertsData, error) {
+	data := &AccumulationAlertsData{}
+	raw, err := os.ReadFile(AccumulationAlertsFile())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read accumulation alerts: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(raw, data); err != nil {
+			return nil, fmt.Errorf("failed to parse accumulation alerts: %w", err)
+		}
+	}
+	if data.Alerted == nil {
+		data.Alerted = make(map[string]string)
+	}
+	return data, nil
+}
+
+func accumulationKey(ticker string, wallet string) string {
+	return ticker + ":" + wallet
+}
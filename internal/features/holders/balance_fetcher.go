@@ -0,0 +1,178 @@
+package holders
+
+// Batch fetch of holder balances for the daily check (Luminex /spark/address/{publicKey})
+// Wallets are fetched by a bounded pool of workers sharing one rate limiter, so a check of thousands of holders
+// runs concurrently but leaves room in the Luminex client limit for swap alerts
+// Failed wallets are retried in later rounds (after RetryDelay, growing with round), wallets still failing
+// are reported in BalanceFetchResult.Failed and keep their saved balance until the next check
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultBalanceWorkers - concurrent balance requests of one check
+	DefaultBalanceWorkers = 4
+	// DefaultBalanceRate - balance requests per second of one check (Luminex client allows 5)
+	DefaultBalanceRate = 3.0
+	// DefaultBalanceRetries - extra rounds for wallets whose balance request failed
+	DefaultBalanceRetries = 2
+	// DefaultBalanceRetryDelay - pause before first retry round, doubles with every round
+	DefaultBalanceRetryDelay = 5 * time.Second
+)
+
+// BalanceFetchConfig - settings of batch balance fetch
+type BalanceFetchConfig struct {
+	Workers    int           // concurrent requests
+	Rate       float64       // requests per second of all workers
+	Retries    int           // retry rounds of failed wallets, 0 - no retries
+	RetryDelay time.Duration // pause before first retry round
+}
+
+// BalanceFetchResult - balances of one batch fetch
+type BalanceFetchResult struct {
+	Balances map[string]float64 // wallet -> token amount (0 if token is not in wallet)
+	Failed   map[string]error   // wallet -> last error, wallets without balance after all rounds
+	Requests int                // balance requests sent, retries included
+}
+
+// FailedWallets returns wallets without balance (sorted)
+func (r *BalanceFetchResult) FailedWallets() []string {
+	wallets := make([]string, 0, len(r.Failed))
+	for wallet := range r.Failed {
+		wallets = append(wallets, wallet)
+	}
+	sort.Strings(wallets)
+	return wallets
+}
+
+// errBalanceNotFetched - wallet had no request yet (ctx cancelled before it)
+var errBalanceNotFetched = errors.New("balance not fetched")
+
+var (
+	balanceFetchConfig      = DefaultBalanceFetchConfig()
+	balanceFetchConfigMutex sync.RWMutex
+)
+
+// DefaultBalanceFetchConfig returns default batch balance fetch settings
+func DefaultBalanceFetchConfig() BalanceFetchConfig {
+	return BalanceFetchConfig{
+		Workers:    DefaultBalanceWorkers,
+		Rate:       DefaultBalanceRate,
+		Retries:    DefaultBalanceRetries,
+		RetryDelay: DefaultBalanceRetryDelay,
+	}
+}
+
+// SetBalanceFetchConfig sets batch balance fetch settings from config (zero values - defaults, except Retries)
+func SetBalanceFetchConfig(cfg BalanceFetchConfig) {
+	balanceFetchConfigMutex.Lock()
+	balanceFetchConfig = cfg.withDefaults()
+	balanceFetchConfigMutex.Unlock()
+}
+
+func getBalanceFetchConfig() BalanceFetchConfig {
+	balanceFetchConfigMutex.RLock()
+	defer balanceFetchConfigMutex.RUnlock()
+	return balanceFetchConfig
+}
+
+func (c BalanceFetchConfig) withDefaults() BalanceFetchConfig {
+	if c.Workers <= 0 {
+		c.Workers = DefaultBalanceWorkers
+	}
+	if c.Rate <= 0 {
+		c.Rate = DefaultBalanceRate
+	}
+	if c.Retries < 0 {
+		c.Retries = 0
+	}
+	if c.RetryDelay <= 0 {
+		c.RetryDelay = DefaultBalanceRetryDelay
+	}
+	return c
+}
+
+// FetchTokenBalances fetches token balance of every wallet with settings of SetBalanceFetchConfig
+// Stops on ctx cancellation, wallets not fetched by then are reported as failed with ctx error
+func FetchTokenBalances(ctx context.Context, ticker string, wallets []string) *BalanceFetchResult {
+	return fetchBalances(ctx, wallets, getBalanceFetchConfig(), func(wallet string) (float64, error) {
+		_, tokenAmount, err := GetTokenBalanceFromWallet(wallet, ticker)
+		return tokenAmount, err
+	})
+}
+
+func fetchBalances(ctx context.Context, wallets []string, cfg BalanceFetchConfig, fetch func(wallet string) (float64, error)) *BalanceFetchResult {
+	result := &BalanceFetchResult{
+		Balances: make(map[string]float64, len(wallets)),
+		Failed:   make(map[string]error),
+	}
+	// Wallet is failed until its request succeeds
+	for _, wallet := range wallets {
+		result.Failed[wallet] = errBalanceNotFetched
+	}
+	limiter := rate.NewLimiter(rate.Limit(cfg.Rate), cfg.Workers)
+	var resultMutex sync.Mutex
+
+	pending := result.FailedWallets()
+	for round := 0; round <= cfg.Retries && len(pending) > 0; round++ {
+		if round > 0 {
+			timer := time.NewTimer(cfg.RetryDelay << (round - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+			case <-timer.C:
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		queue := make(chan string)
+		var wg sync.WaitGroup
+		for i := 0; i < cfg.Workers && i < len(pending); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for wallet := range queue {
+					if err := limiter.Wait(ctx); err != nil {
+						continue // ctx cancelled, wallet keeps error of previous round
+					}
+					tokenAmount, err := fetch(wallet)
+
+					resultMutex.Lock()
+					result.Requests++
+					if err != nil {
+						result.Failed[wallet] = err
+					} else {
+						delete(result.Failed, wallet)
+						result.Balances[wallet] = tokenAmount
+					}
+					resultMutex.Unlock()
+				}
+			}()
+		}
+		for _, wallet := range pending {
+			queue <- wallet
+		}
+		close(queue)
+		wg.Wait()
+
+		pending = result.FailedWallets()
+	}
+
+	if err := ctx.Err(); err != nil {
+		for wallet, failure := range result.Failed {
+			if failure == errBalanceNotFetched {
+				result.Failed[wallet] = err
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package holders
+
+// Last successful holders balance check per ticker (data_out/holders_module/holders_checks.json)
+// Holders dynamic monitor decides from it which tickers are due, so checks missed
+// while process was down or API failed are caught up instead of leaving holes in dynamics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+// HoldersChecksFile - last successful check time per ticker
+func HoldersChecksFile() string {
+	return paths.Output("holders_module", "holders_checks.json")
+}
+
+// HoldersChecksData - file structure for holders_checks.json
+type HoldersChecksData struct {
+	LastSuccess map[string]string `json:"lastSuccess"` // ticker -> RFC3339
+}
+
+var holdersChecksMutex sync.Mutex
+
+// GetLastSuccessfulCheck returns time of last successful balance check of ticker (zero if never)
+func GetLastSuccessfulCheck(ticker string) (time.Time, error) {
+	holdersChecksMutex.Lock()
+	defer holdersChecksMutex.Unlock()
+
+	data, err := loadHoldersChecksUnlocked()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	value, exists := data.LastSuccess[strings.ToUpper(ticker)]
+	if !exists {
+		return time.Time{}, nil
+	}
+	lastCheck, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse last check time of %s: %w", ticker, err)
+	}
+	return lastCheck, nil
+}
+
+// RecordSuccessfulCheck saves time of successful balance check of ticker
+func RecordSuccessfulCheck(ticker string, checkedAt time.Time) error {
+	holdersChecksMutex.Lock()
+	defer holdersChecksMutex.Unlock()
+
+	data, err := loadHoldersChecksUnlocked()
+	if err != nil {
+		return err
+	}
+
+	data.LastSuccess[strings.ToUpper(ticker)] = checkedAt.UTC().Format(time.RFC3339)
+	return saveHoldersChecksUnlocked(data)
+}
+
+func loadHoldersChecksUnlocked() (*HoldersChecksData, error) {
+	raw, err := os.ReadFile(HoldersChecksFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &HoldersChecksData{LastSuccess: make(map[string]string)}, nil
+		}
+		return nil, fmt.Errorf("failed to read holders checks file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &HoldersChecksData{LastSuccess: make(map[string]string)}, nil
+	}
+
+	var data HoldersChecksData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse holders checks JSON: %w", err)
+	}
+	if data.LastSuccess == nil {
+		data.LastSuccess = make(map[string]string)
+	}
+	return &data, nil
+}
+
+func saveHoldersChecksUnlocked(data *HoldersChecksData) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal holders checks JSON: %w", err)
+	}
+
+	if err := storage.WriteFileAtomic(HoldersChecksFile(), raw, 0644); err != nil {
+		return fmt.Errorf("failed to write holders checks file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package holders
+
+// Cohort report of /cohort {ticker} {week}: buyers of week split into first-time and returning,
+// and retention of buyers of previous weeks (share of them who bought again in report week)
+// Buyers come from swaps archived by the bot (UTC days, weeks Monday to Sunday)
+// Wallet is first-time if archive has no earlier buy of token by it (searched at least CohortHistoryWeeks back)
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/i18n"
+	storage "spark-wallet/internal/infra/fs"
+)
+
+const (
+	// CohortWeeks - weeks of report: report week and previous weeks with retention
+	CohortWeeks = 5
+	// CohortHistoryWeeks - weeks before first week of report searched for earlier buys of wallet
+	CohortHistoryWeeks = 12
+)
+
+// CohortWeekFormats - accepted week formats for usage messages
+const CohortWeekFormats = "2025-W49, any date of week (" + ReportDateFormats + "), week, last"
+
+// CohortWeek - buyers of token in one week
+type CohortWeek struct {
+	Start        time.Time // Monday 00:00 UTC
+	Buyers       int       // unique buyers
+	FirstTime    int       // buyers without earlier buys in history
+	Returning    int       // buyers who bought before the week
+	FirstTimeBTC float64   // buy volume of first-time buyers
+	ReturningBTC float64   // buy volume of returning buyers
+	Retained     int       // buyers who bought again in report week (0 for report week)
+}
+
+// Label returns ISO week of cohort (2025-W49)
+func (w CohortWeek) Label() string {
+	year, week := w.Start.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// RetentionPercent - share of buyers who bought again in report week
+func (w CohortWeek) RetentionPercent() float64 {
+	if w.Buyers == 0 {
+		return 0
+	}
+	return float64(w.Retained) / float64(w.Buyers) * 100
+}
+
+// CohortReport - report week (last) and previous weeks, oldest first
+type CohortReport struct {
+	Ticker string
+	Weeks  []CohortWeek
+}
+
+// Current returns report week
+func (r *CohortReport) Current() CohortWeek {
+	return r.Weeks[len(r.Weeks)-1]
+}
+
+// ParseCohortWeek parses week argument relative to now, returns Monday 00:00 UTC of week
+// ISO week (2025-W49), date of week (any report date format), week (current) or last (previous)
+func ParseCohortWeek(weekStr string, now time.Time) (time.Time, error) {
+	weekStr = strings.ToLower(strings.TrimSpace(weekStr))
+	current := weekStart(now)
+
+	switch weekStr {
+	case "", "week", "неделя":
+		return current, nil
+	case "last", "прошлая":
+		return current.AddDate(0, 0, -7), nil
+	}
+
+	if yearStr, weekNumStr, found := strings.Cut(weekStr, "-w"); found {
+		year, err := parseDatePart(yearStr, "year", 2000, 9999)
+		if err != nil {
+			return time.Time{}, err
+		}
+		week, err := strconv.Atoi(weekNumStr)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid week: %q", weekNumStr)
+		}
+		// Week 1 is week with 4 January
+		start := weekStart(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)).AddDate(0, 0, (week-1)*7)
+		if startYear, startWeek := start.ISOWeek(); week < 1 || startYear != year || startWeek != week {
+			return time.Time{}, fmt.Errorf("week %d does not exist in %d", week, year)
+		}
+		if start.After(current) {
+			return time.Time{}, fmt.Errorf("week %s is in the future", weekStr)
+		}
+		return start, nil
+	}
+
+	date, err := ParseReportDate(weekStr, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid week %q: expected %s", weekStr, CohortWeekFormats)
+	}
+	return weekStart(date), nil
+}
+
+// GenerateCohortReport builds cohort report of ticker for week argument
+func GenerateCohortReport(ticker string, weekStr string) (*CohortReport, error) {
+	if !IsTickerAllowed(ticker) {
+		return nil, fmt.Errorf("ticker %s is not in allowed list", ticker)
+	}
+
+	now := time.Now()
+	start, err := ParseCohortWeek(weekStr, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse week: %w", err)
+	}
+
+	poolLpPublicKey, err := luminex.GetPoolLpPublicKeyForTicker(ticker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poolLpPublicKey for ticker %s: %w", ticker, err)
+	}
+
+	report, err := BuildCohortReport(poolLpPublicKey, start, now)
+	if err != nil {
+		return nil, err
+	}
+	report.Ticker = strings.ToUpper(ticker)
+	return report, nil
+}
+
+// BuildCohortReport builds cohorts of pool buyers for week starting at start from swap archive
+// Days after now are not read
+func BuildCohortReport(poolLpPublicKey string, start time.Time, now time.Time) (*CohortReport, error) {
+	start = weekStart(start)
+	firstWeek := start.AddDate(0, 0, -7*(CohortWeeks-1))
+	from := firstWeek.AddDate(0, 0, -7*CohortHistoryWeeks)
+	to := start.AddDate(0, 0, 6)
+	if today := utcDate(now); to.After(today) {
+		to = today
+	}
+
+	// wallet -> buy volume by week start, wallet -> first buy day
+	type walletBuys struct {
+		firstBuy time.Time
+		weeks    map[time.Time]float64
+	}
+	wallets := make(map[string]*walletBuys)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		swaps, err := storage.LoadDailySwaps(day.Format("2006-01-02"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load swaps of %s: %w", day.Format("2006-01-02"), err)
+		}
+		for _, swap := range swaps {
+			if swap.PoolLpPublicKey != poolLpPublicKey || swap.GetSwapType() != flashnet.SwapTypeBuy {
+				continue
+			}
+			buyBTC, _ := amount.SatsToBTCFloat(swap.AmountIn)
+			buys, exists := wallets[swap.SwapperPublicKey]
+			if !exists {
+				buys = &walletBuys{firstBuy: day, weeks: make(map[time.Time]float64)}
+				wallets[swap.SwapperPublicKey] = buys
+			}
+			buys.weeks[weekStart(day)] += buyBTC
+		}
+	}
+
+	report := &CohortReport{}
+	for week := firstWeek; !week.After(start); week = week.AddDate(0, 0, 7) {
+		cohort := CohortWeek{Start: week}
+		for _, buys := range wallets {
+			buyBTC, bought := buys.weeks[week]
+			if !bought {
+				continue
+			}
+			cohort.Buyers++
+			if buys.firstBuy.Before(week) {
+				cohort.Returning++
+				cohort.ReturningBTC += buyBTC
+			} else {
+				cohort.FirstTime++
+				cohort.FirstTimeBTC += buyBTC
+			}
+			if _, retained := buys.weeks[start]; retained && week.Before(start) {
+				cohort.Retained++
+			}
+		}
+		report.Weeks = append(report.Weeks, cohort)
+	}
+	return report, nil
+}
+
+// FormatCohortReport formats cohort report for Telegram in language of chat (HTML)
+func FormatCohortReport(report *CohortReport, lang i18n.Lang) string {
+	current := report.Current()
+	end := current.Start.AddDate(0, 0, 6)
+
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "cohort.title",
+		report.Ticker, current.Label(), formatDateForFlow(current.Start), formatDateForFlow(end)))
+
+	text.WriteString("<blockquote>")
+	text.WriteString(i18n.T(lang, "cohort.buyers", current.Buyers) + "\n")
+	text.WriteString(i18n.T(lang, "cohort.first_time", current.FirstTime, amount.FormatBTC(current.FirstTimeBTC)) + "\n")
+	text.WriteString(i18n.T(lang, "cohort.returning", current.Returning, amount.FormatBTC(current.ReturningBTC)))
+	text.WriteString("</blockquote>")
+
+	if len(report.Weeks) > 1 {
+		text.WriteString("\n" + i18n.T(lang, "cohort.bought_again", current.Label()) + "\n<blockquote>")
+		previous := report.Weeks[:len(report.Weeks)-1]
+		for i := len(previous) - 1; i >= 0; i-- {
+			week := previous[i]
+			text.WriteString(i18n.T(lang, "cohort.retained", formatDateForFlow(week.Start), week.Retained, week.Buyers))
+			text.WriteString(fmt.Sprintf(" (<code>%.0f%%</code>)", week.RetentionPercent()))
+			if i > 0 {
+				text.WriteString("\n")
+			}
+		}
+		text.WriteString("</blockquote>")
+	}
+	text.WriteString("\n<i>" + i18n.T(lang, "cohort.footer", CohortHistoryWeeks) + "</i>")
+
+	return text.String()
+}
+
+// weekStart returns Monday 00:00 UTC of week of t
+func weekStart(t time.Time) time.Time {
+	day := utcDate(t)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func utcDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,297 @@
+package holders
+
+// Holder concentration: top-10 share and Gini coefficient of daily holders snapshots (see top_holders.go)
+// Shares are counted from balances of tracked holders (saved_holders.json), not from supply: supply includes
+// pool reserves and wallets never seen in swaps, tracked balances are comparable day to day
+// Sell pressure warning: concentration rises sharply since previous snapshot, or a top-5 holder of previous
+// snapshot sold part of its balance. Metrics of last days and alerted dates are kept in concentration.json
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+)
+
+const (
+	// ConcentrationTopHolders - holders of top share
+	ConcentrationTopHolders = 10
+	// ConcentrationTopSellers - largest holders of previous snapshot watched for selling
+	ConcentrationTopSellers = 5
+	// concentrationHistoryDays - daily metrics kept per ticker
+	concentrationHistoryDays = 30
+)
+
+// ConcentrationThresholds - changes since previous snapshot that trigger warning, 0 - signal disabled
+type ConcentrationThresholds struct {
+	TopShareRise     float64 // rise of top-10 share, percentage points
+	GiniRise         float64 // rise of Gini coefficient (0-1)
+	TopSellerPercent float64 // part of balance sold by top-5 holder, %
+}
+
+// Enabled reports whether any signal is set
+func (t ConcentrationThresholds) Enabled() bool {
+	return t.TopShareRise > 0 || t.GiniRise > 0 || t.TopSellerPercent > 0
+}
+
+// ConcentrationMetrics - concentration of holders snapshot
+type ConcentrationMetrics struct {
+	Date     string  `json:"date"`     // YYYY-MM-DD of snapshot
+	Holders  int     `json:"holders"`  // holders with balance
+	TopShare float64 `json:"topShare"` // share of top-10 in tracked balances, %
+	Gini     float64 `json:"gini"`     // 0 - equal balances, 1 - one holder owns everything
+}
+
+// TopSeller - top-5 holder of previous snapshot with reduced balance
+type TopSeller struct {
+	Rank        int // rank in previous snapshot
+	Address     string
+	Previous    float64
+	Current     float64
+	SoldPercent float64 // part of previous balance, %
+}
+
+// ConcentrationRisk - concentration of latest snapshot against previous one
+type ConcentrationRisk struct {
+	Ticker       string
+	Current      ConcentrationMetrics
+	Previous     *ConcentrationMetrics // nil - no earlier snapshot
+	Supply       float64               // supply of latest snapshot, 0 - unknown
+	TopShareRise bool
+	GiniRise     bool
+	Sellers      []TopSeller // top holders selling at least TopSellerPercent
+	Alerted      bool        // warning of this snapshot was already sent
+}
+
+// Alerting reports whether any signal of risk is triggered
+func (r *ConcentrationRisk) Alerting() bool {
+	return r.TopShareRise || r.GiniRise || len(r.Sellers) > 0
+}
+
+// tickerConcentration - concentration state of ticker in concentration.json
+type tickerConcentration struct {
+	Metrics     []ConcentrationMetrics `json:"metrics"`               // by date ascending
+	AlertedDate string                 `json:"alertedDate,omitempty"` // snapshot date of last warning
+}
+
+// concentrationData - file structure for concentration.json
+type concentrationData struct {
+	Tickers map[string]*tickerConcentration `json:"tickers"`
+}
+
+var concentrationMutex sync.Mutex
+
+// ConcentrationFile - daily concentration metrics and last warning per ticker
+func ConcentrationFile() string {
+	return filepath.Join(HoldersModuleDir(), "concentration.json")
+}
+
+// ComputeConcentration returns top-10 share and Gini coefficient of snapshot balances
+func ComputeConcentration(snapshot *HoldersSnapshot) ConcentrationMetrics {
+	metrics := ConcentrationMetrics{Date: snapshot.Date}
+	balances := make([]float64, 0, len(snapshot.Holders))
+	total := 0.0
+	for _, balance := range snapshot.Holders {
+		if balance <= 0 {
+			continue
+		}
+		balances = append(balances, balance)
+		total += balance
+	}
+	metrics.Holders = len(balances)
+	if total <= 0 {
+		return metrics
+	}
+
+	sort.Sort(sort.Reverse(sort.Float64Slice(balances)))
+	top := 0.0
+	for i := 0; i < len(balances) && i < ConcentrationTopHolders; i++ {
+		top += balances[i]
+	}
+	metrics.TopShare = top / total * 100
+
+	// Gini of ascending balances: sum((2i - n - 1) * x_i) / (n * sum(x)), i from 1
+	n := float64(len(balances))
+	weighted := 0.0
+	for i, balance := range balances {
+		rank := n - float64(i) // ascending rank of descending slice
+		weighted += (2*rank - n - 1) * balance
+	}
+	metrics.Gini = weighted / (n * total)
+	return metrics
+}
+
+// CheckConcentration compares latest holders snapshot of ticker with previous one and records its metrics
+// Returns nil if ticker has no snapshot yet
+func CheckConcentration(ticker string, thresholds ConcentrationThresholds) (*ConcentrationRisk, error) {
+	ticker = strings.ToUpper(ticker)
+	dates, err := holdersSnapshotDates(ticker)
+	if err != nil {
+		return nil, err
+	}
+	if len(dates) == 0 {
+		return nil, nil
+	}
+
+	current, err := LoadHoldersSnapshot(ticker, dates[len(dates)-1])
+	if err != nil || current == nil {
+		return nil, err
+	}
+	risk := &ConcentrationRisk{Ticker: ticker, Current: ComputeConcentration(current), Supply: current.Supply}
+
+	var previous *HoldersSnapshot
+	if len(dates) > 1 {
+		if previous, err = LoadHoldersSnapshot(ticker, dates[len(dates)-2]); err != nil {
+			return nil, err
+		}
+	}
+	if previous != nil {
+		previousMetrics := ComputeConcentration(previous)
+		risk.Previous = &previousMetrics
+		risk.TopShareRise = thresholds.TopShareRise > 0 && risk.Current.TopShare-previousMetrics.TopShare >= thresholds.TopShareRise
+		risk.GiniRise = thresholds.GiniRise > 0 && risk.Current.Gini-previousMetrics.Gini >= thresholds.GiniRise
+		if thresholds.TopSellerPercent > 0 {
+			risk.Sellers = findTopSellers(previous, current, thresholds.TopSellerPercent)
+		}
+	}
+
+	alertedDate, err := recordConcentrationMetrics(ticker, risk.Current)
+	if err != nil {
+		return nil, err
+	}
+	risk.Alerted = alertedDate == risk.Current.Date
+	return risk, nil
+}
+
+// MarkConcentrationAlerted records warning of ticker for snapshot date, one warning per snapshot
+func MarkConcentrationAlerted(ticker string, date string) error {
+	concentrationMutex.Lock()
+	defer concentrationMutex.Unlock()
+
+	data, err := loadConcentrationDataUnlocked()
+	if err != nil {
+		return err
+	}
+	state := tickerConcentrationState(data, strings.ToUpper(ticker))
+	state.AlertedDate = date
+	return saveConcentrationDataUnlocked(data)
+}
+
+// GetConcentrationHistory returns recorded daily metrics of ticker, oldest first
+func GetConcentrationHistory(ticker string) ([]ConcentrationMetrics, error) {
+	concentrationMutex.Lock()
+	defer concentrationMutex.Unlock()
+
+	data, err := loadConcentrationDataUnlocked()
+	if err != nil {
+		return nil, err
+	}
+	if state, exists := data.Tickers[strings.ToUpper(ticker)]; exists {
+		return state.Metrics, nil
+	}
+	return nil, nil
+}
+
+// findTopSellers returns top holders of previous snapshot that sold at least minPercent of their balance
+func findTopSellers(previous *HoldersSnapshot, current *HoldersSnapshot, minPercent float64) []TopSeller {
+	addresses := make([]string, 0, len(previous.Holders))
+	for address, balance := range previous.Holders {
+		if balance > 0 {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		if previous.Holders[addresses[i]] != previous.Holders[addresses[j]] {
+			return previous.Holders[addresses[i]] > previous.Holders[addresses[j]]
+		}
+		return addresses[i] < addresses[j]
+	})
+	if len(addresses) > ConcentrationTopSellers {
+		addresses = addresses[:ConcentrationTopSellers]
+	}
+
+	var sellers []TopSeller
+	for i, address := range addresses {
+		before := previous.Holders[address]
+		after := current.Holders[address]
+		soldPercent := (before - after) / before * 100
+		if soldPercent < minPercent {
+			continue
+		}
+		sellers = append(sellers, TopSeller{Rank: i + 1, Address: address, Previous: before, Current: after, SoldPercent: soldPercent})
+	}
+	return sellers
+}
+
+// recordConcentrationMetrics saves metrics of snapshot date and returns date of last warning of ticker
+func recordConcentrationMetrics(ticker string, metrics ConcentrationMetrics) (string, error) {
+	concentrationMutex.Lock()
+	defer concentrationMutex.Unlock()
+
+	data, err := loadConcentrationDataUnlocked()
+	if err != nil {
+		return "", err
+	}
+	state := tickerConcentrationState(data, ticker)
+
+	// Snapshot of the same day is overwritten by later check, so are its metrics
+	kept := state.Metrics[:0]
+	for _, recorded := range state.Metrics {
+		if recorded.Date != metrics.Date {
+			kept = append(kept, recorded)
+		}
+	}
+	state.Metrics = append(kept, metrics)
+	sort.Slice(state.Metrics, func(i, j int) bool { return state.Metrics[i].Date < state.Metrics[j].Date })
+
+	if date, err := time.Parse("2006-01-02", metrics.Date); err == nil {
+		cutoff := date.AddDate(0, 0, -concentrationHistoryDays).Format("2006-01-02")
+		for len(state.Metrics) > 0 && state.Metrics[0].Date < cutoff {
+			state.Metrics = state.Metrics[1:]
+		}
+	}
+
+	if err := saveConcentrationDataUnlocked(data); err != nil {
+		return "", err
+	}
+	return state.AlertedDate, nil
+}
+
+func tickerConcentrationState(data *concentrationData, ticker string) *tickerConcentration {
+	state, exists := data.Tickers[ticker]
+	if !exists {
+		state = &tickerConcentration{}
+		data.Tickers[ticker] = state
+	}
+	return state
+}
+
+func loadConcentrationDataUnlocked() (*concentrationData, error) {
+	data := &concentrationData{}
+	raw, err := os.ReadFile(ConcentrationFile())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read concentration data: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(raw, data); err != nil {
+			return nil, fmt.Errorf("failed to parse concentration data: %w", err)
+		}
+	}
+	if data.Tickers == nil {
+		data.Tickers = make(map[string]*tickerConcentration)
+	}
+	return data, nil
+}
+
+func saveConcentrationDataUnlocked(data *concentrationData) error {
+	if err := storage.WriteJSONAtomic(ConcentrationFile(), data); err != nil {
+		return fmt.Errorf("failed to save concentration data: %w", err)
+	}
+	return nil
+}
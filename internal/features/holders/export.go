@@ -0,0 +1,156 @@
+package holders
+
+// /export {ticker} {from} {to}: balance changes of holders from dynamic_holders.json as CSV
+// File opens in Excel as is (UTF-8 BOM, one row per change), so analysts don't need JSON files from server
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/wallet_labels"
+	storage "spark-wallet/internal/infra/fs"
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// ExportRangeFormats - accepted arguments of /export for usage messages
+const ExportRangeFormats = "{from} {to}, {date} or range (" + ReportRangeFormats + ")"
+
+// utf8BOM - Excel reads CSV without BOM in local code page and breaks non-latin usernames
+const utf8BOM = "\ufeff"
+
+// exportColumns - header of exported CSV
+var exportColumns = []string{"date", "wallet", "username", "label", "action", "amount", "delta", "value_btc", "catch_up", "since"}
+
+// ParseExportRange parses /export arguments after ticker: two dates, single date or range of /flow
+func ParseExportRange(args []string, now time.Time) (time.Time, time.Time, error) {
+	switch len(args) {
+	case 1:
+		if IsReportRange(args[0]) {
+			return ParseReportRange(args[0], now)
+		}
+		day, err := ParseReportDate(args[0], now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return day, day, nil
+	case 2:
+		to, err := ParseReportDate(args[1], now)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %w", err)
+		}
+		// Start date without year is the latest such date not after end date (as in ParseReportRange)
+		from, err := ParseReportDate(args[0], to)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %w", err)
+		}
+		if from.After(to) {
+			return time.Time{}, time.Time{}, fmt.Errorf("start date %s is after end date %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		}
+		return from, to, nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("expected %s", ExportRangeFormats)
+}
+
+// ExportHolderChangesCSV returns CSV of balance changes of ticker from dynamic_holders.json between from and to
+// (both included) and number of exported changes
+func ExportHolderChangesCSV(ticker string, from time.Time, to time.Time) ([]byte, int, error) {
+	if !IsTickerAllowed(ticker) {
+		return nil, 0, fmt.Errorf("ticker %s is not in allowed list", ticker)
+	}
+
+	dynamicData, err := LoadDynamicHolders(ticker)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load dynamic holders: %w", err)
+	}
+
+	// Usernames from local table only: Luminex request per wallet would make large export slow
+	usernames := make(map[string]string)
+	if table, err := storage.LoadUsernames(); err != nil {
+		logging.LogWarn("Failed to load usernames for export", zap.String("ticker", ticker), zap.Error(err))
+	} else {
+		for publicKey, entry := range table.Wallets {
+			if entry.Username != "" {
+				usernames[publicKey] = entry.Username
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	rows, err := WriteHolderChangesCSV(&buf, dynamicData, from, to, usernames)
+	if err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), rows, nil
+}
+
+// WriteHolderChangesCSV writes changes of data between from and to (both included) sorted by date and wallet,
+// returns number of written changes
+func WriteHolderChangesCSV(w io.Writer, data *DynamicHoldersData, from time.Time, to time.Time, usernames map[string]string) (int, error) {
+	fromDate := from.Format("2006-01-02")
+	toDate := to.Format("2006-01-02")
+
+	type exportRow struct {
+		address string
+		change  BalanceChange
+	}
+	var rows []exportRow
+	for address, changes := range data.Changes {
+		for _, change := range changes {
+			if change.Date < fromDate || change.Date > toDate {
+				continue
+			}
+			rows = append(rows, exportRow{address: address, change: change})
+		}
+	}
+	// Changes of wallet keep their order within day
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].change.Date != rows[j].change.Date {
+			return rows[i].change.Date < rows[j].change.Date
+		}
+		return rows[i].address < rows[j].address
+	})
+
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return 0, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportColumns); err != nil {
+		return 0, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, row := range rows {
+		change := row.change
+		record := []string{
+			change.Date,
+			row.address,
+			usernames[row.address],
+			wallet_labels.Of(row.address),
+			change.Action,
+			strconv.FormatFloat(change.Amount, 'f', -1, 64),
+			strconv.FormatFloat(change.Delta, 'f', -1, 64),
+			strconv.FormatFloat(change.Value, 'f', 8, 64),
+			strconv.FormatBool(change.CatchUp),
+			change.Since,
+		}
+		if err := writer.Write(record); err != nil {
+			return 0, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return 0, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return len(rows), nil
+}
+
+// ExportFileName returns name of CSV file of ticker export
+func ExportFileName(ticker string, from time.Time, to time.Time) string {
+	return fmt.Sprintf("%s_holders_%s_%s.csv", strings.ToUpper(ticker), from.Format("2006-01-02"), to.Format("2006-01-02"))
+}
@@ -4,14 +4,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
+	storage "spark-wallet/internal/infra/fs"
 	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
 
 	"go.uber.org/zap"
 )
 
+// flowFile - daily buy/sell flow of holders
+func flowFile() string {
+	return paths.Output("telegram_out", "flow.json")
+}
+
 type FlowData struct {
 	// Note: All values and logic are taken from holders_module folder and work together with dynamic_holders.json
 	Note       string               `json:"_note,omitempty"` // holders_module
@@ -27,7 +33,7 @@ type DailyFlow struct {
 }
 
 func LoadFlowData() (*FlowData, error) {
-	filename := filepath.Join("data_out", "telegram_out", "flow.json")
+	filename := flowFile()
 
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
 		return &FlowData{
@@ -71,32 +77,8 @@ func LoadFlowData() (*FlowData, error) {
 }
 
 func SaveFlowData(flowData *FlowData) error {
-	dir := filepath.Join("data_out", "telegram_out")
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create telegram_out directory: %w", err)
-	}
-
-	filename := filepath.Join(dir, "flow.json")
-
-	tmpFile := filename + ".tmp"
-	file, err := os.Create(tmpFile)
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
-	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(flowData); err != nil {
-		os.Remove(tmpFile)
-		return fmt.Errorf("failed to encode flow JSON: %w", err)
-	}
-
-	file.Close()
-
-	if err := os.Rename(tmpFile, filename); err != nil {
-		os.Remove(tmpFile)
-		return fmt.Errorf("failed to rename temp file: %w", err)
+	if err := storage.WriteJSONAtomic(flowFile(), flowData); err != nil {
+		return fmt.Errorf("failed to write flow file: %w", err)
 	}
 
 	return nil
@@ -108,6 +90,9 @@ func UpdateFlowFromSwap(ticker string, action string, value float64) error {
 		return fmt.Errorf("ticker %s is not in allowed list", ticker)
 	}
 
+	unlock := storage.LockFile(flowFile())
+	defer unlock()
+
 	flowData, err := LoadFlowData()
 	if err != nil {
 		return fmt.Errorf("failed to load flow data: %w", err)
@@ -181,6 +166,9 @@ func RecalculateFlowFromDynamicHolders(ticker string, date string) error {
 		return fmt.Errorf("failed to load dynamic holders: %w", err)
 	}
 
+	unlock := storage.LockFile(flowFile())
+	defer unlock()
+
 	flowData, err := LoadFlowData()
 	if err != nil {
 		return fmt.Errorf("failed to load flow data: %w", err)
@@ -0,0 +1,150 @@
+package holders
+
+// /flow over range of days (/flow SOON 0112-0712, /flow SOON month)
+// Volumes come from swaps archived by the bot (digest.GetPoolVolumes, UTC days), not from Luminex pool stats,
+// range is split into days, weeks or months depending on its length
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/digest"
+	"spark-wallet/internal/features/i18n"
+)
+
+const (
+	// flowDailyRangeDays - ranges up to this length are split by day
+	flowDailyRangeDays = 14
+	// flowWeeklyRangeDays - ranges up to this length are split by week, longer ones by month
+	flowWeeklyRangeDays = 92
+)
+
+// flowPeriod - part of range in report breakdown
+type flowPeriod struct {
+	From   string // YYYY-MM-DD
+	To     string // YYYY-MM-DD
+	Volume digest.PoolVolume
+}
+
+// GenerateFlowRangeReport formats buy/sell flow of ticker over range of days for Telegram in language of chat (HTML)
+func GenerateFlowRangeReport(ticker string, rangeStr string, lang i18n.Lang) (string, error) {
+	if !IsTickerAllowed(ticker) {
+		return "", fmt.Errorf("ticker %s is not in allowed list", ticker)
+	}
+
+	now := time.Now()
+	from, to, err := ParseReportRange(rangeStr, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse range: %w", err)
+	}
+
+	poolLpPublicKey, err := luminex.GetPoolLpPublicKeyForTicker(ticker)
+	if err != nil {
+		return "", fmt.Errorf("failed to get poolLpPublicKey for ticker %s: %w", ticker, err)
+	}
+
+	days, err := digest.GetPoolVolumes(poolLpPublicKey, from, to, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to get pool volumes: %w", err)
+	}
+
+	var total digest.PoolVolume
+	for _, day := range days {
+		total.Add(day.PoolVolume)
+	}
+	toDate := to.Format("2006-01-02")
+
+	var report strings.Builder
+	report.WriteString(i18n.T(lang, "flow.range_title",
+		strings.ToUpper(ticker), formatDateForFlow(from), formatDateForFlow(to), len(days)))
+
+	report.WriteString("<blockquote>")
+	report.WriteString(i18n.T(lang, "flow.buys", total.Buys, amount.FormatBTC(total.BuyBTC)+formatUSDAtDate(total.BuyBTC, toDate)) + "\n")
+	report.WriteString(i18n.T(lang, "flow.sells", total.Sells, amount.FormatBTC(total.SellBTC)+formatUSDAtDate(total.SellBTC, toDate)) + "\n\n")
+	report.WriteString(fmt.Sprintf("– B/S = %s\n", formatFlowRatio(float64(total.Buys), float64(total.Sells))))
+	report.WriteString(fmt.Sprintf("– B/S$ = %s\n", formatFlowRatio(total.BuyBTC, total.SellBTC)))
+	report.WriteString(fmt.Sprintf("– Net = <code>%s</code> btc", amount.FormatSignedBTC(total.NetFlowBTC())))
+	report.WriteString("</blockquote>")
+
+	periods, title := splitFlowPeriods(days)
+	if len(periods) > 1 {
+		report.WriteString(fmt.Sprintf("\n%s:\n<blockquote>", i18n.T(lang, title)))
+		for i, period := range periods {
+			label := formatFlowPeriodDate(period.From)
+			if period.To != period.From {
+				label += " – " + formatFlowPeriodDate(period.To)
+			}
+			report.WriteString(fmt.Sprintf("%s: B %s / S %s / Net <code>%s</code>",
+				label,
+				amount.FormatBTC(period.Volume.BuyBTC),
+				amount.FormatBTC(period.Volume.SellBTC),
+				amount.FormatSignedBTC(period.Volume.NetFlowBTC())))
+			if i < len(periods)-1 {
+				report.WriteString("\n")
+			}
+		}
+		report.WriteString("</blockquote>")
+	}
+	report.WriteString("\n<i>" + i18n.T(lang, "flow.footer") + "</i>")
+
+	return report.String(), nil
+}
+
+// splitFlowPeriods groups days by day, week (Monday to Sunday) or month depending on range length
+// Returns periods and catalog key of breakdown title
+func splitFlowPeriods(days []digest.DailyPoolVolume) ([]flowPeriod, string) {
+	title := "flow.by_day"
+	key := func(day time.Time) string { return day.Format("2006-01-02") }
+	switch {
+	case len(days) > flowWeeklyRangeDays:
+		title = "flow.by_month"
+		key = func(day time.Time) string { return day.Format("2006-01") }
+	case len(days) > flowDailyRangeDays:
+		title = "flow.by_week"
+		key = func(day time.Time) string {
+			year, week := day.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}
+	}
+
+	var periods []flowPeriod
+	lastKey := ""
+	for _, day := range days {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			continue
+		}
+		if dayKey := key(date); dayKey != lastKey || len(periods) == 0 {
+			periods = append(periods, flowPeriod{From: day.Date})
+			lastKey = dayKey
+		}
+		period := &periods[len(periods)-1]
+		period.To = day.Date
+		period.Volume.Add(day.PoolVolume)
+	}
+	return periods, title
+}
+
+// formatFlowRatio formats buys/sells ratio (∞ if there are only buys)
+func formatFlowRatio(buys float64, sells float64) string {
+	switch {
+	case sells > 0:
+		return fmt.Sprintf("<code>%.2f</code>", buys/sells)
+	case buys > 0:
+		return "∞"
+	default:
+		return "<code>0.00</code>"
+	}
+}
+
+// formatFlowPeriodDate formats YYYY-MM-DD as DD Mon
+func formatFlowPeriodDate(date string) string {
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return formatDateForFlow(parsed)
+}
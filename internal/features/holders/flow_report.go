@@ -4,7 +4,10 @@ package holders
 
 import (
 	"fmt"
+	"spark-wallet/internal/amount"
 	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/btc_price"
+	"spark-wallet/internal/features/i18n"
 	logging "spark-wallet/internal/infra/log"
 	"strings"
 	"time"
@@ -12,13 +15,17 @@ import (
 	"go.uber.org/zap"
 )
 
-func GenerateFlowReport(ticker string, dateStr string) (string, error) {
+// GenerateFlowReport formats buy/sell flow of ticker for date or range in language of chat (HTML)
+func GenerateFlowReport(ticker string, dateStr string, lang i18n.Lang) (string, error) {
 	if !IsTickerAllowed(ticker) {
 		return "", fmt.Errorf("ticker %s is not in allowed list", ticker)
 	}
+	if IsReportRange(dateStr) {
+		return GenerateFlowRangeReport(ticker, dateStr, lang)
+	}
 
-	// Parse from DDMM
-	parsedDate, err := parseDateFromDDMM(dateStr)
+	// Parse date argument (DDMM, DD.MM, YYYY-MM-DD, today, ...)
+	parsedDate, err := ParseReportDate(dateStr, time.Now())
 	if err != nil {
 		return "", fmt.Errorf("failed to parse date: %w", err)
 	}
@@ -41,21 +48,30 @@ func GenerateFlowReport(ticker string, dateStr string) (string, error) {
 	// Parse from (API
 	var buyVolume, sellVolume float64
 	if poolStats.BuyVolume != "" {
-		if _, err := fmt.Sscanf(poolStats.BuyVolume, "%f", &buyVolume); err != nil {
+		parsed, err := amount.ParseFloat(poolStats.BuyVolume)
+		if err != nil {
 			logging.LogWarn("Failed to parse buyVolume", zap.String("buyVolume", poolStats.BuyVolume), zap.Error(err))
-			buyVolume = 0
+		} else {
+			buyVolume = parsed
 		}
 	}
 	if poolStats.SellVolume != "" {
-		if _, err := fmt.Sscanf(poolStats.SellVolume, "%f", &sellVolume); err != nil {
+		parsed, err := amount.ParseFloat(poolStats.SellVolume)
+		if err != nil {
 			logging.LogWarn("Failed to parse sellVolume", zap.String("sellVolume", poolStats.SellVolume), zap.Error(err))
-			sellVolume = 0
+		} else {
+			sellVolume = parsed
 		}
 	}
 
 	// Format BTC
-	buyValueStr := formatBTCValueForFlow(buyVolume)
-	sellValueStr := formatBTCValueForFlow(sellVolume)
+	buyValueStr := amount.FormatBTC(buyVolume)
+	sellValueStr := amount.FormatBTC(sellVolume)
+
+	// USD at BTC price of report date
+	dateKey := parsedDate.Format("2006-01-02")
+	buyValueStr += formatUSDAtDate(buyVolume, dateKey)
+	sellValueStr += formatUSDAtDate(sellVolume, dateKey)
 
 	// Calculate B/S (count / count
 	var bsRatio string
@@ -80,12 +96,12 @@ func GenerateFlowReport(ticker string, dateStr string) (string, error) {
 	}
 
 	var report strings.Builder
-	report.WriteString(fmt.Sprintf("%s for %s:\n\n", strings.ToUpper(ticker), dateDisplay))
+	report.WriteString(i18n.T(lang, "flow.title", strings.ToUpper(ticker), dateDisplay))
 
 	// in (blockquote)
 	report.WriteString("<blockquote>")
-	report.WriteString(fmt.Sprintf("Buys: %d (%s)\n", poolStats.Buys, buyValueStr))
-	report.WriteString(fmt.Sprintf("Sells: %d (%s)\n\n", poolStats.Sells, sellValueStr))
+	report.WriteString(i18n.T(lang, "flow.buys", poolStats.Buys, buyValueStr) + "\n")
+	report.WriteString(i18n.T(lang, "flow.sells", poolStats.Sells, sellValueStr) + "\n\n")
 
 	bsRatioFormatted := bsRatio
 	if bsRatio != "∞" {
@@ -104,60 +120,21 @@ func GenerateFlowReport(ticker string, dateStr string) (string, error) {
 	return report.String(), nil
 }
 
-// parseDateFromDDMM from DDMM "0912" -> 09
-func parseDateFromDDMM(dateStr string) (time.Time, error) {
-	if len(dateStr) != 4 {
-		return time.Time{}, fmt.Errorf("date must be 4 digits (DDMM format)")
-	}
-
-	dayStr := dateStr[:2]
-	monthStr := dateStr[2:]
-
-	var day, month int
-	if _, err := fmt.Sscanf(dayStr, "%d", &day); err != nil {
-		return time.Time{}, fmt.Errorf("invalid day: %s", dayStr)
-	}
-	if _, err := fmt.Sscanf(monthStr, "%d", &month); err != nil {
-		return time.Time{}, fmt.Errorf("invalid month: %s", monthStr)
-	}
-
-	if day < 1 || day > 31 {
-		return time.Time{}, fmt.Errorf("invalid day: %d", day)
-	}
-	if month < 1 || month > 12 {
-		return time.Time{}, fmt.Errorf("invalid month: %d", month)
-	}
-
-	// Use
-	now := time.Now()
-	year := now.Year()
-
-	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
-
-	// Check, date 31
-	if date.Day() != day || date.Month() != time.Month(month) {
-		return time.Time{}, fmt.Errorf("invalid date: %02d/%02d", day, month)
-	}
-
-	return date, nil
-}
-
 // formatDateForFlow for in (DD Mon)
 func formatDateForFlow(date time.Time) string {
 	months := []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
 	return fmt.Sprintf("%02d %s", date.Day(), months[date.Month()-1])
 }
 
-// formatBTCValueForFlow value BTC for in
-func formatBTCValueForFlow(btcValue float64) string {
+// formatUSDAtDate returns " ≈ $X" for BTC value at BTC price of date (YYYY-MM-DD)
+// Empty if value is 0 or price of date is unknown
+func formatUSDAtDate(btcValue float64, date string) string {
 	if btcValue == 0 {
-		return "0"
+		return ""
 	}
-
-	// Format BTC 8 and remove
-	formatted := fmt.Sprintf("%.8f", btcValue)
-	formatted = strings.TrimRight(formatted, "0")
-	formatted = strings.TrimRight(formatted, ".")
-
-	return formatted
+	usdValue, ok := btc_price.ToUSD(btcValue, date)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" ≈ $%s", luminex.FormatUSDValue(usdValue))
 }
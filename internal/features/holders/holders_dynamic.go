@@ -4,258 +4,78 @@ package holders
 // on saved_holders.json
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"spark-wallet/internal/amount"
 	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/infra/events"
+	storage "spark-wallet/internal/infra/fs"
 	logging "spark-wallet/internal/infra/log"
 
 	"go.uber.org/zap"
 )
 
-// Holder is a minimal legacy holder record used only for converting old saved_holders.json format.
-type Holder struct {
-	Balance string `json:"balance"`
-}
-
-// TokenHoldersData is a legacy format used for converting old saved_holders.json format.
-type TokenHoldersData struct {
-	TokenIdentifier string            `json:"tokenIdentifier"`
-	Ticker          string            `json:"ticker"`
-	LastUpdated     string            `json:"lastUpdated"`
-	Holders         map[string]Holder `json:"holders"`
-	TotalCount      int               `json:"totalCount"`
-}
-
 // LoadTokenIdentifiers loads tokenIdentifier -> ticker map from JSON file.
 // Returns empty map if file doesn't exist (not an error).
 func LoadTokenIdentifiers(filename string) (map[string]string, error) {
-	type tokenIDsFile struct {
-		IDTokens map[string]string `json:"id_tokens"`
+	file, err := loadTokenIDsData(filename)
+	if err != nil {
+		return nil, err
 	}
+	return file.IDTokens, nil
+}
 
+// loadTokenIDsData loads id_tokens.json with discovery state, missing file is empty
+func loadTokenIDsData(filename string) (*tokenIDsData, error) {
+	file := &tokenIDsData{}
 	data, err := os.ReadFile(filename)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return make(map[string]string), nil
-		}
+	if err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("failed to read token identifiers file: %w", err)
 	}
-
-	var file tokenIDsFile
-	if err := json.Unmarshal(data, &file); err != nil {
-		return nil, fmt.Errorf("failed to parse token identifiers JSON: %w", err)
+	if err == nil {
+		if err := json.Unmarshal(data, file); err != nil {
+			return nil, fmt.Errorf("failed to parse token identifiers JSON: %w", err)
+		}
 	}
 
 	if file.IDTokens == nil {
-		return make(map[string]string), nil
+		file.IDTokens = make(map[string]string)
 	}
-
-	return file.IDTokens, nil
-}
-
-// WalletBalanceResponse is a minimal response for /spark/address/{pubkey}.
-// We keep only fields used by this holders module.
-type WalletBalanceResponse struct {
-	Tokens []WalletToken `json:"tokens"`
+	return file, nil
 }
 
-// WalletToken is a minimal token record used by holders checks.
-type WalletToken struct {
-	TokenIdentifier string `json:"tokenIdentifier"`
-	TokenAddress    string `json:"tokenAddress"`
-	Ticker          string `json:"ticker"`
-	Decimals        int    `json:"decimals"`
-	Balance         string `json:"balance"`
-}
+// WalletBalanceResponse - wallet response of /spark/address/{pubkey} (shared with luminex package)
+type WalletBalanceResponse = luminex.WalletBalanceResponse
 
-const luminexAddressAPIBaseURL = "https://api.luminex.io/spark/address"
+// WalletToken - token in wallet (shared with luminex package)
+type WalletToken = luminex.WalletToken
 
 // GetWalletTokensBalance fetches wallet tokens data from Luminex.
+// Compatibility wrapper over luminex.GetWalletTokensBalance
 func GetWalletTokensBalance(publicKey string) (*WalletBalanceResponse, error) {
-	if publicKey == "" {
-		return nil, fmt.Errorf("public key is empty")
-	}
-
-	url := fmt.Sprintf("%s/%s", luminexAddressAPIBaseURL, publicKey)
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Cloudflare-friendly headers (same approach as old system_works implementation).
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from Luminex API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("luminex API returned status %d", resp.StatusCode)
-	}
-
-	var balanceResp WalletBalanceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&balanceResp); err != nil {
-		return nil, fmt.Errorf("failed to decode Luminex API response: %w", err)
-	}
-	return &balanceResp, nil
-}
-
-// TokenMetadata is a minimal token metadata record used by holders module.
-type TokenMetadata struct {
-	Name   string `json:"name"`
-	Ticker string `json:"ticker"`
+	return luminex.GetWalletTokensBalance(publicKey)
 }
 
-const tokenCacheFile = "data_out/saved_ticket.json"
-
-type savedTicketsFile struct {
-	Tickets map[string]string `json:"tickets"` // poolLpPublicKey -> "TICKER:Name"
-}
+// TokenMetadata - token metadata (ticker/name) (shared with luminex package)
+type TokenMetadata = luminex.TokenMetadata
 
-// GetTokenMetadata returns token metadata (ticker/name) from local cache file.
-// This keeps holders module working without depending on internal/luminex token_metadata yet.
+// GetTokenMetadata returns token metadata (ticker/name) from token cache or Luminex API.
+// Compatibility wrapper over luminex.GetTokenMetadata
 func GetTokenMetadata(poolLpPublicKey string) *TokenMetadata {
-	if poolLpPublicKey == "" {
-		return nil
-	}
-
-	data, err := os.ReadFile(tokenCacheFile)
-	if err != nil {
-		return nil
-	}
-
-	var saved savedTicketsFile
-	if err := json.Unmarshal(data, &saved); err != nil {
-		return nil
-	}
-	if saved.Tickets == nil {
-		return nil
-	}
-
-	v, ok := saved.Tickets[poolLpPublicKey]
-	if !ok || strings.TrimSpace(v) == "" {
-		return nil
-	}
-
-	parts := strings.SplitN(v, ":", 2)
-	md := &TokenMetadata{Ticker: strings.TrimSpace(parts[0])}
-	if len(parts) == 2 {
-		md.Name = strings.TrimSpace(parts[1])
-	}
-	return md
-}
-
-const luminexPoolAPIBaseURL = "https://api.luminex.io/spark/pool"
-
-type luminexPoolResponse struct {
-	AssetAAddress  string                    `json:"assetAAddress"`
-	AssetBAddress  string                    `json:"assetBAddress"`
-	TokenAMetadata luminexTokenMetadataBrief `json:"tokenAMetadata"`
-	TokenBMetadata luminexTokenMetadataBrief `json:"tokenBMetadata"`
-}
-
-type luminexTokenMetadataBrief struct {
-	Ticker   string `json:"ticker"`
-	Decimals int    `json:"decimals"`
+	return luminex.GetTokenMetadata(poolLpPublicKey)
 }
 
 // GetTokenDecimals returns token decimals (best-effort). Defaults to 8 on any failure.
+// Compatibility wrapper over luminex.GetTokenDecimals
 func GetTokenDecimals(poolLpPublicKey string, swap flashnet.Swap, ticker string) int {
-	if poolLpPublicKey == "" {
-		return 8
-	}
-
-	url := fmt.Sprintf("%s/%s", luminexPoolAPIBaseURL, poolLpPublicKey)
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		logging.LogDebug("Failed to create request for token decimals", zap.Error(err))
-		return 8
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Referer", "https://luminex.io/")
-	req.Header.Set("Origin", "https://luminex.io")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		logging.LogDebug("Failed to fetch token decimals from Luminex API", zap.Error(err))
-		return 8
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		logging.LogDebug("Luminex pool API returned non-OK status for token decimals", zap.Int("status", resp.StatusCode))
-		return 8
-	}
-
-	var poolResp luminexPoolResponse
-	if err := json.NewDecoder(resp.Body).Decode(&poolResp); err != nil {
-		logging.LogDebug("Failed to decode Luminex pool API response for token decimals", zap.Error(err))
-		return 8
-	}
-
-	// Determine the "token side" (not BTC) using swap info.
-	var tokenAddress string
-	if swap.AssetOutAddress == flashnet.NativeTokenAddress {
-		tokenAddress = swap.AssetInAddress
-	} else if swap.AssetInAddress == flashnet.NativeTokenAddress {
-		tokenAddress = swap.AssetOutAddress
-	} else {
-		if swap.PoolAssetBAddress == flashnet.NativeTokenAddress {
-			tokenAddress = swap.PoolAssetAAddress
-		} else {
-			tokenAddress = swap.PoolAssetBAddress
-		}
-	}
-
-	// Best-effort selection; we don't have asset addresses in swap, so rely on ticker if provided.
-	if ticker != "" {
-		if strings.EqualFold(poolResp.TokenAMetadata.Ticker, ticker) && poolResp.TokenAMetadata.Decimals > 0 {
-			return poolResp.TokenAMetadata.Decimals
-		}
-		if strings.EqualFold(poolResp.TokenBMetadata.Ticker, ticker) && poolResp.TokenBMetadata.Decimals > 0 {
-			return poolResp.TokenBMetadata.Decimals
-		}
-	}
-
-	// Fallback: if tokenAddress known, try to pick opposite side of BTC by comparing pool assets.
-	if tokenAddress != "" {
-		if poolResp.AssetAAddress == tokenAddress && poolResp.TokenAMetadata.Decimals > 0 {
-			return poolResp.TokenAMetadata.Decimals
-		}
-		if poolResp.AssetBAddress == tokenAddress && poolResp.TokenBMetadata.Decimals > 0 {
-			return poolResp.TokenBMetadata.Decimals
-		}
-	}
-
-	// Last resort: pick non-zero.
-	if poolResp.TokenAMetadata.Decimals > 0 {
-		return poolResp.TokenAMetadata.Decimals
-	}
-	if poolResp.TokenBMetadata.Decimals > 0 {
-		return poolResp.TokenBMetadata.Decimals
-	}
-	return 8
+	return luminex.GetTokenDecimals(poolLpPublicKey, swap, ticker)
 }
 
 // SavedHoldersData - for in address:N
@@ -272,22 +92,45 @@ type DynamicHoldersData struct {
 // BalanceChange -
 // - action wallet
 type BalanceChange struct {
-	Amount float64 `json:"amount"` // count tokens
-	Delta  float64 `json:"delta"`  // /)
-	Action string  `json:"action"` // "invested" or "sold" or "liquidated"
-	Value  float64 `json:"value"`  // amount in BTC
-	Date   string  `json:"date"`   // date in YYYY-MM-DD
+	Amount  float64 `json:"amount"`            // count tokens
+	Delta   float64 `json:"delta"`             // /)
+	Action  string  `json:"action"`            // "invested" or "sold" or "liquidated"
+	Value   float64 `json:"value"`             // amount in BTC
+	Date    string  `json:"date"`              // date in YYYY-MM-DD
+	CatchUp bool    `json:"catchUp,omitempty"` // found by catch-up check after missed checks, happened between Since and Date
+	Since   string  `json:"since,omitempty"`   // date of previous successful check in YYYY-MM-DD (catch-up only)
+}
+
+// SavedHoldersFile returns path of saved_holders.json of ticker
+func SavedHoldersFile(ticker string) string {
+	return filepath.Join(HoldersModuleDir(), ticker, "saved_holders.json")
+}
+
+// DynamicHoldersFile returns path of dynamic_holders.json of ticker
+func DynamicHoldersFile(ticker string) string {
+	return filepath.Join(HoldersModuleDir(), ticker, "dynamic_holders.json")
+}
+
+// LockSavedHolders serializes read-modify-write of saved_holders.json of ticker, returns unlock function
+// When both files are changed, saved holders are locked before dynamic holders
+func LockSavedHolders(ticker string) func() {
+	return storage.LockFile(SavedHoldersFile(ticker))
+}
+
+// lockDynamicHolders serializes read-modify-write of dynamic_holders.json of ticker
+func lockDynamicHolders(ticker string) func() {
+	return storage.LockFile(DynamicHoldersFile(ticker))
 }
 
 // LoadSavedHolders from file saved_holders.json
-// for (ASTY, SOON, BITTY)
+// only for tracked tickers (see GetAllowedTickers)
 func LoadSavedHolders(ticker string) (*SavedHoldersData, error) {
 	// Check, ticker
 	if !IsTickerAllowed(ticker) {
-		return nil, fmt.Errorf("ticker %s is not in allowed list (ASTY, SOON, BITTY)", ticker)
+		return nil, fmt.Errorf("ticker %s is not tracked", ticker)
 	}
 
-	filename := filepath.Join("data_out", "holders_module", ticker, "saved_holders.json")
+	filename := SavedHoldersFile(ticker)
 
 	data, err := os.ReadFile(filename)
 	if err != nil {
@@ -308,9 +151,10 @@ func LoadSavedHolders(ticker string) (*SavedHoldersData, error) {
 		}, nil
 	}
 
+	// Old format (holder objects) is converted by startup migration (see migrations.go)
 	var holdersData SavedHoldersData
 	if err := json.Unmarshal(data, &holdersData); err != nil {
-		return convertOldHoldersFormat(data, ticker)
+		return nil, fmt.Errorf("failed to parse saved holders JSON: %w", err)
 	}
 
 	if holdersData.Holders == nil {
@@ -320,52 +164,21 @@ func LoadSavedHolders(ticker string) (*SavedHoldersData, error) {
 	return &holdersData, nil
 }
 
-// convertOldHoldersFormat holders in saved_holders
-func convertOldHoldersFormat(data []byte, ticker string) (*SavedHoldersData, error) {
-	var oldData TokenHoldersData
-	if err := json.Unmarshal(data, &oldData); err != nil {
-		return nil, fmt.Errorf("failed to parse holders file: %w", err)
-	}
-
-	newData := &SavedHoldersData{
-		Holders: make(map[string]string),
-	}
-
-	for pubkey, holder := range oldData.Holders {
-		// Use pubkey address (in
-		// Save balance
-		newData.Holders[pubkey] = holder.Balance
-	}
-
-	if err := SaveSavedHolders(ticker, newData); err != nil {
-		logging.LogWarn("Failed to save converted holders data", zap.String("ticker", ticker), zap.Error(err))
-	}
-
-	return newData, nil
-}
-
 // SaveSavedHolders in file saved_holders.json
-// for (ASTY, SOON, BITTY)
+// only for tracked tickers (see GetAllowedTickers)
 func SaveSavedHolders(ticker string, data *SavedHoldersData) error {
 	// Check, ticker
 	if !IsTickerAllowed(ticker) {
-		return fmt.Errorf("ticker %s is not in allowed list (ASTY, SOON, BITTY)", ticker)
+		return fmt.Errorf("ticker %s is not tracked", ticker)
 	}
 
-	holdersDir := filepath.Join("data_out", "holders_module", ticker)
-	// Check, folder create
-	if _, err := os.Stat(holdersDir); os.IsNotExist(err) {
-		return fmt.Errorf("holders directory does not exist for ticker %s (only ASTY, SOON, BITTY are allowed)", ticker)
-	}
-
-	filename := filepath.Join(holdersDir, "saved_holders.json")
-
 	dataBytes, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal saved holders data: %w", err)
 	}
 
-	if err := os.WriteFile(filename, dataBytes, 0644); err != nil {
+	// Atomic write: concurrent readers never see partial file
+	if err := storage.WriteFileAtomic(SavedHoldersFile(ticker), dataBytes, 0644); err != nil {
 		return fmt.Errorf("failed to write saved holders file: %w", err)
 	}
 
@@ -373,14 +186,14 @@ func SaveSavedHolders(ticker string, data *SavedHoldersData) error {
 }
 
 // LoadDynamicHolders from file dynamic_holders.json
-// for (ASTY, SOON, BITTY)
+// only for tracked tickers (see GetAllowedTickers)
 func LoadDynamicHolders(ticker string) (*DynamicHoldersData, error) {
 	// Check, ticker
 	if !IsTickerAllowed(ticker) {
-		return nil, fmt.Errorf("ticker %s is not in allowed list (ASTY, SOON, BITTY)", ticker)
+		return nil, fmt.Errorf("ticker %s is not tracked", ticker)
 	}
 
-	filename := filepath.Join("data_out", "holders_module", ticker, "dynamic_holders.json")
+	filename := DynamicHoldersFile(ticker)
 
 	data, err := os.ReadFile(filename)
 	if err != nil {
@@ -406,63 +219,10 @@ func LoadDynamicHolders(ticker string) (*DynamicHoldersData, error) {
 		}, nil
 	}
 
+	// Old format that does not match DynamicHoldersData is converted by startup migration (see migrations.go)
 	var dynamicData DynamicHoldersData
 	if err := json.Unmarshal(data, &dynamicData); err != nil {
 		logging.LogError("Failed to parse dynamic holders JSON", zap.String("ticker", ticker), zap.String("filename", filename), zap.String("data", string(data)), zap.Error(err))
-		var oldFormat map[string]interface{}
-		if err2 := json.Unmarshal(data, &oldFormat); err2 == nil {
-			dynamicData = DynamicHoldersData{
-				LastCheckDate: "",
-				Changes:       make(map[string][]BalanceChange),
-				DailyCounts:   make(map[string]int),
-			}
-			if lastCheck, ok := oldFormat["lastCheckDate"].(string); ok {
-				dynamicData.LastCheckDate = lastCheck
-			}
-			if changes, ok := oldFormat["changes"].(map[string]interface{}); ok {
-				dynamicData.Changes = make(map[string][]BalanceChange)
-				for addr, changeList := range changes {
-					if list, ok := changeList.([]interface{}); ok {
-						for _, item := range list {
-							if changeMap, ok := item.(map[string]interface{}); ok {
-								change := BalanceChange{}
-								if amount, ok := changeMap["amount"].(float64); ok {
-									change.Amount = amount
-								}
-								if delta, ok := changeMap["delta"].(float64); ok {
-									change.Delta = delta
-								}
-								if action, ok := changeMap["action"].(string); ok {
-									change.Action = action
-								}
-								// If value use 0 (for
-								if value, ok := changeMap["value"].(float64); ok {
-									change.Value = value
-								} else {
-									change.Value = 0 // for value
-								}
-								// If date in use
-								if date, ok := changeMap["date"].(string); ok {
-									change.Date = date
-								} else {
-									// If use or lastCheckDate
-									if dynamicData.LastCheckDate != "" {
-										change.Date = dynamicData.LastCheckDate
-									} else {
-										change.Date = time.Now().Format("2006-01-02")
-									}
-								}
-								dynamicData.Changes[addr] = append(dynamicData.Changes[addr], change)
-							}
-						}
-					}
-				}
-			}
-			if err := SaveDynamicHolders(ticker, &dynamicData); err != nil {
-				logging.LogWarn("Failed to save converted dynamic holders", zap.String("ticker", ticker), zap.Error(err))
-			}
-			return &dynamicData, nil
-		}
 		return nil, fmt.Errorf("failed to parse dynamic holders JSON: %w", err)
 	}
 
@@ -477,51 +237,29 @@ func LoadDynamicHolders(ticker string) (*DynamicHoldersData, error) {
 }
 
 // SaveDynamicHolders in file dynamic_holders.json
-// for (ASTY, SOON, BITTY)
+// only for tracked tickers (see GetAllowedTickers)
 func SaveDynamicHolders(ticker string, data *DynamicHoldersData) error {
 	// Check, ticker
 	if !IsTickerAllowed(ticker) {
-		return fmt.Errorf("ticker %s is not in allowed list (ASTY, SOON, BITTY)", ticker)
-	}
-
-	holdersDir := filepath.Join("data_out", "holders_module", ticker)
-	// Check, folder create
-	if _, err := os.Stat(holdersDir); os.IsNotExist(err) {
-		return fmt.Errorf("holders directory does not exist for ticker %s (only ASTY, SOON, BITTY are allowed)", ticker)
+		return fmt.Errorf("ticker %s is not tracked", ticker)
 	}
 
-	filename := filepath.Join(holdersDir, "dynamic_holders.json")
-
 	dataBytes, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal dynamic holders data: %w", err)
 	}
 
-	if err := os.WriteFile(filename, dataBytes, 0644); err != nil {
+	if err := storage.WriteFileAtomic(DynamicHoldersFile(ticker), dataBytes, 0644); err != nil {
 		return fmt.Errorf("failed to write dynamic holders file: %w", err)
 	}
 
 	return nil
 }
 
-func GetAllowedTickers() []string {
-	return []string{"ASTY", "SOON", "BITTY"}
-}
-
-func IsTickerAllowed(ticker string) bool {
-	allowed := GetAllowedTickers()
-	for _, allowedTicker := range allowed {
-		if strings.EqualFold(ticker, allowedTicker) {
-			return true
-		}
-	}
-	return false
-}
-
 // AddHolderToSaved in saved_holders.json
 // address - or address wallet
 // tokenAmount - count tokens
-// for (ASTY, SOON, BITTY)
+// only for tracked tickers (see GetAllowedTickers)
 func AddHolderToSaved(ticker string, address string, tokenAmount string) error {
 	if ticker == "" || address == "" || tokenAmount == "" {
 		return fmt.Errorf("ticker, address and tokenAmount are required")
@@ -533,6 +271,9 @@ func AddHolderToSaved(ticker string, address string, tokenAmount string) error {
 		return nil // error,
 	}
 
+	unlock := LockSavedHolders(ticker)
+	defer unlock()
+
 	savedData, err := LoadSavedHolders(ticker)
 	if err != nil {
 		return fmt.Errorf("failed to load saved holders: %w", err)
@@ -558,9 +299,12 @@ func UpdateDynamicHoldersFromSwap(ticker string, swapperPublicKey string, curren
 
 	// Check, ticker
 	if !IsTickerAllowed(ticker) {
-		return fmt.Errorf("ticker %s is not in allowed list (ASTY, SOON, BITTY)", ticker)
+		return fmt.Errorf("ticker %s is not tracked", ticker)
 	}
 
+	unlock := lockDynamicHolders(ticker)
+	defer unlock()
+
 	// Load
 	dynamicData, err := LoadDynamicHolders(ticker)
 	if err != nil {
@@ -605,6 +349,15 @@ func UpdateDynamicHoldersFromSwap(ticker string, swapperPublicKey string, curren
 	if err := SaveDynamicHolders(ticker, dynamicData); err != nil {
 		return fmt.Errorf("failed to save dynamic holders: %w", err)
 	}
+	recordHolderChangeEvents([]events.HolderChange{{
+		Ticker:  ticker,
+		Address: swapperPublicKey,
+		Action:  action,
+		Amount:  currentAmount,
+		Delta:   delta,
+		Value:   btcValue,
+		Source:  events.HolderSourceSwap,
+	}})
 
 	logging.LogDebug("Updated dynamic holders from swap",
 		zap.String("ticker", ticker),
@@ -639,10 +392,9 @@ func GetTokenBalanceFromWallet(publicKey string, ticker string) (string, float64
 	// token by ticker in wallet in GetWalletTokenHolding)
 	for _, token := range balanceResp.Tokens {
 		if token.Ticker == ticker {
-			// Parse balance
-			var balanceValue float64
-			n, err := fmt.Sscanf(token.Balance, "%f", &balanceValue)
-			if err != nil || n != 1 {
+			// Raw balance / 10^decimals
+			tokenAmount, err := amount.ScaleFloat(token.Balance, token.Decimals)
+			if err != nil {
 				logging.LogWarn("Failed to parse token balance",
 					zap.String("ticker", ticker),
 					zap.String("balance", token.Balance),
@@ -650,13 +402,6 @@ func GetTokenBalanceFromWallet(publicKey string, ticker string) (string, float64
 				continue
 			}
 
-			// on 10^decimals for tokens
-			decimalsMultiplier := 1.0
-			for i := 0; i < token.Decimals; i++ {
-				decimalsMultiplier *= 10
-			}
-			tokenAmount := balanceValue / decimalsMultiplier
-
 			logging.LogDebug("Found token balance", zap.String("publicKey", publicKey), zap.String("ticker", ticker), zap.Float64("amount", tokenAmount), zap.String("rawBalance", token.Balance))
 			return token.Balance, tokenAmount, nil
 		}
@@ -702,11 +447,22 @@ func CheckHoldersBalance(ticker string, tokenAddress string) error {
 // CheckHoldersBalanceWithForce balance for token
 // forceCheck - if true, if
 func CheckHoldersBalanceWithForce(ticker string, tokenAddress string, forceCheck bool) error {
+	return checkHoldersBalance(ticker, tokenAddress, forceCheck, "")
+}
+
+// CheckHoldersBalanceCatchUp checks balance after missed checks (process down, API outage)
+// Changes are marked as catch-up with date of previous successful check (since)
+func CheckHoldersBalanceCatchUp(ticker string, tokenAddress string, since time.Time) error {
+	return checkHoldersBalance(ticker, tokenAddress, true, since.Format("2006-01-02"))
+}
+
+// checkHoldersBalance - balance check, catchUpSince is empty for regular check
+func checkHoldersBalance(ticker string, tokenAddress string, forceCheck bool, catchUpSince string) error {
 	if ticker == "" {
 		return fmt.Errorf("ticker is required")
 	}
 
-	// Load
+	// Load snapshot of holders to check (balances are fetched without locks, swaps keep updating files meanwhile)
 	savedData, err := LoadSavedHolders(ticker)
 	if err != nil {
 		logging.LogError("Failed to load saved holders", zap.String("ticker", ticker), zap.Error(err))
@@ -719,31 +475,86 @@ func CheckHoldersBalanceWithForce(ticker string, tokenAddress string, forceCheck
 		return nil
 	}
 
-	// Load
-	dynamicData, err := LoadDynamicHolders(ticker)
-	if err != nil {
-		logging.LogError("Failed to load dynamic holders", zap.String("ticker", ticker), zap.Error(err))
-		return fmt.Errorf("failed to load dynamic holders: %w", err)
-	}
-
 	// Get
 	currentDate := time.Now().Format("2006-01-02")
 
-	if dynamicData.LastCheckDate != currentDate {
-		dynamicData.DailyCounts = make(map[string]int)
-		// Update if
-		dynamicData.LastCheckDate = currentDate
-		// Save if
-		if err := SaveDynamicHolders(ticker, dynamicData); err != nil {
-			logging.LogWarn("Failed to save lastCheckDate update", zap.String("ticker", ticker), zap.Error(err))
+	// If forceCheck = true, check even if already checked today
+	if !forceCheck {
+		dynamicData, err := LoadDynamicHolders(ticker)
+		if err != nil {
+			logging.LogError("Failed to load dynamic holders", zap.String("ticker", ticker), zap.Error(err))
+			return fmt.Errorf("failed to load dynamic holders: %w", err)
+		}
+		if dynamicData.LastCheckDate == currentDate {
+			logging.LogDebug("Holders balance already checked today, skipping", zap.String("ticker", ticker), zap.String("lastCheckDate", dynamicData.LastCheckDate))
+			return nil
 		}
 	}
 
-	// If forceCheck = true
-	if !forceCheck && dynamicData.LastCheckDate == currentDate {
-		logging.LogDebug("Holders balance already checked today, skipping", zap.String("ticker", ticker), zap.String("lastCheckDate", dynamicData.LastCheckDate))
-		// lastCheckDate if date
-		return nil
+	logging.LogInfo("Checking holders balance", zap.String("ticker", ticker), zap.Int("holdersCount", len(savedData.Holders)))
+
+	// Parse balances from saved_holders.json, wallets with valid balance are fetched
+	type checkedBalance struct {
+		savedBalance  string // value in saved_holders.json at fetch time
+		savedAmount   float64
+		currentAmount float64
+	}
+	checked := make(map[string]checkedBalance)
+	wallets := make([]string, 0, len(savedData.Holders))
+	for swapperPublicKey, savedBalanceStr := range savedData.Holders {
+		savedAmount, err := amount.ParseFloat(savedBalanceStr)
+		if err != nil {
+			logging.LogWarn("Failed to parse saved balance", zap.String("swapperPublicKey", swapperPublicKey), zap.String("savedBalanceStr", savedBalanceStr), zap.Error(err))
+			continue
+		}
+		checked[swapperPublicKey] = checkedBalance{savedBalance: savedBalanceStr, savedAmount: savedAmount}
+		wallets = append(wallets, swapperPublicKey)
+	}
+
+	// Balances from https://api.luminex.io/spark/address/{swapperPublicKey} by worker pool (see balance_fetcher.go)
+	fetchStarted := time.Now()
+	fetched := FetchTokenBalances(context.Background(), ticker, wallets)
+	for swapperPublicKey, balance := range checked {
+		currentAmount, ok := fetched.Balances[swapperPublicKey]
+		if !ok {
+			delete(checked, swapperPublicKey)
+			continue
+		}
+		balance.currentAmount = currentAmount
+		checked[swapperPublicKey] = balance
+	}
+	balanceFailures := len(fetched.Failed)
+	if balanceFailures > 0 {
+		failedWallets := fetched.FailedWallets()
+		logging.LogWarn("Failed to get balance of some holders, their saved balance is kept until next check",
+			zap.String("ticker", ticker),
+			zap.Int("failed", balanceFailures),
+			zap.Int("holdersCount", len(wallets)),
+			zap.Strings("wallets", failedWallets[:min(len(failedWallets), 10)]),
+			zap.Error(fetched.Failed[failedWallets[0]]))
+	}
+	logging.LogDebug("Holders balances fetched",
+		zap.String("ticker", ticker),
+		zap.Int("fetched", len(fetched.Balances)),
+		zap.Int("failed", balanceFailures),
+		zap.Int("requests", fetched.Requests),
+		zap.Duration("took", time.Since(fetchStarted)))
+
+	// Apply results under locks of both files (same order as saveHolderFromSwap: saved, then dynamic)
+	unlockSaved := LockSavedHolders(ticker)
+	defer unlockSaved()
+	unlockDynamic := lockDynamicHolders(ticker)
+	defer unlockDynamic()
+
+	savedData, err = LoadSavedHolders(ticker)
+	if err != nil {
+		logging.LogError("Failed to load saved holders", zap.String("ticker", ticker), zap.Error(err))
+		return fmt.Errorf("failed to load saved holders: %w", err)
+	}
+	dynamicData, err := LoadDynamicHolders(ticker)
+	if err != nil {
+		logging.LogError("Failed to load dynamic holders", zap.String("ticker", ticker), zap.Error(err))
+		return fmt.Errorf("failed to load dynamic holders: %w", err)
 	}
 
 	// Update if
@@ -752,32 +563,19 @@ func CheckHoldersBalanceWithForce(ticker string, tokenAddress string, forceCheck
 		dynamicData.DailyCounts = make(map[string]int)
 	}
 
-	logging.LogInfo("Checking holders balance", zap.String("ticker", ticker), zap.Int("holdersCount", len(savedData.Holders)))
-
-	// Check balance from saved_holders.json
 	// addresses saveHolderFromSwap swap'
 	// and and swap'
 	const minBalanceThreshold = 10.0 // balance for (10 tokens)
 	hasChanges := false
 	changesDetected := 0
 	liquidatedCount := 0
-	for swapperPublicKey, savedBalanceStr := range savedData.Holders {
-		// Parse balance from saved_holders.json
-		var savedAmount float64
-		n, err := fmt.Sscanf(savedBalanceStr, "%f", &savedAmount)
-		if err != nil || n != 1 {
-			logging.LogWarn("Failed to parse saved balance", zap.String("swapperPublicKey", swapperPublicKey), zap.String("savedBalanceStr", savedBalanceStr), zap.Error(err))
-			continue
-		}
-
-		// Get balance API from wallet_balance.go
-		// API: https://api.luminex.io/spark/address/{swapperPublicKey}
-		// Use swapperPublicKey and ticker for token
-		_, currentAmount, err := GetTokenBalanceFromWallet(swapperPublicKey, ticker)
-		if err != nil {
-			logging.LogWarn("Failed to get wallet balance", zap.String("swapperPublicKey", swapperPublicKey), zap.String("ticker", ticker), zap.Error(err))
+	var changeEvents []events.HolderChange
+	for swapperPublicKey, balance := range checked {
+		// Swap of holder was recorded while balance was fetched - fetched balance is stale
+		if savedData.Holders[swapperPublicKey] != balance.savedBalance {
 			continue
 		}
+		savedAmount, currentAmount := balance.savedAmount, balance.currentAmount
 
 		// balance from saved_holders.json
 		// Use for float (0.0001)
@@ -832,11 +630,24 @@ func CheckHoldersBalanceWithForce(ticker string, tokenAddress string, forceCheck
 			// - action wallet
 			// in swap, Value = 0
 			dynamicData.Changes[swapperPublicKey] = append(dynamicData.Changes[swapperPublicKey], BalanceChange{
-				Amount: currentAmount,
-				Delta:  delta,
-				Action: action,
-				Value:  0,           // in swap, Value = 0
-				Date:   currentDate, // date in YYYY-MM-DD
+				Amount:  currentAmount,
+				Delta:   delta,
+				Action:  action,
+				Value:   0,           // in swap, Value = 0
+				Date:    currentDate, // date in YYYY-MM-DD
+				CatchUp: catchUpSince != "",
+				Since:   catchUpSince,
+			})
+
+			changeEvents = append(changeEvents, events.HolderChange{
+				Ticker:  ticker,
+				Address: swapperPublicKey,
+				Action:  action,
+				Amount:  currentAmount,
+				Delta:   delta,
+				Source:  events.HolderSourceCheck,
+				CatchUp: catchUpSince != "",
+				Since:   catchUpSince,
 			})
 
 			// Update saved_holders (if balance >= 10 tokens)
@@ -850,16 +661,11 @@ func CheckHoldersBalanceWithForce(ticker string, tokenAddress string, forceCheck
 				zap.Float64("oldBalance", savedAmount),
 				zap.Float64("newBalance", currentAmount),
 				zap.String("action", action),
-				zap.String("source", "periodic_check"))
+				zap.String("source", "periodic_check"),
+				zap.Bool("catchUp", catchUpSince != ""))
 		}
 	}
 
-	// Update if
-	if dynamicData.LastCheckDate != currentDate {
-		dynamicData.LastCheckDate = currentDate
-		dynamicData.DailyCounts = make(map[string]int)
-	}
-
 	// Save if lastCheckDate)
 	if hasChanges {
 		if err := SaveSavedHolders(ticker, savedData); err != nil {
@@ -871,14 +677,23 @@ func CheckHoldersBalanceWithForce(ticker string, tokenAddress string, forceCheck
 		logging.LogError("Failed to save dynamic holders after check", zap.String("ticker", ticker), zap.Error(err))
 		return fmt.Errorf("failed to save dynamic holders: %w", err)
 	}
+	recordHolderChangeEvents(changeEvents)
+
+	// API outage: check of no wallet succeeded, it must be retried
+	if balanceFailures > 0 && balanceFailures == len(wallets) {
+		return fmt.Errorf("failed to get balance of all %d holders of %s", balanceFailures, ticker)
+	}
 
 	if hasChanges {
 		logging.LogInfo("Holders balance check completed with changes",
 			zap.String("ticker", ticker),
 			zap.Int("changesDetected", changesDetected),
-			zap.Int("liquidatedCount", liquidatedCount))
+			zap.Int("liquidatedCount", liquidatedCount),
+			zap.Int("balanceFailures", balanceFailures))
 	} else {
-		logging.LogInfo("Holders balance check completed - no changes detected", zap.String("ticker", ticker))
+		logging.LogInfo("Holders balance check completed - no changes detected",
+			zap.String("ticker", ticker),
+			zap.Int("balanceFailures", balanceFailures))
 	}
 
 	return nil
@@ -886,8 +701,7 @@ func CheckHoldersBalanceWithForce(ticker string, tokenAddress string, forceCheck
 
 // GetTickerFromTokenAddress ticker token by from id_tokens.json
 func GetTickerFromTokenAddress(tokenAddress string) (string, error) {
-	tokenIDsFile := "data_out/holders_module/id_tokens.json"
-	tokenIDs, err := LoadTokenIdentifiers(tokenIDsFile)
+	tokenIDs, err := LoadTokenIdentifiers(TokenIDsFile())
 	if err != nil {
 		return "", fmt.Errorf("failed to load token identifiers: %w", err)
 	}
@@ -920,28 +734,27 @@ func GetTickerFromPoolLpPublicKey(poolLpPublicKey string) (string, error) {
 // FormatTokenAmountForSaved count tokens for in saved_holders.json
 // count tokens from swap and for
 func FormatTokenAmountForSaved(amountStr string, decimals int) string {
-	// Parse count
-	var amountValue float64
-	n, err := fmt.Sscanf(amountStr, "%f", &amountValue)
-	if err != nil || n != 1 {
+	// Raw amount / 10^decimals, exact
+	tokenAmount, err := amount.Scale(amountStr, decimals)
+	if err != nil {
 		logging.LogWarn("Failed to parse token amount in FormatTokenAmountForSaved",
 			zap.String("amountStr", amountStr),
 			zap.Error(err))
 		return "0"
 	}
 
-	// on 10^decimals for tokens
-	decimalsMultiplier := 1.0
-	for i := 0; i < decimals; i++ {
-		decimalsMultiplier *= 10
-	}
-	tokenAmount := amountValue / decimalsMultiplier
-
 	// Format
-	return fmt.Sprintf("%.8f", tokenAmount)
+	return amount.FormatFixed(tokenAmount, 8)
 }
 
 // GetTokenDecimalsFromSwap decimals token from swap
 func GetTokenDecimalsFromSwap(swap flashnet.Swap, poolLpPublicKey string, ticker string) int {
 	return GetTokenDecimals(poolLpPublicKey, swap, ticker)
 }
+
+// recordHolderChangeEvents writes holder changes to event log (data_out/events)
+func recordHolderChangeEvents(changes []events.HolderChange) {
+	if err := events.RecordHolderChanges(changes); err != nil {
+		logging.LogWarn("Failed to record holder change events", zap.Int("count", len(changes)), zap.Error(err))
+	}
+}
@@ -4,12 +4,15 @@ package holders
 
 import (
 	"fmt"
+	"html"
 	"math/big"
+	"spark-wallet/internal/amount"
 	"spark-wallet/internal/clients_api/flashnet"
 	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/wallet_labels"
 	storage "spark-wallet/internal/infra/fs"
 	logging "spark-wallet/internal/infra/log"
-	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +23,7 @@ type HolderReportEntry struct {
 	Address      string  // address (publicKey)
 	AddressShort string  // 3 addresses
 	Username     string  // (username) or
+	Label        string  // wallet label (/label), shown instead of username
 	SparkAddress string  // Spark address for
 	FirstBuy     string  // date or
 	Balance      float64 // balance tokens
@@ -29,18 +33,18 @@ type HolderReportEntry struct {
 	Value        float64 // amount in BTC
 }
 
-func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Client) (string, error) {
+func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Client, lang i18n.Lang) (string, error) {
 	if ticker == "" || dateStr == "" {
 		return "", fmt.Errorf("ticker and date are required")
 	}
 
 	// Check, ticker
 	if !IsTickerAllowed(ticker) {
-		return "", fmt.Errorf("ticker %s is not in allowed list (ASTY, SOON, BITTY)", ticker)
+		return "", fmt.Errorf("ticker %s is not tracked", ticker)
 	}
 
-	// Parse from DDMM in YYYY-MM-DD
-	parsedDate, err := parseDateDDMM(dateStr)
+	// Parse date argument (DDMM, DD.MM, YYYY-MM-DD, today, ...) in YYYY-MM-DD
+	parsedDate, err := ParseReportDate(dateStr, time.Now())
 	if err != nil {
 		return "", fmt.Errorf("failed to parse date %s: %w", dateStr, err)
 	}
@@ -93,7 +97,7 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 	}
 
 	if len(addressesForDate) == 0 {
-		return fmt.Sprintf("Report for %s:\n\nNo data for the specified date", dateFormatted), nil
+		return i18n.T(lang, "holders_report.no_data", dateFormatted), nil
 	}
 
 	var reportEntries []HolderReportEntry
@@ -119,7 +123,7 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 		var currentBalance float64
 		if balanceStr, exists := savedData.Holders[address]; exists {
 			// Parse balance from saved_holders
-			if parsedBalance, err := strconv.ParseFloat(balanceStr, 64); err == nil {
+			if parsedBalance, err := amount.ParseFloat(balanceStr); err == nil {
 				currentBalance = parsedBalance
 			} else {
 				currentBalance = lastChange.Amount
@@ -144,7 +148,7 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 		// Get
 		firstBuyDate := ""
 		if client != nil {
-			firstBuy, err := flashnet.GetFirstBuySwap(client, address, poolLpPublicKey)
+			firstBuy, err := storage.GetFirstBuyDate(client, address, poolLpPublicKey)
 			if err == nil && firstBuy != "" {
 				firstBuyDate = firstBuy
 			}
@@ -172,6 +176,7 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 			Address:      address,
 			AddressShort: addressShort,
 			Username:     username,
+			Label:        wallet_labels.Of(address),
 			SparkAddress: sparkAddress,
 			FirstBuy:     firstBuyDate,
 			Balance:      currentBalance,
@@ -183,7 +188,7 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 	}
 
 	var report strings.Builder
-	report.WriteString(fmt.Sprintf("Report for %s (%s):\n\n", dateFormatted, ticker))
+	report.WriteString(i18n.T(lang, "holders_report.title", dateFormatted, ticker))
 
 	// HTML
 	report.WriteString("<blockquote>\n")
@@ -202,8 +207,10 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 		}
 
 		// Get for (username or "wallet")
-		displayName := "wallet"
-		if entry.Username != "" {
+		displayName := i18n.T(lang, "holders_report.wallet")
+		if entry.Label != "" {
+			displayName = html.EscapeString(entry.Label)
+		} else if entry.Username != "" {
 			displayName = entry.Username
 		}
 
@@ -226,7 +233,7 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 		valueStr := formatBTCValue(entry.Value)
 		// in <code> for in Telegram
 		if valueStr != "{}" {
-			valueStr = fmt.Sprintf("<code>%s</code>", valueStr)
+			valueStr = fmt.Sprintf("<code>%s</code>", valueStr) + formatUSDAtDate(entry.Value, dateFormatted)
 		}
 
 		// in Telegram)
@@ -234,18 +241,18 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 		switch entry.Action {
 		case "invested":
 			if entry.DailyCount > 0 {
-				actionStr = fmt.Sprintf("BUY ×%d", entry.DailyCount)
+				actionStr = i18n.T(lang, "holders_report.buy", entry.DailyCount)
 			} else {
-				actionStr = "BUY ×1"
+				actionStr = i18n.T(lang, "holders_report.buy", 1)
 			}
 		case "sold":
 			if entry.DailyCount > 0 {
-				actionStr = fmt.Sprintf("SELL ×%d", entry.DailyCount)
+				actionStr = i18n.T(lang, "holders_report.sell", entry.DailyCount)
 			} else {
-				actionStr = "SELL ×1"
+				actionStr = i18n.T(lang, "holders_report.sell", 1)
 			}
 		case "liquidated":
-			actionStr = "LIQUIDATED"
+			actionStr = i18n.T(lang, "holders_report.liquidated")
 		default:
 			actionStr = strings.ToUpper(entry.Action)
 		}
@@ -259,7 +266,7 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 			walletLink,
 			displayName,
 			entry.AddressShort))
-		report.WriteString(fmt.Sprintf("Balance: %s | First buy: %s | Value: %s | Action: %s\n\n",
+		report.WriteString(i18n.T(lang, "holders_report.entry",
 			balanceStr,
 			firstBuyStr,
 			valueStr,
@@ -272,41 +279,6 @@ func GenerateHoldersReport(ticker string, dateStr string, client *flashnet.Clien
 	return report.String(), nil
 }
 
-// parseDateDDMM from DDMM in time.Time
-func parseDateDDMM(dateStr string) (time.Time, error) {
-	if len(dateStr) != 4 {
-		return time.Time{}, fmt.Errorf("date must be in DDMM format (4 digits)")
-	}
-
-	day, err := strconv.Atoi(dateStr[:2])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid day: %w", err)
-	}
-
-	month, err := strconv.Atoi(dateStr[2:])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid month: %w", err)
-	}
-
-	if day < 1 || day > 31 {
-		return time.Time{}, fmt.Errorf("day must be between 1 and 31")
-	}
-	if month < 1 || month > 12 {
-		return time.Time{}, fmt.Errorf("month must be between 1 and 12")
-	}
-
-	// Use
-	now := time.Now()
-	year := now.Year()
-
-	// If date in use
-	if month > int(now.Month()) || (month == int(now.Month()) && day > now.Day()) {
-		year--
-	}
-
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
-}
-
 // parseTokenAmount count tokens from decimals
 func parseTokenAmount(amountStr string, decimals int) (float64, error) {
 	// Use big.Float for
@@ -423,3 +395,14 @@ func formatBTCValue(btcValue float64) string {
 
 	return formatted
 }
+
+// walletDisplayName returns label of wallet (/label, HTML-escaped), its username or "wallet"
+func walletDisplayName(address string) string {
+	if label := wallet_labels.Of(address); label != "" {
+		return html.EscapeString(label)
+	}
+	if username := luminex.GetWalletUsername(address); username != "" {
+		return username
+	}
+	return "wallet"
+}
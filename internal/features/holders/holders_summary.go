@@ -0,0 +1,161 @@
+package holders
+
+// Live summary of holders module for /holders {ticker}: tracked holders and today's moves
+// Built from saved_holders.json and dynamic_holders.json (updated on every swap), so it doesn't wait for daily report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+)
+
+// HolderMove - net move of wallet today
+type HolderMove struct {
+	Address  string
+	Delta    float64 // tokens
+	ValueBTC float64 // bought minus sold BTC
+}
+
+// HoldersSummary - current state of holders module of ticker
+type HoldersSummary struct {
+	Ticker     string
+	Date       string      // YYYY-MM-DD
+	Tracked    int         // holders with positive balance in saved_holders.json
+	Invested   int         // buys today
+	Sold       int         // sells today
+	Liquidated int         // full exits today
+	InflowBTC  float64     // bought minus sold and liquidated BTC today
+	TopGainer  *HolderMove // largest net buy today (nil if none)
+	TopSeller  *HolderMove // largest net sell today (nil if none)
+}
+
+// GetHoldersSummary returns state of holders module of ticker on day of now
+// Changes found by catch-up check are skipped: they are dated by check, not by swap
+func GetHoldersSummary(ticker string, now time.Time) (*HoldersSummary, error) {
+	ticker = strings.ToUpper(ticker)
+	if !IsTickerAllowed(ticker) {
+		return nil, fmt.Errorf("ticker %s is not tracked", ticker)
+	}
+
+	savedData, err := LoadSavedHolders(ticker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved holders: %w", err)
+	}
+	dynamicData, err := LoadDynamicHolders(ticker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dynamic holders: %w", err)
+	}
+
+	summary := &HoldersSummary{Ticker: ticker, Date: now.Format("2006-01-02")}
+	for _, amountStr := range savedData.Holders {
+		if balance, err := amount.ParseFloat(amountStr); err == nil && balance > 0 {
+			summary.Tracked++
+		}
+	}
+
+	for address, changes := range dynamicData.Changes {
+		move := HolderMove{Address: address}
+		moved := false
+		for _, change := range changes {
+			if change.Date != summary.Date || change.CatchUp {
+				continue
+			}
+			switch change.Action {
+			case "invested":
+				summary.Invested++
+				summary.InflowBTC += change.Value
+				move.ValueBTC += change.Value
+			case "sold":
+				summary.Sold++
+				summary.InflowBTC -= change.Value
+				move.ValueBTC -= change.Value
+			case "liquidated":
+				summary.Liquidated++
+				summary.InflowBTC -= change.Value
+				move.ValueBTC -= change.Value
+			default:
+				continue
+			}
+			move.Delta += change.Delta
+			moved = true
+		}
+		if !moved {
+			continue
+		}
+
+		if move.ValueBTC > 0 && (summary.TopGainer == nil || isLargerMove(move, *summary.TopGainer, 1)) {
+			gainer := move
+			summary.TopGainer = &gainer
+		}
+		if move.ValueBTC < 0 && (summary.TopSeller == nil || isLargerMove(move, *summary.TopSeller, -1)) {
+			seller := move
+			summary.TopSeller = &seller
+		}
+	}
+	return summary, nil
+}
+
+// isLargerMove compares moves by BTC in direction sign (1 - buys, -1 - sells), address breaks ties
+func isLargerMove(move HolderMove, current HolderMove, sign float64) bool {
+	if move.ValueBTC*sign != current.ValueBTC*sign {
+		return move.ValueBTC*sign > current.ValueBTC*sign
+	}
+	return move.Address < current.Address
+}
+
+// GenerateHoldersSummaryReport formats live holders summary of ticker for Telegram (HTML)
+func GenerateHoldersSummaryReport(ticker string) (string, error) {
+	summary, err := GetHoldersSummary(ticker, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	inflowSign := "+"
+	if summary.InflowBTC < 0 {
+		inflowSign = "-"
+	}
+	inflow := math.Abs(summary.InflowBTC)
+
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("Holders of %s now:\n\n", summary.Ticker))
+	report.WriteString("<blockquote>")
+	report.WriteString(fmt.Sprintf("Tracked holders: %d\n", summary.Tracked))
+	report.WriteString(fmt.Sprintf("Today: 🟢 %d invested | 🟠 %d sold | 🔴 %d liquidated\n",
+		summary.Invested, summary.Sold, summary.Liquidated))
+	report.WriteString(fmt.Sprintf("Net inflow: <code>%s%s</code> btc%s\n",
+		inflowSign, amount.FormatBTC(inflow), formatUSDAtDate(inflow, summary.Date)))
+	report.WriteString(fmt.Sprintf("Biggest gainer: %s\n", formatHolderMove(summary.TopGainer, summary.Date)))
+	report.WriteString(fmt.Sprintf("Biggest seller: %s", formatHolderMove(summary.TopSeller, summary.Date)))
+	report.WriteString("</blockquote>")
+	report.WriteString("\n<i>Updated on every swap, balances are refreshed by daily check</i>")
+
+	return report.String(), nil
+}
+
+// formatHolderMove formats wallet link with its tokens and BTC today
+func formatHolderMove(move *HolderMove, date string) string {
+	if move == nil {
+		return "none"
+	}
+
+	displayName := walletDisplayName(move.Address)
+	addressShort := move.Address
+	if len(addressShort) >= 3 {
+		addressShort = addressShort[len(addressShort)-3:]
+	}
+
+	tokens := "+" + formatBalance(move.Delta)
+	if move.Delta < 0 {
+		tokens = "-" + formatBalance(-move.Delta)
+	}
+	sign := "+"
+	if move.ValueBTC < 0 {
+		sign = "-"
+	}
+	value := math.Abs(move.ValueBTC)
+	return fmt.Sprintf("<a href=\"https://luminex.io/spark/address/%s\">%s</a> (%s) %s | <code>%s%s</code> btc%s",
+		move.Address, displayName, addressShort, tokens, sign, amount.FormatBTC(value), formatUSDAtDate(value, date))
+}
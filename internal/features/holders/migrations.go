@@ -0,0 +1,168 @@
+package holders
+
+// Storage migrations of holders module files (data_out/holders_module/{ticker}/)
+// Run at startup by migrations.Run, before monitors read the files; both are idempotent
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// Holder is a minimal legacy holder record used only for converting old saved_holders.json format.
+type Holder struct {
+	Balance string `json:"balance"`
+}
+
+// TokenHoldersData is a legacy format used for converting old saved_holders.json format.
+type TokenHoldersData struct {
+	TokenIdentifier string            `json:"tokenIdentifier"`
+	Ticker          string            `json:"ticker"`
+	LastUpdated     string            `json:"lastUpdated"`
+	Holders         map[string]Holder `json:"holders"`
+	TotalCount      int               `json:"totalCount"`
+}
+
+// MigrateSavedHoldersFormat converts saved_holders.json of all tickers from holder objects to address -> amount map
+func MigrateSavedHoldersFormat() error {
+	return migrateHoldersFiles("saved_holders.json", func(data []byte) ([]byte, bool, error) {
+		var current SavedHoldersData
+		if err := json.Unmarshal(data, &current); err == nil {
+			return nil, false, nil
+		}
+
+		var oldData TokenHoldersData
+		if err := json.Unmarshal(data, &oldData); err != nil {
+			return nil, false, fmt.Errorf("failed to parse holders file: %w", err)
+		}
+
+		newData := &SavedHoldersData{Holders: make(map[string]string, len(oldData.Holders))}
+		for pubkey, holder := range oldData.Holders {
+			newData.Holders[pubkey] = holder.Balance
+		}
+
+		raw, err := json.MarshalIndent(newData, "", "  ")
+		return raw, true, err
+	})
+}
+
+// MigrateDynamicHoldersFormat converts dynamic_holders.json of all tickers that do not parse as DynamicHoldersData
+// Changes without value get 0, without date - lastCheckDate (or today)
+func MigrateDynamicHoldersFormat() error {
+	return migrateHoldersFiles("dynamic_holders.json", func(data []byte) ([]byte, bool, error) {
+		var current DynamicHoldersData
+		if err := json.Unmarshal(data, &current); err == nil {
+			return nil, false, nil
+		}
+
+		var oldFormat map[string]interface{}
+		if err := json.Unmarshal(data, &oldFormat); err != nil {
+			return nil, false, fmt.Errorf("failed to parse dynamic holders file: %w", err)
+		}
+
+		dynamicData := DynamicHoldersData{
+			Changes:     make(map[string][]BalanceChange),
+			DailyCounts: make(map[string]int),
+		}
+		if lastCheck, ok := oldFormat["lastCheckDate"].(string); ok {
+			dynamicData.LastCheckDate = lastCheck
+		}
+		defaultDate := dynamicData.LastCheckDate
+		if defaultDate == "" {
+			defaultDate = time.Now().Format("2006-01-02")
+		}
+
+		if changes, ok := oldFormat["changes"].(map[string]interface{}); ok {
+			for addr, changeList := range changes {
+				list, ok := changeList.([]interface{})
+				if !ok {
+					continue
+				}
+				for _, item := range list {
+					changeMap, ok := item.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					change := BalanceChange{Date: defaultDate}
+					if amount, ok := changeMap["amount"].(float64); ok {
+						change.Amount = amount
+					}
+					if delta, ok := changeMap["delta"].(float64); ok {
+						change.Delta = delta
+					}
+					if action, ok := changeMap["action"].(string); ok {
+						change.Action = action
+					}
+					if value, ok := changeMap["value"].(float64); ok {
+						change.Value = value
+					}
+					if date, ok := changeMap["date"].(string); ok {
+						change.Date = date
+					}
+					dynamicData.Changes[addr] = append(dynamicData.Changes[addr], change)
+				}
+			}
+		}
+
+		raw, err := json.MarshalIndent(&dynamicData, "", "  ")
+		return raw, true, err
+	})
+}
+
+// migrateHoldersFiles applies convert to file of every ticker folder
+// convert returns new content and true if file must be rewritten
+func migrateHoldersFiles(fileName string, convert func(data []byte) ([]byte, bool, error)) error {
+	entries, err := os.ReadDir(HoldersModuleDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read holders module directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		filename := filepath.Join(HoldersModuleDir(), entry.Name(), fileName)
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+
+		dataStr := strings.TrimSpace(string(data))
+		if dataStr == "" || dataStr == "{}" || dataStr == "null" {
+			continue
+		}
+
+		converted, changed, err := convert(data)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s: %w", filename, err)
+		}
+		if !changed {
+			continue
+		}
+
+		if err := storage.WriteFileAtomic(filename, converted, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filename, err)
+		}
+
+		logging.LogInfo("Converted holders file to current format",
+			zap.String("ticker", entry.Name()),
+			zap.String("file", fileName))
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package holders
+
+// Date argument of /flash and /flow reports
+// Accepted formats: DDMM, DD.MM, DD.MM.YYYY, YYYY-MM-DD and keywords today/yesterday (сегодня/вчера)
+// Date without year is the latest such date not after today (0112 typed on 3 Jan is 1 Dec of previous year)
+// Ranges of /flow: two dates joined by "-" or ".." (0112-0712, 01.12-07.12, 2025-12-01..2025-12-07) and keywords week/month
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportDateFormats - accepted date formats for usage messages
+const ReportDateFormats = "DDMM, DD.MM, DD.MM.YYYY, YYYY-MM-DD, today, yesterday"
+
+// ReportRangeFormats - accepted range formats for usage messages
+const ReportRangeFormats = "0112-0712, 01.12-07.12, 2025-12-01..2025-12-07, week, month"
+
+// ParseReportDate parses report date relative to now (date of now is "today")
+// Returns date at 00:00 UTC, dates after today are rejected
+func ParseReportDate(dateStr string, now time.Time) (time.Time, error) {
+	dateStr = strings.ToLower(strings.TrimSpace(dateStr))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch dateStr {
+	case "":
+		return time.Time{}, fmt.Errorf("date is empty")
+	case "today", "сегодня":
+		return today, nil
+	case "yesterday", "вчера":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	var dayStr, monthStr, yearStr string
+	switch {
+	case strings.Count(dateStr, "-") == 2:
+		// YYYY-MM-DD
+		parts := strings.Split(dateStr, "-")
+		yearStr, monthStr, dayStr = parts[0], parts[1], parts[2]
+		if len(yearStr) != 4 {
+			return time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD", dateStr)
+		}
+	case strings.Contains(dateStr, "."):
+		// DD.MM or DD.MM.YYYY
+		parts := strings.Split(dateStr, ".")
+		if len(parts) < 2 || len(parts) > 3 {
+			return time.Time{}, fmt.Errorf("invalid date %q: expected DD.MM or DD.MM.YYYY", dateStr)
+		}
+		dayStr, monthStr = parts[0], parts[1]
+		if len(parts) == 3 {
+			yearStr = parts[2]
+			if len(yearStr) != 4 {
+				return time.Time{}, fmt.Errorf("invalid date %q: year must have 4 digits", dateStr)
+			}
+		}
+	case len(dateStr) == 4:
+		// DDMM
+		dayStr, monthStr = dateStr[:2], dateStr[2:]
+	default:
+		return time.Time{}, fmt.Errorf("invalid date %q: expected %s", dateStr, ReportDateFormats)
+	}
+
+	day, err := parseDatePart(dayStr, "day", 1, 31)
+	if err != nil {
+		return time.Time{}, err
+	}
+	month, err := parseDatePart(monthStr, "month", 1, 12)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if yearStr != "" {
+		year, err := parseDatePart(yearStr, "year", 2000, 9999)
+		if err != nil {
+			return time.Time{}, err
+		}
+		date, ok := makeDate(year, month, day)
+		if !ok {
+			return time.Time{}, fmt.Errorf("invalid date: %02d.%02d.%d", day, month, year)
+		}
+		if date.After(today) {
+			return time.Time{}, fmt.Errorf("date %s is in the future", date.Format("2006-01-02"))
+		}
+		return date, nil
+	}
+
+	// No year: latest such date not after today
+	// 29.02 is searched back to the last leap year
+	for year := today.Year(); year > today.Year()-8; year-- {
+		date, ok := makeDate(year, month, day)
+		if ok && !date.After(today) {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %02d.%02d", day, month)
+}
+
+// ParseReportRange parses range of days relative to now, both ends included
+// Start date without year is the latest such date not after end date, so 2012-0501 spans new year
+func ParseReportRange(rangeStr string, now time.Time) (time.Time, time.Time, error) {
+	rangeStr = strings.ToLower(strings.TrimSpace(rangeStr))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch rangeStr {
+	case "week", "неделя":
+		return today.AddDate(0, 0, -6), today, nil
+	case "month", "месяц":
+		return today.AddDate(0, 0, -29), today, nil
+	}
+
+	fromStr, toStr, ok := splitReportRange(rangeStr)
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range %q: expected %s", rangeStr, ReportRangeFormats)
+	}
+	to, err := ParseReportDate(toStr, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range end: %w", err)
+	}
+	from, err := ParseReportDate(fromStr, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range start: %w", err)
+	}
+	return from, to, nil
+}
+
+// IsReportRange returns true if argument is range of days, not single date
+func IsReportRange(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "week", "неделя", "month", "месяц":
+		return true
+	}
+	_, _, ok := splitReportRange(value)
+	return ok
+}
+
+// splitReportRange splits range into start and end dates
+func splitReportRange(value string) (string, string, bool) {
+	for _, separator := range []string{"..", "–", "—"} {
+		if from, to, found := strings.Cut(value, separator); found {
+			return from, to, true
+		}
+	}
+
+	switch strings.Count(value, "-") {
+	case 1:
+		// DDMM-DDMM, DD.MM-DD.MM
+		from, to, _ := strings.Cut(value, "-")
+		return from, to, true
+	case 5:
+		// YYYY-MM-DD-YYYY-MM-DD
+		parts := strings.SplitN(value, "-", 4)
+		return strings.Join(parts[:3], "-"), parts[3], true
+	}
+	return "", "", false
+}
+
+func parseDatePart(value string, name string, minValue int, maxValue int) (int, error) {
+	if value == "" || len(value) > 4 {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	if number < minValue || number > maxValue {
+		return 0, fmt.Errorf("%s must be between %d and %d", name, minValue, maxValue)
+	}
+	return number, nil
+}
+
+// makeDate returns date and false if day does not exist in month (31.04, 29.02 of non-leap year)
+func makeDate(year int, month int, day int) (time.Time, bool) {
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	return date, date.Day() == day && int(date.Month()) == month
+}
@@ -0,0 +1,119 @@
+package holders
+
+// Scheduled holders reports (telegram.holders_report_*): date of last posted report per ticker
+// (data_out/holders_module/holders_reports.json), so report of a day is posted once across restarts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+// HoldersReportsFile - date of last posted holders report per ticker
+func HoldersReportsFile() string {
+	return paths.Output("holders_module", "holders_reports.json")
+}
+
+// HoldersReportsData - file structure for holders_reports.json
+type HoldersReportsData struct {
+	LastPosted map[string]string `json:"lastPosted"` // ticker -> report date in YYYY-MM-DD
+}
+
+var holdersReportsMutex sync.Mutex
+
+// GetLastPostedReport returns date of last posted holders report of ticker (empty if never)
+func GetLastPostedReport(ticker string) (string, error) {
+	holdersReportsMutex.Lock()
+	defer holdersReportsMutex.Unlock()
+
+	data, err := loadHoldersReportsUnlocked()
+	if err != nil {
+		return "", err
+	}
+	return data.LastPosted[strings.ToUpper(ticker)], nil
+}
+
+// RecordPostedReport saves date of posted (or skipped as empty) holders report of ticker
+func RecordPostedReport(ticker string, date string) error {
+	holdersReportsMutex.Lock()
+	defer holdersReportsMutex.Unlock()
+
+	data, err := loadHoldersReportsUnlocked()
+	if err != nil {
+		return err
+	}
+
+	data.LastPosted[strings.ToUpper(ticker)] = date
+	return saveHoldersReportsUnlocked(data)
+}
+
+// ReportDateAfterCheck returns date of holders report due after balance check of ticker
+// Report of check day is due once check is done, checks older than a day and posted dates are not due
+func ReportDateAfterCheck(lastCheck time.Time, lastPosted string, now time.Time) (string, bool) {
+	if lastCheck.IsZero() || now.Sub(lastCheck) >= 24*time.Hour {
+		return "", false
+	}
+	// Holder changes are dated in local time of check
+	date := lastCheck.In(time.Local).Format("2006-01-02")
+	return date, date > lastPosted
+}
+
+// CountHoldersChanges returns count of holders with balance changes on date (YYYY-MM-DD)
+func CountHoldersChanges(ticker string, date string) (int, error) {
+	dynamicData, err := LoadDynamicHolders(ticker)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load dynamic holders: %w", err)
+	}
+
+	count := 0
+	for _, changes := range dynamicData.Changes {
+		for _, change := range changes {
+			if change.Date == date {
+				count++
+				break
+			}
+		}
+	}
+	return count, nil
+}
+
+func loadHoldersReportsUnlocked() (*HoldersReportsData, error) {
+	raw, err := os.ReadFile(HoldersReportsFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &HoldersReportsData{LastPosted: make(map[string]string)}, nil
+		}
+		return nil, fmt.Errorf("failed to read holders reports file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &HoldersReportsData{LastPosted: make(map[string]string)}, nil
+	}
+
+	var data HoldersReportsData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse holders reports JSON: %w", err)
+	}
+	if data.LastPosted == nil {
+		data.LastPosted = make(map[string]string)
+	}
+	return &data, nil
+}
+
+func saveHoldersReportsUnlocked(data *HoldersReportsData) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal holders reports JSON: %w", err)
+	}
+
+	if err := storage.WriteFileAtomic(HoldersReportsFile(), raw, 0644); err != nil {
+		return fmt.Errorf("failed to write holders reports file: %w", err)
+	}
+	return nil
+}
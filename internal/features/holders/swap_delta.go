@@ -0,0 +1,79 @@
+package holders
+
+// Holders detection mode (app.holders_mode)
+// api - balance of swapper is requested from Luminex on every swap of tracked token (one request per swap)
+// swap_delta - balance is kept from token amounts of swaps (saved balance + amount out - amount in),
+// Luminex address API is called only by daily check, which reconciles saved balances with wallets
+// (transfers, balance held before first seen swap)
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+)
+
+// DetectionMode - source of holder balance on swap
+type DetectionMode string
+
+const (
+	// DetectionModeAPI - wallet balance from Luminex on every swap (default)
+	DetectionModeAPI DetectionMode = "api"
+	// DetectionModeSwapDelta - balance from swap amounts, reconciled by daily check
+	DetectionModeSwapDelta DetectionMode = "swap_delta"
+)
+
+var (
+	detectionMode      = DetectionModeAPI
+	detectionModeMutex sync.RWMutex
+)
+
+// ParseDetectionMode parses app.holders_mode, empty - api
+func ParseDetectionMode(value string) (DetectionMode, error) {
+	switch DetectionMode(strings.ToLower(strings.TrimSpace(value))) {
+	case "", DetectionModeAPI:
+		return DetectionModeAPI, nil
+	case DetectionModeSwapDelta:
+		return DetectionModeSwapDelta, nil
+	}
+	return "", fmt.Errorf("unsupported holders mode %q, expected %s or %s", value, DetectionModeAPI, DetectionModeSwapDelta)
+}
+
+// SetDetectionMode sets holders detection mode from config
+func SetDetectionMode(mode DetectionMode) {
+	detectionModeMutex.Lock()
+	detectionMode = mode
+	detectionModeMutex.Unlock()
+}
+
+// GetDetectionMode returns holders detection mode
+func GetDetectionMode() DetectionMode {
+	detectionModeMutex.RLock()
+	defer detectionModeMutex.RUnlock()
+	return detectionMode
+}
+
+// SwapTokenDelta returns change of swapper balance of tracked token by swap (tokens, decimals applied)
+// Positive if token was received (amount out), negative if it was given (amount in)
+func SwapTokenDelta(swap flashnet.Swap, ticker string) (float64, error) {
+	tokenAddress := GetTokenAddressFromPoolLpPublicKey(swap.PoolLpPublicKey, swap)
+	var rawAmount string
+	sign := 1.0
+	switch tokenAddress {
+	case swap.AssetOutAddress:
+		rawAmount = swap.AmountOut
+	case swap.AssetInAddress:
+		rawAmount, sign = swap.AmountIn, -1
+	default:
+		return 0, fmt.Errorf("token %s is not an asset of swap %s", tokenAddress, swap.ID)
+	}
+
+	decimals := GetTokenDecimalsFromSwap(swap, swap.PoolLpPublicKey, ticker)
+	tokenAmount, err := amount.ScaleFloat(rawAmount, decimals)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse token amount of swap %s: %w", swap.ID, err)
+	}
+	return sign * tokenAmount, nil
+}
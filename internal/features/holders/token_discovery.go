@@ -0,0 +1,131 @@
+package holders
+
+// Token identifier discovery: id_tokens.json (tokenIdentifier -> ticker) is filled from Luminex tokens-with-pools
+// Identifier of token is address of its side of BTC pool, tokens are read by 24h volume, so of tokens
+// sharing a ticker the most traded one gets it
+// Entries of id_tokens.json that differ from last discovery were added or edited by hand, they are kept
+// as overrides: discovered identifier or ticker of override is skipped
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	storage "spark-wallet/internal/infra/fs"
+)
+
+const (
+	discoveryPageSize = 100
+	discoveryMaxPages = 20 // up to 2000 tokens by 24h volume
+)
+
+// tokenIDsData - file structure for id_tokens.json
+type tokenIDsData struct {
+	IDTokens     map[string]string `json:"id_tokens"`              // tokenIdentifier -> ticker, discovered and manual
+	Discovered   map[string]string `json:"discovered,omitempty"`   // result of last discovery
+	DiscoveredAt string            `json:"discoveredAt,omitempty"` // RFC3339
+}
+
+// DiscoveryResult - counts of token identifiers after discovery
+type DiscoveryResult struct {
+	Discovered int // identifiers found in Luminex
+	Manual     int // overrides kept from id_tokens.json
+	Added      int // identifiers new in id_tokens.json
+	Removed    int // discovered identifiers gone from Luminex
+}
+
+var tokenIDsMutex sync.Mutex
+
+// DiscoverTokenIdentifiers reads tokens of Luminex and refreshes id_tokens.json, manual entries are kept
+func DiscoverTokenIdentifiers(ctx context.Context, now time.Time) (*DiscoveryResult, error) {
+	discovered, err := fetchTokenIdentifiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Empty listing is an API failure, not a reason to drop all tickers
+	if len(discovered) == 0 {
+		return nil, fmt.Errorf("luminex returned no tokens with BTC pools")
+	}
+	return MergeDiscoveredTokenIdentifiers(discovered, now)
+}
+
+// fetchTokenIdentifiers returns tokenIdentifier -> ticker of tokens with BTC pools, one identifier per ticker
+func fetchTokenIdentifiers(ctx context.Context) (map[string]string, error) {
+	discovered := make(map[string]string)
+	tickers := make(map[string]bool)
+	for page := 0; page < discoveryMaxPages; page++ {
+		tokens, err := luminex.GetTokensPage(ctx, page*discoveryPageSize, discoveryPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, token := range tokens {
+			ticker := strings.ToUpper(strings.TrimSpace(token.Ticker))
+			// Clone of a more traded token keeps no ticker
+			if ticker == "" || tickers[ticker] {
+				continue
+			}
+			for _, pool := range token.Pools {
+				if tokenIdentifier, ok := pool.TokenAddress(); ok {
+					discovered[tokenIdentifier] = ticker
+					tickers[ticker] = true
+					break
+				}
+			}
+		}
+
+		if len(tokens) < discoveryPageSize {
+			break
+		}
+	}
+	return discovered, nil
+}
+
+// MergeDiscoveredTokenIdentifiers saves discovered identifiers to id_tokens.json with manual entries kept
+func MergeDiscoveredTokenIdentifiers(discovered map[string]string, now time.Time) (*DiscoveryResult, error) {
+	tokenIDsMutex.Lock()
+	defer tokenIDsMutex.Unlock()
+
+	current, err := loadTokenIDsData(TokenIDsFile())
+	if err != nil {
+		return nil, err
+	}
+
+	// Manual - entries not made by last discovery (added by hand or ticker edited)
+	merged := make(map[string]string, len(discovered))
+	manualTickers := make(map[string]bool)
+	manual := 0
+	for tokenIdentifier, ticker := range current.IDTokens {
+		if current.Discovered[tokenIdentifier] == ticker {
+			continue
+		}
+		merged[tokenIdentifier] = ticker
+		manualTickers[strings.ToUpper(ticker)] = true
+		manual++
+	}
+
+	result := &DiscoveryResult{Discovered: len(discovered), Manual: manual}
+	for tokenIdentifier, ticker := range discovered {
+		if _, overridden := merged[tokenIdentifier]; overridden || manualTickers[ticker] {
+			continue
+		}
+		merged[tokenIdentifier] = ticker
+		if _, known := current.IDTokens[tokenIdentifier]; !known {
+			result.Added++
+		}
+	}
+	for tokenIdentifier := range current.Discovered {
+		if _, found := discovered[tokenIdentifier]; !found {
+			result.Removed++
+		}
+	}
+
+	data := tokenIDsData{IDTokens: merged, Discovered: discovered, DiscoveredAt: now.UTC().Format(time.RFC3339)}
+	if err := storage.WriteJSONAtomic(TokenIDsFile(), data); err != nil {
+		return nil, fmt.Errorf("failed to save token identifiers: %w", err)
+	}
+	return result, nil
+}
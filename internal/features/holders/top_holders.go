@@ -0,0 +1,303 @@
+package holders
+
+// Top holders of tracked token: daily snapshots of holder distribution and /top report
+// Distribution comes from saved_holders.json (wallets found by swaps, balances refreshed by daily check),
+// snapshot is saved after every successful balance check to {ticker}/snapshots/YYYY-MM-DD.json
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	storage "spark-wallet/internal/infra/fs"
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// TopHoldersLimit - holders in /top report
+	TopHoldersLimit = 10
+	// snapshotRetentionDays - snapshots older than this are removed
+	snapshotRetentionDays = 30
+)
+
+// HoldersSnapshot - holder distribution of token on date
+type HoldersSnapshot struct {
+	Date    string             `json:"date"`             // YYYY-MM-DD
+	Supply  float64            `json:"supply,omitempty"` // total supply (0 - unknown)
+	Holders map[string]float64 `json:"holders"`          // address -> count tokens
+}
+
+// TopHolder - holder in top report
+type TopHolder struct {
+	Rank          int
+	Address       string
+	Amount        float64
+	SupplyPercent float64 // share of supply, % (0 if supply is unknown)
+	Change        float64 // tokens since previous snapshot
+	New           bool    // not in previous snapshot
+}
+
+// HoldersSnapshotsDir returns folder of daily snapshots of ticker
+func HoldersSnapshotsDir(ticker string) string {
+	return filepath.Join(HoldersModuleDir(), ticker, "snapshots")
+}
+
+// HoldersSnapshotFile returns path of snapshot of ticker on date (YYYY-MM-DD)
+func HoldersSnapshotFile(ticker string, date string) string {
+	return filepath.Join(HoldersSnapshotsDir(ticker), date+".json")
+}
+
+// SaveHoldersSnapshot saves current distribution of ticker as snapshot of day of now
+// Snapshot of the same day is overwritten, snapshots older than snapshotRetentionDays are removed
+func SaveHoldersSnapshot(ticker string, now time.Time) error {
+	ticker = strings.ToUpper(ticker)
+	snapshot, err := currentHoldersSnapshot(ticker, now)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.WriteJSONAtomic(HoldersSnapshotFile(ticker, snapshot.Date), snapshot); err != nil {
+		return fmt.Errorf("failed to save holders snapshot: %w", err)
+	}
+
+	if err := pruneHoldersSnapshots(ticker, now); err != nil {
+		logging.LogWarn("Failed to prune holders snapshots", zap.String("ticker", ticker), zap.Error(err))
+	}
+	return nil
+}
+
+// LoadHoldersSnapshot loads snapshot of ticker on date
+// Returns nil if there is no snapshot of this date
+func LoadHoldersSnapshot(ticker string, date string) (*HoldersSnapshot, error) {
+	data, err := os.ReadFile(HoldersSnapshotFile(strings.ToUpper(ticker), date))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read holders snapshot: %w", err)
+	}
+
+	var snapshot HoldersSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse holders snapshot JSON: %w", err)
+	}
+	if snapshot.Holders == nil {
+		snapshot.Holders = make(map[string]float64)
+	}
+	return &snapshot, nil
+}
+
+// previousHoldersSnapshot returns latest snapshot before day of now (nil if none)
+func previousHoldersSnapshot(ticker string, now time.Time) (*HoldersSnapshot, error) {
+	dates, err := holdersSnapshotDates(ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	today := now.Format("2006-01-02")
+	for i := len(dates) - 1; i >= 0; i-- {
+		if dates[i] < today {
+			return LoadHoldersSnapshot(ticker, dates[i])
+		}
+	}
+	return nil, nil
+}
+
+// holdersSnapshotDates returns dates of saved snapshots in ascending order
+func holdersSnapshotDates(ticker string) ([]string, error) {
+	entries, err := os.ReadDir(HoldersSnapshotsDir(ticker))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read holders snapshots directory: %w", err)
+	}
+
+	dates := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		date, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates, nil
+}
+
+func pruneHoldersSnapshots(ticker string, now time.Time) error {
+	dates, err := holdersSnapshotDates(ticker)
+	if err != nil {
+		return err
+	}
+
+	cutoff := now.AddDate(0, 0, -snapshotRetentionDays).Format("2006-01-02")
+	for _, date := range dates {
+		if date >= cutoff {
+			break
+		}
+		if err := os.Remove(HoldersSnapshotFile(ticker, date)); err != nil {
+			return fmt.Errorf("failed to remove holders snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// currentHoldersSnapshot builds snapshot from saved_holders.json and pool supply
+func currentHoldersSnapshot(ticker string, now time.Time) (*HoldersSnapshot, error) {
+	savedData, err := LoadSavedHolders(ticker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved holders: %w", err)
+	}
+
+	snapshot := &HoldersSnapshot{
+		Date:    now.Format("2006-01-02"),
+		Holders: make(map[string]float64, len(savedData.Holders)),
+	}
+	for address, amountStr := range savedData.Holders {
+		balance, err := amount.ParseFloat(amountStr)
+		if err != nil || balance <= 0 {
+			continue
+		}
+		snapshot.Holders[address] = balance
+	}
+
+	// Supply is optional: without it report shows balances only
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		logging.LogWarn("Failed to find pool of ticker for holders snapshot", zap.String("ticker", ticker), zap.Error(err))
+		return snapshot, nil
+	}
+	supply, err := GetTokenSupply(poolLpPublicKey)
+	if err != nil {
+		logging.LogWarn("Failed to get token supply for holders snapshot", zap.String("ticker", ticker), zap.Error(err))
+		return snapshot, nil
+	}
+	snapshot.Supply = supply
+	return snapshot, nil
+}
+
+// GetTopHolders returns limit largest holders of ticker with change since previous snapshot
+// previousDate - date of snapshot changes are counted from (empty if there is no earlier snapshot)
+func GetTopHolders(ticker string, limit int, now time.Time) ([]TopHolder, string, error) {
+	ticker = strings.ToUpper(ticker)
+	if !IsTickerAllowed(ticker) {
+		return nil, "", fmt.Errorf("ticker %s is not tracked", ticker)
+	}
+
+	current, err := currentHoldersSnapshot(ticker, now)
+	if err != nil {
+		return nil, "", err
+	}
+	previous, err := previousHoldersSnapshot(ticker, now)
+	if err != nil {
+		logging.LogWarn("Failed to load previous holders snapshot", zap.String("ticker", ticker), zap.Error(err))
+		previous = nil
+	}
+
+	addresses := make([]string, 0, len(current.Holders))
+	for address := range current.Holders {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		if current.Holders[addresses[i]] != current.Holders[addresses[j]] {
+			return current.Holders[addresses[i]] > current.Holders[addresses[j]]
+		}
+		return addresses[i] < addresses[j]
+	})
+	if len(addresses) > limit {
+		addresses = addresses[:limit]
+	}
+
+	top := make([]TopHolder, 0, len(addresses))
+	for i, address := range addresses {
+		holder := TopHolder{Rank: i + 1, Address: address, Amount: current.Holders[address]}
+		if current.Supply > 0 {
+			holder.SupplyPercent = holder.Amount / current.Supply * 100
+		}
+		if previous != nil {
+			previousAmount, existed := previous.Holders[address]
+			holder.Change = holder.Amount - previousAmount
+			holder.New = !existed
+		}
+		top = append(top, holder)
+	}
+
+	previousDate := ""
+	if previous != nil {
+		previousDate = previous.Date
+	}
+	return top, previousDate, nil
+}
+
+// GenerateTopHoldersReport formats top holders of ticker for Telegram (HTML)
+func GenerateTopHoldersReport(ticker string) (string, error) {
+	ticker = strings.ToUpper(ticker)
+	now := time.Now()
+	top, previousDate, err := GetTopHolders(ticker, TopHoldersLimit, now)
+	if err != nil {
+		return "", err
+	}
+	if len(top) == 0 {
+		return fmt.Sprintf("Top holders of %s:\n\nNo holders found yet", ticker), nil
+	}
+
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("Top %d holders of %s:\n\n", len(top), ticker))
+	report.WriteString("<blockquote>")
+	for _, holder := range top {
+		displayName := walletDisplayName(holder.Address)
+		addressShort := holder.Address
+		if len(addressShort) >= 3 {
+			addressShort = addressShort[len(addressShort)-3:]
+		}
+
+		share := ""
+		if holder.SupplyPercent > 0 {
+			share = fmt.Sprintf(" | %.2f%%", holder.SupplyPercent)
+		}
+
+		report.WriteString(fmt.Sprintf("%d. <a href=\"https://luminex.io/spark/address/%s\">%s</a> (%s)\n",
+			holder.Rank, holder.Address, displayName, addressShort))
+		report.WriteString(fmt.Sprintf("Balance: %s%s | Change: %s\n", formatBalance(holder.Amount), share, formatHolderChange(holder, previousDate)))
+	}
+	report.WriteString("</blockquote>")
+
+	switch {
+	case previousDate == "":
+		report.WriteString("\n<i>No earlier snapshot yet, changes are shown from tomorrow</i>")
+	case previousDate != now.AddDate(0, 0, -1).Format("2006-01-02"):
+		report.WriteString(fmt.Sprintf("\n<i>Changes since %s</i>", formatFirstBuyDate(previousDate)))
+	default:
+		report.WriteString("\n<i>Changes since yesterday</i>")
+	}
+
+	return report.String(), nil
+}
+
+// formatHolderChange formats balance change of holder since previous snapshot
+func formatHolderChange(holder TopHolder, previousDate string) string {
+	const epsilon = 0.0001
+	switch {
+	case previousDate == "":
+		return "N/A"
+	case holder.New:
+		return "<b>NEW</b>"
+	case holder.Change > epsilon:
+		return "+" + formatBalance(holder.Change)
+	case holder.Change < -epsilon:
+		return "-" + formatBalance(-holder.Change)
+	default:
+		return "0"
+	}
+}
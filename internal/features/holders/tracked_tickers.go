@@ -0,0 +1,240 @@
+package holders
+
+// Tracked tickers for holders module
+// Tickers come from config (HOLDERS_TICKERS) and from /holdersadd command (tracked_tickers.json)
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+// HoldersModuleDir - root folder of holders module data
+func HoldersModuleDir() string {
+	return paths.Output("holders_module")
+}
+
+// TokenIDsFile - token identifiers of tickers (tokenIdentifier -> ticker)
+func TokenIDsFile() string {
+	return filepath.Join(HoldersModuleDir(), "id_tokens.json")
+}
+
+// TrackedTickersFile - tickers added via /holdersadd
+func TrackedTickersFile() string {
+	return filepath.Join(HoldersModuleDir(), "tracked_tickers.json")
+}
+
+// TrackedTickersData - file structure for tracked_tickers.json
+type TrackedTickersData struct {
+	Tickers []string `json:"tickers"`
+}
+
+var (
+	configuredTickers      []string
+	configuredTickersMutex sync.RWMutex
+
+	// trackedTickers - tickers of tracked_tickers.json kept in memory (checked on every holders load/save)
+	// Loaded on first use and again if output directory changes, updated by AddTrackedTicker and ReloadTrackedTickers
+	trackedTickers      []string
+	trackedTickersPath  string // file trackedTickers were loaded from, empty - not loaded
+	trackedTickersMutex sync.RWMutex
+)
+
+// SetConfiguredTickers sets tickers from config and creates their directories
+func SetConfiguredTickers(tickers []string) {
+	normalized := make([]string, 0, len(tickers))
+	for _, ticker := range tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker == "" {
+			continue
+		}
+		normalized = append(normalized, ticker)
+		_ = EnsureHoldersDir(ticker)
+	}
+
+	configuredTickersMutex.Lock()
+	configuredTickers = normalized
+	configuredTickersMutex.Unlock()
+}
+
+// LoadTrackedTickers returns tickers added via /holdersadd
+// Returns empty list if file doesn't exist
+func LoadTrackedTickers() ([]string, error) {
+	tickers, err := cachedTrackedTickers()
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{}, tickers...), nil
+}
+
+// ReloadTrackedTickers reads tracked_tickers.json again (after storage migrations or manual edit)
+func ReloadTrackedTickers() error {
+	trackedTickersMutex.Lock()
+	defer trackedTickersMutex.Unlock()
+	return reloadTrackedTickersUnlocked()
+}
+
+// AddTrackedTicker adds ticker to tracked_tickers.json and creates its directory
+// Returns false if ticker is already tracked
+func AddTrackedTicker(ticker string) (bool, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return false, fmt.Errorf("ticker is required")
+	}
+
+	if err := EnsureHoldersDir(ticker); err != nil {
+		return false, err
+	}
+
+	if isConfiguredTicker(ticker) {
+		return false, nil
+	}
+
+	trackedTickersMutex.Lock()
+	defer trackedTickersMutex.Unlock()
+
+	// File is read again: it may be edited by hand or by another process since it was loaded
+	if err := reloadTrackedTickersUnlocked(); err != nil {
+		return false, err
+	}
+	if containsTicker(trackedTickers, ticker) {
+		return false, nil
+	}
+	tickers := append(append([]string{}, trackedTickers...), ticker)
+
+	data, err := json.MarshalIndent(TrackedTickersData{Tickers: tickers}, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal tracked tickers: %w", err)
+	}
+
+	if err := storage.WriteFileAtomic(TrackedTickersFile(), data, 0644); err != nil {
+		return false, fmt.Errorf("failed to write tracked tickers file: %w", err)
+	}
+	trackedTickers = tickers
+
+	return true, nil
+}
+
+// EnsureHoldersDir creates data_out/holders_module/{ticker} if missing
+func EnsureHoldersDir(ticker string) error {
+	holdersDir := filepath.Join(HoldersModuleDir(), ticker)
+	if err := os.MkdirAll(holdersDir, 0755); err != nil {
+		return fmt.Errorf("failed to create holders directory for ticker %s: %w", ticker, err)
+	}
+	return nil
+}
+
+// GetAllowedTickers returns configured and tracked tickers (sorted, unique)
+func GetAllowedTickers() []string {
+	seen := make(map[string]bool)
+	var result []string
+
+	configuredTickersMutex.RLock()
+	for _, ticker := range configuredTickers {
+		if !seen[ticker] {
+			seen[ticker] = true
+			result = append(result, ticker)
+		}
+	}
+	configuredTickersMutex.RUnlock()
+
+	tracked, err := cachedTrackedTickers()
+	if err == nil {
+		for _, ticker := range tracked {
+			ticker = strings.ToUpper(ticker)
+			if !seen[ticker] {
+				seen[ticker] = true
+				result = append(result, ticker)
+			}
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// IsTickerAllowed reports whether ticker is configured or tracked (in memory, no file reads)
+func IsTickerAllowed(ticker string) bool {
+	if isConfiguredTicker(ticker) {
+		return true
+	}
+	tracked, err := cachedTrackedTickers()
+	return err == nil && containsTicker(tracked, ticker)
+}
+
+func isConfiguredTicker(ticker string) bool {
+	configuredTickersMutex.RLock()
+	defer configuredTickersMutex.RUnlock()
+	return containsTicker(configuredTickers, ticker)
+}
+
+func containsTicker(tickers []string, ticker string) bool {
+	for _, candidate := range tickers {
+		if strings.EqualFold(ticker, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// cachedTrackedTickers returns tracked tickers in memory, loads them if not loaded for current output directory
+// Returned slice must not be modified
+func cachedTrackedTickers() ([]string, error) {
+	trackedTickersMutex.RLock()
+	if trackedTickersPath == TrackedTickersFile() {
+		tickers := trackedTickers
+		trackedTickersMutex.RUnlock()
+		return tickers, nil
+	}
+	trackedTickersMutex.RUnlock()
+
+	trackedTickersMutex.Lock()
+	defer trackedTickersMutex.Unlock()
+	if trackedTickersPath != TrackedTickersFile() {
+		if err := reloadTrackedTickersUnlocked(); err != nil {
+			return nil, err
+		}
+	}
+	return trackedTickers, nil
+}
+
+// reloadTrackedTickersUnlocked reads tracked_tickers.json into memory, previous list stays on error
+func reloadTrackedTickersUnlocked() error {
+	tickers, err := loadTrackedTickersUnlocked()
+	if err != nil {
+		return err
+	}
+	trackedTickers = tickers
+	trackedTickersPath = TrackedTickersFile()
+	return nil
+}
+
+func loadTrackedTickersUnlocked() ([]string, error) {
+	data, err := os.ReadFile(TrackedTickersFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read tracked tickers file: %w", err)
+	}
+
+	if strings.TrimSpace(string(data)) == "" {
+		return []string{}, nil
+	}
+
+	var file TrackedTickersData
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tracked tickers JSON: %w", err)
+	}
+	if file.Tickers == nil {
+		return []string{}, nil
+	}
+	return file.Tickers, nil
+}
@@ -0,0 +1,143 @@
+package holders
+
+// Whale definition per token: wallet holding above configured percentage of token supply
+// Holdings come from holders discovery data (saved_holders.json), supply is sampled from Luminex pool
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/luminex"
+)
+
+const (
+	// DefaultWhaleSupplyPercent - share of supply (%) from which holder is whale
+	DefaultWhaleSupplyPercent = 1.0
+	// supplySampleTTL - how long sampled total supply is reused
+	supplySampleTTL = time.Hour
+)
+
+// WhaleHolder - whale position of wallet among token holders
+type WhaleHolder struct {
+	Rank          int     // place among holders by balance (1 - largest)
+	Amount        float64 // tokens held
+	SupplyPercent float64 // share of total supply, %
+}
+
+type supplySample struct {
+	supply    float64
+	sampledAt time.Time
+}
+
+var (
+	whaleSupplyPercent      = DefaultWhaleSupplyPercent
+	whaleSupplyPercentMutex sync.RWMutex
+
+	supplySamples      = make(map[string]supplySample) // poolLpPublicKey -> total supply
+	supplySamplesMutex sync.Mutex
+)
+
+// SetWhaleSupplyPercent sets whale threshold from config (0 - whale badges disabled)
+func SetWhaleSupplyPercent(percent float64) {
+	whaleSupplyPercentMutex.Lock()
+	whaleSupplyPercent = percent
+	whaleSupplyPercentMutex.Unlock()
+}
+
+// GetWhaleSupplyPercent returns whale threshold (% of supply)
+func GetWhaleSupplyPercent() float64 {
+	whaleSupplyPercentMutex.RLock()
+	defer whaleSupplyPercentMutex.RUnlock()
+	return whaleSupplyPercent
+}
+
+// GetTokenSupply returns total supply of token (in tokens, decimals applied)
+// Supply is sampled from Luminex pool and reused for supplySampleTTL
+func GetTokenSupply(poolLpPublicKey string) (float64, error) {
+	supplySamplesMutex.Lock()
+	sample, exists := supplySamples[poolLpPublicKey]
+	supplySamplesMutex.Unlock()
+	if exists && time.Since(sample.sampledAt) < supplySampleTTL {
+		return sample.supply, nil
+	}
+
+	totalSupplyStr, decimals, err := luminex.GetPoolTotalSupply(poolLpPublicKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get total supply: %w", err)
+	}
+	supply, err := parseTokenAmount(totalSupplyStr, decimals)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse total supply: %w", err)
+	}
+	if supply <= 0 {
+		return 0, fmt.Errorf("total supply is zero")
+	}
+
+	supplySamplesMutex.Lock()
+	supplySamples[poolLpPublicKey] = supplySample{supply: supply, sampledAt: time.Now()}
+	supplySamplesMutex.Unlock()
+
+	return supply, nil
+}
+
+// FindWhale checks if address is whale of token
+// Returns nil if token is not tracked, threshold is disabled or holding is below threshold
+func FindWhale(ticker string, poolLpPublicKey string, address string) (*WhaleHolder, error) {
+	threshold := GetWhaleSupplyPercent()
+	if threshold <= 0 || ticker == "" || address == "" || !IsTickerAllowed(ticker) {
+		return nil, nil
+	}
+
+	savedData, err := LoadSavedHolders(ticker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved holders: %w", err)
+	}
+
+	balances := make(map[string]float64, len(savedData.Holders))
+	for holder, amountStr := range savedData.Holders {
+		balance, err := amount.ParseFloat(amountStr)
+		if err != nil {
+			continue
+		}
+		balances[holder] = balance
+	}
+
+	holding, exists := balances[address]
+	if !exists || holding <= 0 {
+		return nil, nil
+	}
+
+	supply, err := GetTokenSupply(poolLpPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	supplyPercent := holding / supply * 100
+	if supplyPercent < threshold {
+		return nil, nil
+	}
+
+	addresses := make([]string, 0, len(balances))
+	for holder := range balances {
+		addresses = append(addresses, holder)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		if balances[addresses[i]] != balances[addresses[j]] {
+			return balances[addresses[i]] > balances[addresses[j]]
+		}
+		return addresses[i] < addresses[j]
+	})
+
+	rank := 0
+	for i, holder := range addresses {
+		if holder == address {
+			rank = i + 1
+			break
+		}
+	}
+
+	return &WhaleHolder{Rank: rank, Amount: holding, SupplyPercent: supplyPercent}, nil
+}
@@ -0,0 +1,307 @@
+package hot_token
+
+// Hot token scoring: activity of pool in recent swaps is scored by unique buyers, buy volume and marketcap velocity
+// Alerted tokens have cooldown and are alerted again only when score grows by ScoreConfig.ScoreIncrease percent
+// State (last alert, alerted score, marketcap reference) is kept in data_out/hot_token/state.json and survives restarts
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// ScoreWindow - swaps older than window are not counted in activity
+	ScoreWindow = time.Hour
+
+	// Score weights (sum 100) and values giving full weight
+	buyersWeight         = 40.0
+	volumeWeight         = 35.0
+	velocityWeight       = 25.0
+	buyersForFullScore   = 10   // unique buyers
+	volumeForFullScore   = 0.05 // BTC of buys
+	velocityForFullScore = 50.0 // marketcap growth, percent per hour
+
+	// Marketcap reference for velocity is replaced after velocityWindow,
+	// velocity is not measured over less than minVelocityPeriod (24h price change is used instead)
+	velocityWindow    = time.Hour
+	minVelocityPeriod = 10 * time.Minute
+
+	// scoreMemory - alerted score is forgotten after this time, token is alerted as new one
+	scoreMemory = 24 * time.Hour
+)
+
+// StateFile - scores and alert times of hot tokens
+func StateFile() string {
+	return paths.Output("hot_token", "state.json")
+}
+
+// ScoreConfig - hot token thresholds
+type ScoreConfig struct {
+	MinSwaps      int           // swaps of pool in window to be scored
+	MinAddresses  int           // unique addresses of pool in window to be scored
+	MinScore      float64       // score (0-100) to alert
+	Cooldown      time.Duration // no alerts of token after alert
+	ScoreIncrease float64       // percent of score growth over last alerted score to alert again
+}
+
+// Activity - swaps of pool in ScoreWindow
+type Activity struct {
+	PoolLpPublicKey string
+	Swaps           int
+	UniqueAddresses int
+	UniqueBuyers    int
+	BuyVolumeBTC    float64
+	SellVolumeBTC   float64
+}
+
+// Eligible returns true if activity passes minimum swaps and addresses
+func (a *Activity) Eligible(config ScoreConfig) bool {
+	return a.Swaps >= config.MinSwaps && a.UniqueAddresses >= config.MinAddresses
+}
+
+// Score - hot token score with components
+type Score struct {
+	Total             float64 // 0-100
+	Buyers            float64
+	Volume            float64
+	Velocity          float64
+	MarketcapVelocity float64 // marketcap change, percent per hour
+}
+
+// CollectActivity groups swaps of ScoreWindow before now by pool
+func CollectActivity(swaps []flashnet.Swap, now time.Time) map[string]*Activity {
+	windowStart := now.Add(-ScoreWindow)
+	activities := make(map[string]*Activity)
+	addresses := make(map[string]map[string]bool)
+	buyers := make(map[string]map[string]bool)
+
+	for _, swap := range swaps {
+		if swap.PoolLpPublicKey == "" {
+			continue
+		}
+		if at, ok := storage.SwapTime(swap); !ok || at.Before(windowStart) {
+			continue
+		}
+
+		activity, exists := activities[swap.PoolLpPublicKey]
+		if !exists {
+			activity = &Activity{PoolLpPublicKey: swap.PoolLpPublicKey}
+			activities[swap.PoolLpPublicKey] = activity
+			addresses[swap.PoolLpPublicKey] = make(map[string]bool)
+			buyers[swap.PoolLpPublicKey] = make(map[string]bool)
+		}
+		activity.Swaps++
+		if swap.SwapperPublicKey != "" {
+			addresses[swap.PoolLpPublicKey][swap.SwapperPublicKey] = true
+		}
+
+		switch swap.GetSwapType() {
+		case flashnet.SwapTypeBuy:
+			btc, _ := amount.SatsToBTCFloat(swap.AmountIn)
+			activity.BuyVolumeBTC += btc
+			if swap.SwapperPublicKey != "" {
+				buyers[swap.PoolLpPublicKey][swap.SwapperPublicKey] = true
+			}
+		case flashnet.SwapTypeSell:
+			btc, _ := amount.SatsToBTCFloat(swap.AmountOut)
+			activity.SellVolumeBTC += btc
+		}
+	}
+
+	for pool, activity := range activities {
+		activity.UniqueAddresses = len(addresses[pool])
+		activity.UniqueBuyers = len(buyers[pool])
+	}
+	return activities
+}
+
+// ComputeScore scores activity and marketcap velocity (percent per hour, only growth counts)
+func ComputeScore(activity *Activity, marketcapVelocity float64) Score {
+	score := Score{
+		Buyers:            buyersWeight * math.Min(float64(activity.UniqueBuyers)/buyersForFullScore, 1),
+		Volume:            volumeWeight * math.Min(activity.BuyVolumeBTC/volumeForFullScore, 1),
+		MarketcapVelocity: marketcapVelocity,
+	}
+	if marketcapVelocity > 0 {
+		score.Velocity = velocityWeight * math.Min(marketcapVelocity/velocityForFullScore, 1)
+	}
+	score.Total = score.Buyers + score.Volume + score.Velocity
+	return score
+}
+
+// TokenState - saved state of token
+type TokenState struct {
+	LastAlertAt  string  `json:"last_alert_at,omitempty"` // RFC3339
+	LastScore    float64 `json:"last_score,omitempty"`    // score of last alert
+	MarketcapUsd float64 `json:"marketcap_usd,omitempty"` // marketcap reference for velocity
+	MarketcapAt  string  `json:"marketcap_at,omitempty"`  // RFC3339
+}
+
+// StateData - file structure for state.json
+type StateData struct {
+	Tokens map[string]*TokenState `json:"tokens"` // poolLpPublicKey -> state
+}
+
+var stateMutex sync.Mutex
+
+// InCooldown returns true if token was alerted within cooldown
+func InCooldown(poolLpPublicKey string, cooldown time.Duration, now time.Time) (bool, error) {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	data, err := loadStateUnlocked()
+	if err != nil {
+		return false, err
+	}
+	token, exists := data.Tokens[poolLpPublicKey]
+	if !exists {
+		return false, nil
+	}
+	return since(token.LastAlertAt, now) < cooldown, nil
+}
+
+// Evaluate scores token, updates its marketcap reference and decides if alert should be sent
+// priceChange24h - percent, used for velocity until reference is old enough
+// Alert is saved with MarkAlerted after it is sent
+func Evaluate(activity *Activity, marketcapUsd float64, priceChange24h float64, config ScoreConfig, now time.Time) (Score, bool, error) {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	data, err := loadStateUnlocked()
+	if err != nil {
+		return Score{}, false, err
+	}
+	token, exists := data.Tokens[activity.PoolLpPublicKey]
+	if !exists {
+		token = &TokenState{}
+		data.Tokens[activity.PoolLpPublicKey] = token
+	}
+
+	velocity := priceChange24h / 24
+	referenceAge := since(token.MarketcapAt, now)
+	if token.MarketcapUsd > 0 && marketcapUsd > 0 && referenceAge >= minVelocityPeriod && referenceAge <= velocityWindow*2 {
+		velocity = (marketcapUsd - token.MarketcapUsd) / token.MarketcapUsd * 100 / referenceAge.Hours()
+	}
+	if marketcapUsd > 0 && (token.MarketcapUsd <= 0 || referenceAge >= velocityWindow) {
+		token.MarketcapUsd = marketcapUsd
+		token.MarketcapAt = now.UTC().Format(time.RFC3339)
+	}
+
+	score := ComputeScore(activity, velocity)
+	alert := score.Total >= config.MinScore
+	lastAlertAge := since(token.LastAlertAt, now)
+	if alert && lastAlertAge < config.Cooldown {
+		alert = false
+	}
+	if alert && lastAlertAge < scoreMemory && score.Total < token.LastScore*(1+config.ScoreIncrease/100) {
+		alert = false
+	}
+
+	pruneStateUnlocked(data, now)
+	if err := saveStateUnlocked(data); err != nil {
+		return score, false, err
+	}
+	return score, alert, nil
+}
+
+// MarkAlerted saves alert of token with its score
+func MarkAlerted(poolLpPublicKey string, score float64, sentAt time.Time) error {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	data, err := loadStateUnlocked()
+	if err != nil {
+		return err
+	}
+	token, exists := data.Tokens[poolLpPublicKey]
+	if !exists {
+		token = &TokenState{}
+		data.Tokens[poolLpPublicKey] = token
+	}
+	token.LastAlertAt = sentAt.UTC().Format(time.RFC3339)
+	token.LastScore = score
+	return saveStateUnlocked(data)
+}
+
+// RestoreAlerts applies alert times of previous process (state handoff) newer than saved ones
+func RestoreAlerts(sent map[string]time.Time) error {
+	if len(sent) == 0 {
+		return nil
+	}
+
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	data, err := loadStateUnlocked()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for pool, sentAt := range sent {
+		token, exists := data.Tokens[pool]
+		if !exists {
+			token = &TokenState{}
+			data.Tokens[pool] = token
+		}
+		if now.Sub(sentAt) < since(token.LastAlertAt, now) {
+			token.LastAlertAt = sentAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return saveStateUnlocked(data)
+}
+
+// since returns time passed since RFC3339 value (max duration if value is empty or unparsable)
+func since(value string, now time.Time) time.Duration {
+	if value == "" {
+		return math.MaxInt64
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return math.MaxInt64
+	}
+	return now.Sub(parsed)
+}
+
+// pruneStateUnlocked removes tokens without alert and marketcap reference within scoreMemory
+func pruneStateUnlocked(data *StateData, now time.Time) {
+	for pool, token := range data.Tokens {
+		if since(token.LastAlertAt, now) >= scoreMemory && since(token.MarketcapAt, now) >= scoreMemory {
+			delete(data.Tokens, pool)
+		}
+	}
+}
+
+func loadStateUnlocked() (*StateData, error) {
+	raw, err := os.ReadFile(StateFile())
+	if os.IsNotExist(err) || (err == nil && len(raw) == 0) {
+		return &StateData{Tokens: make(map[string]*TokenState)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hot token state file: %w", err)
+	}
+
+	var data StateData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse hot token state JSON: %w", err)
+	}
+	if data.Tokens == nil {
+		data.Tokens = make(map[string]*TokenState)
+	}
+	return &data, nil
+}
+
+func saveStateUnlocked(data *StateData) error {
+	if err := storage.WriteJSONAtomic(StateFile(), data); err != nil {
+		return fmt.Errorf("failed to save hot token state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package i18n
+
+// English catalog, every key used by the bot must be here (fallback of other languages)
+// cmd.* - command descriptions of Telegram autocomplete, help.* - lines of /helps
+
+var catalogEN = map[string]string{
+	"lang.name":    "English",
+	"lang.current": "🌐 Language of chat: <b>%s</b>",
+	"lang.set":     "🌐 Language of chat is now <b>%s</b>",
+	"lang.usage":   "Usage: /lang {%s}, /lang reset - language from config",
+	"lang.error":   "An error occurred, please try again later",
+
+	// Swap alerts
+	"swap.buy":          "Buy",
+	"swap.sell":         "Sell",
+	"swap.swap":         "Swap",
+	"swap.loading":      "Loading details...",
+	"swap.trade_button": "Trade on Luminex",
+	"swap.combined":     "📦 <b>%d swaps</b>",
+	"swap.watched":      "👀 <b>Watched wallet</b>",
+	"swap.raw.buy":      "BUY",
+	"swap.raw.sell":     "SELL",
+	"swap.raw.swap":     "TOKEN SWAP",
+	"swap.raw.gave":     "Gave",
+	"swap.raw.received": "Received",
+	"swap.raw.tokens":   "tokens",
+
+	// /flow
+	"flow.title":       "%s for %s:\n\n",
+	"flow.range_title": "%s for %s – %s (%d days):\n\n",
+	"flow.buys":        "Buys: %d (%s)",
+	"flow.sells":       "Sells: %d (%s)",
+	"flow.by_day":      "By day",
+	"flow.by_week":     "By week",
+	"flow.by_month":    "By month",
+	"flow.footer":      "From swaps archived by the bot, UTC days",
+
+	// /cohort
+	"cohort.title":        "%s buyers %s (%s – %s):\n\n",
+	"cohort.buyers":       "Buyers: %d",
+	"cohort.first_time":   "– First-time: %d (%s btc)",
+	"cohort.returning":    "– Returning: %d (%s btc)",
+	"cohort.bought_again": "Bought again in %s:",
+	"cohort.retained":     "%s: %d of %d",
+	"cohort.footer":       "From swaps archived by the bot, UTC weeks, returning - bought within %d weeks before",
+
+	// /distribution
+	"distribution.title":  "{%s} trade sizes for %s: %d swaps, %s btc\n\n",
+	"distribution.bucket": "<code>%s</code> btc: %d swaps (%.0f%%), %s btc (%.0f%% of volume), %d buys / %d sells",
+	"distribution.whales": "🐋 Swaps above %s btc: %.0f%% of volume",
+	"distribution.retail": "🐟 Swaps below %s btc: %.0f%% of volume",
+	"distribution.footer": "From swaps archived by the bot, token-to-token swaps are not counted",
+
+	// /leaderboard
+	"leaderboard.title":   "🏆 {%s} most profitable wallets %s - %s (UTC)\n\n",
+	"leaderboard.wallet":  "%s %s: %s, %d sells for %s btc",
+	"leaderboard.sellers": "In profit: %d of %d sellers",
+	"leaderboard.footer":  "Realized PnL of sells (average cost), cost basis from swaps archived by the bot over %d days before",
+
+	// Command usage
+	"usage.ticker":       "Usage: /%[1]s {ticker}\n\nExample: /%[1]s SOON",
+	"usage.flashrefresh": "Usage: /flashrefresh {ticker or pool address}\n\nExample: /flashrefresh SOON",
+	"usage.flash":        "Usage: /flash {ticker} {date}\n\nExample: /flash SOON 0812\n\nDate format: %s (e.g., 0812 or 08.12 for December 8)",
+	"usage.flow":         "Usage: /flow {ticker} {date|range}\n\nExample: /flow SOON 0912 or /flow SOON 0112-0712\n\nDate format: %s (e.g., 0912 or 09.12 for December 9)\nRange format: %s",
+	"usage.cohort":       "Usage: /cohort {ticker} {week}\n\nExample: /cohort SOON or /cohort SOON 2025-W49\n\nWeek format: %s (current week if omitted)",
+	"usage.distribution": "Usage: /distribution {ticker} {24h|7d}\n\nExample: /distribution SOON or /distribution SOON 7d",
+	"usage.export":       "Usage: /export {ticker} {from} {to}\n\nExample: /export SOON 0112 0712 or /export SOON week\n\nArguments after ticker: %s\nDate format: %s",
+	"usage.pnl":          "Usage: /pnl {ticker} {wallet-suffix}\n\nExample: /pnl SOON 4f2a9c",
+	"usage.chart":        "Usage: /chart {ticker} {1m|5m|1h}\n\nExample: /chart SOON 1h",
+	"usage.alert":        "Usage: /alert {ticker} {above|below} {price_usd} [repeat]\n\nExample: /alert SOON above 0.05\nExample: /alert SOON below 0.01 repeat\n\n/alert list - your alerts\n/alert del {id} - remove alert",
+	"usage.watch":        "Usage: /watch {pubkey or spark address}\n\nNo wallets are watched in this chat",
+	"usage.unwatch":      "Usage: /unwatch {pubkey or spark address}",
+	"usage.unmute":       "Usage: /unmute {ticker}",
+	"usage.flagwallet":   "Usage: /flagwallet {pubkey or spark address} {team|rug|other} [note]\n\nBuys of new wallets funded by flagged wallet get a warning in alerts",
+	"usage.unflagwallet": "Usage: /unflagwallet {pubkey or spark address}",
+	"usage.audit":        "Usage: /audit {swapID}\n\nShows whether the bot saw the swap and which chats its alerts were sent to",
+
+	// Command replies
+	"command.admin_only":       "This command is available only in admin chat",
+	"command.error":            "An error occurred, please try again later",
+	"command.ticker_not_found": "Ticker {%s} not found. Make sure the token has been traded before.",
+	"command.ticker_unknown":   "Ticker {%s} not found",
+	"command.wallet_not_found": "Wallet not found. Use a public key or spark address.",
+	"command.report_failed":    "Failed to generate report: %s",
+	"command.flow_failed":      "Failed to generate flow report: %s",
+	"command.cohort_failed":    "Failed to generate cohort report: %s",
+	"command.top_failed":       "Failed to generate top holders: %s",
+	"command.holders_failed":   "Failed to generate holders summary: %s",
+	"command.pnl_failed":       "Failed to generate PnL: %s",
+	"command.stats_failed":     "Failed to get stats: %s",
+	"command.spark_failed":     "Failed to get BTC reserve: %s",
+
+	// /flashadd, /flashdel, /flashrefresh
+	"flashadd.unavailable":          "Ticker {%s} cannot be added at this time",
+	"flashadd.exists":               "Ticker {%s} already exists in the list",
+	"flashadd.added":                "Ticker {%s} successfully added to the list",
+	"flashdel.unavailable":          "Ticker {%s} cannot be removed at this time",
+	"flashdel.missing":              "Ticker {%s} is not in the list",
+	"flashdel.removed":              "Ticker {%s} successfully removed from the list",
+	"flashrefresh.not_found":        "Ticker {%s} not found. Use pool address for renamed tokens.",
+	"flashrefresh.unavailable":      "Luminex is unavailable, cached token data is kept. Please try again later",
+	"flashrefresh.done":             "Token {%s} refreshed\nName: %s\nDecimals: %s",
+	"flashrefresh.decimals_unknown": "unknown (8 is used)",
+	"flashrefresh.was":              "\nWas: {%s} %s",
+
+	// /holdersadd
+	"holdersadd.added":  "Ticker {%s} added to holders tracking",
+	"holdersadd.exists": "Ticker {%s} is already tracked",
+
+	// /alert
+	"alert.title":         "Your price alerts",
+	"alert.created":       "Alert #%d: {%s} %s $%s",
+	"alert.price_now":     "Price now: $%s",
+	"alert.mode_once":     "Mode: once",
+	"alert.mode_repeat":   "Mode: repeat",
+	"alert.none":          "You have no price alerts",
+	"alert.not_found":     "Alert #%d not found",
+	"alert.removed":       "Alert #%d removed",
+	"alert.invalid_price": "Price must be a positive number in USD, e.g. 0.05",
+	"alert.add_failed":    "Failed to add alert: %s",
+
+	// /watch, /unwatch
+	"watch.title":   "Watched wallets",
+	"watch.exists":  "Wallet %s is already watched",
+	"watch.added":   "Watching wallet %s\nEvery swap of this wallet will be posted here",
+	"watch.missing": "Wallet is not watched in this chat",
+	"watch.removed": "Stopped watching wallet %s",
+
+	// /flagwallet, /unflagwallet
+	"flagwallet.title":   "Flagged wallets",
+	"flagwallet.none":    "No wallets are flagged",
+	"flagwallet.added":   "Wallet %s flagged (%s)",
+	"flagwallet.updated": "Flag of wallet %s updated (%s)",
+	"flagwallet.missing": "Wallet %s is not flagged",
+	"flagwallet.removed": "Flag of wallet %s removed",
+
+	// /exclude, /include, /whitelist
+	"exclude.exists":   "Ticker {%s} is already excluded from notifications",
+	"exclude.done":     "Ticker {%s} excluded from notifications",
+	"include.failed":   "Token not found in exclusion list or error occurred",
+	"include.done":     "Ticker {%s} included back in notifications",
+	"whitelist.added":  "Ticker {%s} whitelisted, it will not be auto-blacklisted",
+	"whitelist.exists": "Ticker {%s} is already whitelisted",
+
+	// /whatsnew
+	"whatsnew.not_found": "Version %s not found\nAvailable: %s",
+
+	// /flash holders report
+	"holders_report.title":      "Report for %s (%s):\n\n",
+	"holders_report.no_data":    "Report for %s:\n\nNo data for the specified date",
+	"holders_report.wallet":     "wallet",
+	"holders_report.entry":      "Balance: %s | First buy: %s | Value: %s | Action: %s\n\n",
+	"holders_report.buy":        "BUY ×%d",
+	"holders_report.sell":       "SELL ×%d",
+	"holders_report.liquidated": "LIQUIDATED",
+
+	// /helps
+	"help.title":        "Commands:",
+	"help.flashadd":     "<code>/flashadd {ticker}</code> - adds token to big sales",
+	"help.flashdel":     "<code>/flashdel {ticker}</code> - removes token from big sales",
+	"help.flash":        "<code>/flash {ticker} {date}</code> - holder movement of token",
+	"help.flow":         "<code>/flow {ticker} {date|range}</code> - buy/sell ratio for a day or period (<code>0112-0712</code>, <code>week</code>, <code>month</code>)",
+	"help.cohort":       "<code>/cohort {ticker} {week}</code> - first-time and returning buyers of week and retention of previous weeks",
+	"help.distribution": "<code>/distribution {ticker} {24h|7d}</code> - swaps of token by btc size: retail or whales (24h by default)",
+	"help.export":       "<code>/export {ticker} {from} {to}</code> - holder changes (dates, actions, volumes) as CSV file for Excel",
+	"help.holdersadd":   "<code>/holdersadd {ticker}</code> - enables holder tracking of token",
+	"help.top":          "<code>/top {ticker}</code> - top 10 holders with share of supply and change over the day",
+	"help.holders":      "<code>/holders {ticker}</code> - holders of token now: buys, sells and btc inflow today",
+	"help.pnl":          "<code>/pnl {ticker} {wallet}</code> - PnL of wallet in token (by address ending)",
+	"help.leaderboard":  "<code>/leaderboard {ticker}</code> - top 10 wallets by realized PnL in token over the last 7 days",
+	"help.apr":          "<code>/apr {ticker}</code> - APR estimate for LP",
+	"help.token":        "<code>/token {ticker}</code> - token card: price, market cap, volume, TVL, holders, pool age, our 24h swaps, volatility and links",
+	"help.price":        "<code>/price {ticker}</code> - price, 24h change and market cap with 7 day chart",
+	"help.chart":        "<code>/chart {ticker} {1m|5m|1h}</code> - candlestick chart of token price (5m by default)",
+	"help.community":    "<code>/community {ticker}</code> - members of community chat with price and volume chart",
+	"help.reach":        "<code>/reach {ticker}</code> - reach of token alerts: chats, members, subscribers",
+	"help.alert":        "<code>/alert {ticker} {above|below} {price_usd} [repeat]</code> - token price alert (<code>/alert list</code>, <code>/alert del {id}</code>)",
+	"help.watch":        "<code>/watch {wallet}</code> - alert of every swap of wallet (<code>/unwatch {wallet}</code>, <code>/watch</code> - list)",
+	"help.quiet":        "<code>/quiet {HH:MM-HH:MM}</code> - quiet hours of chat (MSK): alerts are held and sent as one summary afterwards (<code>/quiet off</code>, <code>/quiet</code> - current)",
+	"help.mute":         "<code>/mute {ticker} {duration}</code> - turn off alerts of token in chat for a while (<code>30m</code>, <code>2h</code>, <code>1d</code>; <code>/unmute {ticker}</code>)",
+	"help.lang":         "<code>/lang {en|ru}</code> - language of bot messages in chat",
+	"help.admins":       "<code>/admins</code> - bot owners and admins, commands for admins only (<code>/admins add {userID}</code>, <code>/admins del {userID}</code>)",
+	"help.blacklist":    "<code>/blacklist</code> - tokens excluded from big sales (manually and automatically)",
+	"help.stats":        "<code>/stats</code> - overall spark market statistics",
+	"help.spark":        "<code>/spark</code> - chart of btc reserves in spark",
+	"help.whatsnew":     "<code>/whatsnew</code> - what's new in the bot",
+	"help.botstats":     "<code>/botstats</code> - bot version and state of monitors",
+
+	// Telegram autocomplete
+	"cmd.flashadd":     "Add token to big sales",
+	"cmd.flashdel":     "Remove token from big sales",
+	"cmd.flash":        "Holder movement of token: {ticker} {date}",
+	"cmd.flow":         "Buy/sell ratio: {ticker} {date|range}",
+	"cmd.cohort":       "First-time and returning buyers of week: {ticker} {week}",
+	"cmd.distribution": "Trade sizes of token, whales vs retail: {ticker} {24h|7d}",
+	"cmd.export":       "Holder changes as CSV: {ticker} {from} {to}",
+	"cmd.holdersadd":   "Enable holder tracking of token",
+	"cmd.top":          "Top 10 holders of token: {ticker}",
+	"cmd.holders":      "Holders of token and btc inflow today: {ticker}",
+	"cmd.pnl":          "PnL of wallet in token: {ticker} {wallet}",
+	"cmd.leaderboard":  "Most profitable wallets of week: {ticker}",
+	"cmd.apr":          "APR estimate for LP: {ticker}",
+	"cmd.token":        "Token card: price, holders, TVL, links: {ticker}",
+	"cmd.price":        "Token price with 7 day chart: {ticker}",
+	"cmd.chart":        "Candlestick price chart: {ticker} {1m|5m|1h}",
+	"cmd.community":    "Community members and activity of token: {ticker}",
+	"cmd.reach":        "Reach of token alerts by chat: {ticker}",
+	"cmd.alert":        "Price alert: {ticker} {above|below} {price_usd}",
+	"cmd.watch":        "Alerts of wallet swaps: {wallet}",
+	"cmd.unwatch":      "Stop watching wallet: {wallet}",
+	"cmd.quiet":        "Quiet hours of chat (MSK): {HH:MM-HH:MM|off}",
+	"cmd.mute":         "Turn off alerts of token: {ticker} {duration}",
+	"cmd.unmute":       "Turn on alerts of token: {ticker}",
+	"cmd.lang":         "Language of bot messages: {en|ru}",
+	"cmd.admins":       "Bot admins: add {userID}, del {userID}",
+	"cmd.blacklist":    "Tokens excluded from big sales",
+	"cmd.whitelist":    "Remove token from auto-blacklist: {ticker}",
+	"cmd.exclude":      "Exclude token from big sales: {ticker}",
+	"cmd.include":      "Return token to big sales: {ticker}",
+	"cmd.flashrefresh": "Refresh ticker, name and decimals of token from Luminex: {ticker}",
+	"cmd.flagwallet":   "Flag wallet: {wallet} {team|rug|other}",
+	"cmd.unflagwallet": "Remove flag of wallet: {wallet}",
+	"cmd.label":        "Label of wallet in alerts: {wallet} {name}",
+	"cmd.unlabel":      "Remove label of wallet: {wallet}",
+	"cmd.testalert":    "Test buy and sell alert: {route} [ticker]",
+	"cmd.audit":        "Alerts of swap: seen, sent, failed or held: {swapID}",
+	"cmd.portfolio":    "Portfolio of own wallet: shares, 24h/7d change",
+	"cmd.stats":        "Overall spark market statistics",
+	"cmd.spark":        "Chart of btc reserves in spark",
+	"cmd.whatsnew":     "What's new in the bot",
+	"cmd.botstats":     "Bot version and state of monitors",
+	"cmd.helps":        "List of commands",
+}
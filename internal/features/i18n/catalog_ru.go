@@ -0,0 +1,232 @@
+package i18n
+
+// Russian catalog, keys as in catalog_en.go
+
+var catalogRU = map[string]string{
+	"lang.name":    "Русский",
+	"lang.current": "🌐 Язык чата: <b>%s</b>",
+	"lang.set":     "🌐 Язык чата изменен: <b>%s</b>",
+	"lang.usage":   "Использование: /lang {%s}, /lang reset - язык из конфига",
+	"lang.error":   "Произошла ошибка, попробуйте позже",
+
+	// Swap alerts
+	"swap.buy":          "Покупка",
+	"swap.sell":         "Продажа",
+	"swap.swap":         "Обмен",
+	"swap.loading":      "Загрузка деталей...",
+	"swap.trade_button": "Торговать на Luminex",
+	"swap.combined":     "📦 <b>Свапов: %d</b>",
+	"swap.watched":      "👀 <b>Отслеживаемый кошелек</b>",
+	"swap.raw.buy":      "ПОКУПКА",
+	"swap.raw.sell":     "ПРОДАЖА",
+	"swap.raw.swap":     "ОБМЕН",
+	"swap.raw.gave":     "Отдали",
+	"swap.raw.received": "Получили",
+	"swap.raw.tokens":   "токенов",
+
+	// /flow
+	"flow.title":       "%s за %s:\n\n",
+	"flow.range_title": "%s за %s – %s (дней: %d):\n\n",
+	"flow.buys":        "Покупки: %d (%s)",
+	"flow.sells":       "Продажи: %d (%s)",
+	"flow.by_day":      "По дням",
+	"flow.by_week":     "По неделям",
+	"flow.by_month":    "По месяцам",
+	"flow.footer":      "По свапам из архива бота, дни UTC",
+
+	// /cohort
+	"cohort.title":        "Покупатели %s %s (%s – %s):\n\n",
+	"cohort.buyers":       "Покупателей: %d",
+	"cohort.first_time":   "– Новые: %d (%s btc)",
+	"cohort.returning":    "– Повторные: %d (%s btc)",
+	"cohort.bought_again": "Купили снова в %s:",
+	"cohort.retained":     "%s: %d из %d",
+	"cohort.footer":       "По свапам из архива бота, недели UTC, повторные - покупали в течение %d недель до этого",
+
+	// /distribution
+	"distribution.title":  "Размеры сделок {%s} за %s: свапов %d, %s btc\n\n",
+	"distribution.bucket": "<code>%s</code> btc: свапов %d (%.0f%%), %s btc (%.0f%% объема), покупок %d / продаж %d",
+	"distribution.whales": "🐋 Свапы больше %s btc: %.0f%% объема",
+	"distribution.retail": "🐟 Свапы меньше %s btc: %.0f%% объема",
+	"distribution.footer": "По свапам из архива бота, обмены токен на токен не учитываются",
+
+	// /leaderboard
+	"leaderboard.title":   "🏆 Самые прибыльные кошельки {%s} %s - %s (UTC)\n\n",
+	"leaderboard.wallet":  "%s %s: %s, продаж %d на %s btc",
+	"leaderboard.sellers": "В прибыли: %d из %d продавцов",
+	"leaderboard.footer":  "Реализованный PnL продаж (по средней цене), себестоимость по свапам из архива бота за %d дней до этого",
+
+	// Command usage
+	"usage.ticker":       "Использование: /%[1]s {тикер}\n\nПример: /%[1]s SOON",
+	"usage.flashrefresh": "Использование: /flashrefresh {тикер или адрес пула}\n\nПример: /flashrefresh SOON",
+	"usage.flash":        "Использование: /flash {тикер} {дата}\n\nПример: /flash SOON 0812\n\nФормат даты: %s (например, 0812 или 08.12 для 8 декабря)",
+	"usage.flow":         "Использование: /flow {тикер} {дата|период}\n\nПример: /flow SOON 0912 или /flow SOON 0112-0712\n\nФормат даты: %s (например, 0912 или 09.12 для 9 декабря)\nФормат периода: %s",
+	"usage.cohort":       "Использование: /cohort {тикер} {неделя}\n\nПример: /cohort SOON или /cohort SOON 2025-W49\n\nФормат недели: %s (текущая неделя, если не указана)",
+	"usage.distribution": "Использование: /distribution {тикер} {24h|7d}\n\nПример: /distribution SOON или /distribution SOON 7d",
+	"usage.export":       "Использование: /export {тикер} {с} {по}\n\nПример: /export SOON 0112 0712 или /export SOON week\n\nАргументы после тикера: %s\nФормат даты: %s",
+	"usage.pnl":          "Использование: /pnl {тикер} {конец-адреса}\n\nПример: /pnl SOON 4f2a9c",
+	"usage.chart":        "Использование: /chart {тикер} {1m|5m|1h}\n\nПример: /chart SOON 1h",
+	"usage.alert":        "Использование: /alert {тикер} {above|below} {цена_usd} [repeat]\n\nПример: /alert SOON above 0.05\nПример: /alert SOON below 0.01 repeat\n\n/alert list - ваши алерты\n/alert del {id} - удалить алерт",
+	"usage.watch":        "Использование: /watch {pubkey или spark-адрес}\n\nВ этом чате нет отслеживаемых кошельков",
+	"usage.unwatch":      "Использование: /unwatch {pubkey или spark-адрес}",
+	"usage.unmute":       "Использование: /unmute {тикер}",
+	"usage.flagwallet":   "Использование: /flagwallet {pubkey или spark-адрес} {team|rug|other} [заметка]\n\nПокупки новых кошельков, пополненных с отмеченного кошелька, получают предупреждение в алертах",
+	"usage.unflagwallet": "Использование: /unflagwallet {pubkey или spark-адрес}",
+	"usage.audit":        "Использование: /audit {swapID}\n\nПоказывает, видел ли бот своп и в какие чаты отправлены его алерты",
+
+	// Command replies
+	"command.admin_only":       "Команда доступна только в админ-чате",
+	"command.error":            "Произошла ошибка, попробуйте позже",
+	"command.ticker_not_found": "Тикер {%s} не найден. Убедитесь, что токен уже торговался.",
+	"command.ticker_unknown":   "Тикер {%s} не найден",
+	"command.wallet_not_found": "Кошелек не найден. Используйте публичный ключ или spark-адрес.",
+	"command.report_failed":    "Не удалось сформировать отчет: %s",
+	"command.flow_failed":      "Не удалось сформировать отчет о потоках: %s",
+	"command.cohort_failed":    "Не удалось сформировать когортный отчет: %s",
+	"command.top_failed":       "Не удалось получить топ холдеров: %s",
+	"command.holders_failed":   "Не удалось получить сводку по холдерам: %s",
+	"command.pnl_failed":       "Не удалось рассчитать PnL: %s",
+	"command.stats_failed":     "Не удалось получить статистику: %s",
+	"command.spark_failed":     "Не удалось получить резерв BTC: %s",
+
+	// /flashadd, /flashdel, /flashrefresh
+	"flashadd.unavailable":          "Тикер {%s} сейчас нельзя добавить",
+	"flashadd.exists":               "Тикер {%s} уже есть в списке",
+	"flashadd.added":                "Тикер {%s} добавлен в список",
+	"flashdel.unavailable":          "Тикер {%s} сейчас нельзя удалить",
+	"flashdel.missing":              "Тикера {%s} нет в списке",
+	"flashdel.removed":              "Тикер {%s} удален из списка",
+	"flashrefresh.not_found":        "Тикер {%s} не найден. Для переименованных токенов используйте адрес пула.",
+	"flashrefresh.unavailable":      "Luminex недоступен, сохраненные данные токена оставлены. Попробуйте позже",
+	"flashrefresh.done":             "Токен {%s} обновлен\nНазвание: %s\nDecimals: %s",
+	"flashrefresh.decimals_unknown": "неизвестно (используется 8)",
+	"flashrefresh.was":              "\nБыло: {%s} %s",
+
+	// /holdersadd
+	"holdersadd.added":  "Тикер {%s} добавлен в отслеживание холдеров",
+	"holdersadd.exists": "Тикер {%s} уже отслеживается",
+
+	// /alert
+	"alert.title":         "Ваши ценовые алерты",
+	"alert.created":       "Алерт #%d: {%s} %s $%s",
+	"alert.price_now":     "Цена сейчас: $%s",
+	"alert.mode_once":     "Режим: один раз",
+	"alert.mode_repeat":   "Режим: повторять",
+	"alert.none":          "У вас нет ценовых алертов",
+	"alert.not_found":     "Алерт #%d не найден",
+	"alert.removed":       "Алерт #%d удален",
+	"alert.invalid_price": "Цена должна быть положительным числом в USD, например 0.05",
+	"alert.add_failed":    "Не удалось добавить алерт: %s",
+
+	// /watch, /unwatch
+	"watch.title":   "Отслеживаемые кошельки",
+	"watch.exists":  "Кошелек %s уже отслеживается",
+	"watch.added":   "Кошелек %s отслеживается\nКаждый своп этого кошелька будет публиковаться здесь",
+	"watch.missing": "Кошелек не отслеживается в этом чате",
+	"watch.removed": "Кошелек %s больше не отслеживается",
+
+	// /flagwallet, /unflagwallet
+	"flagwallet.title":   "Отмеченные кошельки",
+	"flagwallet.none":    "Отмеченных кошельков нет",
+	"flagwallet.added":   "Кошелек %s отмечен (%s)",
+	"flagwallet.updated": "Отметка кошелька %s обновлена (%s)",
+	"flagwallet.missing": "Кошелек %s не отмечен",
+	"flagwallet.removed": "Отметка кошелька %s снята",
+
+	// /exclude, /include, /whitelist
+	"exclude.exists":   "Тикер {%s} уже исключен из уведомлений",
+	"exclude.done":     "Тикер {%s} исключен из уведомлений",
+	"include.failed":   "Токен не найден в списке исключений или произошла ошибка",
+	"include.done":     "Тикер {%s} возвращен в уведомления",
+	"whitelist.added":  "Тикер {%s} в белом списке, он не попадет в авто-блэклист",
+	"whitelist.exists": "Тикер {%s} уже в белом списке",
+
+	// /whatsnew
+	"whatsnew.not_found": "Версия %s не найдена\nДоступны: %s",
+
+	// /flash holders report
+	"holders_report.title":      "Отчет за %s (%s):\n\n",
+	"holders_report.no_data":    "Отчет за %s:\n\nНет данных за указанную дату",
+	"holders_report.wallet":     "кошелек",
+	"holders_report.entry":      "Баланс: %s | Первая покупка: %s | Сумма: %s | Действие: %s\n\n",
+	"holders_report.buy":        "ПОКУПКА ×%d",
+	"holders_report.sell":       "ПРОДАЖА ×%d",
+	"holders_report.liquidated": "ЛИКВИДИРОВАН",
+
+	// /helps
+	"help.title":        "Команды:",
+	"help.flashadd":     "<code>/flashadd {ticker}</code> - добавляет токен в big sales",
+	"help.flashdel":     "<code>/flashdel {ticker}</code> - удаляет токен из big sales",
+	"help.flash":        "<code>/flash {ticker} {date}</code> - движение холдеров в токене",
+	"help.flow":         "<code>/flow {ticker} {date|range}</code> - отчет о коэффициенте покупок/продаж за день или период (<code>0112-0712</code>, <code>week</code>, <code>month</code>)",
+	"help.cohort":       "<code>/cohort {ticker} {week}</code> - новые и повторные покупатели за неделю и удержание покупателей прошлых недель",
+	"help.distribution": "<code>/distribution {ticker} {24h|7d}</code> - свапы токена по размеру в btc: ритейл или киты (по умолчанию 24h)",
+	"help.export":       "<code>/export {ticker} {from} {to}</code> - изменения холдеров (даты, действия, объемы) файлом CSV для Excel",
+	"help.holdersadd":   "<code>/holdersadd {ticker}</code> - включает отслеживание холдеров токена",
+	"help.top":          "<code>/top {ticker}</code> - топ-10 холдеров с долей от эмиссии и изменением за день",
+	"help.holders":      "<code>/holders {ticker}</code> - холдеры токена сейчас: покупки, продажи и приток btc за сегодня",
+	"help.pnl":          "<code>/pnl {ticker} {wallet}</code> - PnL кошелька в токене (по окончанию адреса)",
+	"help.leaderboard":  "<code>/leaderboard {ticker}</code> - топ 10 кошельков по реализованному PnL в токене за последние 7 дней",
+	"help.apr":          "<code>/apr {ticker}</code> - оценка APR для LP",
+	"help.token":        "<code>/token {ticker}</code> - карточка токена: цена, капитализация, объем, TVL, холдеры, возраст пула, наши свапы за 24ч, волатильность и ссылки",
+	"help.price":        "<code>/price {ticker}</code> - цена, изменение за 24ч и капитализация с графиком за 7 дней",
+	"help.chart":        "<code>/chart {ticker} {1m|5m|1h}</code> - свечной график цены токена (по умолчанию 5m)",
+	"help.community":    "<code>/community {ticker}</code> - график участников чата сообщества вместе с ценой и объемом",
+	"help.reach":        "<code>/reach {ticker}</code> - охват алертов токена: чаты, участники, подписчики",
+	"help.alert":        "<code>/alert {ticker} {above|below} {price_usd} [repeat]</code> - уведомление о цене токена (<code>/alert list</code>, <code>/alert del {id}</code>)",
+	"help.watch":        "<code>/watch {wallet}</code> - уведомления о каждом свапе кошелька (<code>/unwatch {wallet}</code>, <code>/watch</code> - список)",
+	"help.quiet":        "<code>/quiet {HH:MM-HH:MM}</code> - тихие часы чата (МСК): алерты копятся и приходят одной сводкой после окончания (<code>/quiet off</code>, <code>/quiet</code> - текущие)",
+	"help.mute":         "<code>/mute {ticker} {duration}</code> - отключить алерты токена в чате на время (<code>30m</code>, <code>2h</code>, <code>1d</code>; <code>/unmute {ticker}</code>)",
+	"help.lang":         "<code>/lang {en|ru}</code> - язык сообщений бота в чате",
+	"help.admins":       "<code>/admins</code> - владельцы и админы бота, команды только для админов (<code>/admins add {userID}</code>, <code>/admins del {userID}</code>)",
+	"help.blacklist":    "<code>/blacklist</code> - токены, исключенные из big sales (вручную и автоматически)",
+	"help.stats":        "<code>/stats</code> - общая статистика по рынку spark",
+	"help.spark":        "<code>/spark</code> - график резервов btc в spark",
+	"help.whatsnew":     "<code>/whatsnew</code> - что нового в боте",
+	"help.botstats":     "<code>/botstats</code> - версия бота и состояние мониторов",
+
+	// Telegram autocomplete
+	"cmd.flashadd":     "Добавить токен в big sales",
+	"cmd.flashdel":     "Удалить токен из big sales",
+	"cmd.flash":        "Движение холдеров в токене: {ticker} {date}",
+	"cmd.flow":         "Коэффициент покупок/продаж: {ticker} {date|range}",
+	"cmd.cohort":       "Новые и повторные покупатели токена за неделю: {ticker} {week}",
+	"cmd.distribution": "Размеры сделок токена, киты или ритейл: {ticker} {24h|7d}",
+	"cmd.export":       "Изменения холдеров в CSV: {ticker} {from} {to}",
+	"cmd.holdersadd":   "Включить отслеживание холдеров токена",
+	"cmd.top":          "Топ-10 холдеров токена: {ticker}",
+	"cmd.holders":      "Холдеры токена и приток btc за сегодня: {ticker}",
+	"cmd.pnl":          "PnL кошелька в токене: {ticker} {wallet}",
+	"cmd.leaderboard":  "Самые прибыльные кошельки недели: {ticker}",
+	"cmd.apr":          "Оценка APR для LP: {ticker}",
+	"cmd.token":        "Карточка токена: цена, холдеры, TVL, ссылки: {ticker}",
+	"cmd.price":        "Цена токена с графиком за 7 дней: {ticker}",
+	"cmd.chart":        "Свечной график цены: {ticker} {1m|5m|1h}",
+	"cmd.community":    "Участники сообщества и активность токена: {ticker}",
+	"cmd.reach":        "Охват алертов токена по чатам: {ticker}",
+	"cmd.alert":        "Уведомление о цене: {ticker} {above|below} {price_usd}",
+	"cmd.watch":        "Уведомления о свапах кошелька: {wallet}",
+	"cmd.unwatch":      "Убрать кошелек из отслеживания: {wallet}",
+	"cmd.quiet":        "Тихие часы чата (МСК): {HH:MM-HH:MM|off}",
+	"cmd.mute":         "Отключить алерты токена: {ticker} {duration}",
+	"cmd.unmute":       "Включить алерты токена: {ticker}",
+	"cmd.lang":         "Язык сообщений бота: {en|ru}",
+	"cmd.admins":       "Админы бота: add {userID}, del {userID}",
+	"cmd.blacklist":    "Токены, исключенные из big sales",
+	"cmd.whitelist":    "Снять токен с авто-blacklist: {ticker}",
+	"cmd.exclude":      "Исключить токен из big sales: {ticker}",
+	"cmd.include":      "Вернуть токен в big sales: {ticker}",
+	"cmd.flashrefresh": "Обновить тикер, имя и decimals токена из Luminex: {ticker}",
+	"cmd.flagwallet":   "Пометить кошелек: {wallet} {team|rug|other}",
+	"cmd.unflagwallet": "Снять пометку с кошелька: {wallet}",
+	"cmd.label":        "Подпись кошелька в алертах: {wallet} {name}",
+	"cmd.unlabel":      "Убрать подпись кошелька: {wallet}",
+	"cmd.testalert":    "Тестовый алерт покупки и продажи: {route} [ticker]",
+	"cmd.audit":        "Алерты свапа: увиден, отправлен, ошибка или отложен: {swapID}",
+	"cmd.portfolio":    "Портфель своего кошелька: доли, изменение за 24ч/7д",
+	"cmd.stats":        "Общая статистика по рынку spark",
+	"cmd.spark":        "График резервов btc в spark",
+	"cmd.whatsnew":     "Что нового в боте",
+	"cmd.botstats":     "Версия бота и состояние мониторов",
+	"cmd.helps":        "Список команд",
+}
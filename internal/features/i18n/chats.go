@@ -0,0 +1,105 @@
+package i18n
+
+// Language of each chat (data_out/telegram_out/chat_languages.json)
+// Language set with /lang in chat comes first, then config (telegram.chat_languages), then default language
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+// ChatLanguagesFile - languages set with /lang per chat
+func ChatLanguagesFile() string {
+	return paths.Output("telegram_out", "chat_languages.json")
+}
+
+// ChatLanguagesData - file structure for chat_languages.json
+type ChatLanguagesData struct {
+	Chats map[int64]Lang `json:"chats"`
+}
+
+var (
+	chatsMutex sync.Mutex
+
+	configuredLanguages = make(map[int64]Lang)
+	// Languages are read on every alert, file is loaded once per path (output dir changes in tests)
+	chatsCache     *ChatLanguagesData
+	chatsCachePath string
+)
+
+// Configure sets languages of chats from config (chat ID -> language)
+func Configure(languages map[int64]Lang) {
+	chatsMutex.Lock()
+	defer chatsMutex.Unlock()
+	configuredLanguages = languages
+}
+
+// ChatLang returns language of chat
+func ChatLang(chatID int64) Lang {
+	lang, _ := ChatSetting(chatID)
+	return lang
+}
+
+// ChatSetting returns language of chat and whether it was set with /lang
+func ChatSetting(chatID int64) (Lang, bool) {
+	chatsMutex.Lock()
+	defer chatsMutex.Unlock()
+
+	if data, err := loadChatsUnlocked(); err == nil {
+		if lang, exists := data.Chats[chatID]; exists {
+			return lang, true
+		}
+	}
+	if lang, exists := configuredLanguages[chatID]; exists {
+		return lang, false
+	}
+	return Default(), false
+}
+
+// SetChatLang saves language of chat, empty language removes /lang setting (config or default applies)
+func SetChatLang(chatID int64, lang Lang) error {
+	chatsMutex.Lock()
+	defer chatsMutex.Unlock()
+
+	data, err := loadChatsUnlocked()
+	if err != nil {
+		return err
+	}
+	if lang == "" {
+		delete(data.Chats, chatID)
+	} else {
+		data.Chats[chatID] = lang
+	}
+	if err := storage.WriteJSONAtomic(ChatLanguagesFile(), data); err != nil {
+		return fmt.Errorf("failed to save chat languages: %w", err)
+	}
+	return nil
+}
+
+func loadChatsUnlocked() (*ChatLanguagesData, error) {
+	filePath := ChatLanguagesFile()
+	if chatsCache != nil && chatsCachePath == filePath {
+		return chatsCache, nil
+	}
+
+	data := &ChatLanguagesData{}
+	raw, err := os.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read chat languages file: %w", err)
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, data); err != nil {
+			return nil, fmt.Errorf("failed to parse chat languages JSON: %w", err)
+		}
+	}
+	if data.Chats == nil {
+		data.Chats = make(map[int64]Lang)
+	}
+	chatsCache, chatsCachePath = data, filePath
+	return data, nil
+}
@@ -0,0 +1,107 @@
+package i18n
+
+// Languages of bot messages: message catalogs (catalog_en.go, catalog_ru.go) selected per chat (see chats.go)
+// Text is looked up by key in catalog of language, missing key falls back to English, then to key itself
+// Catalog values with verbs are fmt formats, arguments of T are applied to them
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Lang - language of bot messages
+type Lang string
+
+const (
+	// English - default language
+	English Lang = "en"
+	// Russian - language of the original bot messages
+	Russian Lang = "ru"
+)
+
+// Languages - supported languages in /lang order
+var Languages = []Lang{English, Russian}
+
+var catalogs = map[Lang]map[string]string{
+	English: catalogEN,
+	Russian: catalogRU,
+}
+
+var (
+	defaultLang      = English
+	defaultLangMutex sync.RWMutex
+)
+
+// ParseLang parses language code or name (en, english, ru, russian, рус)
+func ParseLang(value string) (Lang, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "en", "eng", "english", "англ", "английский":
+		return English, nil
+	case "ru", "rus", "russian", "рус", "русский":
+		return Russian, nil
+	}
+	return "", fmt.Errorf("unsupported language %q, expected %s", value, supportedCodes())
+}
+
+// Name returns name of language in this language (English, Русский)
+func (l Lang) Name() string {
+	return T(l, "lang.name")
+}
+
+// SetDefault sets language of chats without configured or /lang language (telegram.language)
+func SetDefault(lang Lang) {
+	defaultLangMutex.Lock()
+	defaultLang = lang
+	defaultLangMutex.Unlock()
+}
+
+// Default returns language of chats without configured or /lang language
+func Default() Lang {
+	defaultLangMutex.RLock()
+	defer defaultLangMutex.RUnlock()
+	return defaultLang
+}
+
+// T returns text of key in language, formatted with args if there are any
+func T(lang Lang, key string, args ...interface{}) string {
+	text, ok := catalogs[lang][key]
+	if !ok {
+		text, ok = catalogEN[key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Has checks if English catalog has key (optional texts, e.g. /helps lines)
+func Has(key string) bool {
+	_, ok := catalogEN[key]
+	return ok
+}
+
+// MissingKeys returns keys of English catalog that language has no translation of (sorted)
+func MissingKeys(lang Lang) []string {
+	var missing []string
+	for key := range catalogEN {
+		if _, ok := catalogs[lang][key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// supportedCodes returns codes of supported languages for error and usage messages ("en, ru")
+func supportedCodes() string {
+	codes := make([]string, 0, len(Languages))
+	for _, lang := range Languages {
+		codes = append(codes, string(lang))
+	}
+	return strings.Join(codes, ", ")
+}
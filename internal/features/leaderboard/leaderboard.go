@@ -0,0 +1,218 @@
+package leaderboard
+
+// Leaderboard of most profitable wallets in token for /leaderboard {ticker} and weekly post
+// Wallets are ranked by realized PnL of their sells in period (average cost method, as /pnl)
+// Cost basis is built from swaps archived by big sales monitor (data_out/archive/swaps) for HistoryDays before period
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/pnl"
+	"spark-wallet/internal/features/wallet_labels"
+	storage "spark-wallet/internal/infra/fs"
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PeriodDays - days of sells ranked by leaderboard
+	PeriodDays = 7
+	// HistoryDays - days of swaps before period used for cost basis
+	HistoryDays = 90
+	// TopSize - wallets shown in leaderboard
+	TopSize = 10
+)
+
+// medals of first three places
+var medals = []string{"🥇", "🥈", "🥉"}
+
+// Wallet - realized PnL of wallet in period
+type Wallet struct {
+	PublicKey      string
+	Name           string // label or username, empty - unknown
+	Buys           int
+	Sells          int
+	ReceivedBTC    float64 // BTC received in sells
+	RealizedPnLBTC float64
+}
+
+// Board - most profitable wallets of token in [From, To)
+type Board struct {
+	Ticker   string
+	From     time.Time
+	To       time.Time
+	Sellers  int      // wallets that sold in period
+	InProfit int      // sellers with positive realized PnL
+	Wallets  []Wallet // in profit, best first, up to TopSize
+}
+
+// LoadBoard builds leaderboard of pool in [from, to) from swap archive, names wallets by labels and usernames
+func LoadBoard(poolLpPublicKey string, from time.Time, to time.Time) (*Board, error) {
+	from, to = from.UTC(), to.UTC()
+
+	var swaps []flashnet.Swap
+	for day := from.AddDate(0, 0, -HistoryDays).Truncate(24 * time.Hour); day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		daySwaps, err := storage.LoadDailySwaps(date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load swaps of %s: %w", date, err)
+		}
+		for _, swap := range daySwaps {
+			if swap.PoolLpPublicKey == poolLpPublicKey {
+				swaps = append(swaps, swap)
+			}
+		}
+	}
+
+	board := Build(swaps, from, to)
+	if len(board.Wallets) == 0 {
+		return board, nil
+	}
+
+	// Usernames from local table only: Luminex request per wallet is not needed for 10 wallets of weekly post
+	usernames := make(map[string]string)
+	if table, err := storage.LoadUsernames(); err != nil {
+		logging.LogWarn("Failed to load usernames for leaderboard", zap.Error(err))
+	} else {
+		for publicKey, entry := range table.Wallets {
+			usernames[publicKey] = entry.Username
+		}
+	}
+	for i := range board.Wallets {
+		wallet := &board.Wallets[i]
+		wallet.Name = wallet_labels.Of(wallet.PublicKey)
+		if wallet.Name == "" {
+			wallet.Name = usernames[wallet.PublicKey]
+		}
+	}
+	return board, nil
+}
+
+// Build ranks wallets by realized PnL of sells within [from, to), swaps before from build cost basis
+// Swaps of one pool in any order
+func Build(swaps []flashnet.Swap, from time.Time, to time.Time) *Board {
+	board := &Board{From: from, To: to}
+
+	sorted := make([]flashnet.Swap, len(swaps))
+	copy(sorted, swaps)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		left, _ := storage.SwapTime(sorted[i])
+		right, _ := storage.SwapTime(sorted[j])
+		return left.Before(right)
+	})
+
+	// Token amounts are raw: realized PnL does not depend on decimals
+	positions := make(map[string]*pnl.WalletPnL)
+	wallets := make(map[string]*Wallet)
+	for _, swap := range sorted {
+		at, ok := storage.SwapTime(swap)
+		if !ok {
+			continue
+		}
+		if !at.Before(to) {
+			break
+		}
+		swapType := swap.GetSwapType()
+		if swapType != flashnet.SwapTypeBuy && swapType != flashnet.SwapTypeSell {
+			continue
+		}
+
+		position := positions[swap.SwapperPublicKey]
+		if position == nil {
+			position = &pnl.WalletPnL{WalletPublicKey: swap.SwapperPublicKey}
+			positions[swap.SwapperPublicKey] = position
+		}
+		realized, received := position.RealizedPnLBTC, position.ReceivedBTC
+		if err := position.AddSwap(swap, 1); err != nil {
+			logging.LogDebug("Skipping swap with invalid amount in leaderboard", zap.Error(err))
+			continue
+		}
+		if at.Before(from) {
+			continue
+		}
+
+		wallet := wallets[swap.SwapperPublicKey]
+		if wallet == nil {
+			wallet = &Wallet{PublicKey: swap.SwapperPublicKey}
+			wallets[swap.SwapperPublicKey] = wallet
+		}
+		if swapType == flashnet.SwapTypeBuy {
+			wallet.Buys++
+			continue
+		}
+		wallet.Sells++
+		wallet.ReceivedBTC += position.ReceivedBTC - received
+		wallet.RealizedPnLBTC += position.RealizedPnLBTC - realized
+	}
+
+	for _, wallet := range wallets {
+		if wallet.Sells == 0 {
+			continue
+		}
+		board.Sellers++
+		if wallet.RealizedPnLBTC > 0 {
+			board.InProfit++
+			board.Wallets = append(board.Wallets, *wallet)
+		}
+	}
+	sort.Slice(board.Wallets, func(i, j int) bool {
+		if board.Wallets[i].RealizedPnLBTC != board.Wallets[j].RealizedPnLBTC {
+			return board.Wallets[i].RealizedPnLBTC > board.Wallets[j].RealizedPnLBTC
+		}
+		return board.Wallets[i].PublicKey < board.Wallets[j].PublicKey
+	})
+	if len(board.Wallets) > TopSize {
+		board.Wallets = board.Wallets[:TopSize]
+	}
+	return board
+}
+
+// Format formats leaderboard for Telegram in language of chat (HTML)
+// btcPriceUSD - price for USD values, 0 - BTC only
+func Format(board *Board, btcPriceUSD float64, lang i18n.Lang) string {
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "leaderboard.title", html.EscapeString(board.Ticker),
+		board.From.Format("02.01"), board.To.Add(-time.Second).Format("02.01")))
+
+	text.WriteString("<blockquote>")
+	for i, wallet := range board.Wallets {
+		place := fmt.Sprintf("%d.", i+1)
+		if i < len(medals) {
+			place = medals[i]
+		}
+		pnlText := amount.FormatSignedBTC(wallet.RealizedPnLBTC) + " btc"
+		if btcPriceUSD > 0 {
+			pnlText += " ($" + luminex.FormatUSDValue(wallet.RealizedPnLBTC*btcPriceUSD) + ")"
+		}
+		text.WriteString(i18n.T(lang, "leaderboard.wallet", place, html.EscapeString(walletName(wallet)),
+			pnlText, wallet.Sells, amount.FormatBTC(wallet.ReceivedBTC)))
+		if i < len(board.Wallets)-1 {
+			text.WriteString("\n")
+		}
+	}
+	text.WriteString("</blockquote>\n")
+
+	text.WriteString(i18n.T(lang, "leaderboard.sellers", board.InProfit, board.Sellers))
+	text.WriteString("\n\n<i>" + i18n.T(lang, "leaderboard.footer", HistoryDays) + "</i>")
+	return text.String()
+}
+
+// walletName returns name of wallet or ending of its public key
+func walletName(wallet Wallet) string {
+	if wallet.Name != "" {
+		return wallet.Name
+	}
+	if len(wallet.PublicKey) <= 6 {
+		return wallet.PublicKey
+	}
+	return "..." + wallet.PublicKey[len(wallet.PublicKey)-6:]
+}
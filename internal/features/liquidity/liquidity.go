@@ -0,0 +1,209 @@
+package liquidity
+
+// Pool liquidity snapshots and TVL change detection (data_out/telegram_out/liquidity.json)
+// Flashnet has no liquidity events endpoint, so add/remove liquidity is derived from reserve snapshots:
+// TVL of each sample is compared with the oldest sample inside the window
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/infra/paths"
+)
+
+// LiquidityFile - reserve snapshots per pool
+func LiquidityFile() string {
+	return paths.Output("telegram_out", "liquidity.json")
+}
+
+// Snapshot - pool reserves at sample time
+type Snapshot struct {
+	Time       string  `json:"time"`        // RFC3339
+	BtcReserve float64 `json:"btc_reserve"` // BTC side reserve, BTC
+	TvlBTC     float64 `json:"tvl_btc"`     // 0 if pool is not paired with BTC
+	TvlUsd     float64 `json:"tvl_usd"`
+}
+
+// PoolLiquidity - snapshots of one pool
+type PoolLiquidity struct {
+	PoolLpPublicKey string     `json:"pool_lp_public_key"`
+	Ticker          string     `json:"ticker"`
+	Snapshots       []Snapshot `json:"snapshots"`
+	LastAlertAt     string     `json:"last_alert_at,omitempty"` // RFC3339
+}
+
+// LiquidityData - file structure for liquidity.json
+type LiquidityData struct {
+	Pools map[string]*PoolLiquidity `json:"pools"` // poolLpPublicKey -> snapshots
+}
+
+// Change - TVL change of pool within window
+type Change struct {
+	PoolLpPublicKey string
+	Ticker          string
+	From            Snapshot
+	To              Snapshot
+	ChangePercent   float64 // negative - liquidity removed
+	Period          time.Duration
+}
+
+// Removed returns true if liquidity was removed
+func (c *Change) Removed() bool {
+	return c.ChangePercent < 0
+}
+
+var liquidityMutex sync.Mutex
+
+// SamplePool saves reserve snapshot of pool and compares TVL with oldest snapshot in window
+// Returns change if TVL moved by thresholdPercent or more (nil otherwise)
+// Pool is not reported again until window passes after previous alert
+func SamplePool(poolLpPublicKey string, window time.Duration, thresholdPercent float64) (*Change, error) {
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool details: %w", err)
+	}
+
+	now := time.Now().UTC()
+	snapshot := Snapshot{
+		Time:   now.Format(time.RFC3339),
+		TvlUsd: poolData.Extra.PoolTvlUsd,
+	}
+	tokenMeta, btcReserve, _ := poolData.TokenSide()
+	ticker := tokenMeta.Ticker
+	if sats, err := strconv.ParseFloat(btcReserve, 64); err == nil {
+		snapshot.BtcReserve = sats / 1e8
+	}
+	if poolData.AssetBAddress == flashnet.NativeTokenAddress {
+		if sats, err := strconv.ParseFloat(poolData.TvlAssetB, 64); err == nil {
+			snapshot.TvlBTC = sats / 1e8
+		}
+	}
+
+	liquidityMutex.Lock()
+	defer liquidityMutex.Unlock()
+
+	data, err := loadLiquidityUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	pool, exists := data.Pools[poolLpPublicKey]
+	if !exists {
+		pool = &PoolLiquidity{PoolLpPublicKey: poolLpPublicKey}
+		data.Pools[poolLpPublicKey] = pool
+	}
+	if ticker != "" {
+		pool.Ticker = ticker
+	}
+
+	// Keep only snapshots inside window, oldest of them is the reference
+	windowStart := now.Add(-window)
+	kept := pool.Snapshots[:0]
+	for _, sample := range pool.Snapshots {
+		sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+		if err != nil || sampleTime.Before(windowStart) {
+			continue
+		}
+		kept = append(kept, sample)
+	}
+	pool.Snapshots = append(kept, snapshot)
+
+	var change *Change
+	if len(pool.Snapshots) > 1 && !alertedWithin(pool.LastAlertAt, now, window) {
+		reference := pool.Snapshots[0]
+		changePercent, ok := tvlChangePercent(reference, snapshot)
+		if ok && math.Abs(changePercent) >= thresholdPercent {
+			referenceTime, _ := time.Parse(time.RFC3339, reference.Time)
+			change = &Change{
+				PoolLpPublicKey: poolLpPublicKey,
+				Ticker:          pool.Ticker,
+				From:            reference,
+				To:              snapshot,
+				ChangePercent:   changePercent,
+				Period:          now.Sub(referenceTime),
+			}
+			pool.LastAlertAt = snapshot.Time
+		}
+	}
+
+	if err := saveLiquidityUnlocked(data); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// tvlChangePercent compares TVL in BTC (TVL in USD if pool has no BTC TVL)
+func tvlChangePercent(from Snapshot, to Snapshot) (float64, bool) {
+	if from.TvlBTC > 0 && to.TvlBTC > 0 {
+		return (to.TvlBTC - from.TvlBTC) / from.TvlBTC * 100, true
+	}
+	if from.TvlUsd > 0 && to.TvlUsd > 0 {
+		return (to.TvlUsd - from.TvlUsd) / from.TvlUsd * 100, true
+	}
+	return 0, false
+}
+
+func alertedWithin(lastAlertAt string, now time.Time, window time.Duration) bool {
+	if lastAlertAt == "" {
+		return false
+	}
+	alertTime, err := time.Parse(time.RFC3339, lastAlertAt)
+	if err != nil {
+		return false
+	}
+	return now.Sub(alertTime) < window
+}
+
+func loadLiquidityUnlocked() (*LiquidityData, error) {
+	if _, err := os.Stat(LiquidityFile()); os.IsNotExist(err) {
+		return &LiquidityData{Pools: make(map[string]*PoolLiquidity)}, nil
+	}
+
+	raw, err := os.ReadFile(LiquidityFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read liquidity file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &LiquidityData{Pools: make(map[string]*PoolLiquidity)}, nil
+	}
+
+	var data LiquidityData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse liquidity JSON: %w", err)
+	}
+	if data.Pools == nil {
+		data.Pools = make(map[string]*PoolLiquidity)
+	}
+	return &data, nil
+}
+
+func saveLiquidityUnlocked(data *LiquidityData) error {
+	if err := os.MkdirAll(filepath.Dir(LiquidityFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal liquidity JSON: %w", err)
+	}
+
+	tempFilePath := LiquidityFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary liquidity file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, LiquidityFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to liquidity file: %w", err)
+	}
+	return nil
+}
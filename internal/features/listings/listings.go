@@ -0,0 +1,119 @@
+package listings
+
+// New pool listings (data_out/telegram_out/known_pools.json)
+// Pools seen in Luminex tokens-with-pools are remembered, pool not seen before is a new listing
+// First run only remembers current pools, so existing pools are not reported as new
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/infra/paths"
+)
+
+// KnownPoolsFile - pools already seen by listing monitor
+func KnownPoolsFile() string {
+	return paths.Output("telegram_out", "known_pools.json")
+}
+
+// KnownPoolsData - file structure for known_pools.json
+type KnownPoolsData struct {
+	Initialized bool              `json:"initialized"`
+	Pools       map[string]string `json:"pools"` // poolLpPublicKey -> first seen time (RFC3339)
+}
+
+// Listing - newly detected pool
+type Listing struct {
+	Token luminex.ListedToken
+	Pool  luminex.ListedPool
+}
+
+var knownPoolsMutex sync.Mutex
+
+// DetectNewPools remembers pools of tokens and returns pools not seen before
+// Returns nothing on first run (all pools are remembered as known)
+func DetectNewPools(tokens []luminex.ListedToken) ([]Listing, error) {
+	knownPoolsMutex.Lock()
+	defer knownPoolsMutex.Unlock()
+
+	data, err := loadKnownPoolsUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	var newListings []Listing
+	for _, token := range tokens {
+		for _, pool := range token.Pools {
+			if pool.LpPublicKey == "" {
+				continue
+			}
+			if _, known := data.Pools[pool.LpPublicKey]; known {
+				continue
+			}
+			data.Pools[pool.LpPublicKey] = now
+			if data.Initialized {
+				newListings = append(newListings, Listing{Token: token, Pool: pool})
+			}
+		}
+	}
+
+	if !data.Initialized || len(newListings) > 0 {
+		data.Initialized = true
+		if err := saveKnownPoolsUnlocked(data); err != nil {
+			return nil, err
+		}
+	}
+	return newListings, nil
+}
+
+func loadKnownPoolsUnlocked() (*KnownPoolsData, error) {
+	if _, err := os.Stat(KnownPoolsFile()); os.IsNotExist(err) {
+		return &KnownPoolsData{Pools: make(map[string]string)}, nil
+	}
+
+	raw, err := os.ReadFile(KnownPoolsFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read known pools file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &KnownPoolsData{Pools: make(map[string]string)}, nil
+	}
+
+	var data KnownPoolsData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse known pools JSON: %w", err)
+	}
+	if data.Pools == nil {
+		data.Pools = make(map[string]string)
+	}
+	return &data, nil
+}
+
+func saveKnownPoolsUnlocked(data *KnownPoolsData) error {
+	if err := os.MkdirAll(filepath.Dir(KnownPoolsFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal known pools JSON: %w", err)
+	}
+
+	tempFilePath := KnownPoolsFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary known pools file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, KnownPoolsFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to known pools file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package metrics_export
+
+// Time-series export of stats and flows for long-term dashboards (app.metrics_sink)
+// Every run collects current values and pushes them to InfluxDB (line protocol) or Prometheus remote-write:
+//   spark_tvl_usd, spark_volume_24h_usd, spark_market_cap_usd, spark_tokens, spark_pools - Luminex stats
+//   spark_token_buy_btc, spark_token_sell_btc, spark_token_net_flow_btc, spark_token_buys, spark_token_sells
+//     - flows of tracked pools (filtered_tokens.json) of current UTC day so far, labels ticker and pool
+//   spark_token_holders - saved holders of tracked tickers, label ticker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/digest"
+	"spark-wallet/internal/features/holders"
+	storage "spark-wallet/internal/infra/fs"
+)
+
+// Sample - value of metric with labels at time
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+	Time   time.Time
+}
+
+// Collect returns samples of stats, token flows and holders at now
+// A failed source doesn't stop the others, its error is returned together with samples of the rest
+func Collect(now time.Time) ([]Sample, error) {
+	var samples []Sample
+	var errs []error
+
+	stats, err := collectStats(now)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	samples = append(samples, stats...)
+
+	flows, err := collectFlows(now)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	samples = append(samples, flows...)
+
+	holderCounts, err := collectHolders(now)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	samples = append(samples, holderCounts...)
+
+	return samples, errors.Join(errs...)
+}
+
+func collectStats(now time.Time) ([]Sample, error) {
+	stats, err := luminex.GetStats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Luminex stats: %w", err)
+	}
+	return []Sample{
+		{Name: "spark_tvl_usd", Value: stats.TotalTVLUSD, Time: now},
+		{Name: "spark_volume_24h_usd", Value: stats.TotalVolume24HUSD, Time: now},
+		{Name: "spark_market_cap_usd", Value: stats.TotalMarketCapUSD, Time: now},
+		{Name: "spark_tokens", Value: float64(stats.TotalTokens), Time: now},
+		{Name: "spark_pools", Value: float64(stats.TotalPools), Time: now},
+	}, nil
+}
+
+// collectFlows returns volumes of tracked pools of current UTC day, pool without ticker is labeled by key only
+func collectFlows(now time.Time) ([]Sample, error) {
+	pools, err := storage.LoadFilteredTokens()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load filtered tokens: %w", err)
+	}
+
+	var samples []Sample
+	for _, poolLpPublicKey := range pools {
+		volumes, err := digest.GetPoolVolumes(poolLpPublicKey, now, now, now)
+		if err != nil {
+			return samples, fmt.Errorf("failed to get volumes of pool %s: %w", poolLpPublicKey, err)
+		}
+		if len(volumes) == 0 {
+			continue
+		}
+		volume := volumes[0]
+
+		labels := map[string]string{"pool": poolLpPublicKey}
+		if metadata := luminex.GetTokenMetadata(poolLpPublicKey); metadata != nil && metadata.Ticker != "" {
+			labels["ticker"] = metadata.Ticker
+		}
+		samples = append(samples,
+			Sample{Name: "spark_token_buy_btc", Labels: labels, Value: volume.BuyBTC, Time: now},
+			Sample{Name: "spark_token_sell_btc", Labels: labels, Value: volume.SellBTC, Time: now},
+			Sample{Name: "spark_token_net_flow_btc", Labels: labels, Value: volume.NetFlowBTC(), Time: now},
+			Sample{Name: "spark_token_buys", Labels: labels, Value: float64(volume.Buys), Time: now},
+			Sample{Name: "spark_token_sells", Labels: labels, Value: float64(volume.Sells), Time: now},
+		)
+	}
+	return samples, nil
+}
+
+func collectHolders(now time.Time) ([]Sample, error) {
+	var samples []Sample
+	for _, ticker := range holders.GetAllowedTickers() {
+		saved, err := holders.LoadSavedHolders(ticker)
+		if err != nil {
+			return samples, fmt.Errorf("failed to load holders of %s: %w", ticker, err)
+		}
+		samples = append(samples, Sample{
+			Name:   "spark_token_holders",
+			Labels: map[string]string{"ticker": ticker},
+			Value:  float64(len(saved.Holders)),
+			Time:   now,
+		})
+	}
+	return samples, nil
+}
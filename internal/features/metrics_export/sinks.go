@@ -0,0 +1,205 @@
+package metrics_export
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sink types (app.metrics_sink)
+const (
+	SinkInflux     = "influx"
+	SinkPrometheus = "prometheus"
+)
+
+const pushTimeout = 30 * time.Second
+
+// Sink - time-series storage samples are pushed to
+type Sink interface {
+	Push(ctx context.Context, samples []Sample) error
+}
+
+// NewSink returns sink of kind writing to url
+// influx - line protocol POST to write endpoint (/api/v2/write?org=..&bucket=.. or /write?db=..), token as "Token"
+// prometheus - remote-write to receive endpoint (/api/v1/write), token as "Bearer"
+func NewSink(kind string, url string, token string) (Sink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("metrics sink URL is empty")
+	}
+	client := &http.Client{Timeout: pushTimeout}
+	switch kind {
+	case SinkInflux:
+		return &influxSink{url: url, token: token, client: client}, nil
+	case SinkPrometheus:
+		return &remoteWriteSink{url: url, token: token, client: client}, nil
+	}
+	return nil, fmt.Errorf("unknown metrics sink %q, expected %s or %s", kind, SinkInflux, SinkPrometheus)
+}
+
+type influxSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// Push writes samples as lines "name,label=value value=1.5 <unix ns>"
+func (s *influxSink) Push(ctx context.Context, samples []Sample) error {
+	var body bytes.Buffer
+	for _, sample := range samples {
+		body.WriteString(escapeInflux(sample.Name, ", "))
+		for _, name := range sortedLabelNames(sample.Labels) {
+			// Line protocol has no empty tag values
+			if sample.Labels[name] == "" {
+				continue
+			}
+			body.WriteByte(',')
+			body.WriteString(escapeInflux(name, ",= "))
+			body.WriteByte('=')
+			body.WriteString(escapeInflux(sample.Labels[name], ",= "))
+		}
+		body.WriteString(" value=")
+		body.WriteString(strconv.FormatFloat(sample.Value, 'f', -1, 64))
+		body.WriteByte(' ')
+		body.WriteString(strconv.FormatInt(sample.Time.UnixNano(), 10))
+		body.WriteByte('\n')
+	}
+
+	headers := map[string]string{"Content-Type": "text/plain; charset=utf-8"}
+	if s.token != "" {
+		headers["Authorization"] = "Token " + s.token
+	}
+	return post(ctx, s.client, s.url, body.Bytes(), headers)
+}
+
+func escapeInflux(value string, special string) string {
+	var escaped strings.Builder
+	for _, r := range value {
+		if r == '\\' || strings.ContainsRune(special, r) {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
+
+type remoteWriteSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// Push sends samples as snappy-compressed protobuf WriteRequest, one series per sample
+func (s *remoteWriteSink) Push(ctx context.Context, samples []Sample) error {
+	headers := map[string]string{
+		"Content-Type":                      "application/x-protobuf",
+		"Content-Encoding":                  "snappy",
+		"X-Prometheus-Remote-Write-Version": "0.1.0",
+	}
+	if s.token != "" {
+		headers["Authorization"] = "Bearer " + s.token
+	}
+	return post(ctx, s.client, s.url, snappyEncode(encodeWriteRequest(samples)), headers)
+}
+
+// encodeWriteRequest encodes prometheus.WriteRequest:
+// WriteRequest{1: repeated TimeSeries}, TimeSeries{1: repeated Label, 2: repeated Sample},
+// Label{1: name, 2: value}, Sample{1: double value, 2: int64 timestamp ms}
+func encodeWriteRequest(samples []Sample) []byte {
+	var request []byte
+	for _, sample := range samples {
+		var series []byte
+		// __name__ sorts before lowercase label names
+		series = appendMessage(series, 1, appendLabel(nil, "__name__", sample.Name))
+		for _, name := range sortedLabelNames(sample.Labels) {
+			if sample.Labels[name] == "" {
+				continue
+			}
+			series = appendMessage(series, 1, appendLabel(nil, name, sample.Labels[name]))
+		}
+
+		var point []byte
+		point = binary.AppendUvarint(point, 1<<3|1) // fixed64
+		point = binary.LittleEndian.AppendUint64(point, math.Float64bits(sample.Value))
+		point = binary.AppendUvarint(point, 2<<3) // varint
+		point = binary.AppendUvarint(point, uint64(sample.Time.UnixMilli()))
+		series = appendMessage(series, 2, point)
+
+		request = appendMessage(request, 1, series)
+	}
+	return request
+}
+
+func appendLabel(b []byte, name string, value string) []byte {
+	b = appendMessage(b, 1, []byte(name))
+	return appendMessage(b, 2, []byte(value))
+}
+
+// appendMessage appends length-delimited field (message, string or bytes)
+func appendMessage(b []byte, field int, value []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|2)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+// snappyBlockSize - max length of one literal of snappy block
+const snappyBlockSize = 1 << 16
+
+// snappyEncode returns snappy block of data made of literals only
+// Data is not compressed, but any snappy decoder reads it, so no compression dependency is needed
+// for a request of a few kilobytes
+func snappyEncode(data []byte) []byte {
+	encoded := binary.AppendUvarint(nil, uint64(len(data)))
+	for len(data) > 0 {
+		chunk := data[:min(len(data), snappyBlockSize)]
+		data = data[len(chunk):]
+
+		// Literal tag: length-1 in upper 6 bits up to 59, otherwise in 2 following bytes (tag 61)
+		n := len(chunk) - 1
+		if n < 60 {
+			encoded = append(encoded, byte(n<<2))
+		} else {
+			encoded = append(encoded, 61<<2, byte(n), byte(n>>8))
+		}
+		encoded = append(encoded, chunk...)
+	}
+	return encoded
+}
+
+func sortedLabelNames(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func post(ctx context.Context, client *http.Client, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create metrics request: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("metrics sink returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package pnl
+
+// Per-wallet PnL for one token (average cost method)
+// Cost basis - BTC spent in buy swaps, realized - BTC received in sell swaps minus cost of sold tokens
+// Unrealized - current value of remaining tokens (pool price) minus their cost
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/hot_token"
+	storage "spark-wallet/internal/infra/fs"
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// userSwapsPageLimit - page size of /swaps/user (API max 100)
+	userSwapsPageLimit = 100
+	// maxUserSwapsPages - pages loaded per wallet+pool
+	maxUserSwapsPages = 20
+)
+
+// WalletPnL - PnL of wallet in one token
+type WalletPnL struct {
+	WalletPublicKey string
+	PoolLpPublicKey string
+	Ticker          string
+
+	BuyCount  int
+	SellCount int
+
+	BoughtTokens float64 // tokens received in buy swaps
+	SoldTokens   float64 // tokens sent in sell swaps
+	SpentBTC     float64 // BTC spent in buy swaps
+	ReceivedBTC  float64 // BTC received in sell swaps
+
+	PositionTokens   float64 // tokens left from swaps (transfers are not counted)
+	CostBasisBTC     float64 // cost of remaining tokens
+	AvgBuyPriceBTC   float64 // average buy price per token
+	RealizedPnLBTC   float64
+	MarketPriceBTC   float64 // current token price in BTC (0 if unknown)
+	MarketPriceUSD   float64 // current token price in USD (0 if unknown)
+	MarketValueBTC   float64
+	MarketValueUSD   float64
+	UnrealizedPnLBTC float64
+}
+
+// ResolveWalletBySuffix finds full wallet public key by its ending
+// Wallets are taken from local table of seen swappers (usernames.json)
+func ResolveWalletBySuffix(suffix string) (string, error) {
+	suffix = strings.ToLower(strings.TrimSpace(suffix))
+	if suffix == "" {
+		return "", fmt.Errorf("wallet suffix cannot be empty")
+	}
+
+	table, err := storage.LoadUsernames()
+	if err != nil {
+		return "", fmt.Errorf("failed to load wallets: %w", err)
+	}
+
+	var matches []string
+	for pubkey := range table.Wallets {
+		if strings.HasSuffix(strings.ToLower(pubkey), suffix) {
+			matches = append(matches, pubkey)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("wallet ending with %s not found", suffix)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%d wallets end with %s, use longer suffix", len(matches), suffix)
+	}
+}
+
+// CalculateWalletPnL loads wallet swaps in pool and calculates PnL
+func CalculateWalletPnL(client *flashnet.Client, walletPublicKey string, poolLpPublicKey string) (*WalletPnL, error) {
+	if client == nil {
+		return nil, fmt.Errorf("flashnet client is nil")
+	}
+
+	swaps, err := loadWalletPoolSwaps(client, walletPublicKey, poolLpPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(swaps) == 0 {
+		return nil, fmt.Errorf("no swaps found for wallet in pool")
+	}
+
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool data: %w", err)
+	}
+
+	tokenMeta, _, _ := poolData.TokenSide()
+	decimals := tokenMeta.Decimals
+	if decimals == 0 {
+		decimals = 8
+	}
+	tokenDivider := math.Pow10(decimals)
+
+	result := &WalletPnL{
+		WalletPublicKey: walletPublicKey,
+		PoolLpPublicKey: poolLpPublicKey,
+		Ticker:          tokenMeta.Ticker,
+	}
+
+	// Swaps are sorted by time (oldest first)
+	for _, swap := range swaps {
+		if err := result.AddSwap(swap, tokenDivider); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.PositionTokens > 0 {
+		result.AvgBuyPriceBTC = result.CostBasisBTC / result.PositionTokens
+	}
+
+	result.MarketPriceUSD = tokenMeta.AggPriceUsd
+	result.MarketPriceBTC = tokenMeta.AggPriceBtc
+	result.MarketValueUSD = result.PositionTokens * result.MarketPriceUSD
+	result.MarketValueBTC = result.PositionTokens * result.MarketPriceBTC
+	if result.MarketPriceBTC > 0 {
+		result.UnrealizedPnLBTC = result.MarketValueBTC - result.CostBasisBTC
+	}
+
+	return result, nil
+}
+
+// AddSwap applies buy or sell swap to PnL, swaps must be added oldest first
+// tokenDivider - 10^decimals of token
+// Swap with invalid amount is not applied
+func (p *WalletPnL) AddSwap(swap flashnet.Swap, tokenDivider float64) error {
+	swapType := swap.GetSwapType()
+	if swapType != flashnet.SwapTypeBuy && swapType != flashnet.SwapTypeSell {
+		return nil
+	}
+
+	sats, tokens := swap.AmountIn, swap.AmountOut
+	if swapType == flashnet.SwapTypeSell {
+		sats, tokens = swap.AmountOut, swap.AmountIn
+	}
+	btcAmount, err := amount.SatsToBTCFloat(sats)
+	if err != nil {
+		return fmt.Errorf("failed to parse BTC amount of swap %s: %w", swap.ID, err)
+	}
+	rawTokens, err := amount.ParseFloat(tokens)
+	if err != nil {
+		return fmt.Errorf("failed to parse token amount of swap %s: %w", swap.ID, err)
+	}
+	tokenAmount := rawTokens / tokenDivider
+
+	switch swapType {
+	case flashnet.SwapTypeBuy:
+		p.BuyCount++
+		p.SpentBTC += btcAmount
+		p.BoughtTokens += tokenAmount
+		p.PositionTokens += tokenAmount
+		p.CostBasisBTC += btcAmount
+	case flashnet.SwapTypeSell:
+		p.SellCount++
+		p.ReceivedBTC += btcAmount
+		p.SoldTokens += tokenAmount
+
+		// Tokens received outside of swaps have no cost basis
+		soldWithCost := math.Min(tokenAmount, p.PositionTokens)
+		var soldCost float64
+		if p.PositionTokens > 0 {
+			soldCost = p.CostBasisBTC * soldWithCost / p.PositionTokens
+		}
+		p.RealizedPnLBTC += btcAmount - soldCost
+		p.CostBasisBTC -= soldCost
+		p.PositionTokens -= soldWithCost
+	}
+	return nil
+}
+
+// GeneratePnLReport builds /pnl {ticker} {wallet-suffix} report
+func GeneratePnLReport(client *flashnet.Client, ticker string, walletSuffix string) (string, error) {
+	poolLpPublicKey, err := storage.FindPoolLpPublicKeyByTicker(ticker)
+	if err != nil {
+		return "", fmt.Errorf("ticker {%s} not found", strings.ToUpper(ticker))
+	}
+
+	walletPublicKey, err := ResolveWalletBySuffix(walletSuffix)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := CalculateWalletPnL(client, walletPublicKey, poolLpPublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	displayTicker := result.Ticker
+	if displayTicker == "" {
+		displayTicker = strings.ToUpper(ticker)
+	}
+
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("<b>PnL</b>: {%s} - ...%s\n", displayTicker, shortWallet(walletPublicKey)))
+	report.WriteString("<blockquote>")
+	report.WriteString(fmt.Sprintf("Buys: %d - %s btc\n", result.BuyCount, amount.FormatBTC(result.SpentBTC)))
+	report.WriteString(fmt.Sprintf("Sells: %d - %s btc\n", result.SellCount, amount.FormatBTC(result.ReceivedBTC)))
+	report.WriteString(fmt.Sprintf("Position: %s (cost %s btc)\n", formatTokens(result.PositionTokens), amount.FormatBTC(result.CostBasisBTC)))
+	if result.AvgBuyPriceBTC > 0 {
+		report.WriteString(fmt.Sprintf("Avg buy: %s sats\n", formatSats(result.AvgBuyPriceBTC)))
+	}
+	if result.MarketValueUSD > 0 {
+		report.WriteString(fmt.Sprintf("Value: %s\n", luminex.FormatUSDValue(result.MarketValueUSD)))
+	}
+	report.WriteString(fmt.Sprintf("Realized: %s btc\n", amount.FormatSignedBTC(result.RealizedPnLBTC)))
+	if result.MarketPriceBTC > 0 {
+		report.WriteString(fmt.Sprintf("Unrealized: %s btc", amount.FormatSignedBTC(result.UnrealizedPnLBTC)))
+	} else {
+		report.WriteString("Unrealized: null")
+	}
+	report.WriteString("</blockquote>")
+
+	return report.String(), nil
+}
+
+// loadWalletPoolSwaps loads all wallet swaps in pool (oldest first)
+func loadWalletPoolSwaps(client *flashnet.Client, walletPublicKey string, poolLpPublicKey string) ([]flashnet.Swap, error) {
+	ctx := context.Background()
+
+	var swaps []flashnet.Swap
+	for page := 0; page < maxUserSwapsPages; page++ {
+		resp, err := client.GetUserSwaps(ctx, walletPublicKey, flashnet.GetUserSwapsOptions{
+			PoolLpPubkey: poolLpPublicKey,
+			Sort:         "timestampAsc",
+			Limit:        userSwapsPageLimit,
+			Offset:       page * userSwapsPageLimit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user swaps: %w", err)
+		}
+
+		for _, swap := range resp.Swaps {
+			if swap.PoolLpPublicKey == poolLpPublicKey {
+				swaps = append(swaps, swap)
+			}
+		}
+
+		if len(resp.Swaps) < userSwapsPageLimit {
+			return swaps, nil
+		}
+	}
+
+	logging.LogWarn("User swaps limit reached, PnL may be incomplete",
+		zap.String("wallet", walletPublicKey),
+		zap.String("poolLpPublicKey", poolLpPublicKey),
+		zap.Int("swaps", len(swaps)))
+	return swaps, nil
+}
+
+func shortWallet(pubkey string) string {
+	if len(pubkey) <= 6 {
+		return pubkey
+	}
+	return pubkey[len(pubkey)-6:]
+}
+
+func formatSats(btcValue float64) string {
+	formatted := fmt.Sprintf("%.4f", btcValue*1e8)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimRight(formatted, ".")
+}
+
+func formatTokens(value float64) string {
+	if value >= 1_000_000 {
+		return fmt.Sprintf("%.2fM", value/1_000_000)
+	}
+	if value >= 1_000 {
+		return fmt.Sprintf("%.2fK", value/1_000)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
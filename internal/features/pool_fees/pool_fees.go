@@ -0,0 +1,289 @@
+package pool_fees
+
+// Pool fee rates and 24h LP fee revenue sampling (data_out/telegram_out/pool_fees.json)
+// Source - Luminex pool details (lpFeeBps, hostFeeBps, TVL, 24h volume)
+// Flashnet client has no pool details endpoint yet, switch source once it is added
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// maxSamplesPerPool - samples kept per pool (7 days of hourly samples)
+	maxSamplesPerPool = 7 * 24
+	// aprWindow - samples window used for APR estimate
+	aprWindow = 7 * 24 * time.Hour
+)
+
+// PoolFeesFile - fee samples per pool
+func PoolFeesFile() string {
+	return paths.Output("telegram_out", "pool_fees.json")
+}
+
+// FeeSample - one sample of pool fee parameters and revenue
+type FeeSample struct {
+	Time             string  `json:"time"` // RFC3339
+	LpFeeBps         int     `json:"lp_fee_bps"`
+	HostFeeBps       int     `json:"host_fee_bps"`
+	TvlUsd           float64 `json:"tvl_usd"`
+	Volume24hUsd     float64 `json:"volume_24h_usd"`
+	FeeRevenue24hUsd float64 `json:"fee_revenue_24h_usd"` // LP part of fees for last 24h
+}
+
+// PoolFees - fee history of one pool
+type PoolFees struct {
+	PoolLpPublicKey string      `json:"pool_lp_public_key"`
+	Ticker          string      `json:"ticker"`
+	Samples         []FeeSample `json:"samples"`
+}
+
+// PoolFeesData - file structure for pool_fees.json
+type PoolFeesData struct {
+	Pools map[string]*PoolFees `json:"pools"` // poolLpPublicKey -> history
+}
+
+// FeeChange - fee parameters change between two samples
+type FeeChange struct {
+	PoolLpPublicKey string
+	Ticker          string
+	OldLpFeeBps     int
+	NewLpFeeBps     int
+	OldHostFeeBps   int
+	NewHostFeeBps   int
+}
+
+// APREstimate - LP APR estimate for pool
+type APREstimate struct {
+	PoolLpPublicKey     string
+	Ticker              string
+	LpFeeBps            int
+	HostFeeBps          int
+	TvlUsd              float64
+	Volume24hUsd        float64
+	FeeRevenue24hUsd    float64
+	AvgFeeRevenue24hUsd float64 // average over samples window
+	CurrentAPR          float64 // percent, from last 24h revenue
+	AvgAPR              float64 // percent, from average daily revenue
+	SamplesCount        int
+	WindowDays          float64
+}
+
+var poolFeesMutex sync.Mutex
+
+// LoadPoolFees loads fee samples
+// Returns empty data if file doesn't exist
+func LoadPoolFees() (*PoolFeesData, error) {
+	poolFeesMutex.Lock()
+	defer poolFeesMutex.Unlock()
+	return loadPoolFeesUnlocked()
+}
+
+// SamplePool fetches pool details and saves new fee sample
+// Returns fee change if fee parameters differ from previous sample (nil otherwise)
+// Only fees monitor samples pools: window of maxSamplesPerPool and APR average assume hourly samples
+func SamplePool(poolLpPublicKey string) (*FeeChange, error) {
+	sample, ticker, err := fetchSample(poolLpPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	poolFeesMutex.Lock()
+	defer poolFeesMutex.Unlock()
+
+	data, err := loadPoolFeesUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	pool, exists := data.Pools[poolLpPublicKey]
+	if !exists {
+		pool = &PoolFees{PoolLpPublicKey: poolLpPublicKey}
+		data.Pools[poolLpPublicKey] = pool
+	}
+	if ticker != "" {
+		pool.Ticker = ticker
+	}
+
+	var change *FeeChange
+	if len(pool.Samples) > 0 {
+		last := pool.Samples[len(pool.Samples)-1]
+		if last.LpFeeBps != sample.LpFeeBps || last.HostFeeBps != sample.HostFeeBps {
+			change = &FeeChange{
+				PoolLpPublicKey: poolLpPublicKey,
+				Ticker:          pool.Ticker,
+				OldLpFeeBps:     last.LpFeeBps,
+				NewLpFeeBps:     sample.LpFeeBps,
+				OldHostFeeBps:   last.HostFeeBps,
+				NewHostFeeBps:   sample.HostFeeBps,
+			}
+		}
+	}
+
+	pool.Samples = append(pool.Samples, sample)
+	if len(pool.Samples) > maxSamplesPerPool {
+		pool.Samples = pool.Samples[len(pool.Samples)-maxSamplesPerPool:]
+	}
+
+	if err := savePoolFeesUnlocked(data); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// EstimateAPR estimates LP APR from saved samples
+// APR = daily LP fee revenue * 365 / TVL
+func EstimateAPR(poolLpPublicKey string) (*APREstimate, error) {
+	data, err := LoadPoolFees()
+	if err != nil {
+		return nil, err
+	}
+
+	pool, exists := data.Pools[poolLpPublicKey]
+	if !exists || len(pool.Samples) == 0 {
+		return nil, fmt.Errorf("no fee samples for pool %s", poolLpPublicKey)
+	}
+
+	estimate := newAPREstimate(poolLpPublicKey, pool.Ticker, pool.Samples[len(pool.Samples)-1])
+	addAverageAPR(estimate, pool.Samples)
+	return estimate, nil
+}
+
+// EstimateCurrentAPR estimates LP APR from live pool details, average comes from saved samples
+// Live details are not saved: a pool without samples gets average of current revenue
+func EstimateCurrentAPR(poolLpPublicKey string) (*APREstimate, error) {
+	sample, ticker, err := fetchSample(poolLpPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := LoadPoolFees()
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := newAPREstimate(poolLpPublicKey, ticker, sample)
+	if pool, exists := data.Pools[poolLpPublicKey]; exists {
+		addAverageAPR(estimate, pool.Samples)
+	}
+	if estimate.SamplesCount == 0 {
+		estimate.AvgFeeRevenue24hUsd = estimate.FeeRevenue24hUsd
+		estimate.AvgAPR = estimate.CurrentAPR
+	}
+	return estimate, nil
+}
+
+// fetchSample fetches pool details as fee sample with ticker of token side
+func fetchSample(poolLpPublicKey string) (FeeSample, string, error) {
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		return FeeSample{}, "", fmt.Errorf("failed to get pool details: %w", err)
+	}
+
+	sample := FeeSample{
+		Time:             time.Now().UTC().Format(time.RFC3339),
+		LpFeeBps:         poolData.LpFeeBps,
+		HostFeeBps:       poolData.HostFeeBps,
+		TvlUsd:           poolData.Extra.PoolTvlUsd,
+		Volume24hUsd:     poolData.Extra.Volume24hUsd,
+		FeeRevenue24hUsd: poolData.Extra.Volume24hUsd * float64(poolData.LpFeeBps) / 10000,
+	}
+
+	tokenMeta, _, _ := poolData.TokenSide()
+	return sample, tokenMeta.Ticker, nil
+}
+
+// newAPREstimate returns estimate with current APR of sample
+func newAPREstimate(poolLpPublicKey string, ticker string, sample FeeSample) *APREstimate {
+	estimate := &APREstimate{
+		PoolLpPublicKey:  poolLpPublicKey,
+		Ticker:           ticker,
+		LpFeeBps:         sample.LpFeeBps,
+		HostFeeBps:       sample.HostFeeBps,
+		TvlUsd:           sample.TvlUsd,
+		Volume24hUsd:     sample.Volume24hUsd,
+		FeeRevenue24hUsd: sample.FeeRevenue24hUsd,
+	}
+	if estimate.TvlUsd > 0 {
+		estimate.CurrentAPR = estimate.FeeRevenue24hUsd * 365 / estimate.TvlUsd * 100
+	}
+	return estimate
+}
+
+// addAverageAPR sets average revenue and APR of samples within aprWindow (APR at TVL of estimate)
+func addAverageAPR(estimate *APREstimate, samples []FeeSample) {
+	cutoff := time.Now().Add(-aprWindow)
+	var revenueSum float64
+	var firstTime time.Time
+	for _, sample := range samples {
+		sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+		if err != nil || sampleTime.Before(cutoff) {
+			continue
+		}
+		if firstTime.IsZero() {
+			firstTime = sampleTime
+		}
+		revenueSum += sample.FeeRevenue24hUsd
+		estimate.SamplesCount++
+	}
+
+	if estimate.SamplesCount > 0 {
+		estimate.AvgFeeRevenue24hUsd = revenueSum / float64(estimate.SamplesCount)
+		estimate.WindowDays = time.Since(firstTime).Hours() / 24
+	}
+	if estimate.TvlUsd > 0 {
+		estimate.AvgAPR = estimate.AvgFeeRevenue24hUsd * 365 / estimate.TvlUsd * 100
+	}
+}
+
+func loadPoolFeesUnlocked() (*PoolFeesData, error) {
+	if _, err := os.Stat(PoolFeesFile()); os.IsNotExist(err) {
+		return &PoolFeesData{Pools: make(map[string]*PoolFees)}, nil
+	}
+
+	raw, err := os.ReadFile(PoolFeesFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pool fees file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &PoolFeesData{Pools: make(map[string]*PoolFees)}, nil
+	}
+
+	var data PoolFeesData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse pool fees JSON: %w", err)
+	}
+	if data.Pools == nil {
+		data.Pools = make(map[string]*PoolFees)
+	}
+	return &data, nil
+}
+
+func savePoolFeesUnlocked(data *PoolFeesData) error {
+	if err := os.MkdirAll(filepath.Dir(PoolFeesFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pool fees JSON: %w", err)
+	}
+
+	tempFilePath := PoolFeesFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary pool fees file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, PoolFeesFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to pool fees file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package portfolio
+
+// Portfolio of own wallet (PUBLIC_KEY) in data_out/telegram_out/portfolio.json
+// Balances come from Luminex address API, token values are converted to BTC at current BTC price
+// Samples of last 8 days give 24h and 7d change, daily values (last sample of day) feed /portfolio chart
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/luminex"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// sampleRetention - samples kept for 24h and 7d change
+	sampleRetention = 8 * 24 * time.Hour
+	// maxDailyValues - days kept for chart
+	maxDailyValues = 365
+	// BTCTicker - ticker of BTC holding
+	BTCTicker = "BTC"
+)
+
+// PortfolioFile - snapshots of own wallet
+func PortfolioFile() string {
+	return paths.Output("telegram_out", "portfolio.json")
+}
+
+// Holding - one asset of wallet
+type Holding struct {
+	Ticker       string  `json:"ticker"`
+	Name         string  `json:"name,omitempty"`
+	TokenAddress string  `json:"token_address,omitempty"` // empty for BTC
+	Amount       float64 `json:"amount"`                  // tokens (decimals applied), BTC for BTC
+	ValueBTC     float64 `json:"value_btc"`
+	ValueUsd     float64 `json:"value_usd"`
+}
+
+// Snapshot - wallet holdings at time
+type Snapshot struct {
+	Time        string    `json:"time"` // RFC3339
+	BTCPriceUsd float64   `json:"btc_price_usd"`
+	ValueBTC    float64   `json:"value_btc"`
+	ValueUsd    float64   `json:"value_usd"`
+	Holdings    []Holding `json:"holdings"` // largest value first
+}
+
+// ValuePoint - total value of wallet at time
+type ValuePoint struct {
+	Time     string  `json:"time"` // RFC3339 (daily values: time of last sample of day)
+	ValueBTC float64 `json:"value_btc"`
+	ValueUsd float64 `json:"value_usd"`
+}
+
+// PortfolioData - file structure for portfolio.json
+type PortfolioData struct {
+	PublicKey string       `json:"public_key"`
+	Latest    *Snapshot    `json:"latest,omitempty"`
+	Samples   []ValuePoint `json:"samples"` // last 8 days, oldest first
+	Daily     []ValuePoint `json:"daily"`   // one per UTC day, oldest first
+}
+
+// Change - value change over period, Known=false if history is shorter than period
+type Change struct {
+	Percent float64
+	Known   bool
+}
+
+var portfolioMutex sync.Mutex
+
+// BuildSnapshot converts Luminex wallet balance to snapshot
+// btcPriceUsd - BTC price for token values, 0 - price implied by Luminex BTC balance value
+func BuildSnapshot(balance *luminex.WalletBalanceResponse, btcPriceUsd float64, now time.Time) (Snapshot, error) {
+	if balance == nil {
+		return Snapshot{}, fmt.Errorf("wallet balance is empty")
+	}
+	sats := balance.Balance.BtcHardBalanceSats
+	if btcPriceUsd <= 0 && sats > 0 && balance.Balance.BtcValueUsdHard > 0 {
+		btcPriceUsd = balance.Balance.BtcValueUsdHard / (float64(sats) / 1e8)
+	}
+	if btcPriceUsd <= 0 {
+		return Snapshot{}, fmt.Errorf("BTC price is unknown")
+	}
+
+	snapshot := Snapshot{Time: now.UTC().Format(time.RFC3339), BTCPriceUsd: btcPriceUsd}
+	if sats > 0 {
+		btc := float64(sats) / 1e8
+		snapshot.Holdings = append(snapshot.Holdings, Holding{
+			Ticker:   BTCTicker,
+			Name:     "Bitcoin",
+			Amount:   btc,
+			ValueBTC: btc,
+			ValueUsd: btc * btcPriceUsd,
+		})
+	}
+	for _, token := range balance.Tokens {
+		tokens, err := amount.ScaleFloat(token.Balance, token.Decimals)
+		if err != nil || tokens <= 0 {
+			continue
+		}
+		address := token.TokenAddress
+		if address == "" {
+			address = token.TokenIdentifier
+		}
+		snapshot.Holdings = append(snapshot.Holdings, Holding{
+			Ticker:       token.Ticker,
+			Name:         token.Name,
+			TokenAddress: address,
+			Amount:       tokens,
+			ValueBTC:     token.ValueUsd / btcPriceUsd,
+			ValueUsd:     token.ValueUsd,
+		})
+	}
+
+	sort.SliceStable(snapshot.Holdings, func(i, j int) bool {
+		return snapshot.Holdings[i].ValueBTC > snapshot.Holdings[j].ValueBTC
+	})
+	for _, holding := range snapshot.Holdings {
+		snapshot.ValueBTC += holding.ValueBTC
+		snapshot.ValueUsd += holding.ValueUsd
+	}
+	return snapshot, nil
+}
+
+// RecordSnapshot saves snapshot of wallet as latest, as sample and as value of its day
+// History of another wallet (PUBLIC_KEY changed) is dropped
+func RecordSnapshot(publicKey string, snapshot Snapshot) error {
+	snapshotTime, err := time.Parse(time.RFC3339, snapshot.Time)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot time: %w", err)
+	}
+
+	portfolioMutex.Lock()
+	defer portfolioMutex.Unlock()
+
+	data, err := loadPortfolioUnlocked()
+	if err != nil {
+		return err
+	}
+	if data.PublicKey != publicKey {
+		data = &PortfolioData{PublicKey: publicKey}
+	}
+
+	point := ValuePoint{Time: snapshot.Time, ValueBTC: snapshot.ValueBTC, ValueUsd: snapshot.ValueUsd}
+	data.Latest = &snapshot
+
+	since := snapshotTime.Add(-sampleRetention)
+	samples := data.Samples[:0]
+	for _, sample := range data.Samples {
+		if sampleTime, err := time.Parse(time.RFC3339, sample.Time); err == nil && sampleTime.After(since) {
+			samples = append(samples, sample)
+		}
+	}
+	data.Samples = append(samples, point)
+
+	day := snapshotTime.UTC().Format("2006-01-02")
+	if n := len(data.Daily); n > 0 && len(data.Daily[n-1].Time) >= 10 && data.Daily[n-1].Time[:10] == day {
+		data.Daily[n-1] = point
+	} else {
+		data.Daily = append(data.Daily, point)
+	}
+	if len(data.Daily) > maxDailyValues {
+		data.Daily = data.Daily[len(data.Daily)-maxDailyValues:]
+	}
+
+	if err := storage.WriteJSONAtomic(PortfolioFile(), data); err != nil {
+		return fmt.Errorf("failed to write portfolio file: %w", err)
+	}
+	return nil
+}
+
+// Load returns saved portfolio (empty if nothing was recorded yet)
+func Load() (*PortfolioData, error) {
+	portfolioMutex.Lock()
+	defer portfolioMutex.Unlock()
+	return loadPortfolioUnlocked()
+}
+
+// ChangeOver returns change of BTC value of latest snapshot against sample taken period ago
+// Sample closest to (latest - period) within 25% of period is used
+func (d *PortfolioData) ChangeOver(period time.Duration) Change {
+	if d.Latest == nil || d.Latest.ValueBTC <= 0 {
+		return Change{}
+	}
+	latestTime, err := time.Parse(time.RFC3339, d.Latest.Time)
+	if err != nil {
+		return Change{}
+	}
+	target := latestTime.Add(-period)
+	tolerance := period / 4
+
+	var best *ValuePoint
+	var bestDistance time.Duration
+	for i := range d.Samples {
+		sampleTime, err := time.Parse(time.RFC3339, d.Samples[i].Time)
+		if err != nil {
+			continue
+		}
+		distance := sampleTime.Sub(target)
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance <= tolerance && (best == nil || distance < bestDistance) {
+			best, bestDistance = &d.Samples[i], distance
+		}
+	}
+	if best == nil || best.ValueBTC <= 0 {
+		return Change{}
+	}
+	return Change{Percent: (d.Latest.ValueBTC - best.ValueBTC) / best.ValueBTC * 100, Known: true}
+}
+
+// DailySince returns daily values from date of since (oldest first)
+func (d *PortfolioData) DailySince(since time.Time) []ValuePoint {
+	day := since.UTC().Format("2006-01-02")
+	var points []ValuePoint
+	for _, point := range d.Daily {
+		if len(point.Time) >= 10 && point.Time[:10] >= day {
+			points = append(points, point)
+		}
+	}
+	return points
+}
+
+func loadPortfolioUnlocked() (*PortfolioData, error) {
+	raw, err := os.ReadFile(PortfolioFile())
+	if os.IsNotExist(err) {
+		return &PortfolioData{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read portfolio file: %w", err)
+	}
+	if len(raw) == 0 {
+		return &PortfolioData{}, nil
+	}
+
+	var data PortfolioData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse portfolio JSON: %w", err)
+	}
+	return &data, nil
+}
@@ -0,0 +1,291 @@
+package price_alerts
+
+// Price alert subscriptions (/alert {ticker} {above|below} {price_usd})
+// Subscriptions are stored per user in data_out/telegram_out/price_alerts.json
+// One-shot subscription is removed after it triggers, recurring one is re-armed
+// when price goes back to the other side of the target
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// MaxSubscriptionsPerUser - limit of active subscriptions of one user
+	MaxSubscriptionsPerUser = 20
+
+	// ConditionAbove - price >= target
+	ConditionAbove = "above"
+	// ConditionBelow - price <= target
+	ConditionBelow = "below"
+)
+
+// PriceAlertsFile - price alert subscriptions
+func PriceAlertsFile() string {
+	return paths.Output("telegram_out", "price_alerts.json")
+}
+
+// Subscription - price alert of one user
+type Subscription struct {
+	ID              int     `json:"id"`
+	BotID           int64   `json:"bot_id"` // bot that received /alert (sends notification)
+	ChatID          int64   `json:"chat_id"`
+	UserID          int64   `json:"user_id"`
+	Username        string  `json:"username"`
+	Ticker          string  `json:"ticker"`
+	PoolLpPublicKey string  `json:"pool_lp_public_key"`
+	Condition       string  `json:"condition"` // above or below
+	PriceUSD        float64 `json:"price_usd"`
+	Recurring       bool    `json:"recurring"`
+	Armed           bool    `json:"armed"` // recurring alert waits for price to cross target again if false
+	CreatedAt       string  `json:"created_at"`
+	LastTriggeredAt string  `json:"last_triggered_at,omitempty"`
+}
+
+// Matches checks subscription condition against price
+func (s Subscription) Matches(priceUSD float64) bool {
+	if priceUSD <= 0 {
+		return false
+	}
+	if s.Condition == ConditionBelow {
+		return priceUSD <= s.PriceUSD
+	}
+	return priceUSD >= s.PriceUSD
+}
+
+// PriceAlertsData - file structure for price_alerts.json
+type PriceAlertsData struct {
+	NextID        int            `json:"next_id"`
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+// Trigger - subscription whose condition is met
+type Trigger struct {
+	Subscription Subscription
+	PriceUSD     float64
+}
+
+var priceAlertsMutex sync.Mutex
+
+// ParseCondition normalizes condition (above/below, also >/<)
+func ParseCondition(condition string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(condition)) {
+	case ConditionAbove, ">", ">=":
+		return ConditionAbove, nil
+	case ConditionBelow, "<", "<=":
+		return ConditionBelow, nil
+	}
+	return "", fmt.Errorf("condition must be above or below")
+}
+
+// AddSubscription saves new subscription and returns it with assigned ID
+func AddSubscription(sub Subscription) (Subscription, error) {
+	if sub.PriceUSD <= 0 {
+		return sub, fmt.Errorf("price must be positive")
+	}
+
+	priceAlertsMutex.Lock()
+	defer priceAlertsMutex.Unlock()
+
+	data, err := loadPriceAlertsUnlocked()
+	if err != nil {
+		return sub, err
+	}
+
+	if len(userSubscriptions(data, sub.UserID)) >= MaxSubscriptionsPerUser {
+		return sub, fmt.Errorf("limit of %d alerts reached", MaxSubscriptionsPerUser)
+	}
+
+	data.NextID++
+	sub.ID = data.NextID
+	sub.Armed = true
+	sub.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	data.Subscriptions = append(data.Subscriptions, sub)
+
+	if err := savePriceAlertsUnlocked(data); err != nil {
+		return sub, err
+	}
+	return sub, nil
+}
+
+// RemoveSubscription removes subscription of user
+// Returns false if user has no subscription with this ID
+func RemoveSubscription(userID int64, id int) (bool, error) {
+	priceAlertsMutex.Lock()
+	defer priceAlertsMutex.Unlock()
+
+	data, err := loadPriceAlertsUnlocked()
+	if err != nil {
+		return false, err
+	}
+
+	for i, sub := range data.Subscriptions {
+		if sub.ID == id && sub.UserID == userID {
+			data.Subscriptions = append(data.Subscriptions[:i], data.Subscriptions[i+1:]...)
+			return true, savePriceAlertsUnlocked(data)
+		}
+	}
+	return false, nil
+}
+
+// ListSubscriptions returns subscriptions of user
+func ListSubscriptions(userID int64) ([]Subscription, error) {
+	priceAlertsMutex.Lock()
+	defer priceAlertsMutex.Unlock()
+
+	data, err := loadPriceAlertsUnlocked()
+	if err != nil {
+		return nil, err
+	}
+	return userSubscriptions(data, userID), nil
+}
+
+// CountSubscribers returns count of users with price alert subscriptions on token
+func CountSubscribers(poolLpPublicKey string) (int, error) {
+	priceAlertsMutex.Lock()
+	defer priceAlertsMutex.Unlock()
+
+	data, err := loadPriceAlertsUnlocked()
+	if err != nil {
+		return 0, err
+	}
+
+	users := make(map[int64]bool)
+	for _, sub := range data.Subscriptions {
+		if sub.PoolLpPublicKey == poolLpPublicKey {
+			users[sub.UserID] = true
+		}
+	}
+	return len(users), nil
+}
+
+// CheckSubscriptions evaluates all subscriptions against current prices
+// priceUSD - returns current token price of subscription pool (0 if unknown), called once per pool
+// Triggered one-shot subscriptions are removed, recurring ones are disarmed until price crosses back
+func CheckSubscriptions(priceUSD func(sub Subscription) float64) ([]Trigger, error) {
+	priceAlertsMutex.Lock()
+	snapshot, err := loadPriceAlertsUnlocked()
+	priceAlertsMutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshot.Subscriptions) == 0 {
+		return nil, nil
+	}
+
+	// Prices are fetched without lock - /alert commands are not blocked by API requests
+	prices := make(map[string]float64)
+	for _, sub := range snapshot.Subscriptions {
+		if _, exists := prices[sub.PoolLpPublicKey]; !exists {
+			prices[sub.PoolLpPublicKey] = priceUSD(sub)
+		}
+	}
+
+	priceAlertsMutex.Lock()
+	defer priceAlertsMutex.Unlock()
+
+	// Reload - subscriptions may have been changed while prices were fetched
+	data, err := loadPriceAlertsUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	var triggers []Trigger
+	changed := false
+	kept := data.Subscriptions[:0]
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	for _, sub := range data.Subscriptions {
+		price := prices[sub.PoolLpPublicKey]
+		if price <= 0 {
+			kept = append(kept, sub)
+			continue
+		}
+
+		matches := sub.Matches(price)
+		switch {
+		case matches && sub.Armed:
+			triggers = append(triggers, Trigger{Subscription: sub, PriceUSD: price})
+			changed = true
+			if !sub.Recurring {
+				continue // one-shot - removed
+			}
+			sub.Armed = false
+			sub.LastTriggeredAt = now
+		case !matches && !sub.Armed:
+			// Price went back - recurring alert may trigger again
+			sub.Armed = true
+			changed = true
+		}
+		kept = append(kept, sub)
+	}
+
+	if !changed {
+		return triggers, nil
+	}
+	data.Subscriptions = kept
+	if err := savePriceAlertsUnlocked(data); err != nil {
+		return triggers, err
+	}
+	return triggers, nil
+}
+
+func userSubscriptions(data *PriceAlertsData, userID int64) []Subscription {
+	var subs []Subscription
+	for _, sub := range data.Subscriptions {
+		if sub.UserID == userID {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+func loadPriceAlertsUnlocked() (*PriceAlertsData, error) {
+	if _, err := os.Stat(PriceAlertsFile()); os.IsNotExist(err) {
+		return &PriceAlertsData{}, nil
+	}
+
+	raw, err := os.ReadFile(PriceAlertsFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read price alerts file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &PriceAlertsData{}, nil
+	}
+
+	var data PriceAlertsData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse price alerts JSON: %w", err)
+	}
+	return &data, nil
+}
+
+func savePriceAlertsUnlocked(data *PriceAlertsData) error {
+	if err := os.MkdirAll(filepath.Dir(PriceAlertsFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal price alerts JSON: %w", err)
+	}
+
+	tempFilePath := PriceAlertsFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary price alerts file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, PriceAlertsFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to price alerts file: %w", err)
+	}
+	return nil
+}
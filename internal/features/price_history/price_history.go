@@ -0,0 +1,264 @@
+package price_history
+
+// Token price history (data_out/telegram_out/token_prices.json) and risk figures from it
+// Source - Luminex pool details (token side agg price, marketcap, 24h volume), sampled hourly for tracked pools
+// Volatility - standard deviation of price returns scaled to one day, max drawdown - largest peak-to-trough drop
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/infra/atomicfile"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// maxSamplesPerPool - samples kept per pool (30 days of hourly samples)
+	maxSamplesPerPool = 30 * 24
+	// minStatsSamples - samples needed for volatility and drawdown
+	minStatsSamples = 3
+)
+
+// PriceHistoryFile - price samples per pool
+func PriceHistoryFile() string {
+	return paths.Output("telegram_out", "token_prices.json")
+}
+
+// PriceSample - one sample of token price
+type PriceSample struct {
+	Time         string  `json:"time"` // RFC3339
+	PriceUsd     float64 `json:"price_usd"`
+	PriceBtc     float64 `json:"price_btc"`
+	MarketcapUsd float64 `json:"marketcap_usd"`
+	Volume24hUsd float64 `json:"volume_24h_usd"`
+}
+
+// TokenPrices - price history of one pool
+type TokenPrices struct {
+	PoolLpPublicKey string        `json:"pool_lp_public_key"`
+	Ticker          string        `json:"ticker"`
+	Samples         []PriceSample `json:"samples"`
+}
+
+// PriceHistoryData - file structure for token_prices.json
+type PriceHistoryData struct {
+	Pools map[string]*TokenPrices `json:"pools"` // poolLpPublicKey -> history
+}
+
+// RiskStats - price risk figures of token over window
+type RiskStats struct {
+	Samples        int
+	Period         time.Duration // time between first and last sample
+	ChangePercent  float64       // price change over period
+	VolatilityPct  float64       // daily volatility, percent
+	MaxDrawdownPct float64       // largest drop from previous high, percent (positive)
+	InBTC          bool          // figures are calculated from BTC price
+}
+
+var priceHistoryMutex sync.Mutex
+
+// LoadPriceHistory loads price samples
+// Returns empty data if file doesn't exist
+func LoadPriceHistory() (*PriceHistoryData, error) {
+	priceHistoryMutex.Lock()
+	defer priceHistoryMutex.Unlock()
+	return loadPriceHistoryUnlocked()
+}
+
+// SamplePool fetches pool details and saves new price sample
+func SamplePool(poolLpPublicKey string) (*PriceSample, error) {
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool details: %w", err)
+	}
+
+	tokenMeta, _, _ := poolData.TokenSide()
+	if tokenMeta.AggPriceUsd <= 0 && tokenMeta.AggPriceBtc <= 0 {
+		return nil, fmt.Errorf("pool %s has no token price", poolLpPublicKey)
+	}
+
+	sample := PriceSample{
+		Time:         time.Now().UTC().Format(time.RFC3339),
+		PriceUsd:     tokenMeta.AggPriceUsd,
+		PriceBtc:     tokenMeta.AggPriceBtc,
+		MarketcapUsd: tokenMeta.AggMarketcapUsd,
+		Volume24hUsd: poolData.Extra.Volume24hUsd,
+	}
+
+	priceHistoryMutex.Lock()
+	defer priceHistoryMutex.Unlock()
+
+	data, err := loadPriceHistoryUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	pool, exists := data.Pools[poolLpPublicKey]
+	if !exists {
+		pool = &TokenPrices{PoolLpPublicKey: poolLpPublicKey}
+		data.Pools[poolLpPublicKey] = pool
+	}
+	if tokenMeta.Ticker != "" {
+		pool.Ticker = tokenMeta.Ticker
+	}
+
+	pool.Samples = append(pool.Samples, sample)
+	if len(pool.Samples) > maxSamplesPerPool {
+		pool.Samples = pool.Samples[len(pool.Samples)-maxSamplesPerPool:]
+	}
+
+	if err := savePriceHistoryUnlocked(data); err != nil {
+		return nil, err
+	}
+	return &sample, nil
+}
+
+// SamplesSince returns price samples of pool taken after since (oldest first)
+func SamplesSince(poolLpPublicKey string, since time.Time) ([]PriceSample, error) {
+	data, err := LoadPriceHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	pool, exists := data.Pools[poolLpPublicKey]
+	if !exists {
+		return nil, nil
+	}
+
+	var samples []PriceSample
+	for _, sample := range pool.Samples {
+		sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+		if err != nil || sampleTime.Before(since) {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// Stats calculates risk figures of pool over last window
+// Returns nil if there are not enough samples
+func Stats(poolLpPublicKey string, window time.Duration) (*RiskStats, error) {
+	samples, err := SamplesSince(poolLpPublicKey, time.Now().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+	return ComputeRiskStats(samples), nil
+}
+
+// ComputeRiskStats calculates volatility and max drawdown of samples (oldest first)
+// BTC price is used if all samples have it (token risk without BTC moves), USD price otherwise
+// Returns nil if there are fewer than minStatsSamples valid samples
+func ComputeRiskStats(samples []PriceSample) *RiskStats {
+	inBTC := true
+	for _, sample := range samples {
+		if sample.PriceBtc <= 0 {
+			inBTC = false
+			break
+		}
+	}
+
+	type point struct {
+		time  time.Time
+		price float64
+	}
+	var points []point
+	for _, sample := range samples {
+		price := sample.PriceUsd
+		if inBTC {
+			price = sample.PriceBtc
+		}
+		sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+		if err != nil || price <= 0 {
+			continue
+		}
+		points = append(points, point{time: sampleTime, price: price})
+	}
+	if len(points) < minStatsSamples {
+		return nil
+	}
+
+	first, last := points[0], points[len(points)-1]
+	stats := &RiskStats{
+		Samples:       len(points),
+		Period:        last.time.Sub(first.time),
+		ChangePercent: (last.price - first.price) / first.price * 100,
+		InBTC:         inBTC,
+	}
+
+	// Log returns between samples
+	returns := make([]float64, 0, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		returns = append(returns, math.Log(points[i].price/points[i-1].price))
+	}
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns))
+
+	// Scale per-sample deviation to one day by average sample interval
+	if stats.Period > 0 {
+		interval := stats.Period / time.Duration(len(returns))
+		samplesPerDay := float64(24*time.Hour) / float64(interval)
+		stats.VolatilityPct = math.Sqrt(variance*samplesPerDay) * 100
+	}
+
+	peak := points[0].price
+	for _, p := range points {
+		if p.price > peak {
+			peak = p.price
+		}
+		if drawdown := (peak - p.price) / peak * 100; drawdown > stats.MaxDrawdownPct {
+			stats.MaxDrawdownPct = drawdown
+		}
+	}
+
+	return stats
+}
+
+func loadPriceHistoryUnlocked() (*PriceHistoryData, error) {
+	if _, err := os.Stat(PriceHistoryFile()); os.IsNotExist(err) {
+		return &PriceHistoryData{Pools: make(map[string]*TokenPrices)}, nil
+	}
+
+	raw, err := os.ReadFile(PriceHistoryFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read price history file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &PriceHistoryData{Pools: make(map[string]*TokenPrices)}, nil
+	}
+
+	var data PriceHistoryData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse price history JSON: %w", err)
+	}
+	if data.Pools == nil {
+		data.Pools = make(map[string]*TokenPrices)
+	}
+	return &data, nil
+}
+
+func savePriceHistoryUnlocked(data *PriceHistoryData) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal price history JSON: %w", err)
+	}
+
+	if err := atomicfile.Write(PriceHistoryFile(), raw, 0644); err != nil {
+		return fmt.Errorf("failed to write price history file: %w", err)
+	}
+	return nil
+}
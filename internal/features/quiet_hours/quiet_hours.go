@@ -0,0 +1,415 @@
+package quiet_hours
+
+// Quiet hours and token mutes per chat (data_out/telegram_out/quiet_hours.json)
+// Quiet hours - daily window (e.g. 01:00-08:00 MSK) when alerts of chat are held and delivered
+// as one summary after window ends, instead of being dropped or sent at night
+// Mute - alerts of one token are dropped in chat until mute expires (/mute {ticker} {duration})
+// Windows come from config (telegram.quiet_hours) and can be changed in chat with /quiet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/infra/scheduler"
+)
+
+const (
+	// MaxMuteDuration - longest mute of token
+	MaxMuteDuration = 30 * 24 * time.Hour
+	// MaxHeldAlerts - alerts kept per chat during quiet hours, later ones are only counted
+	MaxHeldAlerts = 200
+
+	// windowOff - /quiet off, disables configured window of chat
+	windowOff = "off"
+)
+
+// DefaultLocation - timezone of windows set with /quiet and of config windows without timezone
+var DefaultLocation = scheduler.LoadLocation("Europe/Moscow")
+
+// QuietHoursFile - quiet hours, mutes and held alerts per chat
+func QuietHoursFile() string {
+	return paths.Output("telegram_out", "quiet_hours.json")
+}
+
+// Window - daily period from Start to End (minutes after midnight in Location), can cross midnight
+type Window struct {
+	Start    int
+	End      int
+	Location *time.Location
+}
+
+// ParseWindow parses "HH:MM-HH:MM" in location (nil - DefaultLocation)
+func ParseWindow(value string, location *time.Location) (Window, error) {
+	if location == nil {
+		location = DefaultLocation
+	}
+	from, to, found := strings.Cut(strings.ReplaceAll(strings.TrimSpace(value), "–", "-"), "-")
+	if !found {
+		return Window{}, fmt.Errorf("invalid quiet hours %q, expected HH:MM-HH:MM", value)
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid quiet hours start %q: %w", from, err)
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid quiet hours end %q: %w", to, err)
+	}
+	window := Window{
+		Start:    start.Hour()*60 + start.Minute(),
+		End:      end.Hour()*60 + end.Minute(),
+		Location: location,
+	}
+	if window.Start == window.End {
+		return Window{}, fmt.Errorf("quiet hours %q are empty", value)
+	}
+	return window, nil
+}
+
+// Contains checks if t is within window
+func (w Window) Contains(t time.Time) bool {
+	local := t.In(w.Location)
+	minute := local.Hour()*60 + local.Minute()
+	if w.Start < w.End {
+		return minute >= w.Start && minute < w.End
+	}
+	// Crosses midnight (23:00-07:00)
+	return minute >= w.Start || minute < w.End
+}
+
+// String formats window as "HH:MM-HH:MM"
+func (w Window) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.Start/60, w.Start%60, w.End/60, w.End%60)
+}
+
+// Mute - alerts of token are dropped in chat until Until
+type Mute struct {
+	PoolLpPublicKey string `json:"pool_lp_public_key"`
+	Ticker          string `json:"ticker"`
+	Until           string `json:"until"` // RFC3339
+}
+
+// HeldAlert - alert held during quiet hours
+type HeldAlert struct {
+	Time            string `json:"time"` // RFC3339
+	Kind            string `json:"kind"` // event log kind or swap route
+	PoolLpPublicKey string `json:"pool_lp_public_key,omitempty"`
+	Ticker          string `json:"ticker,omitempty"`
+	Text            string `json:"text"`   // first line of alert, plain text
+	BotID           int64  `json:"bot_id"` // bot that would have sent alert (sends summary)
+}
+
+// ChatState - quiet hours settings and held alerts of one chat
+type ChatState struct {
+	QuietHours string           `json:"quiet_hours,omitempty"` // set with /quiet, overrides config ("off" - disabled)
+	Mutes      map[string]*Mute `json:"mutes,omitempty"`       // poolLpPublicKey -> mute
+	Held       []HeldAlert      `json:"held,omitempty"`
+	Dropped    int              `json:"dropped,omitempty"` // alerts held over MaxHeldAlerts
+}
+
+// QuietHoursData - file structure for quiet_hours.json
+type QuietHoursData struct {
+	Chats map[int64]*ChatState `json:"chats"`
+}
+
+// Decision - what happens with alert of chat
+type Decision int
+
+const (
+	// Deliver - alert is sent now
+	Deliver Decision = iota
+	// Muted - token is muted in chat, alert is dropped
+	Muted
+	// Held - chat is in quiet hours, alert is kept for summary
+	Held
+)
+
+// Summary - alerts held in chat during quiet hours
+type Summary struct {
+	ChatID  int64
+	BotID   int64
+	Alerts  []HeldAlert
+	Dropped int
+}
+
+var (
+	quietHoursMutex sync.Mutex
+
+	configuredWindows = make(map[int64]Window)
+)
+
+// Configure sets quiet hours of chats from config (chat ID -> window)
+func Configure(windows map[int64]Window) {
+	quietHoursMutex.Lock()
+	defer quietHoursMutex.Unlock()
+	configuredWindows = windows
+}
+
+// Check decides if alert of chat is sent now, held alert is saved to chat buffer
+// Mute is checked first, so muted token is not in quiet hours summary either
+func Check(chatID int64, alert HeldAlert, now time.Time) (Decision, error) {
+	quietHoursMutex.Lock()
+	defer quietHoursMutex.Unlock()
+
+	data, err := loadQuietHoursUnlocked()
+	if err != nil {
+		return Deliver, err
+	}
+	chat := data.Chats[chatID]
+
+	if chat != nil && alert.PoolLpPublicKey != "" {
+		if mute, exists := chat.Mutes[alert.PoolLpPublicKey]; exists && mutedAt(mute, now) {
+			return Muted, nil
+		}
+	}
+
+	window, enabled := windowOfUnlocked(chatID, chat)
+	if !enabled || !window.Contains(now) {
+		return Deliver, nil
+	}
+
+	if chat == nil {
+		chat = &ChatState{}
+		data.Chats[chatID] = chat
+	}
+	if len(chat.Held) >= MaxHeldAlerts {
+		chat.Dropped++
+	} else {
+		alert.Time = now.UTC().Format(time.RFC3339)
+		chat.Held = append(chat.Held, alert)
+	}
+	if err := storage.WriteJSONAtomic(QuietHoursFile(), data); err != nil {
+		return Deliver, fmt.Errorf("failed to save quiet hours: %w", err)
+	}
+	return Held, nil
+}
+
+// TakeSummaries removes held alerts of chats whose quiet hours are over at now and returns them
+func TakeSummaries(now time.Time) ([]Summary, error) {
+	quietHoursMutex.Lock()
+	defer quietHoursMutex.Unlock()
+
+	data, err := loadQuietHoursUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []Summary
+	for chatID, chat := range data.Chats {
+		if len(chat.Held) == 0 && chat.Dropped == 0 {
+			continue
+		}
+		if window, enabled := windowOfUnlocked(chatID, chat); enabled && window.Contains(now) {
+			continue
+		}
+		summary := Summary{ChatID: chatID, Alerts: chat.Held, Dropped: chat.Dropped}
+		if len(chat.Held) > 0 {
+			summary.BotID = chat.Held[len(chat.Held)-1].BotID
+		}
+		summaries = append(summaries, summary)
+		chat.Held = nil
+		chat.Dropped = 0
+	}
+	if len(summaries) == 0 {
+		return nil, nil
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].ChatID < summaries[j].ChatID })
+
+	if err := storage.WriteJSONAtomic(QuietHoursFile(), data); err != nil {
+		return nil, fmt.Errorf("failed to save quiet hours: %w", err)
+	}
+	return summaries, nil
+}
+
+// WindowOf returns quiet hours of chat, enabled is false if chat has none
+func WindowOf(chatID int64) (window Window, enabled bool, err error) {
+	quietHoursMutex.Lock()
+	defer quietHoursMutex.Unlock()
+
+	data, err := loadQuietHoursUnlocked()
+	if err != nil {
+		return Window{}, false, err
+	}
+	window, enabled = windowOfUnlocked(chatID, data.Chats[chatID])
+	return window, enabled, nil
+}
+
+// SetWindow sets quiet hours of chat ("HH:MM-HH:MM" in DefaultLocation), "off" disables them
+func SetWindow(chatID int64, value string) error {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value != windowOff {
+		window, err := ParseWindow(value, DefaultLocation)
+		if err != nil {
+			return err
+		}
+		value = window.String()
+	}
+
+	quietHoursMutex.Lock()
+	defer quietHoursMutex.Unlock()
+
+	data, err := loadQuietHoursUnlocked()
+	if err != nil {
+		return err
+	}
+	chat := data.Chats[chatID]
+	if chat == nil {
+		chat = &ChatState{}
+		data.Chats[chatID] = chat
+	}
+	chat.QuietHours = value
+
+	if err := storage.WriteJSONAtomic(QuietHoursFile(), data); err != nil {
+		return fmt.Errorf("failed to save quiet hours: %w", err)
+	}
+	return nil
+}
+
+// MuteToken drops alerts of pool in chat until until
+func MuteToken(chatID int64, poolLpPublicKey string, ticker string, until time.Time) error {
+	quietHoursMutex.Lock()
+	defer quietHoursMutex.Unlock()
+
+	data, err := loadQuietHoursUnlocked()
+	if err != nil {
+		return err
+	}
+	chat := data.Chats[chatID]
+	if chat == nil {
+		chat = &ChatState{}
+		data.Chats[chatID] = chat
+	}
+	if chat.Mutes == nil {
+		chat.Mutes = make(map[string]*Mute)
+	}
+	for pool, mute := range chat.Mutes {
+		if !mutedAt(mute, time.Now()) {
+			delete(chat.Mutes, pool)
+		}
+	}
+	chat.Mutes[poolLpPublicKey] = &Mute{
+		PoolLpPublicKey: poolLpPublicKey,
+		Ticker:          ticker,
+		Until:           until.UTC().Format(time.RFC3339),
+	}
+
+	if err := storage.WriteJSONAtomic(QuietHoursFile(), data); err != nil {
+		return fmt.Errorf("failed to save quiet hours: %w", err)
+	}
+	return nil
+}
+
+// UnmuteToken removes mute of pool in chat, returns false if token was not muted
+func UnmuteToken(chatID int64, poolLpPublicKey string) (bool, error) {
+	quietHoursMutex.Lock()
+	defer quietHoursMutex.Unlock()
+
+	data, err := loadQuietHoursUnlocked()
+	if err != nil {
+		return false, err
+	}
+	chat := data.Chats[chatID]
+	if chat == nil || chat.Mutes[poolLpPublicKey] == nil {
+		return false, nil
+	}
+	delete(chat.Mutes, poolLpPublicKey)
+
+	if err := storage.WriteJSONAtomic(QuietHoursFile(), data); err != nil {
+		return false, fmt.Errorf("failed to save quiet hours: %w", err)
+	}
+	return true, nil
+}
+
+// ActiveMutes returns mutes of chat that have not expired at now (soonest expiry first)
+func ActiveMutes(chatID int64, now time.Time) ([]Mute, error) {
+	quietHoursMutex.Lock()
+	defer quietHoursMutex.Unlock()
+
+	data, err := loadQuietHoursUnlocked()
+	if err != nil {
+		return nil, err
+	}
+	chat := data.Chats[chatID]
+	if chat == nil {
+		return nil, nil
+	}
+
+	var mutes []Mute
+	for _, mute := range chat.Mutes {
+		if mutedAt(mute, now) {
+			mutes = append(mutes, *mute)
+		}
+	}
+	sort.Slice(mutes, func(i, j int) bool { return mutes[i].Until < mutes[j].Until })
+	return mutes, nil
+}
+
+// ParseMuteDuration parses mute duration: 30m, 2h, 1d or Go duration (1h30m), up to MaxMuteDuration
+func ParseMuteDuration(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	var duration time.Duration
+	if days, found := strings.CutSuffix(value, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid mute duration %q", value)
+		}
+		duration = time.Duration(count) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid mute duration %q", value)
+		}
+		duration = parsed
+	}
+	if duration < time.Minute {
+		return 0, fmt.Errorf("mute duration %q is shorter than a minute", value)
+	}
+	if duration > MaxMuteDuration {
+		return 0, fmt.Errorf("mute duration %q is longer than %d days", value, int(MaxMuteDuration.Hours()/24))
+	}
+	return duration, nil
+}
+
+// windowOfUnlocked returns window of chat: /quiet setting first, then config
+func windowOfUnlocked(chatID int64, chat *ChatState) (Window, bool) {
+	if chat != nil && chat.QuietHours != "" {
+		if chat.QuietHours == windowOff {
+			return Window{}, false
+		}
+		if window, err := ParseWindow(chat.QuietHours, DefaultLocation); err == nil {
+			return window, true
+		}
+	}
+	window, exists := configuredWindows[chatID]
+	return window, exists
+}
+
+func mutedAt(mute *Mute, now time.Time) bool {
+	until, err := time.Parse(time.RFC3339, mute.Until)
+	return err == nil && now.Before(until)
+}
+
+func loadQuietHoursUnlocked() (*QuietHoursData, error) {
+	data := &QuietHoursData{}
+	raw, err := os.ReadFile(QuietHoursFile())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read quiet hours file: %w", err)
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, data); err != nil {
+			return nil, fmt.Errorf("failed to parse quiet hours JSON: %w", err)
+		}
+	}
+	if data.Chats == nil {
+		data.Chats = make(map[int64]*ChatState)
+	}
+	return data, nil
+}
@@ -0,0 +1,331 @@
+package reach
+
+// Distribution footprint of token alerts (data_out/telegram_out/reach.json)
+// Every delivered alert is counted per token and chat (big sales, filtered, destinations, rules, watchlist, price alerts)
+// Member counts of chats are sampled separately, so reach of token = members of chats its alerts went to
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// maxReachDays - days of daily delivery counts kept per chat
+	maxReachDays = 30
+)
+
+// ReachFile - alert deliveries per token and chat
+func ReachFile() string {
+	return paths.Output("telegram_out", "reach.json")
+}
+
+// Kinds of alert delivery
+const (
+	KindBigSales    = "big_sales"
+	KindFiltered    = "filtered"
+	KindDestination = "destination"
+	KindRule        = "rule"
+	KindWatchlist   = "watchlist"
+	KindPriceAlert  = "price_alert"
+)
+
+// ChatDeliveries - alerts of token delivered to one chat
+type ChatDeliveries struct {
+	Kind        string         `json:"kind"`
+	Label       string         `json:"label,omitempty"` // destination or rule name
+	Alerts      int            `json:"alerts"`
+	Daily       map[string]int `json:"daily"`         // YYYY-MM-DD (UTC) -> alerts, last maxReachDays days
+	LastAlertAt string         `json:"last_alert_at"` // RFC3339
+}
+
+// TokenDeliveries - deliveries of one token
+type TokenDeliveries struct {
+	PoolLpPublicKey string                     `json:"pool_lp_public_key"`
+	Ticker          string                     `json:"ticker"`
+	Chats           map[string]*ChatDeliveries `json:"chats"` // chat ID -> deliveries
+}
+
+// ChatInfo - sampled chat audience
+type ChatInfo struct {
+	Title     string `json:"title,omitempty"`
+	Members   int    `json:"members"`
+	UpdatedAt string `json:"updated_at"` // RFC3339
+}
+
+// ReachData - file structure for reach.json
+type ReachData struct {
+	Tokens map[string]*TokenDeliveries `json:"tokens"` // poolLpPublicKey -> deliveries
+	Chats  map[string]*ChatInfo        `json:"chats"`  // chat ID -> audience
+}
+
+// ChatReach - reach of token in one chat
+type ChatReach struct {
+	ChatID      string `json:"chat_id"`
+	Kind        string `json:"kind"`
+	Label       string `json:"label,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Members     int    `json:"members"` // 0 - unknown
+	Alerts      int    `json:"alerts"`
+	AlertsWeek  int    `json:"alerts_7d"`
+	LastAlertAt string `json:"last_alert_at"`
+}
+
+// Summary - reach of token over all chats
+type Summary struct {
+	PoolLpPublicKey string      `json:"pool_lp_public_key"`
+	Ticker          string      `json:"ticker"`
+	Chats           []ChatReach `json:"chats"` // most members first
+	Members         int         `json:"members"`
+	Alerts          int         `json:"alerts"`
+	AlertsWeek      int         `json:"alerts_7d"`
+	Subscribers     int         `json:"price_alert_subscribers"`
+}
+
+var reachMutex sync.Mutex
+
+// RecordDelivery counts alert of token delivered to chat
+// label - destination or rule name (empty for other kinds)
+func RecordDelivery(poolLpPublicKey string, ticker string, chatID int64, kind string, label string, now time.Time) error {
+	if poolLpPublicKey == "" || chatID == 0 {
+		return nil
+	}
+	now = now.UTC()
+	date := now.Format("2006-01-02")
+	chatKey := strconv.FormatInt(chatID, 10)
+
+	reachMutex.Lock()
+	defer reachMutex.Unlock()
+
+	data, err := loadReachUnlocked()
+	if err != nil {
+		return err
+	}
+
+	token, exists := data.Tokens[poolLpPublicKey]
+	if !exists {
+		token = &TokenDeliveries{PoolLpPublicKey: poolLpPublicKey, Chats: make(map[string]*ChatDeliveries)}
+		data.Tokens[poolLpPublicKey] = token
+	}
+	if ticker != "" {
+		token.Ticker = strings.ToUpper(ticker)
+	}
+
+	chat, exists := token.Chats[chatKey]
+	if !exists {
+		chat = &ChatDeliveries{Daily: make(map[string]int)}
+		token.Chats[chatKey] = chat
+	}
+	chat.Kind = kind
+	chat.Label = label
+	chat.Alerts++
+	chat.Daily[date]++
+	if lastAlertAt := now.Format(time.RFC3339); lastAlertAt > chat.LastAlertAt {
+		chat.LastAlertAt = lastAlertAt
+	}
+
+	cutoff := now.AddDate(0, 0, -maxReachDays).Format("2006-01-02")
+	for day := range chat.Daily {
+		if day < cutoff {
+			delete(chat.Daily, day)
+		}
+	}
+
+	if _, exists := data.Chats[chatKey]; !exists {
+		data.Chats[chatKey] = &ChatInfo{}
+	}
+
+	return saveReachUnlocked(data)
+}
+
+// ChatIDs returns chats that received alerts
+func ChatIDs() ([]int64, error) {
+	reachMutex.Lock()
+	defer reachMutex.Unlock()
+
+	data, err := loadReachUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	chatIDs := make([]int64, 0, len(data.Chats))
+	for chatKey := range data.Chats {
+		chatID, err := strconv.ParseInt(chatKey, 10, 64)
+		if err != nil {
+			continue
+		}
+		chatIDs = append(chatIDs, chatID)
+	}
+	sort.Slice(chatIDs, func(i, j int) bool { return chatIDs[i] < chatIDs[j] })
+	return chatIDs, nil
+}
+
+// SetChatInfo saves sampled title and member count of chat
+func SetChatInfo(chatID int64, title string, members int, now time.Time) error {
+	reachMutex.Lock()
+	defer reachMutex.Unlock()
+
+	data, err := loadReachUnlocked()
+	if err != nil {
+		return err
+	}
+
+	data.Chats[strconv.FormatInt(chatID, 10)] = &ChatInfo{
+		Title:     title,
+		Members:   members,
+		UpdatedAt: now.UTC().Format(time.RFC3339),
+	}
+	return saveReachUnlocked(data)
+}
+
+// GetSummary returns reach of token (nil if its alerts were never delivered)
+func GetSummary(poolLpPublicKey string, now time.Time) (*Summary, error) {
+	reachMutex.Lock()
+	defer reachMutex.Unlock()
+
+	data, err := loadReachUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	token, exists := data.Tokens[poolLpPublicKey]
+	if !exists {
+		return nil, nil
+	}
+	return summarize(data, token, now), nil
+}
+
+// FindPoolByTicker returns pool of token with delivered alerts by ticker (empty if not found)
+func FindPoolByTicker(ticker string) (string, error) {
+	reachMutex.Lock()
+	defer reachMutex.Unlock()
+
+	data, err := loadReachUnlocked()
+	if err != nil {
+		return "", err
+	}
+
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	for pool, token := range data.Tokens {
+		if token.Ticker == ticker {
+			return pool, nil
+		}
+	}
+	return "", nil
+}
+
+// AllSummaries returns reach of all tokens (widest reach first)
+func AllSummaries(now time.Time) ([]Summary, error) {
+	reachMutex.Lock()
+	defer reachMutex.Unlock()
+
+	data, err := loadReachUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]Summary, 0, len(data.Tokens))
+	for _, token := range data.Tokens {
+		summaries = append(summaries, *summarize(data, token, now))
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Members != summaries[j].Members {
+			return summaries[i].Members > summaries[j].Members
+		}
+		return summaries[i].Alerts > summaries[j].Alerts
+	})
+	return summaries, nil
+}
+
+func summarize(data *ReachData, token *TokenDeliveries, now time.Time) *Summary {
+	weekStart := now.UTC().AddDate(0, 0, -6).Format("2006-01-02")
+
+	summary := &Summary{PoolLpPublicKey: token.PoolLpPublicKey, Ticker: token.Ticker}
+	for chatKey, deliveries := range token.Chats {
+		chat := ChatReach{
+			ChatID:      chatKey,
+			Kind:        deliveries.Kind,
+			Label:       deliveries.Label,
+			Alerts:      deliveries.Alerts,
+			LastAlertAt: deliveries.LastAlertAt,
+		}
+		for day, alerts := range deliveries.Daily {
+			if day >= weekStart {
+				chat.AlertsWeek += alerts
+			}
+		}
+		if info, exists := data.Chats[chatKey]; exists {
+			chat.Title = info.Title
+			chat.Members = info.Members
+		}
+
+		summary.Chats = append(summary.Chats, chat)
+		summary.Members += chat.Members
+		summary.Alerts += chat.Alerts
+		summary.AlertsWeek += chat.AlertsWeek
+	}
+	sort.Slice(summary.Chats, func(i, j int) bool {
+		if summary.Chats[i].Members != summary.Chats[j].Members {
+			return summary.Chats[i].Members > summary.Chats[j].Members
+		}
+		return summary.Chats[i].ChatID < summary.Chats[j].ChatID
+	})
+	return summary
+}
+
+func loadReachUnlocked() (*ReachData, error) {
+	if _, err := os.Stat(ReachFile()); os.IsNotExist(err) {
+		return &ReachData{Tokens: make(map[string]*TokenDeliveries), Chats: make(map[string]*ChatInfo)}, nil
+	}
+
+	raw, err := os.ReadFile(ReachFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reach file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &ReachData{Tokens: make(map[string]*TokenDeliveries), Chats: make(map[string]*ChatInfo)}, nil
+	}
+
+	var data ReachData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse reach JSON: %w", err)
+	}
+	if data.Tokens == nil {
+		data.Tokens = make(map[string]*TokenDeliveries)
+	}
+	if data.Chats == nil {
+		data.Chats = make(map[string]*ChatInfo)
+	}
+	return &data, nil
+}
+
+func saveReachUnlocked(data *ReachData) error {
+	if err := os.MkdirAll(filepath.Dir(ReachFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal reach JSON: %w", err)
+	}
+
+	tempFilePath := ReachFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary reach file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, ReachFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to reach file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package reserves
+
+// Reserve history of tracked pools (data_out/telegram_out/pool_reserves.json) and BTC reserve drain detection
+// Both sides of pool are snapshotted every few minutes, so reserves of one pool can be followed over days
+// (/spark charts BTC reserve of whole Spark, this is per pool)
+// Drain - BTC reserve dropped by threshold or more from its highest snapshot within last DrainWindow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/hot_token"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// HistoryRetention - snapshots older than this are dropped
+	HistoryRetention = 7 * 24 * time.Hour
+	// DrainWindow - period BTC reserve drop is measured over, pool is not reported again within it
+	DrainWindow = time.Hour
+)
+
+// ReservesFile - reserve snapshots per pool
+func ReservesFile() string {
+	return paths.Output("telegram_out", "pool_reserves.json")
+}
+
+// Snapshot - reserves of pool at sample time
+type Snapshot struct {
+	Time         string  `json:"time"`          // RFC3339
+	BtcReserve   float64 `json:"btc_reserve"`   // BTC side, BTC
+	TokenReserve float64 `json:"token_reserve"` // token side, tokens (decimals applied)
+}
+
+// PoolReserves - snapshots of one pool (oldest first)
+type PoolReserves struct {
+	PoolLpPublicKey  string     `json:"pool_lp_public_key"`
+	Ticker           string     `json:"ticker"`
+	Snapshots        []Snapshot `json:"snapshots"`
+	LastDrainAlertAt string     `json:"last_drain_alert_at,omitempty"` // RFC3339
+}
+
+// ReservesData - file structure for pool_reserves.json
+type ReservesData struct {
+	Pools map[string]*PoolReserves `json:"pools"` // poolLpPublicKey -> snapshots
+}
+
+// Drain - drop of BTC reserve within DrainWindow
+type Drain struct {
+	PoolLpPublicKey string
+	Ticker          string
+	From            Snapshot // highest BTC reserve in window
+	To              Snapshot // current snapshot
+	DropPercent     float64  // positive
+	Period          time.Duration
+}
+
+var reservesMutex sync.Mutex
+
+// SamplePool fetches pool reserves, saves snapshot and checks BTC reserve drain
+// drainPercent - drop that is reported (0 - snapshot only)
+// Returns drain if BTC reserve dropped by drainPercent or more within DrainWindow (nil otherwise)
+func SamplePool(poolLpPublicKey string, drainPercent float64) (*Drain, error) {
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool details: %w", err)
+	}
+	if poolData.AssetAAddress != flashnet.NativeTokenAddress && poolData.AssetBAddress != flashnet.NativeTokenAddress {
+		return nil, fmt.Errorf("pool %s is not paired with BTC", poolLpPublicKey)
+	}
+
+	tokenMeta, btcReserve, tokenReserve := poolData.TokenSide()
+	ticker, decimals := tokenMeta.Ticker, tokenMeta.Decimals
+
+	now := time.Now().UTC()
+	snapshot := Snapshot{Time: now.Format(time.RFC3339)}
+	sats, err := strconv.ParseFloat(btcReserve, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BTC reserve %q: %w", btcReserve, err)
+	}
+	snapshot.BtcReserve = sats / 1e8
+	if units, err := strconv.ParseFloat(tokenReserve, 64); err == nil {
+		snapshot.TokenReserve = units / pow10(decimals)
+	}
+
+	return RecordSnapshot(poolLpPublicKey, ticker, snapshot, drainPercent, now)
+}
+
+// RecordSnapshot saves snapshot of pool taken at now and checks BTC reserve drain
+func RecordSnapshot(poolLpPublicKey string, ticker string, snapshot Snapshot, drainPercent float64, now time.Time) (*Drain, error) {
+	reservesMutex.Lock()
+	defer reservesMutex.Unlock()
+
+	data, err := loadReservesUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	pool, exists := data.Pools[poolLpPublicKey]
+	if !exists {
+		pool = &PoolReserves{PoolLpPublicKey: poolLpPublicKey}
+		data.Pools[poolLpPublicKey] = pool
+	}
+	if ticker != "" {
+		pool.Ticker = ticker
+	}
+
+	retentionStart := now.Add(-HistoryRetention)
+	kept := pool.Snapshots[:0]
+	for _, sample := range pool.Snapshots {
+		sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+		if err != nil || sampleTime.Before(retentionStart) {
+			continue
+		}
+		kept = append(kept, sample)
+	}
+	pool.Snapshots = append(kept, snapshot)
+
+	var drain *Drain
+	if drainPercent > 0 && !alertedWithin(pool.LastDrainAlertAt, now, DrainWindow) {
+		drain = findDrain(pool, now, drainPercent)
+		if drain != nil {
+			pool.LastDrainAlertAt = snapshot.Time
+		}
+	}
+
+	if err := storage.WriteJSONAtomic(ReservesFile(), data); err != nil {
+		return nil, fmt.Errorf("failed to save pool reserves: %w", err)
+	}
+	return drain, nil
+}
+
+// History returns reserve snapshots of pool taken after since (oldest first)
+func History(poolLpPublicKey string, since time.Time) ([]Snapshot, error) {
+	reservesMutex.Lock()
+	defer reservesMutex.Unlock()
+
+	data, err := loadReservesUnlocked()
+	if err != nil {
+		return nil, err
+	}
+	pool, exists := data.Pools[poolLpPublicKey]
+	if !exists {
+		return nil, nil
+	}
+
+	var snapshots []Snapshot
+	for _, sample := range pool.Snapshots {
+		sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+		if err != nil || sampleTime.Before(since) {
+			continue
+		}
+		snapshots = append(snapshots, sample)
+	}
+	return snapshots, nil
+}
+
+// findDrain compares last snapshot of pool with highest BTC reserve within DrainWindow
+func findDrain(pool *PoolReserves, now time.Time, drainPercent float64) *Drain {
+	current := pool.Snapshots[len(pool.Snapshots)-1]
+	windowStart := now.Add(-DrainWindow)
+
+	var peak *Snapshot
+	for i := range pool.Snapshots[:len(pool.Snapshots)-1] {
+		sample := &pool.Snapshots[i]
+		sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+		if err != nil || sampleTime.Before(windowStart) {
+			continue
+		}
+		if peak == nil || sample.BtcReserve > peak.BtcReserve {
+			peak = sample
+		}
+	}
+	if peak == nil || peak.BtcReserve <= 0 {
+		return nil
+	}
+
+	dropPercent := (peak.BtcReserve - current.BtcReserve) / peak.BtcReserve * 100
+	if dropPercent < drainPercent {
+		return nil
+	}
+	peakTime, _ := time.Parse(time.RFC3339, peak.Time)
+	return &Drain{
+		PoolLpPublicKey: pool.PoolLpPublicKey,
+		Ticker:          pool.Ticker,
+		From:            *peak,
+		To:              current,
+		DropPercent:     dropPercent,
+		Period:          now.Sub(peakTime),
+	}
+}
+
+func alertedWithin(lastAlertAt string, now time.Time, window time.Duration) bool {
+	if lastAlertAt == "" {
+		return false
+	}
+	alertTime, err := time.Parse(time.RFC3339, lastAlertAt)
+	if err != nil {
+		return false
+	}
+	return now.Sub(alertTime) < window
+}
+
+func pow10(decimals int) float64 {
+	result := 1.0
+	for i := 0; i < decimals; i++ {
+		result *= 10
+	}
+	return result
+}
+
+func loadReservesUnlocked() (*ReservesData, error) {
+	data := &ReservesData{}
+	raw, err := os.ReadFile(ReservesFile())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read pool reserves file: %w", err)
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, data); err != nil {
+			return nil, fmt.Errorf("failed to parse pool reserves JSON: %w", err)
+		}
+	}
+	if data.Pools == nil {
+		data.Pools = make(map[string]*PoolReserves)
+	}
+	return data, nil
+}
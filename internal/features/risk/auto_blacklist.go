@@ -0,0 +1,175 @@
+package risk
+
+// Auto-blacklist (data_out/auto_blacklist.json)
+// Tokens with risk score over threshold are suppressed from big sales feed together with manual blacklist
+// Whitelisted tokens (admin override) are never auto-blacklisted again
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/infra/paths"
+)
+
+// AutoBlacklistFile - auto-blacklisted tokens and admin whitelist
+func AutoBlacklistFile() string {
+	return paths.Output("auto_blacklist.json")
+}
+
+// AutoBlacklistEntry - auto-blacklisted token
+type AutoBlacklistEntry struct {
+	PoolLpPublicKey string   `json:"pool_lp_public_key"`
+	Ticker          string   `json:"ticker"`
+	Score           int      `json:"score"`
+	Reasons         []string `json:"reasons"`
+	AddedAt         string   `json:"added_at"` // RFC3339
+}
+
+// AutoBlacklistData - file structure for auto_blacklist.json
+type AutoBlacklistData struct {
+	Entries   map[string]*AutoBlacklistEntry `json:"entries"`   // poolLpPublicKey -> entry
+	Whitelist []string                       `json:"whitelist"` // poolLpPublicKey overridden by admin
+}
+
+var autoBlacklistMutex sync.Mutex
+
+// LoadAutoBlacklist loads auto-blacklist
+// Returns empty data if file doesn't exist
+func LoadAutoBlacklist() (*AutoBlacklistData, error) {
+	autoBlacklistMutex.Lock()
+	defer autoBlacklistMutex.Unlock()
+	return loadAutoBlacklistUnlocked()
+}
+
+// AutoBlacklistedPools returns poolLpPublicKey of auto-blacklisted tokens
+func AutoBlacklistedPools() ([]string, error) {
+	data, err := LoadAutoBlacklist()
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]string, 0, len(data.Entries))
+	for pool := range data.Entries {
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+// IsKnown returns true if token is already auto-blacklisted or whitelisted
+func (d *AutoBlacklistData) IsKnown(poolLpPublicKey string) bool {
+	if _, exists := d.Entries[poolLpPublicKey]; exists {
+		return true
+	}
+	return d.IsWhitelisted(poolLpPublicKey)
+}
+
+// IsWhitelisted returns true if admin whitelisted token
+func (d *AutoBlacklistData) IsWhitelisted(poolLpPublicKey string) bool {
+	for _, pool := range d.Whitelist {
+		if pool == poolLpPublicKey {
+			return true
+		}
+	}
+	return false
+}
+
+// AddAutoBlacklisted adds assessed token to auto-blacklist
+// Returns false if token is whitelisted or already listed
+func AddAutoBlacklisted(assessment *Assessment) (bool, error) {
+	autoBlacklistMutex.Lock()
+	defer autoBlacklistMutex.Unlock()
+
+	data, err := loadAutoBlacklistUnlocked()
+	if err != nil {
+		return false, err
+	}
+	if data.IsKnown(assessment.PoolLpPublicKey) {
+		return false, nil
+	}
+
+	data.Entries[assessment.PoolLpPublicKey] = &AutoBlacklistEntry{
+		PoolLpPublicKey: assessment.PoolLpPublicKey,
+		Ticker:          assessment.Ticker,
+		Score:           assessment.Score,
+		Reasons:         assessment.Reasons,
+		AddedAt:         time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if err := saveAutoBlacklistUnlocked(data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WhitelistToken removes token from auto-blacklist and protects it from auto-blacklisting
+// Returns false if token is already whitelisted
+func WhitelistToken(poolLpPublicKey string) (bool, error) {
+	autoBlacklistMutex.Lock()
+	defer autoBlacklistMutex.Unlock()
+
+	data, err := loadAutoBlacklistUnlocked()
+	if err != nil {
+		return false, err
+	}
+	if data.IsWhitelisted(poolLpPublicKey) {
+		return false, nil
+	}
+
+	delete(data.Entries, poolLpPublicKey)
+	data.Whitelist = append(data.Whitelist, poolLpPublicKey)
+
+	if err := saveAutoBlacklistUnlocked(data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func loadAutoBlacklistUnlocked() (*AutoBlacklistData, error) {
+	if _, err := os.Stat(AutoBlacklistFile()); os.IsNotExist(err) {
+		return &AutoBlacklistData{Entries: make(map[string]*AutoBlacklistEntry)}, nil
+	}
+
+	raw, err := os.ReadFile(AutoBlacklistFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auto-blacklist file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &AutoBlacklistData{Entries: make(map[string]*AutoBlacklistEntry)}, nil
+	}
+
+	var data AutoBlacklistData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse auto-blacklist JSON: %w", err)
+	}
+	if data.Entries == nil {
+		data.Entries = make(map[string]*AutoBlacklistEntry)
+	}
+	return &data, nil
+}
+
+func saveAutoBlacklistUnlocked(data *AutoBlacklistData) error {
+	if err := os.MkdirAll(filepath.Dir(AutoBlacklistFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal auto-blacklist JSON: %w", err)
+	}
+
+	tempFilePath := AutoBlacklistFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary auto-blacklist file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, AutoBlacklistFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to auto-blacklist file: %w", err)
+	}
+	return nil
+}
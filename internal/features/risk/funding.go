@@ -0,0 +1,283 @@
+package risk
+
+// Wallet risk flags from reused funding sources (data_out/wallet_flags.json)
+// Admin flags wallets (team, previous rug); new buyer wallet whose first BTC funding
+// came from flagged wallet gets "funded by flagged wallet" warning in swap alerts
+// Funding source is taken from Luminex wallet transfers and checked once per wallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// FlagReasonTeam - wallet of token team
+	FlagReasonTeam = "team"
+	// FlagReasonRug - wallet involved in previous rug
+	FlagReasonRug = "rug"
+	// FlagReasonOther - any other reason (see note)
+	FlagReasonOther = "other"
+
+	// fundingTransfersLimit - transfers fetched per wallet; wallet with more transfers is not new
+	fundingTransfersLimit = 50
+	// fundingCacheTTL - checked funding sources older than this are dropped
+	fundingCacheTTL = 30 * 24 * time.Hour
+)
+
+// WalletFlagsFile - flagged wallets and cached funding sources
+func WalletFlagsFile() string {
+	return paths.Output("wallet_flags.json")
+}
+
+// FlaggedWallet - wallet marked by admin
+type FlaggedWallet struct {
+	PublicKey string `json:"public_key"`
+	Reason    string `json:"reason"` // team, rug or other
+	Note      string `json:"note,omitempty"`
+	AddedBy   string `json:"added_by"`
+	AddedAt   string `json:"added_at"` // RFC3339
+}
+
+// FundingSource - first BTC funding of wallet
+type FundingSource struct {
+	Funder     string `json:"funder"` // empty - no Spark funding found or wallet is not new
+	AmountSats int64  `json:"amount_sats"`
+	FundedAt   string `json:"funded_at,omitempty"` // RFC3339
+	CheckedAt  string `json:"checked_at"`          // RFC3339
+}
+
+// WalletFlagsData - file structure for wallet_flags.json
+type WalletFlagsData struct {
+	Flagged map[string]*FlaggedWallet `json:"flagged"` // publicKey -> flag
+	Funding map[string]*FundingSource `json:"funding"` // publicKey -> funding source
+}
+
+// FundingFlag - wallet funded by flagged wallet
+type FundingFlag struct {
+	Wallet     string
+	Funder     FlaggedWallet
+	AmountSats int64
+	FundedAt   string
+}
+
+var walletFlagsMutex sync.Mutex
+
+// ValidFlagReason returns normalized reason and true if reason is known
+func ValidFlagReason(reason string) (string, bool) {
+	reason = strings.ToLower(strings.TrimSpace(reason))
+	switch reason {
+	case FlagReasonTeam, FlagReasonRug, FlagReasonOther:
+		return reason, true
+	}
+	return reason, false
+}
+
+// LoadWalletFlags loads flagged wallets and funding sources
+// Returns empty data if file doesn't exist
+func LoadWalletFlags() (*WalletFlagsData, error) {
+	walletFlagsMutex.Lock()
+	defer walletFlagsMutex.Unlock()
+	return loadWalletFlagsUnlocked()
+}
+
+// FlagWallet marks wallet as flagged (existing flag is replaced)
+// Returns false if wallet was already flagged
+func FlagWallet(flag FlaggedWallet) (bool, error) {
+	walletFlagsMutex.Lock()
+	defer walletFlagsMutex.Unlock()
+
+	data, err := loadWalletFlagsUnlocked()
+	if err != nil {
+		return false, err
+	}
+
+	_, exists := data.Flagged[flag.PublicKey]
+	if flag.AddedAt == "" {
+		flag.AddedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	data.Flagged[flag.PublicKey] = &flag
+
+	if err := saveWalletFlagsUnlocked(data); err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// UnflagWallet removes flag of wallet
+// Returns false if wallet was not flagged
+func UnflagWallet(publicKey string) (bool, error) {
+	walletFlagsMutex.Lock()
+	defer walletFlagsMutex.Unlock()
+
+	data, err := loadWalletFlagsUnlocked()
+	if err != nil {
+		return false, err
+	}
+	if _, exists := data.Flagged[publicKey]; !exists {
+		return false, nil
+	}
+
+	delete(data.Flagged, publicKey)
+	if err := saveWalletFlagsUnlocked(data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CheckFunding returns flag if first BTC funding of new wallet came from flagged wallet
+// Returns nil if no wallets are flagged, wallet is not new or its funder is not flagged
+// Funding source is fetched once per wallet and cached
+func CheckFunding(ctx context.Context, publicKey string) (*FundingFlag, error) {
+	data, err := LoadWalletFlags()
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Flagged) == 0 || publicKey == "" {
+		return nil, nil
+	}
+
+	source, cached := data.Funding[publicKey]
+	if !cached {
+		source, err = fetchFundingSource(ctx, publicKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := saveFundingSource(publicKey, source); err != nil {
+			return nil, err
+		}
+	}
+
+	if source.Funder == "" {
+		return nil, nil
+	}
+	funder, flagged := data.Flagged[source.Funder]
+	if !flagged {
+		return nil, nil
+	}
+
+	return &FundingFlag{
+		Wallet:     publicKey,
+		Funder:     *funder,
+		AmountSats: source.AmountSats,
+		FundedAt:   source.FundedAt,
+	}, nil
+}
+
+// fetchFundingSource finds first incoming BTC transfer from Spark wallet
+// Wallet with fundingTransfersLimit transfers or more is not new, its funding is not checked
+func fetchFundingSource(ctx context.Context, publicKey string) (*FundingSource, error) {
+	transfers, err := luminex.GetWalletTransfers(ctx, publicKey, fundingTransfersLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet transfers: %w", err)
+	}
+
+	source := &FundingSource{CheckedAt: time.Now().UTC().Format(time.RFC3339)}
+	if len(transfers) >= fundingTransfersLimit {
+		return source, nil
+	}
+
+	var first *luminex.WalletTransfer
+	for i := range transfers {
+		transfer := &transfers[i]
+		if transfer.Direction != luminex.TransferDirectionIncoming || !transfer.IsBTC() {
+			continue
+		}
+		if transfer.CounterpartyPublicKey() == "" || transfer.CounterpartyPublicKey() == publicKey {
+			continue
+		}
+		if first == nil || transfer.Time().Before(first.Time()) {
+			first = transfer
+		}
+	}
+
+	if first != nil {
+		source.Funder = first.CounterpartyPublicKey()
+		source.AmountSats = first.AmountSats
+		source.FundedAt = first.CreatedAt
+	}
+	return source, nil
+}
+
+func saveFundingSource(publicKey string, source *FundingSource) error {
+	walletFlagsMutex.Lock()
+	defer walletFlagsMutex.Unlock()
+
+	data, err := loadWalletFlagsUnlocked()
+	if err != nil {
+		return err
+	}
+
+	// Drop old entries so file does not grow with every buyer wallet ever seen
+	for wallet, entry := range data.Funding {
+		checkedAt, err := time.Parse(time.RFC3339, entry.CheckedAt)
+		if err != nil || time.Since(checkedAt) > fundingCacheTTL {
+			delete(data.Funding, wallet)
+		}
+	}
+	data.Funding[publicKey] = source
+
+	return saveWalletFlagsUnlocked(data)
+}
+
+func loadWalletFlagsUnlocked() (*WalletFlagsData, error) {
+	empty := &WalletFlagsData{
+		Flagged: make(map[string]*FlaggedWallet),
+		Funding: make(map[string]*FundingSource),
+	}
+
+	if _, err := os.Stat(WalletFlagsFile()); os.IsNotExist(err) {
+		return empty, nil
+	}
+
+	raw, err := os.ReadFile(WalletFlagsFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet flags file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return empty, nil
+	}
+
+	var data WalletFlagsData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse wallet flags JSON: %w", err)
+	}
+	if data.Flagged == nil {
+		data.Flagged = make(map[string]*FlaggedWallet)
+	}
+	if data.Funding == nil {
+		data.Funding = make(map[string]*FundingSource)
+	}
+	return &data, nil
+}
+
+func saveWalletFlagsUnlocked(data *WalletFlagsData) error {
+	if err := os.MkdirAll(filepath.Dir(WalletFlagsFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallet flags JSON: %w", err)
+	}
+
+	tempFilePath := WalletFlagsFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary wallet flags file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, WalletFlagsFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to wallet flags file: %w", err)
+	}
+	return nil
+}
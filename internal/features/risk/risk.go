@@ -0,0 +1,172 @@
+package risk
+
+// Token risk score from scam heuristics (0-100)
+// Signals: holders concentration (top 10, dev, bundled), rug (price crash, TVL collapse), wash trading in recent swaps
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/hot_token"
+	"spark-wallet/internal/features/pool_fees"
+)
+
+const (
+	// MaxScore - upper bound of risk score
+	MaxScore = 100
+
+	top10HoldersPctLimit  = 80.0  // top 10 holders own more than this percent
+	devHoldingPctLimit    = 20.0  // dev wallet owns more than this percent
+	bundledPctLimit       = 30.0  // bundled supply percent at launch
+	rugPriceChangeLimit   = -80.0 // 24h price change percent
+	rugTvlDropLimit       = 0.8   // TVL drop from max of recent fee samples
+	washMinSwaps          = 10    // swaps of pool in recent swaps to check wash trading
+	washMaxUniqueWallets  = 3     // wallets making all these swaps
+	washMinRoundTripSwaps = 2     // buys and sells of same wallet
+
+	concentrationWeight = 25
+	devHoldingWeight    = 20
+	bundledWeight       = 20
+	rugWeight           = 40
+	washWeight          = 30
+)
+
+// Assessment - risk score of token with reasons
+type Assessment struct {
+	PoolLpPublicKey string
+	Ticker          string
+	Score           int
+	Reasons         []string
+	Rug             bool
+	WashTrading     bool
+}
+
+// Evaluate calculates risk score of pool
+// recentSwaps - latest AMM swaps (all pools), used for wash trading check
+func Evaluate(poolLpPublicKey string, recentSwaps []flashnet.Swap) (*Assessment, error) {
+	poolData, err := hot_token.GetFullPoolData(poolLpPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool data: %w", err)
+	}
+
+	tokenMeta, _, _ := poolData.TokenSide()
+
+	assessment := &Assessment{
+		PoolLpPublicKey: poolLpPublicKey,
+		Ticker:          tokenMeta.Ticker,
+	}
+
+	// Risk score: holders concentration
+	if tokenMeta.Top10HoldersPct >= top10HoldersPctLimit {
+		assessment.add(concentrationWeight, fmt.Sprintf("top 10 holders %.0f%%", tokenMeta.Top10HoldersPct))
+	}
+	if tokenMeta.DevHoldingPct != nil && *tokenMeta.DevHoldingPct >= devHoldingPctLimit {
+		assessment.add(devHoldingWeight, fmt.Sprintf("dev holds %.0f%%", *tokenMeta.DevHoldingPct))
+	}
+	if poolData.Extra.BundledPercentage >= bundledPctLimit {
+		assessment.add(bundledWeight, fmt.Sprintf("bundled %.0f%%", poolData.Extra.BundledPercentage))
+	}
+
+	// Rug detection: price crash or liquidity pulled
+	if tokenMeta.AggPriceChange24h <= rugPriceChangeLimit {
+		assessment.Rug = true
+		assessment.add(rugWeight, fmt.Sprintf("price %.0f%% in 24h", tokenMeta.AggPriceChange24h))
+	} else if drop, ok := tvlDrop(poolLpPublicKey, poolData.Extra.PoolTvlUsd); ok && drop >= rugTvlDropLimit {
+		assessment.Rug = true
+		assessment.add(rugWeight, fmt.Sprintf("TVL -%.0f%%", drop*100))
+	}
+
+	// Wash trading: few wallets buying and selling back and forth
+	if wallets, ok := detectWashTrading(poolLpPublicKey, recentSwaps); ok {
+		assessment.WashTrading = true
+		assessment.add(washWeight, fmt.Sprintf("wash trading (%d wallets)", wallets))
+	}
+
+	return assessment, nil
+}
+
+// ReasonsText - reasons joined for messages
+func (a *Assessment) ReasonsText() string {
+	if len(a.Reasons) == 0 {
+		return "no signals"
+	}
+	return strings.Join(a.Reasons, ", ")
+}
+
+func (a *Assessment) add(weight int, reason string) {
+	a.Score += weight
+	if a.Score > MaxScore {
+		a.Score = MaxScore
+	}
+	a.Reasons = append(a.Reasons, reason)
+}
+
+// tvlDrop returns TVL drop (0-1) from max TVL of recent fee samples (only for sampled pools)
+func tvlDrop(poolLpPublicKey string, currentTvl float64) (float64, bool) {
+	data, err := pool_fees.LoadPoolFees()
+	if err != nil {
+		return 0, false
+	}
+	pool, exists := data.Pools[poolLpPublicKey]
+	if !exists {
+		return 0, false
+	}
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+	var maxTvl float64
+	for _, sample := range pool.Samples {
+		sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+		if err != nil || sampleTime.Before(cutoff) {
+			continue
+		}
+		if sample.TvlUsd > maxTvl {
+			maxTvl = sample.TvlUsd
+		}
+	}
+	if maxTvl <= 0 || currentTvl >= maxTvl {
+		return 0, false
+	}
+	return (maxTvl - currentTvl) / maxTvl, true
+}
+
+// detectWashTrading checks pool swaps for round trips of few wallets
+// Returns number of wallets making pool swaps
+func detectWashTrading(poolLpPublicKey string, swaps []flashnet.Swap) (int, bool) {
+	type walletTrades struct {
+		buys  int
+		sells int
+	}
+
+	wallets := make(map[string]*walletTrades)
+	poolSwaps := 0
+	for i := range swaps {
+		swap := &swaps[i]
+		if swap.PoolLpPublicKey != poolLpPublicKey {
+			continue
+		}
+		poolSwaps++
+
+		trades, exists := wallets[swap.SwapperPublicKey]
+		if !exists {
+			trades = &walletTrades{}
+			wallets[swap.SwapperPublicKey] = trades
+		}
+		if swap.IsBuy() {
+			trades.buys++
+		} else if swap.IsSell() {
+			trades.sells++
+		}
+	}
+
+	if poolSwaps < washMinSwaps || len(wallets) > washMaxUniqueWallets {
+		return len(wallets), false
+	}
+	for _, trades := range wallets {
+		if trades.buys >= washMinRoundTripSwaps && trades.sells >= washMinRoundTripSwaps {
+			return len(wallets), true
+		}
+	}
+	return len(wallets), false
+}
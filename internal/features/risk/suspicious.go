@@ -0,0 +1,633 @@
+package risk
+
+// Suspicious activity in new swaps (wash trading, self-swaps) with evidence for alerts
+// Patterns, checked on swaps archived by big sales monitor (data_out/archive/swaps):
+// - flipping: one wallet alternates buys and sells of token within minutes
+// - circular: few wallets make most of pool volume and pass BTC or tokens to each other in a cycle
+// - volume spike: pool volume jumps against trailing hours while holder count does not grow
+// Alerted patterns and holder count samples are kept in data_out/telegram_out/suspicious_activity.json
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/hot_token"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// PatternFlipping - wallet alternates buys and sells
+	PatternFlipping = "flipping"
+	// PatternCircular - transfers in a cycle between wallets trading the pool
+	PatternCircular = "circular"
+	// PatternVolumeSpike - volume spike without holder growth
+	PatternVolumeSpike = "volume_spike"
+
+	flipWindow      = 15 * time.Minute // swaps of one wallet checked for alternation
+	flipMinSwitches = 3                // buy/sell direction changes within flipWindow
+
+	circularWindow         = time.Hour // pool swaps checked for circular trading
+	circularMaxWallets     = 5         // wallets making circularVolumeShare of pool volume
+	circularMinSwaps       = 6         // pool swaps in window
+	circularVolumeShare    = 0.8
+	circularTransfersLimit = 50 // transfers fetched per wallet
+	circularTransfersAge   = 24 * time.Hour
+
+	spikeWindow        = time.Hour // volume of last hour ...
+	spikeTrailingHours = 23        // ... compared with average hour of previous hours
+	spikeMultiplier    = 5.0
+	holderSampleMinAge = 30 * time.Minute // holder count is compared with sample at least this old
+	holderSampleTTL    = 3 * time.Hour
+
+	// SuspiciousCooldown - pool is not alerted again for the same pattern within cooldown
+	SuspiciousCooldown = 6 * time.Hour
+)
+
+// SuspiciousActivityFile - alerted patterns and holder count samples
+func SuspiciousActivityFile() string {
+	return paths.Output("telegram_out", "suspicious_activity.json")
+}
+
+// Evidence - wallet involved in suspicious pattern
+type Evidence struct {
+	Wallet       string
+	Counterparty string // second wallet of transfer (circular pattern)
+	Detail       string // what the wallet did
+}
+
+// SuspiciousActivity - suspicious pattern found in pool swaps
+type SuspiciousActivity struct {
+	PoolLpPublicKey string
+	Pattern         string
+	VolumeBTC       float64 // volume of swaps forming the pattern
+	Summary         string
+	Evidence        []Evidence
+}
+
+// HolderSample - holder count of token at sample time
+type HolderSample struct {
+	Time  string `json:"time"` // RFC3339
+	Count int    `json:"count"`
+}
+
+// SuspiciousActivityData - file structure for suspicious_activity.json
+type SuspiciousActivityData struct {
+	Alerted map[string]string         `json:"alerted"` // poolLpPublicKey:pattern -> last alert (RFC3339)
+	Holders map[string][]HolderSample `json:"holders"` // poolLpPublicKey -> holder count samples
+}
+
+var suspiciousMutex sync.Mutex
+
+// LoadRecentSwaps returns archived swaps made within period before now
+func LoadRecentSwaps(period time.Duration, now time.Time) ([]flashnet.Swap, error) {
+	now = now.UTC()
+	start := now.Add(-period)
+
+	var swaps []flashnet.Swap
+	for day := start.Truncate(24 * time.Hour); !day.After(now); day = day.AddDate(0, 0, 1) {
+		daySwaps, err := storage.LoadDailySwaps(day.Format("2006-01-02"))
+		if err != nil {
+			return nil, err
+		}
+		for _, swap := range daySwaps {
+			swapTime, ok := storage.SwapTime(swap)
+			if !ok || swapTime.Before(start) || swapTime.After(now) {
+				continue
+			}
+			swaps = append(swaps, swap)
+		}
+	}
+	sort.SliceStable(swaps, func(i, j int) bool {
+		left, _ := storage.SwapTime(swaps[i])
+		right, _ := storage.SwapTime(swaps[j])
+		return left.Before(right)
+	})
+	return swaps, nil
+}
+
+// FindSuspiciousActivity checks swaps of last 24 hours for suspicious patterns
+// Patterns with volume below minBTC and pools alerted within SuspiciousCooldown are skipped
+func FindSuspiciousActivity(ctx context.Context, swaps []flashnet.Swap, minBTC float64, now time.Time) ([]SuspiciousActivity, error) {
+	data, err := LoadSuspiciousActivity()
+	if err != nil {
+		return nil, err
+	}
+
+	found := DetectFlipping(swaps, now)
+	found = append(found, detectCircular(ctx, swaps, minBTC, now)...)
+
+	spikes, err := detectVolumeSpikes(swaps, minBTC, now)
+	if err != nil {
+		return nil, err
+	}
+	found = append(found, spikes...)
+
+	var result []SuspiciousActivity
+	for _, activity := range found {
+		if activity.VolumeBTC < minBTC {
+			continue
+		}
+		if alertedAt, err := time.Parse(time.RFC3339, data.Alerted[suspiciousKey(activity.PoolLpPublicKey, activity.Pattern)]); err == nil && now.Sub(alertedAt) < SuspiciousCooldown {
+			continue
+		}
+		result = append(result, activity)
+	}
+	return result, nil
+}
+
+// DetectFlipping finds wallets alternating buys and sells of one token within flipWindow
+func DetectFlipping(swaps []flashnet.Swap, now time.Time) []SuspiciousActivity {
+	type walletPool struct {
+		wallet string
+		pool   string
+	}
+
+	trades := make(map[walletPool][]flashnet.Swap)
+	var order []walletPool
+	for _, swap := range swaps {
+		if swap.SwapperPublicKey == "" || !withinWindow(swap, now, flipWindow) {
+			continue
+		}
+		if !swap.IsBuy() && !swap.IsSell() {
+			continue
+		}
+		key := walletPool{wallet: swap.SwapperPublicKey, pool: swap.PoolLpPublicKey}
+		if _, exists := trades[key]; !exists {
+			order = append(order, key)
+		}
+		trades[key] = append(trades[key], swap)
+	}
+
+	byPool := make(map[string]*SuspiciousActivity)
+	var pools []string
+	for _, key := range order {
+		walletSwaps := trades[key]
+		switches, buys, sells := 0, 0, 0
+		volume := 0.0
+		for i, swap := range walletSwaps {
+			if swap.IsBuy() {
+				buys++
+			} else {
+				sells++
+			}
+			if btc, ok := swap.BTCAmount(); ok {
+				volume += btc
+			}
+			if i > 0 && swap.IsBuy() != walletSwaps[i-1].IsBuy() {
+				switches++
+			}
+		}
+		if switches < flipMinSwitches {
+			continue
+		}
+
+		first, _ := storage.SwapTime(walletSwaps[0])
+		last, _ := storage.SwapTime(walletSwaps[len(walletSwaps)-1])
+		period := last.Sub(first)
+		activity, exists := byPool[key.pool]
+		if !exists {
+			activity = &SuspiciousActivity{PoolLpPublicKey: key.pool, Pattern: PatternFlipping}
+			byPool[key.pool] = activity
+			pools = append(pools, key.pool)
+		}
+		activity.VolumeBTC += volume
+		activity.Evidence = append(activity.Evidence, Evidence{
+			Wallet: key.wallet,
+			Detail: fmt.Sprintf("%d buys / %d sells in %d min, %s btc", buys, sells, int(period.Minutes())+1, amount.FormatBTC(volume)),
+		})
+	}
+
+	result := make([]SuspiciousActivity, 0, len(pools))
+	for _, pool := range pools {
+		activity := byPool[pool]
+		activity.Summary = fmt.Sprintf("%d wallet(s) switching between buys and sells within %d min", len(activity.Evidence), int(flipWindow.Minutes()))
+		result = append(result, *activity)
+	}
+	return result
+}
+
+// circularCandidate - pool traded mostly by few wallets
+type circularCandidate struct {
+	pool      string
+	wallets   []string
+	volumeBTC float64
+}
+
+// circularCandidates returns pools where few wallets made most of volume within circularWindow
+func circularCandidates(swaps []flashnet.Swap, minBTC float64, now time.Time) []circularCandidate {
+	type poolVolume struct {
+		swaps   int
+		total   float64
+		wallets map[string]float64
+	}
+
+	pools := make(map[string]*poolVolume)
+	var order []string
+	for _, swap := range swaps {
+		if swap.SwapperPublicKey == "" || !withinWindow(swap, now, circularWindow) {
+			continue
+		}
+		btc, ok := swap.BTCAmount()
+		if !ok {
+			continue
+		}
+		pool, exists := pools[swap.PoolLpPublicKey]
+		if !exists {
+			pool = &poolVolume{wallets: make(map[string]float64)}
+			pools[swap.PoolLpPublicKey] = pool
+			order = append(order, swap.PoolLpPublicKey)
+		}
+		pool.swaps++
+		pool.total += btc
+		pool.wallets[swap.SwapperPublicKey] += btc
+	}
+
+	var candidates []circularCandidate
+	for _, poolKey := range order {
+		pool := pools[poolKey]
+		if pool.swaps < circularMinSwaps || pool.total < minBTC || len(pool.wallets) < 2 {
+			continue
+		}
+
+		wallets := make([]string, 0, len(pool.wallets))
+		for wallet := range pool.wallets {
+			wallets = append(wallets, wallet)
+		}
+		sort.Slice(wallets, func(i, j int) bool {
+			if pool.wallets[wallets[i]] != pool.wallets[wallets[j]] {
+				return pool.wallets[wallets[i]] > pool.wallets[wallets[j]]
+			}
+			return wallets[i] < wallets[j]
+		})
+		if len(wallets) > circularMaxWallets {
+			wallets = wallets[:circularMaxWallets]
+		}
+
+		share := 0.0
+		for _, wallet := range wallets {
+			share += pool.wallets[wallet]
+		}
+		if share < pool.total*circularVolumeShare {
+			continue
+		}
+		candidates = append(candidates, circularCandidate{pool: poolKey, wallets: wallets, volumeBTC: pool.total})
+	}
+	return candidates
+}
+
+// detectCircular checks transfers between top wallets of candidate pools for a cycle
+// Transfers are fetched from Luminex only for candidate pools
+func detectCircular(ctx context.Context, swaps []flashnet.Swap, minBTC float64, now time.Time) []SuspiciousActivity {
+	var result []SuspiciousActivity
+	for _, candidate := range circularCandidates(swaps, minBTC, now) {
+		if ctx.Err() != nil {
+			return result
+		}
+
+		inSet := make(map[string]bool, len(candidate.wallets))
+		for _, wallet := range candidate.wallets {
+			inSet[wallet] = true
+		}
+
+		// sender -> receivers within wallet set
+		edges := make(map[string]map[string]luminex.WalletTransfer)
+		for _, wallet := range candidate.wallets {
+			transfers, err := luminex.GetWalletTransfers(ctx, wallet, circularTransfersLimit)
+			if err != nil {
+				continue
+			}
+			for _, transfer := range transfers {
+				counterparty := transfer.CounterpartyPublicKey()
+				if transfer.Direction == luminex.TransferDirectionIncoming || !inSet[counterparty] || counterparty == wallet {
+					continue
+				}
+				if transferTime := transfer.Time(); transferTime.IsZero() || now.Sub(transferTime) > circularTransfersAge {
+					continue
+				}
+				if edges[wallet] == nil {
+					edges[wallet] = make(map[string]luminex.WalletTransfer)
+				}
+				edges[wallet][counterparty] = transfer
+			}
+		}
+
+		cycle := findTransferCycle(candidate.wallets, edges)
+		if len(cycle) == 0 {
+			continue
+		}
+
+		activity := SuspiciousActivity{
+			PoolLpPublicKey: candidate.pool,
+			Pattern:         PatternCircular,
+			VolumeBTC:       candidate.volumeBTC,
+			Summary:         fmt.Sprintf("%d wallets trading the pool passed funds to each other in a cycle", len(cycle)),
+		}
+		for i, wallet := range cycle {
+			next := cycle[(i+1)%len(cycle)]
+			transfer := edges[wallet][next]
+			asset := "tokens"
+			if transfer.IsBTC() {
+				asset = amount.FormatBTC(float64(transfer.AmountSats)/1e8) + " btc"
+			}
+			activity.Evidence = append(activity.Evidence, Evidence{
+				Wallet:       wallet,
+				Counterparty: next,
+				Detail:       fmt.Sprintf("sent %s at %s UTC", asset, transfer.Time().UTC().Format("15:04")),
+			})
+		}
+		result = append(result, activity)
+	}
+	return result
+}
+
+// findTransferCycle returns wallets forming a cycle of transfers (empty if none)
+func findTransferCycle(wallets []string, edges map[string]map[string]luminex.WalletTransfer) []string {
+	const (
+		unvisited = iota
+		inPath
+		done
+	)
+	state := make(map[string]int, len(wallets))
+	var path []string
+
+	var visit func(wallet string) []string
+	visit = func(wallet string) []string {
+		state[wallet] = inPath
+		path = append(path, wallet)
+
+		receivers := make([]string, 0, len(edges[wallet]))
+		for receiver := range edges[wallet] {
+			receivers = append(receivers, receiver)
+		}
+		sort.Strings(receivers)
+		for _, receiver := range receivers {
+			switch state[receiver] {
+			case inPath:
+				for i, pathWallet := range path {
+					if pathWallet == receiver {
+						return append([]string(nil), path[i:]...)
+					}
+				}
+			case unvisited:
+				if cycle := visit(receiver); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[wallet] = done
+		return nil
+	}
+
+	for _, wallet := range wallets {
+		if state[wallet] == unvisited {
+			if cycle := visit(wallet); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// detectVolumeSpikes finds pools with last hour volume spikeMultiplier times above trailing average
+// and holder count not grown since sample taken at least holderSampleMinAge ago
+// Holder count is sampled for every pool with at least minBTC volume in last hour
+func detectVolumeSpikes(swaps []flashnet.Swap, minBTC float64, now time.Time) ([]SuspiciousActivity, error) {
+	recent := make(map[string]float64)
+	trailing := make(map[string]float64)
+	var order []string
+	for _, swap := range swaps {
+		btc, ok := swap.BTCAmount()
+		if !ok {
+			continue
+		}
+		at, known := storage.SwapTime(swap)
+		if !known {
+			continue
+		}
+		age := now.Sub(at)
+		switch {
+		case age <= spikeWindow:
+			if _, exists := recent[swap.PoolLpPublicKey]; !exists {
+				order = append(order, swap.PoolLpPublicKey)
+			}
+			recent[swap.PoolLpPublicKey] += btc
+		case age <= spikeWindow+spikeTrailingHours*time.Hour:
+			trailing[swap.PoolLpPublicKey] += btc
+		}
+	}
+
+	var result []SuspiciousActivity
+	for _, pool := range order {
+		if recent[pool] < minBTC {
+			continue
+		}
+
+		poolData, err := hot_token.GetFullPoolData(pool)
+		if err != nil {
+			continue
+		}
+		tokenMeta, _, _ := poolData.TokenSide()
+		previous, err := recordHolderSample(pool, tokenMeta.HolderCount, now)
+		if err != nil {
+			return nil, err
+		}
+
+		average := trailing[pool] / spikeTrailingHours
+		if recent[pool] < average*spikeMultiplier || previous == nil || tokenMeta.HolderCount > previous.Count {
+			continue
+		}
+
+		spike := fmt.Sprintf("no swaps in previous %d hours", spikeTrailingHours)
+		if average > 0 {
+			spike = fmt.Sprintf("%.0fx of average hour", recent[pool]/average)
+		}
+		previousTime, _ := time.Parse(time.RFC3339, previous.Time)
+		summary := fmt.Sprintf("volume of last hour %s btc (%s), holders %d → %d since %s UTC",
+			amount.FormatBTC(recent[pool]), spike, previous.Count, tokenMeta.HolderCount, previousTime.UTC().Format("15:04"))
+		result = append(result, SuspiciousActivity{
+			PoolLpPublicKey: pool,
+			Pattern:         PatternVolumeSpike,
+			VolumeBTC:       recent[pool],
+			Summary:         summary,
+			Evidence:        topSpikeWallets(swaps, pool, now),
+		})
+	}
+	return result, nil
+}
+
+// topSpikeWallets returns wallets with largest volume of pool within spikeWindow
+func topSpikeWallets(swaps []flashnet.Swap, pool string, now time.Time) []Evidence {
+	type walletVolume struct {
+		swaps  int
+		volume float64
+	}
+
+	wallets := make(map[string]*walletVolume)
+	for _, swap := range swaps {
+		if swap.PoolLpPublicKey != pool || swap.SwapperPublicKey == "" || !withinWindow(swap, now, spikeWindow) {
+			continue
+		}
+		btc, ok := swap.BTCAmount()
+		if !ok {
+			continue
+		}
+		wallet, exists := wallets[swap.SwapperPublicKey]
+		if !exists {
+			wallet = &walletVolume{}
+			wallets[swap.SwapperPublicKey] = wallet
+		}
+		wallet.swaps++
+		wallet.volume += btc
+	}
+
+	addresses := make([]string, 0, len(wallets))
+	for address := range wallets {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		if wallets[addresses[i]].volume != wallets[addresses[j]].volume {
+			return wallets[addresses[i]].volume > wallets[addresses[j]].volume
+		}
+		return addresses[i] < addresses[j]
+	})
+	if len(addresses) > circularMaxWallets {
+		addresses = addresses[:circularMaxWallets]
+	}
+
+	evidence := make([]Evidence, 0, len(addresses))
+	for _, address := range addresses {
+		evidence = append(evidence, Evidence{
+			Wallet: address,
+			Detail: fmt.Sprintf("%d swaps, %s btc", wallets[address].swaps, amount.FormatBTC(wallets[address].volume)),
+		})
+	}
+	return evidence
+}
+
+// LoadSuspiciousActivity loads alerted patterns and holder samples
+// Returns empty data if file doesn't exist
+func LoadSuspiciousActivity() (*SuspiciousActivityData, error) {
+	suspiciousMutex.Lock()
+	defer suspiciousMutex.Unlock()
+	return loadSuspiciousUnlocked()
+}
+
+// MarkSuspiciousAlerted records alert of pattern for pool (starts cooldown)
+func MarkSuspiciousAlerted(poolLpPublicKey string, pattern string, now time.Time) error {
+	suspiciousMutex.Lock()
+	defer suspiciousMutex.Unlock()
+
+	data, err := loadSuspiciousUnlocked()
+	if err != nil {
+		return err
+	}
+	for key, alertedAt := range data.Alerted {
+		if parsed, err := time.Parse(time.RFC3339, alertedAt); err != nil || now.Sub(parsed) > SuspiciousCooldown {
+			delete(data.Alerted, key)
+		}
+	}
+	data.Alerted[suspiciousKey(poolLpPublicKey, pattern)] = now.UTC().Format(time.RFC3339)
+	return saveSuspiciousUnlocked(data)
+}
+
+// recordHolderSample saves holder count of pool
+// Returns latest sample at least holderSampleMinAge old (nil if none)
+func recordHolderSample(poolLpPublicKey string, count int, now time.Time) (*HolderSample, error) {
+	suspiciousMutex.Lock()
+	defer suspiciousMutex.Unlock()
+
+	data, err := loadSuspiciousUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *HolderSample
+	samples := make([]HolderSample, 0, len(data.Holders[poolLpPublicKey])+1)
+	for _, sample := range data.Holders[poolLpPublicKey] {
+		sampleTime, err := time.Parse(time.RFC3339, sample.Time)
+		if err != nil || now.Sub(sampleTime) > holderSampleTTL {
+			continue
+		}
+		if now.Sub(sampleTime) >= holderSampleMinAge {
+			sample := sample
+			previous = &sample
+		}
+		samples = append(samples, sample)
+	}
+	data.Holders[poolLpPublicKey] = append(samples, HolderSample{Time: now.UTC().Format(time.RFC3339), Count: count})
+
+	// Pools without recent volume are dropped
+	for pool, poolSamples := range data.Holders {
+		last, err := time.Parse(time.RFC3339, poolSamples[len(poolSamples)-1].Time)
+		if err != nil || now.Sub(last) > holderSampleTTL {
+			delete(data.Holders, pool)
+		}
+	}
+
+	if err := saveSuspiciousUnlocked(data); err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+// withinWindow reports whether swap is not older than window, swap of unknown time is not
+func withinWindow(swap flashnet.Swap, now time.Time, window time.Duration) bool {
+	at, ok := storage.SwapTime(swap)
+	return ok && now.Sub(at) <= window
+}
+
+func suspiciousKey(poolLpPublicKey string, pattern string) string {
+	return poolLpPublicKey + ":" + pattern
+}
+
+func loadSuspiciousUnlocked() (*SuspiciousActivityData, error) {
+	empty := &SuspiciousActivityData{
+		Alerted: make(map[string]string),
+		Holders: make(map[string][]HolderSample),
+	}
+
+	raw, err := os.ReadFile(SuspiciousActivityFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return empty, nil
+		}
+		return nil, fmt.Errorf("failed to read suspicious activity file: %w", err)
+	}
+	if len(raw) == 0 {
+		return empty, nil
+	}
+
+	var data SuspiciousActivityData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse suspicious activity JSON: %w", err)
+	}
+	if data.Alerted == nil {
+		data.Alerted = make(map[string]string)
+	}
+	if data.Holders == nil {
+		data.Holders = make(map[string][]HolderSample)
+	}
+	return &data, nil
+}
+
+func saveSuspiciousUnlocked(data *SuspiciousActivityData) error {
+	if err := os.MkdirAll(filepath.Dir(SuspiciousActivityFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := storage.WriteJSONAtomic(SuspiciousActivityFile(), data); err != nil {
+		return fmt.Errorf("failed to save suspicious activity file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package roles
+
+// Roles of Telegram users for bot commands
+// Owners - user IDs from config (telegram.admin_user_ids), they manage admins with /admins
+// Admins - users added with /admins add (data_out/telegram_out/admins.json), and administrators of chat
+// if telegram.chat_admins is on (checked by command handler)
+// Every command has permission level: member, admin or owner (defaults below, config telegram.command_levels overrides)
+// Without owners, admins and chat admin check roles are off: every member runs every command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+// Level - permission level of command and role of user
+type Level int
+
+// Permission levels, higher level includes lower ones
+const (
+	LevelMember Level = iota
+	LevelAdmin
+	LevelOwner
+)
+
+// String returns name of level (member, admin, owner)
+func (l Level) String() string {
+	switch l {
+	case LevelAdmin:
+		return "admin"
+	case LevelOwner:
+		return "owner"
+	}
+	return "member"
+}
+
+// ParseLevel parses level name
+func ParseLevel(value string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "member", "all":
+		return LevelMember, nil
+	case "admin":
+		return LevelAdmin, nil
+	case "owner":
+		return LevelOwner, nil
+	}
+	return LevelMember, fmt.Errorf("unknown level %q (member, admin or owner)", value)
+}
+
+// defaultLevels - commands above member level
+// Commands of admin chat (API_BOT_CHAT_ID) also need admin role when filtered chat is admin chat
+var defaultLevels = map[string]Level{
+	"flashadd":     LevelAdmin,
+	"flashdel":     LevelAdmin,
+	"holdersadd":   LevelAdmin,
+	"whitelist":    LevelAdmin,
+	"exclude":      LevelAdmin,
+	"include":      LevelAdmin,
+	"flashrefresh": LevelAdmin,
+	"flagwallet":   LevelAdmin,
+	"unflagwallet": LevelAdmin,
+	"label":        LevelAdmin,
+	"unlabel":      LevelAdmin,
+	"testalert":    LevelAdmin,
+	"audit":        LevelAdmin,
+	"portfolio":    LevelAdmin,
+	"admins":       LevelAdmin,
+	// /admins add and /admins del
+	ManageAdminsCommand: LevelOwner,
+}
+
+// Config - roles from config
+type Config struct {
+	Owners     []int64          // telegram.admin_user_ids
+	ChatAdmins bool             // administrators of chat are admins (telegram.chat_admins)
+	Levels     map[string]Level // command -> level, overrides defaults (telegram.command_levels)
+}
+
+// Admin - user added with /admins add
+type Admin struct {
+	UserID   int64     `json:"userId"`
+	Username string    `json:"username,omitempty"`
+	AddedBy  int64     `json:"addedBy"`
+	AddedAt  time.Time `json:"addedAt"`
+}
+
+// AdminsData - file structure for admins.json
+type AdminsData struct {
+	Admins []Admin `json:"admins"`
+}
+
+var (
+	rolesMutex sync.Mutex
+	configured Config
+)
+
+// AdminsFile - admins added with /admins
+func AdminsFile() string {
+	return paths.Output("telegram_out", "admins.json")
+}
+
+// Configure sets owners, chat admin check and command levels from config
+func Configure(cfg Config) {
+	rolesMutex.Lock()
+	defer rolesMutex.Unlock()
+	configured = cfg
+}
+
+// Owners returns user IDs of owners
+func Owners() []int64 {
+	rolesMutex.Lock()
+	defer rolesMutex.Unlock()
+	return append([]int64(nil), configured.Owners...)
+}
+
+// ChatAdmins reports whether administrators of chat are admins
+func ChatAdmins() bool {
+	rolesMutex.Lock()
+	defer rolesMutex.Unlock()
+	return configured.ChatAdmins
+}
+
+// Enabled reports whether roles are on: owners or chat admin check configured, or admins added
+func Enabled() bool {
+	rolesMutex.Lock()
+	defer rolesMutex.Unlock()
+	if len(configured.Owners) > 0 || configured.ChatAdmins {
+		return true
+	}
+	data, err := loadAdminsUnlocked()
+	// Unreadable file keeps commands closed rather than open to everyone
+	return err != nil || len(data.Admins) > 0
+}
+
+// CommandLevel returns level required to run command
+// Owner level falls back to admin without owners in config, so admins are still managed
+func CommandLevel(command string) Level {
+	rolesMutex.Lock()
+	defer rolesMutex.Unlock()
+
+	command = strings.ToLower(command)
+	level, exists := configured.Levels[command]
+	if !exists {
+		level = defaultLevels[command]
+	}
+	if level == LevelOwner && len(configured.Owners) == 0 {
+		return LevelAdmin
+	}
+	return level
+}
+
+// ManageAdminsCommand - level key of /admins add and /admins del (telegram.command_levels)
+const ManageAdminsCommand = "admins_manage"
+
+// CanManageAdmins reports whether user with role may add and remove admins
+// While roles are off every user is a member, so the first admin is added only from trusted admin chat
+// (or owners are set in config), otherwise the first member to try would lock everyone else out
+func CanManageAdmins(role Level, isTrustedChat bool) bool {
+	if !Enabled() {
+		return isTrustedChat
+	}
+	return role >= CommandLevel(ManageAdminsCommand)
+}
+
+// RoleOf returns role of user from owners and added admins (chat administrators are checked by caller)
+func RoleOf(userID int64) (Level, error) {
+	rolesMutex.Lock()
+	defer rolesMutex.Unlock()
+
+	for _, owner := range configured.Owners {
+		if owner == userID {
+			return LevelOwner, nil
+		}
+	}
+	data, err := loadAdminsUnlocked()
+	if err != nil {
+		return LevelMember, err
+	}
+	for _, admin := range data.Admins {
+		if admin.UserID == userID {
+			return LevelAdmin, nil
+		}
+	}
+	return LevelMember, nil
+}
+
+// Admins returns added admins sorted by user ID
+func Admins() ([]Admin, error) {
+	rolesMutex.Lock()
+	defer rolesMutex.Unlock()
+
+	data, err := loadAdminsUnlocked()
+	if err != nil {
+		return nil, err
+	}
+	admins := append([]Admin(nil), data.Admins...)
+	sort.Slice(admins, func(i, j int) bool { return admins[i].UserID < admins[j].UserID })
+	return admins, nil
+}
+
+// AddAdmin saves admin, false if user is already admin (username is updated)
+func AddAdmin(admin Admin) (bool, error) {
+	rolesMutex.Lock()
+	defer rolesMutex.Unlock()
+
+	data, err := loadAdminsUnlocked()
+	if err != nil {
+		return false, err
+	}
+	for i := range data.Admins {
+		if data.Admins[i].UserID == admin.UserID {
+			if admin.Username != "" {
+				data.Admins[i].Username = admin.Username
+			}
+			return false, saveAdminsUnlocked(data)
+		}
+	}
+	data.Admins = append(data.Admins, admin)
+	return true, saveAdminsUnlocked(data)
+}
+
+// RemoveAdmin removes admin, false if user was not admin
+func RemoveAdmin(userID int64) (bool, error) {
+	rolesMutex.Lock()
+	defer rolesMutex.Unlock()
+
+	data, err := loadAdminsUnlocked()
+	if err != nil {
+		return false, err
+	}
+	for i := range data.Admins {
+		if data.Admins[i].UserID == userID {
+			data.Admins = append(data.Admins[:i], data.Admins[i+1:]...)
+			return true, saveAdminsUnlocked(data)
+		}
+	}
+	return false, nil
+}
+
+func loadAdminsUnlocked() (*AdminsData, error) {
+	data := &AdminsData{}
+	raw, err := os.ReadFile(AdminsFile())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read admins file: %w", err)
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, data); err != nil {
+			return nil, fmt.Errorf("failed to parse admins JSON: %w", err)
+		}
+	}
+	return data, nil
+}
+
+func saveAdminsUnlocked(data *AdminsData) error {
+	if err := storage.WriteJSONAtomic(AdminsFile(), data); err != nil {
+		return fmt.Errorf("failed to save admins: %w", err)
+	}
+	return nil
+}
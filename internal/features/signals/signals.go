@@ -0,0 +1,183 @@
+package signals
+
+// External trading signals received by webhook server (TradingView alerts, custom scripts)
+// Every request is signed with secret of its source:
+// X-Signal-Timestamp - unix seconds, X-Signal-Signature - hex HMAC-SHA256 of "{timestamp}.{body}" (optional "sha256=" prefix)
+// Timestamp is part of signed message, so captured request can't be replayed after MaxClockSkew,
+// ReplayGuard remembers accepted signatures until then, so it can't be replayed before either
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// TimestampHeader - unix time of request in seconds
+	TimestampHeader = "X-Signal-Timestamp"
+	// SignatureHeader - HMAC-SHA256 of timestamp and body
+	SignatureHeader = "X-Signal-Signature"
+	// MaxClockSkew - requests with timestamp further from server time are rejected
+	MaxClockSkew = 5 * time.Minute
+	// MaxTextLength - longer signal text is cut
+	MaxTextLength = 1000
+)
+
+// Signal - body of webhook request
+type Signal struct {
+	Title  string  `json:"title"`
+	Text   string  `json:"text"`
+	Ticker string  `json:"ticker"`
+	Side   string  `json:"side"` // buy, sell or empty
+	Price  float64 `json:"price"`
+	URL    string  `json:"url"`
+}
+
+// Sign returns signature of body for timestamp (hex, without prefix)
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature and timestamp of request relative to now
+func Verify(secret string, timestamp string, signature string, body []byte, now time.Time) error {
+	if secret == "" {
+		return fmt.Errorf("source has no secret")
+	}
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("missing %s or %s header", TimestampHeader, SignatureHeader)
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(timestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	skew := now.Sub(time.Unix(seconds, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > MaxClockSkew {
+		return fmt.Errorf("timestamp is %s away from server time, max %s", skew.Round(time.Second), MaxClockSkew)
+	}
+
+	provided, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "sha256="))
+	if err != nil {
+		return fmt.Errorf("signature is not hex")
+	}
+	expected, _ := hex.DecodeString(Sign(secret, strings.TrimSpace(timestamp), body))
+	if !hmac.Equal(provided, expected) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// ReplayGuard - signatures of accepted requests, kept while their timestamp passes Verify
+type ReplayGuard struct {
+	mutex sync.Mutex
+	seen  map[string]time.Time // signature -> time accepted
+}
+
+// NewReplayGuard creates empty guard
+func NewReplayGuard() *ReplayGuard {
+	return &ReplayGuard{seen: make(map[string]time.Time)}
+}
+
+// Accept remembers signature of verified request, returns error if it was already accepted
+// Signatures are kept for 2 x MaxClockSkew (timestamp may be ahead of server time), later their timestamp is rejected anyway
+func (g *ReplayGuard) Accept(signature string, now time.Time) error {
+	key := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(signature), "sha256="))
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for seen, at := range g.seen {
+		if now.Sub(at) > 2*MaxClockSkew {
+			delete(g.seen, seen)
+		}
+	}
+	if _, exists := g.seen[key]; exists {
+		return fmt.Errorf("signal already received")
+	}
+	g.seen[key] = now
+	return nil
+}
+
+// Parse reads signal from JSON body, plain text body is signal text
+func Parse(body []byte) (Signal, error) {
+	var signal Signal
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "{") {
+		if err := json.Unmarshal([]byte(trimmed), &signal); err != nil {
+			return Signal{}, fmt.Errorf("invalid JSON body: %w", err)
+		}
+	} else {
+		signal.Text = trimmed
+	}
+
+	signal.Title = strings.TrimSpace(signal.Title)
+	signal.Text = strings.TrimSpace(signal.Text)
+	signal.Ticker = strings.ToUpper(strings.TrimSpace(signal.Ticker))
+	signal.Side = strings.ToLower(strings.TrimSpace(signal.Side))
+	signal.URL = strings.TrimSpace(signal.URL)
+
+	if signal.Title == "" && signal.Text == "" {
+		return Signal{}, fmt.Errorf("signal has no title or text")
+	}
+	if signal.Side != "" && signal.Side != "buy" && signal.Side != "sell" {
+		return Signal{}, fmt.Errorf("side must be buy, sell or empty")
+	}
+	if signal.URL != "" && !strings.HasPrefix(signal.URL, "https://") && !strings.HasPrefix(signal.URL, "http://") {
+		return Signal{}, fmt.Errorf("url must be http(s)")
+	}
+	if runes := []rune(signal.Text); len(runes) > MaxTextLength {
+		signal.Text = string(runes[:MaxTextLength]) + "…"
+	}
+	return signal, nil
+}
+
+// FormatMessage formats signal of source for Telegram (HTML), all fields of request are escaped
+func FormatMessage(source string, signal Signal) string {
+	var message strings.Builder
+
+	icon := "📡"
+	switch signal.Side {
+	case "buy":
+		icon = "🟢"
+	case "sell":
+		icon = "🔴"
+	}
+	title := signal.Title
+	if title == "" {
+		title = "Signal"
+	}
+	message.WriteString(fmt.Sprintf("%s <b>%s</b>", icon, html.EscapeString(title)))
+	if signal.Ticker != "" {
+		message.WriteString(fmt.Sprintf(" | <b>%s</b>", html.EscapeString(signal.Ticker)))
+	}
+	if signal.Side != "" {
+		message.WriteString(" | " + strings.ToUpper(signal.Side))
+	}
+	if signal.Price > 0 {
+		message.WriteString(fmt.Sprintf(" @ <code>%s</code>", strconv.FormatFloat(signal.Price, 'f', -1, 64)))
+	}
+	message.WriteString("\n")
+
+	if signal.Text != "" {
+		message.WriteString(fmt.Sprintf("\n%s\n", html.EscapeString(signal.Text)))
+	}
+	if signal.URL != "" {
+		message.WriteString(fmt.Sprintf("\n<a href=\"%s\">Open</a>\n", html.EscapeString(signal.URL)))
+	}
+	message.WriteString(fmt.Sprintf("\n<i>Source: %s</i>", html.EscapeString(source)))
+	return message.String()
+}
@@ -0,0 +1,217 @@
+package swap_feed
+
+// Enriched swaps of archive for swaps API (app.swaps_api_addr): ticker, side, BTC and USD value, wallet label
+// External dashboards (Grafana JSON datasource, custom frontends) read the bot's data without Telegram
+// Swaps come from daily swap archive (data_out/swaps), newest first, with filters and offset pagination
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/btc_price"
+	"spark-wallet/internal/features/wallet_labels"
+	storage "spark-wallet/internal/infra/fs"
+)
+
+const (
+	DefaultLimit  = 100
+	MaxLimit      = 500
+	DefaultPeriod = 24 * time.Hour
+	MaxPeriod     = 31 * 24 * time.Hour // archive days read by one request
+
+	defaultTokenDecimals = 8
+)
+
+// Swap sides
+const (
+	SideBuy  = "buy"
+	SideSell = "sell"
+	SideSwap = "swap" // token-to-token
+)
+
+// Swap - archived swap with our figures
+type Swap struct {
+	ID              string  `json:"id"`
+	Time            string  `json:"time"` // RFC3339 UTC
+	PoolLpPublicKey string  `json:"pool_lp_public_key"`
+	Ticker          string  `json:"ticker,omitempty"`
+	TokenAddress    string  `json:"token_address,omitempty"`
+	Side            string  `json:"side"`
+	AmountBTC       float64 `json:"amount_btc,omitempty"`
+	AmountToken     float64 `json:"amount_token,omitempty"`
+	ValueUSD        float64 `json:"value_usd,omitempty"` // BTC amount at BTC price of swap day, 0 - price unknown
+	Wallet          string  `json:"wallet"`
+	WalletLabel     string  `json:"wallet_label,omitempty"`
+}
+
+// Filter - swaps of [From, To) matching all set fields
+type Filter struct {
+	From   time.Time
+	To     time.Time
+	Pool   string  // poolLpPublicKey
+	Ticker string  // case-insensitive
+	Wallet string  // swapper public key
+	Side   string  // buy, sell or swap
+	MinBTC float64 // buys and sells from amount
+}
+
+// Page - swaps of one page and count of all matching swaps
+type Page struct {
+	Swaps  []Swap    `json:"swaps"`
+	Total  int       `json:"total"`
+	Offset int       `json:"offset"`
+	Limit  int       `json:"limit"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+// Validate checks period and side of filter
+func (f Filter) Validate() error {
+	if !f.From.Before(f.To) {
+		return fmt.Errorf("from must be before to")
+	}
+	if f.To.Sub(f.From) > MaxPeriod {
+		return fmt.Errorf("period is longer than %d days", int(MaxPeriod.Hours()/24))
+	}
+	switch f.Side {
+	case "", SideBuy, SideSell, SideSwap:
+	default:
+		return fmt.Errorf("side must be buy, sell or swap")
+	}
+	if f.MinBTC < 0 {
+		return fmt.Errorf("min_btc cannot be negative")
+	}
+	return nil
+}
+
+// Load returns page of enriched swaps of filter from swap archive, newest first
+// Swaps are filtered by archive fields, only swaps of page are enriched
+func Load(filter Filter, offset int, limit int) (*Page, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	from, to := filter.From.UTC(), filter.To.UTC()
+
+	tickers := make(map[string]string) // poolLpPublicKey -> ticker, token cache is asked once per pool
+	var swaps []flashnet.Swap
+	for day := from.Truncate(24 * time.Hour); day.Before(to); day = day.AddDate(0, 0, 1) {
+		daySwaps, err := storage.LoadDailySwaps(day.Format("2006-01-02"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load swaps of %s: %w", day.Format("2006-01-02"), err)
+		}
+		for _, swap := range daySwaps {
+			at, ok := storage.SwapTime(swap)
+			if !ok || at.Before(from) || !at.Before(to) || !filter.matches(swap, tickers) {
+				continue
+			}
+			swaps = append(swaps, swap)
+		}
+	}
+
+	// Stable sort keeps archive order of swaps of the same second, so pages don't shift
+	sort.SliceStable(swaps, func(i, j int) bool {
+		left, _ := storage.SwapTime(swaps[i])
+		right, _ := storage.SwapTime(swaps[j])
+		return left.After(right)
+	})
+
+	page := &Page{Swaps: []Swap{}, Total: len(swaps), Offset: offset, Limit: limit, From: from, To: to}
+	for i := offset; i < len(swaps) && len(page.Swaps) < limit; i++ {
+		page.Swaps = append(page.Swaps, Enrich(swaps[i]))
+	}
+	return page, nil
+}
+
+// Enrich adds ticker, side, amounts, USD value and wallet label to swap
+// Token metadata comes from token cache (Luminex on first request of pool)
+func Enrich(swap flashnet.Swap) Swap {
+	// Swap of unknown time is shown with time it is enriched
+	at, ok := storage.SwapTime(swap)
+	if !ok {
+		at = time.Now()
+	}
+	at = at.UTC()
+	enriched := Swap{
+		ID:              swap.ID,
+		Time:            at.Format(time.RFC3339),
+		PoolLpPublicKey: swap.PoolLpPublicKey,
+		Side:            swapSide(swap),
+		Wallet:          swap.SwapperPublicKey,
+		WalletLabel:     wallet_labels.Of(swap.SwapperPublicKey),
+	}
+
+	var tokenAmount string
+	switch enriched.Side {
+	case SideBuy:
+		tokenAmount, enriched.TokenAddress = swap.AmountOut, swap.AssetOutAddress
+	case SideSell:
+		tokenAmount, enriched.TokenAddress = swap.AmountIn, swap.AssetInAddress
+	default:
+		return enriched
+	}
+
+	decimals := defaultTokenDecimals
+	if metadata := luminex.GetTokenMetadata(swap.PoolLpPublicKey); metadata != nil {
+		enriched.Ticker = metadata.Ticker
+		if metadata.Decimals > 0 {
+			decimals = metadata.Decimals
+		}
+	}
+
+	if btc, ok := swap.BTCAmount(); ok {
+		enriched.AmountBTC = btc
+		// BTC price history is kept by local date
+		if usd, ok := btc_price.ToUSD(btc, at.In(time.Local).Format("2006-01-02")); ok {
+			enriched.ValueUSD = usd
+		}
+	}
+	if tokens, err := amount.ScaleFloat(tokenAmount, decimals); err == nil {
+		enriched.AmountToken = tokens
+	}
+	return enriched
+}
+
+func (f Filter) matches(swap flashnet.Swap, tickers map[string]string) bool {
+	if f.Pool != "" && swap.PoolLpPublicKey != f.Pool {
+		return false
+	}
+	if f.Wallet != "" && !strings.EqualFold(swap.SwapperPublicKey, f.Wallet) {
+		return false
+	}
+	if f.Side != "" && swapSide(swap) != f.Side {
+		return false
+	}
+	if f.MinBTC > 0 {
+		if btc, _ := swap.BTCAmount(); btc < f.MinBTC {
+			return false
+		}
+	}
+	if f.Ticker != "" {
+		ticker, known := tickers[swap.PoolLpPublicKey]
+		if !known {
+			if metadata := luminex.GetTokenMetadata(swap.PoolLpPublicKey); metadata != nil {
+				ticker = metadata.Ticker
+			}
+			tickers[swap.PoolLpPublicKey] = ticker
+		}
+		if !strings.EqualFold(ticker, f.Ticker) {
+			return false
+		}
+	}
+	return true
+}
+
+func swapSide(swap flashnet.Swap) string {
+	switch swap.GetSwapType() {
+	case flashnet.SwapTypeBuy:
+		return SideBuy
+	case flashnet.SwapTypeSell:
+		return SideSell
+	}
+	return SideSwap
+}
@@ -0,0 +1,41 @@
+package swap_templates
+
+// Storage migration seeding templates that used to be hardcoded
+
+import (
+	"fmt"
+	"os"
+
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// soonPoolLpPublicKey - poolLpPublicKey of SOON token
+	soonPoolLpPublicKey = "021cda97a28df127f41e480ebede196f6f7d46dd6754feab7c228d8273dce6d39e"
+	// soonBuyPhotoURL - photo of SOON buy
+	soonBuyPhotoURL = "https://i.ibb.co/VsXVSdx/soongreen.jpg"
+	// soonSellPhotoURL - photo of SOON sell
+	soonSellPhotoURL = "https://i.ibb.co/hRN5G3qn/Gemini-Generated-Image-rzyanmrzyanmrzya.png"
+)
+
+// MigrateSOONPhotos writes template with SOON buy/sell photos (previously hardcoded in big sales monitor)
+// Existing template file of SOON is kept as is
+func MigrateSOONPhotos() error {
+	if _, err := os.Stat(templatePath(soonPoolLpPublicKey)); err == nil {
+		logging.LogInfo("SOON template already exists, skipping")
+		return nil
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to stat SOON template: %w", err)
+	}
+
+	if err := SaveTemplate(soonPoolLpPublicKey, &Template{
+		BuyPhotoURL:  soonBuyPhotoURL,
+		SellPhotoURL: soonSellPhotoURL,
+	}); err != nil {
+		return err
+	}
+	logging.LogInfo("Created SOON template with buy/sell photos", zap.String("dir", TemplatesDir()))
+	return nil
+}
@@ -0,0 +1,342 @@
+package swap_templates
+
+// Per-token templates of swap notifications (data_in/templates/{poolLpPublicKey}.json)
+// Message is rendered with text/template from SwapData, token without template text gets default layout
+// of chat language (DefaultText, DefaultTextRU)
+// Values of SwapData are HTML-escaped, template itself may use Telegram HTML tags
+// Template files are re-read when changed, broken template falls back to default layout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// DefaultBuyEmoji - emoji of buy alert
+	DefaultBuyEmoji = "🟢"
+	// DefaultSellEmoji - emoji of sell alert
+	DefaultSellEmoji = "🔴"
+	// DefaultButtonText - text of trade button (English, see i18n key swap.trade_button)
+	DefaultButtonText = "Trade on Luminex"
+)
+
+// TemplatesDir - directory of per-token template files
+func TemplatesDir() string {
+	return paths.Data("templates")
+}
+
+// DefaultText - layout of swap notification (Telegram HTML)
+const DefaultText = "{{.WhaleBadge}}{{.FundingWarning}}{{.Emoji}} {{.Action}} {{.TokenName}} - {{.BTCAmount}} btc{{if .USDAmount}} ≈ {{.USDAmount}}{{end}}{{if .TokenAmount}} ({{.TokenAmount}}){{end}}\n" +
+	"<blockquote>{{if .MarketCap}}Market cap - {{.MarketCap}}\n{{end}}" +
+	"Buyer wallet - {{if .WalletLink}}<a href=\"{{.WalletLink}}\">{{.WalletName}}</a>{{else}}{{.WalletName}}{{end}} ({{.WalletSuffix}})\n" +
+	"{{if .FirstBuy}}First buy - {{.FirstBuy}}\n{{end}}" +
+	"{{if .Holding}}Holding right now - {{.Holding}}{{if .HoldingValue}} ({{.HoldingValue}}){{end}}\n{{end}}" +
+	"{{if .Balance}}Current net balance - {{.Balance}} btc{{end}}</blockquote>"
+
+// DefaultTextRU - layout of swap notification in Russian
+const DefaultTextRU = "{{.WhaleBadge}}{{.FundingWarning}}{{.Emoji}} {{.Action}} {{.TokenName}} - {{.BTCAmount}} btc{{if .USDAmount}} ≈ {{.USDAmount}}{{end}}{{if .TokenAmount}} ({{.TokenAmount}}){{end}}\n" +
+	"<blockquote>{{if .MarketCap}}Капитализация - {{.MarketCap}}\n{{end}}" +
+	"Кошелек - {{if .WalletLink}}<a href=\"{{.WalletLink}}\">{{.WalletName}}</a>{{else}}{{.WalletName}}{{end}} ({{.WalletSuffix}})\n" +
+	"{{if .FirstBuy}}Первая покупка - {{.FirstBuy}}\n{{end}}" +
+	"{{if .Holding}}Держит сейчас - {{.Holding}}{{if .HoldingValue}} ({{.HoldingValue}}){{end}}\n{{end}}" +
+	"{{if .Balance}}Баланс кошелька - {{.Balance}} btc{{end}}</blockquote>"
+
+// Template - notification template of one token, empty fields use defaults
+type Template struct {
+	Text         string   `json:"text,omitempty"`           // text/template of message, empty - default layout of chat language
+	BuyEmoji     string   `json:"buy_emoji,omitempty"`      // empty - DefaultBuyEmoji
+	SellEmoji    string   `json:"sell_emoji,omitempty"`     // empty - DefaultSellEmoji
+	BuyPhotoURL  string   `json:"buy_photo_url,omitempty"`  // buy alert in filtered chat is sent as photo with message as caption
+	SellPhotoURL string   `json:"sell_photo_url,omitempty"` // same for sell alert
+	Buttons      []Button `json:"buttons,omitempty"`        // inline buttons (one per row), empty - trade button
+}
+
+// Button - inline URL button, Text and URL are templates of SwapData
+type Button struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// SwapData - values available in templates
+type SwapData struct {
+	IsBuy            bool
+	IsSell           bool
+	Emoji            string
+	Action           string // Buy or Sell in language of chat
+	TokenName        string // "Name {TICKER}" or poolLpPublicKey
+	Ticker           string
+	Name             string
+	BTCAmount        string
+	USDAmount        string // "$1.2K" at current BTC price, empty if price is unknown
+	TokenAmount      string // empty if unknown
+	MarketCap        string // "$1.2M", empty if unknown
+	WalletName       string // wallet label, username, "wallet" or swapper public key
+	WalletLink       string // empty if wallet balance is unknown
+	WalletSuffix     string // last 3 chars of swapper public key
+	FirstBuy         string // date of first buy, empty if unknown
+	Holding          string // token holding ("null" if not found), empty if token is unknown
+	HoldingValue     string
+	Balance          string // BTC balance of wallet, empty if unknown
+	WhaleBadge       string // "🐋 ..." line or empty
+	FundingWarning   string // "⚠️ ..." line or empty
+	TradeLink        string
+	PoolLpPublicKey  string
+	SwapperPublicKey string
+	Lang             i18n.Lang // language of chat, empty - English
+}
+
+// Rendered - notification rendered from template
+type Rendered struct {
+	Text    string
+	Buttons []Button // rendered buttons
+}
+
+type cachedTemplate struct {
+	modTime  time.Time
+	template *Template
+	text     *template.Template // nil - default layout of chat language
+	buttons  []parsedButton
+}
+
+type parsedButton struct {
+	text *template.Template
+	url  *template.Template
+}
+
+var (
+	templatesCache = make(map[string]*cachedTemplate)
+	templatesMutex sync.Mutex
+
+	defaultTexts = map[i18n.Lang]*template.Template{
+		i18n.English: template.Must(template.New("default").Parse(DefaultText)),
+		i18n.Russian: template.Must(template.New("default_ru").Parse(DefaultTextRU)),
+	}
+)
+
+// Layouts of swap notification (audit trail)
+const (
+	LayoutDefault = "default" // default layout of chat language
+	LayoutToken   = "token"   // text of token template
+)
+
+// Layout returns layout swap notifications of token are rendered with
+func Layout(poolLpPublicKey string) string {
+	cached, err := load(poolLpPublicKey)
+	if err != nil || cached == nil || cached.text == nil {
+		return LayoutDefault
+	}
+	return LayoutToken
+}
+
+// Emojis returns buy and sell emoji of token
+func Emojis(poolLpPublicKey string) (buy string, sell string) {
+	buy, sell = DefaultBuyEmoji, DefaultSellEmoji
+	cached, err := load(poolLpPublicKey)
+	if err != nil || cached == nil {
+		return buy, sell
+	}
+	if cached.template.BuyEmoji != "" {
+		buy = cached.template.BuyEmoji
+	}
+	if cached.template.SellEmoji != "" {
+		sell = cached.template.SellEmoji
+	}
+	return buy, sell
+}
+
+// PhotoURL returns photo of token buy (buy = true) or sell alert, empty if token has no photo
+func PhotoURL(poolLpPublicKey string, buy bool) string {
+	cached, err := load(poolLpPublicKey)
+	if err != nil || cached == nil {
+		return ""
+	}
+	if buy {
+		return cached.template.BuyPhotoURL
+	}
+	return cached.template.SellPhotoURL
+}
+
+// Render renders swap notification with template of token (default layout if there is none)
+// Emoji of data is replaced with emoji of token template
+// Error is returned with default rendering if token template is broken
+func Render(data SwapData) (*Rendered, error) {
+	cached, loadErr := load(data.PoolLpPublicKey)
+	if loadErr != nil || cached == nil {
+		rendered, err := renderDefault(data)
+		if err != nil {
+			return nil, err
+		}
+		return rendered, loadErr
+	}
+
+	rendered := &Rendered{}
+	switch {
+	case data.IsBuy:
+		if cached.template.BuyEmoji != "" {
+			data.Emoji = cached.template.BuyEmoji
+		}
+	case data.IsSell:
+		if cached.template.SellEmoji != "" {
+			data.Emoji = cached.template.SellEmoji
+		}
+	}
+
+	textTemplate := cached.text
+	if textTemplate == nil {
+		textTemplate = defaultTextOf(data.Lang)
+	}
+	text, err := execute(textTemplate, data)
+	if err != nil {
+		fallback, defaultErr := renderDefault(data)
+		if defaultErr != nil {
+			return nil, defaultErr
+		}
+		return fallback, fmt.Errorf("failed to render template of %s: %w", data.PoolLpPublicKey, err)
+	}
+	rendered.Text = text
+
+	for _, button := range cached.buttons {
+		buttonText, err := execute(button.text, data)
+		if err != nil {
+			return rendered, fmt.Errorf("failed to render button of %s: %w", data.PoolLpPublicKey, err)
+		}
+		buttonURL, err := execute(button.url, data)
+		if err != nil {
+			return rendered, fmt.Errorf("failed to render button of %s: %w", data.PoolLpPublicKey, err)
+		}
+		rendered.Buttons = append(rendered.Buttons, Button{Text: buttonText, URL: strings.TrimSpace(buttonURL)})
+	}
+	if len(rendered.Buttons) == 0 {
+		rendered.Buttons = defaultButtons(data)
+	}
+	return rendered, nil
+}
+
+// SaveTemplate writes template file of token (used to seed built-in templates)
+func SaveTemplate(poolLpPublicKey string, tmpl *Template) error {
+	if err := os.MkdirAll(TemplatesDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(tmpl, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal template JSON: %w", err)
+	}
+
+	filePath := templatePath(poolLpPublicKey)
+	tempFilePath := filePath + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary template file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, filePath); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to template file: %w", err)
+	}
+	return nil
+}
+
+func renderDefault(data SwapData) (*Rendered, error) {
+	text, err := execute(defaultTextOf(data.Lang), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render default template: %w", err)
+	}
+	return &Rendered{Text: text, Buttons: defaultButtons(data)}, nil
+}
+
+// defaultTextOf returns default layout of language (English if language has none)
+func defaultTextOf(lang i18n.Lang) *template.Template {
+	if tmpl, exists := defaultTexts[lang]; exists {
+		return tmpl
+	}
+	return defaultTexts[i18n.English]
+}
+
+func defaultButtons(data SwapData) []Button {
+	return []Button{{Text: i18n.T(data.Lang, "swap.trade_button"), URL: data.TradeLink}}
+}
+
+func execute(tmpl *template.Template, data SwapData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func templatePath(poolLpPublicKey string) string {
+	return filepath.Join(TemplatesDir(), poolLpPublicKey+".json")
+}
+
+// load returns parsed template of token (nil if token has no template file)
+func load(poolLpPublicKey string) (*cachedTemplate, error) {
+	// poolLpPublicKey is hex, anything else can't be a template file name
+	if poolLpPublicKey == "" || strings.ContainsAny(poolLpPublicKey, `/\.`) {
+		return nil, nil
+	}
+
+	filePath := templatePath(poolLpPublicKey)
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
+		templatesMutex.Lock()
+		delete(templatesCache, poolLpPublicKey)
+		templatesMutex.Unlock()
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat template file: %w", err)
+	}
+
+	templatesMutex.Lock()
+	defer templatesMutex.Unlock()
+
+	if cached, exists := templatesCache[poolLpPublicKey]; exists && cached.modTime.Equal(info.ModTime()) {
+		return cached, nil
+	}
+
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	var tmpl Template
+	if err := json.Unmarshal(raw, &tmpl); err != nil {
+		return nil, fmt.Errorf("failed to parse template JSON %s: %w", filePath, err)
+	}
+
+	// Template without text uses default layout of chat language (text is nil)
+	cached := &cachedTemplate{modTime: info.ModTime(), template: &tmpl}
+	if tmpl.Text != "" {
+		cached.text, err = template.New(poolLpPublicKey).Parse(tmpl.Text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", filePath, err)
+		}
+	}
+	for i, button := range tmpl.Buttons {
+		if button.Text == "" || button.URL == "" {
+			return nil, fmt.Errorf("template %s: button %d needs text and url", filePath, i+1)
+		}
+		buttonText, err := template.New("").Parse(button.Text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse button %d of template %s: %w", i+1, filePath, err)
+		}
+		buttonURL, err := template.New("").Parse(button.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse button %d of template %s: %w", i+1, filePath, err)
+		}
+		cached.buttons = append(cached.buttons, parsedButton{text: buttonText, url: buttonURL})
+	}
+
+	templatesCache[poolLpPublicKey] = cached
+	return cached, nil
+}
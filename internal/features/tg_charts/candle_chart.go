@@ -0,0 +1,189 @@
+package tg_charts
+
+// Candlestick chart for /chart {ticker} {timeframe}: OHLC price in sats per token with volume bars
+// Flat candles of buckets without swaps are drawn as thin grey line
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/features/candles"
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+const (
+	candleChartWidth  = 1600
+	candleChartHeight = 900
+
+	candleAreaLeft   = 60.0
+	candleAreaRight  = 1400.0
+	candleAreaTop    = 180.0
+	candleAreaBottom = 640.0
+	volumeAreaTop    = 680.0
+	volumeAreaBottom = 800.0
+
+	candleTitleFontSize  = 48.0
+	candleLegendFontSize = 28.0
+	candleAxisFontSize   = 22.0
+)
+
+var (
+	candleUpColor    = color.RGBA{0, 200, 110, 255}
+	candleDownColor  = color.RGBA{230, 60, 60, 255}
+	candleFlatColor  = color.RGBA{110, 110, 110, 255}
+	candleGridColor  = color.RGBA{45, 45, 45, 255}
+	candleVolumeDim  = uint8(140)
+	candleLabelColor = color.RGBA{200, 200, 200, 255}
+)
+
+// GenerateCandleChart draws candles (oldest first) of token for timeframe
+// Returns path of PNG file
+func GenerateCandleChart(ticker string, timeframe candles.Timeframe, points []candles.Candle) (string, error) {
+	if len(points) < 2 {
+		return "", fmt.Errorf("not enough data for candle chart")
+	}
+
+	dc := gg.NewContext(candleChartWidth, candleChartHeight)
+	dc.SetColor(color.Black)
+	dc.Clear()
+
+	fontPath, fontLoaded := loadChartFont(dc)
+	setFontSize := func(size float64) {
+		if fontLoaded {
+			dc.LoadFontFace(fontPath, size)
+		}
+	}
+
+	// Price range and volume of period
+	low, high := points[0].Low, points[0].High
+	maxVolume, volume := 0.0, 0.0
+	for _, point := range points {
+		low = min(low, point.Low)
+		high = max(high, point.High)
+		maxVolume = max(maxVolume, point.VolumeBTC)
+		volume += point.VolumeBTC
+	}
+	span := high - low
+	if span <= 0 {
+		// Flat price - center it
+		span = high * 0.02
+		low -= span / 2
+		high += span / 2
+	}
+
+	// Title and legend
+	first, last := points[0], points[len(points)-1]
+	setFontSize(candleTitleFontSize)
+	dc.SetColor(color.White)
+	dc.DrawString(fmt.Sprintf("{%s} %s", strings.ToUpper(ticker), timeframe.Name), candleAreaLeft, 90)
+
+	change := "n/a"
+	if first.Open > 0 {
+		change = fmt.Sprintf("%+.2f%%", (last.Close-first.Open)/first.Open*100)
+	}
+	changeColor := candleUpColor
+	if last.Close < first.Open {
+		changeColor = candleDownColor
+	}
+	setFontSize(candleLegendFontSize)
+	dc.SetColor(changeColor)
+	dc.DrawString(fmt.Sprintf("%s sats (%s)", formatCandleSats(last.Close), change), candleAreaLeft, 140)
+	dc.SetColor(candleLabelColor)
+	dc.DrawStringAnchored(fmt.Sprintf("Volume %s BTC", strconv.FormatFloat(volume, 'f', 4, 64)), candleAreaRight, 140, 1, 0)
+
+	areaHeight := candleAreaBottom - candleAreaTop
+	step := (candleAreaRight - candleAreaLeft) / float64(len(points))
+	bodyWidth := max(step*0.7, 1)
+	xFor := func(i int) float64 {
+		return candleAreaLeft + (float64(i)+0.5)*step
+	}
+	yFor := func(price float64) float64 {
+		return candleAreaBottom - (price-low)/(high-low)*areaHeight
+	}
+
+	// Horizontal grid with price labels
+	setFontSize(candleAxisFontSize)
+	for i := 0; i <= 4; i++ {
+		price := low + (high-low)*float64(i)/4
+		y := yFor(price)
+		dc.SetColor(candleGridColor)
+		dc.SetLineWidth(1)
+		dc.DrawLine(candleAreaLeft, y, candleAreaRight, y)
+		dc.Stroke()
+		dc.SetColor(candleLabelColor)
+		dc.DrawStringAnchored(formatCandleSats(price), candleAreaRight+15, y, 0, 0.35)
+	}
+
+	// Candles and volume bars
+	for i, point := range points {
+		x := xFor(i)
+		candleColor := candleUpColor
+		if point.Close < point.Open {
+			candleColor = candleDownColor
+		}
+		if point.Trades == 0 {
+			dc.SetColor(candleFlatColor)
+			dc.SetLineWidth(2)
+			dc.DrawLine(x-bodyWidth/2, yFor(point.Close), x+bodyWidth/2, yFor(point.Close))
+			dc.Stroke()
+			continue
+		}
+
+		dc.SetColor(candleColor)
+		dc.SetLineWidth(2)
+		dc.DrawLine(x, yFor(point.High), x, yFor(point.Low))
+		dc.Stroke()
+
+		top, bottom := yFor(max(point.Open, point.Close)), yFor(min(point.Open, point.Close))
+		dc.DrawRectangle(x-bodyWidth/2, top, bodyWidth, max(bottom-top, 2))
+		dc.Fill()
+
+		if maxVolume > 0 {
+			height := point.VolumeBTC / maxVolume * (volumeAreaBottom - volumeAreaTop)
+			dc.SetColor(color.RGBA{candleColor.R, candleColor.G, candleColor.B, candleVolumeDim})
+			dc.DrawRectangle(x-bodyWidth/2, volumeAreaBottom-height, bodyWidth, height)
+			dc.Fill()
+		}
+	}
+
+	// Times (UTC): first, middle, last
+	timeLayout := "15:04"
+	if last.Time.Sub(first.Time) > 24*time.Hour {
+		timeLayout = "02 Jan 15:04"
+	}
+	dc.SetColor(color.White)
+	dc.DrawStringAnchored(first.Time.Format(timeLayout), xFor(0), volumeAreaBottom+45, 0, 0)
+	middle := len(points) / 2
+	dc.DrawStringAnchored(points[middle].Time.Format(timeLayout), xFor(middle), volumeAreaBottom+45, 0.5, 0)
+	dc.DrawStringAnchored(last.Time.Format(timeLayout)+" UTC", xFor(len(points)-1), volumeAreaBottom+45, 1, 0)
+
+	chartsDir := paths.Charts()
+	if err := os.MkdirAll(chartsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create charts directory: %w", err)
+	}
+
+	filename := filepath.Join(chartsDir, fmt.Sprintf("candles_%s_%s.png", strings.ToLower(ticker), timeframe.Name))
+	if err := dc.SavePNG(filename); err != nil {
+		return "", fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	logging.LogInfo("Candle chart generated successfully",
+		zap.String("filename", filename),
+		zap.Int("candles", len(points)))
+
+	return filename, nil
+}
+
+// formatCandleSats - price in sats per token, 4 significant digits
+func formatCandleSats(priceBTC float64) string {
+	return strconv.FormatFloat(priceBTC*1e8, 'g', 4, 64)
+}
@@ -0,0 +1,328 @@
+package tg_charts
+
+// Chart core: themed canvas, plot area, value and time scales, axes, gridlines, ticks and series
+// Volume (/stats) and BTC reserve (/spark) charts are built on it, a new chart type only
+// declares its scale and series and draws them with the helpers below
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+// Layout shared by charts of chartWidth x chartHeight
+const (
+	chartAreaLeft   = 300.0
+	chartAreaRight  = 2000.0
+	chartAreaTop    = 400.0 // below headline
+	chartAreaBottom = 1200.0
+
+	headlineLabelY    = 160.0
+	headlineValueY    = 240.0
+	headlineLabelSize = 35.0
+	headlineValueSize = 70.0 // 2x of label
+
+	mainFontSize = 35.0
+	dateFontSize = 28.0
+
+	axisLineWidth   = 2.0
+	gridLineWidth   = 1.0
+	tickLength      = 8.0
+	tickLabelOffset = 10.0
+	dateOffsetY     = 40.0
+)
+
+// gridDash - dash pattern of dashed gridlines
+var gridDash = []float64{10, 5}
+
+// plotArea - rectangle of chart data in layout coordinates
+type plotArea struct {
+	Left, Right, Top, Bottom float64
+}
+
+// defaultPlotArea - plot area of volume and BTC reserve charts
+var defaultPlotArea = plotArea{Left: chartAreaLeft, Right: chartAreaRight, Top: chartAreaTop, Bottom: chartAreaBottom}
+
+func (a plotArea) Width() float64  { return a.Right - a.Left }
+func (a plotArea) Height() float64 { return a.Bottom - a.Top }
+
+// X returns X of position in area (0 - left edge, 1 - right edge)
+func (a plotArea) X(ratio float64) float64 { return a.Left + ratio*a.Width() }
+
+// Y returns Y of value on scale (Min - bottom edge, Max - top edge)
+func (a plotArea) Y(scale valueScale, value float64) float64 {
+	return a.Bottom - scale.Ratio(value)*a.Height()
+}
+
+// ContainsY reports whether Y is inside area
+func (a plotArea) ContainsY(y float64) bool { return y >= a.Top && y <= a.Bottom }
+
+// valueScale - Y axis from Min to Max with gridlines every Step
+type valueScale struct {
+	Min, Max, Step float64
+}
+
+// stepScaleWithHeadroom returns scale from 0 rounded up to Step with one more step above max (volume bars)
+func stepScaleWithHeadroom(max, step float64) valueScale {
+	if max <= 0 {
+		return valueScale{Min: 0, Max: step, Step: step}
+	}
+	return valueScale{Min: 0, Max: (math.Ceil(max/step) + 1) * step, Step: step}
+}
+
+// paddedStepScale returns scale around [min, max] rounded to Step with padding of a step
+// (half a step for range of 2+ steps), clamped at 0 (BTC reserve line)
+func paddedStepScale(min, max, step float64) valueScale {
+	scale := valueScale{
+		Min:  math.Floor(min/step) * step,
+		Max:  (math.Floor(max/step) + 1) * step,
+		Step: step,
+	}
+	pad := step * 0.5
+	if scale.Max-scale.Min < step*2 {
+		pad = step
+	}
+	scale.Min = math.Max(scale.Min-pad, 0)
+	scale.Max += pad
+	return scale
+}
+
+// Ratio returns position of value on scale (0 - Min, 1 - Max)
+func (s valueScale) Ratio(value float64) float64 {
+	if s.Max == s.Min {
+		return 0
+	}
+	return (value - s.Min) / (s.Max - s.Min)
+}
+
+// Ticks returns values of gridlines at multiples of Step from Min up to Max, at most limit lines (0 - no limit)
+func (s valueScale) Ticks(limit int) []float64 {
+	if s.Step <= 0 {
+		return nil
+	}
+	var ticks []float64
+	for i := math.Ceil(s.Min / s.Step); i*s.Step <= s.Max; i++ {
+		if limit > 0 && len(ticks) == limit {
+			break
+		}
+		ticks = append(ticks, i*s.Step)
+	}
+	return ticks
+}
+
+// timeScale - X axis from From to To
+type timeScale struct {
+	From, To time.Time
+}
+
+// newTimeScale returns scale of sorted times, a single moment is stretched to a day
+func newTimeScale(from, to time.Time) timeScale {
+	if !to.After(from) {
+		to = from.Add(24 * time.Hour)
+	}
+	return timeScale{From: from, To: to}
+}
+
+// Ratio returns position of time on scale (0 - From, 1 - To)
+func (s timeScale) Ratio(t time.Time) float64 {
+	return float64(t.Sub(s.From)) / float64(s.To.Sub(s.From))
+}
+
+// seriesPoint - point of line series in layout coordinates
+type seriesPoint struct {
+	X, Y  float64
+	Label string // X label (date), empty - no tick
+}
+
+// chartCanvas - chart drawn in chartWidth x chartHeight layout in theme of chat
+type chartCanvas struct {
+	dc       *gg.Context
+	theme    Theme
+	fontPath string
+	hasFont  bool
+}
+
+// newChartCanvas returns canvas with theme background, logo and font of chat
+func newChartCanvas(chatID int64) *chartCanvas {
+	theme := ThemeFor(chatID)
+	dc := gg.NewContext(chartWidth, chartHeight)
+	dc.SetColor(theme.Background)
+	dc.Clear()
+
+	drawThemeLogo(dc, theme)
+
+	fontPath, hasFont := loadThemeFont(dc, theme, mainFontSize)
+	return &chartCanvas{dc: dc, theme: theme, fontPath: fontPath, hasFont: hasFont}
+}
+
+// SetFontSize switches font of theme to size, default gg font has one size
+func (c *chartCanvas) SetFontSize(size float64) {
+	if c.hasFont {
+		c.dc.LoadFontFace(c.fontPath, size)
+	}
+}
+
+// DrawHeadline draws label with big value under it at X (Daily Volume, BTC Reserve)
+func (c *chartCanvas) DrawHeadline(x float64, label, value string, valueColor color.Color) {
+	c.SetFontSize(headlineLabelSize)
+	c.dc.SetColor(c.theme.Text)
+	c.dc.DrawString(label, x, headlineLabelY)
+
+	c.SetFontSize(headlineValueSize)
+	c.dc.SetColor(valueColor)
+	c.dc.DrawString(value, x, headlineValueY)
+
+	c.SetFontSize(mainFontSize)
+}
+
+// DrawAxes draws X axis at bottom and Y axis at left of area
+func (c *chartCanvas) DrawAxes(area plotArea) {
+	c.setLine(c.theme.Text, axisLineWidth)
+	c.dc.DrawLine(area.Left, area.Bottom, area.Right, area.Bottom)
+	c.dc.Stroke()
+	c.dc.DrawLine(area.Left, area.Top, area.Left, area.Bottom)
+	c.dc.Stroke()
+}
+
+// DrawHorizontalGrid draws gridlines of scale ticks from x0 to x1
+// With label format ticks are marked and labeled on Y axis
+func (c *chartCanvas) DrawHorizontalGrid(area plotArea, scale valueScale, ticks []float64, x0, x1 float64, dashed bool, label func(float64) string) {
+	for _, value := range ticks {
+		y := area.Y(scale, value)
+		if !area.ContainsY(y) {
+			continue
+		}
+		c.setLine(c.theme.Grid, gridLineWidth, c.dash(dashed)...)
+		c.dc.DrawLine(x0, y, x1, y)
+		c.dc.Stroke()
+
+		if label != nil {
+			c.DrawYTick(area, y, label(value))
+		}
+	}
+	c.dc.SetDash()
+}
+
+// DrawVerticalGrid draws dashed gridlines splitting area into equal sections
+func (c *chartCanvas) DrawVerticalGrid(area plotArea, sections int) {
+	c.setLine(c.theme.Grid, gridLineWidth, gridDash...)
+	for i := 0; i <= sections; i++ {
+		x := area.X(float64(i) / float64(sections))
+		c.dc.DrawLine(x, area.Top, x, area.Bottom)
+		c.dc.Stroke()
+	}
+	c.dc.SetDash()
+}
+
+// DrawYTick draws tick on Y axis with label right aligned before it
+func (c *chartCanvas) DrawYTick(area plotArea, y float64, label string) {
+	c.setLine(c.theme.Text, axisLineWidth)
+	c.dc.DrawLine(area.Left-tickLength, y, area.Left, y)
+	c.dc.Stroke()
+
+	c.SetFontSize(dateFontSize)
+	width, _ := c.dc.MeasureString(label)
+	c.dc.DrawString(label, area.Left-width-tickLabelOffset, y)
+}
+
+// DrawXTick draws tick on X axis with label centered under it
+func (c *chartCanvas) DrawXTick(area plotArea, x float64, label string) {
+	c.setLine(c.theme.Text, axisLineWidth)
+	c.dc.DrawLine(x, area.Bottom, x, area.Bottom+tickLength)
+	c.dc.Stroke()
+	c.DrawXLabel(area, x, label)
+}
+
+// DrawXLabel draws label centered at X under area
+func (c *chartCanvas) DrawXLabel(area plotArea, x float64, label string) {
+	c.SetFontSize(dateFontSize)
+	c.dc.SetColor(c.theme.Text)
+	width, _ := c.dc.MeasureString(label)
+	c.dc.DrawString(label, x-width/2, area.Bottom+dateOffsetY)
+}
+
+// DrawLineSeries draws line through points with dots, first point of every label gets X tick
+func (c *chartCanvas) DrawLineSeries(area plotArea, points []seriesPoint, lineColor color.Color, lineWidth, dotRadius float64) {
+	c.setLine(lineColor, lineWidth)
+	for i := 1; i < len(points); i++ {
+		c.dc.DrawLine(points[i-1].X, points[i-1].Y, points[i].X, points[i].Y)
+		c.dc.Stroke()
+	}
+	for _, point := range points {
+		c.dc.DrawCircle(point.X, point.Y, dotRadius)
+		c.dc.Fill()
+	}
+
+	labeled := make(map[string]bool)
+	for _, point := range points {
+		if point.Label == "" || labeled[point.Label] {
+			continue
+		}
+		labeled[point.Label] = true
+		c.DrawXTick(area, point.X, point.Label)
+	}
+}
+
+// DrawBar draws bar of value from bottom of area with value label above it
+func (c *chartCanvas) DrawBar(area plotArea, scale valueScale, x, width, value float64, valueLabel string, labelSize, labelOffset float64) {
+	top := area.Y(scale, value)
+	c.dc.SetColor(c.theme.Bar)
+	c.dc.DrawRectangle(x, top, width, area.Bottom-top)
+	c.dc.Fill()
+
+	if valueLabel == "" {
+		return
+	}
+	c.SetFontSize(labelSize)
+	c.dc.SetColor(c.theme.Text)
+	labelWidth, _ := c.dc.MeasureString(valueLabel)
+	c.dc.DrawString(valueLabel, x+(width-labelWidth)/2, top-labelOffset)
+}
+
+// Save saves chart to charts directory as name scaled to canvas of theme
+// Returns path and size of file
+func (c *chartCanvas) Save(name string) (string, int64, error) {
+	chartsDir := paths.Charts()
+	if err := os.MkdirAll(chartsDir, 0755); err != nil {
+		return "", 0, fmt.Errorf("failed to create charts directory: %w", err)
+	}
+
+	filename := filepath.Join(chartsDir, name)
+	if err := saveThemedChart(c.dc, c.theme, filename); err != nil {
+		return "", 0, fmt.Errorf("failed to save chart %s: %w", name, err)
+	}
+
+	// Check file is written, empty PNG can't be sent to Telegram
+	fileInfo, err := os.Stat(filename)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to stat chart file: %w", err)
+	}
+	if fileInfo.Size() == 0 {
+		os.Remove(filename)
+		logging.LogError("Chart file is empty after rendering", zap.String("filename", filename))
+		return "", 0, fmt.Errorf("chart file %s is empty after rendering", name)
+	}
+	return filename, fileInfo.Size(), nil
+}
+
+func (c *chartCanvas) setLine(lineColor color.Color, width float64, dashes ...float64) {
+	c.dc.SetColor(lineColor)
+	c.dc.SetLineWidth(width)
+	c.dc.SetDash(dashes...)
+}
+
+func (c *chartCanvas) dash(dashed bool) []float64 {
+	if dashed {
+		return gridDash
+	}
+	return nil
+}
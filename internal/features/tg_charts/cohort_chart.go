@@ -0,0 +1,112 @@
+package tg_charts
+
+// Cohort chart for /cohort {ticker} {week}: buyers by week as stacked bars (first-time and returning),
+// retention of previous weeks in report week is written above their bars
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"spark-wallet/internal/features/holders"
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+var (
+	cohortFirstTimeColor = color.RGBA{0, 200, 120, 255}
+	cohortReturningColor = color.RGBA{80, 160, 255, 255}
+	cohortRetentionColor = color.RGBA{247, 147, 26, 255}
+)
+
+// GenerateCohortChart draws buyers of report weeks split into first-time and returning
+// Returns path of PNG file
+func GenerateCohortChart(report *holders.CohortReport) (string, error) {
+	maxBuyers := 0
+	for _, week := range report.Weeks {
+		if week.Buyers > maxBuyers {
+			maxBuyers = week.Buyers
+		}
+	}
+	if maxBuyers == 0 {
+		return "", fmt.Errorf("no buyers for cohort chart")
+	}
+
+	dc := gg.NewContext(communityChartWidth, communityChartHeight)
+	dc.SetColor(color.Black)
+	dc.Clear()
+
+	fontPath, fontLoaded := loadChartFont(dc)
+	setFontSize := func(size float64) {
+		if fontLoaded {
+			dc.LoadFontFace(fontPath, size)
+		}
+	}
+
+	// Title and legend
+	setFontSize(communityTitleFontSize)
+	dc.SetColor(color.White)
+	dc.DrawString(fmt.Sprintf("{%s} buyers by week", strings.ToUpper(report.Ticker)), communityAreaLeft, 90)
+	setFontSize(communityLegendFontSize)
+	dc.SetColor(cohortFirstTimeColor)
+	dc.DrawString("First-time", communityAreaLeft, 150)
+	dc.SetColor(cohortReturningColor)
+	dc.DrawString("Returning", communityAreaLeft+200, 150)
+	dc.SetColor(cohortRetentionColor)
+	dc.DrawString(fmt.Sprintf("Bought again in %s", report.Current().Label()), communityAreaLeft+400, 150)
+
+	areaWidth := communityAreaRight - communityAreaLeft
+	areaHeight := communityAreaBottom - communityAreaTop
+	slot := areaWidth / float64(len(report.Weeks))
+	barWidth := slot * 0.6
+	heightFor := func(buyers int) float64 {
+		return float64(buyers) / float64(maxBuyers) * areaHeight * 0.85
+	}
+
+	for i, week := range report.Weeks {
+		x := communityAreaLeft + slot*float64(i) + (slot-barWidth)/2
+		center := x + barWidth/2
+
+		firstTimeHeight := heightFor(week.FirstTime)
+		returningHeight := heightFor(week.Returning)
+		dc.SetColor(cohortFirstTimeColor)
+		dc.DrawRectangle(x, communityAreaBottom-firstTimeHeight, barWidth, firstTimeHeight)
+		dc.Fill()
+		dc.SetColor(cohortReturningColor)
+		dc.DrawRectangle(x, communityAreaBottom-firstTimeHeight-returningHeight, barWidth, returningHeight)
+		dc.Fill()
+
+		top := communityAreaBottom - firstTimeHeight - returningHeight
+		setFontSize(communityDateFontSize)
+		dc.SetColor(color.White)
+		dc.DrawStringAnchored(fmt.Sprintf("%d", week.Buyers), center, top-15, 0.5, 0)
+		if i < len(report.Weeks)-1 && week.Buyers > 0 {
+			dc.SetColor(cohortRetentionColor)
+			dc.DrawStringAnchored(fmt.Sprintf("%.0f%%", week.RetentionPercent()), center, top-50, 0.5, 0)
+		}
+
+		dc.SetColor(color.White)
+		dc.DrawStringAnchored(week.Start.Format("02 Jan"), center, communityAreaBottom+45, 0.5, 0)
+	}
+
+	chartsDir := paths.Charts()
+	if err := os.MkdirAll(chartsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create charts directory: %w", err)
+	}
+
+	filename := filepath.Join(chartsDir, fmt.Sprintf("cohort_%s.png", strings.ToLower(report.Ticker)))
+	if err := dc.SavePNG(filename); err != nil {
+		return "", fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	logging.LogInfo("Cohort chart generated successfully",
+		zap.String("filename", filename),
+		zap.Int("weeks", len(report.Weeks)))
+
+	return filename, nil
+}
@@ -0,0 +1,190 @@
+package tg_charts
+
+// Community chart for /community {ticker}: chat member count over token price and volume
+// Each series has its own scale, chart shows how they move together, not absolute values
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/community"
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+const (
+	communityChartWidth  = 1600
+	communityChartHeight = 900
+
+	communityAreaLeft   = 120.0
+	communityAreaRight  = 1480.0
+	communityAreaTop    = 200.0
+	communityAreaBottom = 780.0
+
+	communityTitleFontSize  = 48.0
+	communityLegendFontSize = 28.0
+	communityDateFontSize   = 24.0
+)
+
+var (
+	communityMembersColor = color.RGBA{80, 160, 255, 255}
+	communityPriceColor   = color.RGBA{0, 255, 0, 255}
+	communityVolumeColor  = color.RGBA{90, 90, 90, 255}
+)
+
+// GenerateCommunityChart draws member count line, price line and volume bars by day
+// Returns path of PNG file
+func GenerateCommunityChart(ticker string, points []community.Point) (string, error) {
+	if len(points) < 2 {
+		return "", fmt.Errorf("not enough data for community chart")
+	}
+
+	dc := gg.NewContext(communityChartWidth, communityChartHeight)
+	dc.SetColor(color.Black)
+	dc.Clear()
+
+	fontPath, fontLoaded := loadChartFont(dc)
+	setFontSize := func(size float64) {
+		if fontLoaded {
+			dc.LoadFontFace(fontPath, size)
+		}
+	}
+
+	// Title and legend
+	setFontSize(communityTitleFontSize)
+	dc.SetColor(color.White)
+	dc.DrawString(fmt.Sprintf("{%s} community", strings.ToUpper(ticker)), communityAreaLeft, 90)
+
+	first, last := points[0], points[len(points)-1]
+	membersFrom, membersTo := firstMembers(points), lastMembers(points)
+	setFontSize(communityLegendFontSize)
+	dc.SetColor(communityMembersColor)
+	dc.DrawString(fmt.Sprintf("Members: %d → %d", membersFrom, membersTo), communityAreaLeft, 150)
+	dc.SetColor(communityPriceColor)
+	dc.DrawString("Price", communityAreaLeft+520, 150)
+	dc.SetColor(communityVolumeColor)
+	dc.DrawString("Volume 24h", communityAreaLeft+680, 150)
+
+	areaWidth := communityAreaRight - communityAreaLeft
+	areaHeight := communityAreaBottom - communityAreaTop
+	step := areaWidth / float64(len(points)-1)
+	xFor := func(i int) float64 {
+		return communityAreaLeft + float64(i)*step
+	}
+
+	// Volume bars
+	maxVolume := 0.0
+	for _, point := range points {
+		if point.Volume24hUsd > maxVolume {
+			maxVolume = point.Volume24hUsd
+		}
+	}
+	if maxVolume > 0 {
+		barWidth := step * 0.6
+		if barWidth > 40 {
+			barWidth = 40
+		}
+		dc.SetColor(communityVolumeColor)
+		for i, point := range points {
+			height := point.Volume24hUsd / maxVolume * areaHeight * 0.5
+			dc.DrawRectangle(xFor(i)-barWidth/2, communityAreaBottom-height, barWidth, height)
+			dc.Fill()
+		}
+		setFontSize(communityDateFontSize)
+		dc.DrawStringAnchored(fmt.Sprintf("max $%s", luminex.FormatUSDValue(maxVolume)), communityAreaRight, communityAreaBottom-areaHeight*0.5-10, 1, 0)
+	}
+
+	// Lines, each scaled to own min..max
+	drawLine := func(value func(community.Point) float64, lineColor color.Color) {
+		minValue, maxValue := 0.0, 0.0
+		started := false
+		for _, point := range points {
+			v := value(point)
+			if v <= 0 {
+				continue
+			}
+			if !started || v < minValue {
+				minValue = v
+			}
+			if !started || v > maxValue {
+				maxValue = v
+			}
+			started = true
+		}
+		if !started {
+			return
+		}
+		span := maxValue - minValue
+		dc.SetColor(lineColor)
+		dc.SetLineWidth(4)
+		moved := false
+		for i, point := range points {
+			v := value(point)
+			if v <= 0 {
+				continue
+			}
+			y := communityAreaTop + areaHeight/2
+			if span > 0 {
+				y = communityAreaBottom - (v-minValue)/span*areaHeight
+			}
+			if !moved {
+				dc.MoveTo(xFor(i), y)
+				moved = true
+			} else {
+				dc.LineTo(xFor(i), y)
+			}
+		}
+		dc.Stroke()
+	}
+	drawLine(func(p community.Point) float64 { return p.PriceUsd }, communityPriceColor)
+	drawLine(func(p community.Point) float64 { return float64(p.Members) }, communityMembersColor)
+
+	// Dates: first, middle, last
+	setFontSize(communityDateFontSize)
+	dc.SetColor(color.White)
+	dc.DrawStringAnchored(first.Date.Format("02 Jan"), xFor(0), communityAreaBottom+45, 0, 0)
+	middle := len(points) / 2
+	dc.DrawStringAnchored(points[middle].Date.Format("02 Jan"), xFor(middle), communityAreaBottom+45, 0.5, 0)
+	dc.DrawStringAnchored(last.Date.Format("02 Jan"), xFor(len(points)-1), communityAreaBottom+45, 1, 0)
+
+	chartsDir := paths.Charts()
+	if err := os.MkdirAll(chartsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create charts directory: %w", err)
+	}
+
+	filename := filepath.Join(chartsDir, fmt.Sprintf("community_%s.png", strings.ToLower(ticker)))
+	if err := dc.SavePNG(filename); err != nil {
+		return "", fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	logging.LogInfo("Community chart generated successfully",
+		zap.String("filename", filename),
+		zap.Int("points", len(points)))
+
+	return filename, nil
+}
+
+func firstMembers(points []community.Point) int {
+	for _, point := range points {
+		if point.Members > 0 {
+			return point.Members
+		}
+	}
+	return 0
+}
+
+func lastMembers(points []community.Point) int {
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i].Members > 0 {
+			return points[i].Members
+		}
+	}
+	return 0
+}
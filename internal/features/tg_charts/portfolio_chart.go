@@ -0,0 +1,149 @@
+package tg_charts
+
+// Portfolio chart for /portfolio: BTC value of own wallet by day
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/portfolio"
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+const (
+	portfolioChartWidth  = 1600
+	portfolioChartHeight = 900
+
+	portfolioAreaLeft   = 120.0
+	portfolioAreaRight  = 1480.0
+	portfolioAreaTop    = 200.0
+	portfolioAreaBottom = 780.0
+)
+
+var (
+	portfolioLineColor = color.RGBA{247, 147, 26, 255}
+	portfolioFillColor = color.RGBA{247, 147, 26, 60}
+	portfolioGridColor = color.RGBA{60, 60, 60, 255}
+)
+
+// GeneratePortfolioChart draws BTC value line of wallet by day
+// Returns path of PNG file
+func GeneratePortfolioChart(points []portfolio.ValuePoint) (string, error) {
+	if len(points) < 2 {
+		return "", fmt.Errorf("not enough data for portfolio chart")
+	}
+
+	dc := gg.NewContext(portfolioChartWidth, portfolioChartHeight)
+	dc.SetColor(color.Black)
+	dc.Clear()
+
+	fontPath, fontLoaded := loadChartFont(dc)
+	setFontSize := func(size float64) {
+		if fontLoaded {
+			dc.LoadFontFace(fontPath, size)
+		}
+	}
+
+	first, last := points[0], points[len(points)-1]
+	minValue, maxValue := first.ValueBTC, first.ValueBTC
+	for _, point := range points {
+		if point.ValueBTC < minValue {
+			minValue = point.ValueBTC
+		}
+		if point.ValueBTC > maxValue {
+			maxValue = point.ValueBTC
+		}
+	}
+
+	// Title and legend
+	setFontSize(communityTitleFontSize)
+	dc.SetColor(color.White)
+	dc.DrawString("Portfolio", portfolioAreaLeft, 90)
+	setFontSize(communityLegendFontSize)
+	dc.SetColor(portfolioLineColor)
+	dc.DrawString(fmt.Sprintf("%.8f btc  ≈ $%s", last.ValueBTC, luminex.FormatUSDValue(last.ValueUsd)), portfolioAreaLeft, 150)
+
+	areaWidth := portfolioAreaRight - portfolioAreaLeft
+	areaHeight := portfolioAreaBottom - portfolioAreaTop
+	step := areaWidth / float64(len(points)-1)
+	xFor := func(i int) float64 {
+		return portfolioAreaLeft + float64(i)*step
+	}
+	span := maxValue - minValue
+	yFor := func(v float64) float64 {
+		if span <= 0 {
+			return portfolioAreaTop + areaHeight/2
+		}
+		return portfolioAreaBottom - (v-minValue)/span*areaHeight
+	}
+
+	// Min and max grid lines
+	setFontSize(communityDateFontSize)
+	dc.SetLineWidth(1)
+	for _, v := range []float64{minValue, maxValue} {
+		dc.SetColor(portfolioGridColor)
+		dc.DrawLine(portfolioAreaLeft, yFor(v), portfolioAreaRight, yFor(v))
+		dc.Stroke()
+		dc.SetColor(color.White)
+		dc.DrawStringAnchored(fmt.Sprintf("%.5f", v), portfolioAreaRight, yFor(v)-10, 1, 0)
+	}
+
+	// Area under line
+	dc.MoveTo(xFor(0), portfolioAreaBottom)
+	for i, point := range points {
+		dc.LineTo(xFor(i), yFor(point.ValueBTC))
+	}
+	dc.LineTo(xFor(len(points)-1), portfolioAreaBottom)
+	dc.ClosePath()
+	dc.SetColor(portfolioFillColor)
+	dc.Fill()
+
+	dc.SetColor(portfolioLineColor)
+	dc.SetLineWidth(4)
+	for i, point := range points {
+		if i == 0 {
+			dc.MoveTo(xFor(i), yFor(point.ValueBTC))
+		} else {
+			dc.LineTo(xFor(i), yFor(point.ValueBTC))
+		}
+	}
+	dc.Stroke()
+
+	// Dates: first, middle, last
+	dateOf := func(point portfolio.ValuePoint) string {
+		t, err := time.Parse(time.RFC3339, point.Time)
+		if err != nil {
+			return ""
+		}
+		return t.Format("02 Jan")
+	}
+	dc.SetColor(color.White)
+	dc.DrawStringAnchored(dateOf(first), xFor(0), portfolioAreaBottom+45, 0, 0)
+	middle := len(points) / 2
+	dc.DrawStringAnchored(dateOf(points[middle]), xFor(middle), portfolioAreaBottom+45, 0.5, 0)
+	dc.DrawStringAnchored(dateOf(last), xFor(len(points)-1), portfolioAreaBottom+45, 1, 0)
+
+	chartsDir := paths.Charts()
+	if err := os.MkdirAll(chartsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create charts directory: %w", err)
+	}
+
+	filename := filepath.Join(chartsDir, "portfolio.png")
+	if err := dc.SavePNG(filename); err != nil {
+		return "", fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	logging.LogInfo("Portfolio chart generated successfully",
+		zap.String("filename", filename),
+		zap.Int("points", len(points)))
+
+	return filename, nil
+}
@@ -0,0 +1,149 @@
+package tg_charts
+
+// Price sparkline for /price {ticker}: small line of token price over last days without axes
+// Line is green if price went up over period, red if down; min and max price are labeled
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"spark-wallet/internal/features/price_history"
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+const (
+	sparklineWidth  = 800
+	sparklineHeight = 260
+
+	sparklinePaddingX = 24.0
+	sparklineTop      = 70.0
+	sparklineBottom   = 236.0
+
+	sparklineTitleFontSize = 30.0
+	sparklineLabelFontSize = 22.0
+)
+
+var (
+	sparklineUpColor   = color.RGBA{0, 200, 83, 255}
+	sparklineDownColor = color.RGBA{255, 82, 82, 255}
+	sparklineLabel     = color.RGBA{170, 170, 170, 255}
+)
+
+// GeneratePriceSparkline draws USD price of samples (oldest first) as sparkline
+// Returns path of PNG file
+func GeneratePriceSparkline(ticker string, samples []price_history.PriceSample) (string, error) {
+	var prices []float64
+	for _, sample := range samples {
+		if sample.PriceUsd > 0 {
+			prices = append(prices, sample.PriceUsd)
+		}
+	}
+	if len(prices) < 2 {
+		return "", fmt.Errorf("not enough price samples for sparkline")
+	}
+
+	minPrice, maxPrice := prices[0], prices[0]
+	for _, price := range prices {
+		if price < minPrice {
+			minPrice = price
+		}
+		if price > maxPrice {
+			maxPrice = price
+		}
+	}
+
+	lineColor := sparklineUpColor
+	if prices[len(prices)-1] < prices[0] {
+		lineColor = sparklineDownColor
+	}
+
+	dc := gg.NewContext(sparklineWidth, sparklineHeight)
+	dc.SetColor(color.Black)
+	dc.Clear()
+
+	fontPath, fontLoaded := loadChartFont(dc)
+	setFontSize := func(size float64) {
+		if fontLoaded {
+			dc.LoadFontFace(fontPath, size)
+		}
+	}
+
+	setFontSize(sparklineTitleFontSize)
+	dc.SetColor(color.White)
+	dc.DrawString(fmt.Sprintf("{%s} 7d", strings.ToUpper(ticker)), sparklinePaddingX, 42)
+	setFontSize(sparklineLabelFontSize)
+	dc.SetColor(sparklineLabel)
+	dc.DrawStringAnchored(fmt.Sprintf("high $%s  low $%s", formatSparklinePrice(maxPrice), formatSparklinePrice(minPrice)),
+		sparklineWidth-sparklinePaddingX, 42, 1, 0)
+
+	areaWidth := sparklineWidth - 2*sparklinePaddingX
+	areaHeight := sparklineBottom - sparklineTop
+	span := maxPrice - minPrice
+	xFor := func(i int) float64 {
+		return sparklinePaddingX + float64(i)*areaWidth/float64(len(prices)-1)
+	}
+	yFor := func(price float64) float64 {
+		if span == 0 {
+			return sparklineTop + areaHeight/2
+		}
+		return sparklineBottom - (price-minPrice)/span*areaHeight
+	}
+
+	// Area under line
+	dc.MoveTo(xFor(0), sparklineBottom)
+	for i, price := range prices {
+		dc.LineTo(xFor(i), yFor(price))
+	}
+	dc.LineTo(xFor(len(prices)-1), sparklineBottom)
+	dc.ClosePath()
+	dc.SetColor(color.NRGBA{lineColor.R, lineColor.G, lineColor.B, 50})
+	dc.Fill()
+
+	dc.SetColor(lineColor)
+	dc.SetLineWidth(3)
+	for i, price := range prices {
+		if i == 0 {
+			dc.MoveTo(xFor(i), yFor(price))
+		} else {
+			dc.LineTo(xFor(i), yFor(price))
+		}
+	}
+	dc.Stroke()
+	dc.DrawCircle(xFor(len(prices)-1), yFor(prices[len(prices)-1]), 5)
+	dc.Fill()
+
+	chartsDir := paths.Charts()
+	if err := os.MkdirAll(chartsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create charts directory: %w", err)
+	}
+
+	filename := filepath.Join(chartsDir, fmt.Sprintf("price_%s.png", strings.ToLower(ticker)))
+	if err := dc.SavePNG(filename); err != nil {
+		return "", fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	logging.LogInfo("Price sparkline generated successfully",
+		zap.String("filename", filename),
+		zap.Int("points", len(prices)))
+
+	return filename, nil
+}
+
+// formatSparklinePrice formats USD price like price alerts (more decimals for small prices)
+func formatSparklinePrice(price float64) string {
+	switch {
+	case price >= 1:
+		return fmt.Sprintf("%.2f", price)
+	case price >= 0.01:
+		return fmt.Sprintf("%.4f", price)
+	default:
+		return fmt.Sprintf("%.8f", price)
+	}
+}
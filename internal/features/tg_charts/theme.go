@@ -0,0 +1,387 @@
+package tg_charts
+
+// Chart theme: canvas size, colors, fonts and branding logo of volume, BTC reserve and future charts
+// Declared in YAML file (app.chart_theme_file), loaded once on start and shared by all charts
+// Built-in dark (default) and light themes, file can override their colors or add new themes
+// Chats can have own theme, logo and font (chats: {chat_id: {theme, logo, font}})
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+	"go.yaml.in/yaml/v3"
+)
+
+// Built-in themes
+const (
+	ThemeDark  = "dark"
+	ThemeLight = "light"
+)
+
+// Layout of volume and BTC reserve charts, other canvas sizes are scaled from it
+const (
+	chartWidth  = 2326
+	chartHeight = 1334
+
+	defaultLogoX     = 200.0
+	defaultLogoY     = 30.0
+	defaultLogoScale = 0.3 // 1.0 - original size, 0.5 - 2 times smaller
+)
+
+// ThemeColors - colors of theme as hex (#rrggbb or #rrggbbaa), empty - color of built-in theme
+type ThemeColors struct {
+	Background string `yaml:"background"`
+	Text       string `yaml:"text"`
+	Grid       string `yaml:"grid"`
+	Bar        string `yaml:"bar"`
+	Accent     string `yaml:"accent"` // daily volume value, BTC reserve line
+	Logo       string `yaml:"logo"`   // logo of theme (dark logo on light background), empty - logo.path
+}
+
+// LogoConfig - branding logo drawn in top left corner
+type LogoConfig struct {
+	Path  string  `yaml:"path"` // empty - etc/telegram/spark.png
+	X     float64 `yaml:"x"`
+	Y     float64 `yaml:"y"`
+	Scale float64 `yaml:"scale"`
+}
+
+// CanvasConfig - size of chart image in pixels
+type CanvasConfig struct {
+	Width  int `yaml:"width"`
+	Height int `yaml:"height"`
+}
+
+// ChatBranding - theme, logo and font of one chat
+type ChatBranding struct {
+	Theme string `yaml:"theme"`
+	Logo  string `yaml:"logo"`
+	Font  string `yaml:"font"`
+}
+
+// ThemeFile - file structure of chart theme config
+type ThemeFile struct {
+	Theme  string                  `yaml:"theme"` // theme of chats without own theme (by default dark)
+	Canvas CanvasConfig            `yaml:"canvas"`
+	Fonts  []string                `yaml:"fonts"` // font files tried before built-in search paths
+	Logo   LogoConfig              `yaml:"logo"`
+	Themes map[string]ThemeColors  `yaml:"themes"`
+	Chats  map[string]ChatBranding `yaml:"chats"`
+}
+
+// Theme - resolved theme of chart for one chat
+type Theme struct {
+	Name          string
+	Width, Height int
+	Background    color.RGBA
+	Text          color.RGBA
+	Grid          color.RGBA
+	Bar           color.RGBA
+	Accent        color.RGBA
+	Fonts         []string // font files in search order
+	Logo          LogoConfig
+}
+
+var builtinThemes = map[string]ThemeColors{
+	ThemeDark: {
+		Background: "#000000",
+		Text:       "#ffffff",
+		Grid:       "#ffffff",
+		Bar:        "#808080",
+		Accent:     "#00ff00",
+	},
+	ThemeLight: {
+		Background: "#ffffff",
+		Text:       "#1a1a1a",
+		Grid:       "#b4b4b4",
+		Bar:        "#8c8c8c",
+		Accent:     "#00a046",
+	},
+}
+
+// defaultChartFonts - Inter and fallback system fonts
+var defaultChartFonts = []string{
+	paths.Asset("fonts", "InterVariable.ttf"),
+	paths.Asset("fonts", "Inter-Regular.ttf"),
+	paths.Asset("fonts", "Inter-Regular.otf"),
+	"./etc/fonts/InterVariable.ttf",
+	"./etc/fonts/Inter-Regular.ttf",
+	"./etc/fonts/Inter-Regular.otf",
+	// Inter on macOS, gg needs TTF
+	"~/Library/Fonts/InterVariable.ttf",
+	"~/Library/Fonts/Inter-Regular.ttf",
+	"/Library/Fonts/InterVariable.ttf",
+	"/Library/Fonts/Inter-Regular.ttf",
+	"/System/Library/Fonts/Supplemental/InterVariable.ttf",
+	"/System/Library/Fonts/Supplemental/Inter-Regular.ttf",
+	"/usr/share/fonts/truetype/inter/InterVariable.ttf",
+	"/usr/share/fonts/truetype/inter/Inter-Regular.ttf",
+	"/usr/local/share/fonts/InterVariable.ttf",
+	"/usr/local/share/fonts/Inter-Regular.ttf",
+	"/System/Library/Fonts/SFNS.ttf",
+	"/System/Library/Fonts/HelveticaNeue.ttc",
+	"/System/Library/Fonts/Helvetica.ttc",
+	"/System/Library/Fonts/Supplemental/Arial.ttf",
+	"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf",
+	"/usr/share/fonts/truetype/liberation/LiberationSans-Regular.ttf",
+}
+
+var (
+	themeFile      = ThemeFile{}
+	themeFileMutex sync.RWMutex
+)
+
+// LoadThemeFile reads and validates chart theme file
+func LoadThemeFile(path string) (*ThemeFile, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart theme file: %w", err)
+	}
+
+	var file ThemeFile
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse chart theme file: %w", err)
+	}
+	if err := file.validate(); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// SetThemeFile sets chart theme config used by all charts
+func SetThemeFile(file ThemeFile) {
+	themeFileMutex.Lock()
+	themeFile = file
+	themeFileMutex.Unlock()
+}
+
+// ThemeFor returns chart theme of chat, chat 0 - theme of chats without own branding
+func ThemeFor(chatID int64) Theme {
+	themeFileMutex.RLock()
+	file := themeFile
+	themeFileMutex.RUnlock()
+
+	branding := file.Chats[strconv.FormatInt(chatID, 10)]
+	name := strings.ToLower(file.Theme)
+	if branding.Theme != "" {
+		name = strings.ToLower(branding.Theme)
+	}
+	if name == "" {
+		name = ThemeDark
+	}
+	colors := file.colors(name)
+
+	theme := Theme{
+		Name:   name,
+		Width:  chartWidth,
+		Height: chartHeight,
+		Logo: LogoConfig{
+			Path:  file.Logo.Path,
+			X:     defaultLogoX,
+			Y:     defaultLogoY,
+			Scale: defaultLogoScale,
+		},
+	}
+	if file.Canvas.Width > 0 && file.Canvas.Height > 0 {
+		theme.Width, theme.Height = file.Canvas.Width, file.Canvas.Height
+	}
+	if file.Logo.X != 0 || file.Logo.Y != 0 {
+		theme.Logo.X, theme.Logo.Y = file.Logo.X, file.Logo.Y
+	}
+	if file.Logo.Scale > 0 {
+		theme.Logo.Scale = file.Logo.Scale
+	}
+	if colors.Logo != "" {
+		theme.Logo.Path = colors.Logo
+	}
+	if branding.Logo != "" {
+		theme.Logo.Path = branding.Logo
+	}
+
+	// Colors are checked by LoadThemeFile
+	theme.Background, _ = parseHexColor(colors.Background)
+	theme.Text, _ = parseHexColor(colors.Text)
+	theme.Grid, _ = parseHexColor(colors.Grid)
+	theme.Bar, _ = parseHexColor(colors.Bar)
+	theme.Accent, _ = parseHexColor(colors.Accent)
+
+	if branding.Font != "" {
+		theme.Fonts = append(theme.Fonts, branding.Font)
+	}
+	theme.Fonts = append(theme.Fonts, file.Fonts...)
+	theme.Fonts = append(theme.Fonts, defaultChartFonts...)
+	return theme
+}
+
+// colors returns colors of theme, unset colors are taken from built-in theme (dark for new themes)
+func (f *ThemeFile) colors(name string) ThemeColors {
+	colors, builtin := builtinThemes[name]
+	if !builtin {
+		colors = builtinThemes[ThemeDark]
+	}
+	custom := f.Themes[name]
+	for _, field := range []struct{ value, custom *string }{
+		{&colors.Background, &custom.Background},
+		{&colors.Text, &custom.Text},
+		{&colors.Grid, &custom.Grid},
+		{&colors.Bar, &custom.Bar},
+		{&colors.Accent, &custom.Accent},
+		{&colors.Logo, &custom.Logo},
+	} {
+		if *field.custom != "" {
+			*field.value = *field.custom
+		}
+	}
+	return colors
+}
+
+func (f *ThemeFile) validate() error {
+	themes := make(map[string]ThemeColors, len(f.Themes))
+	for name, colors := range f.Themes {
+		name = strings.ToLower(name)
+		for _, value := range []string{colors.Background, colors.Text, colors.Grid, colors.Bar, colors.Accent} {
+			if value == "" {
+				continue
+			}
+			if _, err := parseHexColor(value); err != nil {
+				return fmt.Errorf("chart theme %q: %w", name, err)
+			}
+		}
+		themes[name] = colors
+	}
+	f.Themes = themes
+
+	known := func(name string) bool {
+		name = strings.ToLower(name)
+		_, builtin := builtinThemes[name]
+		_, custom := f.Themes[name]
+		return name == "" || builtin || custom
+	}
+	if !known(f.Theme) {
+		return fmt.Errorf("unknown chart theme %q", f.Theme)
+	}
+	for chat, branding := range f.Chats {
+		if _, err := strconv.ParseInt(chat, 10, 64); err != nil {
+			return fmt.Errorf("chart theme chats: invalid chat id %q", chat)
+		}
+		if !known(branding.Theme) {
+			return fmt.Errorf("chart theme of chat %s: unknown theme %q", chat, branding.Theme)
+		}
+	}
+	if f.Canvas.Width < 0 || f.Canvas.Height < 0 || (f.Canvas.Width == 0) != (f.Canvas.Height == 0) {
+		return fmt.Errorf("chart canvas must have both width and height")
+	}
+	if f.Logo.Scale < 0 {
+		return fmt.Errorf("chart logo scale must not be negative")
+	}
+	return nil
+}
+
+// parseHexColor parses #rrggbb or #rrggbbaa
+func parseHexColor(value string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q, expected #rrggbb or #rrggbbaa", value)
+	}
+	rgba, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q, expected #rrggbb or #rrggbbaa", value)
+	}
+	return color.RGBA{uint8(rgba >> 24), uint8(rgba >> 16), uint8(rgba >> 8), uint8(rgba)}, nil
+}
+
+// loadThemeFont loads first font of theme that can be loaded
+// Returns path of font, false - default gg font is used
+func loadThemeFont(dc *gg.Context, theme Theme, size float64) (string, bool) {
+	for _, fontPath := range theme.Fonts {
+		fontPath = expandHome(fontPath)
+		if _, err := os.Stat(fontPath); err != nil {
+			continue
+		}
+		err := dc.LoadFontFace(fontPath, size)
+		if err == nil {
+			return fontPath, true
+		}
+		logging.LogWarn("Font file exists but failed to load",
+			zap.String("path", fontPath),
+			zap.Error(err))
+	}
+	logging.LogWarn("Failed to load font for chart, using default font",
+		zap.String("theme", theme.Name),
+		zap.Int("paths_checked", len(theme.Fonts)))
+	return "", false
+}
+
+// loadChartFont loads font of default theme for charts without chat
+func loadChartFont(dc *gg.Context) (string, bool) {
+	return loadThemeFont(dc, ThemeFor(0), communityLegendFontSize)
+}
+
+// drawThemeLogo draws branding logo of theme, missing logo is logged and skipped
+func drawThemeLogo(dc *gg.Context, theme Theme) {
+	logoPaths := []string{theme.Logo.Path}
+	if theme.Logo.Path == "" {
+		logoPaths = []string{
+			paths.Asset("telegram", "spark.png"),
+			filepath.Join(".", "etc", "telegram", "spark.png"),
+			filepath.Join("..", "etc", "telegram", "spark.png"),
+			filepath.Join("..", "..", "etc", "telegram", "spark.png"),
+		}
+	}
+
+	var logoImg image.Image
+	for _, logoPath := range logoPaths {
+		if img, err := gg.LoadImage(expandHome(logoPath)); err == nil {
+			logoImg = img
+			break
+		}
+	}
+	if logoImg == nil {
+		logging.LogWarn("Failed to load chart logo", zap.Strings("tried_paths", logoPaths))
+		return
+	}
+
+	if theme.Logo.Scale != 1.0 {
+		width := float64(logoImg.Bounds().Dx()) * theme.Logo.Scale
+		height := float64(logoImg.Bounds().Dy()) * theme.Logo.Scale
+		scaledCtx := gg.NewContext(int(width), int(height))
+		scaledCtx.Scale(theme.Logo.Scale, theme.Logo.Scale)
+		scaledCtx.DrawImage(logoImg, 0, 0)
+		logoImg = scaledCtx.Image()
+	}
+	dc.DrawImage(logoImg, int(theme.Logo.X), int(theme.Logo.Y))
+}
+
+// saveThemedChart saves chart drawn in chartWidth x chartHeight layout scaled to canvas of theme
+func saveThemedChart(dc *gg.Context, theme Theme, filename string) error {
+	if theme.Width != chartWidth || theme.Height != chartHeight {
+		scaled := gg.NewContext(theme.Width, theme.Height)
+		scaled.Scale(float64(theme.Width)/chartWidth, float64(theme.Height)/chartHeight)
+		scaled.DrawImage(dc.Image(), 0, 0)
+		dc = scaled
+	}
+	return dc.SavePNG(filename)
+}
+
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, path[1:])
+		}
+	}
+	return path
+}
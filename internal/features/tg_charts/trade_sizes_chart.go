@@ -0,0 +1,111 @@
+package tg_charts
+
+// Trade-size histogram for /distribution {ticker} {24h|7d}: swaps by BTC size bucket as stacked bars
+// (buys and sells), share of volume of bucket is written above its bar
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"spark-wallet/internal/features/trade_sizes"
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/fogleman/gg"
+	"go.uber.org/zap"
+)
+
+var (
+	tradeSizesBuyColor    = color.RGBA{0, 200, 120, 255}
+	tradeSizesSellColor   = color.RGBA{255, 80, 80, 255}
+	tradeSizesVolumeColor = color.RGBA{247, 147, 26, 255}
+)
+
+// GenerateTradeSizesChart draws swaps of distribution buckets split into buys and sells
+// Returns path of PNG file
+func GenerateTradeSizesChart(report *trade_sizes.Report) (string, error) {
+	maxSwaps := 0
+	for _, bucket := range report.Buckets {
+		maxSwaps = max(maxSwaps, bucket.Swaps())
+	}
+	if maxSwaps == 0 {
+		return "", fmt.Errorf("no swaps for trade sizes chart")
+	}
+
+	dc := gg.NewContext(communityChartWidth, communityChartHeight)
+	dc.SetColor(color.Black)
+	dc.Clear()
+
+	fontPath, fontLoaded := loadChartFont(dc)
+	setFontSize := func(size float64) {
+		if fontLoaded {
+			dc.LoadFontFace(fontPath, size)
+		}
+	}
+
+	// Title and legend
+	setFontSize(communityTitleFontSize)
+	dc.SetColor(color.White)
+	dc.DrawString(fmt.Sprintf("{%s} trade sizes, %s", strings.ToUpper(report.Ticker), report.Period.Name), communityAreaLeft, 90)
+	setFontSize(communityLegendFontSize)
+	dc.SetColor(tradeSizesBuyColor)
+	dc.DrawString("Buys", communityAreaLeft, 150)
+	dc.SetColor(tradeSizesSellColor)
+	dc.DrawString("Sells", communityAreaLeft+150, 150)
+	dc.SetColor(tradeSizesVolumeColor)
+	dc.DrawString("Share of volume", communityAreaLeft+300, 150)
+
+	areaWidth := communityAreaRight - communityAreaLeft
+	areaHeight := communityAreaBottom - communityAreaTop
+	slot := areaWidth / float64(len(report.Buckets))
+	barWidth := slot * 0.6
+	heightFor := func(swaps int) float64 {
+		return float64(swaps) / float64(maxSwaps) * areaHeight * 0.85
+	}
+
+	totalVolume := report.VolumeBTC()
+	for i, bucket := range report.Buckets {
+		x := communityAreaLeft + slot*float64(i) + (slot-barWidth)/2
+		center := x + barWidth/2
+
+		buysHeight := heightFor(bucket.Buys)
+		sellsHeight := heightFor(bucket.Sells)
+		dc.SetColor(tradeSizesBuyColor)
+		dc.DrawRectangle(x, communityAreaBottom-buysHeight, barWidth, buysHeight)
+		dc.Fill()
+		dc.SetColor(tradeSizesSellColor)
+		dc.DrawRectangle(x, communityAreaBottom-buysHeight-sellsHeight, barWidth, sellsHeight)
+		dc.Fill()
+
+		top := communityAreaBottom - buysHeight - sellsHeight
+		setFontSize(communityDateFontSize)
+		dc.SetColor(color.White)
+		dc.DrawStringAnchored(fmt.Sprintf("%d", bucket.Swaps()), center, top-15, 0.5, 0)
+		if totalVolume > 0 {
+			dc.SetColor(tradeSizesVolumeColor)
+			dc.DrawStringAnchored(fmt.Sprintf("%.0f%%", bucket.VolumeBTC()/totalVolume*100), center, top-50, 0.5, 0)
+		}
+
+		dc.SetColor(color.White)
+		dc.DrawStringAnchored(bucket.Label()+" btc", center, communityAreaBottom+45, 0.5, 0)
+	}
+
+	chartsDir := paths.Charts()
+	if err := os.MkdirAll(chartsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create charts directory: %w", err)
+	}
+
+	filename := filepath.Join(chartsDir, fmt.Sprintf("trade_sizes_%s_%s.png", strings.ToLower(report.Ticker), report.Period.Name))
+	if err := dc.SavePNG(filename); err != nil {
+		return "", fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	logging.LogInfo("Trade sizes chart generated successfully",
+		zap.String("filename", filename),
+		zap.Int("swaps", report.Swaps()))
+
+	return filename, nil
+}
@@ -0,0 +1,140 @@
+package token_info
+
+// Our figures of token card (/token {ticker}): swap activity from archive and pool age
+// Price, market cap, volume, holders and TVL come from Luminex pool response, this package adds
+// what the bot has seen itself: swaps, buys, sells and unique wallets of last 24h, BTC volume of them
+
+import (
+	"fmt"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
+)
+
+// ActivityWindow - period of swap activity in token card
+const ActivityWindow = 24 * time.Hour
+
+// Activity - buy and sell swaps of pool in [From, To), token-to-token swaps are skipped
+type Activity struct {
+	From    time.Time
+	To      time.Time
+	Buys    int
+	Sells   int
+	Wallets int // unique swappers
+	BuyBTC  float64
+	SellBTC float64
+}
+
+// Swaps returns count of buys and sells
+func (a *Activity) Swaps() int {
+	return a.Buys + a.Sells
+}
+
+// VolumeBTC returns buy and sell volume
+func (a *Activity) VolumeBTC() float64 {
+	return a.BuyBTC + a.SellBTC
+}
+
+// LoadActivity builds activity of pool over ActivityWindow up to now from swap archive
+func LoadActivity(poolLpPublicKey string, now time.Time) (*Activity, error) {
+	now = now.UTC()
+	from := now.Add(-ActivityWindow)
+
+	var swaps []flashnet.Swap
+	for day := from.Truncate(24 * time.Hour); !day.After(now); day = day.AddDate(0, 0, 1) {
+		daySwaps, err := storage.LoadDailySwaps(day.Format("2006-01-02"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load swaps of %s: %w", day.Format("2006-01-02"), err)
+		}
+		for _, swap := range daySwaps {
+			if swap.PoolLpPublicKey == poolLpPublicKey {
+				swaps = append(swaps, swap)
+			}
+		}
+	}
+
+	return Build(swaps, from, now), nil
+}
+
+// Build counts buy and sell swaps of one pool within [from, to)
+func Build(swaps []flashnet.Swap, from time.Time, to time.Time) *Activity {
+	activity := &Activity{From: from, To: to}
+	wallets := make(map[string]bool)
+	for _, swap := range swaps {
+		at, ok := storage.SwapTime(swap)
+		if !ok || at.Before(from) || !at.Before(to) {
+			continue
+		}
+
+		var sats string
+		isBuy := false
+		switch swap.GetSwapType() {
+		case flashnet.SwapTypeBuy:
+			sats, isBuy = swap.AmountIn, true
+		case flashnet.SwapTypeSell:
+			sats = swap.AmountOut
+		default:
+			continue
+		}
+		btc, err := amount.SatsToBTCFloat(sats)
+		if err != nil || btc <= 0 {
+			continue
+		}
+
+		if isBuy {
+			activity.Buys++
+			activity.BuyBTC += btc
+		} else {
+			activity.Sells++
+			activity.SellBTC += btc
+		}
+		if swap.SwapperPublicKey != "" {
+			wallets[swap.SwapperPublicKey] = true
+		}
+	}
+	activity.Wallets = len(wallets)
+	return activity
+}
+
+// PoolAge returns time since pool creation (createdAt of Luminex pool, RFC3339), false if unknown
+func PoolAge(createdAt string, now time.Time) (time.Duration, bool) {
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil || created.After(now) {
+		return 0, false
+	}
+	return now.Sub(created), true
+}
+
+// FormatAge formats pool age in largest units (2y 3mo, 45d, 5d 4h, 3h, <1h)
+func FormatAge(age time.Duration) string {
+	days := int(age.Hours() / 24)
+	switch {
+	case days >= 365:
+		years, months := days/365, days%365/30
+		if months == 0 {
+			return fmt.Sprintf("%dy", years)
+		}
+		return fmt.Sprintf("%dy %dmo", years, months)
+	case days >= 30:
+		return fmt.Sprintf("%dd", days)
+	case days >= 1:
+		hours := int(age.Hours()) % 24
+		if hours == 0 {
+			return fmt.Sprintf("%dd", days)
+		}
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	}
+	return "<1h"
+}
+
+// ExplorerURL returns Sparkscan page of token, empty if token address is unknown
+func ExplorerURL(tokenAddress string) string {
+	if tokenAddress == "" {
+		return ""
+	}
+	return "https://www.sparkscan.io/token/" + tokenAddress + "?network=mainnet"
+}
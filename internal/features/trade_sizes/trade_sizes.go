@@ -0,0 +1,226 @@
+package trade_sizes
+
+// Trade-size distribution of token for /distribution {ticker} {24h|7d}
+// Buy and sell swaps of pool in period are bucketed by BTC size, share of swaps and of volume per bucket
+// shows whether whales or retail drive the token
+// Swaps come from archive of big sales monitor (data_out/archive/swaps), token-to-token swaps are skipped
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/i18n"
+	storage "spark-wallet/internal/infra/fs"
+)
+
+// Period - time window of distribution up to now
+type Period struct {
+	Name     string // 24h or 7d
+	Duration time.Duration
+}
+
+// Supported periods
+var (
+	Period24h = Period{Name: "24h", Duration: 24 * time.Hour}
+	Period7d  = Period{Name: "7d", Duration: 7 * 24 * time.Hour}
+)
+
+// Bucket - swaps of one size range, Max 0 - no upper bound
+type Bucket struct {
+	Min, Max float64 // BTC, Min inclusive, Max exclusive
+	Buys     int
+	Sells    int
+	BuyBTC   float64
+	SellBTC  float64
+}
+
+// BucketLimits - upper bounds of buckets in BTC, last bucket is above last limit
+var BucketLimits = []float64{0.001, 0.01, 0.1}
+
+// Label returns size range of bucket (<0.001, 0.001–0.01, >0.1)
+func (b Bucket) Label() string {
+	switch {
+	case b.Min == 0:
+		return "<" + amount.FormatBTC(b.Max)
+	case b.Max == 0:
+		return ">" + amount.FormatBTC(b.Min)
+	}
+	return amount.FormatBTC(b.Min) + "–" + amount.FormatBTC(b.Max)
+}
+
+// Swaps returns count of buys and sells of bucket
+func (b Bucket) Swaps() int {
+	return b.Buys + b.Sells
+}
+
+// VolumeBTC returns buy and sell volume of bucket
+func (b Bucket) VolumeBTC() float64 {
+	return b.BuyBTC + b.SellBTC
+}
+
+// Report - trade-size distribution of token in period, buckets smallest first
+type Report struct {
+	Ticker  string
+	Period  Period
+	From    time.Time
+	To      time.Time
+	Buckets []Bucket
+}
+
+// Swaps returns count of bucketed swaps
+func (r *Report) Swaps() int {
+	total := 0
+	for _, bucket := range r.Buckets {
+		total += bucket.Swaps()
+	}
+	return total
+}
+
+// VolumeBTC returns volume of bucketed swaps
+func (r *Report) VolumeBTC() float64 {
+	total := 0.0
+	for _, bucket := range r.Buckets {
+		total += bucket.VolumeBTC()
+	}
+	return total
+}
+
+// VolumeShare returns % of volume in swaps of at least minBTC (minBTC 0 - all) and below maxBTC (0 - no upper bound)
+func (r *Report) VolumeShare(minBTC, maxBTC float64) float64 {
+	total := r.VolumeBTC()
+	if total == 0 {
+		return 0
+	}
+	share := 0.0
+	for _, bucket := range r.Buckets {
+		if bucket.Min >= minBTC && (maxBTC == 0 || (bucket.Max != 0 && bucket.Max <= maxBTC)) {
+			share += bucket.VolumeBTC()
+		}
+	}
+	return share / total * 100
+}
+
+// ParsePeriod returns period by name, empty - 24h
+func ParsePeriod(name string) (Period, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "24h", "1d", "day":
+		return Period24h, nil
+	case "7d", "week":
+		return Period7d, nil
+	}
+	return Period{}, fmt.Errorf("period must be 24h or 7d")
+}
+
+// LoadReport builds distribution of pool swaps in period up to now from swap archive
+func LoadReport(poolLpPublicKey string, period Period, now time.Time) (*Report, error) {
+	now = now.UTC()
+	from := now.Add(-period.Duration)
+
+	var swaps []flashnet.Swap
+	for day := from.Truncate(24 * time.Hour); !day.After(now); day = day.AddDate(0, 0, 1) {
+		daySwaps, err := storage.LoadDailySwaps(day.Format("2006-01-02"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load swaps of %s: %w", day.Format("2006-01-02"), err)
+		}
+		for _, swap := range daySwaps {
+			if swap.PoolLpPublicKey == poolLpPublicKey {
+				swaps = append(swaps, swap)
+			}
+		}
+	}
+
+	return Build(swaps, period, from, now), nil
+}
+
+// Build buckets buy and sell swaps of one pool within [from, to)
+func Build(swaps []flashnet.Swap, period Period, from time.Time, to time.Time) *Report {
+	report := &Report{Period: period, From: from, To: to, Buckets: newBuckets()}
+	for _, swap := range swaps {
+		at, ok := storage.SwapTime(swap)
+		if !ok || at.Before(from) || !at.Before(to) {
+			continue
+		}
+
+		var sats string
+		isBuy := false
+		switch swap.GetSwapType() {
+		case flashnet.SwapTypeBuy:
+			sats, isBuy = swap.AmountIn, true
+		case flashnet.SwapTypeSell:
+			sats = swap.AmountOut
+		default:
+			continue
+		}
+		btc, err := amount.SatsToBTCFloat(sats)
+		if err != nil || btc <= 0 {
+			continue
+		}
+
+		bucket := &report.Buckets[bucketIndex(btc)]
+		if isBuy {
+			bucket.Buys++
+			bucket.BuyBTC += btc
+		} else {
+			bucket.Sells++
+			bucket.SellBTC += btc
+		}
+	}
+	return report
+}
+
+// Format formats distribution for Telegram in language of chat (HTML)
+func Format(report *Report, lang i18n.Lang) string {
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, "distribution.title", report.Ticker, report.Period.Name,
+		report.Swaps(), amount.FormatBTC(report.VolumeBTC())))
+
+	totalSwaps, totalVolume := report.Swaps(), report.VolumeBTC()
+	text.WriteString("<blockquote>")
+	for i, bucket := range report.Buckets {
+		text.WriteString(i18n.T(lang, "distribution.bucket", html.EscapeString(bucket.Label()),
+			bucket.Swaps(), percent(float64(bucket.Swaps()), float64(totalSwaps)),
+			amount.FormatBTC(bucket.VolumeBTC()), percent(bucket.VolumeBTC(), totalVolume),
+			bucket.Buys, bucket.Sells))
+		if i < len(report.Buckets)-1 {
+			text.WriteString("\n")
+		}
+	}
+	text.WriteString("</blockquote>\n")
+
+	whaleMin := BucketLimits[len(BucketLimits)-1]
+	retailMax := BucketLimits[1]
+	text.WriteString(i18n.T(lang, "distribution.whales", amount.FormatBTC(whaleMin), report.VolumeShare(whaleMin, 0)) + "\n")
+	text.WriteString(i18n.T(lang, "distribution.retail", amount.FormatBTC(retailMax), report.VolumeShare(0, retailMax)))
+	text.WriteString("\n\n<i>" + i18n.T(lang, "distribution.footer") + "</i>")
+	return text.String()
+}
+
+func newBuckets() []Bucket {
+	buckets := make([]Bucket, 0, len(BucketLimits)+1)
+	lower := 0.0
+	for _, limit := range BucketLimits {
+		buckets = append(buckets, Bucket{Min: lower, Max: limit})
+		lower = limit
+	}
+	return append(buckets, Bucket{Min: lower})
+}
+
+func bucketIndex(btc float64) int {
+	for i, limit := range BucketLimits {
+		if btc < limit {
+			return i
+		}
+	}
+	return len(BucketLimits)
+}
+
+func percent(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return part / total * 100
+}
@@ -0,0 +1,279 @@
+package trading
+
+// Swap placement through Flashnet AMM with safety limits (SafeGuard)
+// Trading is off unless trading.enabled (TRADING_ENABLED) is set: quotes work, execution returns ErrTradingDisabled
+// Every order is simulated first, the quote is checked against limits (size in sats, slippage, price impact)
+// and min amount out of execution is derived from the quote and order slippage
+// Only BTC pairs are traded (buy token for BTC or sell token for BTC), so order size is always known in sats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"spark-wallet/internal/amount"
+	"spark-wallet/internal/clients_api/flashnet"
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultMaxSlippageBps - max slippage of order (1%)
+	DefaultMaxSlippageBps = 100
+	// DefaultMaxAmountSats - max BTC side of order (0.001 BTC)
+	DefaultMaxAmountSats = 100_000
+	// DefaultMaxPriceImpactPercent - max price impact of quote
+	DefaultMaxPriceImpactPercent = 5.0
+)
+
+// ErrTradingDisabled - execution without trading.enabled
+var ErrTradingDisabled = errors.New("trading is disabled (set trading.enabled or TRADING_ENABLED)")
+
+// Limits - trading switch and SafeGuard limits
+type Limits struct {
+	Enabled               bool    // execution allowed
+	MaxSlippageBps        int     // order slippage above it is rejected
+	MaxAmountSats         int64   // BTC side of order above it is rejected
+	MaxPriceImpactPercent float64 // quote with higher price impact is rejected, 0 - not checked
+}
+
+// DefaultLimits returns limits with trading disabled
+func DefaultLimits() Limits {
+	return Limits{
+		MaxSlippageBps:        DefaultMaxSlippageBps,
+		MaxAmountSats:         DefaultMaxAmountSats,
+		MaxPriceImpactPercent: DefaultMaxPriceImpactPercent,
+	}
+}
+
+var (
+	limits      = DefaultLimits()
+	limitsMutex sync.RWMutex
+)
+
+// SetLimits sets trading limits from config (zero slippage or size - defaults)
+func SetLimits(l Limits) {
+	if l.MaxSlippageBps <= 0 {
+		l.MaxSlippageBps = DefaultMaxSlippageBps
+	}
+	if l.MaxAmountSats <= 0 {
+		l.MaxAmountSats = DefaultMaxAmountSats
+	}
+	limitsMutex.Lock()
+	limits = l
+	limitsMutex.Unlock()
+}
+
+// TightenLimits applies limits of reloaded config only where they are stricter than current ones
+// Trading switch and raised size, slippage or price impact caps need restart, so a config edit
+// on a running process can't enable trading or allow larger orders
+// Returns limits in effect
+func TightenLimits(l Limits) Limits {
+	limitsMutex.Lock()
+	defer limitsMutex.Unlock()
+
+	if l.MaxSlippageBps > 0 && l.MaxSlippageBps < limits.MaxSlippageBps {
+		limits.MaxSlippageBps = l.MaxSlippageBps
+	}
+	if l.MaxAmountSats > 0 && l.MaxAmountSats < limits.MaxAmountSats {
+		limits.MaxAmountSats = l.MaxAmountSats
+	}
+	// 0 - price impact not checked, the loosest value
+	if l.MaxPriceImpactPercent > 0 && (limits.MaxPriceImpactPercent == 0 || l.MaxPriceImpactPercent < limits.MaxPriceImpactPercent) {
+		limits.MaxPriceImpactPercent = l.MaxPriceImpactPercent
+	}
+	return limits
+}
+
+// GetLimits returns current trading limits
+func GetLimits() Limits {
+	limitsMutex.RLock()
+	defer limitsMutex.RUnlock()
+	return limits
+}
+
+// Order - swap to place
+type Order struct {
+	PoolID          string // pool LP public key
+	AssetInAddress  string // flashnet.NativeTokenAddress to buy token
+	AssetOutAddress string // flashnet.NativeTokenAddress to sell token
+	AmountIn        string // raw units of asset in (sats for BTC)
+	SlippageBps     int    // 0 - MaxSlippageBps
+	// AssetInSparkTransferID - Spark transfer of AmountIn to pool, made by wallet before execution
+	AssetInSparkTransferID string
+}
+
+// Quote - simulated order checked by SafeGuard
+type Quote struct {
+	Order        Order
+	Simulation   *flashnet.SimulateSwapResponse
+	AmountSats   int64  // BTC side of order
+	MinAmountOut string // amount out with slippage applied
+}
+
+// SafeGuard - checks orders and quotes against limits
+type SafeGuard struct {
+	Limits Limits
+}
+
+// CheckOrder validates order before simulation: BTC pair, slippage and size of BTC in
+func (g SafeGuard) CheckOrder(order Order) error {
+	if order.PoolID == "" || order.AssetInAddress == "" || order.AssetOutAddress == "" {
+		return fmt.Errorf("pool, asset in and asset out are required")
+	}
+	if order.AssetInAddress == order.AssetOutAddress {
+		return fmt.Errorf("asset in and asset out are the same")
+	}
+	if order.AssetInAddress != flashnet.NativeTokenAddress && order.AssetOutAddress != flashnet.NativeTokenAddress {
+		return fmt.Errorf("only BTC pairs can be traded")
+	}
+	amountIn, ok := new(big.Int).SetString(strings.TrimSpace(order.AmountIn), 10)
+	if !ok || amountIn.Sign() <= 0 {
+		return fmt.Errorf("invalid amount in %q", order.AmountIn)
+	}
+	if order.SlippageBps < 0 || order.SlippageBps > g.Limits.MaxSlippageBps {
+		return fmt.Errorf("slippage %d bps is above limit %d bps", order.SlippageBps, g.Limits.MaxSlippageBps)
+	}
+	if order.AssetInAddress == flashnet.NativeTokenAddress {
+		if err := g.checkSize(amountIn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckQuote validates simulated order: size of BTC out and price impact
+// Returns BTC side of order in sats
+func (g SafeGuard) CheckQuote(order Order, simulation *flashnet.SimulateSwapResponse) (int64, error) {
+	if simulation == nil {
+		return 0, fmt.Errorf("swap simulation is empty")
+	}
+	amountOut, ok := new(big.Int).SetString(strings.TrimSpace(simulation.AmountOut), 10)
+	if !ok || amountOut.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid simulated amount out %q", simulation.AmountOut)
+	}
+
+	sats := amountOut
+	if order.AssetInAddress == flashnet.NativeTokenAddress {
+		sats, _ = new(big.Int).SetString(strings.TrimSpace(order.AmountIn), 10)
+	}
+	if err := g.checkSize(sats); err != nil {
+		return 0, err
+	}
+
+	if g.Limits.MaxPriceImpactPercent > 0 && simulation.PriceImpactPct != "" {
+		impact, err := amount.ParseFloat(simulation.PriceImpactPct)
+		if err != nil {
+			return 0, fmt.Errorf("invalid price impact %q: %w", simulation.PriceImpactPct, err)
+		}
+		if impact > g.Limits.MaxPriceImpactPercent {
+			return 0, fmt.Errorf("price impact %.2f%% is above limit %.2f%%", impact, g.Limits.MaxPriceImpactPercent)
+		}
+	}
+	return sats.Int64(), nil
+}
+
+func (g SafeGuard) checkSize(sats *big.Int) error {
+	if sats.Cmp(big.NewInt(g.Limits.MaxAmountSats)) > 0 {
+		return fmt.Errorf("order size %s sats is above limit %d sats", sats.String(), g.Limits.MaxAmountSats)
+	}
+	return nil
+}
+
+// MinAmountOut returns amount out reduced by slippage (rounded down)
+func MinAmountOut(amountOut string, slippageBps int) (string, error) {
+	out, ok := new(big.Int).SetString(strings.TrimSpace(amountOut), 10)
+	if !ok || out.Sign() < 0 {
+		return "", fmt.Errorf("invalid amount out %q", amountOut)
+	}
+	out.Mul(out, big.NewInt(int64(10_000-slippageBps)))
+	out.Quo(out, big.NewInt(10_000))
+	return out.String(), nil
+}
+
+// Trader - places orders through Flashnet client within limits
+type Trader struct {
+	client *flashnet.Client
+	limits func() Limits
+}
+
+// NewTrader creates trader using limits set by SetLimits
+func NewTrader(client *flashnet.Client) *Trader {
+	return &Trader{client: client, limits: GetLimits}
+}
+
+// Quote simulates order and checks it against limits (works with trading disabled)
+func (t *Trader) Quote(ctx context.Context, order Order) (*Quote, error) {
+	guard := SafeGuard{Limits: t.limits()}
+	if order.SlippageBps == 0 {
+		order.SlippageBps = guard.Limits.MaxSlippageBps
+	}
+	if err := guard.CheckOrder(order); err != nil {
+		return nil, fmt.Errorf("order rejected: %w", err)
+	}
+
+	simulation, err := t.client.SimulateSwap(ctx, flashnet.SimulateSwapRequest{
+		PoolID:          order.PoolID,
+		AssetInAddress:  order.AssetInAddress,
+		AssetOutAddress: order.AssetOutAddress,
+		AmountIn:        order.AmountIn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sats, err := guard.CheckQuote(order, simulation)
+	if err != nil {
+		return nil, fmt.Errorf("quote rejected: %w", err)
+	}
+	minAmountOut, err := MinAmountOut(simulation.AmountOut, order.SlippageBps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Quote{Order: order, Simulation: simulation, AmountSats: sats, MinAmountOut: minAmountOut}, nil
+}
+
+// Execute quotes order again and places it if trading is enabled
+func (t *Trader) Execute(ctx context.Context, order Order) (*flashnet.ExecuteSwapResponse, error) {
+	if !t.limits().Enabled {
+		return nil, ErrTradingDisabled
+	}
+	if order.AssetInSparkTransferID == "" {
+		return nil, fmt.Errorf("order rejected: asset in transfer to pool is required")
+	}
+
+	quote, err := t.Quote(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Simulation.WarningMessage != "" {
+		logging.LogWarn("Swap simulation warning",
+			zap.String("poolId", order.PoolID),
+			zap.String("warning", quote.Simulation.WarningMessage))
+	}
+
+	logging.LogInfo("Placing swap",
+		zap.String("poolId", order.PoolID),
+		zap.String("assetIn", order.AssetInAddress),
+		zap.String("assetOut", order.AssetOutAddress),
+		zap.String("amountIn", order.AmountIn),
+		zap.String("minAmountOut", quote.MinAmountOut),
+		zap.Int64("sats", quote.AmountSats),
+		zap.Int("slippageBps", quote.Order.SlippageBps))
+
+	return t.client.ExecuteSwap(ctx, flashnet.ExecuteSwapRequest{
+		PoolID:                 order.PoolID,
+		AssetInAddress:         order.AssetInAddress,
+		AssetOutAddress:        order.AssetOutAddress,
+		AmountIn:               order.AmountIn,
+		MaxSlippageBps:         strconv.Itoa(quote.Order.SlippageBps),
+		MinAmountOut:           quote.MinAmountOut,
+		AssetInSparkTransferID: order.AssetInSparkTransferID,
+	})
+}
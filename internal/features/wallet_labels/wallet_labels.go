@@ -0,0 +1,249 @@
+package wallet_labels
+
+// Wallet labels (data_in/wallet_labels.json): public key -> human label ("team wallet", "MM bot", "whale #3")
+// Labels are set with /label and shown in swap alerts and holders reports instead of generic "wallet"
+// File can be edited by hand, changes are picked up without restart
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// MaxNameLength - longest label (characters), label is shown inline in alerts
+	MaxNameLength = 32
+	// MinSuffixLength - shortest wallet suffix accepted by /label
+	MinSuffixLength = 3
+)
+
+// LabelsFile - labels of wallets
+func LabelsFile() string {
+	return paths.Data("wallet_labels.json")
+}
+
+// Label - label of wallet
+type Label struct {
+	PublicKey string `json:"public_key"`
+	Name      string `json:"name"`
+	AddedBy   string `json:"added_by,omitempty"`
+	AddedAt   string `json:"added_at"` // RFC3339
+}
+
+// LabelsData - file structure for wallet_labels.json
+type LabelsData struct {
+	Labels map[string]*Label `json:"labels"` // publicKey (lower case) -> label
+}
+
+var (
+	labelsMutex sync.Mutex
+
+	// labelsCache - labels of labelsPath with labelsModTime, reloaded when file changes
+	labelsCache   *LabelsData
+	labelsPath    string
+	labelsModTime time.Time
+)
+
+// ValidName returns trimmed label name and error if it is empty or too long
+func ValidName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", fmt.Errorf("label is empty")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("label is longer than %d characters", MaxNameLength)
+	}
+	return name, nil
+}
+
+// Of returns label of wallet, empty if wallet has no label or labels can't be read
+func Of(publicKey string) string {
+	if publicKey == "" {
+		return ""
+	}
+
+	labelsMutex.Lock()
+	defer labelsMutex.Unlock()
+
+	data, err := loadLabelsUnlocked()
+	if err != nil {
+		return ""
+	}
+	if label := data.Labels[strings.ToLower(publicKey)]; label != nil {
+		return label.Name
+	}
+	return ""
+}
+
+// All returns labels sorted by name
+func All() ([]Label, error) {
+	labelsMutex.Lock()
+	defer labelsMutex.Unlock()
+
+	data, err := loadLabelsUnlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]Label, 0, len(data.Labels))
+	for _, label := range data.Labels {
+		labels = append(labels, *label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Name != labels[j].Name {
+			return labels[i].Name < labels[j].Name
+		}
+		return labels[i].PublicKey < labels[j].PublicKey
+	})
+	return labels, nil
+}
+
+// Set labels wallet (existing label is replaced), returns false if wallet already had label
+func Set(label Label) (bool, error) {
+	name, err := ValidName(label.Name)
+	if err != nil {
+		return false, err
+	}
+	label.Name = name
+	label.PublicKey = strings.ToLower(label.PublicKey)
+	if label.AddedAt == "" {
+		label.AddedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	labelsMutex.Lock()
+	defer labelsMutex.Unlock()
+
+	data, err := loadLabelsUnlocked()
+	if err != nil {
+		return false, err
+	}
+	_, exists := data.Labels[label.PublicKey]
+	data.Labels[label.PublicKey] = &label
+
+	if err := saveLabelsUnlocked(data); err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// Remove removes label of wallet, returns false if wallet had no label
+func Remove(publicKey string) (bool, error) {
+	publicKey = strings.ToLower(publicKey)
+
+	labelsMutex.Lock()
+	defer labelsMutex.Unlock()
+
+	data, err := loadLabelsUnlocked()
+	if err != nil {
+		return false, err
+	}
+	if _, exists := data.Labels[publicKey]; !exists {
+		return false, nil
+	}
+	delete(data.Labels, publicKey)
+
+	if err := saveLabelsUnlocked(data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// MatchSuffix returns wallets of candidates (and labeled wallets) ending with suffix, without duplicates
+func MatchSuffix(suffix string, candidates []string) ([]string, error) {
+	suffix = strings.ToLower(strings.TrimSpace(suffix))
+	if len(suffix) < MinSuffixLength {
+		return nil, fmt.Errorf("wallet suffix must have at least %d characters", MinSuffixLength)
+	}
+
+	labelsMutex.Lock()
+	data, err := loadLabelsUnlocked()
+	labelsMutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var matches []string
+	add := func(publicKey string) {
+		publicKey = strings.ToLower(publicKey)
+		if !seen[publicKey] && strings.HasSuffix(publicKey, suffix) {
+			seen[publicKey] = true
+			matches = append(matches, publicKey)
+		}
+	}
+	for publicKey := range data.Labels {
+		add(publicKey)
+	}
+	for _, publicKey := range candidates {
+		add(publicKey)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+func loadLabelsUnlocked() (*LabelsData, error) {
+	filePath := LabelsFile()
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
+		labelsCache = nil
+		return &LabelsData{Labels: make(map[string]*Label)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat wallet labels file: %w", err)
+	}
+	if labelsCache != nil && labelsPath == filePath && info.ModTime().Equal(labelsModTime) {
+		return labelsCache, nil
+	}
+
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet labels file: %w", err)
+	}
+	data := &LabelsData{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, data); err != nil {
+			return nil, fmt.Errorf("failed to parse wallet labels JSON: %w", err)
+		}
+	}
+	// Hand-edited keys may be upper case
+	labels := make(map[string]*Label, len(data.Labels))
+	for publicKey, label := range data.Labels {
+		if label == nil {
+			continue
+		}
+		publicKey = strings.ToLower(publicKey)
+		label.PublicKey = publicKey
+		labels[publicKey] = label
+	}
+	data.Labels = labels
+
+	labelsCache = data
+	labelsPath = filePath
+	labelsModTime = info.ModTime()
+	return data, nil
+}
+
+func saveLabelsUnlocked(data *LabelsData) error {
+	filePath := LabelsFile()
+	if err := storage.WriteJSONAtomic(filePath, data); err != nil {
+		labelsCache = nil
+		return fmt.Errorf("failed to save wallet labels: %w", err)
+	}
+	// Cache is kept, next read reloads it only if file changed after this write
+	if info, err := os.Stat(filePath); err == nil {
+		labelsCache = data
+		labelsPath = filePath
+		labelsModTime = info.ModTime()
+	} else {
+		labelsCache = nil
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package atomicfile
+
+// Atomic file writes shared by storage and API clients (no dependencies on other packages of the app,
+// so clients imported by storage can use it too)
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Write writes file via temporary file in the same directory and rename,
+// so readers see either old or new content, never a partial write.
+// Directory of file is created if missing.
+func Write(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Unique temporary name: concurrent writers of one file don't share it
+	tempFile, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tempFilePath := tempFile.Name()
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Chmod(tempFilePath, perm); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to set temporary file mode: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, path); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package buildinfo
+
+// Version and build information of running binary
+// Version, Commit and BuildTime are set at build time:
+//   go build -ldflags "-X spark-wallet/internal/infra/buildinfo.Commit=$(git rev-parse --short HEAD)"
+// Without ldflags commit and build time are taken from VCS info embedded by go build (if any)
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+var (
+	// Version - release version, must match latest entry of changelog
+	Version = "1.1.0"
+	// Commit - VCS revision (set via -ldflags)
+	Commit = ""
+	// BuildTime - build time in RFC3339 (set via -ldflags)
+	BuildTime = ""
+)
+
+// startedAt - process start time (for uptime)
+var startedAt = time.Now()
+
+// Info - build information of running binary
+type Info struct {
+	Version   string
+	Commit    string // empty if unknown
+	BuildTime string // empty if unknown
+	Modified  bool   // built from working tree with uncommitted changes
+	GoVersion string
+	StartedAt time.Time
+}
+
+// Get returns build information of running binary
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		StartedAt: startedAt,
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	if len(info.Commit) > 12 {
+		info.Commit = info.Commit[:12]
+	}
+	return info
+}
+
+// Uptime returns time since process start
+func Uptime() time.Duration {
+	return time.Since(startedAt)
+}
@@ -0,0 +1,308 @@
+package cloudflare
+
+// Cloudflare challenge detection and mitigation shared by API clients
+// Detection: cf-mitigated header, HTML content-type and challenge markers in body
+// Mitigation: growing cool-down after block and rotation of browser header profiles
+// Block rate is tracked per client, operator is alerted when it spikes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// baseCooldown - pause after first block, doubled for each next block in a row
+	baseCooldown = 2 * time.Second
+	// maxCooldown - max pause between requests while blocked
+	maxCooldown = 2 * time.Minute
+
+	// spikeWindow - window for block rate
+	spikeWindow = 5 * time.Minute
+	// spikeMinBlocks - min blocks in window for alert
+	spikeMinBlocks = 5
+	// spikeRate - share of blocked responses in window for alert
+	spikeRate = 0.3
+	// alertInterval - min interval between operator alerts of one client
+	alertInterval = 30 * time.Minute
+)
+
+// ErrBlocked - response is Cloudflare challenge/block page instead of API response
+var ErrBlocked = errors.New("blocked by Cloudflare")
+
+// headerProfile - plausible browser headers
+type headerProfile struct {
+	userAgent      string
+	acceptLanguage string
+	secChUa        string
+	platform       string
+}
+
+var headerProfiles = []headerProfile{
+	{
+		userAgent:      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Safari/537.36",
+		acceptLanguage: "en-US,en;q=0.9",
+		secChUa:        `"Chromium";v="122", "Not(A:Brand";v="24", "Google Chrome";v="122"`,
+		platform:       `"macOS"`,
+	},
+	{
+		userAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+		acceptLanguage: "en-US,en;q=0.9",
+		secChUa:        `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`,
+		platform:       `"Windows"`,
+	},
+	{
+		userAgent:      "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/123.0.0.0 Safari/537.36",
+		acceptLanguage: "en-GB,en;q=0.9",
+		secChUa:        `"Google Chrome";v="123", "Not:A-Brand";v="8", "Chromium";v="123"`,
+		platform:       `"Linux"`,
+	},
+	{
+		userAgent:      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+		acceptLanguage: "en-US,en;q=0.8",
+	},
+}
+
+// challengeMarkers - body fragments of Cloudflare challenge and block pages
+var challengeMarkers = [][]byte{
+	[]byte("cf-chl-"),
+	[]byte("challenge-platform"),
+	[]byte("Just a moment..."),
+	[]byte("Attention Required! | Cloudflare"),
+	[]byte("cf-error-details"),
+	[]byte("cf_chl_opt"),
+}
+
+// IsChallenge checks if response is Cloudflare challenge/block page
+func IsChallenge(header http.Header, body []byte) bool {
+	if header.Get("Cf-Mitigated") == "challenge" {
+		return true
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	isHTML := strings.Contains(strings.ToLower(header.Get("Content-Type")), "text/html") ||
+		bytes.HasPrefix(bytes.ToLower(trimmed), []byte("<!doctype html")) ||
+		bytes.HasPrefix(bytes.ToLower(trimmed), []byte("<html"))
+	if !isHTML {
+		return false
+	}
+
+	for _, marker := range challengeMarkers {
+		if bytes.Contains(body, marker) {
+			return true
+		}
+	}
+	// HTML from Cloudflare edge instead of JSON API response
+	return strings.EqualFold(header.Get("Server"), "cloudflare")
+}
+
+// Stats - block statistics of one client
+type Stats struct {
+	Name          string
+	Requests      int64     // responses observed
+	Blocked       int64     // Cloudflare blocks
+	WindowBlocked int       // blocks in spike window
+	WindowRate    float64   // share of blocked responses in spike window
+	BlockedUntil  time.Time // end of current cool-down (zero if not blocked)
+}
+
+// Guard - Cloudflare detection and mitigation state of one API client
+type Guard struct {
+	name string
+
+	mu           sync.Mutex
+	profile      int         // index of current header profile
+	blockStreak  int         // blocks in a row
+	blockedUntil time.Time   // requests wait until this time
+	requests     int64       // responses observed
+	blocked      int64       // blocks observed
+	window       []time.Time // response times in spike window
+	windowBlocks []time.Time // block times in spike window
+	lastAlert    time.Time
+}
+
+var (
+	guards      []*Guard
+	guardsMutex sync.Mutex
+
+	alertFunc      func(text string)
+	alertFuncMutex sync.RWMutex
+)
+
+// NewGuard creates guard for API client (name is used in logs, stats and alerts)
+func NewGuard(name string) *Guard {
+	g := &Guard{name: name}
+	guardsMutex.Lock()
+	guards = append(guards, g)
+	guardsMutex.Unlock()
+	return g
+}
+
+// SetAlertFunc sets sender of operator alerts on block rate spike (nil - alerts are only logged)
+func SetAlertFunc(alert func(text string)) {
+	alertFuncMutex.Lock()
+	alertFunc = alert
+	alertFuncMutex.Unlock()
+}
+
+// AllStats returns block statistics of all clients
+func AllStats() []Stats {
+	guardsMutex.Lock()
+	list := make([]*Guard, len(guards))
+	copy(list, guards)
+	guardsMutex.Unlock()
+
+	stats := make([]Stats, 0, len(list))
+	for _, g := range list {
+		stats = append(stats, g.Stats())
+	}
+	return stats
+}
+
+// Wait blocks until cool-down after Cloudflare block is over
+func (g *Guard) Wait(ctx context.Context) error {
+	g.mu.Lock()
+	wait := time.Until(g.blockedUntil)
+	g.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// SetHeaders sets browser headers of current profile (client specific headers are kept)
+func (g *Guard) SetHeaders(req *http.Request) {
+	g.mu.Lock()
+	profile := headerProfiles[g.profile]
+	g.mu.Unlock()
+
+	req.Header.Set("User-Agent", profile.userAgent)
+	req.Header.Set("Accept-Language", profile.acceptLanguage)
+	if profile.secChUa != "" {
+		req.Header.Set("Sec-Ch-Ua", profile.secChUa)
+		req.Header.Set("Sec-Ch-Ua-Mobile", "?0")
+		req.Header.Set("Sec-Ch-Ua-Platform", profile.platform)
+	} else {
+		req.Header.Del("Sec-Ch-Ua")
+		req.Header.Del("Sec-Ch-Ua-Mobile")
+		req.Header.Del("Sec-Ch-Ua-Platform")
+	}
+}
+
+// Observe records response and returns ErrBlocked if it is Cloudflare challenge
+// On block next requests wait for cool-down and use next header profile
+func (g *Guard) Observe(statusCode int, header http.Header, body []byte) error {
+	now := time.Now()
+	isBlocked := IsChallenge(header, body)
+
+	g.mu.Lock()
+	g.requests++
+	g.window = append(trimWindow(g.window, now), now)
+	if !isBlocked {
+		g.blockStreak = 0
+		g.windowBlocks = trimWindow(g.windowBlocks, now)
+		g.mu.Unlock()
+		return nil
+	}
+
+	g.blocked++
+	g.blockStreak++
+	g.windowBlocks = append(trimWindow(g.windowBlocks, now), now)
+	g.profile = (g.profile + 1) % len(headerProfiles)
+
+	cooldown := baseCooldown << (g.blockStreak - 1)
+	if cooldown > maxCooldown || cooldown <= 0 {
+		cooldown = maxCooldown
+	}
+	g.blockedUntil = now.Add(cooldown)
+
+	windowBlocked := len(g.windowBlocks)
+	windowRate := float64(windowBlocked) / float64(len(g.window))
+	shouldAlert := windowBlocked >= spikeMinBlocks && windowRate >= spikeRate && now.Sub(g.lastAlert) >= alertInterval
+	if shouldAlert {
+		g.lastAlert = now
+	}
+	streak := g.blockStreak
+	g.mu.Unlock()
+
+	log.LogWarn("Cloudflare block detected",
+		zap.String("client", g.name),
+		zap.Int("status", statusCode),
+		zap.Int("streak", streak),
+		zap.Duration("cooldown", cooldown),
+		zap.Int("windowBlocked", windowBlocked),
+		zap.Float64("windowRate", windowRate))
+
+	if shouldAlert {
+		g.alert(windowBlocked, windowRate)
+	}
+
+	return fmt.Errorf("%w (status %d)", ErrBlocked, statusCode)
+}
+
+// Stats returns block statistics of client
+func (g *Guard) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	g.window = trimWindow(g.window, now)
+	g.windowBlocks = trimWindow(g.windowBlocks, now)
+
+	stats := Stats{
+		Name:          g.name,
+		Requests:      g.requests,
+		Blocked:       g.blocked,
+		WindowBlocked: len(g.windowBlocks),
+	}
+	if len(g.window) > 0 {
+		stats.WindowRate = float64(len(g.windowBlocks)) / float64(len(g.window))
+	}
+	if g.blockedUntil.After(now) {
+		stats.BlockedUntil = g.blockedUntil
+	}
+	return stats
+}
+
+func (g *Guard) alert(windowBlocked int, windowRate float64) {
+	log.LogError("Cloudflare block rate spike",
+		zap.String("client", g.name),
+		zap.Int("windowBlocked", windowBlocked),
+		zap.Float64("windowRate", windowRate))
+
+	alertFuncMutex.RLock()
+	alert := alertFunc
+	alertFuncMutex.RUnlock()
+	if alert == nil {
+		return
+	}
+	alert(fmt.Sprintf("⚠️ <b>%s</b>: Cloudflare blocks %d of last requests (%.0f%%) in %s, requests are slowed down",
+		g.name, windowBlocked, windowRate*100, spikeWindow))
+}
+
+// trimWindow removes times older than spikeWindow
+func trimWindow(times []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-spikeWindow)
+	i := 0
+	for i < len(times) && times[i].Before(cutoff) {
+		i++
+	}
+	return times[i:]
+}
@@ -3,47 +3,191 @@ package config
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
+// DefaultHoldersTickers - tickers tracked by holders module if HOLDERS_TICKERS is not set
+var DefaultHoldersTickers = []string{"ASTY", "SOON", "BITTY"}
+
+// DefaultAlertRulesFile - alert rules file if ALERT_RULES_FILE is not set
+const DefaultAlertRulesFile = "alert_rules.yaml"
+
+// DefaultChartThemeFile - chart theme file if CHART_THEME_FILE is not set
+const DefaultChartThemeFile = "chart_theme.yaml"
+
 // Config -
 type Config struct {
 	Telegram TelegramConfig `mapstructure:"telegram"`
 	Flashnet FlashnetConfig `mapstructure:"flashnet"`
 	App      AppConfig      `mapstructure:"app"`
+	Trading  TradingConfig  `mapstructure:"trading"`
 }
 
 type TelegramConfig struct {
-	Bot1Token            string   `mapstructure:"bot1_token"`
-	Bot2Token            string   `mapstructure:"bot2_token"`
-	ApiBotToken          string   `mapstructure:"api_bot_token"` // API- for
-	BigSalesChatID       string   `mapstructure:"big_sales_chat_id"`
-	ApiBotChatID         string   `mapstructure:"api_bot_chat_id"`          // Chat ID for API-
-	FilteredChatID       string   `mapstructure:"filtered_chat_id"`         // Chat ID for tokens
-	FilteredTokens       []string `mapstructure:"filtered_tokens"`          // poolLpPublicKey from YAML or from .env)
-	BigSalesMinBTCAmount float64  `mapstructure:"big_sales_min_btc_amount"` // amount for (by default 0.0025)
-	FilteredMinBTCAmount float64  `mapstructure:"filtered_min_btc_amount"`  // amount for (by default 0.01)
-	StatsSendTime        string   `mapstructure:"stats_send_time"`          // time "10:00", by default "10:00")
-	HotTokenSwapsCount   int      `mapstructure:"hot_token_swaps_count"`    // count for token (by default 6)
-	HotTokenMinAddresses int      `mapstructure:"hot_token_min_addresses"`  // count for token (by default 3)
+	Bot1Token              string   `mapstructure:"bot1_token"`
+	Bot2Token              string   `mapstructure:"bot2_token"`
+	ApiBotToken            string   `mapstructure:"api_bot_token"` // API- for
+	BigSalesChatID         string   `mapstructure:"big_sales_chat_id"`
+	ApiBotChatID           string   `mapstructure:"api_bot_chat_id"`           // Chat ID for API-
+	FilteredChatID         string   `mapstructure:"filtered_chat_id"`          // Chat ID for tokens
+	FilteredTokens         []string `mapstructure:"filtered_tokens"`           // poolLpPublicKey from YAML or from .env)
+	BigSalesMinBTCAmount   float64  `mapstructure:"big_sales_min_btc_amount"`  // amount for (by default 0.0025)
+	FilteredMinBTCAmount   float64  `mapstructure:"filtered_min_btc_amount"`   // amount for (by default 0.01)
+	StatsSendTime          string   `mapstructure:"stats_send_time"`           // time "10:00", by default "10:00")
+	DigestSendTime         string   `mapstructure:"digest_send_time"`          // time (MSK) of daily digest of previous day's swaps, by default "09:00"
+	HotTokenSwapsCount     int      `mapstructure:"hot_token_swaps_count"`     // count for token (by default 6)
+	HotTokenMinAddresses   int      `mapstructure:"hot_token_min_addresses"`   // count for token (by default 3)
+	HotTokenMinScore       float64  `mapstructure:"hot_token_min_score"`       // hot token score (0-100) for alert (by default 40)
+	HotTokenCooldown       int      `mapstructure:"hot_token_cooldown"`        // minutes without alerts of token after alert (by default 60)
+	HotTokenScoreIncrease  float64  `mapstructure:"hot_token_score_increase"`  // score increase (percent) over last alert to alert token again (by default 50)
+	AutoBlacklistThreshold int      `mapstructure:"auto_blacklist_threshold"`  // risk score (0-100) for auto-blacklist, 0 - disabled (by default 70)
+	FastPathMultiplier     float64  `mapstructure:"fast_path_multiplier"`      // swaps above chat threshold x N are sent as minimal alert first, 0 - disabled (by default 10)
+	LiquidityChangePercent float64  `mapstructure:"liquidity_change_percent"`  // TVL change (percent) of tracked pool within window for alert, 0 - disabled (by default 30)
+	LiquidityWindow        int      `mapstructure:"liquidity_window"`          // window of TVL change in minutes (by default 60)
+	SuspiciousMinBTC       float64  `mapstructure:"suspicious_min_btc"`        // volume (BTC) of wash trading pattern for suspicious activity alert, 0 - disabled (by default 0.01)
+	AccumulationPercent    float64  `mapstructure:"accumulation_percent"`      // net buying of wallet over 7 days (% of token supply) for accumulation alert, 0 - disabled (by default 0.5)
+	AccumulationMinBTC     float64  `mapstructure:"accumulation_min_btc"`      // net buying of wallet over 7 days (BTC) for accumulation alert, 0 - disabled (by default 0.05)
+	ConcentrationTopRise   float64  `mapstructure:"concentration_top_rise"`    // rise of top-10 holders share (percentage points) between daily snapshots for sell pressure alert, 0 - disabled (by default 5)
+	ConcentrationGiniRise  float64  `mapstructure:"concentration_gini_rise"`   // rise of holders Gini coefficient between daily snapshots for sell pressure alert, 0 - disabled (by default 0.05)
+	ConcentrationTopSell   float64  `mapstructure:"concentration_top_sell"`    // part of balance (percent) sold by top-5 holder between daily snapshots for sell pressure alert, 0 - disabled (by default 20)
+	ReserveDrainPercent    float64  `mapstructure:"reserve_drain_percent"`     // BTC reserve drop (percent) of tracked pool within an hour for drain alert, 0 - disabled (by default 20)
+	ReserveInterval        int      `mapstructure:"reserve_interval"`          // minutes between pool reserve snapshots (by default 5)
+	SwapPollInterval       int      `mapstructure:"swap_poll_interval"`        // seconds between swaps requests of big sales monitor (by default 5)
+	SwapPollLimit          int      `mapstructure:"swap_poll_limit"`           // swaps per request (by default 100)
+	SwapPollIdleInterval   int      `mapstructure:"swap_poll_idle_interval"`   // longest seconds between requests when no new swaps, not above swap_poll_interval - adaptive polling disabled (by default 30)
+	SwapPollIdleAfter      int      `mapstructure:"swap_poll_idle_after"`      // seconds without new swaps before polling slows down (by default 120)
+	Language               string   `mapstructure:"language"`                  // language of bot messages (en, ru) in chats without /lang or chat_languages (by default en)
+	AdminUserIDs           []string `mapstructure:"admin_user_ids"`            // Telegram user IDs of bot owners: run every command and manage admins with /admins
+	ChatAdmins             bool     `mapstructure:"chat_admins"`               // administrators of chat are bot admins (by default false)
+	HoldersReportTickers   []string `mapstructure:"holders_report_tickers"`    // tracked tickers whose holders report (/flash) is posted daily to filtered chat, empty - disabled
+	HoldersReportTime      string   `mapstructure:"holders_report_time"`       // time (MSK) of holders report of previous day, empty - report of check day right after daily balance check
+	HoldersReportSkipEmpty bool     `mapstructure:"holders_report_skip_empty"` // holders report of day without holder changes is not posted (by default false)
+
+	// Marketcap range (USD) of big sales and filtered chats, 0 - no bound (tokens with unknown marketcap are skipped when set)
+	BigSalesMinMarketCapUSD float64 `mapstructure:"big_sales_min_marketcap_usd"` // swaps of tokens below marketcap are not sent to big sales chat
+	BigSalesMaxMarketCapUSD float64 `mapstructure:"big_sales_max_marketcap_usd"` // swaps of tokens above marketcap are not sent to big sales chat
+	FilteredMinMarketCapUSD float64 `mapstructure:"filtered_min_marketcap_usd"`  // swaps of tokens below marketcap are not sent to filtered chat
+	FilteredMaxMarketCapUSD float64 `mapstructure:"filtered_max_marketcap_usd"`  // swaps of tokens above marketcap are not sent to filtered chat
+
+	Destinations   []DestinationConfig `mapstructure:"destinations"`    // extra chats for swap notifications (YAML only)
+	CommunityChats map[string]string   `mapstructure:"community_chats"` // ticker -> community chat ID or @username, member count is sampled for /community (YAML only)
+	Webhooks       []WebhookConfig     `mapstructure:"webhooks"`        // sources of external signals for webhook server (app.webhook_addr, YAML only)
+	QuietHours     []QuietHoursConfig  `mapstructure:"quiet_hours"`     // chats holding alerts for one summary at night, /quiet overrides (YAML only)
+	ChatLanguages  map[string]string   `mapstructure:"chat_languages"`  // chat ID -> language of bot messages, /lang overrides (YAML only)
+	CommandLevels  map[string]string   `mapstructure:"command_levels"`  // command -> member, admin or owner, overrides default levels (YAML only)
+}
+
+// DestinationConfig - Telegram chat receiving swap notifications with its own filters
+type DestinationConfig struct {
+	Name     string   `mapstructure:"name"`
+	ChatID   string   `mapstructure:"chat_id"`
+	BotToken string   `mapstructure:"bot_token"` // empty - API bot (or bot1)
+	MinBTC   float64  `mapstructure:"min_btc"`   // 0 - big_sales_min_btc_amount
+	Tokens   []string `mapstructure:"tokens"`    // tickers or poolLpPublicKey, empty - all tokens
+	Side     string   `mapstructure:"side"`      // buy, sell or empty for both
+
+	MinMarketCapUSD float64 `mapstructure:"min_marketcap_usd"` // swaps of tokens below marketcap (USD) are not sent, 0 - no bound
+	MaxMarketCapUSD float64 `mapstructure:"max_marketcap_usd"` // swaps of tokens above marketcap (USD) are not sent, 0 - no bound
+}
+
+// WebhookConfig - source of signals posted to webhook server, signed with its secret
+type WebhookConfig struct {
+	Name     string   `mapstructure:"name"`      // URL path: POST /webhook/{name}
+	Secret   string   `mapstructure:"secret"`    // HMAC-SHA256 key of source
+	ChatIDs  []string `mapstructure:"chat_ids"`  // chats receiving signals
+	BotToken string   `mapstructure:"bot_token"` // empty - API bot (or bot1)
+}
+
+// QuietHoursConfig - daily window when alerts of chat are held and sent as one summary after it ends
+type QuietHoursConfig struct {
+	ChatID   string `mapstructure:"chat_id"`
+	Window   string `mapstructure:"window"`   // "01:00-08:00", can cross midnight
+	Timezone string `mapstructure:"timezone"` // IANA name, empty - Europe/Moscow
 }
 
 // FlashnetConfig - Flashnet API
 type FlashnetConfig struct {
 	Network        string `mapstructure:"network"`
+	APIURL         string `mapstructure:"api_url"` // overrides API URL of network (required for regtest, dev and other custom networks)
 	PublicKey      string `mapstructure:"public_key"`
+	PrivateKey     string `mapstructure:"private_key"`   // identity private key (hex) for challenge signing
+	KeystorePath   string `mapstructure:"keystore_path"` // file with private key if PRIVATE_KEY is not set
 	RequestTimeout int    `mapstructure:"request_timeout"`
 	MaxRetries     int    `mapstructure:"max_retries"`
+	// TokenRenewMargin - JWT is renewed this many minutes before expiry
+	TokenRenewMargin int `mapstructure:"token_renew_margin"`
+	// Retry of 429/502/503 responses: backoff starts at RetryDelayMs and grows by RetryBackoff up to RetryMaxDelaySec
+	// Retry-After of response is honored up to RetryMaxDelaySec
+	RetryDelayMs     int     `mapstructure:"retry_delay_ms"`
+	RetryBackoff     float64 `mapstructure:"retry_backoff"`
+	RetryMaxDelaySec int     `mapstructure:"retry_max_delay_sec"`
+
+	Accounts        []AccountConfig   `mapstructure:"accounts"`         // extra identities, token files in data_in/{public_key}/ (YAML only)
+	MonitorAccounts map[string]string `mapstructure:"monitor_accounts"` // monitor name -> account name, unset - default identity (YAML only)
+}
+
+// AccountConfig - Flashnet identity used by monitors listed in flashnet.monitor_accounts
+type AccountConfig struct {
+	Name         string `mapstructure:"name"`
+	PublicKey    string `mapstructure:"public_key"`    // empty - derived from private key
+	PrivateKey   string `mapstructure:"private_key"`   // identity private key (hex) for challenge signing
+	KeystorePath string `mapstructure:"keystore_path"` // file with private key if private_key is not set
+}
+
+// TradingConfig - swap placement through Flashnet AMM (internal/features/trading)
+type TradingConfig struct {
+	Enabled               bool    `mapstructure:"enabled"`                  // swaps can be executed only if set (env: TRADING_ENABLED, by default false)
+	MaxSlippageBps        int     `mapstructure:"max_slippage_bps"`         // orders with higher slippage are rejected (by default 100)
+	MaxAmountSats         int64   `mapstructure:"max_amount_sats"`          // orders with larger BTC side are rejected (by default 100000)
+	MaxPriceImpactPercent float64 `mapstructure:"max_price_impact_percent"` // quotes with higher price impact are rejected, 0 - not checked (by default 5)
 }
 
 // AppConfig -
 type AppConfig struct {
-	DataDir         string `mapstructure:"data_dir"`
-	CheckInterval   int    `mapstructure:"check_interval"`
-	MaxResponseSize int64  `mapstructure:"max_response_size"`
+	DataDir                string   `mapstructure:"data_dir"`
+	OutputDir              string   `mapstructure:"output_dir"` // state, archives, reports and charts (by default data_out)
+	CheckInterval          int      `mapstructure:"check_interval"`
+	MaxResponseSize        int64    `mapstructure:"max_response_size"`
+	HoldersTickers         []string `mapstructure:"holders_tickers"`          // tickers for holders tracking (env: HOLDERS_TICKERS, comma-separated)
+	HoldersBalanceWorkers  int      `mapstructure:"holders_balance_workers"`  // concurrent wallet balance requests of holders check (by default 4)
+	HoldersBalanceRate     float64  `mapstructure:"holders_balance_rate"`     // wallet balance requests per second of holders check (by default 3)
+	HoldersBalanceRetries  int      `mapstructure:"holders_balance_retries"`  // retry rounds of wallets whose balance request failed (by default 2)
+	HoldersMode            string   `mapstructure:"holders_mode"`             // api - wallet balance on every swap, swap_delta - balance from swap amounts, reconciled by daily check (env: HOLDERS_MODE, by default api)
+	ArchiveCompressDays    int      `mapstructure:"archive_compress_days"`    // archive files older than N days are gzipped, 0 - disabled (by default 7)
+	EventsRetentionDays    int      `mapstructure:"events_retention_days"`    // event log files (data_out/events) older than N days are removed, 0 - kept forever (by default 90)
+	AlertRulesFile         string   `mapstructure:"alert_rules_file"`         // YAML/JSON alert rules evaluated for each new swap (by default alert_rules.yaml)
+	ChartThemeFile         string   `mapstructure:"chart_theme_file"`         // YAML chart theme: light/dark themes, fonts, canvas and per-chat logos (by default chart_theme.yaml, missing file - built-in dark theme)
+	MonitorErrorBudget     int      `mapstructure:"monitor_error_budget"`     // consecutive monitor failures before restart (by default 10)
+	WhaleSupplyPercent     float64  `mapstructure:"whale_supply_percent"`     // holding above % of token supply marks whale wallet, 0 - disabled (by default 1)
+	AdminAPIAddr           string   `mapstructure:"admin_api_addr"`           // listen address of HTTP admin API ("127.0.0.1:8090"), empty - disabled
+	AdminAPIToken          string   `mapstructure:"admin_api_token"`          // bearer token of admin API (env: ADMIN_API_TOKEN)
+	SwapsAPIAddr           string   `mapstructure:"swaps_api_addr"`           // listen address of read-only swaps API for dashboards ("127.0.0.1:8093"), empty - disabled (env: SWAPS_API_ADDR)
+	SwapsAPIToken          string   `mapstructure:"swaps_api_token"`          // bearer token of swaps API, separate from admin token (env: SWAPS_API_TOKEN)
+	MetricsSink            string   `mapstructure:"metrics_sink"`             // time-series export of stats and flows: influx or prometheus (remote-write), empty - disabled (env: METRICS_SINK)
+	MetricsURL             string   `mapstructure:"metrics_url"`              // write endpoint of metrics sink (env: METRICS_URL)
+	MetricsToken           string   `mapstructure:"metrics_token"`            // token of metrics sink, optional (env: METRICS_TOKEN)
+	MetricsIntervalMinutes int      `mapstructure:"metrics_interval_minutes"` // minutes between metrics pushes (env: METRICS_INTERVAL_MINUTES, by default 60)
+	WebhookAddr            string   `mapstructure:"webhook_addr"`             // listen address of signal webhook server ("0.0.0.0:8091"), empty - disabled (env: WEBHOOK_ADDR)
+	HealthAddr             string   `mapstructure:"health_addr"`              // listen address of /healthz ("0.0.0.0:8092"), empty - disabled (env: HEALTH_ADDR)
+	HealthStallMinutes     int      `mapstructure:"health_stall_minutes"`     // swap monitors or swaps request without success for N minutes - unhealthy (by default 15)
+	Mode                   string   `mapstructure:"mode"`                     // bot (by default) or collector - data collection without Telegram (env: APP_MODE)
+	BTCPriceSource         string   `mapstructure:"btc_price_source"`         // coingecko (by default) or luminex, the other one is fallback (env: BTC_PRICE_SOURCE)
+}
+
+// App modes (app.mode)
+const (
+	AppModeBot       = "bot"
+	AppModeCollector = "collector"
+)
+
+// IsCollector returns true if bot runs without Telegram as data collector
+// (swap archive, holders, sampling monitors and admin API, no alerts and commands)
+func (c AppConfig) IsCollector() bool {
+	return c.Mode == AppModeCollector
 }
 
 // LoadConfig from env, and
@@ -71,15 +215,110 @@ func LoadConfig() (*Config, error) {
 	if v.IsSet("monitoring.filtered_min_btc_amount") {
 		v.Set("telegram.filtered_min_btc_amount", v.Get("monitoring.filtered_min_btc_amount"))
 	}
+	for _, key := range []string{"big_sales_min_marketcap_usd", "big_sales_max_marketcap_usd", "filtered_min_marketcap_usd", "filtered_max_marketcap_usd"} {
+		if v.IsSet("monitoring." + key) {
+			v.Set("telegram."+key, v.Get("monitoring."+key))
+		}
+	}
 	if v.IsSet("monitoring.stats_send_time") {
 		v.Set("telegram.stats_send_time", v.Get("monitoring.stats_send_time"))
 	}
+	if v.IsSet("monitoring.digest_send_time") {
+		v.Set("telegram.digest_send_time", v.Get("monitoring.digest_send_time"))
+	}
 	if v.IsSet("monitoring.hot_token.swaps_count") {
 		v.Set("telegram.hot_token_swaps_count", v.Get("monitoring.hot_token.swaps_count"))
 	}
 	if v.IsSet("monitoring.hot_token.min_addresses") {
 		v.Set("telegram.hot_token_min_addresses", v.Get("monitoring.hot_token.min_addresses"))
 	}
+	if v.IsSet("monitoring.hot_token.min_score") {
+		v.Set("telegram.hot_token_min_score", v.Get("monitoring.hot_token.min_score"))
+	}
+	if v.IsSet("monitoring.hot_token.cooldown") {
+		v.Set("telegram.hot_token_cooldown", v.Get("monitoring.hot_token.cooldown"))
+	}
+	if v.IsSet("monitoring.hot_token.score_increase") {
+		v.Set("telegram.hot_token_score_increase", v.Get("monitoring.hot_token.score_increase"))
+	}
+	if v.IsSet("monitoring.auto_blacklist_threshold") {
+		v.Set("telegram.auto_blacklist_threshold", v.Get("monitoring.auto_blacklist_threshold"))
+	}
+	if v.IsSet("monitoring.fast_path_multiplier") {
+		v.Set("telegram.fast_path_multiplier", v.Get("monitoring.fast_path_multiplier"))
+	}
+	if v.IsSet("monitoring.liquidity.change_percent") {
+		v.Set("telegram.liquidity_change_percent", v.Get("monitoring.liquidity.change_percent"))
+	}
+	if v.IsSet("monitoring.liquidity.window") {
+		v.Set("telegram.liquidity_window", v.Get("monitoring.liquidity.window"))
+	}
+	if v.IsSet("monitoring.reserves.drain_percent") {
+		v.Set("telegram.reserve_drain_percent", v.Get("monitoring.reserves.drain_percent"))
+	}
+	if v.IsSet("monitoring.reserves.interval") {
+		v.Set("telegram.reserve_interval", v.Get("monitoring.reserves.interval"))
+	}
+	if v.IsSet("monitoring.swap_poll.interval") {
+		v.Set("telegram.swap_poll_interval", v.Get("monitoring.swap_poll.interval"))
+	}
+	if v.IsSet("monitoring.swap_poll.limit") {
+		v.Set("telegram.swap_poll_limit", v.Get("monitoring.swap_poll.limit"))
+	}
+	if v.IsSet("monitoring.swap_poll.idle_interval") {
+		v.Set("telegram.swap_poll_idle_interval", v.Get("monitoring.swap_poll.idle_interval"))
+	}
+	if v.IsSet("monitoring.swap_poll.idle_after") {
+		v.Set("telegram.swap_poll_idle_after", v.Get("monitoring.swap_poll.idle_after"))
+	}
+	if v.IsSet("monitoring.suspicious_min_btc") {
+		v.Set("telegram.suspicious_min_btc", v.Get("monitoring.suspicious_min_btc"))
+	}
+	if v.IsSet("monitoring.accumulation.supply_percent") {
+		v.Set("telegram.accumulation_percent", v.Get("monitoring.accumulation.supply_percent"))
+	}
+	if v.IsSet("monitoring.accumulation.min_btc") {
+		v.Set("telegram.accumulation_min_btc", v.Get("monitoring.accumulation.min_btc"))
+	}
+	if v.IsSet("monitoring.concentration.top_share_rise") {
+		v.Set("telegram.concentration_top_rise", v.Get("monitoring.concentration.top_share_rise"))
+	}
+	if v.IsSet("monitoring.concentration.gini_rise") {
+		v.Set("telegram.concentration_gini_rise", v.Get("monitoring.concentration.gini_rise"))
+	}
+	if v.IsSet("monitoring.concentration.top_seller_percent") {
+		v.Set("telegram.concentration_top_sell", v.Get("monitoring.concentration.top_seller_percent"))
+	}
+	if v.IsSet("monitoring.holders_report.tickers") {
+		v.Set("telegram.holders_report_tickers", v.Get("monitoring.holders_report.tickers"))
+	}
+	if v.IsSet("monitoring.holders_report.send_time") {
+		v.Set("telegram.holders_report_time", v.Get("monitoring.holders_report.send_time"))
+	}
+	if v.IsSet("monitoring.holders_report.skip_empty") {
+		v.Set("telegram.holders_report_skip_empty", v.Get("monitoring.holders_report.skip_empty"))
+	}
+
+	// telegram.destinations is YAML only - keep it when .env is read below
+	if v.IsSet("telegram.destinations") {
+		v.Set("telegram.destinations", v.Get("telegram.destinations"))
+	}
+	if v.IsSet("telegram.community_chats") {
+		v.Set("telegram.community_chats", v.Get("telegram.community_chats"))
+	}
+	if v.IsSet("telegram.chat_languages") {
+		v.Set("telegram.chat_languages", v.Get("telegram.chat_languages"))
+	}
+	if v.IsSet("telegram.command_levels") {
+		v.Set("telegram.command_levels", v.Get("telegram.command_levels"))
+	}
+	// flashnet.accounts and flashnet.monitor_accounts are YAML only as well
+	if v.IsSet("flashnet.accounts") {
+		v.Set("flashnet.accounts", v.Get("flashnet.accounts"))
+	}
+	if v.IsSet("flashnet.monitor_accounts") {
+		v.Set("flashnet.monitor_accounts", v.Get("flashnet.monitor_accounts"))
+	}
 
 	// Load from .env file (if -
 	v.SetConfigType("env")
@@ -102,30 +341,16 @@ func LoadConfig() (*Config, error) {
 	// FilteredTokens from in (for .env)
 	// If from .env Viper in
 	if filteredTokensRaw := v.Get("telegram.filtered_tokens"); filteredTokensRaw != nil {
-		switch v := filteredTokensRaw.(type) {
-		case string:
-			// If (from .env), parse
-			if v != "" {
-				config.Telegram.FilteredTokens = strings.Split(v, ",")
-				for i, token := range config.Telegram.FilteredTokens {
-					config.Telegram.FilteredTokens[i] = strings.TrimSpace(token)
-				}
-			} else {
-				config.Telegram.FilteredTokens = []string{}
-			}
-		case []string:
-			// If (from YAML), use
-			config.Telegram.FilteredTokens = v
-		case []interface{}:
-			// If Viper []interface{}, in []string
-			result := make([]string, 0, len(v))
-			for _, item := range v {
-				if str, ok := item.(string); ok {
-					result = append(result, strings.TrimSpace(str))
-				}
-			}
-			config.Telegram.FilteredTokens = result
-		}
+		config.Telegram.FilteredTokens = parseStringList(filteredTokensRaw)
+	}
+	if adminUserIDsRaw := v.Get("telegram.admin_user_ids"); adminUserIDsRaw != nil {
+		config.Telegram.AdminUserIDs = parseStringList(adminUserIDsRaw)
+	}
+	if holdersReportTickersRaw := v.Get("telegram.holders_report_tickers"); holdersReportTickersRaw != nil {
+		config.Telegram.HoldersReportTickers = parseStringList(holdersReportTickersRaw)
+	}
+	if holdersTickersRaw := v.Get("app.holders_tickers"); holdersTickersRaw != nil {
+		config.App.HoldersTickers = parseStringList(holdersTickersRaw)
 	}
 
 	// Check
@@ -136,6 +361,39 @@ func LoadConfig() (*Config, error) {
 	return &config, nil
 }
 
+// parseStringList list from YAML array or comma-separated string (.env)
+func parseStringList(raw interface{}) []string {
+	switch v := raw.(type) {
+	case string:
+		// If (from .env), parse
+		if v == "" {
+			return []string{}
+		}
+		result := strings.Split(v, ",")
+		for i, item := range result {
+			result[i] = strings.TrimSpace(item)
+		}
+		return result
+	case []string:
+		// If (from YAML), use
+		return v
+	case []interface{}:
+		// If Viper []interface{}, in []string
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			switch value := item.(type) {
+			case string:
+				result = append(result, strings.TrimSpace(value))
+			case int, int64, float64:
+				// Numeric IDs of YAML list (admin_user_ids)
+				result = append(result, fmt.Sprint(value))
+			}
+		}
+		return result
+	}
+	return []string{}
+}
+
 func setupEnvAliases(v *viper.Viper) {
 	// and SPARK_)
 	// TELEGRAM_BOT1_TOKEN -> telegram.bot1_token
@@ -150,20 +408,88 @@ func setupEnvAliases(v *viper.Viper) {
 	v.BindEnv("telegram.filtered_tokens", "FILTERED_TOKENS")
 	v.BindEnv("telegram.big_sales_min_btc_amount", "BIG_SALES_MIN_BTC_AMOUNT")
 	v.BindEnv("telegram.filtered_min_btc_amount", "FILTERED_MIN_BTC_AMOUNT")
+	v.BindEnv("telegram.big_sales_min_marketcap_usd", "BIG_SALES_MIN_MARKETCAP_USD")
+	v.BindEnv("telegram.big_sales_max_marketcap_usd", "BIG_SALES_MAX_MARKETCAP_USD")
+	v.BindEnv("telegram.filtered_min_marketcap_usd", "FILTERED_MIN_MARKETCAP_USD")
+	v.BindEnv("telegram.filtered_max_marketcap_usd", "FILTERED_MAX_MARKETCAP_USD")
 	v.BindEnv("telegram.stats_send_time", "STATS_SEND_TIME")
+	v.BindEnv("telegram.digest_send_time", "DIGEST_SEND_TIME")
 	v.BindEnv("telegram.hot_token_swaps_count", "HOT_TOKEN_SWAPS_COUNT")
 	v.BindEnv("telegram.hot_token_min_addresses", "HOT_TOKEN_MIN_ADDRESSES")
+	v.BindEnv("telegram.hot_token_min_score", "HOT_TOKEN_MIN_SCORE")
+	v.BindEnv("telegram.hot_token_cooldown", "HOT_TOKEN_COOLDOWN")
+	v.BindEnv("telegram.hot_token_score_increase", "HOT_TOKEN_SCORE_INCREASE")
+	v.BindEnv("telegram.auto_blacklist_threshold", "AUTO_BLACKLIST_THRESHOLD")
+	v.BindEnv("telegram.fast_path_multiplier", "FAST_PATH_MULTIPLIER")
+	v.BindEnv("telegram.liquidity_change_percent", "LIQUIDITY_CHANGE_PERCENT")
+	v.BindEnv("telegram.liquidity_window", "LIQUIDITY_WINDOW")
+	v.BindEnv("telegram.suspicious_min_btc", "SUSPICIOUS_MIN_BTC")
+	v.BindEnv("telegram.accumulation_percent", "ACCUMULATION_PERCENT")
+	v.BindEnv("telegram.accumulation_min_btc", "ACCUMULATION_MIN_BTC")
+	v.BindEnv("telegram.concentration_top_rise", "CONCENTRATION_TOP_RISE")
+	v.BindEnv("telegram.concentration_gini_rise", "CONCENTRATION_GINI_RISE")
+	v.BindEnv("telegram.concentration_top_sell", "CONCENTRATION_TOP_SELL")
+	v.BindEnv("telegram.reserve_drain_percent", "RESERVE_DRAIN_PERCENT")
+	v.BindEnv("telegram.reserve_interval", "RESERVE_INTERVAL")
+	v.BindEnv("telegram.swap_poll_interval", "SWAP_POLL_INTERVAL")
+	v.BindEnv("telegram.swap_poll_limit", "SWAP_POLL_LIMIT")
+	v.BindEnv("telegram.swap_poll_idle_interval", "SWAP_POLL_IDLE_INTERVAL")
+	v.BindEnv("telegram.swap_poll_idle_after", "SWAP_POLL_IDLE_AFTER")
+	v.BindEnv("telegram.language", "TELEGRAM_LANGUAGE")
+	v.BindEnv("telegram.admin_user_ids", "ADMIN_USER_IDS")
+	v.BindEnv("telegram.chat_admins", "TELEGRAM_CHAT_ADMINS")
+	v.BindEnv("telegram.holders_report_tickers", "HOLDERS_REPORT_TICKERS")
+	v.BindEnv("telegram.holders_report_time", "HOLDERS_REPORT_TIME")
+	v.BindEnv("telegram.holders_report_skip_empty", "HOLDERS_REPORT_SKIP_EMPTY")
 
 	// Flashnet -
 	v.BindEnv("flashnet.network", "NETWORK")
+	v.BindEnv("flashnet.api_url", "FLASHNET_API_URL")
 	v.BindEnv("flashnet.public_key", "PUBLIC_KEY")
+	v.BindEnv("flashnet.private_key", "PRIVATE_KEY")
+	v.BindEnv("flashnet.keystore_path", "FLASHNET_KEYSTORE")
 	v.BindEnv("flashnet.request_timeout", "SPARK_FLASHNET_REQUEST_TIMEOUT")
 	v.BindEnv("flashnet.max_retries", "SPARK_FLASHNET_MAX_RETRIES")
+	v.BindEnv("flashnet.token_renew_margin", "FLASHNET_TOKEN_RENEW_MARGIN")
+	v.BindEnv("flashnet.retry_delay_ms", "FLASHNET_RETRY_DELAY_MS")
+	v.BindEnv("flashnet.retry_backoff", "FLASHNET_RETRY_BACKOFF")
+	v.BindEnv("flashnet.retry_max_delay_sec", "FLASHNET_RETRY_MAX_DELAY_SEC")
 
 	// App -
 	v.BindEnv("app.data_dir", "SPARK_APP_DATA_DIR")
+	v.BindEnv("app.output_dir", "SPARK_APP_OUTPUT_DIR")
 	v.BindEnv("app.check_interval", "SPARK_APP_CHECK_INTERVAL")
 	v.BindEnv("app.max_response_size", "SPARK_APP_MAX_RESPONSE_SIZE")
+	v.BindEnv("app.holders_tickers", "HOLDERS_TICKERS")
+	v.BindEnv("app.holders_balance_workers", "HOLDERS_BALANCE_WORKERS")
+	v.BindEnv("app.holders_balance_rate", "HOLDERS_BALANCE_RATE")
+	v.BindEnv("app.holders_balance_retries", "HOLDERS_BALANCE_RETRIES")
+	v.BindEnv("app.holders_mode", "HOLDERS_MODE")
+	v.BindEnv("app.archive_compress_days", "ARCHIVE_COMPRESS_DAYS")
+	v.BindEnv("app.events_retention_days", "EVENTS_RETENTION_DAYS")
+	v.BindEnv("app.alert_rules_file", "ALERT_RULES_FILE")
+	v.BindEnv("app.chart_theme_file", "CHART_THEME_FILE")
+	v.BindEnv("app.monitor_error_budget", "MONITOR_ERROR_BUDGET")
+	v.BindEnv("app.whale_supply_percent", "WHALE_SUPPLY_PERCENT")
+	v.BindEnv("app.admin_api_addr", "ADMIN_API_ADDR")
+	v.BindEnv("app.admin_api_token", "ADMIN_API_TOKEN")
+	v.BindEnv("app.swaps_api_addr", "SWAPS_API_ADDR")
+	v.BindEnv("app.swaps_api_token", "SWAPS_API_TOKEN")
+	v.BindEnv("app.metrics_sink", "METRICS_SINK")
+	v.BindEnv("app.metrics_url", "METRICS_URL")
+	v.BindEnv("app.metrics_token", "METRICS_TOKEN")
+	v.BindEnv("app.metrics_interval_minutes", "METRICS_INTERVAL_MINUTES")
+	v.BindEnv("app.webhook_addr", "WEBHOOK_ADDR")
+	v.BindEnv("app.health_addr", "HEALTH_ADDR")
+	v.BindEnv("app.health_stall_minutes", "HEALTH_STALL_MINUTES")
+	v.BindEnv("app.mode", "APP_MODE")
+	v.BindEnv("app.btc_price_source", "BTC_PRICE_SOURCE")
+
+	// Trading
+	v.BindEnv("trading.enabled", "TRADING_ENABLED")
+	v.BindEnv("trading.max_slippage_bps", "TRADING_MAX_SLIPPAGE_BPS")
+	v.BindEnv("trading.max_amount_sats", "TRADING_MAX_AMOUNT_SATS")
+	v.BindEnv("trading.max_price_impact_percent", "TRADING_MAX_PRICE_IMPACT_PERCENT")
 }
 
 // setDefaults by default
@@ -179,22 +505,90 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("telegram.big_sales_min_btc_amount", 0.0025) // 0.0025 BTC by default
 	v.SetDefault("telegram.filtered_min_btc_amount", 0.01)    // 0.01 BTC by default
 	v.SetDefault("telegram.stats_send_time", "10:00")         // 10:00 by default
+	v.SetDefault("telegram.digest_send_time", "09:00")        // 09:00 by default
 	v.SetDefault("telegram.hot_token_swaps_count", 6)         // 6 by default
 	v.SetDefault("telegram.hot_token_min_addresses", 3)       // 3 addresses by default
+	v.SetDefault("telegram.hot_token_min_score", 40.0)        // 40 by default
+	v.SetDefault("telegram.hot_token_cooldown", 60)           // 60 minutes by default
+	v.SetDefault("telegram.hot_token_score_increase", 50.0)   // 50% by default
+	v.SetDefault("telegram.auto_blacklist_threshold", 70)     // 70 by default
+	v.SetDefault("telegram.fast_path_multiplier", 10.0)       // 10 by default
+	v.SetDefault("telegram.liquidity_change_percent", 30.0)   // 30% by default
+	v.SetDefault("telegram.liquidity_window", 60)             // 60 minutes by default
+	v.SetDefault("telegram.suspicious_min_btc", 0.01)         // 0.01 BTC by default
+	v.SetDefault("telegram.accumulation_percent", 0.5)        // 0.5% of supply in 7 days
+	v.SetDefault("telegram.accumulation_min_btc", 0.05)       // 0.05 BTC in 7 days
+	v.SetDefault("telegram.concentration_top_rise", 5.0)      // +5 pp of top-10 share in a day
+	v.SetDefault("telegram.concentration_gini_rise", 0.05)    // +0.05 Gini in a day
+	v.SetDefault("telegram.concentration_top_sell", 20.0)     // 20% of top-5 holder balance in a day
+	v.SetDefault("telegram.reserve_drain_percent", 20.0)      // 20% by default
+	v.SetDefault("telegram.reserve_interval", 5)              // 5 minutes by default
+	v.SetDefault("telegram.swap_poll_interval", 5)            // 5 seconds by default
+	v.SetDefault("telegram.swap_poll_limit", 100)             // 100 swaps by default
+	v.SetDefault("telegram.swap_poll_idle_interval", 30)      // 30 seconds by default
+	v.SetDefault("telegram.swap_poll_idle_after", 120)        // 2 minutes by default
+	v.SetDefault("telegram.language", "en")                   // English by default
+	v.SetDefault("telegram.admin_user_ids", []string{})
+	v.SetDefault("telegram.chat_admins", false)
+	v.SetDefault("telegram.holders_report_tickers", []string{})
+	v.SetDefault("telegram.holders_report_time", "") // after daily balance check by default
+	v.SetDefault("telegram.holders_report_skip_empty", false)
 
 	// Flashnet
 	v.SetDefault("flashnet.network", "mainnet")
+	v.SetDefault("flashnet.api_url", "")
 	v.SetDefault("flashnet.public_key", "")
+	v.SetDefault("flashnet.private_key", "")
+	v.SetDefault("flashnet.keystore_path", "")
 	v.SetDefault("flashnet.request_timeout", 30)
 	v.SetDefault("flashnet.max_retries", 3)
+	v.SetDefault("flashnet.token_renew_margin", 10)
+	v.SetDefault("flashnet.retry_delay_ms", 500)
+	v.SetDefault("flashnet.retry_backoff", 2.0)
+	v.SetDefault("flashnet.retry_max_delay_sec", 30)
 
 	// App
 	v.SetDefault("app.data_dir", "data_in")
+	v.SetDefault("app.output_dir", "data_out")
 	v.SetDefault("app.check_interval", 30)
 	v.SetDefault("app.max_response_size", 10*1024*1024) // 10MB
+	v.SetDefault("app.holders_tickers", DefaultHoldersTickers)
+	v.SetDefault("app.holders_balance_workers", 4)
+	v.SetDefault("app.holders_balance_rate", 3.0)
+	v.SetDefault("app.holders_balance_retries", 2)
+	v.SetDefault("app.holders_mode", "api")
+	v.SetDefault("app.archive_compress_days", 7)
+	v.SetDefault("app.events_retention_days", 90)
+	v.SetDefault("app.alert_rules_file", DefaultAlertRulesFile)
+	v.SetDefault("app.chart_theme_file", DefaultChartThemeFile)
+	v.SetDefault("app.monitor_error_budget", 10)
+	v.SetDefault("app.whale_supply_percent", 1.0)
+	v.SetDefault("app.admin_api_addr", "")
+	v.SetDefault("app.swaps_api_addr", "")
+	v.SetDefault("app.metrics_sink", "")
+	v.SetDefault("app.metrics_interval_minutes", 60)
+	v.SetDefault("app.webhook_addr", "")
+	v.SetDefault("app.health_addr", "")
+	v.SetDefault("app.health_stall_minutes", 15)
+	v.SetDefault("app.mode", AppModeBot)
+	v.SetDefault("app.btc_price_source", "coingecko")
+
+	// Trading
+	v.SetDefault("trading.enabled", false)
+	v.SetDefault("trading.max_slippage_bps", 100)
+	v.SetDefault("trading.max_amount_sats", 100000)
+	v.SetDefault("trading.max_price_impact_percent", 5.0)
 }
 
 func setupFlags(v *viper.Viper) {
+	// Flags are defined and parsed once, config reload binds the same flags again
+	flagsOnce.Do(defineFlags)
+	v.BindPFlags(pflag.CommandLine)
+}
+
+var flagsOnce sync.Once
+
+func defineFlags() {
 	// Telegram
 	pflag.String("telegram.bot1_token", "", "Telegram Bot 1 token (env: SPARK_TELEGRAM_BOT1_TOKEN)")
 	pflag.String("telegram.bot2_token", "", "Telegram Bot 2 token (env: SPARK_TELEGRAM_BOT2_TOKEN)")
@@ -205,34 +599,244 @@ func setupFlags(v *viper.Viper) {
 	pflag.String("telegram.filtered_tokens", "", "Comma-separated list of poolLpPublicKey for filtered chat (env: FILTERED_TOKENS)")
 	pflag.Float64("telegram.big_sales_min_btc_amount", 0.0025, "Minimum BTC amount for big sales chat (env: BIG_SALES_MIN_BTC_AMOUNT)")
 	pflag.Float64("telegram.filtered_min_btc_amount", 0.01, "Minimum BTC amount for filtered chat (env: FILTERED_MIN_BTC_AMOUNT)")
+	pflag.Float64("telegram.big_sales_min_marketcap_usd", 0, "Minimum token marketcap (USD) for big sales chat, 0 disables (env: BIG_SALES_MIN_MARKETCAP_USD)")
+	pflag.Float64("telegram.big_sales_max_marketcap_usd", 0, "Maximum token marketcap (USD) for big sales chat, 0 disables (env: BIG_SALES_MAX_MARKETCAP_USD)")
+	pflag.Float64("telegram.filtered_min_marketcap_usd", 0, "Minimum token marketcap (USD) for filtered chat, 0 disables (env: FILTERED_MIN_MARKETCAP_USD)")
+	pflag.Float64("telegram.filtered_max_marketcap_usd", 0, "Maximum token marketcap (USD) for filtered chat, 0 disables (env: FILTERED_MAX_MARKETCAP_USD)")
 	pflag.String("telegram.stats_send_time", "10:00", "Time to send stats report (format: HH:MM, env: STATS_SEND_TIME)")
+	pflag.String("telegram.digest_send_time", "09:00", "Time (MSK) to send daily digest of previous day's swaps (format: HH:MM, env: DIGEST_SEND_TIME)")
 	pflag.Int("telegram.hot_token_swaps_count", 6, "Number of swaps to check for hot token (env: HOT_TOKEN_SWAPS_COUNT)")
 	pflag.Int("telegram.hot_token_min_addresses", 3, "Minimum number of different addresses for hot token (env: HOT_TOKEN_MIN_ADDRESSES)")
+	pflag.Float64("telegram.hot_token_min_score", 40.0, "Hot token score (0-100) to send alert (env: HOT_TOKEN_MIN_SCORE)")
+	pflag.Int("telegram.hot_token_cooldown", 60, "Minutes without alerts of token after hot token alert (env: HOT_TOKEN_COOLDOWN)")
+	pflag.Float64("telegram.hot_token_score_increase", 50.0, "Score increase (percent) over last alert to alert token again (env: HOT_TOKEN_SCORE_INCREASE)")
+	pflag.Int("telegram.auto_blacklist_threshold", 70, "Risk score (0-100) to auto-blacklist token, 0 disables (env: AUTO_BLACKLIST_THRESHOLD)")
+	pflag.Float64("telegram.fast_path_multiplier", 10.0, "Swaps above chat threshold x N are sent as minimal alert and edited with details, 0 disables (env: FAST_PATH_MULTIPLIER)")
+	pflag.Float64("telegram.liquidity_change_percent", 30.0, "TVL change (percent) of tracked pool within window for liquidity alert, 0 disables (env: LIQUIDITY_CHANGE_PERCENT)")
+	pflag.Int("telegram.liquidity_window", 60, "Window of TVL change for liquidity alert in minutes (env: LIQUIDITY_WINDOW)")
+	pflag.Float64("telegram.suspicious_min_btc", 0.01, "Volume (BTC) of wash trading pattern for suspicious activity alert, 0 disables (env: SUSPICIOUS_MIN_BTC)")
+	pflag.Float64("telegram.accumulation_percent", 0.5, "Net buying of wallet over 7 days (% of supply) for accumulation alert, 0 disables (env: ACCUMULATION_PERCENT)")
+	pflag.Float64("telegram.accumulation_min_btc", 0.05, "Net buying of wallet over 7 days (BTC) for accumulation alert, 0 disables (env: ACCUMULATION_MIN_BTC)")
+	pflag.Float64("telegram.concentration_top_rise", 5.0, "Rise of top-10 holders share (percentage points) between daily snapshots for sell pressure alert, 0 disables (env: CONCENTRATION_TOP_RISE)")
+	pflag.Float64("telegram.concentration_gini_rise", 0.05, "Rise of holders Gini coefficient between daily snapshots for sell pressure alert, 0 disables (env: CONCENTRATION_GINI_RISE)")
+	pflag.Float64("telegram.concentration_top_sell", 20.0, "Part of balance (percent) sold by top-5 holder between daily snapshots for sell pressure alert, 0 disables (env: CONCENTRATION_TOP_SELL)")
+	pflag.Float64("telegram.reserve_drain_percent", 20.0, "BTC reserve drop (percent) of tracked pool within an hour for drain alert, 0 disables (env: RESERVE_DRAIN_PERCENT)")
+	pflag.Int("telegram.reserve_interval", 5, "Minutes between pool reserve snapshots (env: RESERVE_INTERVAL)")
+	pflag.Int("telegram.swap_poll_interval", 5, "Seconds between swaps requests of big sales monitor (env: SWAP_POLL_INTERVAL)")
+	pflag.Int("telegram.swap_poll_limit", 100, "Swaps per request of big sales monitor (env: SWAP_POLL_LIMIT)")
+	pflag.Int("telegram.swap_poll_idle_interval", 30, "Longest seconds between swaps requests when no new swaps, not above swap_poll_interval disables adaptive polling (env: SWAP_POLL_IDLE_INTERVAL)")
+	pflag.Int("telegram.swap_poll_idle_after", 120, "Seconds without new swaps before swap polling slows down (env: SWAP_POLL_IDLE_AFTER)")
+	pflag.String("telegram.language", "en", "Language of bot messages (en, ru) in chats without /lang or chat_languages (env: TELEGRAM_LANGUAGE)")
+	pflag.String("telegram.admin_user_ids", "", "Comma-separated Telegram user IDs of bot owners (env: ADMIN_USER_IDS)")
+	pflag.Bool("telegram.chat_admins", false, "Administrators of chat are bot admins (env: TELEGRAM_CHAT_ADMINS)")
+	pflag.String("telegram.holders_report_tickers", "", "Comma-separated tracked tickers whose holders report is posted daily to filtered chat (env: HOLDERS_REPORT_TICKERS)")
+	pflag.String("telegram.holders_report_time", "", "Time (MSK) of daily holders report of previous day, empty posts report right after balance check (format: HH:MM, env: HOLDERS_REPORT_TIME)")
+	pflag.Bool("telegram.holders_report_skip_empty", false, "Holders report of day without holder changes is not posted (env: HOLDERS_REPORT_SKIP_EMPTY)")
 
 	// Flashnet
-	pflag.String("flashnet.network", "mainnet", "Network: mainnet or testnet (env: SPARK_FLASHNET_NETWORK)")
+	pflag.String("flashnet.network", "mainnet", "Network: mainnet, testnet or custom one with flashnet.api_url (env: SPARK_FLASHNET_NETWORK)")
+	pflag.String("flashnet.api_url", "", "Flashnet API URL, overrides URL of network (env: FLASHNET_API_URL)")
 	pflag.String("flashnet.public_key", "", "Public key for API auth (env: SPARK_FLASHNET_PUBLIC_KEY)")
+	pflag.String("flashnet.keystore_path", "", "Keystore file with private key for challenge signing (env: FLASHNET_KEYSTORE)")
 	pflag.Int("flashnet.request_timeout", 30, "Request timeout in seconds (env: SPARK_FLASHNET_REQUEST_TIMEOUT)")
 	pflag.Int("flashnet.max_retries", 3, "Max retries for failed requests (env: SPARK_FLASHNET_MAX_RETRIES)")
+	pflag.Int("flashnet.token_renew_margin", 10, "Renew JWT this many minutes before expiry (env: FLASHNET_TOKEN_RENEW_MARGIN)")
+	pflag.Int("flashnet.retry_delay_ms", 500, "Base backoff delay of retried requests in ms (env: FLASHNET_RETRY_DELAY_MS)")
+	pflag.Float64("flashnet.retry_backoff", 2.0, "Backoff factor of retried requests (env: FLASHNET_RETRY_BACKOFF)")
+	pflag.Int("flashnet.retry_max_delay_sec", 30, "Max delay between retries and max Retry-After waited, seconds (env: FLASHNET_RETRY_MAX_DELAY_SEC)")
 
 	// App
 	pflag.String("app.data_dir", "data_in", "Data directory (env: SPARK_APP_DATA_DIR)")
+	pflag.String("app.output_dir", "data_out", "Output directory for state, archives and charts (env: SPARK_APP_OUTPUT_DIR)")
 	pflag.Int("app.check_interval", 30, "Check interval in seconds (env: SPARK_APP_CHECK_INTERVAL)")
 	pflag.Int64("app.max_response_size", 10*1024*1024, "Max response size in bytes (env: SPARK_APP_MAX_RESPONSE_SIZE)")
-
+	pflag.String("app.holders_tickers", "", "Comma-separated list of tickers for holders tracking (env: HOLDERS_TICKERS)")
+	pflag.Int("app.holders_balance_workers", 4, "Concurrent wallet balance requests of holders check (env: HOLDERS_BALANCE_WORKERS)")
+	pflag.Float64("app.holders_balance_rate", 3.0, "Wallet balance requests per second of holders check (env: HOLDERS_BALANCE_RATE)")
+	pflag.Int("app.holders_balance_retries", 2, "Retry rounds of wallets whose balance request failed in holders check (env: HOLDERS_BALANCE_RETRIES)")
+	pflag.String("app.holders_mode", "api", "Holder balance on swap: api (wallet request) or swap_delta (swap amounts, reconciled by daily check) (env: HOLDERS_MODE)")
+	pflag.Int("app.archive_compress_days", 7, "Gzip archive files older than N days, 0 disables (env: ARCHIVE_COMPRESS_DAYS)")
+	pflag.Int("app.events_retention_days", 90, "Remove event log files older than N days, 0 keeps them (env: EVENTS_RETENTION_DAYS)")
+	pflag.String("app.alert_rules_file", DefaultAlertRulesFile, "YAML/JSON file with alert rules (env: ALERT_RULES_FILE)")
+	pflag.String("app.chart_theme_file", DefaultChartThemeFile, "YAML file with chart themes, fonts and per-chat logos (env: CHART_THEME_FILE)")
+	pflag.Int("app.monitor_error_budget", 10, "Consecutive monitor failures before restart with backoff (env: MONITOR_ERROR_BUDGET)")
+	pflag.Float64("app.whale_supply_percent", 1.0, "Holding above this % of token supply marks whale wallet, 0 disables (env: WHALE_SUPPLY_PERCENT)")
+	pflag.String("app.admin_api_addr", "", "Listen address of HTTP admin API, empty disables (env: ADMIN_API_ADDR)")
+	pflag.String("app.swaps_api_addr", "", "Listen address of read-only swaps API, empty disables (env: SWAPS_API_ADDR)")
+	pflag.String("app.metrics_sink", "", "Time-series export of stats and flows: influx or prometheus, empty disables (env: METRICS_SINK)")
+	pflag.String("app.metrics_url", "", "Write endpoint of metrics sink (env: METRICS_URL)")
+	pflag.Int("app.metrics_interval_minutes", 60, "Minutes between metrics pushes (env: METRICS_INTERVAL_MINUTES)")
+	pflag.String("app.webhook_addr", "", "Listen address of signal webhook server, empty disables (env: WEBHOOK_ADDR)")
+	pflag.String("app.health_addr", "", "Listen address of /healthz endpoint, empty disables (env: HEALTH_ADDR)")
+	pflag.Int("app.health_stall_minutes", 15, "Minutes without successful swaps request before /healthz returns 503 (env: HEALTH_STALL_MINUTES)")
+	pflag.String("app.btc_price_source", "coingecko", "Source of BTC/USD price: coingecko or luminex, the other one is fallback (env: BTC_PRICE_SOURCE)")
+	pflag.String("app.mode", AppModeBot, "bot or collector (no Telegram: swap archive, holders, sampling, admin API) (env: APP_MODE)")
+
+	// Trading
+	pflag.Bool("trading.enabled", false, "Allow swap execution through Flashnet AMM (env: TRADING_ENABLED)")
+	pflag.Int("trading.max_slippage_bps", 100, "Max slippage of order in bps (env: TRADING_MAX_SLIPPAGE_BPS)")
+	pflag.Int64("trading.max_amount_sats", 100000, "Max BTC side of order in sats (env: TRADING_MAX_AMOUNT_SATS)")
+	pflag.Float64("trading.max_price_impact_percent", 5.0, "Max price impact of quote in percent, 0 disables check (env: TRADING_MAX_PRICE_IMPACT_PERCENT)")
+
+	// Command flags (--handoff, --dry-run) are parsed by cobra, not here
+	pflag.CommandLine.ParseErrorsWhitelist.UnknownFlags = true
 	pflag.Parse()
-	v.BindPFlags(pflag.CommandLine)
 }
 
 func validateConfig(cfg *Config) error {
-	// Check, (Bot1Token or ApiBotToken)
-	if cfg.Telegram.Bot1Token == "" && cfg.Telegram.ApiBotToken == "" {
-		return fmt.Errorf("at least one bot token is required: telegram.bot1_token or telegram.api_bot_token")
+	cfg.App.Mode = strings.ToLower(strings.TrimSpace(cfg.App.Mode))
+	if cfg.App.Mode == "" {
+		cfg.App.Mode = AppModeBot
+	}
+	if cfg.App.Mode != AppModeBot && cfg.App.Mode != AppModeCollector {
+		return fmt.Errorf("app.mode must be %s or %s", AppModeBot, AppModeCollector)
+	}
+
+	// Collector mode doesn't use Telegram, bot tokens and chats are not required
+	if !cfg.App.IsCollector() {
+		// Check, (Bot1Token or ApiBotToken)
+		if cfg.Telegram.Bot1Token == "" && cfg.Telegram.ApiBotToken == "" {
+			return fmt.Errorf("at least one bot token is required: telegram.bot1_token or telegram.api_bot_token (or app.mode: collector)")
+		}
+
+		// Check, for Big Sales (BigSalesChatID or ApiBotChatID)
+		if cfg.Telegram.BigSalesChatID == "" && cfg.Telegram.ApiBotChatID == "" {
+			return fmt.Errorf("at least one big sales chat is required: telegram.big_sales_chat_id or telegram.api_bot_chat_id")
+		}
 	}
 
-	// Check, for Big Sales (BigSalesChatID or ApiBotChatID)
-	if cfg.Telegram.BigSalesChatID == "" && cfg.Telegram.ApiBotChatID == "" {
-		return fmt.Errorf("at least one big sales chat is required: telegram.big_sales_chat_id or telegram.api_bot_chat_id")
+	for i := range cfg.Telegram.Destinations {
+		destination := &cfg.Telegram.Destinations[i]
+		if destination.Name == "" {
+			destination.Name = fmt.Sprintf("destination %d", i+1)
+		}
+		if destination.ChatID == "" {
+			return fmt.Errorf("telegram.destinations %q: chat_id is required", destination.Name)
+		}
+		destination.Side = strings.ToLower(strings.TrimSpace(destination.Side))
+		if destination.Side != "" && destination.Side != "buy" && destination.Side != "sell" {
+			return fmt.Errorf("telegram.destinations %q: side must be buy, sell or empty", destination.Name)
+		}
+		if destination.MinMarketCapUSD < 0 || destination.MaxMarketCapUSD < 0 {
+			return fmt.Errorf("telegram.destinations %q: min_marketcap_usd and max_marketcap_usd can't be negative", destination.Name)
+		}
+		if destination.MaxMarketCapUSD > 0 && destination.MinMarketCapUSD > destination.MaxMarketCapUSD {
+			return fmt.Errorf("telegram.destinations %q: min_marketcap_usd is above max_marketcap_usd", destination.Name)
+		}
+	}
+	for _, chat := range []struct {
+		prefix   string
+		min, max float64
+	}{
+		{"telegram.big_sales", cfg.Telegram.BigSalesMinMarketCapUSD, cfg.Telegram.BigSalesMaxMarketCapUSD},
+		{"telegram.filtered", cfg.Telegram.FilteredMinMarketCapUSD, cfg.Telegram.FilteredMaxMarketCapUSD},
+	} {
+		if chat.min < 0 || chat.max < 0 {
+			return fmt.Errorf("%s_min_marketcap_usd and %s_max_marketcap_usd can't be negative", chat.prefix, chat.prefix)
+		}
+		if chat.max > 0 && chat.min > chat.max {
+			return fmt.Errorf("%s_min_marketcap_usd is above %s_max_marketcap_usd", chat.prefix, chat.prefix)
+		}
+	}
+
+	if cfg.Trading.MaxSlippageBps < 0 || cfg.Trading.MaxSlippageBps >= 10000 {
+		return fmt.Errorf("trading.max_slippage_bps must be between 0 and 9999")
+	}
+	if cfg.Trading.MaxAmountSats < 0 {
+		return fmt.Errorf("trading.max_amount_sats can't be negative")
+	}
+
+	if cfg.App.AdminAPIAddr != "" && cfg.App.AdminAPIToken == "" {
+		return fmt.Errorf("app.admin_api_token (ADMIN_API_TOKEN) is required when app.admin_api_addr is set")
+	}
+	if cfg.App.SwapsAPIAddr != "" && cfg.App.SwapsAPIToken == "" {
+		return fmt.Errorf("app.swaps_api_token (SWAPS_API_TOKEN) is required when app.swaps_api_addr is set")
+	}
+	switch cfg.App.MetricsSink {
+	case "":
+	case "influx", "prometheus":
+		if cfg.App.MetricsURL == "" {
+			return fmt.Errorf("app.metrics_url (METRICS_URL) is required when app.metrics_sink is set")
+		}
+		if cfg.App.MetricsIntervalMinutes <= 0 {
+			return fmt.Errorf("app.metrics_interval_minutes must be positive")
+		}
+	default:
+		return fmt.Errorf("app.metrics_sink must be influx or prometheus, got %q", cfg.App.MetricsSink)
+	}
+
+	webhookNames := make(map[string]bool)
+	for i := range cfg.Telegram.Webhooks {
+		webhook := &cfg.Telegram.Webhooks[i]
+		webhook.Name = strings.TrimSpace(webhook.Name)
+		if webhook.Name == "" || strings.Contains(webhook.Name, "/") {
+			return fmt.Errorf("telegram.webhooks[%d]: name is required and can't contain /", i)
+		}
+		if webhookNames[webhook.Name] {
+			return fmt.Errorf("telegram.webhooks %q: duplicate name", webhook.Name)
+		}
+		webhookNames[webhook.Name] = true
+		if len(webhook.Secret) < 16 {
+			return fmt.Errorf("telegram.webhooks %q: secret of at least 16 characters is required", webhook.Name)
+		}
+		if len(webhook.ChatIDs) == 0 {
+			return fmt.Errorf("telegram.webhooks %q: chat_ids is required", webhook.Name)
+		}
+	}
+
+	quietChats := make(map[string]bool)
+	for i := range cfg.Telegram.QuietHours {
+		quiet := &cfg.Telegram.QuietHours[i]
+		quiet.ChatID = strings.TrimSpace(quiet.ChatID)
+		if quiet.ChatID == "" {
+			return fmt.Errorf("telegram.quiet_hours[%d]: chat_id is required", i)
+		}
+		if quietChats[quiet.ChatID] {
+			return fmt.Errorf("telegram.quiet_hours %q: duplicate chat_id", quiet.ChatID)
+		}
+		quietChats[quiet.ChatID] = true
+		if strings.TrimSpace(quiet.Window) == "" {
+			return fmt.Errorf("telegram.quiet_hours %q: window is required", quiet.ChatID)
+		}
+	}
+
+	for chat, lang := range cfg.Telegram.ChatLanguages {
+		if strings.TrimSpace(lang) == "" {
+			return fmt.Errorf("telegram.chat_languages %q: language is required", chat)
+		}
+	}
+
+	for command, level := range cfg.Telegram.CommandLevels {
+		if strings.TrimSpace(level) == "" {
+			return fmt.Errorf("telegram.command_levels %q: level is required", command)
+		}
+	}
+
+	for ticker, chat := range cfg.Telegram.CommunityChats {
+		if strings.TrimSpace(chat) == "" {
+			return fmt.Errorf("telegram.community_chats %q: chat ID or @username is required", ticker)
+		}
+	}
+
+	accountNames := make(map[string]bool)
+	for i := range cfg.Flashnet.Accounts {
+		account := &cfg.Flashnet.Accounts[i]
+		account.Name = strings.TrimSpace(account.Name)
+		if account.Name == "" {
+			return fmt.Errorf("flashnet.accounts[%d]: name is required", i)
+		}
+		if accountNames[account.Name] {
+			return fmt.Errorf("flashnet.accounts %q: duplicate name", account.Name)
+		}
+		accountNames[account.Name] = true
+		if account.PublicKey == "" && account.PrivateKey == "" && account.KeystorePath == "" {
+			return fmt.Errorf("flashnet.accounts %q: public_key, private_key or keystore_path is required", account.Name)
+		}
+	}
+	for monitor, account := range cfg.Flashnet.MonitorAccounts {
+		if !accountNames[account] {
+			return fmt.Errorf("flashnet.monitor_accounts %q: unknown account %q", monitor, account)
+		}
 	}
 
 	return nil
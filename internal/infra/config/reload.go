@@ -0,0 +1,164 @@
+package config
+
+// Config reload without restart
+// config.yaml is watched (fsnotify) and reloaded on change and on SIGHUP, valid config replaces
+// current one atomically and is passed to onReload with list of changed keys
+// Invalid config is passed as error, current config stays
+// Environment variables (including .env values loaded on start) keep their values until restart
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// File - config file read by LoadConfig and watched by Watch
+const File = "config.yaml"
+
+// reloadDebounce - editors write file in several steps, reload once after the last one
+const reloadDebounce = 500 * time.Millisecond
+
+var current atomic.Pointer[Config]
+
+// Current returns config in use (nil before SetCurrent)
+func Current() *Config {
+	return current.Load()
+}
+
+// SetCurrent sets config in use (on start, Reload replaces it)
+func SetCurrent(cfg *Config) {
+	current.Store(cfg)
+}
+
+// Change - changed config key, secrets are masked
+type Change struct {
+	Key string // telegram.big_sales_min_btc_amount
+	Old string
+	New string
+}
+
+// Reload loads config and replaces current one if it is valid
+// Returns previous config and changed keys
+func Reload() (*Config, []Change, error) {
+	next, err := LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	previous := current.Swap(next)
+	if previous == nil {
+		return nil, nil, nil
+	}
+	return previous, Diff(previous, next), nil
+}
+
+// Diff returns keys changed between configs, sorted by key
+func Diff(old, new *Config) []Change {
+	var changes []Change
+	diffValue("", reflect.ValueOf(*old), reflect.ValueOf(*new), &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+func diffValue(key string, old, new reflect.Value, changes *[]Change) {
+	if old.Kind() == reflect.Struct {
+		for i := 0; i < old.NumField(); i++ {
+			name := old.Type().Field(i).Tag.Get("mapstructure")
+			if name == "" {
+				continue
+			}
+			if key != "" {
+				name = key + "." + name
+			}
+			diffValue(name, old.Field(i), new.Field(i), changes)
+		}
+		return
+	}
+	if reflect.DeepEqual(old.Interface(), new.Interface()) {
+		return
+	}
+	*changes = append(*changes, Change{Key: key, Old: formatValue(key, old), New: formatValue(key, new)})
+}
+
+func formatValue(key string, value reflect.Value) string {
+	if isSecretKey(key) {
+		if value.IsZero() {
+			return ""
+		}
+		return "***"
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
+
+// isSecretKey - tokens and keys are not logged (destinations, webhooks and accounts contain them too)
+func isSecretKey(key string) bool {
+	if strings.HasSuffix(key, "_token") || strings.HasSuffix(key, "private_key") {
+		return true
+	}
+	for _, part := range []string{"secret", "destinations", "webhooks", "accounts"} {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch reloads config on change of file and on SIGHUP until ctx is done
+// onReload gets changes of valid config (Current), or error of invalid one
+func Watch(ctx context.Context, file string, onReload func(changes []Change, err error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Directory is watched: editors replace file by rename, watch of file would be lost
+	file, err = filepath.Abs(file)
+	if err != nil {
+		return fmt.Errorf("failed to resolve config path: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		return fmt.Errorf("failed to watch config directory: %w", err)
+	}
+
+	hangup := notifyReload()
+	defer stopReload(hangup)
+
+	reload := func() {
+		_, changes, err := Reload()
+		onReload(changes, err)
+	}
+
+	debounce := time.NewTimer(reloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == file && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				debounce.Reset(reloadDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			onReload(nil, fmt.Errorf("config watcher: %w", err))
+		case <-debounce.C:
+			reload()
+		case <-hangup:
+			reload()
+		}
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package config
+
+import "os"
+
+// notifyReload returns channel that never receives (SIGHUP is not available), config is reloaded on file change only
+func notifyReload() chan os.Signal {
+	return make(chan os.Signal)
+}
+
+func stopReload(ch chan os.Signal) {}
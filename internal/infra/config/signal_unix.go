@@ -0,0 +1,20 @@
+//go:build unix
+
+package config
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyReload returns channel receiving SIGHUP (config reload request)
+func notifyReload() chan os.Signal {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	return ch
+}
+
+func stopReload(ch chan os.Signal) {
+	signal.Stop(ch)
+}
@@ -0,0 +1,240 @@
+package dryrun
+
+// Dry-run (paper) mode: monitors run fully, but Telegram messages are written to file instead of being sent
+// Bots are created with HTTP client that passes read requests (getMe, getUpdates, getChatMemberCount, ...) to Telegram
+// and answers send/edit/delete requests itself, so monitors see a successful send
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"spark-wallet/internal/infra/health"
+	"spark-wallet/internal/infra/network"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// DefaultMessagesFile - messages written in dry-run mode (JSON lines)
+const DefaultMessagesFile = "logs/dry_run_messages.jsonl"
+
+// Entry - Telegram request captured in dry-run mode
+type Entry struct {
+	Time   string            `json:"time"` // RFC3339
+	BotID  string            `json:"bot_id"`
+	Method string            `json:"method"`
+	ChatID string            `json:"chat_id,omitempty"`
+	Text   string            `json:"text,omitempty"` // text or caption
+	Params map[string]string `json:"params,omitempty"`
+	Files  []string          `json:"files,omitempty"` // names of uploaded files (photos, documents)
+}
+
+var (
+	enabled      atomic.Bool
+	messagesFile = DefaultMessagesFile
+	fileMutex    sync.Mutex
+	messageID    atomic.Int64
+)
+
+// Enable turns dry-run mode on, file - messages file (empty - DefaultMessagesFile)
+// Must be called before bots are created
+func Enable(file string) {
+	if file != "" {
+		messagesFile = file
+	}
+	enabled.Store(true)
+}
+
+// Enabled returns true in dry-run mode
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// MessagesFile returns file messages are written to
+func MessagesFile() string {
+	return messagesFile
+}
+
+// EnabledFromEnv returns true if DRY_RUN env is set to true value
+func EnabledFromEnv() bool {
+	value, err := strconv.ParseBool(strings.TrimSpace(os.Getenv("DRY_RUN")))
+	return err == nil && value
+}
+
+// NewBotAPI creates Telegram bot, in dry-run mode its messages are written to file
+// Messages of non-mainnet instance are prefixed with network label (written to file labeled as well)
+// Sent messages are recorded for /healthz
+func NewBotAPI(token string) (*tgbotapi.BotAPI, error) {
+	var httpClient tgbotapi.HTTPClient = &http.Client{}
+	if Enabled() {
+		httpClient = &client{next: httpClient}
+	}
+	return tgbotapi.NewBotAPIWithClient(token, tgbotapi.APIEndpoint, network.WrapBotClient(health.WrapBotClient(httpClient)))
+}
+
+// client - HTTP client of bot in dry-run mode
+type client struct {
+	next tgbotapi.HTTPClient
+}
+
+func (c *client) Do(req *http.Request) (*http.Response, error) {
+	method := path.Base(req.URL.Path)
+	if !isWriteMethod(method) {
+		return c.next.Do(req)
+	}
+
+	entry, err := captureRequest(req, method)
+	if err != nil {
+		return nil, fmt.Errorf("dry-run: failed to read %s request: %w", method, err)
+	}
+	if err := writeEntry(entry); err != nil {
+		return nil, fmt.Errorf("dry-run: failed to write message: %w", err)
+	}
+	return fakeResponse(req, entry), nil
+}
+
+// isWriteMethod - Bot API methods that change chat state (not sent in dry-run mode)
+func isWriteMethod(method string) bool {
+	for _, prefix := range []string{"send", "edit", "delete", "pin", "unpin", "forward", "copy", "answer", "setMy", "deleteMy", "setChat", "ban", "unban", "restrict", "promote"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func captureRequest(req *http.Request, method string) (*Entry, error) {
+	entry := &Entry{
+		Time:   time.Now().UTC().Format(time.RFC3339),
+		BotID:  botID(req.URL.Path),
+		Method: method,
+		Params: make(map[string]string),
+	}
+	if req.Body == nil {
+		return entry, nil
+	}
+	defer req.Body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		// Files (charts) are uploaded as multipart, only file names are kept
+		if err := req.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+		for key, values := range req.MultipartForm.Value {
+			if len(values) > 0 {
+				entry.Params[key] = values[0]
+			}
+		}
+		for key, files := range req.MultipartForm.File {
+			for _, file := range files {
+				entry.Files = append(entry.Files, key+":"+file.Filename)
+			}
+		}
+	} else {
+		raw, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		values, err := url.ParseQuery(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		for key := range values {
+			entry.Params[key] = values.Get(key)
+		}
+	}
+
+	entry.ChatID = entry.Params["chat_id"]
+	entry.Text = entry.Params["text"]
+	if entry.Text == "" {
+		entry.Text = entry.Params["caption"]
+	}
+	delete(entry.Params, "chat_id")
+	delete(entry.Params, "text")
+	delete(entry.Params, "caption")
+	for key, value := range entry.Params {
+		if value == "" || value == "null" {
+			delete(entry.Params, key)
+		}
+	}
+	if len(entry.Params) == 0 {
+		entry.Params = nil
+	}
+	return entry, nil
+}
+
+// botID - numeric part of bot token from /bot{id}:{secret}/{method}, secret is not logged
+func botID(urlPath string) string {
+	segment := strings.TrimPrefix(path.Base(path.Dir(urlPath)), "bot")
+	if id, _, found := strings.Cut(segment, ":"); found {
+		return id
+	}
+	return ""
+}
+
+func writeEntry(entry *Entry) error {
+	fileMutex.Lock()
+	defer fileMutex.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(messagesFile), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Messages are HTML, keep tags readable
+	var raw bytes.Buffer
+	encoder := json.NewEncoder(&raw)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(entry); err != nil {
+		return fmt.Errorf("failed to marshal dry-run entry: %w", err)
+	}
+
+	file, err := os.OpenFile(messagesFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open dry-run messages file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(raw.Bytes())
+	return err
+}
+
+// fakeResponse - successful Bot API response: sent message for send/edit methods, true for others
+func fakeResponse(req *http.Request, entry *Entry) *http.Response {
+	var result interface{} = true
+	if strings.HasPrefix(entry.Method, "send") || strings.HasPrefix(entry.Method, "edit") ||
+		strings.HasPrefix(entry.Method, "forward") || strings.HasPrefix(entry.Method, "copy") {
+		chatID, _ := strconv.ParseInt(entry.ChatID, 10, 64)
+		message := map[string]interface{}{
+			"message_id": messageID.Add(1),
+			"date":       time.Now().Unix(),
+			"chat":       map[string]interface{}{"id": chatID, "type": "group"},
+			"text":       entry.Text,
+		}
+		result = message
+		if entry.Method == "sendMediaGroup" {
+			result = []interface{}{message}
+		}
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"ok": true, "result": result})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}
+}
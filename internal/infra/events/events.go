@@ -0,0 +1,345 @@
+package events
+
+// Append-only event log: every processed swap, holder change and sent alert as JSON line
+// Events of one UTC day are written to data_out/events/YYYY-MM-DD.jsonl, file of a day is rolled over
+// to YYYY-MM-DD.1.jsonl, YYYY-MM-DD.2.jsonl, ... when it reaches MaxFileSize
+// Old days are gzipped and removed after retention (see Compress and Prune), ReadDay reads both
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// MaxFileSize - size of event file after which events of the day go to next part
+	MaxFileSize = 64 * 1024 * 1024
+
+	dateLayout = "2006-01-02"
+)
+
+// Dir returns folder of daily event files
+func Dir() string {
+	return paths.Output("events")
+}
+
+// Event types
+const (
+	TypeSwap         = "swap"          // swap processed by Big Sales monitor or collector (data: flashnet.Swap)
+	TypeHolderChange = "holder_change" // balance change of saved holder (data: HolderChange)
+	TypeAlert        = "alert"         // alert sent to chat (data: Alert)
+	TypeDelivery     = "delivery"      // outcome of swap alert for chat, sent or not (data: Delivery)
+)
+
+// Event - line of event file
+type Event struct {
+	Time time.Time       `json:"time"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// HolderChange - balance change of holder of tracked token
+type HolderChange struct {
+	Ticker  string  `json:"ticker"`
+	Address string  `json:"address"`
+	Action  string  `json:"action"` // invested, sold or liquidated
+	Amount  float64 `json:"amount"` // count tokens after change
+	Delta   float64 `json:"delta"`
+	Value   float64 `json:"value,omitempty"`   // BTC amount of swap (0 for balance check)
+	Source  string  `json:"source"`            // swap or check
+	CatchUp bool    `json:"catchUp,omitempty"` // found by catch-up check
+	Since   string  `json:"since,omitempty"`   // date of previous successful check (catch-up only)
+}
+
+// Holder change sources
+const (
+	HolderSourceSwap  = "swap"
+	HolderSourceCheck = "check"
+)
+
+// Alert - alert sent to chat
+type Alert struct {
+	Kind            string `json:"kind"` // big_sales, filtered, destination, rule, watchlist, price_alert, hot_token, liquidity, listing, suspicious, signal, reserve_drain
+	ChatID          int64  `json:"chatId"`
+	Label           string `json:"label,omitempty"` // destination or rule name
+	PoolLpPublicKey string `json:"poolLpPublicKey,omitempty"`
+	Ticker          string `json:"ticker,omitempty"`
+	SwapID          string `json:"swapId,omitempty"` // swap of alert (swap event with the same ID)
+	Text            string `json:"text,omitempty"`   // plain text of message (swap alerts: empty)
+}
+
+// Delivery - outcome of swap alert queued for chat (audit trail of /audit)
+type Delivery struct {
+	SwapID          string `json:"swapId"`
+	PoolLpPublicKey string `json:"poolLpPublicKey,omitempty"`
+	ChatID          int64  `json:"chatId"`
+	Route           string `json:"route"`               // big sales, filtered, rule {name}, destination {name}, watchlist
+	Status          string `json:"status"`              // sent, failed, held or unsent
+	MessageID       int    `json:"messageId,omitempty"` // Telegram message of sent alert
+	Layout          string `json:"layout,omitempty"`    // default or token (template of token)
+	Format          string `json:"format,omitempty"`    // message, fast_path, photo or combined
+	Error           string `json:"error,omitempty"`     // send error of failed alert
+}
+
+// Delivery statuses
+const (
+	DeliverySent   = "sent"
+	DeliveryFailed = "failed"
+	DeliveryHeld   = "held"   // muted token or quiet hours of chat, goes to summary
+	DeliveryUnsent = "unsent" // cut by shutdown, sent again after restart
+)
+
+// Delivery formats
+const (
+	DeliveryFormatMessage  = "message"
+	DeliveryFormatFastPath = "fast_path" // minimal alert edited with full details
+	DeliveryFormatPhoto    = "photo"     // photo of token template with caption
+	DeliveryFormatCombined = "combined"  // one message of several small swaps
+)
+
+var eventsMutex sync.Mutex
+
+// RecordSwaps writes swap events
+func RecordSwaps(swaps []flashnet.Swap) error {
+	payloads := make([]any, 0, len(swaps))
+	for _, swap := range swaps {
+		payloads = append(payloads, swap)
+	}
+	return appendEvents(TypeSwap, payloads)
+}
+
+// RecordHolderChanges writes holder change events
+func RecordHolderChanges(changes []HolderChange) error {
+	payloads := make([]any, 0, len(changes))
+	for _, change := range changes {
+		payloads = append(payloads, change)
+	}
+	return appendEvents(TypeHolderChange, payloads)
+}
+
+// RecordAlert writes alert event
+func RecordAlert(alert Alert) error {
+	return appendEvents(TypeAlert, []any{alert})
+}
+
+// RecordDeliveries writes delivery events
+func RecordDeliveries(deliveries []Delivery) error {
+	payloads := make([]any, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		payloads = append(payloads, delivery)
+	}
+	return appendEvents(TypeDelivery, payloads)
+}
+
+func appendEvents(eventType string, payloads []any) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	var buffer []byte
+	for _, payload := range payloads {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+		}
+		line, err := json.Marshal(Event{Time: now, Type: eventType, Data: data})
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+		}
+		buffer = append(buffer, line...)
+		buffer = append(buffer, '\n')
+	}
+
+	eventsMutex.Lock()
+	defer eventsMutex.Unlock()
+
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return fmt.Errorf("failed to create events directory: %w", err)
+	}
+
+	file, err := os.OpenFile(currentFileUnlocked(now.Format(dateLayout)), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open events file: %w", err)
+	}
+	if _, err := file.Write(buffer); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write events file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close events file: %w", err)
+	}
+	return nil
+}
+
+// File returns path of part of day's event file (part 0 - YYYY-MM-DD.jsonl)
+func File(date string, part int) string {
+	if part == 0 {
+		return filepath.Join(Dir(), date+".jsonl")
+	}
+	return filepath.Join(Dir(), fmt.Sprintf("%s.%d.jsonl", date, part))
+}
+
+// currentFileUnlocked returns first part of day below MaxFileSize
+func currentFileUnlocked(date string) string {
+	for part := 0; ; part++ {
+		path := File(date, part)
+		info, err := os.Stat(path)
+		if err != nil || info.Size() < MaxFileSize {
+			return path
+		}
+	}
+}
+
+// dayParts returns existing parts of day (plain or gzipped) in write order
+func dayParts(date string) ([]string, error) {
+	entries, err := os.ReadDir(Dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read events directory: %w", err)
+	}
+
+	parts := make(map[int]string)
+	for _, entry := range entries {
+		fileDate, part, ok := parseFileName(entry.Name())
+		if !ok || fileDate != date {
+			continue
+		}
+		// Plain and gzipped file of one part: plain is read, OpenArchiveFile falls back to .gz
+		parts[part] = File(date, part)
+	}
+
+	indexes := make([]int, 0, len(parts))
+	for part := range parts {
+		indexes = append(indexes, part)
+	}
+	sort.Ints(indexes)
+
+	paths := make([]string, 0, len(indexes))
+	for _, part := range indexes {
+		paths = append(paths, parts[part])
+	}
+	return paths, nil
+}
+
+// parseFileName parses YYYY-MM-DD[.N].jsonl[.gz]
+func parseFileName(name string) (string, int, bool) {
+	name = strings.TrimSuffix(name, storage.CompressedExt)
+	if !strings.HasSuffix(name, ".jsonl") {
+		return "", 0, false
+	}
+	name = strings.TrimSuffix(name, ".jsonl")
+
+	date, partStr, hasPart := strings.Cut(name, ".")
+	if _, err := time.Parse(dateLayout, date); err != nil {
+		return "", 0, false
+	}
+	if !hasPart {
+		return date, 0, true
+	}
+	part, err := strconv.Atoi(partStr)
+	if err != nil || part <= 0 {
+		return "", 0, false
+	}
+	return date, part, true
+}
+
+// ReadDay calls fn for each event of date (YYYY-MM-DD) in write order
+// Day without events is not an error. Lines cut by crash are skipped
+func ReadDay(date string, fn func(Event) error) error {
+	eventsMutex.Lock()
+	paths, err := dayParts(date)
+	eventsMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if err := readFile(path, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readFile(path string, fn func(Event) error) error {
+	reader, err := storage.OpenArchiveFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open events file: %w", err)
+	}
+	defer reader.Close()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			continue
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read events file: %w", err)
+	}
+	return nil
+}
+
+// Compress gzips event files not written for olderThan
+func Compress(olderThan time.Duration) (int, error) {
+	return storage.CompressOldFiles(Dir(), olderThan)
+}
+
+// Prune removes event files of days older than retentionDays
+// Returns count of removed files
+func Prune(retentionDays int, now time.Time) (int, error) {
+	if retentionDays <= 0 {
+		return 0, nil
+	}
+
+	eventsMutex.Lock()
+	defer eventsMutex.Unlock()
+
+	entries, err := os.ReadDir(Dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read events directory: %w", err)
+	}
+
+	cutoff := now.UTC().AddDate(0, 0, -retentionDays).Format(dateLayout)
+	removed := 0
+	for _, entry := range entries {
+		date, _, ok := parseFileName(entry.Name())
+		if !ok || date >= cutoff {
+			continue
+		}
+		if err := os.Remove(filepath.Join(Dir(), entry.Name())); err != nil {
+			return removed, fmt.Errorf("failed to remove events file: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,260 @@
+package fs
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// CompressedExt is the suffix of compressed archive files.
+	CompressedExt = ".gz"
+)
+
+// ArchiveDir is the root of daily archives (swaps, snapshots, backups).
+func ArchiveDir() string {
+	return paths.Output("archive")
+}
+
+// OpenArchiveFile opens file for reading, compressed or not.
+// If path doesn't exist, path + ".gz" is tried; gzip content is decompressed transparently.
+// If both exist (plain file written after the day was compressed), compressed content is read first.
+func OpenArchiveFile(path string) (io.ReadCloser, error) {
+	if !strings.HasSuffix(path, CompressedExt) {
+		if _, err := os.Stat(path + CompressedExt); err == nil {
+			if _, err := os.Stat(path); err == nil {
+				return openArchivePair(path)
+			}
+		}
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) && !strings.HasSuffix(path, CompressedExt) {
+		file, err = os.Open(path + CompressedExt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return openArchiveReader(file)
+}
+
+// openArchiveReader wraps file with gzip reader if content is gzip (detected by magic bytes, not by name).
+func openArchiveReader(file *os.File) (io.ReadCloser, error) {
+	header := make([]byte, 2)
+	n, _ := io.ReadFull(file, header)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to rewind file: %w", err)
+	}
+	if n < 2 || header[0] != 0x1f || header[1] != 0x8b {
+		return file, nil
+	}
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open gzip reader: %w", err)
+	}
+	return &gzipFile{Reader: gzipReader, file: file}, nil
+}
+
+// openArchivePair reads path + ".gz" and then path as one stream.
+func openArchivePair(path string) (io.ReadCloser, error) {
+	var readers []io.ReadCloser
+	for _, part := range []string{path + CompressedExt, path} {
+		file, err := os.Open(part)
+		if err != nil {
+			closeAll(readers)
+			return nil, err
+		}
+		reader, err := openArchiveReader(file)
+		if err != nil {
+			closeAll(readers)
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+	return &multiFile{Reader: io.MultiReader(readers[0], readers[1]), parts: readers}, nil
+}
+
+// ReadArchiveFile reads whole file, compressed or not (see OpenArchiveFile).
+func ReadArchiveFile(path string) ([]byte, error) {
+	reader, err := OpenArchiveFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// CompressOldFiles gzips files under dir last modified more than olderThan ago.
+// Compressed file keeps modification time of original, original is removed.
+// Returns count of compressed files.
+func CompressOldFiles(dir string, olderThan time.Duration) (int, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return 0, nil
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	compressed := 0
+
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasSuffix(path, CompressedExt) || strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(cutoff) {
+			return nil
+		}
+
+		if err := compressArchiveFile(path, info); err != nil {
+			logging.LogWarn("Failed to compress archive file", zap.String("file", path), zap.Error(err))
+			return nil
+		}
+		compressed++
+		return nil
+	})
+	if err != nil {
+		return compressed, fmt.Errorf("failed to walk archive directory: %w", err)
+	}
+
+	if compressed > 0 {
+		logging.LogInfo("Compressed old archive files",
+			zap.String("dir", dir),
+			zap.Int("count", compressed))
+	}
+	return compressed, nil
+}
+
+// archiveFileMutex returns mutex of writers of archive file (nil if file has no concurrent writers).
+func archiveFileMutex(path string) *sync.Mutex {
+	if filepath.Dir(path) == SwapArchiveDir() {
+		return &swapArchiveMutex
+	}
+	return nil
+}
+
+// compressArchiveFile compresses file under lock of its writers, so lines appended meanwhile are not lost.
+func compressArchiveFile(path string, info os.FileInfo) error {
+	if mutex := archiveFileMutex(path); mutex != nil {
+		mutex.Lock()
+		defer mutex.Unlock()
+	}
+	return compressFile(path, info)
+}
+
+// compressFile gzips path into path + ".gz" and removes path.
+// Existing compressed file is kept: content of path is added to it as another gzip member.
+func compressFile(path string, info os.FileInfo) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	target := path + CompressedExt
+	modTime := info.ModTime()
+	if existing, err := os.Stat(target); err == nil && existing.ModTime().After(modTime) {
+		modTime = existing.ModTime()
+	}
+
+	err = writeCompressedMember(target, filepath.Base(path), info.ModTime(), func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.Chtimes(target, modTime, modTime); err != nil {
+		logging.LogDebug("Failed to keep modification time of compressed file", zap.String("file", target), zap.Error(err))
+	}
+	return os.Remove(path)
+}
+
+// writeCompressedMember writes content of write as gzip member after existing content of target (if any).
+// Target is replaced atomically, readers see either old or new file.
+// Go gzip reader reads concatenated members as one stream.
+func writeCompressedMember(target string, name string, modTime time.Time, write func(io.Writer) error) error {
+	tempFilePath := target + ".tmp"
+	dst, err := os.Create(tempFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	var copyErr error
+	if existing, err := os.Open(target); err == nil {
+		_, copyErr = io.Copy(dst, existing)
+		existing.Close()
+	} else if !os.IsNotExist(err) {
+		copyErr = fmt.Errorf("failed to open compressed file: %w", err)
+	}
+
+	var writeErr, closeErr error
+	if copyErr == nil {
+		gzipWriter := gzip.NewWriter(dst)
+		gzipWriter.Name = name
+		gzipWriter.ModTime = modTime
+		writeErr = write(gzipWriter)
+		closeErr = gzipWriter.Close()
+	}
+	fileErr := dst.Close()
+	if copyErr != nil || writeErr != nil || closeErr != nil || fileErr != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to write compressed file: %w", errors.Join(copyErr, writeErr, closeErr, fileErr))
+	}
+
+	if err := os.Rename(tempFilePath, target); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to compressed file: %w", err)
+	}
+	return nil
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	gzipErr := g.Reader.Close()
+	if err := g.file.Close(); err != nil {
+		return err
+	}
+	return gzipErr
+}
+
+// multiFile reads several archive parts as one stream.
+type multiFile struct {
+	io.Reader
+	parts []io.ReadCloser
+}
+
+func (m *multiFile) Close() error {
+	return closeAll(m.parts)
+}
+
+func closeAll(closers []io.ReadCloser) error {
+	var errs []error
+	for _, closer := range closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,38 @@
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"spark-wallet/internal/infra/atomicfile"
+)
+
+// fileLocks holds one mutex per file path (see LockFile).
+var fileLocks sync.Map // cleaned path -> *sync.Mutex
+
+// LockFile serializes read-modify-write of file between goroutines, returns unlock function.
+// Files locked together must always be locked in the same order.
+func LockFile(path string) func() {
+	mutex, _ := fileLocks.LoadOrStore(filepath.Clean(path), &sync.Mutex{})
+	mutex.(*sync.Mutex).Lock()
+	return mutex.(*sync.Mutex).Unlock
+}
+
+// WriteFileAtomic writes file via temporary file in the same directory and rename,
+// so readers see either old or new content, never a partial write.
+// Directory of file is created if missing.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return atomicfile.Write(path, data, perm)
+}
+
+// WriteJSONAtomic writes value as indented JSON with WriteFileAtomic.
+func WriteJSONAtomic(path string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return WriteFileAtomic(path, data, 0644)
+}
@@ -4,24 +4,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
 
 	"go.uber.org/zap"
 )
 
-const (
-	BlacklistedTokensFile = "data_out/blacklisted_tokens.json"
-)
+// BlacklistedTokensFile returns path of data_out/blacklisted_tokens.json
+func BlacklistedTokensFile() string {
+	return paths.Output("blacklisted_tokens.json")
+}
 
 type BlacklistedTokensData struct {
 	Tokens []string `json:"tokens"`
 }
 
 func LoadBlacklistedTokens() ([]string, error) {
-	filePath := BlacklistedTokensFile
+	filePath := BlacklistedTokensFile()
 
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		logging.LogDebug("Blacklisted tokens file does not exist, returning empty list", zap.String("file", filePath))
@@ -51,12 +52,7 @@ func LoadBlacklistedTokens() ([]string, error) {
 }
 
 func SaveBlacklistedTokens(tokens []string) error {
-	filePath := BlacklistedTokensFile
-
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
+	filePath := BlacklistedTokensFile()
 
 	tokensData := BlacklistedTokensData{
 		Tokens: tokens,
@@ -67,14 +63,8 @@ func SaveBlacklistedTokens(tokens []string) error {
 		return fmt.Errorf("failed to marshal blacklisted tokens JSON: %w", err)
 	}
 
-	tempFilePath := filePath + ".tmp"
-	if err := os.WriteFile(tempFilePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temporary blacklisted tokens file: %w", err)
-	}
-
-	if err := os.Rename(tempFilePath, filePath); err != nil {
-		os.Remove(tempFilePath)
-		return fmt.Errorf("failed to rename temporary file to blacklisted tokens file: %w", err)
+	if err := WriteFileAtomic(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write blacklisted tokens file: %w", err)
 	}
 
 	logging.LogInfo("Saved blacklisted tokens to file",
@@ -89,6 +79,9 @@ func AddBlacklistedToken(poolLpPublicKey string) error {
 		return fmt.Errorf("poolLpPublicKey cannot be empty")
 	}
 
+	unlock := LockFile(BlacklistedTokensFile())
+	defer unlock()
+
 	tokens, err := LoadBlacklistedTokens()
 	if err != nil {
 		return fmt.Errorf("failed to load blacklisted tokens: %w", err)
@@ -135,6 +128,9 @@ func RemoveBlacklistedToken(poolLpPublicKey string) error {
 		return fmt.Errorf("poolLpPublicKey cannot be empty")
 	}
 
+	unlock := LockFile(BlacklistedTokensFile())
+	defer unlock()
+
 	tokens, err := LoadBlacklistedTokens()
 	if err != nil {
 		return fmt.Errorf("failed to load blacklisted tokens: %w", err)
@@ -4,15 +4,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
-)
 
-const (
-	// BTCSparkDataFile is the path used by the bot to store BTC Spark snapshots.
-	BTCSparkDataFile = "data_out/telegram_out/btc_spark.json"
+	"spark-wallet/internal/infra/paths"
 )
 
+// BTCSparkDataFile is the path used by the bot to store BTC Spark snapshots.
+func BTCSparkDataFile() string {
+	return paths.Output("telegram_out", "btc_spark.json")
+}
+
 // BTCSparkDataEntry is one snapshot record.
 type BTCSparkDataEntry struct {
 	Timestamp  string  `json:"timestamp"`   // RFC3339
@@ -29,7 +30,7 @@ type BTCSparkData struct {
 // LoadBTCSparkData loads BTC spark data from file.
 // Returns empty dataset if file doesn't exist (not an error).
 func LoadBTCSparkData() (*BTCSparkData, error) {
-	filePath := BTCSparkDataFile
+	filePath := BTCSparkDataFile()
 
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return &BTCSparkData{Entries: []BTCSparkDataEntry{}}, nil
@@ -58,12 +59,10 @@ func LoadBTCSparkData() (*BTCSparkData, error) {
 
 // SaveBTCSparkData appends one snapshot into btc_spark.json.
 func SaveBTCSparkData(btcReserve float64, check bool) error {
-	filePath := BTCSparkDataFile
+	filePath := BTCSparkDataFile()
 
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
+	unlock := LockFile(filePath)
+	defer unlock()
 
 	now := time.Now()
 	timestamp := now.Format(time.RFC3339)
@@ -86,14 +85,8 @@ func SaveBTCSparkData(btcReserve float64, check bool) error {
 		return fmt.Errorf("failed to marshal BTC spark data JSON: %w", err)
 	}
 
-	tempFilePath := filePath + ".tmp"
-	if err := os.WriteFile(tempFilePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temporary BTC spark data file: %w", err)
-	}
-
-	if err := os.Rename(tempFilePath, filePath); err != nil {
-		_ = os.Remove(tempFilePath)
-		return fmt.Errorf("failed to rename temporary file to BTC spark data file: %w", err)
+	if err := WriteFileAtomic(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write BTC spark data file: %w", err)
 	}
 
 	return nil
@@ -7,15 +7,19 @@ import (
 	"path/filepath"
 
 	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/infra/paths"
 )
 
-const jsonsDir = "data_out"
+// jsonsDir - folder of saved API responses
+func jsonsDir() string {
+	return paths.OutputDir()
+}
 
 func ensureJsonsDir() error {
-	if err := os.MkdirAll(filepath.Join(jsonsDir, "big_sales_module"), 0755); err != nil {
+	if err := os.MkdirAll(filepath.Join(jsonsDir(), "big_sales_module"), 0755); err != nil {
 		return err
 	}
-	return os.MkdirAll(jsonsDir, 0755)
+	return os.MkdirAll(jsonsDir(), 0755)
 }
 
 func SaveSwapsResponse(filename string, data *flashnet.SwapsResponse) error {
@@ -28,8 +32,8 @@ func SaveSwapsResponse(filename string, data *flashnet.SwapsResponse) error {
 		return fmt.Errorf("failed to marshal swaps response: %w", err)
 	}
 
-	fullPath := filepath.Join(jsonsDir, filename)
-	if err := os.WriteFile(fullPath, jsonData, 0644); err != nil {
+	fullPath := filepath.Join(jsonsDir(), filename)
+	if err := WriteFileAtomic(fullPath, jsonData, 0644); err != nil {
 		return fmt.Errorf("failed to save swaps response: %w", err)
 	}
 	return nil
@@ -45,18 +49,19 @@ func SaveUserSwapsResponse(filename string, data interface{}) error {
 		return fmt.Errorf("failed to marshal user swaps response: %w", err)
 	}
 
-	fullPath := filepath.Join(jsonsDir, filename)
-	if err := os.WriteFile(fullPath, jsonData, 0644); err != nil {
+	fullPath := filepath.Join(jsonsDir(), filename)
+	if err := WriteFileAtomic(fullPath, jsonData, 0644); err != nil {
 		return fmt.Errorf("failed to save user swaps response: %w", err)
 	}
 	return nil
 }
 
 // LoadSwapsResponse loads swaps response from JSON file under data_out.
+// Compressed (.gz) files are read transparently.
 func LoadSwapsResponse(filename string) (*flashnet.SwapsResponse, error) {
-	fullPath := filepath.Join(jsonsDir, filename)
+	fullPath := filepath.Join(jsonsDir(), filename)
 
-	data, err := os.ReadFile(fullPath)
+	data, err := ReadArchiveFile(fullPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read swaps response file: %w", err)
 	}
@@ -70,9 +75,9 @@ func LoadSwapsResponse(filename string) (*flashnet.SwapsResponse, error) {
 }
 
 func LoadUserSwapsResponse(filename string) (*flashnet.UserSwapsResponse, error) {
-	fullPath := filepath.Join(jsonsDir, filename)
+	fullPath := filepath.Join(jsonsDir(), filename)
 
-	data, err := os.ReadFile(fullPath)
+	data, err := ReadArchiveFile(fullPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read user swaps response file: %w", err)
 	}
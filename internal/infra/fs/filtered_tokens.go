@@ -6,31 +6,52 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
 
 	"go.uber.org/zap"
 )
 
-const (
-	// FilteredTokensFile -
-	FilteredTokensFile = "data_out/filtered_tokens.json"
-)
+// FilteredTokensFile -
+func FilteredTokensFile() string {
+	return paths.Output("filtered_tokens.json")
+}
 
 // FilteredTokensData - for tokens
 type FilteredTokensData struct {
 	Tokens []string `json:"tokens"` // poolLpPublicKey tokens for
+	// Assets - token asset address (tokenIdentifier) -> poolLpPublicKey it was added with
+	// Swaps of token are filtered in any of its pools, not only in the added one
+	Assets map[string]string `json:"assets,omitempty"`
 }
 
 // LoadFilteredTokens tokens from file
 func LoadFilteredTokens() ([]string, error) {
-	filePath := FilteredTokensFile
+	tokensData, err := loadFilteredTokensData()
+	if err != nil {
+		return nil, err
+	}
+	return tokensData.Tokens, nil
+}
+
+// LoadFilteredAssets returns token asset addresses of filtered tokens (asset address -> poolLpPublicKey)
+func LoadFilteredAssets() (map[string]string, error) {
+	tokensData, err := loadFilteredTokensData()
+	if err != nil {
+		return nil, err
+	}
+	return tokensData.Assets, nil
+}
+
+func loadFilteredTokensData() (*FilteredTokensData, error) {
+	filePath := FilteredTokensFile()
+	empty := &FilteredTokensData{Tokens: []string{}, Assets: make(map[string]string)}
 
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		logging.LogDebug("Filtered tokens file does not exist, returning empty list", zap.String("file", filePath))
-		return []string{}, nil
+		return empty, nil
 	}
 
 	data, err := os.ReadFile(filePath)
@@ -41,7 +62,7 @@ func LoadFilteredTokens() ([]string, error) {
 	// Check, file
 	if len(data) == 0 || strings.TrimSpace(string(data)) == "" || strings.TrimSpace(string(data)) == "{}" {
 		logging.LogDebug("Filtered tokens file is empty, returning empty list", zap.String("file", filePath))
-		return []string{}, nil
+		return empty, nil
 	}
 
 	// Parse JSON
@@ -49,47 +70,91 @@ func LoadFilteredTokens() ([]string, error) {
 	if err := json.Unmarshal(data, &tokensData); err != nil {
 		return nil, fmt.Errorf("failed to parse filtered tokens JSON: %w", err)
 	}
+	if tokensData.Tokens == nil {
+		tokensData.Tokens = []string{}
+	}
+	if tokensData.Assets == nil {
+		tokensData.Assets = make(map[string]string)
+	}
 
 	logging.LogDebug("Loaded filtered tokens from file",
 		zap.String("file", filePath),
-		zap.Int("count", len(tokensData.Tokens)))
+		zap.Int("count", len(tokensData.Tokens)),
+		zap.Int("assets", len(tokensData.Assets)))
 
-	return tokensData.Tokens, nil
+	return &tokensData, nil
 }
 
 // SaveFilteredTokens tokens in file
+// Asset addresses of tokens that stay in the list are kept
 func SaveFilteredTokens(tokens []string) error {
-	filePath := FilteredTokensFile
-
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	tokensData, err := loadFilteredTokensData()
+	if err != nil {
+		logging.LogWarn("Failed to load asset addresses of filtered tokens, saving pools only", zap.Error(err))
+		tokensData = &FilteredTokensData{Assets: make(map[string]string)}
 	}
+	tokensData.Tokens = tokens
 
-	tokensData := FilteredTokensData{
-		Tokens: tokens,
+	kept := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		kept[strings.TrimSpace(token)] = true
+	}
+	for asset, pool := range tokensData.Assets {
+		if !kept[pool] {
+			delete(tokensData.Assets, asset)
+		}
 	}
 
+	return saveFilteredTokensData(tokensData)
+}
+
+func saveFilteredTokensData(tokensData *FilteredTokensData) error {
+	filePath := FilteredTokensFile()
+
 	data, err := json.MarshalIndent(tokensData, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal filtered tokens JSON: %w", err)
 	}
 
-	// Use file,
-	tempFilePath := filePath + ".tmp"
-	if err := os.WriteFile(tempFilePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temporary filtered tokens file: %w", err)
-	}
-
-	// on Unix-
-	if err := os.Rename(tempFilePath, filePath); err != nil {
-		os.Remove(tempFilePath)
-		return fmt.Errorf("failed to rename temporary file to filtered tokens file: %w", err)
+	if err := WriteFileAtomic(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write filtered tokens file: %w", err)
 	}
 
 	logging.LogInfo("Saved filtered tokens to file",
 		zap.String("file", filePath),
-		zap.Int("count", len(tokens)))
+		zap.Int("count", len(tokensData.Tokens)),
+		zap.Int("assets", len(tokensData.Assets)))
+
+	return nil
+}
+
+// SetFilteredTokenAsset saves token asset address of filtered pool, so swaps of token in its other pools are filtered too
+func SetFilteredTokenAsset(poolLpPublicKey string, assetAddress string) error {
+	poolLpPublicKey = strings.TrimSpace(poolLpPublicKey)
+	assetAddress = strings.TrimSpace(assetAddress)
+	if poolLpPublicKey == "" || assetAddress == "" {
+		return fmt.Errorf("poolLpPublicKey and assetAddress cannot be empty")
+	}
+
+	unlock := LockFile(FilteredTokensFile())
+	defer unlock()
+
+	tokensData, err := loadFilteredTokensData()
+	if err != nil {
+		return fmt.Errorf("failed to load filtered tokens: %w", err)
+	}
+	if tokensData.Assets[assetAddress] == poolLpPublicKey {
+		return nil
+	}
+	tokensData.Assets[assetAddress] = poolLpPublicKey
+
+	if err := saveFilteredTokensData(tokensData); err != nil {
+		return fmt.Errorf("failed to save filtered tokens: %w", err)
+	}
+
+	logging.LogInfo("Added token asset to filtered list",
+		zap.String("poolLpPublicKey", poolLpPublicKey),
+		zap.String("assetAddress", assetAddress))
 
 	return nil
 }
@@ -100,6 +165,9 @@ func AddFilteredToken(poolLpPublicKey string) error {
 		return fmt.Errorf("poolLpPublicKey cannot be empty")
 	}
 
+	unlock := LockFile(FilteredTokensFile())
+	defer unlock()
+
 	// Load
 	tokens, err := LoadFilteredTokens()
 	if err != nil {
@@ -145,12 +213,15 @@ func AddFilteredToken(poolLpPublicKey string) error {
 	return nil
 }
 
-// RemoveFilteredToken token from tokens
+// RemoveFilteredToken token from tokens (with its asset address)
 func RemoveFilteredToken(poolLpPublicKey string) error {
 	if poolLpPublicKey == "" {
 		return fmt.Errorf("poolLpPublicKey cannot be empty")
 	}
 
+	unlock := LockFile(FilteredTokensFile())
+	defer unlock()
+
 	// Load
 	tokens, err := LoadFilteredTokens()
 	if err != nil {
@@ -229,7 +300,7 @@ func FindPoolLpPublicKeyByTicker(ticker string) (string, error) {
 	}
 
 	// Load saved_ticket.json
-	filePath := "data_out/saved_ticket.json"
+	filePath := paths.Output("saved_ticket.json")
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read saved_ticket.json: %w", err)
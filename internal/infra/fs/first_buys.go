@@ -0,0 +1,119 @@
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"go.uber.org/zap"
+)
+
+// FirstBuysFile is the cache of first buy dates per wallet and pool.
+func FirstBuysFile() string {
+	return paths.Output("first_buys.json")
+}
+
+// FirstBuysData is file structure for first_buys.json.
+// First buy of wallet never changes, so entries are kept without expiry.
+type FirstBuysData struct {
+	FirstBuys map[string]string `json:"first_buys"` // wallet:poolLpPublicKey -> date (MSK, "2006-01-02 15:04")
+}
+
+var (
+	firstBuysMutex  sync.Mutex
+	firstBuysCache  map[string]string // loaded from file on first use
+	firstBuysLoaded bool
+)
+
+// GetFirstBuyDate returns date (MSK) of first buy of wallet in pool.
+// Cached date is returned without API request; on miss it is fetched with GetFirstBuySwap and cached.
+// Empty result (no buy found yet) is not cached, next call queries API again.
+func GetFirstBuyDate(client *flashnet.Client, wallet string, poolLpPublicKey string) (string, error) {
+	if date, ok := CachedFirstBuyDate(wallet, poolLpPublicKey); ok {
+		return date, nil
+	}
+
+	date, err := flashnet.GetFirstBuySwap(client, wallet, poolLpPublicKey)
+	if err != nil || date == "" {
+		return date, err
+	}
+
+	if err := SaveFirstBuyDate(wallet, poolLpPublicKey, date); err != nil {
+		logging.LogWarn("Failed to cache first buy date",
+			zap.String("wallet", wallet),
+			zap.String("poolLpPublicKey", poolLpPublicKey),
+			zap.Error(err))
+	}
+	return date, nil
+}
+
+// CachedFirstBuyDate returns cached first buy date of wallet in pool (false if not cached).
+func CachedFirstBuyDate(wallet string, poolLpPublicKey string) (string, bool) {
+	firstBuysMutex.Lock()
+	defer firstBuysMutex.Unlock()
+
+	if err := loadFirstBuysUnlocked(); err != nil {
+		return "", false
+	}
+	date, ok := firstBuysCache[firstBuyKey(wallet, poolLpPublicKey)]
+	return date, ok
+}
+
+// SaveFirstBuyDate stores first buy date of wallet in pool.
+func SaveFirstBuyDate(wallet string, poolLpPublicKey string, date string) error {
+	if wallet == "" || poolLpPublicKey == "" || date == "" {
+		return nil
+	}
+
+	firstBuysMutex.Lock()
+	defer firstBuysMutex.Unlock()
+
+	if err := loadFirstBuysUnlocked(); err != nil {
+		return err
+	}
+	key := firstBuyKey(wallet, poolLpPublicKey)
+	if firstBuysCache[key] == date {
+		return nil
+	}
+	firstBuysCache[key] = date
+
+	if err := WriteJSONAtomic(FirstBuysFile(), FirstBuysData{FirstBuys: firstBuysCache}); err != nil {
+		return fmt.Errorf("failed to save first buys file: %w", err)
+	}
+	return nil
+}
+
+func firstBuyKey(wallet string, poolLpPublicKey string) string {
+	return wallet + ":" + poolLpPublicKey
+}
+
+// loadFirstBuysUnlocked reads file into memory once, later calls use memory.
+func loadFirstBuysUnlocked() error {
+	if firstBuysLoaded {
+		return nil
+	}
+
+	data, err := os.ReadFile(FirstBuysFile())
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read first buys file: %w", err)
+	}
+
+	var stored FirstBuysData
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return fmt.Errorf("failed to parse first buys JSON: %w", err)
+		}
+	}
+	if stored.FirstBuys == nil {
+		stored.FirstBuys = make(map[string]string)
+	}
+
+	firstBuysCache = stored.FirstBuys
+	firstBuysLoaded = true
+	return nil
+}
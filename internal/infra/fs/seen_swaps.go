@@ -0,0 +1,103 @@
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/infra/paths"
+)
+
+// SeenSwapsWindow - swap IDs are kept this long for deduplication of swap feed across restarts.
+const SeenSwapsWindow = 24 * time.Hour
+
+// SeenSwapsFile is the index of swap IDs seen by swap feed.
+func SeenSwapsFile() string {
+	return paths.Output("big_sales_module", "seen_swaps.json")
+}
+
+// SeenSwapsData is file structure for seen_swaps.json.
+type SeenSwapsData struct {
+	Swaps map[string]time.Time `json:"swaps"` // swap ID -> first seen
+}
+
+var (
+	seenSwapsMutex sync.Mutex
+	seenSwapsCache map[string]time.Time // loaded from file on first use
+	seenSwapsPath  string               // file of cache, reloaded when output directory changes
+)
+
+// UnseenSwaps returns swaps not seen within SeenSwapsWindow, order is kept.
+func UnseenSwaps(swaps []flashnet.Swap) ([]flashnet.Swap, error) {
+	seenSwapsMutex.Lock()
+	defer seenSwapsMutex.Unlock()
+
+	if err := loadSeenSwapsUnlocked(); err != nil {
+		return swaps, err
+	}
+	var unseen []flashnet.Swap
+	for _, swap := range swaps {
+		if _, seen := seenSwapsCache[swap.ID]; !seen {
+			unseen = append(unseen, swap)
+		}
+	}
+	return unseen, nil
+}
+
+// RecordSeenSwaps adds swaps to index and removes IDs seen before now - SeenSwapsWindow.
+func RecordSeenSwaps(swaps []flashnet.Swap, now time.Time) error {
+	seenSwapsMutex.Lock()
+	defer seenSwapsMutex.Unlock()
+
+	if err := loadSeenSwapsUnlocked(); err != nil {
+		// Broken index is replaced, deduplication falls back to last saved page until it fills again
+		seenSwapsCache = make(map[string]time.Time)
+		seenSwapsPath = SeenSwapsFile()
+	}
+	for _, swap := range swaps {
+		if _, seen := seenSwapsCache[swap.ID]; !seen && swap.ID != "" {
+			seenSwapsCache[swap.ID] = now
+		}
+	}
+	cutoff := now.Add(-SeenSwapsWindow)
+	for id, seenAt := range seenSwapsCache {
+		if seenAt.Before(cutoff) {
+			delete(seenSwapsCache, id)
+		}
+	}
+
+	if err := WriteJSONAtomic(SeenSwapsFile(), SeenSwapsData{Swaps: seenSwapsCache}); err != nil {
+		return fmt.Errorf("failed to save seen swaps file: %w", err)
+	}
+	return nil
+}
+
+// loadSeenSwapsUnlocked reads file into memory once per output directory, later calls use memory.
+func loadSeenSwapsUnlocked() error {
+	path := SeenSwapsFile()
+	if seenSwapsCache != nil && seenSwapsPath == path {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read seen swaps file: %w", err)
+	}
+
+	var stored SeenSwapsData
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return fmt.Errorf("failed to parse seen swaps JSON: %w", err)
+		}
+	}
+	if stored.Swaps == nil {
+		stored.Swaps = make(map[string]time.Time)
+	}
+
+	seenSwapsCache = stored.Swaps
+	seenSwapsPath = path
+	return nil
+}
@@ -0,0 +1,171 @@
+package fs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+)
+
+var swapArchiveMutex sync.Mutex
+
+// SwapArchiveDir holds one JSON lines file of swaps per day (UTC): YYYY-MM-DD.jsonl.
+func SwapArchiveDir() string {
+	return filepath.Join(ArchiveDir(), "swaps")
+}
+
+// SwapArchiveFile returns archive file path of date (YYYY-MM-DD).
+func SwapArchiveFile(date string) string {
+	return filepath.Join(SwapArchiveDir(), date+".jsonl")
+}
+
+// AppendDailySwaps appends swaps to archive file of their day (by swap timestamp, UTC).
+func AppendDailySwaps(swaps []flashnet.Swap) error {
+	if len(swaps) == 0 {
+		return nil
+	}
+
+	byDate := make(map[string][]flashnet.Swap)
+	var dates []string
+	for _, swap := range swaps {
+		// Swap of unknown time is archived in day it is received
+		at, ok := SwapTime(swap)
+		if !ok {
+			at = time.Now()
+		}
+		date := at.UTC().Format("2006-01-02")
+		if _, exists := byDate[date]; !exists {
+			dates = append(dates, date)
+		}
+		byDate[date] = append(byDate[date], swap)
+	}
+
+	swapArchiveMutex.Lock()
+	defer swapArchiveMutex.Unlock()
+
+	if err := os.MkdirAll(SwapArchiveDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create swap archive directory: %w", err)
+	}
+
+	for _, date := range dates {
+		path := SwapArchiveFile(date)
+		// Day already compressed (late swaps, gap fill): new lines go into the .gz, which stays the only file of the day
+		if compressedDay(path) {
+			if err := appendCompressedSwapLines(path, byDate[date]); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := appendSwapLines(path, byDate[date]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compressedDay reports whether day has only compressed archive file
+func compressedDay(path string) bool {
+	if _, err := os.Stat(path); err == nil {
+		return false
+	}
+	_, err := os.Stat(path + CompressedExt)
+	return err == nil
+}
+
+// LoadDailySwaps loads archived swaps of date (YYYY-MM-DD), duplicates are dropped.
+// Returns empty list if day has no archive (not an error). Compressed (.gz) file is read transparently.
+func LoadDailySwaps(date string) ([]flashnet.Swap, error) {
+	swapArchiveMutex.Lock()
+	defer swapArchiveMutex.Unlock()
+
+	reader, err := OpenArchiveFile(SwapArchiveFile(date))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open swap archive: %w", err)
+	}
+	defer reader.Close()
+
+	var swaps []flashnet.Swap
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var swap flashnet.Swap
+		if err := json.Unmarshal(line, &swap); err != nil {
+			// Line may be cut by crash during write - skip it
+			continue
+		}
+		if seen[swap.ID] {
+			continue
+		}
+		seen[swap.ID] = true
+		swaps = append(swaps, swap)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read swap archive: %w", err)
+	}
+	return swaps, nil
+}
+
+// SwapTime returns time of swap (Timestamp, then CreatedAt), false if both are unparsable
+func SwapTime(swap flashnet.Swap) (time.Time, bool) {
+	for _, value := range []string{swap.Timestamp, swap.CreatedAt} {
+		if value == "" {
+			continue
+		}
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func appendSwapLines(path string, swaps []flashnet.Swap) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open swap archive file: %w", err)
+	}
+
+	if err := writeSwapLines(file, swaps); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close swap archive file: %w", err)
+	}
+	return nil
+}
+
+func appendCompressedSwapLines(path string, swaps []flashnet.Swap) error {
+	return writeCompressedMember(path+CompressedExt, filepath.Base(path), time.Now(), func(w io.Writer) error {
+		return writeSwapLines(w, swaps)
+	})
+}
+
+func writeSwapLines(w io.Writer, swaps []flashnet.Swap) error {
+	writer := bufio.NewWriter(w)
+	for _, swap := range swaps {
+		line, err := json.Marshal(swap)
+		if err != nil {
+			return fmt.Errorf("failed to marshal swap: %w", err)
+		}
+		writer.Write(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write swap archive file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package fs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"go.uber.org/zap"
+)
+
+// UsernamesRetention - wallets not seen (or resolved) within this window are pruned from the table.
+const UsernamesRetention = 90 * 24 * time.Hour
+
+// errUsernamesCorrupt - usernames.json exists but can't be parsed.
+var errUsernamesCorrupt = errors.New("usernames file is corrupt")
+
+// UsernamesFile is the local table of wallet -> Luminex username.
+func UsernamesFile() string {
+	return paths.Output("usernames.json")
+}
+
+// UsernameEntry is one wallet row in the local username table.
+type UsernameEntry struct {
+	Username string `json:"username"`  // empty if wallet has no profile
+	LastSeen string `json:"last_seen"` // RFC3339, last swap seen for wallet
+	SyncedAt string `json:"synced_at"` // RFC3339, empty if never resolved
+}
+
+// UsernamesData is file structure for usernames.json.
+type UsernamesData struct {
+	Wallets map[string]UsernameEntry `json:"wallets"` // pubkey -> entry
+}
+
+var usernamesFileMutex sync.Mutex
+
+// LoadUsernames loads the local username table.
+// Returns empty table if file doesn't exist (not an error).
+func LoadUsernames() (*UsernamesData, error) {
+	usernamesFileMutex.Lock()
+	defer usernamesFileMutex.Unlock()
+	return loadUsernamesUnlocked()
+}
+
+// SaveUsernames writes the local username table.
+func SaveUsernames(table *UsernamesData) error {
+	usernamesFileMutex.Lock()
+	defer usernamesFileMutex.Unlock()
+	return saveUsernamesUnlocked(table)
+}
+
+// RecordSeenWallets marks wallets as seen now, adding new rows when needed.
+func RecordSeenWallets(pubkeys []string) error {
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	usernamesFileMutex.Lock()
+	defer usernamesFileMutex.Unlock()
+
+	table, err := loadUsernamesForUpdateUnlocked()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	for _, pubkey := range pubkeys {
+		if pubkey == "" {
+			continue
+		}
+		entry := table.Wallets[pubkey]
+		entry.LastSeen = now
+		table.Wallets[pubkey] = entry
+	}
+
+	return saveUsernamesUnlocked(table)
+}
+
+// UpdateUsernames stores resolved usernames for wallets.
+// Wallets missing from resolved are stored with empty username.
+func UpdateUsernames(pubkeys []string, resolved map[string]string) error {
+	usernamesFileMutex.Lock()
+	defer usernamesFileMutex.Unlock()
+
+	table, err := loadUsernamesForUpdateUnlocked()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	for _, pubkey := range pubkeys {
+		entry := table.Wallets[pubkey]
+		entry.Username = resolved[pubkey]
+		entry.SyncedAt = now
+		table.Wallets[pubkey] = entry
+	}
+
+	return saveUsernamesUnlocked(table)
+}
+
+// PruneUsernames removes wallets whose last swap and last sync are older than maxAge.
+// Returns count of removed wallets.
+func PruneUsernames(now time.Time, maxAge time.Duration) (int, error) {
+	usernamesFileMutex.Lock()
+	defer usernamesFileMutex.Unlock()
+
+	table, err := loadUsernamesUnlocked()
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := now.Add(-maxAge)
+	removed := 0
+	for pubkey, entry := range table.Wallets {
+		if usernameEntryTime(entry.LastSeen).Before(cutoff) && usernameEntryTime(entry.SyncedAt).Before(cutoff) {
+			delete(table.Wallets, pubkey)
+			removed++
+		}
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, saveUsernamesUnlocked(table)
+}
+
+// usernameEntryTime parses RFC3339 time of entry, zero time if empty or invalid.
+func usernameEntryTime(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// loadUsernamesForUpdateUnlocked loads table before a write.
+// A corrupt file is moved aside (usernames.json.corrupt-{unix}) and the table starts empty,
+// read errors are returned so the next save doesn't overwrite resolved usernames.
+func loadUsernamesForUpdateUnlocked() (*UsernamesData, error) {
+	table, err := loadUsernamesUnlocked()
+	if !errors.Is(err, errUsernamesCorrupt) {
+		return table, err
+	}
+
+	corruptPath := fmt.Sprintf("%s.corrupt-%d", UsernamesFile(), time.Now().Unix())
+	if renameErr := os.Rename(UsernamesFile(), corruptPath); renameErr != nil {
+		return nil, fmt.Errorf("failed to move corrupt usernames file aside: %w", renameErr)
+	}
+	logging.LogWarn("Usernames file is corrupt, moved aside and started a new table",
+		zap.String("file", corruptPath),
+		zap.Error(err))
+	return &UsernamesData{Wallets: make(map[string]UsernameEntry)}, nil
+}
+
+func loadUsernamesUnlocked() (*UsernamesData, error) {
+	filePath := UsernamesFile()
+
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return &UsernamesData{Wallets: make(map[string]UsernameEntry)}, nil
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usernames file: %w", err)
+	}
+
+	if len(data) == 0 {
+		return &UsernamesData{Wallets: make(map[string]UsernameEntry)}, nil
+	}
+
+	var table UsernamesData
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("%w: %w", errUsernamesCorrupt, err)
+	}
+
+	if table.Wallets == nil {
+		table.Wallets = make(map[string]UsernameEntry)
+	}
+
+	return &table, nil
+}
+
+func saveUsernamesUnlocked(table *UsernamesData) error {
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal usernames JSON: %w", err)
+	}
+
+	if err := WriteFileAtomic(UsernamesFile(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write usernames file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/infra/paths"
+)
+
+// WatchlistFile is the list of wallets watched via /watch.
+func WatchlistFile() string {
+	return paths.Output("telegram_out", "watchlist.json")
+}
+
+// WatchedWallet is one wallet watched in one chat.
+type WatchedWallet struct {
+	PublicKey    string `json:"public_key"`
+	SparkAddress string `json:"spark_address,omitempty"`
+	BotID        int64  `json:"bot_id"` // bot that received /watch (sends notifications)
+	ChatID       int64  `json:"chat_id"`
+	AddedBy      string `json:"added_by"`
+	AddedAt      string `json:"added_at"` // RFC3339
+}
+
+// WatchlistData is file structure for watchlist.json.
+type WatchlistData struct {
+	Wallets []WatchedWallet `json:"wallets"`
+}
+
+var watchlistFileMutex sync.Mutex
+
+// LoadWatchlist loads watched wallets.
+// Returns empty list if file doesn't exist (not an error).
+func LoadWatchlist() (*WatchlistData, error) {
+	watchlistFileMutex.Lock()
+	defer watchlistFileMutex.Unlock()
+	return loadWatchlistUnlocked()
+}
+
+// AddWatchedWallet adds wallet to watchlist of chat.
+// Returns false if wallet is already watched in this chat.
+func AddWatchedWallet(wallet WatchedWallet) (bool, error) {
+	watchlistFileMutex.Lock()
+	defer watchlistFileMutex.Unlock()
+
+	data, err := loadWatchlistUnlocked()
+	if err != nil {
+		return false, err
+	}
+
+	for _, existing := range data.Wallets {
+		if existing.PublicKey == wallet.PublicKey && existing.ChatID == wallet.ChatID {
+			return false, nil
+		}
+	}
+
+	wallet.AddedAt = time.Now().Format(time.RFC3339)
+	data.Wallets = append(data.Wallets, wallet)
+	return true, saveWatchlistUnlocked(data)
+}
+
+// RemoveWatchedWallet removes wallet (public key or spark address) from watchlist of chat.
+// Returns false if wallet is not watched in this chat.
+func RemoveWatchedWallet(chatID int64, wallet string) (bool, error) {
+	watchlistFileMutex.Lock()
+	defer watchlistFileMutex.Unlock()
+
+	data, err := loadWatchlistUnlocked()
+	if err != nil {
+		return false, err
+	}
+
+	for i, existing := range data.Wallets {
+		if existing.ChatID == chatID && (existing.PublicKey == wallet || (existing.SparkAddress != "" && existing.SparkAddress == wallet)) {
+			data.Wallets = append(data.Wallets[:i], data.Wallets[i+1:]...)
+			return true, saveWatchlistUnlocked(data)
+		}
+	}
+	return false, nil
+}
+
+func loadWatchlistUnlocked() (*WatchlistData, error) {
+	raw, err := os.ReadFile(WatchlistFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &WatchlistData{}, nil
+		}
+		return nil, fmt.Errorf("failed to read watchlist file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &WatchlistData{}, nil
+	}
+
+	var data WatchlistData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse watchlist JSON: %w", err)
+	}
+	return &data, nil
+}
+
+func saveWatchlistUnlocked(data *WatchlistData) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal watchlist JSON: %w", err)
+	}
+
+	if err := WriteFileAtomic(WatchlistFile(), raw, 0644); err != nil {
+		return fmt.Errorf("failed to write watchlist file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package handoff
+
+// Zero-downtime restart via state handoff
+// Old process receives SIGUSR2, stops monitors and writes checkpoint (data_out/handoff/checkpoint.json)
+// New process (bot --handoff) signals old one, waits for checkpoint and restores state before starting monitors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"spark-wallet/internal/infra/paths"
+)
+
+const (
+	// CheckpointMaxAge - older checkpoints are ignored on startup
+	CheckpointMaxAge = 10 * time.Minute
+	// maxProcessedSwaps - processed swap IDs kept for dedup
+	maxProcessedSwaps = 500
+)
+
+// HandoffDir - folder for pid file and checkpoint
+func HandoffDir() string {
+	return paths.Output("handoff")
+}
+
+// PIDFile - pid of running bot process
+func PIDFile() string {
+	return filepath.Join(HandoffDir(), "bot.pid")
+}
+
+// CheckpointFile - state written by old process on SIGUSR2
+func CheckpointFile() string {
+	return filepath.Join(HandoffDir(), "checkpoint.json")
+}
+
+// Checkpoint - state passed from old process to new one
+type Checkpoint struct {
+	PID              int                  `json:"pid"`
+	CreatedAt        time.Time            `json:"created_at"`
+	LastSwapID       string               `json:"last_swap_id"`       // newest swap seen by Big Sales monitor
+	ProcessedSwapIDs []string             `json:"processed_swap_ids"` // dedup set (alerts already handled)
+	PendingSwapIDs   []string             `json:"pending_swap_ids"`   // queue: new swaps not yet handled
+	HotTokenSent     map[string]time.Time `json:"hot_token_sent"`     // poolLpPublicKey -> last hot token notification
+}
+
+// state - live state of current process, restored - state received from previous process
+var (
+	stateMutex       sync.Mutex
+	lastSwapID       string
+	processedSwapIDs []string
+	processedSet     = make(map[string]bool)
+	pendingSwapIDs   []string
+	hotTokenSent     = make(map[string]time.Time)
+
+	restored      *Checkpoint
+	swapsRestored bool
+)
+
+// SetLastSwapID sets newest swap ID seen by Big Sales monitor
+func SetLastSwapID(swapID string) {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+	lastSwapID = swapID
+}
+
+// BeginSwapBatch marks swaps as queued for processing
+func BeginSwapBatch(swapIDs []string) {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+	pendingSwapIDs = append([]string(nil), swapIDs...)
+}
+
+// MarkSwapProcessed removes swap from queue and adds it to dedup set
+func MarkSwapProcessed(swapID string) {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	for i, id := range pendingSwapIDs {
+		if id == swapID {
+			pendingSwapIDs = append(pendingSwapIDs[:i], pendingSwapIDs[i+1:]...)
+			break
+		}
+	}
+
+	if processedSet[swapID] {
+		return
+	}
+	processedSet[swapID] = true
+	processedSwapIDs = append(processedSwapIDs, swapID)
+	if len(processedSwapIDs) > maxProcessedSwaps {
+		delete(processedSet, processedSwapIDs[0])
+		processedSwapIDs = processedSwapIDs[1:]
+	}
+}
+
+// RecordHotTokenNotification saves time of sent hot token notification
+func RecordHotTokenNotification(poolLpPublicKey string, sentAt time.Time) {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+	hotTokenSent[poolLpPublicKey] = sentAt
+}
+
+// Snapshot returns current state as checkpoint
+func Snapshot() *Checkpoint {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	hotTokens := make(map[string]time.Time, len(hotTokenSent))
+	for pool, sentAt := range hotTokenSent {
+		hotTokens[pool] = sentAt
+	}
+
+	return &Checkpoint{
+		PID:              os.Getpid(),
+		CreatedAt:        time.Now(),
+		LastSwapID:       lastSwapID,
+		ProcessedSwapIDs: append([]string(nil), processedSwapIDs...),
+		PendingSwapIDs:   append([]string(nil), pendingSwapIDs...),
+		HotTokenSent:     hotTokens,
+	}
+}
+
+// WriteCheckpoint writes current state to checkpoint file
+func WriteCheckpoint() (*Checkpoint, error) {
+	checkpoint := Snapshot()
+
+	if err := os.MkdirAll(HandoffDir(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create handoff directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	tempFilePath := CheckpointFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write temporary checkpoint file: %w", err)
+	}
+	if err := os.Rename(tempFilePath, CheckpointFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return nil, fmt.Errorf("failed to rename temporary file to checkpoint file: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+// LoadCheckpoint loads checkpoint file
+// Returns nil if file doesn't exist
+func LoadCheckpoint() (*Checkpoint, error) {
+	data, err := os.ReadFile(CheckpointFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint JSON: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// Restore applies checkpoint of previous process and removes checkpoint file
+// Checkpoint is used once - next restart without handoff starts from clean state
+func Restore(checkpoint *Checkpoint) {
+	stateMutex.Lock()
+	restored = checkpoint
+	swapsRestored = false
+	for pool, sentAt := range checkpoint.HotTokenSent {
+		hotTokenSent[pool] = sentAt
+	}
+	for _, swapID := range checkpoint.ProcessedSwapIDs {
+		if !processedSet[swapID] {
+			processedSet[swapID] = true
+			processedSwapIDs = append(processedSwapIDs, swapID)
+		}
+	}
+	lastSwapID = checkpoint.LastSwapID
+	stateMutex.Unlock()
+
+	_ = os.Remove(CheckpointFile())
+}
+
+// TakeRestoredSwaps returns swap state of previous process (only once)
+// ok=false if nothing was restored or state already taken
+func TakeRestoredSwaps() (lastSeenID string, processed map[string]bool, pending map[string]bool, ok bool) {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	if restored == nil || swapsRestored {
+		return "", nil, nil, false
+	}
+	swapsRestored = true
+
+	processed = make(map[string]bool, len(restored.ProcessedSwapIDs))
+	for _, swapID := range restored.ProcessedSwapIDs {
+		processed[swapID] = true
+	}
+	pending = make(map[string]bool, len(restored.PendingSwapIDs))
+	for _, swapID := range restored.PendingSwapIDs {
+		pending[swapID] = true
+	}
+	return restored.LastSwapID, processed, pending, true
+}
+
+// RestoredHotTokenNotifications returns hot token notification times of previous process
+func RestoredHotTokenNotifications() map[string]time.Time {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	result := make(map[string]time.Time)
+	if restored == nil {
+		return result
+	}
+	for pool, sentAt := range restored.HotTokenSent {
+		result[pool] = sentAt
+	}
+	return result
+}
+
+// WritePIDFile saves pid of current process
+func WritePIDFile() error {
+	if err := os.MkdirAll(HandoffDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create handoff directory: %w", err)
+	}
+	if err := os.WriteFile(PIDFile(), []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		return fmt.Errorf("failed to write pid file: %w", err)
+	}
+	return nil
+}
+
+// RemovePIDFile removes pid file if it belongs to current process
+func RemovePIDFile() {
+	if pid, err := readPIDFile(); err == nil && pid == os.Getpid() {
+		_ = os.Remove(PIDFile())
+	}
+}
+
+// RequestHandoff signals running process (from pid file) and waits for its checkpoint
+// Returns nil checkpoint if no running process found
+func RequestHandoff(timeout time.Duration) (*Checkpoint, error) {
+	pid, err := readPIDFile()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if pid == os.Getpid() || !processAlive(pid) {
+		return nil, nil
+	}
+
+	requestedAt := time.Now()
+	if err := signalHandoff(pid); err != nil {
+		return nil, fmt.Errorf("failed to signal process %d: %w", pid, err)
+	}
+
+	deadline := requestedAt.Add(timeout)
+	for time.Now().Before(deadline) {
+		checkpoint, err := LoadCheckpoint()
+		if err == nil && checkpoint != nil && checkpoint.PID == pid && !checkpoint.CreatedAt.Before(requestedAt) {
+			// Wait for old process to exit so monitors don't overlap
+			for processAlive(pid) && time.Now().Before(deadline) {
+				time.Sleep(100 * time.Millisecond)
+			}
+			return checkpoint, nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	return nil, fmt.Errorf("checkpoint from process %d not received within %v", pid, timeout)
+}
+
+func readPIDFile() (int, error) {
+	data, err := os.ReadFile(PIDFile())
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid pid file %s: %w", filepath.Base(PIDFile()), err)
+	}
+	return pid, nil
+}
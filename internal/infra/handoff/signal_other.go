@@ -0,0 +1,21 @@
+//go:build !unix
+
+package handoff
+
+import (
+	"fmt"
+	"os"
+)
+
+// Notify returns channel that never receives (SIGUSR2 is not available)
+func Notify() <-chan os.Signal {
+	return make(chan os.Signal)
+}
+
+func signalHandoff(pid int) error {
+	return fmt.Errorf("state handoff is not supported on this platform")
+}
+
+func processAlive(pid int) bool {
+	return false
+}
@@ -0,0 +1,24 @@
+//go:build unix
+
+package handoff
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Notify returns channel receiving SIGUSR2 (handoff request)
+func Notify() <-chan os.Signal {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR2)
+	return ch
+}
+
+func signalHandoff(pid int) error {
+	return syscall.Kill(pid, syscall.SIGUSR2)
+}
+
+func processAlive(pid int) bool {
+	return syscall.Kill(pid, 0) == nil
+}
@@ -0,0 +1,68 @@
+package health
+
+// Liveness timestamps for /healthz: last successful Flashnet swaps request and last Telegram message sent
+// API clients and bots mark them as they work, health server only reads them
+
+import (
+	"net/http"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var (
+	swapsFetchedAt atomic.Int64 // unix nanoseconds, 0 - never
+	telegramSentAt atomic.Int64
+)
+
+// MarkSwapsFetched records successful swaps request
+func MarkSwapsFetched() {
+	swapsFetchedAt.Store(time.Now().UnixNano())
+}
+
+// LastSwapsFetched returns time of last successful swaps request (zero if none)
+func LastSwapsFetched() time.Time {
+	return loadTime(&swapsFetchedAt)
+}
+
+// MarkTelegramSent records message sent (or edited) by any bot
+func MarkTelegramSent() {
+	telegramSentAt.Store(time.Now().UnixNano())
+}
+
+// LastTelegramSent returns time of last message sent by any bot (zero if none)
+func LastTelegramSent() time.Time {
+	return loadTime(&telegramSentAt)
+}
+
+func loadTime(value *atomic.Int64) time.Time {
+	nanos := value.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// WrapBotClient returns bot HTTP client that records successful send*/edit* requests
+func WrapBotClient(next tgbotapi.HTTPClient) tgbotapi.HTTPClient {
+	return &botClient{next: next}
+}
+
+type botClient struct {
+	next tgbotapi.HTTPClient
+}
+
+func (c *botClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.next.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	method := path.Base(req.URL.Path)
+	if strings.HasPrefix(method, "send") || strings.HasPrefix(method, "edit") {
+		MarkTelegramSent()
+	}
+	return resp, nil
+}
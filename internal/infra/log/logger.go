@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"sync"
 
+	"spark-wallet/internal/infra/network"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/buffer"
 	"go.uber.org/zap/zapcore"
@@ -85,6 +87,14 @@ func initializeLoggers() error {
 	return nil
 }
 
+// withNetworkLabel prefixes console message with network label of non-mainnet instance
+func withNetworkLabel(message string) string {
+	if label := network.Label(); label != "" {
+		return label + " " + message
+	}
+	return message
+}
+
 // GenerateRequestID ID for
 func GenerateRequestID() string {
 	b := make([]byte, 8)
@@ -126,9 +136,9 @@ func LogResponse(requestID string, statusCode int, durationMs int64, fields ...z
 		// in -
 		endpointStr := fieldsToString(fields)
 		if endpointStr != "" {
-			consoleLogger.Error(fmt.Sprintf("✗ HTTP request failed [%d] %s", statusCode, endpointStr))
+			consoleLogger.Error(withNetworkLabel(fmt.Sprintf("✗ HTTP request failed [%d] %s", statusCode, endpointStr)))
 		} else {
-			consoleLogger.Error(fmt.Sprintf("✗ HTTP request failed [%d]", statusCode))
+			consoleLogger.Error(withNetworkLabel(fmt.Sprintf("✗ HTTP request failed [%d]", statusCode)))
 		}
 	}
 }
@@ -174,9 +184,9 @@ func LogSuccess(message string, fields ...zap.Field) {
 	Logger.Info(message, fields...)
 
 	if durationMs > 0 {
-		consoleLogger.Info(fmt.Sprintf("✓ %s (%dms)", message, durationMs))
+		consoleLogger.Info(withNetworkLabel(fmt.Sprintf("✓ %s (%dms)", message, durationMs)))
 	} else {
-		consoleLogger.Info("✓ " + message)
+		consoleLogger.Info(withNetworkLabel("✓ " + message))
 	}
 }
 
@@ -187,9 +197,9 @@ func LogError(message string, fields ...zap.Field) {
 	Logger.Error(message, fields...)
 
 	if durationMs > 0 {
-		consoleLogger.Error(fmt.Sprintf("✗ %s (%dms)", message, durationMs))
+		consoleLogger.Error(withNetworkLabel(fmt.Sprintf("✗ %s (%dms)", message, durationMs)))
 	} else {
-		consoleLogger.Error("✗ " + message)
+		consoleLogger.Error(withNetworkLabel("✗ " + message))
 	}
 }
 
@@ -334,6 +344,12 @@ func (e *customFileEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Fi
 	buf.AppendString(levelStr)
 	buf.AppendString(" ") // 1 and
 
+	// 3. network label of non-mainnet instance
+	if label := network.Label(); label != "" {
+		buf.AppendString(label)
+		buf.AppendString(" ")
+	}
+
 	if entry.Message != "" {
 		buf.AppendString(entry.Message)
 	}
@@ -0,0 +1,135 @@
+package migrations
+
+// Versioned storage migrations run at startup
+// Applied version is recorded in data_out/schema_version.json, only migrations above it run
+// Each migration must be idempotent: if process stops before version is saved, it runs again on next start
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	logging "spark-wallet/internal/infra/log"
+	"spark-wallet/internal/infra/paths"
+
+	"go.uber.org/zap"
+)
+
+// SchemaVersionFile - applied schema version of data_out
+func SchemaVersionFile() string {
+	return paths.Output("schema_version.json")
+}
+
+// Migration - one storage format change
+type Migration struct {
+	Version int    // unique, increasing
+	Name    string // short description for log and schema file
+	Up      func() error
+}
+
+// AppliedMigration - record of applied migration
+type AppliedMigration struct {
+	Version   int    `json:"version"`
+	Name      string `json:"name"`
+	AppliedAt string `json:"applied_at"` // RFC3339
+}
+
+// SchemaVersion - file structure for schema_version.json
+type SchemaVersion struct {
+	Version int                `json:"version"`
+	Applied []AppliedMigration `json:"applied"`
+}
+
+// Run applies migrations with version above recorded schema version, in version order
+// Stops at first failed migration, version stays at last applied one
+func Run(migrations []Migration) error {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Version == sorted[i-1].Version {
+			return fmt.Errorf("duplicate migration version %d", sorted[i].Version)
+		}
+	}
+
+	schema, err := LoadSchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range sorted {
+		if migration.Version <= schema.Version {
+			continue
+		}
+
+		logging.LogInfo("Applying storage migration",
+			zap.Int("version", migration.Version),
+			zap.String("name", migration.Name))
+
+		if err := migration.Up(); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+		}
+
+		schema.Version = migration.Version
+		schema.Applied = append(schema.Applied, AppliedMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now().UTC().Format(time.RFC3339),
+		})
+		if err := saveSchemaVersion(schema); err != nil {
+			return err
+		}
+	}
+
+	logging.LogDebug("Storage schema is up to date", zap.Int("version", schema.Version))
+	return nil
+}
+
+// LoadSchemaVersion loads applied schema version
+// Returns version 0 if file doesn't exist
+func LoadSchemaVersion() (*SchemaVersion, error) {
+	raw, err := os.ReadFile(SchemaVersionFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &SchemaVersion{}, nil
+		}
+		return nil, fmt.Errorf("failed to read schema version file: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return &SchemaVersion{}, nil
+	}
+
+	var schema SchemaVersion
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema version JSON: %w", err)
+	}
+	return &schema, nil
+}
+
+func saveSchemaVersion(schema *SchemaVersion) error {
+	if err := os.MkdirAll(filepath.Dir(SchemaVersionFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema version JSON: %w", err)
+	}
+
+	tempFilePath := SchemaVersionFile() + ".tmp"
+	if err := os.WriteFile(tempFilePath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary schema version file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, SchemaVersionFile()); err != nil {
+		_ = os.Remove(tempFilePath)
+		return fmt.Errorf("failed to rename temporary file to schema version file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package network
+
+// Network of bot instance (flashnet.network): mainnet, testnet or custom one (regtest, dev) with FLASHNET_API_URL
+// Instances of different networks can run from the same binary and working directory:
+// - data and output folders of non-mainnet network get {network} subfolder (see paths)
+// - log lines are tagged with [network]
+// - Telegram messages of non-mainnet bots are prefixed with [network], so shared chats can tell alerts apart
+// Mainnet keeps plain layout and messages, existing deployments are not affected
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Mainnet - default network
+const Mainnet = "mainnet"
+
+var (
+	nameMutex sync.RWMutex
+	name      = Mainnet
+)
+
+// Set sets network of instance (empty - mainnet)
+// Must be called before data folders are used and bots are created
+func Set(network string) {
+	network = strings.ToLower(strings.TrimSpace(network))
+	if network == "" {
+		network = Mainnet
+	}
+
+	nameMutex.Lock()
+	name = network
+	nameMutex.Unlock()
+}
+
+// Name returns network of instance
+func Name() string {
+	nameMutex.RLock()
+	defer nameMutex.RUnlock()
+	return name
+}
+
+// IsMainnet returns true if instance runs on mainnet
+func IsMainnet() bool {
+	return Name() == Mainnet
+}
+
+// Label returns [network] tag of messages and logs, empty on mainnet
+func Label() string {
+	if IsMainnet() {
+		return ""
+	}
+	return "[" + Name() + "]"
+}
+
+// WrapBotClient returns HTTP client of bot that prefixes text and captions of sent messages with network label
+// On mainnet next is returned as is
+func WrapBotClient(next tgbotapi.HTTPClient) tgbotapi.HTTPClient {
+	if IsMainnet() {
+		return next
+	}
+	return &labelClient{next: next}
+}
+
+// labelClient - HTTP client of bot on non-mainnet network
+type labelClient struct {
+	next tgbotapi.HTTPClient
+}
+
+func (c *labelClient) Do(req *http.Request) (*http.Response, error) {
+	method := path.Base(req.URL.Path)
+	if req.Body == nil || (!strings.HasPrefix(method, "send") && !strings.HasPrefix(method, "edit")) {
+		return c.next.Do(req)
+	}
+
+	var body bytes.Buffer
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		raw, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		values, err := url.ParseQuery(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		labelValues(values)
+		body.WriteString(values.Encode())
+	case "multipart/form-data":
+		// Photos with captions (charts) are uploaded as multipart, form is written again with labeled caption
+		contentType, err := relabelMultipart(req, &body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+	default:
+		return c.next.Do(req)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body.Bytes()))
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body.Bytes())), nil }
+	req.ContentLength = int64(body.Len())
+	req.Header.Set("Content-Length", strconv.Itoa(body.Len()))
+	return c.next.Do(req)
+}
+
+// relabelMultipart writes multipart form of req with labeled text and caption to body, returns its content type
+func relabelMultipart(req *http.Request, body *bytes.Buffer) (string, error) {
+	defer req.Body.Close()
+	if err := req.ParseMultipartForm(32 << 20); err != nil {
+		return "", err
+	}
+	defer req.MultipartForm.RemoveAll()
+
+	values := url.Values(req.MultipartForm.Value)
+	labelValues(values)
+
+	writer := multipart.NewWriter(body)
+	for key := range values {
+		if err := writer.WriteField(key, values.Get(key)); err != nil {
+			return "", err
+		}
+	}
+	for key, files := range req.MultipartForm.File {
+		for _, header := range files {
+			if err := copyFormFile(writer, key, header); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return writer.FormDataContentType(), nil
+}
+
+func copyFormFile(writer *multipart.Writer, key string, header *multipart.FileHeader) error {
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	part, err := writer.CreateFormFile(key, header.Filename)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, file)
+	return err
+}
+
+// labelValues prefixes text and caption params with network label
+func labelValues(values url.Values) {
+	label := Label()
+	for _, key := range []string{"text", "caption"} {
+		value := values.Get(key)
+		if value == "" || strings.HasPrefix(value, label) {
+			continue
+		}
+		values.Set(key, label+" "+value)
+	}
+}
@@ -0,0 +1,102 @@
+package paths
+
+// Base folders of bot files, set from config (app.data_dir, app.output_dir) before monitors start:
+// - data dir (default data_in): input files - auth challenges and tokens, account tokens, alert templates
+// - output dir (default data_out): state, archives, reports and generated charts
+// Relative folders are resolved against working directory, absolute ones let the bot run from any directory
+// and in containers with mounted volumes
+// Non-mainnet instance (network.Set) works in {network} subfolders of both, so mainnet and testnet bots
+// started from the same directory don't share state
+// Static assets (etc/fonts, etc/telegram) are looked up in working directory, then next to executable
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"spark-wallet/internal/infra/network"
+)
+
+const (
+	// DefaultDataDir - input folder when app.data_dir is not set
+	DefaultDataDir = "data_in"
+	// DefaultOutputDir - output folder when app.output_dir is not set
+	DefaultOutputDir = "data_out"
+
+	assetsDir = "etc"
+)
+
+var (
+	dirsMutex sync.RWMutex
+	dataDir   = DefaultDataDir
+	outputDir = DefaultOutputDir
+)
+
+// Configure sets data and output folders (empty - default)
+func Configure(data string, output string) {
+	if data == "" {
+		data = DefaultDataDir
+	}
+	if output == "" {
+		output = DefaultOutputDir
+	}
+
+	dirsMutex.Lock()
+	dataDir = filepath.Clean(data)
+	outputDir = filepath.Clean(output)
+	dirsMutex.Unlock()
+}
+
+// DataDir returns input folder of network
+func DataDir() string {
+	dirsMutex.RLock()
+	defer dirsMutex.RUnlock()
+	return networkDir(dataDir)
+}
+
+// OutputDir returns output folder of network
+func OutputDir() string {
+	dirsMutex.RLock()
+	defer dirsMutex.RUnlock()
+	return networkDir(outputDir)
+}
+
+// networkDir returns {dir}/{network} for non-mainnet network, dir for mainnet
+func networkDir(dir string) string {
+	if network.IsMainnet() {
+		return dir
+	}
+	return filepath.Join(dir, network.Name())
+}
+
+// Data returns path inside input folder
+func Data(elem ...string) string {
+	return filepath.Join(append([]string{DataDir()}, elem...)...)
+}
+
+// Output returns path inside output folder
+func Output(elem ...string) string {
+	return filepath.Join(append([]string{OutputDir()}, elem...)...)
+}
+
+// Charts returns folder of generated charts
+func Charts() string {
+	return Output("charts")
+}
+
+// Asset returns path of static asset in etc/
+// Working directory is checked first, then folder of executable; path in working directory is returned if asset is not found
+func Asset(elem ...string) string {
+	relative := filepath.Join(append([]string{assetsDir}, elem...)...)
+	if _, err := os.Stat(relative); err == nil {
+		return relative
+	}
+
+	if executable, err := os.Executable(); err == nil {
+		candidate := filepath.Join(filepath.Dir(executable), relative)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return relative
+}
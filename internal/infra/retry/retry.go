@@ -1,14 +1,15 @@
 package retry
 
 // Retry mechanism with exponential backoff and full jitter
-// Handles retryable errors (HTTP 429, 500, 502, 503, 504)
-// Supports Retry-After header for 429 responses
+// Handles retryable errors (HTTP 429, 500, 502, 503, 504 or statuses set in Options)
+// Supports Retry-After header for 429 and 503 responses
 // Applies random delay (full jitter) to prevent thundering herd
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -21,6 +22,10 @@ type Options struct {
 	BaseDelay  time.Duration
 	MaxDelay   time.Duration
 	Backoff    float64
+	// RetryableStatuses - HTTP statuses that are retried, nil - 429, 500, 502, 503, 504
+	RetryableStatuses []int
+	// OnRetry is called before sleeping between attempts (attempt starts at 0)
+	OnRetry func(attempt int, err error, sleep time.Duration)
 }
 
 type HTTPError struct {
@@ -55,6 +60,23 @@ func IsRetryable(err error) bool {
 	return false
 }
 
+// IsRetryableStatus checks if err is HTTPError with one of statuses (nil - same as IsRetryable)
+func IsRetryableStatus(err error, statuses []int) bool {
+	if statuses == nil {
+		return IsRetryable(err)
+	}
+	var he *HTTPError
+	if !errors.As(err, &he) {
+		return false
+	}
+	for _, status := range statuses {
+		if he.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
 var seedOnce sync.Once
 
 func seedRand() {
@@ -90,13 +112,25 @@ func clamp(d, max time.Duration) time.Duration {
 }
 
 func FullJitterSleep(attempt int, baseDelay, maxDelay time.Duration) time.Duration {
+	return BackoffJitterSleep(attempt, baseDelay, maxDelay, 2.0)
+}
+
+// BackoffJitterSleep returns random delay up to baseDelay * backoff^attempt (capped by maxDelay)
+func BackoffJitterSleep(attempt int, baseDelay, maxDelay time.Duration, backoff float64) time.Duration {
 	if attempt < 0 {
 		attempt = 0
 	}
 	if baseDelay <= 0 {
 		return 0
 	}
-	maxForAttempt := baseDelay << attempt
+	if backoff < 1 {
+		backoff = 1
+	}
+	maxForAttempt := time.Duration(float64(baseDelay) * math.Pow(backoff, float64(attempt)))
+	if maxForAttempt < 0 {
+		// Overflow of large attempt
+		maxForAttempt = maxDelay
+	}
 	maxForAttempt = clamp(maxForAttempt, maxDelay)
 	if maxForAttempt <= 0 {
 		return 0
@@ -131,18 +165,21 @@ func Do(ctx context.Context, opts Options, fn func() error) error {
 		}
 		lastErr = err
 
-		if !IsRetryable(err) || attempt == totalAttempts-1 {
+		if !IsRetryableStatus(err, opts.RetryableStatuses) || attempt == totalAttempts-1 {
 			return lastErr
 		}
 
 		// Default: jitter sleep based on exponential cap for this attempt.
-		sleep := FullJitterSleep(attempt, opts.BaseDelay, opts.MaxDelay)
+		sleep := BackoffJitterSleep(attempt, opts.BaseDelay, opts.MaxDelay, opts.Backoff)
 
-		// Prefer Retry-After for 429 if present.
+		// Prefer Retry-After for 429 and 503 if present.
 		var he *HTTPError
-		if errors.As(err, &he) && he.StatusCode == 429 && he.RetryAfter > 0 {
+		if errors.As(err, &he) && (he.StatusCode == 429 || he.StatusCode == 503) && he.RetryAfter > 0 {
 			sleep = clamp(he.RetryAfter, opts.MaxDelay)
 		}
+		if opts.OnRetry != nil {
+			opts.OnRetry(attempt, err, sleep)
+		}
 
 		t := time.NewTimer(sleep)
 		select {
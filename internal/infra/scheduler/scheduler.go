@@ -0,0 +1,167 @@
+package scheduler
+
+// Scheduler of periodic jobs (daily stats, digests, holders checks)
+// Every job has its own schedule: daily at HH:MM in job's timezone (optionally on some weekdays) or fixed interval
+// Time comes from Clock, so jobs can be driven by fake clock in tests instead of waiting for real time
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	logging "spark-wallet/internal/infra/log"
+
+	"go.uber.org/zap"
+)
+
+// Clock - time source of scheduler
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// System - real time clock
+var System Clock = systemClock{}
+
+// Default - scheduler of bot jobs, replaced in tests to run jobs on fake clock
+var Default = New(System)
+
+// Schedule returns next run of job strictly after given time
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// Daily - every day at Hour:Minute in Location, only on Weekdays if set
+type Daily struct {
+	Hour     int
+	Minute   int
+	Location *time.Location
+	Weekdays []time.Weekday // empty - every day
+}
+
+// ParseDaily creates daily schedule from "HH:MM" in location
+func ParseDaily(at string, location *time.Location, weekdays ...time.Weekday) (Daily, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(at))
+	if err != nil {
+		return Daily{}, fmt.Errorf("invalid time %q, expected HH:MM: %w", at, err)
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return Daily{Hour: parsed.Hour(), Minute: parsed.Minute(), Location: location, Weekdays: weekdays}, nil
+}
+
+// Next returns first Hour:Minute after given time on allowed weekday
+// Days are stepped in calendar of Location, so DST changes don't shift send time
+func (d Daily) Next(after time.Time) time.Time {
+	location := d.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	local := after.In(location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), d.Hour, d.Minute, 0, 0, location)
+	for i := 0; i < 8; i++ {
+		if next.After(after) && d.allowed(next.Weekday()) {
+			return next
+		}
+		next = time.Date(next.Year(), next.Month(), next.Day()+1, d.Hour, d.Minute, 0, 0, location)
+	}
+	return next
+}
+
+func (d Daily) allowed(weekday time.Weekday) bool {
+	if len(d.Weekdays) == 0 {
+		return true
+	}
+	for _, allowed := range d.Weekdays {
+		if allowed == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Interval - every d after previous run
+type Interval time.Duration
+
+// Next returns time d after given time
+func (i Interval) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(i))
+}
+
+// Job - named task run on schedule
+type Job struct {
+	Name       string
+	Schedule   Schedule
+	RunOnStart bool                                     // run once right away, before first scheduled time
+	Run        func(ctx context.Context, now time.Time) // now - time of run by scheduler clock
+}
+
+// Scheduler runs jobs on its clock
+type Scheduler struct {
+	clock Clock
+}
+
+// New creates scheduler, nil clock - System
+func New(clock Clock) *Scheduler {
+	if clock == nil {
+		clock = System
+	}
+	return &Scheduler{clock: clock}
+}
+
+// Now returns current time of scheduler clock
+func (s *Scheduler) Now() time.Time {
+	return s.clock.Now()
+}
+
+// Run runs job on its schedule until ctx is done (blocks)
+func (s *Scheduler) Run(ctx context.Context, job Job) {
+	if job.RunOnStart {
+		if ctx.Err() != nil {
+			return
+		}
+		job.Run(ctx, s.clock.Now())
+	}
+
+	for {
+		now := s.clock.Now()
+		next := job.Schedule.Next(now)
+		logging.LogDebug("Job scheduled",
+			zap.String("job", job.Name),
+			zap.Time("next", next),
+			zap.Duration("delay", next.Sub(now)))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.clock.After(next.Sub(now)):
+		}
+		job.Run(ctx, s.clock.Now())
+	}
+}
+
+// Date returns calendar date (YYYY-MM-DD) of t in location, so "today" of job doesn't depend on server timezone
+func Date(t time.Time, location *time.Location) string {
+	if location == nil {
+		location = time.UTC
+	}
+	return t.In(location).Format("2006-01-02")
+}
+
+// LoadLocation returns timezone by name, UTC if it is not available (e.g. no tzdata in container)
+func LoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		logging.LogError("Failed to load timezone, using UTC", zap.String("timezone", name), zap.Error(err))
+		return time.UTC
+	}
+	return location
+}
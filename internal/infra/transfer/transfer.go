@@ -0,0 +1,114 @@
+package transfer
+
+// Compressed API responses and transfer size metrics shared by API clients
+// Requests ask for gzip explicitly, so response is decompressed here (not by http.Transport)
+// and both wire (compressed) and body (decompressed) sizes are counted per client
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Stats - transfer counters of one API client
+type Stats struct {
+	Client     string
+	Responses  int64 // responses read
+	Compressed int64 // responses received gzipped
+	WireBytes  int64 // bytes received over network
+	BodyBytes  int64 // bytes after decompression
+}
+
+// Savings returns share of traffic saved by compression (0-1)
+func (s Stats) Savings() float64 {
+	if s.BodyBytes == 0 {
+		return 0
+	}
+	return 1 - float64(s.WireBytes)/float64(s.BodyBytes)
+}
+
+var (
+	stats      = make(map[string]*Stats) // client -> counters
+	statsMutex sync.Mutex
+)
+
+// AcceptGzip asks server for gzipped response
+// Response must be read with ReadBody
+func AcceptGzip(req *http.Request) {
+	req.Header.Set("Accept-Encoding", "gzip")
+}
+
+// ReadBody reads response body (decompressing gzip) and records transfer sizes for client
+// limit - max decompressed size in bytes (0 - no limit)
+// Returns body and size of response on the wire
+func ReadBody(client string, resp *http.Response, limit int64) ([]byte, int64, error) {
+	wire := &countingReader{r: resp.Body}
+
+	var reader io.Reader = wire
+	compressed := strings.EqualFold(strings.TrimSpace(resp.Header.Get("Content-Encoding")), "gzip")
+	if compressed {
+		gz, err := gzip.NewReader(wire)
+		if err != nil {
+			return nil, wire.n, fmt.Errorf("failed to open gzip response: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	if limit > 0 {
+		reader = io.LimitReader(reader, limit)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, wire.n, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	record(client, wire.n, int64(len(body)), compressed)
+	return body, wire.n, nil
+}
+
+// AllStats returns transfer counters of all clients (sorted by client)
+func AllStats() []Stats {
+	statsMutex.Lock()
+	defer statsMutex.Unlock()
+
+	list := make([]Stats, 0, len(stats))
+	for _, s := range stats {
+		list = append(list, *s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Client < list[j].Client })
+	return list
+}
+
+func record(client string, wireBytes, bodyBytes int64, compressed bool) {
+	statsMutex.Lock()
+	defer statsMutex.Unlock()
+
+	s, exists := stats[client]
+	if !exists {
+		s = &Stats{Client: client}
+		stats[client] = s
+	}
+	s.Responses++
+	s.WireBytes += wireBytes
+	s.BodyBytes += bodyBytes
+	if compressed {
+		s.Compressed++
+	}
+}
+
+// countingReader counts bytes read from underlying reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
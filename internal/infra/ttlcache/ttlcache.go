@@ -0,0 +1,182 @@
+package ttlcache
+
+// In-memory cache of API responses keyed by string (wallet public key) with per-result lifetime
+// Found values live for TTL, "not found" results for NegativeTTL and failed fetches for ErrorTTL,
+// so burst of lookups of the same key sends one request. Concurrent misses of key share one fetch
+// Found value older than RefreshAfter is still returned and refreshed in background
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Options - lifetimes of cached results
+type Options struct {
+	TTL          time.Duration    // lifetime of found value
+	NegativeTTL  time.Duration    // lifetime of "not found" result
+	ErrorTTL     time.Duration    // lifetime of failed fetch, 0 - failures are not cached
+	RefreshAfter time.Duration    // found value older than this is refreshed in background, 0 - no refresh
+	MaxEntries   int              // expired entries are pruned above this size, 0 - unbounded
+	Now          func() time.Time // clock, time.Now if nil
+}
+
+// FetchFunc loads value of key, found=false is negative result (e.g. wallet has no profile)
+type FetchFunc[V any] func(ctx context.Context, key string) (value V, found bool, err error)
+
+// Cache - TTL cache of fetched values
+type Cache[V any] struct {
+	opts  Options
+	fetch FetchFunc[V]
+
+	mu       sync.Mutex
+	entries  map[string]*entry[V]
+	inflight map[string]*fetchCall[V]
+}
+
+type entry[V any] struct {
+	value      V
+	err        error
+	fetchedAt  time.Time
+	expiresAt  time.Time
+	found      bool
+	refreshing bool
+}
+
+// fetchCall - fetch of key shared by concurrent callers
+type fetchCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// New creates cache that loads missing keys with fetch
+func New[V any](opts Options, fetch FetchFunc[V]) *Cache[V] {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Cache[V]{
+		opts:     opts,
+		fetch:    fetch,
+		entries:  make(map[string]*entry[V]),
+		inflight: make(map[string]*fetchCall[V]),
+	}
+}
+
+// Get returns cached value of key or fetches it
+// Cached failure is returned as error until ErrorTTL passes
+func (c *Cache[V]) Get(ctx context.Context, key string) (V, error) {
+	c.mu.Lock()
+	now := c.opts.Now()
+	if e, ok := c.entries[key]; ok && now.Before(e.expiresAt) {
+		if e.found && c.opts.RefreshAfter > 0 && !e.refreshing && now.Sub(e.fetchedAt) >= c.opts.RefreshAfter {
+			e.refreshing = true
+			go c.refresh(key)
+		}
+		value, err := e.value, e.err
+		c.mu.Unlock()
+		return value, err
+	}
+	// Key is already being fetched - wait for it instead of sending second request
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+	call := &fetchCall[V]{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	value, found, err := c.fetch(ctx, key)
+	call.value, call.err = value, err
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	// Cancelled caller is not a failure of key
+	if ctx.Err() == nil || err == nil {
+		c.storeUnlocked(key, value, found, err)
+	}
+	c.mu.Unlock()
+	close(call.done)
+
+	return value, err
+}
+
+// Set stores value of key fetched elsewhere (e.g. batch request or local table)
+func (c *Cache[V]) Set(key string, value V, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.storeUnlocked(key, value, found, nil)
+}
+
+// Len returns number of cached keys (including expired, not pruned yet)
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// refresh fetches found value again, on failure old value is kept until it expires
+func (c *Cache[V]) refresh(key string) {
+	value, found, err := c.fetch(context.Background(), key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		if e, ok := c.entries[key]; ok {
+			e.refreshing = false
+		}
+		return
+	}
+	c.storeUnlocked(key, value, found, nil)
+}
+
+func (c *Cache[V]) storeUnlocked(key string, value V, found bool, err error) {
+	ttl := c.opts.TTL
+	switch {
+	case err != nil:
+		ttl = c.opts.ErrorTTL
+	case !found:
+		ttl = c.opts.NegativeTTL
+	}
+	if ttl <= 0 {
+		delete(c.entries, key)
+		return
+	}
+
+	now := c.opts.Now()
+	if c.opts.MaxEntries > 0 && len(c.entries) >= c.opts.MaxEntries {
+		c.pruneUnlocked(now)
+	}
+	c.entries[key] = &entry[V]{
+		value:     value,
+		err:       err,
+		fetchedAt: now,
+		expiresAt: now.Add(ttl),
+		found:     found && err == nil,
+	}
+}
+
+// pruneUnlocked removes expired entries, then oldest ones if cache is still full
+func (c *Cache[V]) pruneUnlocked(now time.Time) {
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	for len(c.entries) >= c.opts.MaxEntries {
+		oldestKey := ""
+		var oldest time.Time
+		for key, e := range c.entries {
+			if oldestKey == "" || e.fetchedAt.Before(oldest) {
+				oldestKey, oldest = key, e.fetchedAt
+			}
+		}
+		delete(c.entries, oldestKey)
+	}
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/testutil"
+)
+
+const (
+	accumulationTransferWallet = "03e2e0000000000000000000000000000000000000000000000000000000000a01"
+	accumulationFlipperWallet  = "03e2e0000000000000000000000000000000000000000000000000000000000a02"
+	accumulationOldWallet      = "03e2e0000000000000000000000000000000000000000000000000000000000a03"
+)
+
+// saveAccumulationChanges tracks E2E with holder changes of whale (swaps for 0.028 BTC net, 1400 tokens),
+// transfer wallet (5000 tokens found by check), flipper (bought and liquidated), small buyer and buy of 10 days ago
+func saveAccumulationChanges(t *testing.T, now time.Time) {
+	t.Helper()
+	holders.SetConfiguredTickers([]string{"E2E"})
+	t.Cleanup(func() { holders.SetConfiguredTickers(nil) })
+
+	day := func(daysAgo int) string { return now.AddDate(0, 0, -daysAgo).Format("2006-01-02") }
+	data := &holders.DynamicHoldersData{
+		Changes: map[string][]holders.BalanceChange{
+			e2eWhaleSwapperKey: {
+				{Amount: 1000, Delta: 1000, Action: "invested", Value: 0.02, Date: day(3)},
+				{Amount: 900, Delta: -100, Action: "sold", Value: 0.002, Date: day(1)},
+				{Amount: 1400, Delta: 500, Action: "invested", Value: 0.01, Date: day(0)},
+			},
+			accumulationTransferWallet: {
+				{Amount: 5000, Delta: 5000, Action: "invested", Date: day(2)},
+			},
+			accumulationFlipperWallet: {
+				{Amount: 9000, Delta: 9000, Action: "invested", Value: 0.2, Date: day(2)},
+				{Amount: 0, Delta: -9000, Action: "liquidated", Value: 0.21, Date: day(1)},
+			},
+			e2eSmallSwapperKey: {
+				{Amount: 10, Delta: 10, Action: "invested", Value: 0.001, Date: day(0)},
+			},
+			accumulationOldWallet: {
+				{Amount: 90000, Delta: 90000, Action: "invested", Value: 1, Date: day(10)},
+			},
+		},
+	}
+	if err := holders.SaveDynamicHolders("E2E", data); err != nil {
+		t.Fatalf("failed to save dynamic holders: %v", err)
+	}
+}
+
+func accumulationWallets(found []holders.Accumulation) []string {
+	wallets := make([]string, 0, len(found))
+	for _, accumulation := range found {
+		wallets = append(wallets, accumulation.Wallet)
+	}
+	return wallets
+}
+
+func TestFindAccumulations(t *testing.T) {
+	newE2EEnv(t)
+	now := time.Now()
+	saveAccumulationChanges(t, now)
+	thresholds := holders.AccumulationThresholds{SupplyPercent: 2, MinBTC: 0.025}
+
+	// Supply of 100K: transfer wallet got 5%, whale 1.4% but 0.028 BTC
+	found, err := holders.FindAccumulations("E2E", 100_000, thresholds, now)
+	if err != nil {
+		t.Fatalf("FindAccumulations failed: %v", err)
+	}
+	if got := accumulationWallets(found); len(got) != 2 || got[0] != e2eWhaleSwapperKey || got[1] != accumulationTransferWallet {
+		t.Fatalf("accumulating wallets = %v, want whale then transfer wallet", got)
+	}
+	whale := found[0]
+	if whale.NetTokens != 1400 || math.Abs(whale.NetBTC-0.028) > 1e-9 || math.Abs(whale.SupplyPercent-1.4) > 1e-9 || whale.Balance != 1400 {
+		t.Errorf("whale accumulation = %+v", whale)
+	}
+	if len(whale.Timeline) != 3 || whale.Timeline[0].Delta != 1000 || whale.Timeline[1].Sells != 1 || whale.Timeline[2].Buys != 1 {
+		t.Errorf("whale timeline = %+v, want 3 days oldest first", whale.Timeline)
+	}
+
+	// Unknown supply leaves BTC criterion only
+	found, _ = holders.FindAccumulations("E2E", 0, thresholds, now)
+	if got := accumulationWallets(found); len(got) != 1 || got[0] != e2eWhaleSwapperKey {
+		t.Errorf("accumulating wallets without supply = %v, want whale", got)
+	}
+
+	// Alerted wallet waits for the next window
+	if err := holders.MarkAccumulationAlerted("E2E", e2eWhaleSwapperKey, now); err != nil {
+		t.Fatalf("MarkAccumulationAlerted failed: %v", err)
+	}
+	found, _ = holders.FindAccumulations("E2E", 100_000, thresholds, now.Add(time.Hour))
+	if got := accumulationWallets(found); len(got) != 1 || got[0] != accumulationTransferWallet {
+		t.Errorf("accumulating wallets after alert = %v, want transfer wallet", got)
+	}
+
+	if found, _ := holders.FindAccumulations("E2E", 100_000, holders.AccumulationThresholds{}, now); len(found) != 0 {
+		t.Errorf("disabled thresholds found %v", accumulationWallets(found))
+	}
+}
+
+func TestAccumulationMonitor_E2E(t *testing.T) {
+	newE2EEnv(t)
+	now := time.Now()
+	saveAccumulationChanges(t, now)
+
+	// Pool has no supply in mock Luminex, whale is found by BTC
+	telegram := testutil.NewFakeTelegram(t)
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunAccumulationMonitor(ctx, telegram.Bot, e2eFilteredChatID, holders.AccumulationThresholds{SupplyPercent: 2, MinBTC: 0.025}, time.Hour)
+	})
+
+	sent := telegram.WaitForSent(t, 1, e2eMessageTimeout)
+	time.Sleep(e2eSettleAfterAlert)
+	if got := len(telegram.Sent()); got != 1 || sent[0].ChatID != e2eFilteredChatID {
+		t.Fatalf("alerts sent = %+v, want one to filtered chat", telegram.Sent())
+	}
+	text := sent[0].Text
+	for _, want := range []string{"<b>accumulation</b>: {E2E}", "Net 7d: +1.4K tokens", "Net BTC: +0.028 btc", "Buy timeline:", "-100, -0.002 btc (1 sell)", "+500, +0.01 btc (1 buy)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("alert doesn't contain %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+)
+
+// Token file holds JWT in plain text without encryption key: owner-only mode, concurrent writers don't share temp file
+func TestAuthStorage_TokenFileWrite(t *testing.T) {
+	dataDir := t.TempDir()
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := flashnet.SaveTokenToFile(dataDir, fmt.Sprintf("token-%d", i), "public-key", expiresAt); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("SaveTokenToFile failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("files in data dir = %v, want only token file", entries)
+	}
+	info, err := os.Stat(filepath.Join(dataDir, entries[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("token file mode = %o, want 600", mode)
+	}
+	if tokenFile, err := flashnet.LoadTokenFromFile(dataDir); err != nil || tokenFile.AccessToken == "" {
+		t.Errorf("LoadTokenFromFile = %+v, %v", tokenFile, err)
+	}
+}
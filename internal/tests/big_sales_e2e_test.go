@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/btc_price"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/testutil"
+)
+
+const (
+	e2ePoolLpPublicKey  = "03e2e0000000000000000000000000000000000000000000000000000000000001"
+	e2eOtherPoolKey     = "03e2e0000000000000000000000000000000000000000000000000000000000002"
+	e2eTokenAddress     = "btkn1e2etokenaddress"
+	e2eOtherToken       = "btkn1e2eothertoken"
+	e2eWhaleSwapperKey  = "02e2e00000000000000000000000000000000000000000000000000000000000a1"
+	e2eSmallSwapperKey  = "02e2e00000000000000000000000000000000000000000000000000000000000a2"
+	e2eFilteredSwapper  = "02e2e00000000000000000000000000000000000000000000000000000000000a3"
+	e2eMainChatID       = "-1001000000001"
+	e2eFilteredChatID   = "-1001000000002"
+	e2eMessageTimeout   = 10 * time.Second
+	e2eSettleAfterAlert = 200 * time.Millisecond
+)
+
+// e2eEnv - mock APIs with E2E token pool and swap polling every 20ms
+type e2eEnv struct {
+	flashnet *testutil.FlashnetServer
+	luminex  *testutil.LuminexServer
+}
+
+func newE2EEnv(t *testing.T) *e2eEnv {
+	t.Helper()
+	paths.Configure(t.TempDir(), t.TempDir())
+
+	bots_monitor.SetSwapPollConfig(bots_monitor.SwapPollConfig{Interval: 20 * time.Millisecond, IdleAfter: time.Hour})
+	t.Cleanup(func() { bots_monitor.SetSwapPollConfig(bots_monitor.DefaultSwapPollConfig()) })
+	btc_price.SetFeed(testutil.StaticPriceFeed(100_000))
+	t.Cleanup(func() { btc_price.SetFeed(btc_price.NewCoinGeckoFeed()) })
+
+	env := &e2eEnv{flashnet: testutil.NewFlashnetServer(t), luminex: testutil.NewLuminexServer(t)}
+	env.luminex.AddPool(testutil.TokenPool(e2ePoolLpPublicKey, e2eTokenAddress, "E2E", "End To End"))
+	env.luminex.AddPool(testutil.TokenPool(e2eOtherPoolKey, e2eOtherToken, "OTHER", "Other Token"))
+	env.luminex.SetUsername(e2eWhaleSwapperKey, "whale")
+	env.luminex.SetWallet(e2eWhaleSwapperKey, luminex.WalletBalanceResponse{
+		SparkAddress: "sp1e2ewhale",
+		Balance:      luminex.WalletBalance{BtcHardBalanceSats: 50_000_000},
+	})
+	return env
+}
+
+// runMonitor runs monitor until end of test
+func runMonitor(t *testing.T, run func(ctx context.Context)) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+}
+
+func TestBigSalesMonitor_E2E_MainChat(t *testing.T) {
+	env := newE2EEnv(t)
+	start := time.Now().Add(-time.Minute)
+	env.flashnet.AddSwaps(
+		testutil.BuySwap("e2e-main-small", e2ePoolLpPublicKey, e2eTokenAddress, e2eSmallSwapperKey, 100_000, "10000000000", start.Add(time.Second)),
+		testutil.BuySwap("e2e-main-big", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 2_000_000, "160000000000", start),
+	)
+
+	telegram := testutil.NewFakeTelegram(t)
+	client := env.flashnet.Client()
+	rulesFile := filepath.Join(t.TempDir(), "alert_rules.json")
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunBigSalesBuysMonitor(ctx, telegram.Bot, client, e2eMainChatID, 0.01, nil, "", nil, 0, rulesFile, nil)
+	})
+
+	// Only swap above 0.01 BTC is alerted
+	sent := telegram.WaitForSent(t, 1, e2eMessageTimeout)
+	alert := sent[0]
+	if alert.ChatID != e2eMainChatID {
+		t.Errorf("alert sent to %s, want %s", alert.ChatID, e2eMainChatID)
+	}
+	for _, want := range []string{"{E2E}", "0.02 btc", "whale", "Market cap - $1.25M", "0.5 btc"} {
+		if !strings.Contains(alert.Text, want) {
+			t.Errorf("alert has no %q:\n%s", want, alert.Text)
+		}
+	}
+
+	// Next poll alerts only new swap
+	env.flashnet.AddSwaps(testutil.SellSwap("e2e-main-sell", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, "80000000000", 1_500_000, start.Add(2*time.Second)))
+	sent = telegram.WaitForSent(t, 2, e2eMessageTimeout)
+	if !strings.Contains(sent[1].Text, "Sell") || !strings.Contains(sent[1].Text, "0.015 btc") {
+		t.Errorf("second alert is not the new sell:\n%s", sent[1].Text)
+	}
+
+	time.Sleep(e2eSettleAfterAlert)
+	if got := len(telegram.Sent()); got != 2 {
+		t.Errorf("%d alerts sent, want 2 (swaps are alerted once)", got)
+	}
+	if env.flashnet.Requests("/swaps") < 2 {
+		t.Errorf("swaps polled %d times, want at least 2", env.flashnet.Requests("/swaps"))
+	}
+}
+
+func TestBigSalesMonitor_E2E_FilteredChatFastPath(t *testing.T) {
+	env := newE2EEnv(t)
+	start := time.Now().Add(-time.Minute)
+	env.flashnet.AddSwaps(
+		testutil.BuySwap("e2e-filtered-other", e2eOtherPoolKey, e2eOtherToken, e2eSmallSwapperKey, 5_000_000, "10000000000", start.Add(time.Second)),
+		testutil.BuySwap("e2e-filtered-big", e2ePoolLpPublicKey, e2eTokenAddress, e2eFilteredSwapper, 20_000_000, "1600000000000", start),
+	)
+
+	telegram := testutil.NewFakeTelegram(t)
+	client := env.flashnet.Client()
+	rulesFile := filepath.Join(t.TempDir(), "alert_rules.json")
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunBigSalesBuysMonitor(ctx, nil, client, "", 0, telegram.Bot, e2eFilteredChatID, []string{e2ePoolLpPublicKey}, 0.01, rulesFile, nil)
+	})
+
+	// 0.2 BTC is above filtered threshold x fast path multiplier: minimal alert, then edit with details
+	sent := telegram.WaitForSent(t, 1, e2eMessageTimeout)
+	if sent[0].ChatID != e2eFilteredChatID || !strings.Contains(sent[0].Text, "0.2 btc") {
+		t.Fatalf("unexpected filtered alert: %+v", sent[0])
+	}
+
+	deadline := time.Now().Add(e2eMessageTimeout)
+	var edit *testutil.TelegramMessage
+	for edit == nil && time.Now().Before(deadline) {
+		for _, message := range telegram.Messages() {
+			if message.Method == "editMessageText" && message.MessageID == sent[0].MessageID {
+				edit = &message
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if edit == nil {
+		t.Fatalf("fast path alert was not edited with details: %+v", telegram.Messages())
+	}
+	if !strings.Contains(edit.Text, "{E2E}") {
+		t.Errorf("edited alert has no ticker:\n%s", edit.Text)
+	}
+
+	// Swap of token not in filtered list is not sent
+	time.Sleep(e2eSettleAfterAlert)
+	if got := len(telegram.Sent()); got != 1 {
+		t.Errorf("%d alerts sent to filtered chat, want 1: %+v", got, telegram.Sent())
+	}
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"unicode/utf8"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/features/i18n"
+)
+
+// Telegram limits of setMyCommands
+var botCommandNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+func TestBotCommands_Registrable(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, name := range bots_monitor.BotCommandNames() {
+		if !botCommandNamePattern.MatchString(name) {
+			t.Errorf("command %q is not a valid Telegram command name", name)
+		}
+		if seen[name] {
+			t.Errorf("command %q is listed twice", name)
+		}
+		seen[name] = true
+
+		for _, lang := range i18n.Languages {
+			description := i18n.T(lang, "cmd."+name)
+			if !i18n.Has("cmd."+name) || description == "" || utf8.RuneCountInString(description) > 256 {
+				t.Errorf("command %q has no %s description of 1-256 characters: %q", name, lang, description)
+			}
+		}
+	}
+
+	for alias, command := range bots_monitor.CommandAliases() {
+		if seen[alias] || !seen[command] {
+			t.Errorf("alias %q -> %q: alias must not be listed and must point to listed command", alias, command)
+		}
+	}
+}
+
+// Every command handled by bot is in autocomplete list or is alias, so new commands are not left out of Telegram menu
+func TestBotCommands_HandledCommandsListed(t *testing.T) {
+	listed := make(map[string]bool)
+	for _, name := range bots_monitor.BotCommandNames() {
+		listed[name] = true
+	}
+	for alias := range bots_monitor.CommandAliases() {
+		listed[alias] = true
+	}
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "bots_monitor", "*.go"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("failed to find command handler sources: %v", err)
+	}
+	handledPattern := regexp.MustCompile(`command == "([a-z0-9_]+)"`)
+	handled := 0
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, match := range handledPattern.FindAllSubmatch(source, -1) {
+			handled++
+			if name := string(match[1]); !listed[name] {
+				t.Errorf("/%s is handled in %s but missing from botCommands (bot_commands.go)", name, filepath.Base(file))
+			}
+		}
+	}
+	if handled == 0 {
+		t.Fatal("no handled commands found")
+	}
+}
@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"encoding/json"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/tg_charts"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+
+	"github.com/fogleman/gg"
+)
+
+const chartThemeBrandedChat = -1001234567890
+
+const chartThemeYAML = `
+theme: dark
+canvas:
+  width: 1163
+  height: 667
+fonts:
+  - "/missing/Custom.ttf"
+themes:
+  dark:
+    accent: "#f7931a"
+  paper:
+    background: "#fafaf0"
+chats:
+  "-1001234567890":
+    theme: light
+    logo: "/missing/partner.png"
+    font: "/missing/Partner.ttf"
+  "-1009876543210":
+    theme: paper
+`
+
+func writeChartTheme(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "chart_theme.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write chart theme: %v", err)
+	}
+	return path
+}
+
+func loadChartTheme(t *testing.T, content string) {
+	t.Helper()
+	file, err := tg_charts.LoadThemeFile(writeChartTheme(t, content))
+	if err != nil {
+		t.Fatalf("LoadThemeFile failed: %v", err)
+	}
+	tg_charts.SetThemeFile(*file)
+	t.Cleanup(func() { tg_charts.SetThemeFile(tg_charts.ThemeFile{}) })
+}
+
+func TestChartTheme_Resolve(t *testing.T) {
+	builtin := tg_charts.ThemeFor(chartThemeBrandedChat)
+	if builtin.Name != tg_charts.ThemeDark || builtin.Background != (color.RGBA{0, 0, 0, 255}) || builtin.Width != 2326 {
+		t.Fatalf("theme without file = %s %v %dpx, want dark black 2326px", builtin.Name, builtin.Background, builtin.Width)
+	}
+
+	loadChartTheme(t, chartThemeYAML)
+
+	def := tg_charts.ThemeFor(0)
+	if def.Name != tg_charts.ThemeDark || def.Accent != (color.RGBA{0xf7, 0x93, 0x1a, 255}) || def.Text != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("default theme = %s accent %v text %v, want dark with orange accent", def.Name, def.Accent, def.Text)
+	}
+	if def.Width != 1163 || def.Height != 667 || def.Fonts[0] != "/missing/Custom.ttf" {
+		t.Errorf("default theme canvas %dx%d, first font %q", def.Width, def.Height, def.Fonts[0])
+	}
+
+	branded := tg_charts.ThemeFor(chartThemeBrandedChat)
+	if branded.Name != tg_charts.ThemeLight || branded.Background != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("branded chat theme = %s %v, want light", branded.Name, branded.Background)
+	}
+	if branded.Logo.Path != "/missing/partner.png" || branded.Fonts[0] != "/missing/Partner.ttf" || branded.Fonts[1] != "/missing/Custom.ttf" {
+		t.Errorf("branded chat logo %q, fonts %v", branded.Logo.Path, branded.Fonts[:2])
+	}
+
+	// New theme takes unset colors from dark
+	paper := tg_charts.ThemeFor(-1009876543210)
+	if paper.Background != (color.RGBA{0xfa, 0xfa, 0xf0, 255}) || paper.Bar != (color.RGBA{128, 128, 128, 255}) {
+		t.Errorf("paper theme background %v bar %v", paper.Background, paper.Bar)
+	}
+}
+
+func TestChartTheme_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"bad color":          "themes:\n  dark:\n    text: \"white\"\n",
+		"unknown theme":      "theme: sepia\n",
+		"unknown chat theme": "chats:\n  \"-100\":\n    theme: sepia\n",
+		"bad chat id":        "chats:\n  main:\n    theme: light\n",
+		"canvas height":      "canvas:\n  width: 800\n",
+	}
+	for name, content := range cases {
+		if _, err := tg_charts.LoadThemeFile(writeChartTheme(t, content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestChartTheme_VolumeChart(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	loadChartTheme(t, chartThemeYAML)
+
+	date := time.Now().Format("2006-01-02")
+	if err := luminex.SaveStatsData(&luminex.StatsResponse{TotalVolume24HUSD: 120000, TotalTVLUSD: 500000}, true, date); err != nil {
+		t.Fatalf("SaveStatsData failed: %v", err)
+	}
+
+	for chatID, want := range map[int64]color.RGBA{0: {0, 0, 0, 255}, chartThemeBrandedChat: {255, 255, 255, 255}} {
+		chartPath, err := tg_charts.GenerateVolumeChart(chatID)
+		if err != nil {
+			t.Fatalf("GenerateVolumeChart(%d) failed: %v", chatID, err)
+		}
+		img, err := gg.LoadPNG(chartPath)
+		if err != nil {
+			t.Fatalf("failed to read chart: %v", err)
+		}
+		if size := img.Bounds().Size(); size.X != 1163 || size.Y != 667 {
+			t.Errorf("chart of chat %d is %dx%d, want canvas 1163x667", chatID, size.X, size.Y)
+		}
+		if got := color.RGBAModel.Convert(img.At(2, 2)).(color.RGBA); got != want {
+			t.Errorf("background of chat %d = %v, want %v", chatID, got, want)
+		}
+	}
+}
+
+// Entries out of time order, old date-only entry, failed check (zero reserve) and broken entry
+func TestChartTheme_BTCSparkChart(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	loadChartTheme(t, chartThemeYAML)
+
+	if _, err := tg_charts.GenerateBTCSparkChart(0); err == nil {
+		t.Error("GenerateBTCSparkChart without data expected error")
+	}
+
+	now := time.Now()
+	data := storage.BTCSparkData{Entries: []storage.BTCSparkDataEntry{
+		{Timestamp: now.Format(time.RFC3339), Date: now.Format("2006-01-02"), BtcReserve: 12.4},
+		{Timestamp: now.Add(-72 * time.Hour).Format(time.RFC3339), Date: now.Add(-72 * time.Hour).Format("2006-01-02"), BtcReserve: 10.1},
+		{Date: now.Add(-96 * time.Hour).Format("2006-01-02"), BtcReserve: 9.8},
+		{Timestamp: now.Add(-24 * time.Hour).Format(time.RFC3339), Date: now.Add(-24 * time.Hour).Format("2006-01-02"), BtcReserve: 0},
+		{Timestamp: now.Add(-48 * time.Hour).Format(time.RFC3339), Date: now.Add(-48 * time.Hour).Format("2006-01-02"), BtcReserve: 11.7},
+		{Timestamp: "broken", Date: "broken", BtcReserve: 50},
+	}}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(storage.BTCSparkDataFile()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(storage.BTCSparkDataFile(), raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for chatID, want := range map[int64]color.RGBA{0: {0, 0, 0, 255}, chartThemeBrandedChat: {255, 255, 255, 255}} {
+		chartPath, err := tg_charts.GenerateBTCSparkChart(chatID)
+		if err != nil {
+			t.Fatalf("GenerateBTCSparkChart(%d) failed: %v", chatID, err)
+		}
+		img, err := gg.LoadPNG(chartPath)
+		if err != nil {
+			t.Fatalf("failed to read chart: %v", err)
+		}
+		if size := img.Bounds().Size(); size.X != 1163 || size.Y != 667 {
+			t.Errorf("chart of chat %d is %dx%d, want canvas 1163x667", chatID, size.X, size.Y)
+		}
+		if got := color.RGBAModel.Convert(img.At(2, 2)).(color.RGBA); got != want {
+			t.Errorf("background of chat %d = %v, want %v", chatID, got, want)
+		}
+	}
+}
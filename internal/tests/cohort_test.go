@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/i18n"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/testutil"
+)
+
+const (
+	cohortPool      = "03c0e0000000000000000000000000000000000000000000000000000000000001"
+	cohortOtherPool = "03c0e0000000000000000000000000000000000000000000000000000000000002"
+	cohortToken     = "btkn1cohorttoken"
+)
+
+func TestParseCohortWeek(t *testing.T) {
+	now := time.Date(2025, 12, 10, 15, 0, 0, 0, time.UTC) // Wednesday of 2025-W50
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "2025-12-08"},
+		{"week", "2025-12-08"},
+		{"last", "2025-12-01"},
+		{"2025-W49", "2025-12-01"},
+		{"2025-w01", "2024-12-30"},
+		{"0312", "2025-12-01"},
+		{"2025-11-30", "2025-11-24"},
+	}
+	for _, tt := range tests {
+		got, err := holders.ParseCohortWeek(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseCohortWeek(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got.Format("2006-01-02") != tt.want {
+			t.Errorf("ParseCohortWeek(%q) = %s, want %s", tt.input, got.Format("2006-01-02"), tt.want)
+		}
+	}
+
+	for _, input := range []string{"2025-W51", "2025-W54", "2025-W00", "soon"} {
+		if _, err := holders.ParseCohortWeek(input, now); err == nil {
+			t.Errorf("ParseCohortWeek(%q) succeeded, want error", input)
+		}
+	}
+}
+
+func TestBuildCohortReport_FirstTimeReturningRetention(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	week := time.Date(2025, 12, 8, 12, 0, 0, 0, time.UTC) // Monday of report week
+	now := week.AddDate(0, 0, 2)
+
+	buy := func(id, wallet string, sats int64, at time.Time) flashnet.Swap {
+		return testutil.BuySwap(id, cohortPool, cohortToken, wallet, sats, "1000", at)
+	}
+	swaps := []flashnet.Swap{
+		// Old buyer from 10 weeks ago returns in report week
+		buy("old", "wallet-old", 1000, week.AddDate(0, 0, -70)),
+		buy("old-again", "wallet-old", 2000, week.AddDate(0, 0, 1)),
+		// Buyer of previous week returns, another one does not
+		buy("prev-a", "wallet-a", 1000, week.AddDate(0, 0, -7)),
+		buy("prev-b", "wallet-b", 1000, week.AddDate(0, 0, -6)),
+		buy("prev-a-again", "wallet-a", 3000, week),
+		// New buyer with two buys in report week, sell and other pool are ignored
+		buy("new-1", "wallet-new", 500, week),
+		buy("new-2", "wallet-new", 500, week.AddDate(0, 0, 1)),
+		testutil.SellSwap("sell", cohortPool, cohortToken, "wallet-seller", "1000", 9000, week),
+		testutil.BuySwap("other", cohortOtherPool, "btkn1other", "wallet-other", 9000, "1000", week),
+		// Swap after now is not counted
+		buy("future", "wallet-future", 1000, week.AddDate(0, 0, 4)),
+	}
+	if err := storage.AppendDailySwaps(swaps); err != nil {
+		t.Fatalf("failed to archive swaps: %v", err)
+	}
+
+	report, err := holders.BuildCohortReport(cohortPool, week, now)
+	if err != nil {
+		t.Fatalf("BuildCohortReport failed: %v", err)
+	}
+	if len(report.Weeks) != holders.CohortWeeks {
+		t.Fatalf("%d weeks, want %d", len(report.Weeks), holders.CohortWeeks)
+	}
+
+	current := report.Current()
+	if current.Label() != "2025-W50" {
+		t.Errorf("report week = %s, want 2025-W50", current.Label())
+	}
+	if current.Buyers != 3 || current.FirstTime != 1 || current.Returning != 2 {
+		t.Errorf("report week = %+v, want 3 buyers: 1 first-time, 2 returning", current)
+	}
+	if current.FirstTimeBTC != 0.00001 || current.ReturningBTC != 0.00005 {
+		t.Errorf("volumes = %v first-time, %v returning", current.FirstTimeBTC, current.ReturningBTC)
+	}
+
+	previous := report.Weeks[len(report.Weeks)-2]
+	if previous.Buyers != 2 || previous.FirstTime != 2 || previous.Retained != 1 || previous.RetentionPercent() != 50 {
+		t.Errorf("previous week = %+v, want 2 first-time buyers, 50%% retained", previous)
+	}
+	if holders.FormatCohortReport(report, i18n.English) == "" {
+		t.Errorf("empty cohort report")
+	}
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/features/holders"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/testutil"
+)
+
+// saveSnapshot writes holders snapshot of ticker on date as daily balance check does
+func saveSnapshot(t *testing.T, ticker string, date string, balances map[string]float64) {
+	t.Helper()
+	snapshot := holders.HoldersSnapshot{Date: date, Holders: balances}
+	if err := storage.WriteJSONAtomic(holders.HoldersSnapshotFile(ticker, date), snapshot); err != nil {
+		t.Fatalf("failed to save holders snapshot: %v", err)
+	}
+}
+
+// equalHolders returns count holders with the same balance
+func equalHolders(count int, balance float64) map[string]float64 {
+	balances := make(map[string]float64, count)
+	for i := 0; i < count; i++ {
+		balances[fmt.Sprintf("03e2e%060d", i)] = balance
+	}
+	return balances
+}
+
+func TestComputeConcentration(t *testing.T) {
+	equal := holders.ComputeConcentration(&holders.HoldersSnapshot{Holders: equalHolders(20, 1000)})
+	if equal.Holders != 20 || math.Abs(equal.TopShare-50) > 1e-9 || math.Abs(equal.Gini) > 1e-9 {
+		t.Errorf("equal holders = %+v, want 20 holders, 50%% top share, Gini 0", equal)
+	}
+
+	// Gini of 1 and 3: mean difference 1 / (2 * mean 2), empty balances are skipped
+	pair := holders.ComputeConcentration(&holders.HoldersSnapshot{Holders: map[string]float64{"a": 1, "b": 3, "c": 0}})
+	if pair.Holders != 2 || math.Abs(pair.TopShare-100) > 1e-9 || math.Abs(pair.Gini-0.25) > 1e-9 {
+		t.Errorf("pair = %+v, want 2 holders, 100%% top share, Gini 0.25", pair)
+	}
+
+	if empty := holders.ComputeConcentration(&holders.HoldersSnapshot{}); empty.Holders != 0 || empty.TopShare != 0 || empty.Gini != 0 {
+		t.Errorf("empty snapshot = %+v", empty)
+	}
+}
+
+func TestCheckConcentration_TopSeller(t *testing.T) {
+	newE2EEnv(t)
+	thresholds := holders.ConcentrationThresholds{TopShareRise: 5, GiniRise: 0.05, TopSellerPercent: 20}
+
+	if risk, err := holders.CheckConcentration("DUMP", thresholds); err != nil || risk != nil {
+		t.Fatalf("no snapshots = %+v, %v, want nil", risk, err)
+	}
+
+	// First snapshot is recorded without warning
+	previous := equalHolders(10, 1000)
+	previous[e2eWhaleSwapperKey] = 40000
+	previous[e2eSmallSwapperKey] = 20000
+	saveSnapshot(t, "DUMP", "2026-03-01", previous)
+	risk, err := holders.CheckConcentration("DUMP", thresholds)
+	if err != nil || risk == nil || risk.Previous != nil || risk.Alerting() {
+		t.Fatalf("first snapshot = %+v, %v, want metrics without warning", risk, err)
+	}
+
+	// Largest holder sold half, second sold 10%: concentration falls, only the seller is reported
+	current := equalHolders(10, 1000)
+	current[e2eWhaleSwapperKey] = 20000
+	current[e2eSmallSwapperKey] = 18000
+	saveSnapshot(t, "DUMP", "2026-03-02", current)
+	risk, err = holders.CheckConcentration("DUMP", thresholds)
+	if err != nil {
+		t.Fatalf("CheckConcentration failed: %v", err)
+	}
+	if risk.TopShareRise || risk.GiniRise || !risk.Alerting() || risk.Previous.Date != "2026-03-01" {
+		t.Errorf("risk = %+v, want seller signal against 2026-03-01", risk)
+	}
+	if len(risk.Sellers) != 1 || risk.Sellers[0].Address != e2eWhaleSwapperKey || risk.Sellers[0].Rank != 1 || risk.Sellers[0].SoldPercent != 50 {
+		t.Errorf("sellers = %+v, want whale ranked 1 sold 50%%", risk.Sellers)
+	}
+	if message := bots_monitor.FormatConcentrationMessage(risk); !strings.Contains(message, "Top 5 holders selling:") || !strings.Contains(message, "-50% (40K → 20K)") {
+		t.Errorf("message doesn't show the seller:\n%s", message)
+	}
+
+	history, err := holders.GetConcentrationHistory("dump")
+	if err != nil || len(history) != 2 || history[0].Date != "2026-03-01" || history[1].Date != "2026-03-02" {
+		t.Errorf("history = %+v, %v, want both snapshot dates", history, err)
+	}
+}
+
+func TestConcentrationMonitor_E2E(t *testing.T) {
+	newE2EEnv(t)
+	holders.SetConfiguredTickers([]string{"E2E"})
+	t.Cleanup(func() { holders.SetConfiguredTickers(nil) })
+
+	// One of 20 equal holders grows to 20K: top-10 share 50% -> 74.36%
+	now := time.Now()
+	saveSnapshot(t, "E2E", now.AddDate(0, 0, -1).Format("2006-01-02"), equalHolders(20, 1000))
+	current := equalHolders(20, 1000)
+	current[fmt.Sprintf("03e2e%060d", 0)] = 20000
+	saveSnapshot(t, "E2E", now.Format("2006-01-02"), current)
+
+	telegram := testutil.NewFakeTelegram(t)
+	thresholds := holders.ConcentrationThresholds{TopShareRise: 5, GiniRise: 0.05, TopSellerPercent: 20}
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunConcentrationMonitor(ctx, telegram.Bot, e2eFilteredChatID, thresholds, time.Hour)
+	})
+
+	sent := telegram.WaitForSent(t, 1, e2eMessageTimeout)
+	time.Sleep(e2eSettleAfterAlert)
+	if got := len(telegram.Sent()); got != 1 || sent[0].ChatID != e2eFilteredChatID {
+		t.Fatalf("alerts sent = %+v, want one to filtered chat", telegram.Sent())
+	}
+	text := sent[0].Text
+	for _, want := range []string{"<b>sell pressure</b>: {E2E}", "Top 10 share: 50.00% → 74.36% (+24.36 pp) ⚠️", "Holders: 20 → 20"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("alert doesn't contain %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "selling") {
+		t.Errorf("alert reports sellers without balance drop:\n%s", text)
+	}
+
+	// Warning of the snapshot is sent once
+	risk, err := holders.CheckConcentration("E2E", thresholds)
+	if err != nil || !risk.Alerted {
+		t.Errorf("risk after alert = %+v, %v, want alerted", risk, err)
+	}
+}
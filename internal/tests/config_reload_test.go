@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/infra/config"
+)
+
+func writeConfigFile(t *testing.T, dir string, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, config.File), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func findChange(changes []config.Change, key string) (config.Change, bool) {
+	for _, change := range changes {
+		if change.Key == key {
+			return change, true
+		}
+	}
+	return config.Change{}, false
+}
+
+func TestConfigDiff(t *testing.T) {
+	old := &config.Config{}
+	old.Telegram.BigSalesMinBTCAmount = 0.0025
+	old.Telegram.ApiBotToken = "old-token"
+	old.Telegram.FilteredTokens = []string{"pool-a"}
+
+	next := *old
+	next.Telegram.BigSalesMinBTCAmount = 0.005
+	next.Telegram.ApiBotToken = "new-token"
+	next.Telegram.FilteredTokens = []string{"pool-a", "pool-b"}
+	next.Telegram.Destinations = []config.DestinationConfig{{Name: "vip", BotToken: "dest-token"}}
+
+	changes := config.Diff(old, &next)
+	if len(changes) != 4 {
+		t.Fatalf("changes = %+v, want 4", changes)
+	}
+	if change, ok := findChange(changes, "telegram.big_sales_min_btc_amount"); !ok || change.Old != "0.0025" || change.New != "0.005" {
+		t.Errorf("threshold change = %+v", change)
+	}
+	if change, ok := findChange(changes, "telegram.filtered_tokens"); !ok || change.New != "[pool-a pool-b]" {
+		t.Errorf("filtered tokens change = %+v", change)
+	}
+	// Secrets are masked
+	if change, ok := findChange(changes, "telegram.api_bot_token"); !ok || change.Old != "***" || change.New != "***" {
+		t.Errorf("bot token change = %+v, want masked", change)
+	}
+	if change, ok := findChange(changes, "telegram.destinations"); !ok || change.Old != "" || change.New != "***" {
+		t.Errorf("destinations change = %+v, want masked", change)
+	}
+
+	if changes := config.Diff(old, old); len(changes) != 0 {
+		t.Errorf("changes of same config = %+v", changes)
+	}
+}
+
+func TestConfigReload(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Cleanup(func() { config.SetCurrent(nil) })
+
+	writeConfigFile(t, dir, "app:\n  mode: collector\ntelegram:\n  big_sales_min_btc_amount: 0.003\n")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	config.SetCurrent(cfg)
+
+	// Invalid config keeps current one
+	writeConfigFile(t, dir, "app:\n  mode: unknown\n")
+	if _, _, err := config.Reload(); err == nil {
+		t.Fatal("Reload of invalid config expected error")
+	}
+	if config.Current() != cfg {
+		t.Fatal("invalid config replaced current one")
+	}
+
+	writeConfigFile(t, dir, "app:\n  mode: collector\ntelegram:\n  big_sales_min_btc_amount: 0.004\n  swap_poll_interval: 10\n")
+	previous, changes, err := config.Reload()
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if previous != cfg || config.Current().Telegram.BigSalesMinBTCAmount != 0.004 {
+		t.Fatalf("current config was not replaced: %+v", config.Current().Telegram)
+	}
+	if _, ok := findChange(changes, "telegram.big_sales_min_btc_amount"); !ok || len(changes) != 2 {
+		t.Errorf("changes = %+v, want threshold and swap_poll_interval", changes)
+	}
+}
+
+func TestConfigWatch(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Cleanup(func() { config.SetCurrent(nil) })
+
+	writeConfigFile(t, dir, "app:\n  mode: collector\ntelegram:\n  fast_path_multiplier: 10\n")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	config.SetCurrent(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloaded := make(chan []config.Change, 1)
+	watchDone := make(chan error, 1)
+	go func() {
+		watchDone <- config.Watch(ctx, config.File, func(changes []config.Change, err error) {
+			if err != nil {
+				t.Errorf("reload error: %v", err)
+				return
+			}
+			reloaded <- changes
+		})
+	}()
+
+	// Watcher is set up asynchronously, file is rewritten (slower than reload debounce) until change is seen
+	deadline := time.After(10 * time.Second)
+	tick := time.NewTicker(time.Second)
+	defer tick.Stop()
+	for {
+		select {
+		case changes := <-reloaded:
+			if _, ok := findChange(changes, "telegram.fast_path_multiplier"); !ok {
+				t.Errorf("changes = %+v, want fast_path_multiplier", changes)
+			}
+			if config.Current().Telegram.FastPathMultiplier != 5 {
+				t.Errorf("fast path multiplier = %v, want 5", config.Current().Telegram.FastPathMultiplier)
+			}
+			cancel()
+			if err := <-watchDone; err != nil {
+				t.Errorf("Watch returned error: %v", err)
+			}
+			return
+		case <-tick.C:
+			writeConfigFile(t, dir, "app:\n  mode: collector\ntelegram:\n  fast_path_multiplier: 5\n")
+		case <-deadline:
+			t.Fatal("config was not reloaded after file change")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/features/delivery_audit"
+	"spark-wallet/internal/infra/events"
+	"spark-wallet/internal/testutil"
+)
+
+func TestDeliveryAudit_E2E(t *testing.T) {
+	env := newE2EEnv(t)
+	start := time.Now().Add(-time.Minute)
+	env.flashnet.AddSwaps(
+		testutil.BuySwap("e2e-audit-small", e2ePoolLpPublicKey, e2eTokenAddress, e2eSmallSwapperKey, 100_000, "10000000000", start.Add(time.Second)),
+		testutil.BuySwap("e2e-audit-big", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 2_000_000, "160000000000", start),
+	)
+
+	telegram := testutil.NewFakeTelegram(t)
+	client := env.flashnet.Client()
+	rulesFile := filepath.Join(t.TempDir(), "alert_rules.json")
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunBigSalesBuysMonitor(ctx, telegram.Bot, client, e2eMainChatID, 0.01, nil, "", nil, 0, rulesFile, nil)
+	})
+	sent := telegram.WaitForSent(t, 1, e2eMessageTimeout)
+
+	// Delivery is recorded after queue is flushed
+	var trail *delivery_audit.Trail
+	deadline := time.Now().Add(e2eMessageTimeout)
+	for time.Now().Before(deadline) {
+		var err error
+		trail, err = delivery_audit.Lookup("e2e-audit-big", delivery_audit.LookbackDays, time.Now())
+		if err != nil {
+			t.Fatalf("Lookup failed: %v", err)
+		}
+		if len(trail.Deliveries) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if trail.Swap == nil || trail.Swap.ID != "e2e-audit-big" {
+		t.Fatalf("big swap was not seen: %+v", trail)
+	}
+	if len(trail.Deliveries) != 1 {
+		t.Fatalf("%d deliveries of big swap, want 1: %+v", len(trail.Deliveries), trail.Deliveries)
+	}
+	delivery := trail.Deliveries[0]
+	if delivery.Status != events.DeliverySent || delivery.ChatID != -1001000000001 || delivery.MessageID != sent[0].MessageID {
+		t.Errorf("delivery = %s to %d message %d, want sent to %s message %d",
+			delivery.Status, delivery.ChatID, delivery.MessageID, e2eMainChatID, sent[0].MessageID)
+	}
+	if delivery.Route != "big sales" || delivery.Layout != "default" || delivery.Format != events.DeliveryFormatMessage {
+		t.Errorf("delivery route %q, layout %q, format %q", delivery.Route, delivery.Layout, delivery.Format)
+	}
+	text := delivery_audit.Format(trail, delivery_audit.LookbackDays)
+	for _, want := range []string{"buy 0.02 btc", "Alerts: 1 sent of 1", "https://t.me/c/1000000001/"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("audit has no %q:\n%s", want, text)
+		}
+	}
+
+	// Swap below threshold is seen, but has no alerts
+	small, err := delivery_audit.Lookup("e2e-audit-small", delivery_audit.LookbackDays, time.Now())
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	if small.Swap == nil || len(small.Deliveries) != 0 {
+		t.Errorf("small swap seen %v with %d deliveries, want seen without deliveries", small.Swap != nil, len(small.Deliveries))
+	}
+	if text := delivery_audit.Format(small, delivery_audit.LookbackDays); !strings.Contains(text, "No alerts") {
+		t.Errorf("audit of small swap:\n%s", text)
+	}
+
+	unknown, err := delivery_audit.Lookup("e2e-audit-unknown", delivery_audit.LookbackDays, time.Now())
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	if text := delivery_audit.Format(unknown, delivery_audit.LookbackDays); !strings.Contains(text, "Not seen") {
+		t.Errorf("audit of unknown swap:\n%s", text)
+	}
+}
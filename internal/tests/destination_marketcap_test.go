@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/testutil"
+)
+
+const (
+	e2eLargeCapsChatID = "-1001000000003"
+	e2eSmallCapsChatID = "-1001000000004"
+)
+
+// Token of E2E pool has marketcap $1.25M: destination from $2M skips it, destination up to $2M gets it
+func TestBigSalesMonitor_E2E_DestinationMarketCap(t *testing.T) {
+	env := newE2EEnv(t)
+	start := time.Now().Add(-time.Minute)
+	env.flashnet.AddSwaps(testutil.BuySwap("e2e-marketcap", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 2_000_000, "160000000000", start))
+
+	telegram := testutil.NewFakeTelegram(t)
+	destinations := []bots_monitor.SwapDestination{
+		{Name: "large caps", Bot: telegram.Bot, ChatID: e2eLargeCapsChatID, MinBTC: 0.01, MinMarketCapUSD: 2_000_000},
+		{Name: "small caps", Bot: telegram.Bot, ChatID: e2eSmallCapsChatID, MinBTC: 0.01, MaxMarketCapUSD: 2_000_000},
+	}
+	client := env.flashnet.Client()
+	rulesFile := filepath.Join(t.TempDir(), "alert_rules.json")
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunBigSalesBuysMonitor(ctx, nil, client, "", 0, nil, "", nil, 0, rulesFile, destinations)
+	})
+
+	sent := telegram.WaitForSent(t, 1, e2eMessageTimeout)
+	time.Sleep(e2eSettleAfterAlert)
+	if got := len(telegram.Sent()); got != 1 || sent[0].ChatID != e2eSmallCapsChatID {
+		t.Errorf("alerts sent to %+v, want only small caps destination", telegram.Sent())
+	}
+}
+
+// Big sales chat up to $1M skips the $1.25M token, filtered chat from $1M gets it
+func TestBigSalesMonitor_E2E_ChatMarketCap(t *testing.T) {
+	env := newE2EEnv(t)
+	start := time.Now().Add(-time.Minute)
+	env.flashnet.AddSwaps(testutil.BuySwap("e2e-chat-marketcap", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 2_000_000, "160000000000", start))
+
+	bots_monitor.SetChatMarketCapRanges(bots_monitor.ChatMarketCapRanges{BigSalesMaxUSD: 1_000_000, FilteredMinUSD: 1_000_000})
+	t.Cleanup(func() { bots_monitor.SetChatMarketCapRanges(bots_monitor.ChatMarketCapRanges{}) })
+
+	telegram := testutil.NewFakeTelegram(t)
+	client := env.flashnet.Client()
+	rulesFile := filepath.Join(t.TempDir(), "alert_rules.json")
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunBigSalesBuysMonitor(ctx, telegram.Bot, client, e2eMainChatID, 0.01, telegram.Bot, e2eFilteredChatID, []string{e2ePoolLpPublicKey}, 0.01, rulesFile, nil)
+	})
+
+	sent := telegram.WaitForSent(t, 1, e2eMessageTimeout)
+	time.Sleep(e2eSettleAfterAlert)
+	if got := len(telegram.Sent()); got != 1 || sent[0].ChatID != e2eFilteredChatID {
+		t.Errorf("alerts sent to %+v, want only filtered chat", telegram.Sent())
+	}
+}
+
+func TestSwapDestination_MarketCap(t *testing.T) {
+	newE2EEnv(t)
+	swap := testutil.BuySwap("marketcap-match", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 2_000_000, "160000000000", time.Now())
+
+	for _, tc := range []struct {
+		name     string
+		min, max float64
+		want     bool
+	}{
+		{"no bounds", 0, 0, true},
+		{"above min", 1_000_000, 0, true},
+		{"below min", 1_500_000, 0, false},
+		{"below max", 0, 1_500_000, true},
+		{"above max", 0, 1_000_000, false},
+		{"within range", 1_000_000, 1_500_000, true},
+	} {
+		destination := bots_monitor.SwapDestination{MinBTC: 0.01, MinMarketCapUSD: tc.min, MaxMarketCapUSD: tc.max}
+		if got := destination.Matches(swap); got != tc.want {
+			t.Errorf("%s: Matches = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	// Unknown marketcap (pool not in Luminex) is skipped only when a bound is set
+	unknown := testutil.BuySwap("marketcap-unknown", "03e2e00000000000000000000000000000000000000000000000000000000000ff", e2eTokenAddress, e2eWhaleSwapperKey, 2_000_000, "160000000000", time.Now())
+	if (&bots_monitor.SwapDestination{MinBTC: 0.01, MaxMarketCapUSD: 1_500_000}).Matches(unknown) {
+		t.Error("swap of token with unknown marketcap matches destination with max marketcap")
+	}
+	if !(&bots_monitor.SwapDestination{MinBTC: 0.01}).Matches(unknown) {
+		t.Error("swap of token with unknown marketcap doesn't match destination without bounds")
+	}
+}
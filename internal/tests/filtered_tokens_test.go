@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"testing"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+func TestFilteredTokenAssets(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+
+	const (
+		soonPool  = "021cda97a28df127f41e480ebede196f6f7d46dd6754feab7c228d8273dce6d39e"
+		soonAsset = "btkn1soonasset"
+		otherPool = "03a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+	)
+
+	for _, pool := range []string{soonPool, otherPool} {
+		if err := storage.AddFilteredToken(pool); err != nil {
+			t.Fatalf("AddFilteredToken failed: %v", err)
+		}
+	}
+	if err := storage.SetFilteredTokenAsset(soonPool, soonAsset); err != nil {
+		t.Fatalf("SetFilteredTokenAsset failed: %v", err)
+	}
+
+	// Saving pools (admin API, config migration) keeps asset of pool that stays
+	if err := storage.SaveFilteredTokens([]string{soonPool, otherPool}); err != nil {
+		t.Fatalf("SaveFilteredTokens failed: %v", err)
+	}
+	assets, err := storage.LoadFilteredAssets()
+	if err != nil || assets[soonAsset] != soonPool {
+		t.Fatalf("LoadFilteredAssets = %v, %v, want %s -> %s", assets, err, soonAsset, soonPool)
+	}
+
+	// Removing pool removes its asset
+	if err := storage.RemoveFilteredToken(soonPool); err != nil {
+		t.Fatalf("RemoveFilteredToken failed: %v", err)
+	}
+	assets, err = storage.LoadFilteredAssets()
+	if err != nil || len(assets) != 0 {
+		t.Errorf("LoadFilteredAssets after remove = %v, %v, want empty", assets, err)
+	}
+	tokens, err := storage.LoadFilteredTokens()
+	if err != nil || len(tokens) != 1 || tokens[0] != otherPool {
+		t.Errorf("LoadFilteredTokens = %v, %v, want [%s]", tokens, err, otherPool)
+	}
+}
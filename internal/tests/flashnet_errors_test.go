@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/infra/cloudflare"
+)
+
+// newErrorTestClient returns Flashnet client (no retries) of test server answering status with body
+func newErrorTestClient(t *testing.T, status int, contentType string, body string) *flashnet.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "7")
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client := flashnet.NewAMMClient("mainnet")
+	client.SetBaseURL(server.URL)
+	client.SetRetry(0, time.Millisecond, time.Millisecond, 1)
+	return client
+}
+
+func TestFlashnetErrors_Taxonomy(t *testing.T) {
+	ctx := context.Background()
+
+	client := newErrorTestClient(t, http.StatusUnauthorized, "application/json", `{"error":"invalid token"}`)
+	_, err := client.GetSwaps(ctx, flashnet.GetSwapsOptions{})
+	if !errors.Is(err, flashnet.ErrUnauthorized) || errors.Is(err, flashnet.ErrRateLimited) {
+		t.Errorf("401: err = %v, want ErrUnauthorized only", err)
+	}
+
+	client = newErrorTestClient(t, http.StatusTooManyRequests, "application/json", `{"error":"slow down"}`)
+	_, err = client.MakeRequest(ctx, "GET", "/swaps", nil)
+	if !errors.Is(err, flashnet.ErrRateLimited) {
+		t.Errorf("429: err = %v, want ErrRateLimited", err)
+	}
+	if wait := flashnet.RetryAfter(err); wait != 7*time.Second {
+		t.Errorf("RetryAfter = %v, want 7s", wait)
+	}
+
+	// Auth methods wrap APIError with code of response
+	client = newErrorTestClient(t, http.StatusBadRequest, "application/json", `{"errorCode":"FSAG-4102","message":"Session exists"}`)
+	_, err = client.VerifySignature(ctx, "02aa", "sig")
+	var apiErr *flashnet.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != flashnet.CodeAlreadySignedIn {
+		t.Fatalf("verify: err = %v, want APIError 400 %s", err, flashnet.CodeAlreadySignedIn)
+	}
+	if !flashnet.IsAlreadySignedInError(err) || errors.Is(err, flashnet.ErrUnauthorized) {
+		t.Errorf("verify: err = %v, want ErrAlreadySignedIn", err)
+	}
+	if flashnet.IsAlreadySignedInError(errors.New("API error (400): bad signature")) {
+		t.Errorf("plain error matched ErrAlreadySignedIn")
+	}
+
+	client = newErrorTestClient(t, http.StatusForbidden, "text/html", `<title>Just a moment...</title>`)
+	_, err = client.MakeRequest(ctx, "GET", "/swaps", nil)
+	if !errors.Is(err, flashnet.ErrCloudflareBlocked) || !errors.Is(err, cloudflare.ErrBlocked) {
+		t.Errorf("Cloudflare: err = %v, want ErrCloudflareBlocked", err)
+	}
+}
+
+func TestFlashnetErrors_UnauthorizedHandler(t *testing.T) {
+	client := newErrorTestClient(t, http.StatusUnauthorized, "application/json", `{"error":"jwt expired"}`)
+	var calls int32
+	var rejected atomic.Value
+	client.SetUnauthorizedHandler(func(token string) {
+		atomic.AddInt32(&calls, 1)
+		rejected.Store(token)
+	})
+
+	// Request without JWT and auth endpoints don't trigger renewal
+	client.MakeRequest(context.Background(), "GET", "/swaps", nil)
+	client.SetJWT("expired")
+	client.MakeRequest(context.Background(), "POST", "/auth/verify", nil)
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Fatalf("handler called %d times, want 0", got)
+	}
+
+	client.MakeRequest(context.Background(), "GET", "/swaps", nil)
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("handler called %d times, want 1", got)
+	}
+	// Handler gets token of rejected request, so tokens replaced meanwhile are not renewed again
+	if got, _ := rejected.Load().(string); got != "expired" {
+		t.Errorf("rejected token = %q, want %q", got, "expired")
+	}
+}
@@ -5,9 +5,7 @@ package tests
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
@@ -22,25 +20,6 @@ type localTokenFile struct {
 	PublicKey   string `json:"publicKey"`
 }
 
-// findRepoRoot walks up from current working dir until it finds go.mod.
-func findRepoRoot() (string, error) {
-	dir, err := os.Getwd()
-	if err != nil {
-		return "", err
-	}
-	for i := 0; i < 10; i++ {
-		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
-			return dir, nil
-		}
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			break
-		}
-		dir = parent
-	}
-	return "", fmt.Errorf("repo root not found (go.mod)")
-}
-
 // loadLocalTokenFile is a helper for integration test only.
 func loadLocalTokenFile(dataDir string) (*localTokenFile, error) {
 	b, err := os.ReadFile(filepath.Join(dataDir, "token.json"))
@@ -71,7 +50,7 @@ func tokenValid(tf *localTokenFile) bool {
 
 // TestIntegration_Flashnet_ChallengeVerifySwaps:
 // - Always checks /auth/challenge endpoint
-// - Ensures valid token: uses existing token.json if valid, otherwise refreshes using spark-cli sign script + /auth/verify
+// - Ensures valid token: uses existing token.json if valid, otherwise refreshes using native challenge signing + /auth/verify
 // - Calls /swaps with JWT
 func TestIntegration_Flashnet_ChallengeVerifySwaps(t *testing.T) {
 	dataDir := "data_in"
@@ -123,28 +102,16 @@ func TestIntegration_Flashnet_ChallengeVerifySwaps(t *testing.T) {
 			t.Fatalf("GetChallengeAndSave failed: %v", err)
 		}
 
-		// Run spark-cli signer to produce signature.json for current challenge.json
-		if _, err := exec.LookPath("node"); err != nil {
-			t.Skip("node not found in PATH; cannot run spark-cli sign script")
-		}
-		root, err := findRepoRoot()
-		if err != nil {
-			t.Fatalf("findRepoRoot failed: %v", err)
-		}
-
-		signCtx, signCancel := context.WithTimeout(ctx, 60*time.Second)
-		defer signCancel()
-
-		cmd := exec.CommandContext(signCtx, "node", filepath.Join("spark-cli", "sign-challenge.mjs"))
-		cmd.Dir = root
-		out, err := cmd.CombinedOutput()
+		// Sign challenge.json natively with PRIVATE_KEY / FLASHNET_KEYSTORE
+		signer, err := flashnet.LoadSigner(os.Getenv("PRIVATE_KEY"), os.Getenv("FLASHNET_KEYSTORE"))
 		if err != nil {
-			t.Fatalf("sign-challenge.mjs failed: %v\noutput:\n%s", err, string(out))
+			t.Skipf("signing key not configured; cannot refresh token: %v", err)
 		}
+		c.SetSigner(signer)
 
-		sig, err := flashnet.LoadSignatureFromFile(dataDir)
+		sig, err := c.SignChallengeAndSave(dataDir)
 		if err != nil {
-			t.Fatalf("LoadSignatureFromFile failed: %v", err)
+			t.Fatalf("SignChallengeAndSave failed: %v", err)
 		}
 		if sig.Signature == "" {
 			t.Fatalf("signature.json has empty signature after signing")
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+)
+
+// newRetryTestClient returns Flashnet client of test server that answers statuses in order (200 after the last one)
+func newRetryTestClient(t *testing.T, maxRetries int, statuses ...int) (*flashnet.Client, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&calls, 1))
+		w.Header().Set("Content-Type", "application/json")
+		if call <= len(statuses) {
+			status := statuses[call-1]
+			if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+				w.Header().Set("Retry-After", "1")
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error":"try later"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := flashnet.NewAMMClient("mainnet")
+	client.SetBaseURL(server.URL)
+	// Retry-After of 1s is capped by max delay, test doesn't wait
+	client.SetRetry(maxRetries, time.Millisecond, 10*time.Millisecond, 2.0)
+	return client, &calls
+}
+
+func TestFlashnetRetry_RetryableStatuses(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable} {
+		client, calls := newRetryTestClient(t, 3, status, status)
+		body, err := client.MakeRequest(context.Background(), "GET", "/swaps", nil)
+		if err != nil {
+			t.Fatalf("status %d: MakeRequest failed: %v", status, err)
+		}
+		if string(body) != `{"ok":true}` {
+			t.Errorf("status %d: body = %s", status, body)
+		}
+		if got := atomic.LoadInt32(calls); got != 3 {
+			t.Errorf("status %d: %d calls, want 3", status, got)
+		}
+	}
+}
+
+func TestFlashnetRetry_NotRetryable(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError} {
+		client, calls := newRetryTestClient(t, 3, status)
+		_, err := client.MakeRequest(context.Background(), "GET", "/swaps", nil)
+		var apiErr *flashnet.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != status {
+			t.Fatalf("status %d: err = %v, want APIError", status, err)
+		}
+		if got := atomic.LoadInt32(calls); got != 1 {
+			t.Errorf("status %d: %d calls, want 1", status, got)
+		}
+	}
+}
+
+func TestFlashnetRetry_Budget(t *testing.T) {
+	client, calls := newRetryTestClient(t, 2, 429, 429, 429, 429)
+	_, err := client.MakeRequest(context.Background(), "GET", "/swaps", nil)
+	var apiErr *flashnet.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("err = %v, want 429 APIError", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("%d calls, want 3 (1 + 2 retries)", got)
+	}
+
+	// Per-request budget overrides client setting
+	client, calls = newRetryTestClient(t, 3, 502)
+	noRetries := 0
+	if _, err := client.MakeRequestWithOptions(context.Background(), "GET", "/swaps", nil, flashnet.RequestOptions{MaxRetries: &noRetries}); err == nil {
+		t.Fatal("expected error without retries")
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("%d calls, want 1", got)
+	}
+}
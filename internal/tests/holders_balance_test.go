@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/testutil"
+)
+
+const (
+	balanceTicker       = "BAL"
+	balanceInvestedKey  = "02ba1000000000000000000000000000000000000000000000000000000000000a"
+	balanceSoldOutKey   = "02ba1000000000000000000000000000000000000000000000000000000000000b"
+	balanceUnchangedKey = "02ba1000000000000000000000000000000000000000000000000000000000000c"
+	balanceFailingKey   = "02ba1000000000000000000000000000000000000000000000000000000000000d"
+)
+
+// newBalanceCheckEnv - tracked BAL ticker, mock Luminex and fast balance fetch with one retry round
+func newBalanceCheckEnv(t *testing.T, saved map[string]string) *testutil.LuminexServer {
+	t.Helper()
+	paths.Configure(t.TempDir(), t.TempDir())
+	holders.SetConfiguredTickers([]string{balanceTicker})
+	t.Cleanup(func() { holders.SetConfiguredTickers(nil) })
+	holders.SetBalanceFetchConfig(holders.BalanceFetchConfig{Workers: 3, Rate: 1000, Retries: 1, RetryDelay: 10 * time.Millisecond})
+	t.Cleanup(func() { holders.SetBalanceFetchConfig(holders.DefaultBalanceFetchConfig()) })
+
+	if err := holders.SaveSavedHolders(balanceTicker, &holders.SavedHoldersData{Holders: saved}); err != nil {
+		t.Fatalf("SaveSavedHolders failed: %v", err)
+	}
+	return testutil.NewLuminexServer(t)
+}
+
+func balanceWallet(rawBalance string) luminex.WalletBalanceResponse {
+	return luminex.WalletBalanceResponse{Tokens: []luminex.WalletToken{{Ticker: balanceTicker, Decimals: 8, Balance: rawBalance}}}
+}
+
+func TestCheckHoldersBalance_PartialFailure(t *testing.T) {
+	server := newBalanceCheckEnv(t, map[string]string{
+		balanceInvestedKey:  "100.00000000",
+		balanceSoldOutKey:   "200.00000000",
+		balanceUnchangedKey: "50.00000000",
+		balanceFailingKey:   "75.00000000",
+	})
+	server.SetWallet(balanceInvestedKey, balanceWallet("15000000000"))
+	server.SetWallet(balanceSoldOutKey, luminex.WalletBalanceResponse{})
+	server.SetWallet(balanceUnchangedKey, balanceWallet("5000000000"))
+	// balanceFailingKey is answered with 404
+
+	if err := holders.CheckHoldersBalanceWithForce(balanceTicker, "", true); err != nil {
+		t.Fatalf("check with one failed wallet should succeed: %v", err)
+	}
+
+	// Failed wallet is requested once more in retry round, others once
+	if got := server.Requests("/spark/address/" + balanceFailingKey); got != 2 {
+		t.Errorf("failed wallet requests = %d, want 2", got)
+	}
+	if got := server.Requests("/spark/address/" + balanceInvestedKey); got != 1 {
+		t.Errorf("fetched wallet requests = %d, want 1", got)
+	}
+
+	saved, err := holders.LoadSavedHolders(balanceTicker)
+	if err != nil {
+		t.Fatalf("LoadSavedHolders failed: %v", err)
+	}
+	want := map[string]string{
+		balanceInvestedKey:  "150.00000000",
+		balanceUnchangedKey: "50.00000000",
+		balanceFailingKey:   "75.00000000", // kept until next check
+	}
+	if len(saved.Holders) != len(want) {
+		t.Errorf("saved holders = %v, want %v", saved.Holders, want)
+	}
+	for wallet, balance := range want {
+		if saved.Holders[wallet] != balance {
+			t.Errorf("saved balance of %s = %q, want %q", wallet[len(wallet)-1:], saved.Holders[wallet], balance)
+		}
+	}
+
+	dynamic, err := holders.LoadDynamicHolders(balanceTicker)
+	if err != nil {
+		t.Fatalf("LoadDynamicHolders failed: %v", err)
+	}
+	actions := map[string]string{balanceInvestedKey: "invested", balanceSoldOutKey: "liquidated"}
+	if len(dynamic.Changes) != len(actions) {
+		t.Errorf("changes of %d wallets, want %d", len(dynamic.Changes), len(actions))
+	}
+	for wallet, action := range actions {
+		changes := dynamic.Changes[wallet]
+		if len(changes) != 1 || changes[0].Action != action {
+			t.Errorf("changes of %s = %+v, want one %s", wallet[len(wallet)-1:], changes, action)
+		}
+	}
+}
+
+func TestCheckHoldersBalance_AllFailed(t *testing.T) {
+	newBalanceCheckEnv(t, map[string]string{balanceFailingKey: "75.00000000"})
+
+	if err := holders.CheckHoldersBalanceWithForce(balanceTicker, "", true); err == nil {
+		t.Fatal("check without any fetched balance should fail to be retried")
+	}
+
+	saved, err := holders.LoadSavedHolders(balanceTicker)
+	if err != nil {
+		t.Fatalf("LoadSavedHolders failed: %v", err)
+	}
+	if saved.Holders[balanceFailingKey] != "75.00000000" {
+		t.Errorf("saved balance = %q, want unchanged", saved.Holders[balanceFailingKey])
+	}
+}
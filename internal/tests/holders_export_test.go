@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/infra/paths"
+)
+
+func TestParseExportRange(t *testing.T) {
+	now := time.Date(2025, time.January, 3, 15, 4, 0, 0, time.UTC)
+
+	cases := []struct {
+		args     []string
+		from, to string
+	}{
+		{[]string{"0112", "0712"}, "2024-12-01", "2024-12-07"},
+		// Start without year is taken before end, range spans new year
+		{[]string{"2012", "0201"}, "2024-12-20", "2025-01-02"},
+		{[]string{"0812"}, "2024-12-08", "2024-12-08"},
+		{[]string{"0112-0712"}, "2024-12-01", "2024-12-07"},
+		{[]string{"week"}, "2024-12-28", "2025-01-03"},
+	}
+	for _, tc := range cases {
+		from, to, err := holders.ParseExportRange(tc.args, now)
+		if err != nil {
+			t.Errorf("ParseExportRange(%q) failed: %v", tc.args, err)
+			continue
+		}
+		if from.Format("2006-01-02") != tc.from || to.Format("2006-01-02") != tc.to {
+			t.Errorf("ParseExportRange(%q) = %s..%s, want %s..%s", tc.args,
+				from.Format("2006-01-02"), to.Format("2006-01-02"), tc.from, tc.to)
+		}
+	}
+
+	for _, args := range [][]string{nil, {"ab"}, {"0112", "xx"}, {"2025-01-02", "2025-01-01"}, {"0112", "0212", "0312"}} {
+		if _, _, err := holders.ParseExportRange(args, now); err == nil {
+			t.Errorf("ParseExportRange(%q) expected error", args)
+		}
+	}
+}
+
+func TestWriteHolderChangesCSV(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+
+	data := &holders.DynamicHoldersData{
+		Changes: map[string][]holders.BalanceChange{
+			"02bbb": {
+				{Amount: 1500000, Delta: 1500000, Action: "invested", Value: 0.0125, Date: "2024-12-02"},
+				{Amount: 0, Delta: -1500000, Action: "liquidated", Value: 0.013, Date: "2024-12-09"},
+			},
+			"02aaa": {
+				{Amount: 250.5, Delta: -100, Action: "sold", Value: 0.00000123, Date: "2024-12-02", CatchUp: true, Since: "2024-11-30"},
+				{Amount: 10, Delta: 10, Action: "invested", Value: 0.0001, Date: "2024-11-30"},
+			},
+		},
+	}
+	from := time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.December, 7, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	rows, err := holders.WriteHolderChangesCSV(&buf, data, from, to, map[string]string{"02bbb": "Иван, trader"})
+	if err != nil {
+		t.Fatalf("WriteHolderChangesCSV failed: %v", err)
+	}
+	if rows != 2 {
+		t.Errorf("rows = %d, want 2", rows)
+	}
+
+	want := "\ufeff" +
+		"date,wallet,username,label,action,amount,delta,value_btc,catch_up,since\n" +
+		"2024-12-02,02aaa,,,sold,250.5,-100,0.00000123,true,2024-11-30\n" +
+		"2024-12-02,02bbb,\"Иван, trader\",,invested,1500000,1500000,0.01250000,false,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("CSV mismatch\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+	if holders.ExportFileName("soon", from, to) != "SOON_holders_2024-12-01_2024-12-07.csv" {
+		t.Errorf("ExportFileName = %q", holders.ExportFileName("soon", from, to))
+	}
+}
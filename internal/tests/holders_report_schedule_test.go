@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/infra/paths"
+)
+
+func TestHoldersReportDateAfterCheck(t *testing.T) {
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.Local)
+	checkedAt := now.Add(-time.Hour)
+
+	if date, due := holders.ReportDateAfterCheck(checkedAt, "", now); !due || date != "2025-12-10" {
+		t.Errorf("report after check = %q, %v, want 2025-12-10 due", date, due)
+	}
+	// Day is reported once
+	if _, due := holders.ReportDateAfterCheck(checkedAt, "2025-12-10", now); due {
+		t.Error("report of posted day is due again")
+	}
+	if date, due := holders.ReportDateAfterCheck(checkedAt, "2025-12-09", now); !due || date != "2025-12-10" {
+		t.Errorf("report after previous day = %q, %v, want 2025-12-10 due", date, due)
+	}
+	// No check yet or check older than a day (first start with old check file)
+	if _, due := holders.ReportDateAfterCheck(time.Time{}, "", now); due {
+		t.Error("report is due without check")
+	}
+	if _, due := holders.ReportDateAfterCheck(now.Add(-25*time.Hour), "", now); due {
+		t.Error("report of old check is due")
+	}
+}
+
+func TestHoldersReportPostedAndChanges(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	holders.SetConfiguredTickers([]string{"REP"})
+	t.Cleanup(func() { holders.SetConfiguredTickers(nil) })
+
+	if date, err := holders.GetLastPostedReport("REP"); err != nil || date != "" {
+		t.Fatalf("last posted report without file = %q, %v", date, err)
+	}
+	if err := holders.RecordPostedReport("rep", "2025-12-10"); err != nil {
+		t.Fatalf("RecordPostedReport failed: %v", err)
+	}
+	if date, err := holders.GetLastPostedReport("REP"); err != nil || date != "2025-12-10" {
+		t.Errorf("last posted report = %q, %v, want 2025-12-10", date, err)
+	}
+
+	dynamic := holders.DynamicHoldersData{Changes: map[string][]holders.BalanceChange{
+		"wallet-a": {{Date: "2025-12-09", Action: "invested"}, {Date: "2025-12-10", Action: "sold"}, {Date: "2025-12-10", Action: "sold"}},
+		"wallet-b": {{Date: "2025-12-10", Action: "invested"}},
+		"wallet-c": {{Date: "2025-12-08", Action: "liquidated"}},
+	}}
+	raw, err := json.Marshal(dynamic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(holders.DynamicHoldersFile("REP")), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(holders.DynamicHoldersFile("REP"), raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for date, want := range map[string]int{"2025-12-10": 2, "2025-12-09": 1, "2025-12-11": 0} {
+		if got, err := holders.CountHoldersChanges("REP", date); err != nil || got != want {
+			t.Errorf("holders changed on %s = %d, %v, want %d", date, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/testutil"
+)
+
+const e2eDeltaSwapperKey = "02e2e00000000000000000000000000000000000000000000000000000000000a4"
+
+func TestHolders_ParseDetectionMode(t *testing.T) {
+	for input, want := range map[string]holders.DetectionMode{"": holders.DetectionModeAPI, "api": holders.DetectionModeAPI, " Swap_Delta ": holders.DetectionModeSwapDelta} {
+		if mode, err := holders.ParseDetectionMode(input); err != nil || mode != want {
+			t.Errorf("ParseDetectionMode(%q) = %q, %v, want %q", input, mode, err, want)
+		}
+	}
+	if _, err := holders.ParseDetectionMode("snapshot"); err == nil {
+		t.Error("ParseDetectionMode(snapshot) expected error")
+	}
+}
+
+func TestHolders_SwapTokenDelta(t *testing.T) {
+	newE2EEnv(t)
+	at := time.Now()
+
+	cases := map[string]struct {
+		swap flashnet.Swap
+		want float64
+	}{
+		"buy":  {testutil.BuySwap("delta-buy", e2ePoolLpPublicKey, e2eTokenAddress, e2eDeltaSwapperKey, 2_000_000, "160000000000", at), 1600},
+		"sell": {testutil.SellSwap("delta-sell", e2ePoolLpPublicKey, e2eTokenAddress, e2eDeltaSwapperKey, "80000000000", 1_500_000, at), -800},
+	}
+	for name, tc := range cases {
+		delta, err := holders.SwapTokenDelta(tc.swap, "E2E")
+		if err != nil || math.Abs(delta-tc.want) > 1e-9 {
+			t.Errorf("%s delta = %v, %v, want %v", name, delta, err, tc.want)
+		}
+	}
+}
+
+// waitSavedHolder waits until saved balance of wallet is want
+func waitSavedHolder(t *testing.T, ticker, wallet, want string) {
+	t.Helper()
+	deadline := time.Now().Add(e2eMessageTimeout)
+	var got string
+	for time.Now().Before(deadline) {
+		saved, err := holders.LoadSavedHolders(ticker)
+		if err != nil {
+			t.Fatalf("LoadSavedHolders failed: %v", err)
+		}
+		if got = saved.Holders[wallet]; got == want {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("saved balance of wallet = %q, want %q", got, want)
+}
+
+func TestBigSalesMonitor_E2E_SwapDeltaHolders(t *testing.T) {
+	env := newE2EEnv(t)
+	holders.SetConfiguredTickers([]string{"E2E"})
+	t.Cleanup(func() { holders.SetConfiguredTickers(nil) })
+	holders.SetDetectionMode(holders.DetectionModeSwapDelta)
+	t.Cleanup(func() { holders.SetDetectionMode(holders.DetectionModeAPI) })
+
+	// Wallet holds more than it bought in seen swaps, only daily check sees it
+	env.luminex.SetWallet(e2eDeltaSwapperKey, luminex.WalletBalanceResponse{
+		Tokens: []luminex.WalletToken{{Ticker: "E2E", TokenAddress: e2eTokenAddress, Decimals: 8, Balance: "500000000000"}},
+	})
+	start := time.Now().Add(-time.Minute)
+	env.flashnet.AddSwaps(testutil.BuySwap("e2e-delta-buy", e2ePoolLpPublicKey, e2eTokenAddress, e2eDeltaSwapperKey, 2_000_000, "160000000000", start))
+
+	telegram := testutil.NewFakeTelegram(t)
+	rulesFile := filepath.Join(t.TempDir(), "alert_rules.json")
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunBigSalesBuysMonitor(ctx, telegram.Bot, env.flashnet.Client(), e2eMainChatID, 0.01, nil, "", nil, 0, rulesFile, nil)
+	})
+
+	telegram.WaitForSent(t, 1, e2eMessageTimeout)
+	waitSavedHolder(t, "E2E", e2eDeltaSwapperKey, "1600.00000000")
+
+	env.flashnet.AddSwaps(testutil.SellSwap("e2e-delta-sell", e2ePoolLpPublicKey, e2eTokenAddress, e2eDeltaSwapperKey, "80000000000", 1_500_000, start.Add(time.Second)))
+	telegram.WaitForSent(t, 2, e2eMessageTimeout)
+	waitSavedHolder(t, "E2E", e2eDeltaSwapperKey, "800.00000000")
+
+	// dynamic_holders.json is written right after saved_holders.json
+	var changes []holders.BalanceChange
+	for deadline := time.Now().Add(e2eMessageTimeout); len(changes) < 2 && time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		dynamic, err := holders.LoadDynamicHolders("E2E")
+		if err != nil {
+			t.Fatalf("LoadDynamicHolders failed: %v", err)
+		}
+		changes = dynamic.Changes[e2eDeltaSwapperKey]
+	}
+	if len(changes) != 2 || changes[0].Action != "invested" || changes[0].Delta != 1600 || changes[1].Action != "sold" || changes[1].Delta != -800 {
+		t.Fatalf("changes from swaps = %+v, want invested 1600 and sold -800", changes)
+	}
+
+	// Daily check reconciles balance with wallet
+	if err := holders.CheckHoldersBalanceWithForce("E2E", e2eTokenAddress, true); err != nil {
+		t.Fatalf("holders check failed: %v", err)
+	}
+	waitSavedHolder(t, "E2E", e2eDeltaSwapperKey, "5000.00000000")
+}
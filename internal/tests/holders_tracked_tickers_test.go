@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"os"
+	"testing"
+
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/infra/paths"
+)
+
+func TestTrackedTickers_InMemory(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+
+	if holders.IsTickerAllowed("TRK") {
+		t.Fatal("ticker allowed before /holdersadd")
+	}
+	added, err := holders.AddTrackedTicker("trk")
+	if err != nil || !added {
+		t.Fatalf("AddTrackedTicker = %v, %v", added, err)
+	}
+	if !holders.IsTickerAllowed("TRK") {
+		t.Error("added ticker is not allowed")
+	}
+	if added, err := holders.AddTrackedTicker("TRK"); err != nil || added {
+		t.Errorf("second AddTrackedTicker = %v, %v, want already tracked", added, err)
+	}
+
+	// File edited by hand: list in memory is kept until reload
+	if err := os.WriteFile(holders.TrackedTickersFile(), []byte(`{"tickers": ["TRK", "HAND"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if holders.IsTickerAllowed("HAND") {
+		t.Error("ticker of edited file allowed before reload")
+	}
+	if err := holders.ReloadTrackedTickers(); err != nil {
+		t.Fatalf("ReloadTrackedTickers failed: %v", err)
+	}
+	if !holders.IsTickerAllowed("HAND") {
+		t.Error("ticker of edited file not allowed after reload")
+	}
+
+	// Other output directory has its own tracked tickers
+	paths.Configure(t.TempDir(), t.TempDir())
+	if holders.IsTickerAllowed("TRK") {
+		t.Error("ticker of previous output directory is allowed")
+	}
+}
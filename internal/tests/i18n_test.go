@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/infra/paths"
+)
+
+func TestI18n_CatalogsAndFallback(t *testing.T) {
+	for _, lang := range i18n.Languages {
+		if missing := i18n.MissingKeys(lang); len(missing) > 0 {
+			t.Errorf("catalog %s has no translation of %v", lang, missing)
+		}
+	}
+
+	if got := i18n.T(i18n.Russian, "swap.combined", 3); got != "📦 <b>Свапов: 3</b>" {
+		t.Errorf("T(ru, swap.combined) = %q", got)
+	}
+	if got := i18n.T("de", "swap.buy"); got != "Buy" {
+		t.Errorf("unknown language = %q, want English fallback", got)
+	}
+	if got := i18n.T(i18n.English, "no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key = %q, want key itself", got)
+	}
+
+	for input, want := range map[string]i18n.Lang{"en": i18n.English, " RU ": i18n.Russian, "русский": i18n.Russian} {
+		if lang, err := i18n.ParseLang(input); err != nil || lang != want {
+			t.Errorf("ParseLang(%q) = %q, %v, want %q", input, lang, err, want)
+		}
+	}
+	if _, err := i18n.ParseLang("de"); err == nil || !strings.Contains(err.Error(), "en, ru") {
+		t.Errorf("ParseLang(de) error = %v, want list of supported languages", err)
+	}
+}
+
+func TestI18n_ChatLanguage(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	i18n.SetDefault(i18n.English)
+	i18n.Configure(map[int64]i18n.Lang{-1001: i18n.Russian})
+	t.Cleanup(func() { i18n.Configure(map[int64]i18n.Lang{}) })
+
+	if got := i18n.ChatLang(-1001); got != i18n.Russian {
+		t.Errorf("configured chat = %q, want ru", got)
+	}
+	if got := i18n.ChatLang(-1002); got != i18n.English {
+		t.Errorf("chat without language = %q, want default en", got)
+	}
+
+	// /lang overrides config and is kept in file
+	if err := i18n.SetChatLang(-1001, i18n.English); err != nil {
+		t.Fatalf("SetChatLang failed: %v", err)
+	}
+	if err := i18n.SetChatLang(-1002, i18n.Russian); err != nil {
+		t.Fatalf("SetChatLang failed: %v", err)
+	}
+	if lang, fromChat := i18n.ChatSetting(-1001); lang != i18n.English || !fromChat {
+		t.Errorf("ChatSetting(-1001) = %q, %v, want en set with /lang", lang, fromChat)
+	}
+	if got := i18n.ChatLang(-1002); got != i18n.Russian {
+		t.Errorf("chat set with /lang = %q, want ru", got)
+	}
+
+	// /lang reset returns to config
+	if err := i18n.SetChatLang(-1001, ""); err != nil {
+		t.Fatalf("SetChatLang reset failed: %v", err)
+	}
+	if lang, fromChat := i18n.ChatSetting(-1001); lang != i18n.Russian || fromChat {
+		t.Errorf("reset chat = %q, %v, want configured ru", lang, fromChat)
+	}
+
+	// Another output dir has its own file
+	paths.Configure(t.TempDir(), t.TempDir())
+	if got := i18n.ChatLang(-1002); got != i18n.English {
+		t.Errorf("chat in new output dir = %q, want default en", got)
+	}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/leaderboard"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/testutil"
+)
+
+const (
+	leaderboardPool      = "03ead0000000000000000000000000000000000000000000000000000000000001"
+	leaderboardOtherPool = "03ead0000000000000000000000000000000000000000000000000000000000002"
+	leaderboardToken     = "btkn1leaderboardtoken"
+)
+
+func TestLoadLeaderboard(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	to := time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -leaderboard.PeriodDays)
+
+	buy := func(id, wallet string, sats int64, tokens string, at time.Time) flashnet.Swap {
+		return testutil.BuySwap(id, leaderboardPool, leaderboardToken, wallet, sats, tokens, at)
+	}
+	sell := func(id, wallet string, tokens string, sats int64, at time.Time) flashnet.Swap {
+		return testutil.SellSwap(id, leaderboardPool, leaderboardToken, wallet, tokens, sats, at)
+	}
+	swaps := []flashnet.Swap{
+		// Bought before period: cost basis from history
+		buy("a-buy", "wallet-aaaaaa", 1_000_000, "1000", time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC)),
+		sell("a-sell", "wallet-aaaaaa", "500", 2_000_000, time.Date(2025, 12, 5, 10, 0, 0, 0, time.UTC)),
+		buy("b-buy", "wallet-bbbbbb", 100_000, "100", time.Date(2025, 12, 4, 10, 0, 0, 0, time.UTC)),
+		sell("b-sell", "wallet-bbbbbb", "100", 300_000, time.Date(2025, 12, 6, 10, 0, 0, 0, time.UTC)),
+		// Loss: seller, but not in leaderboard
+		buy("c-buy", "wallet-cccccc", 1_000_000, "100", time.Date(2025, 12, 4, 11, 0, 0, 0, time.UTC)),
+		sell("c-sell", "wallet-cccccc", "100", 400_000, time.Date(2025, 12, 6, 11, 0, 0, 0, time.UTC)),
+		// Sells outside of period and swaps of other pool are not ranked
+		sell("d-sell", "wallet-dddddd", "100", 5_000_000, time.Date(2025, 11, 25, 10, 0, 0, 0, time.UTC)),
+		sell("e-sell", "wallet-eeeeee", "100", 5_000_000, time.Date(2025, 12, 10, 13, 0, 0, 0, time.UTC)),
+		testutil.SellSwap("f-sell", leaderboardOtherPool, leaderboardToken, "wallet-ffffff", "100", 5_000_000, time.Date(2025, 12, 5, 10, 0, 0, 0, time.UTC)),
+	}
+	if err := storage.AppendDailySwaps(swaps); err != nil {
+		t.Fatalf("AppendDailySwaps failed: %v", err)
+	}
+
+	board, err := leaderboard.LoadBoard(leaderboardPool, from, to)
+	if err != nil {
+		t.Fatalf("LoadBoard failed: %v", err)
+	}
+	if board.Sellers != 3 || board.InProfit != 2 || len(board.Wallets) != 2 {
+		t.Fatalf("sellers %d, in profit %d, wallets %+v, want 3 sellers and 2 in profit", board.Sellers, board.InProfit, board.Wallets)
+	}
+
+	// a: sold half of 1000 tokens bought for 0.01 btc, for 0.02 btc
+	first, second := board.Wallets[0], board.Wallets[1]
+	if first.PublicKey != "wallet-aaaaaa" || math.Abs(first.RealizedPnLBTC-0.015) > 1e-9 || first.Sells != 1 || first.Buys != 0 {
+		t.Errorf("first place = %+v, want wallet-aaaaaa with 0.015 btc", first)
+	}
+	if second.PublicKey != "wallet-bbbbbb" || math.Abs(second.RealizedPnLBTC-0.002) > 1e-9 || second.Buys != 1 {
+		t.Errorf("second place = %+v, want wallet-bbbbbb with 0.002 btc", second)
+	}
+
+	board.Ticker = "LEAD"
+	text := leaderboard.Format(board, 100_000, i18n.English)
+	for _, want := range []string{"{LEAD}", "03.12 - 09.12", "🥇 ...aaaaaa: +0.015 btc ($1.5K), 1 sells for 0.02 btc", "🥈 ...bbbbbb: +0.002 btc ($200)", "In profit: 2 of 3 sellers"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("leaderboard has no %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestBuildLeaderboard_Top(t *testing.T) {
+	to := time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -leaderboard.PeriodDays)
+
+	var swaps []flashnet.Swap
+	for i := 1; i <= 12; i++ {
+		wallet := fmt.Sprintf("wallet-%02d", i)
+		at := from.Add(time.Duration(i) * time.Hour)
+		swaps = append(swaps,
+			testutil.SellSwap("sell-"+wallet, leaderboardPool, leaderboardToken, wallet, "100", int64(i)*10_000, at.Add(time.Minute)),
+			testutil.BuySwap("buy-"+wallet, leaderboardPool, leaderboardToken, wallet, 10_000, "100", at))
+	}
+
+	board := leaderboard.Build(swaps, from, to)
+	if board.Sellers != 12 || board.InProfit != 11 || len(board.Wallets) != leaderboard.TopSize {
+		t.Fatalf("sellers %d, in profit %d, %d wallets, want 12, 11 and top %d", board.Sellers, board.InProfit, len(board.Wallets), leaderboard.TopSize)
+	}
+	if board.Wallets[0].PublicKey != "wallet-12" || board.Wallets[9].PublicKey != "wallet-03" {
+		t.Errorf("top is %s ... %s, want wallet-12 ... wallet-03", board.Wallets[0].PublicKey, board.Wallets[9].PublicKey)
+	}
+
+	text := leaderboard.Format(board, 0, i18n.English)
+	if !strings.Contains(text, "🥉") || !strings.Contains(text, "4. ") || !strings.Contains(text, "10. ") || strings.Contains(text, "$") {
+		t.Errorf("unexpected places or USD values:\n%s", text)
+	}
+}
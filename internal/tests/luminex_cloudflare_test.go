@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/infra/cloudflare"
+)
+
+// luminexBlocked returns Cloudflare blocks seen by Luminex clients
+func luminexBlocked() int64 {
+	var blocked int64
+	for _, stats := range cloudflare.AllStats() {
+		if stats.Name == "LuminexAPI" {
+			blocked += stats.Blocked
+		}
+	}
+	return blocked
+}
+
+func TestLuminexStats_CloudflareChallenge(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// First request gets challenge page, next ones the stats
+		if requests.Add(1) == 1 {
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Cf-Mitigated", "challenge")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<title>Just a moment...</title>`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(luminex.StatsResponse{TotalTokens: 42, TotalPools: 7})
+	}))
+	defer server.Close()
+	luminex.SetAPIHost(server.URL)
+	defer luminex.SetAPIHost("")
+
+	blockedBefore := luminexBlocked()
+
+	_, err := luminex.GetStats()
+	if !errors.Is(err, cloudflare.ErrBlocked) {
+		t.Fatalf("GetStats err = %v, want cloudflare.ErrBlocked", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1 (challenge is not retried)", got)
+	}
+	if luminexBlocked() != blockedBefore+1 {
+		t.Errorf("blocked = %d, want %d", luminexBlocked(), blockedBefore+1)
+	}
+
+	// Next request waits for cool-down of shared client
+	start := time.Now()
+	stats, err := luminex.GetStats()
+	if err != nil {
+		t.Fatalf("GetStats after cool-down: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("GetStats after block returned in %v, want cool-down wait", elapsed)
+	}
+	if stats.TotalTokens != 42 || stats.TotalPools != 7 {
+		t.Errorf("stats = %+v, want 42 tokens and 7 pools", stats)
+	}
+}
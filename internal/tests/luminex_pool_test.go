@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"testing"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+)
+
+func TestLuminexPoolResponse_TokenSide(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		pool luminex.LuminexPoolResponse
+	}{
+		{"BTC as asset B", luminex.LuminexPoolResponse{
+			AssetAAddress: "token", AssetBAddress: flashnet.NativeTokenAddress,
+			AssetAReserve: "500", AssetBReserve: "100",
+			TokenAMetadata: luminex.LuminexTokenMetadata{Ticker: "TKN"},
+		}},
+		{"BTC as asset A", luminex.LuminexPoolResponse{
+			AssetAAddress: flashnet.NativeTokenAddress, AssetBAddress: "token",
+			AssetAReserve: "100", AssetBReserve: "500",
+			TokenBMetadata: luminex.LuminexTokenMetadata{Ticker: "TKN"},
+		}},
+	} {
+		meta, btcReserve, tokenReserve := tc.pool.TokenSide()
+		if meta.Ticker != "TKN" || btcReserve != "100" || tokenReserve != "500" {
+			t.Errorf("%s: TokenSide = %q, %q, %q, want TKN, 100, 500", tc.name, meta.Ticker, btcReserve, tokenReserve)
+		}
+	}
+}
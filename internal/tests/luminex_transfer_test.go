@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/infra/transfer"
+)
+
+// luminexTransfer returns transfer counters of Luminex client
+func luminexTransfer() transfer.Stats {
+	for _, stats := range transfer.AllStats() {
+		if stats.Client == "LuminexAPI" {
+			return stats
+		}
+	}
+	return transfer.Stats{Client: "LuminexAPI"}
+}
+
+func TestLuminexTopTokens_GzipTransfer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("Accept-Encoding = %q, want gzip", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		json.NewEncoder(gz).Encode(luminex.TokensResponse{
+			{Ticker: "BTC", Volume24HUSD: 900000},
+			{Ticker: "SPARK", Volume24HUSD: 120000, MarketCapUSD: 1250000},
+			{Ticker: "USDB", Volume24HUSD: 80000},
+			{Ticker: "FLASH", Volume24HUSD: 50000, MarketCapUSD: 300000},
+		})
+		gz.Close()
+	}))
+	defer server.Close()
+	luminex.SetAPIHost(server.URL)
+	defer luminex.SetAPIHost("")
+
+	before := luminexTransfer()
+
+	tokens, err := luminex.GetTopTokens(2)
+	if err != nil {
+		t.Fatalf("GetTopTokens: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0].Ticker != "SPARK" || tokens[1].Ticker != "FLASH" {
+		t.Fatalf("tokens = %+v, want SPARK and FLASH", tokens)
+	}
+
+	after := luminexTransfer()
+	if after.Responses != before.Responses+1 || after.Compressed != before.Compressed+1 {
+		t.Errorf("responses %d->%d, compressed %d->%d, want +1 each",
+			before.Responses, after.Responses, before.Compressed, after.Compressed)
+	}
+	if after.WireBytes <= before.WireBytes || after.BodyBytes <= before.BodyBytes {
+		t.Errorf("transfer sizes not recorded: before %+v, after %+v", before, after)
+	}
+}
@@ -0,0 +1,260 @@
+package tests
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/holders"
+	"spark-wallet/internal/features/metrics_export"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/testutil"
+)
+
+const metricsToken = "metrics-test-token"
+
+// prepareMetricsData sets Luminex stats, tracks E2E pool with a buy and a sell of today and 2 holders of E2E
+func prepareMetricsData(t *testing.T, env *e2eEnv, now time.Time) {
+	t.Helper()
+	env.luminex.SetStats(luminex.StatsResponse{TotalTokens: 42, TotalMarketCapUSD: 5_000_000, TotalVolume24HUSD: 250_000, TotalTVLUSD: 1_500_000, TotalPools: 40}, nil)
+
+	if err := storage.AddFilteredToken(e2ePoolLpPublicKey); err != nil {
+		t.Fatalf("failed to track pool: %v", err)
+	}
+	// Swaps of today (UTC) right after midnight, so the test doesn't depend on time of day
+	day := now.UTC().Truncate(24 * time.Hour)
+	if err := storage.AppendDailySwaps([]flashnet.Swap{
+		testutil.BuySwap("metrics-buy", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 2_000_000, "160000000000", day.Add(time.Second)),
+		testutil.SellSwap("metrics-sell", e2ePoolLpPublicKey, e2eTokenAddress, e2eSmallSwapperKey, "40000000000", 500_000, day.Add(2*time.Second)),
+	}); err != nil {
+		t.Fatalf("failed to archive swaps: %v", err)
+	}
+
+	holders.SetConfiguredTickers([]string{"E2E"})
+	t.Cleanup(func() { holders.SetConfiguredTickers(nil) })
+	saved := &holders.SavedHoldersData{Holders: map[string]string{e2eWhaleSwapperKey: "1600", e2eSmallSwapperKey: "400"}}
+	if err := holders.SaveSavedHolders("E2E", saved); err != nil {
+		t.Fatalf("failed to save holders: %v", err)
+	}
+}
+
+func findSample(samples []metrics_export.Sample, name string) (metrics_export.Sample, bool) {
+	for _, sample := range samples {
+		if sample.Name == name {
+			return sample, true
+		}
+	}
+	return metrics_export.Sample{}, false
+}
+
+func TestMetricsExport_Collect(t *testing.T) {
+	env := newE2EEnv(t)
+	now := time.Now()
+	prepareMetricsData(t, env, now)
+
+	samples, err := metrics_export.Collect(now)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	for name, want := range map[string]float64{
+		"spark_tvl_usd":            1_500_000,
+		"spark_volume_24h_usd":     250_000,
+		"spark_tokens":             42,
+		"spark_token_buy_btc":      0.02,
+		"spark_token_sell_btc":     0.005,
+		"spark_token_net_flow_btc": 0.015,
+		"spark_token_buys":         1,
+		"spark_token_holders":      2,
+	} {
+		sample, ok := findSample(samples, name)
+		if !ok || math.Abs(sample.Value-want) > 1e-9 || !sample.Time.Equal(now) {
+			t.Errorf("%s = %+v (found %v), want %v", name, sample, ok, want)
+		}
+	}
+	if flow, _ := findSample(samples, "spark_token_net_flow_btc"); flow.Labels["ticker"] != "E2E" || flow.Labels["pool"] != e2ePoolLpPublicKey {
+		t.Errorf("flow labels = %v, want ticker E2E and pool", flow.Labels)
+	}
+
+	// Stats failure doesn't lose flows and holders
+	luminex.SetAPIHost("http://127.0.0.1:1")
+	samples, err = metrics_export.Collect(now)
+	if err == nil {
+		t.Error("expected error of unavailable Luminex stats")
+	}
+	if _, ok := findSample(samples, "spark_token_holders"); !ok {
+		t.Errorf("samples without stats = %v, want holders", samples)
+	}
+}
+
+// captureMetrics starts sink endpoint saving headers and body of last push
+func captureMetrics(t *testing.T) (*httptest.Server, *http.Header, *[]byte) {
+	t.Helper()
+	var headers http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server, &headers, &body
+}
+
+func TestMetricsExport_InfluxSink(t *testing.T) {
+	server, headers, body := captureMetrics(t)
+	sink, err := metrics_export.NewSink(metrics_export.SinkInflux, server.URL+"/api/v2/write?org=spark&bucket=metrics", metricsToken)
+	if err != nil {
+		t.Fatalf("NewSink failed: %v", err)
+	}
+
+	at := time.Unix(1_700_000_000, 0)
+	samples := []metrics_export.Sample{
+		{Name: "spark_tvl_usd", Value: 1_500_000.5, Time: at},
+		{Name: "spark_token_holders", Labels: map[string]string{"ticker": "MY TOKEN", "pool": "03ab", "empty": ""}, Value: 2, Time: at},
+	}
+	if err := sink.Push(context.Background(), samples); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	want := "spark_tvl_usd value=1500000.5 1700000000000000000\n" +
+		"spark_token_holders,pool=03ab,ticker=MY\\ TOKEN value=2 1700000000000000000\n"
+	if string(*body) != want {
+		t.Errorf("line protocol =\n%s\nwant\n%s", *body, want)
+	}
+	if got := headers.Get("Authorization"); got != "Token "+metricsToken {
+		t.Errorf("Authorization = %q", got)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bucket not found", http.StatusNotFound)
+	}))
+	t.Cleanup(failing.Close)
+	sink, _ = metrics_export.NewSink(metrics_export.SinkInflux, failing.URL, "")
+	if err := sink.Push(context.Background(), samples); err == nil || !strings.Contains(err.Error(), "bucket not found") {
+		t.Errorf("Push to failing sink = %v, want error with response", err)
+	}
+
+	if _, err := metrics_export.NewSink("graphite", server.URL, ""); err == nil {
+		t.Error("expected error of unknown sink")
+	}
+}
+
+func TestMetricsExport_PrometheusSink(t *testing.T) {
+	server, headers, body := captureMetrics(t)
+	sink, err := metrics_export.NewSink(metrics_export.SinkPrometheus, server.URL+"/api/v1/write", metricsToken)
+	if err != nil {
+		t.Fatalf("NewSink failed: %v", err)
+	}
+
+	at := time.UnixMilli(1_700_000_000_123)
+	// Label longer than 60 bytes makes a long snappy literal
+	pool := strings.Repeat("03", 40)
+	if err := sink.Push(context.Background(), []metrics_export.Sample{
+		{Name: "spark_token_net_flow_btc", Labels: map[string]string{"ticker": "E2E", "pool": pool}, Value: -0.25, Time: at},
+	}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	if headers.Get("Content-Encoding") != "snappy" || headers.Get("X-Prometheus-Remote-Write-Version") != "0.1.0" || headers.Get("Authorization") != "Bearer "+metricsToken {
+		t.Errorf("headers = %v", *headers)
+	}
+
+	request := decodeSnappyLiterals(t, *body)
+	series := protoFields(t, request)
+	if len(series) != 1 || series[0].field != 1 {
+		t.Fatalf("WriteRequest fields = %+v, want one time series", series)
+	}
+	var labels []string
+	var value float64
+	var timestamp uint64
+	for _, field := range protoFields(t, series[0].bytes) {
+		switch field.field {
+		case 1:
+			label := protoFields(t, field.bytes)
+			labels = append(labels, string(label[0].bytes)+"="+string(label[1].bytes))
+		case 2:
+			for _, point := range protoFields(t, field.bytes) {
+				if point.field == 1 {
+					value = math.Float64frombits(point.number)
+				} else {
+					timestamp = point.number
+				}
+			}
+		}
+	}
+	wantLabels := "__name__=spark_token_net_flow_btc pool=" + pool + " ticker=E2E"
+	if strings.Join(labels, " ") != wantLabels || value != -0.25 || timestamp != 1_700_000_000_123 {
+		t.Errorf("series = %v, %v at %d, want %s, -0.25 at 1700000000123", labels, value, timestamp, wantLabels)
+	}
+}
+
+// decodeSnappyLiterals decodes snappy block made of literals
+func decodeSnappyLiterals(t *testing.T, block []byte) []byte {
+	t.Helper()
+	size, n := binary.Uvarint(block)
+	block = block[n:]
+	var data []byte
+	for len(block) > 0 {
+		tag := block[0]
+		if tag&3 != 0 {
+			t.Fatalf("snappy element %08b is not a literal", tag)
+		}
+		length, header := int(tag>>2)+1, 1
+		if tag>>2 >= 60 {
+			extra := int(tag>>2) - 59
+			length = 1
+			for i := 0; i < extra; i++ {
+				length += int(block[1+i]) << (8 * i)
+			}
+			header += extra
+		}
+		data = append(data, block[header:header+length]...)
+		block = block[header+length:]
+	}
+	if uint64(len(data)) != size {
+		t.Fatalf("snappy data has %d bytes, preamble %d", len(data), size)
+	}
+	return data
+}
+
+type protoField struct {
+	field  int
+	number uint64 // varint and fixed64
+	bytes  []byte // length-delimited
+}
+
+// protoFields splits protobuf message into fields
+func protoFields(t *testing.T, message []byte) []protoField {
+	t.Helper()
+	var fields []protoField
+	for len(message) > 0 {
+		key, n := binary.Uvarint(message)
+		message = message[n:]
+		field := protoField{field: int(key >> 3)}
+		switch key & 7 {
+		case 0:
+			field.number, n = binary.Uvarint(message)
+			message = message[n:]
+		case 1:
+			field.number = binary.LittleEndian.Uint64(message)
+			message = message[8:]
+		case 2:
+			length, n := binary.Uvarint(message)
+			field.bytes = message[n : n+int(length)]
+			message = message[n+int(length):]
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+)
+
+func TestMonitorRegistry_PanicRestart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	alerts := make(chan string, 1)
+	registry := bots_monitor.NewMonitorRegistry(0, func(text string) {
+		alerts <- text
+		// Stop before restart backoff
+		cancel()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		registry.Run(ctx, "panicky", func(ctx context.Context) {
+			var values map[string]*int
+			_ = *values["missing"] // nil dereference
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+
+	alert := <-alerts
+	for _, want := range []string{"monitor restart", "panicky", "Reason: panic", "nil pointer dereference", "monitor_registry_test.go"} {
+		if !strings.Contains(alert, want) {
+			t.Errorf("alert does not contain %q:\n%s", want, alert)
+		}
+	}
+
+	statuses := registry.Statuses()
+	if len(statuses) != 1 || statuses[0].Restarts != 1 || statuses[0].TotalFailures != 1 {
+		t.Fatalf("statuses = %+v, want 1 restart and 1 failure", statuses)
+	}
+	if !strings.Contains(statuses[0].LastError, "TestMonitorRegistry_PanicRestart") {
+		t.Errorf("LastError = %q, want panicking function", statuses[0].LastError)
+	}
+}
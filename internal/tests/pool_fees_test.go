@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"os"
+	"testing"
+
+	"spark-wallet/internal/features/pool_fees"
+)
+
+func TestEstimateCurrentAPR_DoesNotSample(t *testing.T) {
+	newE2EEnv(t)
+
+	// Pool without hourly samples: live details only, nothing saved
+	estimate, err := pool_fees.EstimateCurrentAPR(e2ePoolLpPublicKey)
+	if err != nil {
+		t.Fatalf("EstimateCurrentAPR failed: %v", err)
+	}
+	if estimate.Ticker != "E2E" || estimate.SamplesCount != 0 || estimate.AvgAPR != estimate.CurrentAPR {
+		t.Errorf("estimate without samples = %+v, want E2E with average of current APR", estimate)
+	}
+	if _, err := os.Stat(pool_fees.PoolFeesFile()); !os.IsNotExist(err) {
+		t.Errorf("pool fees file written by APR estimate: %v", err)
+	}
+
+	// Sample of fees monitor is used for average and stays the only one
+	if change, err := pool_fees.SamplePool(e2ePoolLpPublicKey); err != nil || change != nil {
+		t.Fatalf("SamplePool = %+v, %v", change, err)
+	}
+	for i := 0; i < 3; i++ {
+		if estimate, err = pool_fees.EstimateCurrentAPR(e2ePoolLpPublicKey); err != nil {
+			t.Fatalf("EstimateCurrentAPR failed: %v", err)
+		}
+	}
+	if estimate.SamplesCount != 1 {
+		t.Errorf("samples in estimate = %d, want 1", estimate.SamplesCount)
+	}
+	data, err := pool_fees.LoadPoolFees()
+	if err != nil {
+		t.Fatalf("LoadPoolFees failed: %v", err)
+	}
+	if samples := len(data.Pools[e2ePoolLpPublicKey].Samples); samples != 1 {
+		t.Errorf("saved samples = %d, want 1 of fees monitor", samples)
+	}
+}
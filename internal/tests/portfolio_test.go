@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/portfolio"
+	"spark-wallet/internal/infra/paths"
+)
+
+func TestBuildSnapshot_ImpliedPriceAndOrder(t *testing.T) {
+	balance := &luminex.WalletBalanceResponse{
+		Balance: luminex.WalletBalance{BtcHardBalanceSats: 10_000_000, BtcValueUsdHard: 10_000}, // 0.1 BTC at $100k
+		Tokens: []luminex.WalletToken{
+			{TokenAddress: "btkn1small", Ticker: "SMALL", Decimals: 6, Balance: "5000000", ValueUsd: 1_000},
+			{TokenAddress: "btkn1big", Ticker: "BIG", Decimals: 8, Balance: "300000000000", ValueUsd: 30_000},
+			{TokenAddress: "btkn1zero", Ticker: "ZERO", Decimals: 8, Balance: "0", ValueUsd: 0},
+		},
+	}
+
+	snapshot, err := portfolio.BuildSnapshot(balance, 0, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("BuildSnapshot failed: %v", err)
+	}
+	if snapshot.BTCPriceUsd != 100_000 {
+		t.Errorf("BTCPriceUsd = %v, want implied 100000", snapshot.BTCPriceUsd)
+	}
+	if len(snapshot.Holdings) != 3 {
+		t.Fatalf("holdings = %+v, want BIG, BTC, SMALL", snapshot.Holdings)
+	}
+	for i, ticker := range []string{"BIG", portfolio.BTCTicker, "SMALL"} {
+		if snapshot.Holdings[i].Ticker != ticker {
+			t.Errorf("holding %d = %s, want %s", i, snapshot.Holdings[i].Ticker, ticker)
+		}
+	}
+	if math.Abs(snapshot.Holdings[0].ValueBTC-0.3) > 1e-9 || snapshot.Holdings[0].Amount != 3000 {
+		t.Errorf("BIG = %+v, want 3000 tokens worth 0.3 BTC", snapshot.Holdings[0])
+	}
+	if math.Abs(snapshot.ValueBTC-0.41) > 1e-9 || snapshot.ValueUsd != 41_000 {
+		t.Errorf("value = %v BTC / $%v, want 0.41 BTC / $41000", snapshot.ValueBTC, snapshot.ValueUsd)
+	}
+
+	if _, err := portfolio.BuildSnapshot(&luminex.WalletBalanceResponse{}, 0, time.Now()); err == nil {
+		t.Errorf("BuildSnapshot without any BTC price succeeded")
+	}
+}
+
+func TestRecordSnapshot_ChangeAndDaily(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	record := func(publicKey string, at time.Time, valueBTC float64) {
+		t.Helper()
+		snapshot := portfolio.Snapshot{Time: at.Format(time.RFC3339), BTCPriceUsd: 100_000, ValueBTC: valueBTC, ValueUsd: valueBTC * 100_000}
+		if err := portfolio.RecordSnapshot(publicKey, snapshot); err != nil {
+			t.Fatalf("RecordSnapshot failed: %v", err)
+		}
+	}
+
+	// Every 6 hours for 8 days, value grows by 0.01 BTC per sample
+	value := 1.0
+	for at := start; !at.After(start.Add(8 * 24 * time.Hour)); at = at.Add(6 * time.Hour) {
+		record("wallet-a", at, value)
+		value += 0.01
+	}
+
+	data, err := portfolio.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(data.Daily) != 9 {
+		t.Errorf("daily values = %d, want 9 (one per day)", len(data.Daily))
+	}
+	if last := data.Daily[len(data.Daily)-1]; last.ValueBTC != data.Latest.ValueBTC {
+		t.Errorf("daily value of today = %v, want latest %v", last.ValueBTC, data.Latest.ValueBTC)
+	}
+
+	// 24h ago: 4 samples back
+	day := data.ChangeOver(24 * time.Hour)
+	want := (data.Latest.ValueBTC - (data.Latest.ValueBTC - 0.04)) / (data.Latest.ValueBTC - 0.04) * 100
+	if !day.Known || math.Abs(day.Percent-want) > 1e-6 {
+		t.Errorf("24h change = %+v, want %.4f%%", day, want)
+	}
+	if week := data.ChangeOver(7 * 24 * time.Hour); !week.Known {
+		t.Errorf("7d change unknown with 8 days of samples")
+	}
+	if month := data.ChangeOver(30 * 24 * time.Hour); month.Known {
+		t.Errorf("30d change known with 8 days of samples: %+v", month)
+	}
+	if points := data.DailySince(start.Add(6 * 24 * time.Hour)); len(points) != 3 {
+		t.Errorf("DailySince = %d points, want 3", len(points))
+	}
+
+	// Another wallet starts a new history
+	record("wallet-b", start.Add(9*24*time.Hour), 2)
+	data, err = portfolio.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if data.PublicKey != "wallet-b" || len(data.Samples) != 1 || len(data.Daily) != 1 {
+		t.Errorf("history not reset for new wallet: %s, %d samples, %d days", data.PublicKey, len(data.Samples), len(data.Daily))
+	}
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"spark-wallet/internal/features/quiet_hours"
+)
+
+func TestQuietHoursWindow(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	cases := []struct {
+		window string
+		at     time.Time
+		quiet  bool
+	}{
+		{"01:00-08:00", time.Date(2025, 1, 3, 22, 0, 0, 0, time.UTC), true},   // 01:00 MSK
+		{"01:00-08:00", time.Date(2025, 1, 3, 4, 59, 0, 0, time.UTC), true},   // 07:59 MSK
+		{"01:00-08:00", time.Date(2025, 1, 3, 5, 0, 0, 0, time.UTC), false},   // 08:00 MSK
+		{"01:00-08:00", time.Date(2025, 1, 3, 21, 59, 0, 0, time.UTC), false}, // 00:59 MSK
+		{"23:00-07:00", time.Date(2025, 1, 3, 20, 30, 0, 0, time.UTC), true},  // 23:30 MSK
+		{"23:00-07:00", time.Date(2025, 1, 3, 2, 0, 0, 0, time.UTC), true},    // 05:00 MSK
+		{"23:00-07:00", time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC), false},  // 15:00 MSK
+		{"23:00–07:00", time.Date(2025, 1, 3, 20, 30, 0, 0, time.UTC), true},  // en dash
+	}
+
+	for _, tc := range cases {
+		window, err := quiet_hours.ParseWindow(tc.window, moscow)
+		if err != nil {
+			t.Fatalf("ParseWindow(%q) failed: %v", tc.window, err)
+		}
+		if got := window.Contains(tc.at); got != tc.quiet {
+			t.Errorf("%s Contains(%s) = %v, want %v", tc.window, tc.at.In(moscow).Format("15:04"), got, tc.quiet)
+		}
+	}
+}
+
+func TestQuietHoursWindow_Invalid(t *testing.T) {
+	for _, value := range []string{"", "01:00", "1-8", "25:00-08:00", "01:00-08:60", "08:00-08:00"} {
+		if _, err := quiet_hours.ParseWindow(value, nil); err == nil {
+			t.Errorf("ParseWindow(%q) expected error", value)
+		}
+	}
+}
+
+func TestParseMuteDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"30m":   30 * time.Minute,
+		"2h":    2 * time.Hour,
+		"1h30m": 90 * time.Minute,
+		"1d":    24 * time.Hour,
+		"7D":    7 * 24 * time.Hour,
+	}
+	for value, want := range cases {
+		got, err := quiet_hours.ParseMuteDuration(value)
+		if err != nil {
+			t.Fatalf("ParseMuteDuration(%q) failed: %v", value, err)
+		}
+		if got != want {
+			t.Errorf("ParseMuteDuration(%q) = %s, want %s", value, got, want)
+		}
+	}
+
+	for _, value := range []string{"", "soon", "30s", "-1h", "31d", "xd"} {
+		if _, err := quiet_hours.ParseMuteDuration(value); err == nil {
+			t.Errorf("ParseMuteDuration(%q) expected error", value)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"spark-wallet/internal/features/holders"
+)
+
+func TestParseReportDate(t *testing.T) {
+	now := time.Date(2025, time.January, 3, 15, 4, 0, 0, time.UTC)
+
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"0301", "2025-01-03"},
+		{"0201", "2025-01-02"},
+		// Year boundary: date after today belongs to previous year
+		{"3112", "2024-12-31"},
+		{"0812", "2024-12-08"},
+		{"08.12", "2024-12-08"},
+		{"8.12", "2024-12-08"},
+		{"08.12.2023", "2023-12-08"},
+		{"2024-12-08", "2024-12-08"},
+		{"today", "2025-01-03"},
+		{"Yesterday", "2025-01-02"},
+		{"сегодня", "2025-01-03"},
+		{"вчера", "2025-01-02"},
+		// 29.02 without year is the last leap day
+		{"2902", "2024-02-29"},
+	}
+
+	for _, tc := range cases {
+		got, err := holders.ParseReportDate(tc.input, now)
+		if err != nil {
+			t.Errorf("ParseReportDate(%q) failed: %v", tc.input, err)
+			continue
+		}
+		if got.Format("2006-01-02") != tc.want {
+			t.Errorf("ParseReportDate(%q) = %s, want %s", tc.input, got.Format("2006-01-02"), tc.want)
+		}
+	}
+}
+
+func TestParseReportDate_Yesterday_YearBoundary(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 0, 30, 0, 0, time.UTC)
+
+	got, err := holders.ParseReportDate("yesterday", now)
+	if err != nil {
+		t.Fatalf("ParseReportDate failed: %v", err)
+	}
+	if got.Format("2006-01-02") != "2024-12-31" {
+		t.Fatalf("expected 2024-12-31, got %s", got.Format("2006-01-02"))
+	}
+}
+
+func TestParseReportDate_Invalid(t *testing.T) {
+	now := time.Date(2025, time.January, 3, 15, 4, 0, 0, time.UTC)
+
+	inputs := []string{
+		"",
+		"123",
+		"3204",
+		"0013",
+		"3104",       // 31 April
+		"29.02.2023", // not a leap year
+		"2025-01-04", // in the future
+		"08.12.24",
+		"24-12-08",
+		"ab.cd",
+		"tomorrow",
+	}
+
+	for _, input := range inputs {
+		if got, err := holders.ParseReportDate(input, now); err == nil {
+			t.Errorf("ParseReportDate(%q) = %s, expected error", input, got.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestParseReportRange(t *testing.T) {
+	now := time.Date(2025, time.January, 3, 15, 4, 0, 0, time.UTC)
+
+	cases := []struct {
+		input    string
+		wantFrom string
+		wantTo   string
+	}{
+		{"0101-0301", "2025-01-01", "2025-01-03"},
+		{"01.01-03.01", "2025-01-01", "2025-01-03"},
+		{"2024-12-01..2024-12-07", "2024-12-01", "2024-12-07"},
+		// Start after end day belongs to previous year
+		{"2812-0201", "2024-12-28", "2025-01-02"},
+		{"28.12–02.01", "2024-12-28", "2025-01-02"},
+		{"2024-12-28-2025-01-02", "2024-12-28", "2025-01-02"},
+		{"week", "2024-12-28", "2025-01-03"},
+		{"month", "2024-12-05", "2025-01-03"},
+		{"неделя", "2024-12-28", "2025-01-03"},
+	}
+
+	for _, tc := range cases {
+		from, to, err := holders.ParseReportRange(tc.input, now)
+		if err != nil {
+			t.Errorf("ParseReportRange(%q) failed: %v", tc.input, err)
+			continue
+		}
+		if from.Format("2006-01-02") != tc.wantFrom || to.Format("2006-01-02") != tc.wantTo {
+			t.Errorf("ParseReportRange(%q) = %s..%s, want %s..%s", tc.input,
+				from.Format("2006-01-02"), to.Format("2006-01-02"), tc.wantFrom, tc.wantTo)
+		}
+	}
+}
+
+func TestParseReportRange_Invalid(t *testing.T) {
+	now := time.Date(2025, time.January, 3, 15, 4, 0, 0, time.UTC)
+
+	inputs := []string{
+		"",
+		"0301",
+		"0101-",
+		"2025-01-03..2025-01-01", // start after end
+		"ab-cd",
+	}
+
+	for _, input := range inputs {
+		if from, to, err := holders.ParseReportRange(input, now); err == nil {
+			t.Errorf("ParseReportRange(%q) = %s..%s, expected error", input, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestIsReportRange(t *testing.T) {
+	for _, input := range []string{"0112-0712", "01.12-07.12", "2025-12-01..2025-12-07", "week", "Month"} {
+		if !holders.IsReportRange(input) {
+			t.Errorf("IsReportRange(%q) = false, want true", input)
+		}
+	}
+	for _, input := range []string{"", "0112", "yesterday", "2025-12-01", "08.12.2024"} {
+		if holders.IsReportRange(input) {
+			t.Errorf("IsReportRange(%q) = true, want false", input)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/features/roles"
+	"spark-wallet/internal/infra/paths"
+)
+
+func configureRoles(t *testing.T, cfg roles.Config) {
+	t.Helper()
+	paths.Configure(t.TempDir(), t.TempDir())
+	roles.Configure(cfg)
+	t.Cleanup(func() { roles.Configure(roles.Config{}) })
+}
+
+func TestRoles_OffWithoutAdmins(t *testing.T) {
+	configureRoles(t, roles.Config{})
+
+	if roles.Enabled() {
+		t.Fatal("roles are on without owners and admins")
+	}
+	// Without owners admins are managed at admin level
+	if level := roles.CommandLevel("admins_manage"); level != roles.LevelAdmin {
+		t.Errorf("admins_manage level = %s, want admin without owners", level)
+	}
+
+	if _, err := roles.AddAdmin(roles.Admin{UserID: 42, AddedAt: time.Now()}); err != nil {
+		t.Fatalf("AddAdmin failed: %v", err)
+	}
+	if !roles.Enabled() {
+		t.Error("roles are off with added admin")
+	}
+}
+
+func TestRoles_LevelsAndAdmins(t *testing.T) {
+	configureRoles(t, roles.Config{
+		Owners: []int64{1001},
+		Levels: map[string]roles.Level{"flashdel": roles.LevelOwner, "quiet": roles.LevelAdmin},
+	})
+
+	cases := map[string]roles.Level{
+		"flashadd":      roles.LevelAdmin,
+		"FLASHADD":      roles.LevelAdmin,
+		"flashdel":      roles.LevelOwner,
+		"quiet":         roles.LevelAdmin,
+		"flow":          roles.LevelMember,
+		"admins":        roles.LevelAdmin,
+		"admins_manage": roles.LevelOwner,
+	}
+	for command, want := range cases {
+		if got := roles.CommandLevel(command); got != want {
+			t.Errorf("CommandLevel(%s) = %s, want %s", command, got, want)
+		}
+	}
+
+	if role, err := roles.RoleOf(1001); err != nil || role != roles.LevelOwner {
+		t.Errorf("RoleOf(owner) = %s, %v", role, err)
+	}
+	if role, _ := roles.RoleOf(2002); role != roles.LevelMember {
+		t.Errorf("RoleOf(2002) = %s before /admins add, want member", role)
+	}
+
+	added, err := roles.AddAdmin(roles.Admin{UserID: 2002, AddedBy: 1001, AddedAt: time.Now()})
+	if err != nil || !added {
+		t.Fatalf("AddAdmin = %v, %v", added, err)
+	}
+	if added, _ := roles.AddAdmin(roles.Admin{UserID: 2002, Username: "alice"}); added {
+		t.Error("admin added twice")
+	}
+	admins, err := roles.Admins()
+	if err != nil || len(admins) != 1 || admins[0].Username != "alice" || admins[0].AddedBy != 1001 {
+		t.Fatalf("Admins = %+v, %v, want 2002 with updated username", admins, err)
+	}
+	if role, _ := roles.RoleOf(2002); role != roles.LevelAdmin {
+		t.Errorf("RoleOf(2002) = %s, want admin", role)
+	}
+
+	if removed, err := roles.RemoveAdmin(2002); err != nil || !removed {
+		t.Fatalf("RemoveAdmin = %v, %v", removed, err)
+	}
+	if removed, _ := roles.RemoveAdmin(1001); removed {
+		t.Error("owner from config removed as admin")
+	}
+	if role, _ := roles.RoleOf(2002); role != roles.LevelMember {
+		t.Errorf("RoleOf(2002) = %s after /admins del, want member", role)
+	}
+}
+
+func TestRoles_BrokenAdminsFile(t *testing.T) {
+	configureRoles(t, roles.Config{})
+	if err := os.MkdirAll(paths.Output("telegram_out"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(roles.AdminsFile(), []byte("{broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Commands are not opened to everyone because admins can't be read
+	if !roles.Enabled() {
+		t.Error("roles are off with unreadable admins file")
+	}
+	if _, err := roles.RoleOf(2002); err == nil {
+		t.Error("RoleOf expected error")
+	}
+}
+
+func TestParseRoleLevel(t *testing.T) {
+	for input, want := range map[string]roles.Level{"member": roles.LevelMember, "Admin": roles.LevelAdmin, " owner ": roles.LevelOwner} {
+		if level, err := roles.ParseLevel(input); err != nil || level != want {
+			t.Errorf("ParseLevel(%q) = %s, %v, want %s", input, level, err, want)
+		}
+	}
+	if _, err := roles.ParseLevel("moderator"); err == nil {
+		t.Error("ParseLevel(moderator) expected error")
+	}
+}
+
+func TestRoles_BootstrapFirstAdmin(t *testing.T) {
+	configureRoles(t, roles.Config{})
+
+	// Roles off: a member of the filtered chat can't make themselves the first admin
+	if roles.CanManageAdmins(roles.LevelMember, false) {
+		t.Error("member manages admins while roles are off")
+	}
+	if !roles.CanManageAdmins(roles.LevelMember, true) {
+		t.Error("admin chat can't add the first admin")
+	}
+
+	// Owner in config: owners manage admins from any chat, admins don't
+	configureRoles(t, roles.Config{Owners: []int64{1001}})
+	if !roles.CanManageAdmins(roles.LevelOwner, false) {
+		t.Error("owner can't manage admins")
+	}
+	if roles.CanManageAdmins(roles.LevelAdmin, true) {
+		t.Error("admin manages admins with owners in config")
+	}
+
+	// Roles on without owners: admins manage the list
+	configureRoles(t, roles.Config{})
+	if _, err := roles.AddAdmin(roles.Admin{UserID: 42, AddedAt: time.Now()}); err != nil {
+		t.Fatalf("AddAdmin failed: %v", err)
+	}
+	if !roles.CanManageAdmins(roles.LevelAdmin, false) || roles.CanManageAdmins(roles.LevelMember, false) {
+		t.Error("with added admins only admins manage the list")
+	}
+}
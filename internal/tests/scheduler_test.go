@@ -0,0 +1,211 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/infra/scheduler"
+)
+
+// fakeClock - manual clock, time moves only with Advance
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+	added   chan struct{} // signaled on every After call
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, added: make(chan struct{}, 16)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	c.mu.Unlock()
+	c.added <- struct{}{}
+	return ch
+}
+
+// Advance moves clock to at and fires waiters that are due
+func (c *fakeClock) Advance(at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = at
+	pending := c.waiters[:0]
+	for _, waiter := range c.waiters {
+		if waiter.at.After(at) {
+			pending = append(pending, waiter)
+			continue
+		}
+		waiter.ch <- at
+	}
+	c.waiters = pending
+}
+
+func (c *fakeClock) waitScheduled(t *testing.T) {
+	t.Helper()
+	select {
+	case <-c.added:
+	case <-time.After(time.Second):
+		t.Fatal("job was not scheduled")
+	}
+}
+
+func TestDailyNext(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		schedule scheduler.Daily
+		after    time.Time
+		want     time.Time
+	}{
+		{
+			name:     "later today",
+			schedule: scheduler.Daily{Hour: 10, Minute: 0, Location: moscow},
+			after:    time.Date(2025, 1, 3, 5, 0, 0, 0, time.UTC), // 08:00 MSK
+			want:     time.Date(2025, 1, 3, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "exact time moves to next day",
+			schedule: scheduler.Daily{Hour: 10, Minute: 0, Location: moscow},
+			after:    time.Date(2025, 1, 3, 7, 0, 0, 0, time.UTC),
+			want:     time.Date(2025, 1, 4, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "job day differs from UTC day",
+			schedule: scheduler.Daily{Hour: 1, Minute: 30, Location: moscow},
+			after:    time.Date(2025, 1, 3, 21, 0, 0, 0, time.UTC), // 00:00 MSK on Jan 4
+			want:     time.Date(2025, 1, 3, 22, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekday",
+			schedule: scheduler.Daily{Hour: 10, Minute: 0, Location: moscow, Weekdays: []time.Weekday{time.Monday}},
+			after:    time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC), // Friday
+			want:     time.Date(2025, 1, 6, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "DST keeps local time",
+			schedule: scheduler.Daily{Hour: 9, Minute: 0, Location: berlin},
+			after:    time.Date(2025, 3, 29, 12, 0, 0, 0, time.UTC), // day before switch to CEST
+			want:     time.Date(2025, 3, 30, 7, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.schedule.Next(tc.after)
+			if !got.Equal(tc.want) {
+				t.Fatalf("Next(%s) = %s, want %s", tc.after, got.UTC(), tc.want)
+			}
+		})
+	}
+}
+
+func TestParseDaily(t *testing.T) {
+	schedule, err := scheduler.ParseDaily(" 09:05 ", time.UTC)
+	if err != nil {
+		t.Fatalf("ParseDaily failed: %v", err)
+	}
+	if schedule.Hour != 9 || schedule.Minute != 5 {
+		t.Fatalf("got %02d:%02d, want 09:05", schedule.Hour, schedule.Minute)
+	}
+
+	for _, input := range []string{"", "9", "25:00", "10:60", "ten"} {
+		if _, err := scheduler.ParseDaily(input, time.UTC); err == nil {
+			t.Fatalf("ParseDaily(%q) expected error", input)
+		}
+	}
+}
+
+func TestDateUsesJobTimezone(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	at := time.Date(2025, 1, 3, 22, 0, 0, 0, time.UTC)
+
+	if got := scheduler.Date(at, moscow); got != "2025-01-04" {
+		t.Fatalf("Date in MSK = %s, want 2025-01-04", got)
+	}
+	if got := scheduler.Date(at, time.UTC); got != "2025-01-03" {
+		t.Fatalf("Date in UTC = %s, want 2025-01-03", got)
+	}
+}
+
+func TestSchedulerRunsJobOnClock(t *testing.T) {
+	start := time.Date(2025, 1, 3, 6, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	s := scheduler.New(clock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := make(chan time.Time, 4)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx, scheduler.Job{
+			Name:       "test",
+			Schedule:   scheduler.Daily{Hour: 7, Minute: 0, Location: time.UTC},
+			RunOnStart: true,
+			Run: func(ctx context.Context, now time.Time) {
+				runs <- now
+			},
+		})
+	}()
+
+	if got := <-runs; !got.Equal(start) {
+		t.Fatalf("start run at %s, want %s", got, start)
+	}
+
+	clock.waitScheduled(t)
+	clock.Advance(start.Add(30 * time.Minute))
+	select {
+	case got := <-runs:
+		t.Fatalf("job ran early at %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	first := time.Date(2025, 1, 3, 7, 0, 0, 0, time.UTC)
+	clock.Advance(first)
+	if got := <-runs; !got.Equal(first) {
+		t.Fatalf("scheduled run at %s, want %s", got, first)
+	}
+
+	clock.waitScheduled(t)
+	second := first.AddDate(0, 0, 1)
+	clock.Advance(second)
+	if got := <-runs; !got.Equal(second) {
+		t.Fatalf("next run at %s, want %s", got, second)
+	}
+
+	clock.waitScheduled(t)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop on context cancel")
+	}
+}
+
+func TestIntervalSchedule(t *testing.T) {
+	at := time.Date(2025, 1, 3, 6, 0, 0, 0, time.UTC)
+	if got := scheduler.Interval(time.Hour).Next(at); !got.Equal(at.Add(time.Hour)) {
+		t.Fatalf("Interval.Next = %s, want %s", got, at.Add(time.Hour))
+	}
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/features/signals"
+)
+
+func TestVerifySignal(t *testing.T) {
+	const secret = "0123456789abcdef"
+	now := time.Date(2025, time.January, 3, 15, 4, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"title":"RSI oversold","ticker":"soon","side":"buy"}`)
+	signature := signals.Sign(secret, timestamp, body)
+
+	if err := signals.Verify(secret, timestamp, signature, body, now); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if err := signals.Verify(secret, timestamp, "sha256="+strings.ToUpper(signature), body, now.Add(time.Minute)); err != nil {
+		t.Fatalf("Verify with prefix failed: %v", err)
+	}
+
+	cases := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+	}{
+		{"wrong secret", "fedcba9876543210", timestamp, signature, body, now},
+		{"changed body", secret, timestamp, signature, []byte(`{"title":"RSI oversold","ticker":"soon","side":"sell"}`), now},
+		{"changed timestamp", secret, strconv.FormatInt(now.Unix()+1, 10), signature, body, now},
+		{"expired", secret, timestamp, signature, body, now.Add(signals.MaxClockSkew + time.Second)},
+		{"from future", secret, timestamp, signature, body, now.Add(-signals.MaxClockSkew - time.Second)},
+		{"missing signature", secret, timestamp, "", body, now},
+		{"missing timestamp", secret, "", signature, body, now},
+		{"not hex", secret, timestamp, "not-a-signature", body, now},
+		{"empty secret", "", timestamp, signals.Sign("", timestamp, body), body, now},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := signals.Verify(tc.secret, tc.timestamp, tc.signature, tc.body, tc.now); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestReplayGuardRejectsRepeatedSignal(t *testing.T) {
+	const secret = "0123456789abcdef"
+	now := time.Date(2025, time.January, 3, 15, 4, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"title":"RSI oversold","ticker":"soon","side":"buy"}`)
+	signature := signals.Sign(secret, timestamp, body)
+	guard := signals.NewReplayGuard()
+
+	// accept runs checks of webhook handler for request received at
+	accept := func(timestamp string, signature string, body []byte, at time.Time) error {
+		if err := signals.Verify(secret, timestamp, signature, body, at); err != nil {
+			return err
+		}
+		return guard.Accept(signature, at)
+	}
+
+	if err := accept(timestamp, signature, body, now); err != nil {
+		t.Fatalf("first request rejected: %v", err)
+	}
+	// Same signed request inside MaxClockSkew, also with prefix and other case of signature
+	if err := accept(timestamp, signature, body, now.Add(time.Minute)); err == nil {
+		t.Fatal("repeated request accepted")
+	}
+	if err := accept(timestamp, "sha256="+strings.ToUpper(signature), body, now.Add(2*time.Minute)); err == nil {
+		t.Fatal("repeated request with prefix accepted")
+	}
+
+	// New request of the same source is accepted
+	next := strconv.FormatInt(now.Unix()+1, 10)
+	if err := accept(next, signals.Sign(secret, next, body), body, now.Add(time.Minute)); err != nil {
+		t.Fatalf("new request rejected: %v", err)
+	}
+
+	// After signature is forgotten, request is rejected by its timestamp
+	if err := accept(timestamp, signature, body, now.Add(2*signals.MaxClockSkew+time.Second)); err == nil {
+		t.Fatal("expired request accepted")
+	}
+}
+
+func TestParseSignal(t *testing.T) {
+	signal, err := signals.Parse([]byte(`{"title":" RSI oversold ","ticker":"soon","side":"BUY","price":0.42}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if signal.Title != "RSI oversold" || signal.Ticker != "SOON" || signal.Side != "buy" || signal.Price != 0.42 {
+		t.Fatalf("unexpected signal: %+v", signal)
+	}
+
+	signal, err = signals.Parse([]byte("  breakout on SOON\n"))
+	if err != nil {
+		t.Fatalf("Parse of plain text failed: %v", err)
+	}
+	if signal.Text != "breakout on SOON" {
+		t.Fatalf("Text = %q, want %q", signal.Text, "breakout on SOON")
+	}
+
+	for _, body := range []string{"", "{}", `{"title":`, `{"text":"x","side":"hold"}`, `{"text":"x","url":"javascript:alert(1)"}`} {
+		if _, err := signals.Parse([]byte(body)); err == nil {
+			t.Errorf("Parse(%q) expected error", body)
+		}
+	}
+}
+
+func TestFormatSignalEscapesHTML(t *testing.T) {
+	message := signals.FormatMessage("script<1>", signals.Signal{
+		Title: "<b>pump</b>",
+		Text:  "a & b",
+		URL:   `https://example.com/?a="x"`,
+	})
+
+	for _, unexpected := range []string{"<b>pump</b>", "script<1>", `"x"`} {
+		if strings.Contains(message, unexpected) {
+			t.Errorf("message contains unescaped %q: %s", unexpected, message)
+		}
+	}
+	for _, expected := range []string{"&lt;b&gt;pump&lt;/b&gt;", "a &amp; b", "script&lt;1&gt;"} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("message doesn't contain %q: %s", expected, message)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+func TestSwapArchive_LateAppendAfterCompression(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	day := time.Now().UTC().AddDate(0, 0, -10).Truncate(24 * time.Hour)
+	date := day.Format("2006-01-02")
+	path := storage.SwapArchiveFile(date)
+	swap := func(id string) flashnet.Swap {
+		return flashnet.Swap{ID: id, Timestamp: day.Add(time.Minute).Format(time.RFC3339)}
+	}
+
+	if err := storage.AppendDailySwaps([]flashnet.Swap{swap("first")}); err != nil {
+		t.Fatalf("AppendDailySwaps failed: %v", err)
+	}
+	if _, err := storage.CompressOldFiles(storage.ArchiveDir(), 0); err != nil {
+		t.Fatalf("CompressOldFiles failed: %v", err)
+	}
+
+	// Late swaps of compressed day go into the .gz, no plain file shadows it
+	if err := storage.AppendDailySwaps([]flashnet.Swap{swap("late")}); err != nil {
+		t.Fatalf("AppendDailySwaps failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("plain file of compressed day was created: %v", err)
+	}
+	assertArchivedSwaps(t, date, "first", "late")
+
+	// Plain file next to .gz (written by older version) is read with it and merged on compression
+	if err := os.WriteFile(path, []byte(`{"id":"legacy"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertArchivedSwaps(t, date, "first", "late", "legacy")
+	if _, err := storage.CompressOldFiles(storage.ArchiveDir(), 0); err != nil {
+		t.Fatalf("CompressOldFiles failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("plain file was not removed after compression: %v", err)
+	}
+	assertArchivedSwaps(t, date, "first", "late", "legacy")
+}
+
+func assertArchivedSwaps(t *testing.T, date string, wantIDs ...string) {
+	t.Helper()
+	swaps, err := storage.LoadDailySwaps(date)
+	if err != nil {
+		t.Fatalf("LoadDailySwaps failed: %v", err)
+	}
+	if len(swaps) != len(wantIDs) {
+		t.Fatalf("archived swaps = %+v, want %v", swaps, wantIDs)
+	}
+	for i, id := range wantIDs {
+		if swaps[i].ID != id {
+			t.Errorf("swap %d = %s, want %s", i, swaps[i].ID, id)
+		}
+	}
+}
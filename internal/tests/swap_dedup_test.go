@@ -0,0 +1,186 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/clients_api/flashnet"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/testutil"
+)
+
+func TestSeenSwaps_Window(t *testing.T) {
+	dataDir, outputDir := t.TempDir(), t.TempDir()
+	paths.Configure(dataDir, outputDir)
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	swap := func(id string) flashnet.Swap { return flashnet.Swap{ID: id} }
+
+	if err := storage.RecordSeenSwaps([]flashnet.Swap{swap("a"), swap("b")}, now); err != nil {
+		t.Fatalf("RecordSeenSwaps failed: %v", err)
+	}
+	unseen, err := storage.UnseenSwaps([]flashnet.Swap{swap("a"), swap("c"), swap("b"), swap("d")})
+	if err != nil || len(unseen) != 2 || unseen[0].ID != "c" || unseen[1].ID != "d" {
+		t.Fatalf("UnseenSwaps = %+v, %v, want c, d", unseen, err)
+	}
+
+	// Index is kept in file: read again after switching output directory back
+	paths.Configure(t.TempDir(), t.TempDir())
+	paths.Configure(dataDir, outputDir)
+	if unseen, _ := storage.UnseenSwaps([]flashnet.Swap{swap("a")}); len(unseen) != 0 {
+		t.Errorf("swap a is unseen after reload: %+v", unseen)
+	}
+
+	// Swaps seen before window are removed
+	if err := storage.RecordSeenSwaps([]flashnet.Swap{swap("c")}, now.Add(storage.SeenSwapsWindow+time.Minute)); err != nil {
+		t.Fatalf("RecordSeenSwaps failed: %v", err)
+	}
+	unseen, _ = storage.UnseenSwaps([]flashnet.Swap{swap("a"), swap("b"), swap("c")})
+	if len(unseen) != 2 || unseen[0].ID != "a" || unseen[1].ID != "b" {
+		t.Errorf("UnseenSwaps = %+v, want a, b out of window", unseen)
+	}
+}
+
+// Burst of swaps above swap_poll_limit: older swaps are fetched by offset, each swap is alerted once
+func TestBigSalesMonitor_E2E_FillsGap(t *testing.T) {
+	env := newE2EEnv(t)
+	bots_monitor.SetSwapPollConfig(bots_monitor.SwapPollConfig{Interval: 20 * time.Millisecond, Limit: 2, IdleAfter: time.Hour})
+	start := time.Now().Add(-time.Hour)
+	env.flashnet.AddSwaps(testutil.BuySwap("gap-0", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 1_500_000, "160000000000", start))
+
+	telegram := testutil.NewFakeTelegram(t)
+	client := env.flashnet.Client()
+	rulesFile := filepath.Join(t.TempDir(), "alert_rules.json")
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunBigSalesBuysMonitor(ctx, telegram.Bot, client, e2eMainChatID, 0.01, nil, "", nil, 0, rulesFile, nil)
+	})
+	telegram.WaitForSent(t, 1, e2eMessageTimeout)
+
+	// 5 swaps at once, newest first: 3 pages of limit 2
+	var burst []flashnet.Swap
+	for i := 5; i >= 1; i-- {
+		burst = append(burst, testutil.BuySwap(fmt.Sprintf("gap-%d", i), e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, int64(i+1)*1_000_000, "160000000000", start.Add(time.Duration(i)*time.Minute)))
+	}
+	env.flashnet.AddSwaps(burst...)
+
+	// Burst may be grouped into one message, every swap is in alerts once
+	alertsText := func() string {
+		var text strings.Builder
+		for _, message := range telegram.Sent() {
+			text.WriteString(message.Text + "\n")
+		}
+		return text.String()
+	}
+	deadline := time.Now().Add(e2eMessageTimeout)
+	for !strings.Contains(alertsText(), "0.02 btc") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(e2eSettleAfterAlert)
+
+	text := alertsText()
+	for i := 2; i <= 6; i++ {
+		if got := strings.Count(text, fmt.Sprintf("0.0%d btc", i)); got != 1 {
+			t.Errorf("swap of 0.0%d btc is in alerts %d times, want once:\n%s", i, got, text)
+		}
+	}
+	// Oldest swap of burst is alerted first
+	if strings.Index(text, "0.02 btc") > strings.Index(text, "0.06 btc") {
+		t.Errorf("burst alerts out of order:\n%s", text)
+	}
+}
+
+// Newest saved swap with malformed time: next pages are not stale and gap is not closed by time
+func TestBigSalesMonitor_E2E_GapWithMalformedTime(t *testing.T) {
+	env := newE2EEnv(t)
+	bots_monitor.SetSwapPollConfig(bots_monitor.SwapPollConfig{Interval: 20 * time.Millisecond, Limit: 2, IdleAfter: time.Hour})
+	start := time.Now().Add(-time.Hour)
+	malformed := testutil.BuySwap("bad-time", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 1_500_000, "160000000000", start)
+	malformed.Timestamp, malformed.CreatedAt = "not-a-time", "yesterday"
+	env.flashnet.AddSwaps(malformed)
+
+	telegram := testutil.NewFakeTelegram(t)
+	client := env.flashnet.Client()
+	rulesFile := filepath.Join(t.TempDir(), "alert_rules.json")
+	runMonitor(t, func(ctx context.Context) {
+		bots_monitor.RunBigSalesBuysMonitor(ctx, telegram.Bot, client, e2eMainChatID, 0.01, nil, "", nil, 0, rulesFile, nil)
+	})
+	telegram.WaitForSent(t, 1, e2eMessageTimeout)
+
+	// 3 swaps at once, newest first: 2 pages of limit 2
+	var burst []flashnet.Swap
+	for i := 3; i >= 1; i-- {
+		burst = append(burst, testutil.BuySwap(fmt.Sprintf("after-bad-%d", i), e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, int64(i+1)*1_000_000, "160000000000", start.Add(time.Duration(i)*time.Minute)))
+	}
+	env.flashnet.AddSwaps(burst...)
+
+	alertsText := func() string {
+		var text strings.Builder
+		for _, message := range telegram.Sent() {
+			text.WriteString(message.Text + "\n")
+		}
+		return text.String()
+	}
+	deadline := time.Now().Add(e2eMessageTimeout)
+	for !strings.Contains(alertsText(), "0.02 btc") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(e2eSettleAfterAlert)
+
+	text := alertsText()
+	for i := 2; i <= 4; i++ {
+		if got := strings.Count(text, fmt.Sprintf("0.0%d btc", i)); got != 1 {
+			t.Errorf("swap of 0.0%d btc is in alerts %d times, want once:\n%s", i, got, text)
+		}
+	}
+}
+
+// Swaps alerted before restart are not alerted again when saved page is lost
+func TestBigSalesMonitor_E2E_DedupAcrossRestart(t *testing.T) {
+	env := newE2EEnv(t)
+	start := time.Now().Add(-time.Minute)
+	env.flashnet.AddSwaps(testutil.BuySwap("restart-old", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 2_000_000, "160000000000", start))
+	client := env.flashnet.Client()
+	rulesFile := filepath.Join(t.TempDir(), "alert_rules.json")
+
+	run := func(telegram *testutil.FakeTelegram) (stop func()) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bots_monitor.RunBigSalesBuysMonitor(ctx, telegram.Bot, client, e2eMainChatID, 0.01, nil, "", nil, 0, rulesFile, nil)
+		}()
+		return func() {
+			cancel()
+			wg.Wait()
+		}
+	}
+
+	first := testutil.NewFakeTelegram(t)
+	stop := run(first)
+	first.WaitForSent(t, 1, e2eMessageTimeout)
+	time.Sleep(e2eSettleAfterAlert)
+	stop()
+
+	if err := os.Remove(paths.Output("big_sales_module", "100_swaps.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	second := testutil.NewFakeTelegram(t)
+	stop = run(second)
+	defer stop()
+	env.flashnet.AddSwaps(testutil.SellSwap("restart-new", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, "80000000000", 1_500_000, start.Add(time.Second)))
+
+	sent := second.WaitForSent(t, 1, e2eMessageTimeout)
+	time.Sleep(e2eSettleAfterAlert)
+	if got := len(second.Sent()); got != 1 || !strings.Contains(sent[0].Text, "0.015 btc") {
+		t.Errorf("after restart %d alerts sent, want only the new sell: %+v", got, second.Sent())
+	}
+}
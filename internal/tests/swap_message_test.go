@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/swap_templates"
+	"spark-wallet/internal/infra/paths"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// go test ./internal/tests -run TestRenderSwapMessage -update
+var updateGolden = flag.Bool("update", false, "rewrite golden files of swap messages")
+
+const (
+	testPoolLpPublicKey = "03a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+	testSwapperKey      = "02f0e1d2c3b4a5968778695a4b3c2d1e0ff0e1d2c3b4a5968778695a4b3c2d1e0f"
+	testTokenAddress    = "btkn1testtokenaddress"
+	soonPoolLpPublicKey = "021cda97a28df127f41e480ebede196f6f7d46dd6754feab7c228d8273dce6d39e"
+)
+
+func TestRenderSwapMessage(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+
+	cases := []struct {
+		golden string
+		sc     bots_monitor.SwapContext
+	}{
+		{"buy", buySwapContext(testPoolLpPublicKey)},
+		{"sell", sellSwapContext()},
+		{"buy_ru", russianSwapContext(buySwapContext(testPoolLpPublicKey))},
+		{"token_to_token", bots_monitor.SwapContext{
+			Swap: flashnet.Swap{
+				AmountIn:         "500000000000",
+				AmountOut:        "120000000",
+				AssetInAddress:   testTokenAddress,
+				AssetOutAddress:  "btkn1othertokenaddress",
+				CreatedAt:        "2025-01-03T15:04:05Z",
+				FeePaid:          "1500",
+				PoolLpPublicKey:  testPoolLpPublicKey,
+				PoolType:         "CONSTANT_PRODUCT",
+				Price:            "0.00024",
+				SwapperPublicKey: testSwapperKey,
+			},
+			Decimals:    8,
+			BTCPriceUSD: 100000,
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.golden, func(t *testing.T) {
+			text, keyboard := bots_monitor.RenderSwapMessage(tc.sc)
+			assertGolden(t, tc.golden, formatRenderedSwap("", text, keyboard))
+		})
+	}
+}
+
+func TestRenderSwapPhoto_SOON(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	if err := swap_templates.MigrateSOONPhotos(); err != nil {
+		t.Fatalf("MigrateSOONPhotos failed: %v", err)
+	}
+
+	photoURL := swap_templates.PhotoURL(soonPoolLpPublicKey, true)
+	if photoURL == "" {
+		t.Fatal("SOON template has no buy photo")
+	}
+
+	photo := bots_monitor.RenderSwapPhoto(-1003190218710, photoURL, buySwapContext(soonPoolLpPublicKey))
+	if photo.ParseMode != tgbotapi.ModeHTML {
+		t.Errorf("ParseMode = %q, want %q", photo.ParseMode, tgbotapi.ModeHTML)
+	}
+	keyboard, ok := photo.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	if !ok {
+		t.Fatalf("ReplyMarkup is %T, want inline keyboard", photo.ReplyMarkup)
+	}
+	assertGolden(t, "soon_photo", formatRenderedSwap(string(photo.File.(tgbotapi.FileURL)), photo.Caption, keyboard))
+}
+
+func buySwapContext(poolLpPublicKey string) bots_monitor.SwapContext {
+	return bots_monitor.SwapContext{
+		Swap: flashnet.Swap{
+			AmountIn:         "2500000",
+			AmountOut:        "150000000000000",
+			AssetInAddress:   flashnet.NativeTokenAddress,
+			AssetOutAddress:  testTokenAddress,
+			PoolLpPublicKey:  poolLpPublicKey,
+			SwapperPublicKey: testSwapperKey,
+		},
+		Name:         "Test <Token>",
+		Ticker:       "TEST",
+		Decimals:     8,
+		MarketCapUSD: 1250000,
+		BTCPriceUSD:  100000,
+		FirstBuy:     "03.01.2025",
+		Holding:      "1.5M TEST",
+		HoldingValue: "0.025 BTC",
+		Username:     "satoshi",
+		BalanceKnown: true,
+		SparkAddress: "sp1testsparkaddress",
+		BalanceSats:  12345678,
+	}
+}
+
+// russianSwapContext renders swap context in chat with Russian language
+func russianSwapContext(sc bots_monitor.SwapContext) bots_monitor.SwapContext {
+	sc.Lang = i18n.Russian
+	return sc
+}
+
+func sellSwapContext() bots_monitor.SwapContext {
+	return bots_monitor.SwapContext{
+		Swap: flashnet.Swap{
+			AmountIn:         "4200000000000",
+			AmountOut:        "130000",
+			AssetInAddress:   testTokenAddress,
+			AssetOutAddress:  flashnet.NativeTokenAddress,
+			PoolLpPublicKey:  testPoolLpPublicKey,
+			SwapperPublicKey: testSwapperKey,
+		},
+		Name:           "Test <Token>",
+		Ticker:         "TEST",
+		Decimals:       8,
+		Holding:        "null",
+		HoldingValue:   "0 BTC",
+		Label:          "MM <bot>",
+		WhaleBadge:     "🐋 Whale: 5.2% of supply\n",
+		FundingWarning: "⚠️ Funded by flagged wallet\n",
+	}
+}
+
+// formatRenderedSwap writes photo, message and buttons as plain text for golden file
+func formatRenderedSwap(photoURL string, text string, keyboard tgbotapi.InlineKeyboardMarkup) string {
+	var b strings.Builder
+	if photoURL != "" {
+		b.WriteString("photo: " + photoURL + "\n")
+	}
+	b.WriteString(text)
+	b.WriteString("\n--- buttons ---\n")
+	for _, row := range keyboard.InlineKeyboard {
+		for _, button := range row {
+			url := ""
+			if button.URL != nil {
+				url = *button.URL
+			}
+			b.WriteString(button.Text + " -> " + url + "\n")
+		}
+	}
+	return b.String()
+}
+
+func assertGolden(t *testing.T, name string, got string) {
+	t.Helper()
+	goldenPath := filepath.Join("testdata", "swap_messages", name+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+			t.Fatalf("failed to create golden dir: %v", err)
+		}
+		if err := os.WriteFile(goldenPath, []byte(got), 0644); err != nil {
+			t.Fatalf("failed to write golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", goldenPath, got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+)
+
+func TestSwapPoller_SlowsDownWhenIdle(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	poller := bots_monitor.NewSwapPoller(bots_monitor.SwapPollConfig{
+		Interval:     5 * time.Second,
+		Limit:        100,
+		IdleInterval: 30 * time.Second,
+		IdleAfter:    time.Minute,
+	}, start)
+
+	now := start
+	poll := func(newSwaps int) time.Duration {
+		next := poller.Observe(newSwaps, now)
+		now = now.Add(next)
+		return next
+	}
+
+	// Quiet, but not for idle_after yet
+	for now.Sub(start) < time.Minute {
+		if next := poll(0); next != 5*time.Second {
+			t.Fatalf("interval before idle_after = %s, want 5s", next)
+		}
+	}
+
+	var intervals []time.Duration
+	for i := 0; i < 4; i++ {
+		intervals = append(intervals, poll(0))
+	}
+	want := []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second}
+	for i := range want {
+		if intervals[i] != want[i] {
+			t.Fatalf("idle intervals = %v, want %v", intervals, want)
+		}
+	}
+	if !poller.Idle() {
+		t.Fatalf("poller should be idle after quiet period")
+	}
+
+	// Burst: back to base interval at once, and stays there for idle_after
+	if next := poll(3); next != 5*time.Second {
+		t.Fatalf("interval after new swaps = %s, want 5s", next)
+	}
+	if poller.Idle() {
+		t.Fatalf("poller should not be idle after new swaps")
+	}
+	if next := poll(0); next != 5*time.Second {
+		t.Fatalf("interval right after burst = %s, want 5s", next)
+	}
+}
+
+func TestSwapPoller_FixedRate(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	// idle_interval 0 - adaptive polling disabled
+	cfg := bots_monitor.SwapPollConfig{Interval: 5 * time.Second, IdleAfter: time.Second}
+	if cfg.Adaptive() {
+		t.Fatalf("config without idle interval should not be adaptive")
+	}
+	poller := bots_monitor.NewSwapPoller(cfg, start)
+	for i := 1; i <= 20; i++ {
+		if next := poller.Observe(0, start.Add(time.Duration(i)*time.Minute)); next != 5*time.Second {
+			t.Fatalf("fixed rate interval = %s, want 5s", next)
+		}
+	}
+}
+
+func TestSwapPollConfig_Defaults(t *testing.T) {
+	poller := bots_monitor.NewSwapPoller(bots_monitor.SwapPollConfig{}, time.Now())
+	if poller.Interval() != bots_monitor.DefaultSwapPollInterval {
+		t.Fatalf("default interval = %s, want %s", poller.Interval(), bots_monitor.DefaultSwapPollInterval)
+	}
+	if !bots_monitor.DefaultSwapPollConfig().Adaptive() {
+		t.Fatalf("default config should be adaptive")
+	}
+}
@@ -0,0 +1,188 @@
+package tests
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"spark-wallet/bots_monitor"
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/btc_price"
+	"spark-wallet/internal/features/swap_feed"
+	"spark-wallet/internal/features/wallet_labels"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/testutil"
+)
+
+const swapsAPIToken = "swaps-api-test-token"
+
+// archiveSwapsAPISwaps archives buy, sell, buy of other pool and token-to-token swap within hour before now,
+// and a buy of previous day out of the period
+func archiveSwapsAPISwaps(t *testing.T, now time.Time) {
+	t.Helper()
+	tokenSwap := testutil.BuySwap("api-token-swap", e2eOtherPoolKey, e2eOtherToken, e2eSmallSwapperKey, 0, "500", now.Add(-10*time.Minute))
+	tokenSwap.AssetInAddress, tokenSwap.AmountIn = e2eTokenAddress, "700"
+
+	swaps := []flashnet.Swap{
+		testutil.BuySwap("api-buy", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 2_000_000, "160000000000", now.Add(-50*time.Minute)),
+		testutil.SellSwap("api-sell", e2ePoolLpPublicKey, e2eTokenAddress, e2eSmallSwapperKey, "40000000000", 500_000, now.Add(-40*time.Minute)),
+		testutil.BuySwap("api-other", e2eOtherPoolKey, e2eOtherToken, e2eSmallSwapperKey, 1_000_000, "100000000", now.Add(-30*time.Minute)),
+		tokenSwap,
+		testutil.BuySwap("api-old", e2ePoolLpPublicKey, e2eTokenAddress, e2eWhaleSwapperKey, 9_000_000, "1000", now.Add(-25*time.Hour)),
+	}
+	if err := storage.AppendDailySwaps(swaps); err != nil {
+		t.Fatalf("failed to archive swaps: %v", err)
+	}
+	if err := btc_price.RecordPrice(now, 100_000); err != nil {
+		t.Fatalf("failed to record BTC price: %v", err)
+	}
+	if _, err := wallet_labels.Set(wallet_labels.Label{PublicKey: e2eWhaleSwapperKey, Name: "team wallet"}); err != nil {
+		t.Fatalf("failed to label wallet: %v", err)
+	}
+}
+
+func swapFeedIDs(page *swap_feed.Page) []string {
+	ids := make([]string, 0, len(page.Swaps))
+	for _, swap := range page.Swaps {
+		ids = append(ids, swap.ID)
+	}
+	return ids
+}
+
+func TestSwapFeed_Load(t *testing.T) {
+	newE2EEnv(t)
+	now := time.Now().UTC()
+	archiveSwapsAPISwaps(t, now)
+	period := swap_feed.Filter{From: now.Add(-24 * time.Hour), To: now}
+
+	page, err := swap_feed.Load(period, 0, swap_feed.DefaultLimit)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := swapFeedIDs(page); page.Total != 4 || len(got) != 4 || got[0] != "api-token-swap" || got[3] != "api-buy" {
+		t.Fatalf("swaps of last 24h = %v (total %d), want 4 newest first", got, page.Total)
+	}
+
+	buy := page.Swaps[3]
+	if buy.Side != swap_feed.SideBuy || buy.Ticker != "E2E" || buy.TokenAddress != e2eTokenAddress || buy.WalletLabel != "team wallet" {
+		t.Errorf("enriched buy = %+v", buy)
+	}
+	if math.Abs(buy.AmountBTC-0.02) > 1e-9 || math.Abs(buy.ValueUSD-2000) > 1e-6 || math.Abs(buy.AmountToken-1600) > 1e-9 {
+		t.Errorf("buy amounts = %v BTC, $%v, %v tokens, want 0.02 BTC, $2000, 1600 tokens", buy.AmountBTC, buy.ValueUSD, buy.AmountToken)
+	}
+	if sell := page.Swaps[2]; sell.Side != swap_feed.SideSell || math.Abs(sell.AmountBTC-0.005) > 1e-9 || sell.WalletLabel != "" {
+		t.Errorf("enriched sell = %+v", sell)
+	}
+	if swap := page.Swaps[0]; swap.Side != swap_feed.SideSwap || swap.AmountBTC != 0 || swap.Ticker != "" {
+		t.Errorf("token-to-token swap = %+v, want side swap without BTC amount", swap)
+	}
+
+	for name, tc := range map[string]struct {
+		filter swap_feed.Filter
+		want   int
+	}{
+		"ticker":  {swap_feed.Filter{Ticker: "e2e"}, 2},
+		"pool":    {swap_feed.Filter{Pool: e2eOtherPoolKey}, 2},
+		"wallet":  {swap_feed.Filter{Wallet: e2eSmallSwapperKey}, 3},
+		"side":    {swap_feed.Filter{Side: swap_feed.SideSell}, 1},
+		"min BTC": {swap_feed.Filter{MinBTC: 0.01}, 2},
+	} {
+		tc.filter.From, tc.filter.To = period.From, period.To
+		page, err := swap_feed.Load(tc.filter, 0, swap_feed.DefaultLimit)
+		if err != nil || page.Total != tc.want {
+			t.Errorf("%s: %v (total %d, %v), want %d", name, swapFeedIDs(page), page.Total, err, tc.want)
+		}
+	}
+
+	// Second page of one swap, total counts the whole period
+	page, err = swap_feed.Load(period, 1, 1)
+	if got := swapFeedIDs(page); err != nil || page.Total != 4 || len(got) != 1 || got[0] != "api-other" {
+		t.Errorf("page 2 of 1 = %v (total %d, %v), want api-other", got, page.Total, err)
+	}
+	page, err = swap_feed.Load(period, 10, 1)
+	if err != nil || len(page.Swaps) != 0 || page.Total != 4 {
+		t.Errorf("page after the end = %v (total %d, %v)", swapFeedIDs(page), page.Total, err)
+	}
+
+	for name, filter := range map[string]swap_feed.Filter{
+		"empty period": {From: now, To: now},
+		"too long":     {From: now.Add(-40 * 24 * time.Hour), To: now},
+		"unknown side": {From: period.From, To: now, Side: "long"},
+		"negative min": {From: period.From, To: now, MinBTC: -1},
+	} {
+		if _, err := swap_feed.Load(filter, 0, 1); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSwapsAPI_HTTP(t *testing.T) {
+	newE2EEnv(t)
+	now := time.Now().UTC()
+	archiveSwapsAPISwaps(t, now)
+
+	server := httptest.NewServer(bots_monitor.NewSwapsAPIHandler(swapsAPIToken))
+	t.Cleanup(server.Close)
+
+	get := func(query string, token string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/api/swaps"+query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	if resp := get("", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("request without token = %d, want 401", resp.StatusCode)
+	}
+	if resp := get("", "admin-token"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("request with wrong token = %d, want 401", resp.StatusCode)
+	}
+	for _, query := range []string{"?limit=0", "?limit=501", "?offset=-1", "?from=yesterday", "?side=long", "?min_btc=x", "?from=2025-01-01&to=2025-03-01"} {
+		if resp := get(query, swapsAPIToken); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("GET /api/swaps%s = %d, want 400", query, resp.StatusCode)
+		}
+	}
+
+	// Grafana sends period in unix milliseconds
+	from := strconv.FormatInt(now.Add(-time.Hour).UnixMilli(), 10)
+	to := strconv.FormatInt(now.Add(time.Minute).UnixMilli(), 10)
+	resp := get("?from="+from+"&to="+to+"&side=buy&limit=1", swapsAPIToken)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Access-Control-Allow-Origin") != "*" {
+		t.Fatalf("GET /api/swaps = %d, CORS %q", resp.StatusCode, resp.Header.Get("Access-Control-Allow-Origin"))
+	}
+	var page swap_feed.Page
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode swaps: %v", err)
+	}
+	if page.Total != 2 || len(page.Swaps) != 1 || page.Swaps[0].ID != "api-other" || page.Limit != 1 {
+		t.Errorf("buys of last hour = %v (total %d, limit %d), want api-other of 2", swapFeedIDs(&page), page.Total, page.Limit)
+	}
+
+	// Browser preflight goes without token
+	req, err := http.NewRequest(http.MethodOptions, server.URL+"/api/swaps", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	preflight, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("preflight failed: %v", err)
+	}
+	preflight.Body.Close()
+	if preflight.StatusCode != http.StatusNoContent || preflight.Header.Get("Access-Control-Allow-Headers") != "Authorization" {
+		t.Errorf("preflight = %d, allowed headers %q", preflight.StatusCode, preflight.Header.Get("Access-Control-Allow-Headers"))
+	}
+}
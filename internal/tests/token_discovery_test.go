@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+	"spark-wallet/internal/features/holders"
+)
+
+func listedToken(ticker string, pools ...luminex.ListedPool) luminex.ListedToken {
+	return luminex.ListedToken{Ticker: ticker, Name: ticker + " Token", Pools: pools}
+}
+
+func btcPool(tokenAddress string) luminex.ListedPool {
+	return luminex.ListedPool{LpPublicKey: "lp-" + tokenAddress, AssetAAddress: tokenAddress, AssetBAddress: flashnet.NativeTokenAddress}
+}
+
+// editTokenIDs changes ticker of identifier in id_tokens.json by hand, discovery state is kept
+func editTokenIDs(t *testing.T, tokenIdentifier, ticker string) {
+	t.Helper()
+	raw, err := os.ReadFile(holders.TokenIDsFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file map[string]any
+	if err := json.Unmarshal(raw, &file); err != nil {
+		t.Fatal(err)
+	}
+	file["id_tokens"].(map[string]any)[tokenIdentifier] = ticker
+	raw, _ = json.Marshal(file)
+	if err := os.WriteFile(holders.TokenIDsFile(), raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTokenDiscovery(t *testing.T) {
+	env := newE2EEnv(t)
+	now := time.Now()
+
+	// Hand-made file of older versions
+	if err := os.MkdirAll(filepath.Dir(holders.TokenIDsFile()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(holders.TokenIDsFile(), []byte(`{"id_tokens": {"btkn1manual": "MANUAL"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Most traded first: clone of E2E, token-to-token pool and Luminex entry of manual ticker get no identifier
+	tokens := []luminex.ListedToken{
+		listedToken("E2E", btcPool(e2eTokenAddress)),
+		listedToken("Other", luminex.ListedPool{LpPublicKey: "lp-other", AssetAAddress: flashnet.NativeTokenAddress, AssetBAddress: e2eOtherToken}),
+		listedToken("e2e", btcPool("btkn1clone")),
+		listedToken("PAIR", luminex.ListedPool{LpPublicKey: "lp-pair", AssetAAddress: "btkn1pair", AssetBAddress: e2eTokenAddress}),
+		listedToken("MANUAL", btcPool("btkn1manualluminex")),
+	}
+	// Second page
+	for i := 0; i < 120; i++ {
+		tokens = append(tokens, listedToken(fmt.Sprintf("FILL%d", i), btcPool(fmt.Sprintf("btkn1fill%d", i))))
+	}
+	env.luminex.SetListedTokens(tokens)
+
+	result, err := holders.DiscoverTokenIdentifiers(context.Background(), now)
+	if err != nil {
+		t.Fatalf("DiscoverTokenIdentifiers failed: %v", err)
+	}
+	if result.Discovered != 123 || result.Manual != 1 || result.Added != 122 {
+		t.Errorf("first discovery = %+v, want 123 discovered, 1 manual, 122 added", result)
+	}
+
+	ids, err := holders.LoadTokenIdentifiers(holders.TokenIDsFile())
+	if err != nil {
+		t.Fatalf("LoadTokenIdentifiers failed: %v", err)
+	}
+	for tokenIdentifier, want := range map[string]string{
+		e2eTokenAddress: "E2E", e2eOtherToken: "OTHER", "btkn1manual": "MANUAL", "btkn1fill119": "FILL119",
+		"btkn1clone": "", "btkn1pair": "", "btkn1manualluminex": "",
+	} {
+		if got := ids[tokenIdentifier]; got != want {
+			t.Errorf("id_tokens[%s] = %q, want %q", tokenIdentifier, got, want)
+		}
+	}
+	if ticker, err := holders.GetTickerFromTokenAddress(e2eTokenAddress); err != nil || ticker != "E2E" {
+		t.Errorf("GetTickerFromTokenAddress = %q, %v, want E2E", ticker, err)
+	}
+
+	// Ticker edited by hand survives refresh, token gone from Luminex is removed
+	editTokenIDs(t, e2eOtherToken, "OTHERX")
+	env.luminex.SetListedTokens(tokens[1:])
+	result, err = holders.DiscoverTokenIdentifiers(context.Background(), now)
+	if err != nil {
+		t.Fatalf("DiscoverTokenIdentifiers failed: %v", err)
+	}
+	if result.Manual != 2 || result.Removed != 1 || result.Added != 1 {
+		t.Errorf("refresh = %+v, want 2 manual, 1 removed, 1 added (clone)", result)
+	}
+	ids, _ = holders.LoadTokenIdentifiers(holders.TokenIDsFile())
+	// Clone of E2E takes ticker once the original is gone
+	if ids[e2eOtherToken] != "OTHERX" || ids[e2eTokenAddress] != "" || ids["btkn1clone"] != "E2E" || ids["btkn1manual"] != "MANUAL" {
+		t.Errorf("id_tokens after refresh = %v", ids)
+	}
+
+	// Empty listing keeps the file
+	env.luminex.SetListedTokens([]luminex.ListedToken{})
+	if _, err := holders.DiscoverTokenIdentifiers(context.Background(), now); err == nil {
+		t.Error("expected error of empty listing")
+	}
+	if kept, _ := holders.LoadTokenIdentifiers(holders.TokenIDsFile()); len(kept) != len(ids) {
+		t.Errorf("id_tokens after empty listing has %d entries, want %d", len(kept), len(ids))
+	}
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/token_info"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/testutil"
+)
+
+const (
+	tokenInfoPool      = "03d160000000000000000000000000000000000000000000000000000000000001"
+	tokenInfoOtherPool = "03d160000000000000000000000000000000000000000000000000000000000002"
+	tokenInfoToken     = "btkn1tokeninfotoken"
+)
+
+func TestLoadTokenActivity(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	swaps := []flashnet.Swap{
+		// Two buys of one wallet and a sell of another wallet, one buy is from previous day
+		testutil.BuySwap("buy-1", tokenInfoPool, tokenInfoToken, "wallet-a", 1_000_000, "1000", now.Add(-time.Hour)),
+		testutil.BuySwap("buy-2", tokenInfoPool, tokenInfoToken, "wallet-a", 500_000, "500", now.Add(-20*time.Hour)),
+		testutil.SellSwap("sell-1", tokenInfoPool, tokenInfoToken, "wallet-b", "800", 2_000_000, now.Add(-2*time.Hour)),
+		// Older than 24h and other pool are not counted
+		testutil.BuySwap("old", tokenInfoPool, tokenInfoToken, "wallet-c", 9_000_000, "1000", now.Add(-25*time.Hour)),
+		testutil.BuySwap("other", tokenInfoOtherPool, "btkn1other", "wallet-d", 9_000_000, "1000", now.Add(-time.Hour)),
+	}
+	if err := storage.AppendDailySwaps(swaps); err != nil {
+		t.Fatalf("failed to archive swaps: %v", err)
+	}
+
+	activity, err := token_info.LoadActivity(tokenInfoPool, now)
+	if err != nil {
+		t.Fatalf("LoadActivity failed: %v", err)
+	}
+	if activity.Buys != 2 || activity.Sells != 1 || activity.Wallets != 2 {
+		t.Errorf("activity = %d buys, %d sells, %d wallets, want 2, 1, 2", activity.Buys, activity.Sells, activity.Wallets)
+	}
+	if math.Abs(activity.BuyBTC-0.015) > 1e-9 || math.Abs(activity.SellBTC-0.02) > 1e-9 || math.Abs(activity.VolumeBTC()-0.035) > 1e-9 {
+		t.Errorf("volume = %v buys, %v sells, want 0.015, 0.02", activity.BuyBTC, activity.SellBTC)
+	}
+
+	// Pool without swaps
+	empty, err := token_info.LoadActivity(tokenInfoOtherPool, now.Add(48*time.Hour))
+	if err != nil || empty.Swaps() != 0 {
+		t.Errorf("activity without swaps = %+v, %v", empty, err)
+	}
+}
+
+func TestTokenPoolAge(t *testing.T) {
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	for createdAt, want := range map[string]string{
+		"2025-12-10T11:30:00Z": "<1h",
+		"2025-12-10T07:00:00Z": "5h",
+		"2025-12-08T08:00:00Z": "2d 4h",
+		"2025-12-03T12:00:00Z": "7d",
+		"2025-10-01T12:00:00Z": "70d",
+		"2023-09-01T12:00:00Z": "2y 3mo",
+	} {
+		age, ok := token_info.PoolAge(createdAt, now)
+		if got := token_info.FormatAge(age); !ok || got != want {
+			t.Errorf("age of pool created %s = %q, %v, want %q", createdAt, got, ok, want)
+		}
+	}
+	for _, createdAt := range []string{"", "not a date", "2025-12-11T00:00:00Z"} {
+		if _, ok := token_info.PoolAge(createdAt, now); ok {
+			t.Errorf("PoolAge(%q) expected unknown age", createdAt)
+		}
+	}
+
+	if got := token_info.ExplorerURL(tokenInfoToken); got != "https://www.sparkscan.io/token/btkn1tokeninfotoken?network=mainnet" {
+		t.Errorf("ExplorerURL = %q", got)
+	}
+	if got := token_info.ExplorerURL(""); got != "" {
+		t.Errorf("ExplorerURL of unknown token = %q, want empty", got)
+	}
+}
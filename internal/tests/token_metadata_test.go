@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/luminex"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/testutil"
+)
+
+const (
+	metadataLegacyPool = "03c0000000000000000000000000000000000000000000000000000000000000a1"
+	metadataFreshPool  = "03c0000000000000000000000000000000000000000000000000000000000000a2"
+	metadataStalePool  = "03c0000000000000000000000000000000000000000000000000000000000000a3"
+	metadataToken      = "btkn1metadatatoken"
+)
+
+func TestTokenMetadataCache_TTLAndRefresh(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	server := testutil.NewLuminexServer(t)
+
+	renamed := testutil.TokenPool(metadataLegacyPool, metadataToken, "NEW", "New Name")
+	renamed.TokenAMetadata.Decimals = 6
+	server.AddPool(renamed)
+	server.AddPool(testutil.TokenPool(metadataFreshPool, "btkn1fresh", "FRESH2", "Fresh Renamed"))
+
+	// ticker:name entry of older version, fresh entry and stale entry of pool unknown to Luminex
+	saved := map[string]interface{}{
+		"tickets": map[string]string{metadataLegacyPool: "OLD:Old Name"},
+		"tokens": map[string]luminex.TokenMetadata{
+			metadataFreshPool: {Ticker: "FRESH", Name: "Fresh", Decimals: 8, FetchedAt: time.Now().UTC().Format(time.RFC3339)},
+			metadataStalePool: {Ticker: "STALE", Name: "Stale", FetchedAt: time.Now().Add(-2 * luminex.TokenMetadataTTL).UTC().Format(time.RFC3339)},
+		},
+	}
+	raw, _ := json.Marshal(saved)
+	if err := os.WriteFile(luminex.TokenCacheFile(), raw, 0644); err != nil {
+		t.Fatalf("failed to write token cache: %v", err)
+	}
+
+	// Entry without fetch time is refreshed with decimals and token address
+	metadata := luminex.GetTokenMetadata(metadataLegacyPool)
+	if metadata == nil || metadata.Ticker != "NEW" || metadata.Decimals != 6 || metadata.TokenAddress != metadataToken {
+		t.Fatalf("legacy entry = %+v, want NEW with 6 decimals", metadata)
+	}
+	swap := testutil.BuySwap("metadata-swap", metadataLegacyPool, metadataToken, testSwapperKey, 1000, "1000000", time.Now())
+	if decimals := luminex.GetTokenDecimals(metadataLegacyPool, swap, "NEW"); decimals != 6 {
+		t.Errorf("GetTokenDecimals = %d, want 6", decimals)
+	}
+	if pool, err := storage.FindPoolLpPublicKeyByTicker("NEW"); err != nil || pool != metadataLegacyPool {
+		t.Errorf("FindPoolLpPublicKeyByTicker(NEW) = %s, %v", pool, err)
+	}
+
+	// Fresh entry is served from cache
+	if metadata := luminex.GetTokenMetadata(metadataFreshPool); metadata == nil || metadata.Ticker != "FRESH" {
+		t.Errorf("fresh entry = %+v, want cached FRESH", metadata)
+	}
+	if got := server.Requests("/spark/pool/" + metadataFreshPool); got != 0 {
+		t.Errorf("fresh entry requested %d times", got)
+	}
+
+	// Stale entry is kept when Luminex fails, and not requested again right away
+	if metadata := luminex.GetTokenMetadata(metadataStalePool); metadata == nil || metadata.Ticker != "STALE" {
+		t.Errorf("stale entry on failure = %+v, want STALE", metadata)
+	}
+	requests := server.Requests("/spark/pool/" + metadataStalePool)
+	luminex.GetTokenMetadata(metadataStalePool)
+	if got := server.Requests("/spark/pool/" + metadataStalePool); got != requests {
+		t.Errorf("failed refresh retried immediately: %d requests, want %d", got, requests)
+	}
+
+	// Forced refresh (/flashrefresh) bypasses TTL
+	previous, refreshed, err := luminex.RefreshTokenMetadata(metadataFreshPool)
+	if err != nil {
+		t.Fatalf("RefreshTokenMetadata failed: %v", err)
+	}
+	if previous == nil || previous.Ticker != "FRESH" || refreshed.Ticker != "FRESH2" {
+		t.Errorf("refresh = %+v -> %+v, want FRESH -> FRESH2", previous, refreshed)
+	}
+	if metadata := luminex.GetCachedTokenMetadata(metadataFreshPool); metadata == nil || metadata.Ticker != "FRESH2" {
+		t.Errorf("cache after refresh = %+v", metadata)
+	}
+	if _, _, err := luminex.RefreshTokenMetadata(metadataStalePool); err == nil {
+		t.Errorf("RefreshTokenMetadata of unknown pool succeeded")
+	}
+	if metadata := luminex.GetCachedTokenMetadata(metadataStalePool); metadata == nil || metadata.Ticker != "STALE" {
+		t.Errorf("failed refresh dropped cached entry: %+v", metadata)
+	}
+}
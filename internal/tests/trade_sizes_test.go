@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/i18n"
+	"spark-wallet/internal/features/trade_sizes"
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+	"spark-wallet/internal/testutil"
+)
+
+const (
+	tradeSizesPool      = "03d150000000000000000000000000000000000000000000000000000000000001"
+	tradeSizesOtherPool = "03d150000000000000000000000000000000000000000000000000000000000002"
+	tradeSizesToken     = "btkn1tradesizestoken"
+)
+
+func TestParseTradeSizesPeriod(t *testing.T) {
+	for input, want := range map[string]trade_sizes.Period{"": trade_sizes.Period24h, "24h": trade_sizes.Period24h, "7D": trade_sizes.Period7d, "week": trade_sizes.Period7d} {
+		if period, err := trade_sizes.ParsePeriod(input); err != nil || period != want {
+			t.Errorf("ParsePeriod(%q) = %v, %v, want %v", input, period, err, want)
+		}
+	}
+	if _, err := trade_sizes.ParsePeriod("30d"); err == nil {
+		t.Error("ParsePeriod(30d) expected error")
+	}
+}
+
+func TestLoadTradeSizesReport(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	buy := func(id string, sats int64, at time.Time) flashnet.Swap {
+		return testutil.BuySwap(id, tradeSizesPool, tradeSizesToken, "wallet-"+id, sats, "1000", at)
+	}
+	sell := func(id string, sats int64, at time.Time) flashnet.Swap {
+		return testutil.SellSwap(id, tradeSizesPool, tradeSizesToken, "wallet-"+id, "1000", sats, at)
+	}
+	swaps := []flashnet.Swap{
+		// Retail: two buys below 0.001 btc and a sell at 0.005 btc
+		buy("tiny-1", 50_000, now.Add(-time.Hour)),
+		buy("tiny-2", 50_000, now.Add(-20*time.Hour)),
+		sell("small", 500_000, now.Add(-2*time.Hour)),
+		// 0.01 btc is in 0.01–0.1 bucket, whale buy above 0.1 btc
+		sell("mid", 1_000_000, now.Add(-3*time.Hour)),
+		buy("whale", 20_000_000, now.Add(-5*time.Hour)),
+		// Older than 24h, other pool and token-to-token swap are not counted in 24h
+		buy("old", 20_000_000, now.Add(-30*time.Hour)),
+		testutil.BuySwap("other", tradeSizesOtherPool, "btkn1other", "wallet-other", 20_000_000, "1000", now.Add(-time.Hour)),
+	}
+	tokenSwap := buy("token-to-token", 5_000_000, now.Add(-time.Hour))
+	tokenSwap.AssetInAddress = "btkn1other"
+	swaps = append(swaps, tokenSwap)
+	if err := storage.AppendDailySwaps(swaps); err != nil {
+		t.Fatalf("failed to archive swaps: %v", err)
+	}
+
+	report, err := trade_sizes.LoadReport(tradeSizesPool, trade_sizes.Period24h, now)
+	if err != nil {
+		t.Fatalf("LoadReport failed: %v", err)
+	}
+	report.Ticker = "SIZE"
+
+	want := []struct {
+		label       string
+		buys, sells int
+	}{
+		{"<0.001", 2, 0},
+		{"0.001–0.01", 0, 1},
+		{"0.01–0.1", 0, 1},
+		{">0.1", 1, 0},
+	}
+	if len(report.Buckets) != len(want) {
+		t.Fatalf("%d buckets, want %d", len(report.Buckets), len(want))
+	}
+	for i, w := range want {
+		bucket := report.Buckets[i]
+		if bucket.Label() != w.label || bucket.Buys != w.buys || bucket.Sells != w.sells {
+			t.Errorf("bucket %d = %s %d buys %d sells, want %s %d buys %d sells",
+				i, bucket.Label(), bucket.Buys, bucket.Sells, w.label, w.buys, w.sells)
+		}
+	}
+	if report.Swaps() != 5 || math.Abs(report.VolumeBTC()-0.216) > 1e-9 {
+		t.Errorf("report has %d swaps, %v btc, want 5 swaps, 0.216 btc", report.Swaps(), report.VolumeBTC())
+	}
+	if share := report.VolumeShare(0.1, 0); share < 92.5 || share > 92.6 {
+		t.Errorf("whale share = %.2f%%, want 92.59%%", share)
+	}
+
+	text := trade_sizes.Format(report, i18n.English)
+	for _, part := range []string{"{SIZE} trade sizes for 24h: 5 swaps, 0.216 btc", "<code>&lt;0.001</code>", "above 0.1 btc: 93% of volume", "below 0.01 btc: 3% of volume"} {
+		if !strings.Contains(text, part) {
+			t.Errorf("report has no %q:\n%s", part, text)
+		}
+	}
+
+	// 7 days include older whale buy
+	week, err := trade_sizes.LoadReport(tradeSizesPool, trade_sizes.Period7d, now)
+	if err != nil {
+		t.Fatalf("LoadReport 7d failed: %v", err)
+	}
+	if week.Buckets[3].Buys != 2 {
+		t.Errorf("7d whale buys = %d, want 2", week.Buckets[3].Buys)
+	}
+}
@@ -0,0 +1,185 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/features/trading"
+)
+
+const tradingTokenAddress = "03aa00000000000000000000000000000000000000000000000000000000000001"
+
+func TestSafeGuard_Limits(t *testing.T) {
+	guard := trading.SafeGuard{Limits: trading.Limits{MaxSlippageBps: 100, MaxAmountSats: 50_000, MaxPriceImpactPercent: 5}}
+	buy := trading.Order{PoolID: "pool", AssetInAddress: flashnet.NativeTokenAddress, AssetOutAddress: tradingTokenAddress, AmountIn: "40000", SlippageBps: 50}
+
+	if err := guard.CheckOrder(buy); err != nil {
+		t.Fatalf("order within limits rejected: %v", err)
+	}
+
+	tooBig := buy
+	tooBig.AmountIn = "60000"
+	if err := guard.CheckOrder(tooBig); err == nil {
+		t.Errorf("buy above max size accepted")
+	}
+
+	slippage := buy
+	slippage.SlippageBps = 150
+	if err := guard.CheckOrder(slippage); err == nil {
+		t.Errorf("order above max slippage accepted")
+	}
+
+	tokenToToken := buy
+	tokenToToken.AssetInAddress = "03bb00000000000000000000000000000000000000000000000000000000000002"
+	if err := guard.CheckOrder(tokenToToken); err == nil {
+		t.Errorf("token-to-token order accepted")
+	}
+
+	// Sell is sized by BTC out of quote
+	sell := trading.Order{PoolID: "pool", AssetInAddress: tradingTokenAddress, AssetOutAddress: flashnet.NativeTokenAddress, AmountIn: "123456789000", SlippageBps: 100}
+	if err := guard.CheckOrder(sell); err != nil {
+		t.Fatalf("sell rejected before quote: %v", err)
+	}
+	if _, err := guard.CheckQuote(sell, &flashnet.SimulateSwapResponse{AmountOut: "70000", PriceImpactPct: "1.2"}); err == nil {
+		t.Errorf("sell above max size accepted")
+	}
+	if _, err := guard.CheckQuote(sell, &flashnet.SimulateSwapResponse{AmountOut: "30000", PriceImpactPct: "7.5"}); err == nil {
+		t.Errorf("quote above max price impact accepted")
+	}
+	sats, err := guard.CheckQuote(sell, &flashnet.SimulateSwapResponse{AmountOut: "30000", PriceImpactPct: "1.2"})
+	if err != nil || sats != 30000 {
+		t.Errorf("CheckQuote = %d, %v, want 30000 sats", sats, err)
+	}
+}
+
+func TestMinAmountOut(t *testing.T) {
+	got, err := trading.MinAmountOut("123456789", 100)
+	if err != nil {
+		t.Fatalf("MinAmountOut failed: %v", err)
+	}
+	// 123456789 * 0.99 rounded down
+	if got != "122222221" {
+		t.Errorf("MinAmountOut = %s, want 122222221", got)
+	}
+}
+
+func TestTrader_ExecuteGatedAndNotRetried(t *testing.T) {
+	var simulations, executions int32
+	var executed flashnet.ExecuteSwapRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/swap/simulate":
+			atomic.AddInt32(&simulations, 1)
+			w.Write([]byte(`{"amountOut":"1000000","executionPrice":"25","feePaidAssetIn":"400","priceImpactPct":"0.8"}`))
+		case r.Method == "POST" && r.URL.Path == "/swap":
+			if atomic.AddInt32(&executions, 1) == 1 {
+				// Not idempotent - must not be retried
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"error":"busy"}`))
+				return
+			}
+			json.NewDecoder(r.Body).Decode(&executed)
+			w.Write([]byte(`{"requestId":"r1","accepted":true,"amountOut":"995000"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := flashnet.NewAMMClient("mainnet")
+	client.SetBaseURL(server.URL)
+	client.SetRetry(3, time.Millisecond, 10*time.Millisecond, 2.0)
+	signer, err := flashnet.NewSigner(strings.Repeat("11", 32))
+	if err != nil {
+		t.Fatalf("NewSigner failed: %v", err)
+	}
+	client.SetSigner(signer)
+
+	previous := trading.GetLimits()
+	t.Cleanup(func() { trading.SetLimits(previous) })
+
+	order := trading.Order{
+		PoolID:                 "pool",
+		AssetInAddress:         flashnet.NativeTokenAddress,
+		AssetOutAddress:        tradingTokenAddress,
+		AmountIn:               "40000",
+		SlippageBps:            50,
+		AssetInSparkTransferID: "transfer-1",
+	}
+	trader := trading.NewTrader(client)
+
+	trading.SetLimits(trading.DefaultLimits())
+	if _, err := trader.Execute(context.Background(), order); !errors.Is(err, trading.ErrTradingDisabled) {
+		t.Fatalf("Execute with trading disabled = %v, want ErrTradingDisabled", err)
+	}
+	if quote, err := trader.Quote(context.Background(), order); err != nil || quote.MinAmountOut != "995000" {
+		t.Fatalf("Quote with trading disabled = %+v, %v", quote, err)
+	}
+	if atomic.LoadInt32(&executions) != 0 {
+		t.Fatalf("swap was sent with trading disabled")
+	}
+
+	limits := trading.DefaultLimits()
+	limits.Enabled = true
+	trading.SetLimits(limits)
+	if _, err := trader.Execute(context.Background(), order); err == nil {
+		t.Fatalf("Execute succeeded on 503")
+	}
+	if got := atomic.LoadInt32(&executions); got != 1 {
+		t.Fatalf("swap sent %d times after 503, want 1", got)
+	}
+
+	result, err := trader.Execute(context.Background(), order)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !result.Accepted || result.AmountOut != "995000" {
+		t.Errorf("result = %+v", result)
+	}
+	if executed.MinAmountOut != "995000" || executed.MaxSlippageBps != "50" {
+		t.Errorf("minAmountOut = %s, maxSlippageBps = %s", executed.MinAmountOut, executed.MaxSlippageBps)
+	}
+	if executed.Signature == "" || executed.Nonce == "" || executed.UserPublicKey != signer.PublicKey() {
+		t.Errorf("swap is not signed: %+v", executed)
+	}
+}
+
+func TestTightenLimits_ReloadOnlyTightens(t *testing.T) {
+	previous := trading.GetLimits()
+	t.Cleanup(func() { trading.SetLimits(previous) })
+
+	trading.SetLimits(trading.Limits{MaxSlippageBps: 100, MaxAmountSats: 100_000, MaxPriceImpactPercent: 5})
+
+	// Enabling trading and raising limits are ignored
+	applied := trading.TightenLimits(trading.Limits{Enabled: true, MaxSlippageBps: 500, MaxAmountSats: 10_000_000})
+	if applied.Enabled || applied.MaxSlippageBps != 100 || applied.MaxAmountSats != 100_000 || applied.MaxPriceImpactPercent != 5 {
+		t.Errorf("limits after loosening reload = %+v, want unchanged", applied)
+	}
+
+	// Lower caps are applied
+	applied = trading.TightenLimits(trading.Limits{MaxSlippageBps: 50, MaxAmountSats: 20_000, MaxPriceImpactPercent: 2})
+	if applied.MaxSlippageBps != 50 || applied.MaxAmountSats != 20_000 || applied.MaxPriceImpactPercent != 2 {
+		t.Errorf("limits after tightening reload = %+v", applied)
+	}
+	if trading.GetLimits() != applied {
+		t.Errorf("GetLimits = %+v, want %+v", trading.GetLimits(), applied)
+	}
+
+	// Price impact check can't be turned off by reload, but can be turned on
+	if applied = trading.TightenLimits(trading.Limits{}); applied.MaxPriceImpactPercent != 2 {
+		t.Errorf("price impact after reload with 0 = %v, want 2", applied.MaxPriceImpactPercent)
+	}
+	trading.SetLimits(trading.Limits{MaxSlippageBps: 100, MaxAmountSats: 100_000})
+	if applied = trading.TightenLimits(trading.Limits{MaxPriceImpactPercent: 3}); applied.MaxPriceImpactPercent != 3 {
+		t.Errorf("price impact after reload with 3 = %v, want 3", applied.MaxPriceImpactPercent)
+	}
+}
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/infra/ttlcache"
+)
+
+// fakeCacheClock - settable clock of cache tests
+type fakeCacheClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeCacheClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeCacheClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestCache(clock *fakeCacheClock, fetch ttlcache.FetchFunc[string]) *ttlcache.Cache[string] {
+	return ttlcache.New(ttlcache.Options{
+		TTL:          time.Hour,
+		NegativeTTL:  10 * time.Minute,
+		ErrorTTL:     time.Minute,
+		RefreshAfter: 30 * time.Minute,
+		MaxEntries:   100,
+		Now:          clock.Now,
+	}, fetch)
+}
+
+func TestTTLCache_NegativeAndErrorResults(t *testing.T) {
+	clock := &fakeCacheClock{now: time.Date(2025, time.January, 3, 12, 0, 0, 0, time.UTC)}
+	var calls int32
+	failing := true
+	cache := newTestCache(clock, func(ctx context.Context, key string) (string, bool, error) {
+		atomic.AddInt32(&calls, 1)
+		switch {
+		case key == "nobody":
+			return "", false, nil
+		case failing:
+			return "", false, errors.New("cloudflare challenge")
+		}
+		return "satoshi", true, nil
+	})
+	ctx := context.Background()
+
+	// No profile is cached for NegativeTTL
+	for i := 0; i < 3; i++ {
+		if value, err := cache.Get(ctx, "nobody"); value != "" || err != nil {
+			t.Fatalf("Get(nobody) = %q, %v", value, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("%d fetches of negative result, want 1", calls)
+	}
+	clock.Advance(11 * time.Minute)
+	cache.Get(ctx, "nobody")
+	if calls != 2 {
+		t.Errorf("%d fetches after NegativeTTL, want 2", calls)
+	}
+
+	// Failure is cached for ErrorTTL, then key is fetched again
+	calls = 0
+	for i := 0; i < 3; i++ {
+		if _, err := cache.Get(ctx, "02aa"); err == nil {
+			t.Fatal("expected cached error")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("%d fetches of failed key, want 1", calls)
+	}
+	failing = false
+	clock.Advance(2 * time.Minute)
+	if value, err := cache.Get(ctx, "02aa"); value != "satoshi" || err != nil {
+		t.Errorf("Get after ErrorTTL = %q, %v", value, err)
+	}
+}
+
+func TestTTLCache_BackgroundRefresh(t *testing.T) {
+	clock := &fakeCacheClock{now: time.Date(2025, time.January, 3, 12, 0, 0, 0, time.UTC)}
+	var calls int32
+	refreshed := make(chan struct{}, 1)
+	cache := newTestCache(clock, func(ctx context.Context, key string) (string, bool, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return "old", true, nil
+		}
+		defer func() { refreshed <- struct{}{} }()
+		return "new", true, nil
+	})
+	ctx := context.Background()
+
+	cache.Get(ctx, "02aa")
+	clock.Advance(40 * time.Minute)
+	// Stale value is returned at once, refresh runs in background
+	if value, _ := cache.Get(ctx, "02aa"); value != "old" {
+		t.Errorf("Get after RefreshAfter = %q, want old", value)
+	}
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("value was not refreshed")
+	}
+	// Wait for refreshed value to be stored
+	deadline := time.Now().Add(time.Second)
+	for {
+		if value, _ := cache.Get(ctx, "02aa"); value == "new" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("refreshed value was not stored")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("%d fetches, want 2", got)
+	}
+}
+
+func TestTTLCache_ConcurrentMisses(t *testing.T) {
+	clock := &fakeCacheClock{now: time.Date(2025, time.January, 3, 12, 0, 0, 0, time.UTC)}
+	var calls int32
+	release := make(chan struct{})
+	cache := newTestCache(clock, func(ctx context.Context, key string) (string, bool, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "satoshi", true, nil
+	})
+
+	// Burst of alerts of one wallet
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, err := cache.Get(context.Background(), "02aa"); value != "satoshi" || err != nil {
+				t.Errorf("Get = %q, %v", value, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("%d fetches for concurrent misses, want 1", got)
+	}
+}
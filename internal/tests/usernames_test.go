@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	storage "spark-wallet/internal/infra/fs"
+	"spark-wallet/internal/infra/paths"
+)
+
+func TestUsernames_LoadErrorKeepsTable(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	if err := os.MkdirAll(filepath.Dir(storage.UsernamesFile()), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unreadable file: writes fail instead of replacing the table
+	if err := os.Mkdir(storage.UsernamesFile(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.RecordSeenWallets([]string{"wallet-new"}); err == nil {
+		t.Error("RecordSeenWallets succeeded with unreadable table")
+	}
+	if err := storage.UpdateUsernames([]string{"wallet-new"}, map[string]string{"wallet-new": "alice"}); err == nil {
+		t.Error("UpdateUsernames succeeded with unreadable table")
+	}
+	if info, err := os.Stat(storage.UsernamesFile()); err != nil || !info.IsDir() {
+		t.Fatalf("unreadable table was replaced: %v", err)
+	}
+	if err := os.Remove(storage.UsernamesFile()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt file is moved aside before a new table is written
+	if err := os.WriteFile(storage.UsernamesFile(), []byte(`{"wallets": {"wallet-old": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.RecordSeenWallets([]string{"wallet-new"}); err != nil {
+		t.Fatalf("RecordSeenWallets failed: %v", err)
+	}
+	moved, _ := filepath.Glob(storage.UsernamesFile() + ".corrupt-*")
+	if len(moved) != 1 {
+		t.Fatalf("corrupt files = %v, want one moved aside", moved)
+	}
+	if raw, _ := os.ReadFile(moved[0]); string(raw) != `{"wallets": {"wallet-old": ` {
+		t.Errorf("moved file = %q, want original content", raw)
+	}
+	table, err := storage.LoadUsernames()
+	if err != nil || len(table.Wallets) != 1 || table.Wallets["wallet-new"].LastSeen == "" {
+		t.Errorf("table after recovery = %+v, %v", table, err)
+	}
+}
+
+func TestUsernames_Prune(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+	now := time.Now()
+	old := now.Add(-storage.UsernamesRetention - time.Hour).Format(time.RFC3339)
+	recent := now.Add(-time.Hour).Format(time.RFC3339)
+
+	table := &storage.UsernamesData{Wallets: map[string]storage.UsernameEntry{
+		"wallet-gone":     {Username: "bob", LastSeen: old, SyncedAt: old},
+		"wallet-seen":     {Username: "alice", LastSeen: recent, SyncedAt: old},
+		"wallet-resolved": {Username: "carol", SyncedAt: recent}, // resolved by balance lookup, never seen on swaps
+		"wallet-invalid":  {LastSeen: "yesterday"},
+	}}
+	if err := storage.SaveUsernames(table); err != nil {
+		t.Fatal(err)
+	}
+
+	pruned, err := storage.PruneUsernames(now, storage.UsernamesRetention)
+	if err != nil || pruned != 2 {
+		t.Fatalf("PruneUsernames = %d, %v, want 2", pruned, err)
+	}
+	table, _ = storage.LoadUsernames()
+	if len(table.Wallets) != 2 || table.Wallets["wallet-seen"].Username != "alice" || table.Wallets["wallet-resolved"].Username != "carol" {
+		t.Errorf("table after prune = %+v", table.Wallets)
+	}
+}
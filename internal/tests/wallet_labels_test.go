@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/features/wallet_labels"
+	"spark-wallet/internal/infra/paths"
+)
+
+func TestWalletLabels(t *testing.T) {
+	paths.Configure(t.TempDir(), t.TempDir())
+
+	const (
+		teamWallet = "02aa11bb22cc33dd44ee55ff66778899aabbccddeeff00112233445566778899e0f"
+		otherE0f   = "03bb11bb22cc33dd44ee55ff66778899aabbccddeeff00112233445566778800e0f"
+		seenWallet = "0299887766554433221100ffeeddccbbaa99887766554433221100ffeeddcc123"
+	)
+
+	added, err := wallet_labels.Set(wallet_labels.Label{PublicKey: teamWallet, Name: "  team   wallet "})
+	if err != nil || !added {
+		t.Fatalf("Set = %v, %v", added, err)
+	}
+	if got := wallet_labels.Of(teamWallet); got != "team wallet" {
+		t.Errorf("Of = %q, want %q", got, "team wallet")
+	}
+	if added, err := wallet_labels.Set(wallet_labels.Label{PublicKey: teamWallet, Name: "MM bot"}); err != nil || added {
+		t.Errorf("second Set = %v, %v, want update", added, err)
+	}
+
+	// Suffix matches labeled and seen wallets
+	matches, err := wallet_labels.MatchSuffix("123", []string{seenWallet})
+	if err != nil || len(matches) != 1 || matches[0] != seenWallet {
+		t.Errorf("MatchSuffix(123) = %v, %v", matches, err)
+	}
+	matches, err = wallet_labels.MatchSuffix("E0F", []string{otherE0f})
+	if err != nil || len(matches) != 2 {
+		t.Errorf("MatchSuffix(E0F) = %v, %v, want 2 wallets", matches, err)
+	}
+	if _, err := wallet_labels.MatchSuffix("0f", nil); err == nil {
+		t.Error("MatchSuffix of 2 characters expected error")
+	}
+
+	// Hand edit of file is picked up (mtime moved so cache sees the change)
+	raw := `{"labels":{"` + seenWallet + `":{"name":"whale #3"}}}`
+	if err := os.WriteFile(wallet_labels.LabelsFile(), []byte(raw), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(wallet_labels.LabelsFile(), future, future); err != nil {
+		t.Fatal(err)
+	}
+	if got := wallet_labels.Of(seenWallet); got != "whale #3" {
+		t.Errorf("Of after edit = %q, want %q", got, "whale #3")
+	}
+	if got := wallet_labels.Of(teamWallet); got != "" {
+		t.Errorf("Of of removed label = %q", got)
+	}
+
+	if removed, err := wallet_labels.Remove(seenWallet); err != nil || !removed {
+		t.Errorf("Remove = %v, %v", removed, err)
+	}
+	if removed, err := wallet_labels.Remove(seenWallet); err != nil || removed {
+		t.Errorf("second Remove = %v, %v", removed, err)
+	}
+}
+
+func TestWalletLabelName(t *testing.T) {
+	for _, name := range []string{"", "   ", "a label that is much longer than thirty two characters"} {
+		if _, err := wallet_labels.ValidName(name); err == nil {
+			t.Errorf("ValidName(%q) expected error", name)
+		}
+	}
+}
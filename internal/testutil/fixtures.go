@@ -0,0 +1,65 @@
+package testutil
+
+// Canned swaps, pools and BTC price for end-to-end tests
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+	"spark-wallet/internal/clients_api/luminex"
+)
+
+// StaticPriceFeed - BTC price feed with fixed price (btc_price.SetFeed), no CoinGecko requests
+type StaticPriceFeed float64
+
+// Name returns feed name
+func (f StaticPriceFeed) Name() string { return "static" }
+
+// PriceUSD returns fixed BTC price
+func (f StaticPriceFeed) PriceUSD(ctx context.Context) (float64, error) { return float64(f), nil }
+
+// TokenPool returns Luminex pool of token against BTC (BTC is asset B)
+func TokenPool(lpPublicKey, tokenAddress, ticker, name string) luminex.LuminexPoolResponse {
+	return luminex.LuminexPoolResponse{
+		LpPublicKey:   lpPublicKey,
+		AssetAAddress: tokenAddress,
+		AssetBAddress: flashnet.NativeTokenAddress,
+		TokenAMetadata: luminex.LuminexTokenMetadata{
+			Name:            name,
+			Ticker:          ticker,
+			AggMarketcapUsd: 1_250_000,
+			AggPriceUsd:     0.0125,
+			Decimals:        8,
+		},
+		TokenBMetadata: luminex.LuminexTokenMetadata{Name: "Bitcoin", Ticker: "BTC", AggPriceUsd: 100_000, Decimals: 8},
+	}
+}
+
+// BuySwap returns swap of sats for tokens (raw units) in pool of TokenPool
+func BuySwap(id, lpPublicKey, tokenAddress, swapper string, sats int64, tokens string, at time.Time) flashnet.Swap {
+	return flashnet.Swap{
+		ID:                id,
+		AmountIn:          strconv.FormatInt(sats, 10),
+		AmountOut:         tokens,
+		AssetInAddress:    flashnet.NativeTokenAddress,
+		AssetOutAddress:   tokenAddress,
+		CreatedAt:         at.UTC().Format(time.RFC3339),
+		Timestamp:         at.UTC().Format(time.RFC3339),
+		FeePaid:           "100",
+		PoolAssetAAddress: tokenAddress,
+		PoolAssetBAddress: flashnet.NativeTokenAddress,
+		PoolLpPublicKey:   lpPublicKey,
+		PoolType:          "CONSTANT_PRODUCT",
+		SwapperPublicKey:  swapper,
+	}
+}
+
+// SellSwap returns swap of tokens (raw units) for sats in pool of TokenPool
+func SellSwap(id, lpPublicKey, tokenAddress, swapper string, tokens string, sats int64, at time.Time) flashnet.Swap {
+	swap := BuySwap(id, lpPublicKey, tokenAddress, swapper, sats, tokens, at)
+	swap.AmountIn, swap.AmountOut = tokens, strconv.FormatInt(sats, 10)
+	swap.AssetInAddress, swap.AssetOutAddress = tokenAddress, flashnet.NativeTokenAddress
+	return swap
+}
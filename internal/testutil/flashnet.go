@@ -0,0 +1,129 @@
+package testutil
+
+// Mock Flashnet AMM API (httptest) with canned swaps and pools
+// Serves GET /swaps, GET /swaps/user/{publicKey} and GET /pools/{lpPublicKey}
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"spark-wallet/internal/clients_api/flashnet"
+)
+
+// FlashnetServer - mock Flashnet AMM API
+type FlashnetServer struct {
+	URL string
+
+	mutex    sync.Mutex
+	swaps    []flashnet.Swap // newest first, as returned by API
+	pools    map[string]flashnet.Pool
+	requests map[string]int // path -> requests
+}
+
+// NewFlashnetServer starts mock Flashnet API, stopped at end of test
+func NewFlashnetServer(t testing.TB) *FlashnetServer {
+	t.Helper()
+	s := &FlashnetServer{
+		pools:    make(map[string]flashnet.Pool),
+		requests: make(map[string]int),
+	}
+	server := httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(server.Close)
+	s.URL = server.URL
+	return s
+}
+
+// Client returns Flashnet client of mock API (without retries)
+func (s *FlashnetServer) Client() *flashnet.Client {
+	client := flashnet.NewAMMClient("mainnet")
+	client.SetBaseURL(s.URL)
+	client.SetRetry(0, time.Millisecond, time.Millisecond, 1)
+	return client
+}
+
+// AddSwaps adds new swaps (newest first) on top of swap feed
+func (s *FlashnetServer) AddSwaps(swaps ...flashnet.Swap) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.swaps = append(append([]flashnet.Swap(nil), swaps...), s.swaps...)
+}
+
+// AddPool adds pool served by GET /pools/{lpPublicKey}
+func (s *FlashnetServer) AddPool(pool flashnet.Pool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pools[pool.LpPublicKey] = pool
+}
+
+// Requests returns count of requests to path ("/swaps", "/pools/{lpPublicKey}", ...)
+func (s *FlashnetServer) Requests(path string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests[path]
+}
+
+func (s *FlashnetServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests[r.URL.Path]++
+
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/swaps":
+		page := paginate(s.swaps, r)
+		writeJSON(w, http.StatusOK, flashnet.SwapsResponse{Swaps: page, TotalCount: len(s.swaps)})
+	case strings.HasPrefix(r.URL.Path, "/swaps/user/"):
+		user := strings.TrimPrefix(r.URL.Path, "/swaps/user/")
+		pool := r.URL.Query().Get("poolLpPubkey")
+		var swaps []flashnet.Swap
+		for _, swap := range s.swaps {
+			if swap.SwapperPublicKey == user && (pool == "" || swap.PoolLpPublicKey == pool) {
+				swaps = append(swaps, swap)
+			}
+		}
+		if r.URL.Query().Get("sort") == "timestampAsc" {
+			for i, j := 0, len(swaps)-1; i < j; i, j = i+1, j-1 {
+				swaps[i], swaps[j] = swaps[j], swaps[i]
+			}
+		}
+		writeJSON(w, http.StatusOK, flashnet.UserSwapsResponse{Swaps: paginate(swaps, r), TotalCount: len(swaps)})
+	case strings.HasPrefix(r.URL.Path, "/pools/"):
+		pool, exists := s.pools[strings.TrimPrefix(r.URL.Path, "/pools/")]
+		if !exists {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "pool not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, pool)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+	}
+}
+
+// paginate applies limit and offset query parameters
+func paginate(swaps []flashnet.Swap, r *http.Request) []flashnet.Swap {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if offset < 0 || offset > len(swaps) {
+		offset = len(swaps)
+	}
+	page := swaps[offset:]
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit >= 0 && limit < len(page) {
+		page = page[:limit]
+	}
+	return append([]flashnet.Swap{}, page...)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
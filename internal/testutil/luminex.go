@@ -0,0 +1,159 @@
+package testutil
+
+// Mock Luminex API (httptest) with canned pools, wallets and stats
+// Requests of luminex package are sent to the mock with luminex.SetAPIHost while test runs
+// Serves GET /spark/pool/{lp}, /spark/pools/{lp}/stats, /spark/address/{publicKey},
+// /spark-users/profiles?pubkeys=, /spark/stats and /spark/tokens-with-pools (top tokens or paged tokens with pools)
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"spark-wallet/internal/clients_api/luminex"
+)
+
+// LuminexServer - mock Luminex API
+type LuminexServer struct {
+	URL string
+
+	mutex     sync.Mutex
+	pools     map[string]luminex.LuminexPoolResponse
+	poolStats map[string]luminex.PoolStatsResponse
+	wallets   map[string]luminex.WalletBalanceResponse
+	usernames map[string]string
+	stats     luminex.StatsResponse
+	tokens    luminex.TokensResponse
+	listed    []luminex.ListedToken // tokens with pools, served instead of tokens when set
+	requests  map[string]int        // path -> requests
+}
+
+// NewLuminexServer starts mock Luminex API and sends luminex package requests to it until end of test
+// Unknown pools and wallets are answered with 404
+func NewLuminexServer(t testing.TB) *LuminexServer {
+	t.Helper()
+	s := &LuminexServer{
+		pools:     make(map[string]luminex.LuminexPoolResponse),
+		poolStats: make(map[string]luminex.PoolStatsResponse),
+		wallets:   make(map[string]luminex.WalletBalanceResponse),
+		usernames: make(map[string]string),
+		tokens:    luminex.TokensResponse{},
+		requests:  make(map[string]int),
+	}
+	server := httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = server.URL
+
+	luminex.SetAPIHost(server.URL)
+	t.Cleanup(func() {
+		luminex.SetAPIHost("")
+		server.Close()
+	})
+	return s
+}
+
+// AddPool adds pool served by /spark/pool/{lp}
+func (s *LuminexServer) AddPool(pool luminex.LuminexPoolResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pools[pool.LpPublicKey] = pool
+}
+
+// SetPoolStats sets 24h stats of pool served by /spark/pools/{lp}/stats
+func (s *LuminexServer) SetPoolStats(poolLpPublicKey string, stats luminex.PoolStatsResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.poolStats[poolLpPublicKey] = stats
+}
+
+// SetWallet sets balance of wallet served by /spark/address/{publicKey}
+func (s *LuminexServer) SetWallet(publicKey string, balance luminex.WalletBalanceResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	balance.PublicKey = publicKey
+	s.wallets[publicKey] = balance
+}
+
+// SetUsername sets Luminex username of wallet
+func (s *LuminexServer) SetUsername(publicKey string, username string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.usernames[publicKey] = username
+}
+
+// SetStats sets market stats and top tokens
+func (s *LuminexServer) SetStats(stats luminex.StatsResponse, tokens luminex.TokensResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats = stats
+	s.tokens = tokens
+}
+
+// SetListedTokens sets tokens with pools served by /spark/tokens-with-pools page by page (offset, limit)
+func (s *LuminexServer) SetListedTokens(tokens []luminex.ListedToken) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.listed = tokens
+}
+
+// Requests returns count of requests to path ("/spark/pool/{lp}", ...)
+func (s *LuminexServer) Requests(path string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests[path]
+}
+
+func (s *LuminexServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests[r.URL.Path]++
+
+	notFound := func() {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+	}
+
+	switch {
+	case r.URL.Path == "/spark/stats":
+		writeJSON(w, http.StatusOK, s.stats)
+	case r.URL.Path == "/spark/tokens-with-pools" && s.listed != nil:
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page := s.listed[min(offset, len(s.listed)):]
+		writeJSON(w, http.StatusOK, page[:min(limit, len(page))])
+	case r.URL.Path == "/spark/tokens-with-pools":
+		writeJSON(w, http.StatusOK, s.tokens)
+	case r.URL.Path == "/spark-users/profiles":
+		profiles := luminex.UserProfileResponse{Data: []luminex.UserProfile{}}
+		for _, pubkey := range strings.Split(r.URL.Query().Get("pubkeys"), ",") {
+			if username, exists := s.usernames[pubkey]; exists {
+				profiles.Data = append(profiles.Data, luminex.UserProfile{Pubkey: pubkey, Username: username})
+			}
+		}
+		writeJSON(w, http.StatusOK, profiles)
+	case strings.HasPrefix(r.URL.Path, "/spark/pool/"):
+		pool, exists := s.pools[strings.TrimPrefix(r.URL.Path, "/spark/pool/")]
+		if !exists {
+			notFound()
+			return
+		}
+		writeJSON(w, http.StatusOK, pool)
+	case strings.HasPrefix(r.URL.Path, "/spark/pools/") && strings.HasSuffix(r.URL.Path, "/stats"):
+		stats, exists := s.poolStats[strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/spark/pools/"), "/stats")]
+		if !exists {
+			notFound()
+			return
+		}
+		writeJSON(w, http.StatusOK, stats)
+	case strings.HasPrefix(r.URL.Path, "/spark/address/"):
+		wallet, exists := s.wallets[strings.TrimPrefix(r.URL.Path, "/spark/address/")]
+		if !exists {
+			notFound()
+			return
+		}
+		writeJSON(w, http.StatusOK, wallet)
+	default:
+		notFound()
+	}
+}
@@ -0,0 +1,164 @@
+package testutil
+
+// Fake Telegram Bot API for end-to-end tests of monitors
+// Bot is created with HTTP client that answers Bot API requests itself and records send/edit requests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// TelegramMessage - Bot API request recorded by FakeTelegram
+type TelegramMessage struct {
+	Method    string // sendMessage, sendPhoto, editMessageText, ...
+	ChatID    string
+	MessageID int               // sent message ID (send methods) or edited message ID (edit methods)
+	Text      string            // text or caption
+	Params    map[string]string // other request parameters
+}
+
+// FakeTelegram - Telegram bot whose messages are recorded instead of being sent
+type FakeTelegram struct {
+	Bot *tgbotapi.BotAPI
+
+	mutex     sync.Mutex
+	messages  []TelegramMessage
+	messageID int
+}
+
+// NewFakeTelegram creates bot backed by fake Bot API
+func NewFakeTelegram(t testing.TB) *FakeTelegram {
+	t.Helper()
+	fake := &FakeTelegram{}
+	bot, err := tgbotapi.NewBotAPIWithClient("123456:test-token", tgbotapi.APIEndpoint, fake)
+	if err != nil {
+		t.Fatalf("failed to create fake Telegram bot: %v", err)
+	}
+	fake.Bot = bot
+	return fake
+}
+
+// Messages returns recorded send and edit requests (oldest first)
+func (f *FakeTelegram) Messages() []TelegramMessage {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]TelegramMessage(nil), f.messages...)
+}
+
+// Sent returns recorded requests of send methods (edits are left out)
+func (f *FakeTelegram) Sent() []TelegramMessage {
+	var sent []TelegramMessage
+	for _, message := range f.Messages() {
+		if strings.HasPrefix(message.Method, "send") {
+			sent = append(sent, message)
+		}
+	}
+	return sent
+}
+
+// WaitForSent waits until count messages are sent, fails test after timeout
+func (f *FakeTelegram) WaitForSent(t testing.TB, count int, timeout time.Duration) []TelegramMessage {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		sent := f.Sent()
+		if len(sent) >= count {
+			return sent
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d Telegram messages sent after %s, want %d: %+v", len(sent), timeout, count, sent)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Do answers Bot API request: getMe with bot user, send and edit methods with message, others with true
+func (f *FakeTelegram) Do(req *http.Request) (*http.Response, error) {
+	method := path.Base(req.URL.Path)
+	var result interface{} = true
+
+	switch {
+	case method == "getMe":
+		result = map[string]interface{}{"id": 123456, "is_bot": true, "first_name": "Test", "username": "test_bot"}
+	case strings.HasPrefix(method, "send") || strings.HasPrefix(method, "edit"):
+		message, err := f.record(req, method)
+		if err != nil {
+			return nil, err
+		}
+		chatID, _ := strconv.ParseInt(message.ChatID, 10, 64)
+		result = map[string]interface{}{
+			"message_id": message.MessageID,
+			"date":       time.Now().Unix(),
+			"chat":       map[string]interface{}{"id": chatID, "type": "group"},
+			"text":       message.Text,
+		}
+		if method == "sendMediaGroup" {
+			result = []interface{}{result}
+		}
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"ok": true, "result": result})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func (f *FakeTelegram) record(req *http.Request, method string) (TelegramMessage, error) {
+	params := make(map[string]string)
+	if req.Body != nil {
+		mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if mediaType == "multipart/form-data" {
+			if err := req.ParseMultipartForm(32 << 20); err != nil {
+				return TelegramMessage{}, err
+			}
+			for key, values := range req.MultipartForm.Value {
+				params[key] = values[0]
+			}
+		} else {
+			if err := req.ParseForm(); err != nil {
+				return TelegramMessage{}, err
+			}
+			for key := range req.PostForm {
+				params[key] = req.PostForm.Get(key)
+			}
+		}
+	}
+
+	message := TelegramMessage{Method: method, ChatID: params["chat_id"], Text: params["text"]}
+	if message.Text == "" {
+		message.Text = params["caption"]
+	}
+	delete(params, "chat_id")
+	delete(params, "text")
+	delete(params, "caption")
+	message.Params = params
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if strings.HasPrefix(method, "send") {
+		f.messageID++
+		message.MessageID = f.messageID
+	} else {
+		message.MessageID, _ = strconv.Atoi(params["message_id"])
+	}
+	f.messages = append(f.messages, message)
+	return message, nil
+}